@@ -1,38 +1,106 @@
+// Command api-services runs the HTTP API and, when enabled, the internal gRPC server.
+//
+//	api-services [--validate-config] [--print-config]
+//
+// --validate-config loads and validates the configuration and exits, with status 1 when
+// it is invalid. --print-config also prints the effective settings as NAME=value lines
+// with secrets redacted. Neither connects to any dependency.
 package main
 
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// Time zones of users and organizations resolve without the zoneinfo of the host
+	_ "time/tzdata"
 
+	"github.com/samaasi/uptime-application/services/api-services/internal/activity"
+	"github.com/samaasi/uptime-application/services/api-services/internal/analytics"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/router"
+	apiservices "github.com/samaasi/uptime-application/services/api-services/internal/api/services"
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
 	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/grpcserver"
+	"github.com/samaasi/uptime-application/services/api-services/internal/orphans"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/partitions"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/internal/reports"
+	"github.com/samaasi/uptime-application/services/api-services/internal/retention"
 	"github.com/samaasi/uptime-application/services/api-services/internal/seeder"
+	"github.com/samaasi/uptime-application/services/api-services/internal/selfmonitor"
+	"github.com/samaasi/uptime-application/services/api-services/internal/sla"
+	"github.com/samaasi/uptime-application/services/api-services/internal/ticketing"
 	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/internal/warmup"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/lifecycle"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/sms"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/queue"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// Startup steps, in the order they run. /readyz reports not ready until they all finished.
+const (
+	startupStepMigrations  = "migrations"
+	startupStepSeeders     = "seeders"
+	startupStepCacheWarmup = "cache_warmup"
+	startupStepScheduler   = "scheduler"
+)
+
+// seedLockRetryInterval is how often a replica waiting for another one to seed retries the lock
+const seedLockRetryInterval = 2 * time.Second
+
 type ServiceContainer struct {
 	PostgresClient   database.Client
 	ClickHouseClient database.Client
+	CheckResults     *database.BatchWriter[models.CheckResult]
 	CacheService     *cache.Service
 	StorageDriver    storage.Driver
 	EmailService     email.Service
+	SMSService       sms.Service
+	RealtimeHub      *realtime.Hub
+	Analytics        *analytics.Recorder
+	Activity         *activity.Recorder
+	Retention        *retention.Purger
+	DataRetention    *retention.DataPurger
+	Outbox           *outbox.Relay
+	Queue            *queue.Queue
+	Partitions       *partitions.Manager
+	Warmup           *warmup.Warmer
+	OrphanCleaner    *orphans.Cleaner
+	Reports          *reports.Scheduler
+	ReportFiles      *reports.Generator
+	SLA              *sla.Evaluator
+	SelfMonitor      *selfmonitor.Monitor
+	Jobs             *jobs.Scheduler
 }
 
 func main() {
+	validateConfig := flag.Bool("validate-config", false, "load and validate the configuration, then exit")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted, then exit")
+	flag.Parse()
+
+	if *validateConfig || *printConfig {
+		os.Exit(checkConfig(*printConfig))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -45,7 +113,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if appConfig.App.Mode == config.AppModeProduction {
+	if appConfig.App.Mode != config.AppModeDevelopment {
 		gin.SetMode(gin.ReleaseMode)
 	} else {
 		gin.SetMode(gin.DebugMode)
@@ -67,11 +135,65 @@ func main() {
 		logger.String("port", appConfig.App.Port),
 	)
 
-	services, err := initializeServices(appConfig)
+	// The HTTP server listens right away so that the liveness and startup probes answer
+	// while migrations and seeders run; the application routes are served once set up
+	startup := lifecycle.NewStartup(startupStepMigrations, startupStepSeeders, startupStepCacheWarmup, startupStepScheduler)
+	startupHandler := router.NewStartupHandler(startup)
+	srv := &http.Server{
+		Addr:              ":" + appConfig.App.Port,
+		Handler:           startupHandler,
+		ReadTimeout:       appConfig.Server.ReadTimeout,
+		ReadHeaderTimeout: appConfig.Server.ReadHeaderTimeout,
+		WriteTimeout:      appConfig.Server.WriteTimeout,
+		IdleTimeout:       appConfig.Server.IdleTimeout,
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Failed to start HTTP server", logger.ErrorField(err))
+		}
+	}()
+
+	services, err := initializeServices(ctx, appConfig, startup)
 	if err != nil {
 		logger.Fatal("failed to initialize services", logger.ErrorField(err))
 	}
-	go runHealthChecks(ctx, services)
+	services.Jobs, err = newJobScheduler(appConfig.Jobs, services)
+	if err != nil {
+		logger.Fatal("Failed to register background jobs", logger.ErrorField(err))
+	}
+	shutdown := lifecycle.NewManager(appConfig.Server.ShutdownHookTimeout)
+	registerShutdownHooks(shutdown, services)
+
+	// Background loops stop before the queues are drained and the clients closed, the
+	// scheduler first so that no job starts while the rest goes down
+	shutdown.Go(ctx, "job_scheduler", lifecycle.PhaseWorkers, func(ctx context.Context) {
+		startup.Begin(startupStepScheduler)
+		startup.Complete(startupStepScheduler, nil)
+		services.Jobs.Run(ctx)
+	})
+	configReloader := config.NewReloader(appConfig)
+	configReloader.OnChange(applyLoggingConfig)
+	shutdown.Go(ctx, "config_watcher", lifecycle.PhaseWorkers, func(ctx context.Context) { watchConfig(ctx, configReloader) })
+	if services.Outbox != nil {
+		shutdown.Go(ctx, "outbox_relay", lifecycle.PhaseWorkers, services.Outbox.Run)
+	}
+	if services.Queue != nil {
+		shutdown.Go(ctx, "job_queue", lifecycle.PhaseWorkers, services.Queue.Run)
+	}
+	if services.Warmup != nil {
+		shutdown.Go(ctx, "cache_warmup", lifecycle.PhaseWorkers, func(ctx context.Context) {
+			startup.Begin(startupStepCacheWarmup)
+			services.Warmup.Run(ctx)
+			startup.Complete(startupStepCacheWarmup, nil)
+		})
+	} else {
+		startup.Skip(startupStepCacheWarmup)
+	}
+	shutdown.Go(ctx, "realtime_hub", lifecycle.PhaseWorkers, services.RealtimeHub.Run)
+	if services.CacheService != nil {
+		shutdown.Go(ctx, "cache_invalidation", lifecycle.PhaseWorkers, services.CacheService.Run)
+	}
 
 	ginRouter, err := router.SetupRoutes(
 		appConfig,
@@ -80,21 +202,44 @@ func main() {
 		services.CacheService,
 		services.StorageDriver,
 		services.EmailService,
+		services.SMSService,
+		services.RealtimeHub,
+		services.Analytics,
+		services.Jobs,
+		services.SelfMonitor,
+		services.Outbox,
+		services.CheckResults,
+		configReloader,
+		startup,
 	)
 	if err != nil {
 		logger.Fatal("Failed to setup routes", logger.ErrorField(err))
 	}
+	startupHandler.Serve(ginRouter)
+	shutdown.Register(lifecycle.Hook{Name: "http_server", Phase: lifecycle.PhaseServers, Stop: srv.Shutdown})
+
+	var grpcSrv *grpcserver.Server
+	if appConfig.GRPC.Enable {
+		var monitorRepository repositories.MonitorRepository
+		if services.PostgresClient != nil {
+			monitorRepository = newMonitorRepository(appConfig, services)
+		}
 
-	srv := &http.Server{
-		Addr:    ":" + appConfig.App.Port,
-		Handler: ginRouter,
-	}
-
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logger.Fatal("Failed to start HTTP server", logger.ErrorField(err))
+		grpcSrv, err = grpcserver.New(appConfig.GRPC, services.PostgresClient, services.ClickHouseClient, monitorRepository, services.CheckResults, services.RealtimeHub)
+		if err != nil {
+			logger.Fatal("Failed to setup gRPC server", logger.ErrorField(err))
 		}
-	}()
+
+		go func() {
+			if err := grpcSrv.Start(); err != nil {
+				logger.Fatal("Failed to start gRPC server", logger.ErrorField(err))
+			}
+		}()
+		shutdown.Register(lifecycle.Hook{Name: "grpc_server", Phase: lifecycle.PhaseServers, Stop: func(ctx context.Context) error {
+			grpcSrv.Stop(ctx)
+			return nil
+		}})
+	}
 
 	<-sigChan
 	logger.Info("Shutting down application...")
@@ -105,22 +250,29 @@ func main() {
 		logger.Error("Failed to sync logger during shutdown", logger.ErrorField(err))
 	}
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 15*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), appConfig.Server.ShutdownTimeout)
 	defer shutdownCancel()
 
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		logger.Error("HTTP server shutdown failed", logger.ErrorField(err))
-	} else {
-		logger.Info("HTTP server gracefully stopped")
+	if err := shutdown.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Application shutdown completed with errors", logger.ErrorField(err))
 	}
 
-	shutdownServices(shutdownCtx, services)
-
 	logger.Info("Application shutdown complete.")
+	// The logger flushes its sinks with a budget of its own, whatever the hooks left
+	loggerCtx, loggerCancel := context.WithTimeout(context.Background(), appConfig.Server.ShutdownHookTimeout)
+	defer loggerCancel()
+	logger.Shutdown(loggerCtx)
+}
+
+// useCheckResultFallback reports whether check results go to Postgres instead of ClickHouse
+func useCheckResultFallback(appConfig *config.Config) bool {
+	return appConfig.CheckResults.PostgresFallback && !appConfig.ClickHouse.Enable && appConfig.Postgres.Enable
 }
 
-// initializeServices initializes and returns a ServiceContainer
-func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
+// initializeServices initializes and returns a ServiceContainer, recording the migrations
+// and seeders in startup. ctx bounds the startup work, which ends when the application stops.
+
+func initializeServices(ctx context.Context, appConfig *config.Config, startup *lifecycle.Startup) (*ServiceContainer, error) {
 	services := &ServiceContainer{}
 
 	if appConfig.Redis.Enable {
@@ -128,12 +280,30 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize Redis client: %w", err)
 		}
-		services.CacheService = cache.NewCacheService(redisClient)
+		services.CacheService = cache.NewCacheService(redisClient,
+			cache.WithLocalCache(appConfig.Redis.LocalCacheSize, appConfig.Redis.LocalCacheTTL))
 		logger.Info("Redis client and CacheService initialized")
+
+		// Initialize the job queue the outbox relay hands checks and notifications over to
+		if appConfig.Queue.Enable {
+			services.Queue = queue.New(redisClient.Client(), appConfig.Queue.Stream, appConfig.Queue.Group, newQueueOptions(appConfig.Queue))
+			logger.Info("Job queue initialized", logger.String("stream", appConfig.Queue.Stream))
+		}
 	}
 
+	// Secrets of integrations are encrypted at rest with keys that may be unwrapped by a KMS
+	kmsCtx, kmsCancel := context.WithTimeout(ctx, appConfig.Encryption.KMSTimeout)
+	fieldCipher, err := security.NewFieldCipherFromConfig(kmsCtx, appConfig.Encryption, appConfig.App.Keys())
+	kmsCancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize field encryption: %w", err)
+	}
+	database.SetFieldCipher(fieldCipher)
+
+	startup.Begin(startupStepMigrations)
 	if appConfig.Postgres.Enable {
 		postgresOpts := database.DefaultPostgresClientOptions()
+		postgresOpts.QueryTimeout = appConfig.Postgres.QueryTimeout
 		postgresOpts.AutoMigrateModels = []interface{}{
 			&models.User{},
 			&models.OrganizationType{},
@@ -142,6 +312,7 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 			&models.ApplicationType{},
 			&models.Application{},
 			&models.Environment{},
+			&models.Monitor{},
 			// Authorizaton models
 			&models.Role{},
 			&models.Permission{},
@@ -149,6 +320,42 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 			&models.UserRole{},
 			&models.UserPermission{},
 			&models.Policy{},
+			// Email suppression list
+			&models.EmailSuppression{},
+			// Uptime report subscriptions
+			&models.ReportSubscription{},
+			&models.GeneratedReport{},
+			// SLA targets
+			&models.SLATarget{},
+			// Read-only status tokens
+			&models.StatusToken{},
+			// Inbound integrations and the incidents they raise
+			&models.InboundIntegration{},
+			&models.Incident{},
+			&models.MonitorDependency{},
+			// Chat integrations running slash commands
+			&models.ChatIntegration{},
+			// Ticket integrations filing incidents in issue trackers
+			&models.TicketIntegration{},
+			&models.IncidentTicket{},
+			// Probes of the network, known from the results they report
+			&models.Probe{},
+			// Retention
+			&models.PurgeAuditLog{},
+			&models.RetentionPolicy{},
+			// Organization defaults
+			&models.OrganizationSettings{},
+			// Outbox
+			&models.OutboxMessage{},
+			// Seeding
+			&models.SeedHistory{},
+			// Activity feed
+			&models.ActivityEntry{},
+		}
+
+		postgresOpts.SQLObjects = repositories.SearchIndexMigrations()
+		if useCheckResultFallback(appConfig) {
+			postgresOpts.SQLObjects = append(postgresOpts.SQLObjects, repositories.CheckResultPartitionMigrations()...)
 		}
 
 		pgClient, err := database.NewPostgresClient(appConfig.Postgres, postgresOpts)
@@ -158,19 +365,25 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 		services.PostgresClient = pgClient
 		logger.Info("PostgreSQL client initialized")
 
-		// Seed default data including permissions
-		ctx := context.Background()
-		if err := seeder.SeedDefaultData(ctx, pgClient.DB()); err != nil {
-			logger.Warn("Failed to seed default data", logger.ErrorField(err))
+		// Values stored in plain text or with a previous key are rewritten with the current one
+		encrypted, err := database.EncryptFields(ctx, pgClient.DB(), &models.ChatIntegration{}, &models.TicketIntegration{})
+		if err != nil {
+			logger.Warn("Failed to encrypt stored secrets", logger.ErrorField(err))
+		} else if encrypted > 0 {
+			logger.Info("Encrypted stored secrets", logger.Int64("count", encrypted))
 		}
 	}
 
 	// Initialize ClickHouse (GORM-based client)
 	if appConfig.ClickHouse.Enable {
 		chOpts := database.DefaultClickHouseClientOptions()
+		chOpts.QueryTimeout = appConfig.ClickHouse.QueryTimeout
 		chOpts.AutoMigrateModels = []interface{}{
-			//&models.ClickHouseEvent{},
+			&models.CheckResult{},
+			&models.RequestLog{},
+			&models.IncidentRecord{},
 		}
+		chOpts.SQLObjects = repositories.CheckResultRollupMigrations()
 
 		chClient, err := database.NewClickHouseClient(appConfig.ClickHouse, chOpts)
 		if err != nil {
@@ -179,11 +392,62 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 		}
 		services.ClickHouseClient = chClient
 		logger.Info("ClickHouse client initialized")
-		chClient.DebugDbInfo(context.Background())
+
+		services.CheckResults = database.NewBatchWriter[models.CheckResult](
+			chClient.DB(), database.ClickHouseWriterOptions("check_results", appConfig.ClickHouse))
+		services.CheckResults.Start()
+	}
+
+	// Without ClickHouse, store check results in monthly Postgres partitions. The current
+	// partitions are created before the writer starts so the first inserts have a target.
+	if useCheckResultFallback(appConfig) && services.PostgresClient != nil {
+		cfg := appConfig.CheckResults
+		services.Partitions = partitions.NewManager(services.PostgresClient.DB(), repositories.CheckResultsTable,
+			cfg.PartitionsAhead, cfg.Retention, cfg.MaintenanceInterval)
+		if err := services.Partitions.EnsurePartitions(ctx, time.Now()); err != nil {
+			return nil, fmt.Errorf("failed to create check result partitions: %w", err)
+		}
+
+		writerOpts := database.DefaultBatchWriterOptions()
+		writerOpts.Name = repositories.CheckResultsTable
+		services.CheckResults = database.NewBatchWriter[models.CheckResult](services.PostgresClient.DB(), writerOpts)
+		services.CheckResults.Start()
+		logger.Info("Check results stored in PostgreSQL partitions")
+	}
+
+	startup.Complete(startupStepMigrations, nil)
+
+	// Seed default data including permissions; deployments normally run cmd/seed instead
+	if appConfig.Postgres.SeedOnStartup && services.PostgresClient != nil {
+		startup.Begin(startupStepSeeders)
+		pgClient := services.PostgresClient
+		opts := seeder.SeedOptions{
+			Profile: seeder.ProfileForMode(appConfig.App.Mode),
+			Release: appConfig.App.Version,
+		}
+		seed := func(ctx context.Context) error {
+			return seeder.SeedDefaultDataWithOptions(ctx, pgClient.DB(), "", opts)
+		}
+		// Replicas starting together take turns: one finding the lock held waits for it and
+		// seeds next, which the seed history makes a no-op, so no replica reports seeding
+		// complete before the data is there
+		var err error
+		if services.CacheService != nil {
+			err = seedInTurn(ctx, services.CacheService, seed)
+		} else {
+			err = seed(ctx)
+		}
+		if err != nil {
+			logger.Warn("Failed to seed default data", logger.ErrorField(err))
+		}
+		startup.Complete(startupStepSeeders, err)
+	} else {
+		startup.Skip(startupStepSeeders)
 	}
 
 	// Initialize Storage
-	storageDriver, err := storage.NewLocalStorageDriver(appConfig.LocalStorage.Path, appConfig.LocalStorage.BaseURL)
+	storageDriver, err := storage.NewLocalStorageDriver(appConfig.LocalStorage.Path, appConfig.LocalStorage.BaseURL,
+		storage.WithSigner(urlsigner.NewFromConfig(appConfig.URLSigner, appConfig.App.Keys())))
 	if err != nil {
 		logger.Error("Failed to initialize storage driver", logger.ErrorField(err))
 		return nil, fmt.Errorf("failed to initialize storage driver: %w", err)
@@ -191,8 +455,18 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 	services.StorageDriver = storageDriver
 	logger.Info("Storage driver initialized")
 
-	// Initialize Email Service
-	emailService, err := email.NewEmailService(&appConfig.Email)
+	// Initialize the job deleting stored files no record references anymore
+	if appConfig.StorageCleanup.Enable && services.PostgresClient != nil {
+		services.OrphanCleaner = orphans.NewCleaner(services.PostgresClient.DB(), storageDriver, services.CacheService, appConfig.StorageCleanup)
+		logger.Info("Orphaned file cleanup job initialized", logger.Bool("dry_run", appConfig.StorageCleanup.DryRun))
+	}
+
+	// Initialize Email Service, refusing to send to addresses that bounced or complained
+	emailOpts := []email.ServiceOption{email.WithCaptureStorage(storageDriver)}
+	if services.PostgresClient != nil {
+		emailOpts = append(emailOpts, email.WithSuppressionList(repositories.NewEmailSuppressionRepository(services.PostgresClient.DB())))
+	}
+	emailService, err := email.NewEmailService(&appConfig.Email, emailOpts...)
 	if err != nil {
 		logger.Error("Failed to initialize email service", logger.ErrorField(err))
 		return nil, fmt.Errorf("failed to initialize email service: %w", err)
@@ -200,99 +474,416 @@ func initializeServices(appConfig *config.Config) (*ServiceContainer, error) {
 	services.EmailService = emailService
 	logger.Info("Email service initialized")
 
+	// Raise internal incidents when dependencies keep failing their health checks
+	services.SelfMonitor = selfmonitor.New(appConfig.Admin, emailService)
+
+	// Initialize SMS Service
+	smsService, err := sms.NewSMSService(&appConfig.SMS)
+	if err != nil {
+		logger.Error("Failed to initialize SMS service", logger.ErrorField(err))
+		return nil, fmt.Errorf("failed to initialize SMS service: %w", err)
+	}
+	services.SMSService = smsService
+	if smsService != nil {
+		logger.Info("SMS service initialized")
+	}
+
+	// Initialize API request analytics (requires ClickHouse, enforced by config validation)
+	if appConfig.Analytics.Enable && services.ClickHouseClient != nil {
+		services.Analytics = analytics.NewRecorder(services.ClickHouseClient.DB(), appConfig.Analytics)
+		services.Analytics.Start()
+		logger.Info("Analytics recorder initialized")
+	}
+
+	// Initialize the activity feed, stored in Postgres so every replica lists the same entries
+	if services.PostgresClient != nil {
+		services.Activity = activity.NewRecorder(services.PostgresClient.DB())
+		services.Activity.Start()
+		logger.SetAuditObserver(services.Activity.Record)
+		logger.Info("Activity recorder initialized")
+	}
+
+	// Initialize the soft-delete purge job
+	if appConfig.Retention.Enable && services.PostgresClient != nil {
+		services.Retention = retention.NewPurger(services.PostgresClient.DB(), services.CacheService, appConfig.Retention)
+		logger.Info("Retention purge job initialized")
+
+		// Check results live in ClickHouse with their rollups and the incident records, or in
+		// the Postgres partitions
+		var checkResultsDB, clickHouseDB *gorm.DB
+		if services.ClickHouseClient != nil {
+			checkResultsDB = services.ClickHouseClient.DB()
+			clickHouseDB = services.ClickHouseClient.DB()
+		} else if services.Partitions != nil {
+			checkResultsDB = services.PostgresClient.DB()
+		}
+		services.DataRetention = retention.NewDataPurger(services.PostgresClient.DB(), checkResultsDB, clickHouseDB, services.CacheService, appConfig.Retention)
+		logger.Info("Data retention job initialized")
+	}
+
+	// Initialize the outbox relay delivering side effects queued by services
+	if appConfig.Outbox.Enable && services.PostgresClient != nil {
+		services.Outbox = outbox.NewRelay(services.PostgresClient.DB(), appConfig.Outbox)
+		registerDelivery(services, outbox.TopicEmail, outbox.EmailHandler(services.EmailService))
+		if services.SMSService != nil {
+			registerDelivery(services, outbox.TopicSMS, outbox.SMSHandler(services.SMSService))
+		}
+		tickets := ticketing.NewSyncer(services.PostgresClient.DB())
+		services.Outbox.Register(outbox.TopicIncidentOpened, tickets.HandleIncidentOpened())
+		services.Outbox.Register(outbox.TopicIncidentResolved, tickets.HandleIncidentResolved())
+		// Resolved incidents are recorded for the incident analytics when ClickHouse is enabled
+		var incidentRecordsDB *gorm.DB
+		if services.ClickHouseClient != nil {
+			incidentRecordsDB = services.ClickHouseClient.DB()
+		}
+		services.Outbox.Register(outbox.TopicIncidentRecord,
+			analytics.NewIncidentRecorder(services.PostgresClient.DB(), incidentRecordsDB).HandleIncident())
+		logger.Info("Outbox relay initialized")
+	}
+
+	// Initialize the uptime report emails (requires the outbox and ClickHouse, enforced by config validation)
+	if appConfig.Reports.Enable && services.Outbox != nil && services.ClickHouseClient != nil {
+		services.Reports = reports.NewScheduler(services.PostgresClient.DB(), services.ClickHouseClient.DB(),
+			services.CacheService, appConfig.Reports, appConfig.ReportUnsubscribeURL())
+		logger.Info("Uptime report scheduler initialized")
+	}
+
+	// Initialize the report file generation (requires ClickHouse, enforced by config validation)
+	if appConfig.ReportFiles.Enable && services.PostgresClient != nil && services.ClickHouseClient != nil {
+		services.ReportFiles = reports.NewGenerator(services.PostgresClient.DB(), services.ClickHouseClient.DB(),
+			services.StorageDriver, services.CacheService, appConfig.ReportFiles, appConfig.Reports.SLATarget)
+		logger.Info("Report file generator initialized")
+	}
+
+	// Initialize the cache warm-up, run once by a single replica after seeding
+	if appConfig.Redis.WarmOnStartup && appConfig.Redis.RepositoryCacheTTL > 0 &&
+		services.CacheService != nil && services.PostgresClient != nil {
+		services.Warmup = newCacheWarmer(appConfig, services)
+		logger.Info("Cache warm-up initialized")
+	}
+
+	// Initialize realtime hub (fans out across replicas through Redis when enabled)
+	services.RealtimeHub = realtime.NewHub(services.CacheService)
+	logger.Info("Realtime hub initialized")
+
+	// Initialize the monitor checks requested from the API (requires the outbox and a check result store)
+	if services.Outbox != nil && services.CheckResults != nil {
+		registerDelivery(services, outbox.TopicMonitorCheck, newMonitorCheckHandler(appConfig, services))
+		logger.Info("Requested monitor checks initialized")
+	}
+
+	// Initialize the SLA evaluation (requires the outbox and ClickHouse, enforced by config validation)
+	if appConfig.SLA.Enable && services.Outbox != nil && services.ClickHouseClient != nil {
+		services.SLA = sla.NewEvaluator(services.PostgresClient.DB(), services.ClickHouseClient.DB(),
+			services.RealtimeHub, services.CacheService, appConfig.SLA)
+		logger.Info("SLA evaluator initialized")
+	}
+
 	return services, nil
 }
 
-// runHealthChecks periodically checks the health of various services
-func runHealthChecks(ctx context.Context, services *ServiceContainer) {
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+// newQueueOptions returns the options of the job queue, consumed under a name unique to this process
+func newQueueOptions(cfg config.QueueConfig) queue.Options {
+	opts := queue.DefaultOptions()
+	opts.BatchSize = cfg.BatchSize
+	opts.Block = cfg.Block
+	opts.ClaimIdle = cfg.ClaimIdle
+	opts.MaxAttempts = cfg.MaxAttempts
+	opts.HandlerTimeout = cfg.HandlerTimeout
+	opts.MaxLen = cfg.MaxLen
+	return opts
+}
 
-	for {
-		select {
-		case <-ticker.C:
-			if services.PostgresClient != nil {
-				if err := services.PostgresClient.HealthCheck(ctx); err != nil {
-					logger.Error("PostgreSQL health check failed", logger.ErrorField(err))
-				}
-			}
+// registerDelivery sets the handler delivering outbox messages of topic. With the job queue
+// enabled, the relay only hands the messages over to it and its consumers on every replica
+// run handler, so a slow delivery does not hold the relay transaction.
+func registerDelivery(services *ServiceContainer, topic string, handler outbox.Handler) {
+	if services.Queue == nil {
+		services.Outbox.Register(topic, handler)
+		return
+	}
+	services.Queue.Register(topic, queue.Handler(handler))
+	services.Outbox.Register(topic, outbox.QueueHandler(services.Queue, topic))
+}
 
-			if services.ClickHouseClient != nil {
-				if err := services.ClickHouseClient.HealthCheck(ctx); err != nil {
-					logger.Error("ClickHouse health check failed", logger.ErrorField(err))
-				}
-			}
+// newMonitorRepository returns the monitor repository, cached when the repository cache is enabled
+func newMonitorRepository(appConfig *config.Config, services *ServiceContainer) repositories.MonitorRepository {
+	monitorRepository := repositories.NewMonitorRepository(services.PostgresClient.DB())
+	if services.CacheService != nil && appConfig.Redis.RepositoryCacheTTL > 0 {
+		monitorRepository = repositories.NewCachedMonitorRepository(monitorRepository, services.CacheService, appConfig.Redis.RepositoryCacheTTL)
+	}
+	return monitorRepository
+}
 
-			if services.CacheService != nil {
-				if err := services.CacheService.HealthCheck(ctx); err != nil {
-					logger.Error("Redis (CacheService) health check failed", logger.ErrorField(err))
-				}
-			}
+// newMonitorCheckHandler runs the monitor checks requested from the API and ingests their
+// results like the ones of probes, tracking incidents and notifying dashboards
+func newMonitorCheckHandler(appConfig *config.Config, services *ServiceContainer) outbox.Handler {
+	db := services.PostgresClient.DB()
+	monitorRepository := newMonitorRepository(appConfig, services)
+	probeService := apiservices.NewProbeService(repositories.NewProbeRepository(db))
+	incidentService := apiservices.NewIncidentService(
+		repositories.NewIncidentRepository(db),
+		repositories.NewMonitorDependencyRepository(db),
+		monitorRepository,
+		repositories.NewOrganizationRepository(db),
+		probeService,
+		services.RealtimeHub,
+		outbox.NewPublisher(db),
+	)
+	checkResultService := apiservices.NewCheckResultService(monitorRepository,
+		repositories.NewCheckResultRepository(services.CheckResults, nil), incidentService, probeService, services.RealtimeHub)
+	return apiservices.NewMonitorCheckService(monitorRepository, database.NewTransactor(db), outbox.NewPublisher(db), checkResultService).HandleCheck()
+}
 
-			if services.StorageDriver != nil {
-				// Add storage health check if applicable
-				if err := checkStorageHealth(services.StorageDriver); err != nil {
-					logger.Error("Storage health check failed", logger.ErrorField(err))
-				}
-			}
+// newCacheWarmer registers the hot data preloaded into the repository cache on startup
+func newCacheWarmer(appConfig *config.Config, services *ServiceContainer) *warmup.Warmer {
+	ttl := appConfig.Redis.RepositoryCacheTTL
+	db := services.PostgresClient.DB()
+	warmer := warmup.NewWarmer(services.CacheService, ttl)
+
+	warmer.Register("permissions", func(ctx context.Context) (int, error) {
+		permissions := repositories.NewCachedPermissionRepository(repositories.NewPermissionRepository(db), services.CacheService, ttl)
+		catalogue, err := permissions.ListAll(ctx)
+		return len(catalogue), err
+	})
+	warmer.Register("monitors", func(ctx context.Context) (int, error) {
+		return repositories.PrimeMonitorCache(ctx, repositories.NewMonitorRepository(db), services.CacheService, ttl, appConfig.Redis.WarmMaxMonitors)
+	})
+	return warmer
+}
 
-			if services.EmailService != nil {
-				if err := services.EmailService.HealthCheck(ctx); err != nil {
-					logger.Error("Email service health check failed", logger.ErrorField(err))
-				}
+// healthCheckInterval is how often the dependencies are checked
+const healthCheckInterval = 30 * time.Second
+
+// newJobScheduler registers the background jobs of services, replacing their schedules
+// with the ones set in cfg
+func newJobScheduler(cfg config.JobsConfig, services *ServiceContainer) (*jobs.Scheduler, error) {
+	registered := []jobs.Job{{
+		Name:     "health_checks",
+		Schedule: jobs.Every(healthCheckInterval),
+		Timeout:  healthCheckInterval,
+		Run: func(ctx context.Context) error {
+			return checkHealth(ctx, services)
+		},
+	}}
+	if services.Retention != nil {
+		registered = append(registered, services.Retention.Job())
+	}
+	if services.DataRetention != nil {
+		registered = append(registered, services.DataRetention.Job())
+	}
+	if services.Partitions != nil {
+		registered = append(registered, services.Partitions.Job())
+	}
+	if services.OrphanCleaner != nil {
+		registered = append(registered, services.OrphanCleaner.Job())
+	}
+	if services.Reports != nil {
+		registered = append(registered, services.Reports.Job())
+	}
+	if services.ReportFiles != nil {
+		registered = append(registered, services.ReportFiles.Job())
+	}
+	if services.SLA != nil {
+		registered = append(registered, services.SLA.Job())
+	}
+
+	scheduler := jobs.NewScheduler(cfg.Workers, cfg.Timeout)
+	names := make(map[string]bool, len(registered))
+	for _, job := range registered {
+		if spec, ok := cfg.Schedules[job.Name]; ok {
+			schedule, err := jobs.ParseSchedule(spec)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schedule for job %s: %w", job.Name, err)
 			}
+			job.Schedule = schedule
+		}
+		if err := scheduler.Add(job); err != nil {
+			return nil, err
+		}
+		names[job.Name] = true
+	}
+	for name := range cfg.Schedules {
+		if !names[name] {
+			logger.Warn("Ignoring the schedule of a job that is not running", logger.String("job", name))
+		}
+	}
+	return scheduler, nil
+}
 
-		case <-ctx.Done():
-			logger.Info("Health checks stopped as context was cancelled")
-			return
+// checkHealth checks every configured dependency, returning the failures together. Each
+// result is reported to the self monitor, which raises an incident for dependencies that
+// keep failing.
+func checkHealth(ctx context.Context, services *ServiceContainer) error {
+	type dependency struct {
+		name  string
+		check func(context.Context) error
+	}
+	var dependencies []dependency
+	if services.PostgresClient != nil {
+		dependencies = append(dependencies, dependency{"postgres", services.PostgresClient.HealthCheck})
+	}
+	if services.ClickHouseClient != nil {
+		dependencies = append(dependencies, dependency{"clickhouse", services.ClickHouseClient.HealthCheck})
+	}
+	if services.CacheService != nil {
+		dependencies = append(dependencies, dependency{"redis", services.CacheService.HealthCheck})
+	}
+	if services.StorageDriver != nil {
+		dependencies = append(dependencies, dependency{"storage", services.StorageDriver.HealthCheck})
+	}
+	if services.EmailService != nil {
+		dependencies = append(dependencies, dependency{"email", services.EmailService.HealthCheck})
+	}
+	if services.SMSService != nil {
+		dependencies = append(dependencies, dependency{"sms", services.SMSService.HealthCheck})
+	}
+
+	var errs []error
+	for _, dep := range dependencies {
+		err := dep.check(ctx)
+		if services.SelfMonitor != nil {
+			services.SelfMonitor.Observe(ctx, dep.name, err)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s health check failed: %w", dep.name, err))
 		}
 	}
+	return errors.Join(errs...)
 }
 
-// checkStorageHealth is a placeholder, implement based on storage needs
-func checkStorageHealth(driver storage.Driver) error {
-	// For local storage, check if base path is accessible
-	// Return nil if healthy, error otherwise
-	return nil
+// checkConfig loads and validates the configuration without starting the application,
+// printing the effective settings when printSettings is set, and returns the exit status
+func checkConfig(printSettings bool) int {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %v\n", err)
+		return 1
+	}
+
+	if !printSettings {
+		fmt.Println("configuration is valid")
+		return 0
+	}
+	for _, setting := range appConfig.Settings() {
+		fmt.Printf("%s=%s\n", setting.Name, setting.Value)
+	}
+	return 0
 }
 
-// shutdownServices gracefully shuts down all services
-func shutdownServices(ctx context.Context, services *ServiceContainer) {
-	_, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+// watchConfig reloads the configuration on every SIGHUP and, when APP_CONFIG_RELOAD_INTERVAL
+// is set, whenever the .env files change
+func watchConfig(ctx context.Context, reloader *config.Reloader) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	var poll <-chan time.Time
+	if interval := reloader.Current().App.ConfigReloadInterval; interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
 
-	if services.PostgresClient != nil {
-		if err := services.PostgresClient.Close(); err != nil {
-			logger.Error("failed to close PostgreSQL client", logger.ErrorField(err))
-		} else {
-			logger.Info("PostgreSQL client closed successfully")
+	for {
+		select {
+		case <-hangups:
+			reloadConfig(reloader, "sighup")
+		case <-poll:
+			if reloader.Modified() {
+				reloadConfig(reloader, "file")
+			}
+		case <-ctx.Done():
+			return
 		}
 	}
+}
 
-	if services.ClickHouseClient != nil {
-		if err := services.ClickHouseClient.Close(); err != nil {
-			logger.Error("failed to close ClickHouse client", logger.ErrorField(err))
-		} else {
-			logger.Info("ClickHouse client closed successfully")
-		}
+// reloadConfig reloads the configuration and records the settings it changed
+func reloadConfig(reloader *config.Reloader, source string) {
+	result, err := reloader.Reload()
+	if err != nil {
+		logger.Error("Failed to reload configuration, keeping the current one", logger.String("source", source), logger.ErrorField(err))
+		return
+	}
+	if result.RestartRequired {
+		logger.Warn("Configuration changed in settings that only apply after a restart", logger.String("source", source))
 	}
+	if len(result.Changed) == 0 {
+		logger.Info("Configuration reloaded without changes", logger.String("source", source))
+		return
+	}
+	logger.Audit(logger.AuditConfigReloaded, logger.Strings("changed", result.Changed), logger.String("source", source))
+}
 
-	if services.CacheService != nil { // Close the CacheService
-		if err := services.CacheService.Close(); err != nil {
-			logger.Error("failed to close Redis (CacheService) client", logger.ErrorField(err))
-		} else {
-			logger.Info("Redis (CacheService) client closed successfully")
+// applyLoggingConfig applies reloaded logging settings to the global logger
+func applyLoggingConfig(previous, current *config.Config) {
+	if previous.Logging.Level != current.Logging.Level {
+		if _, err := logger.SetLevel(current.Logging.Level, 0); err != nil {
+			logger.Error("Failed to apply reloaded log level", logger.String("level", current.Logging.Level), logger.ErrorField(err))
 		}
 	}
+	if previous.Logging.RateLimitInterval != current.Logging.RateLimitInterval || previous.Logging.RateLimitBurst != current.Logging.RateLimitBurst {
+		logger.SetRateLimit(current.Logging.RateLimitInterval, current.Logging.RateLimitBurst)
+	}
+}
 
-	// Add shutdown for storage and email if they have close methods
-	if services.StorageDriver != nil {
-		// If storage has a Close method, call it
+// registerShutdownHooks registers the hooks draining the buffered writers and closing the
+// clients of the services
+func registerShutdownHooks(shutdown *lifecycle.Manager, services *ServiceContainer) {
+	if services.Analytics != nil {
+		shutdown.Register(lifecycle.Hook{Name: "analytics_recorder", Phase: lifecycle.PhaseDrain, Stop: func(ctx context.Context) error {
+			services.Analytics.Close(ctx)
+			return nil
+		}})
+	}
+	if services.Activity != nil {
+		shutdown.Register(lifecycle.Hook{Name: "activity_recorder", Phase: lifecycle.PhaseDrain, Stop: func(ctx context.Context) error {
+			logger.SetAuditObserver(nil)
+			services.Activity.Close(ctx)
+			return nil
+		}})
+	}
+	if services.CheckResults != nil {
+		shutdown.Register(lifecycle.Hook{Name: "check_result_writer", Phase: lifecycle.PhaseDrain, Stop: services.CheckResults.Close})
 	}
 
-	if services.EmailService != nil {
-		// If email has a Close method, call it
+	closers := []struct {
+		name   string
+		enable bool
+		close  func() error
+	}{
+		{"postgres", services.PostgresClient != nil, func() error { return services.PostgresClient.Close() }},
+		{"clickhouse", services.ClickHouseClient != nil, func() error { return services.ClickHouseClient.Close() }},
+		{"redis", services.CacheService != nil, func() error { return services.CacheService.Close() }},
+		{"storage", services.StorageDriver != nil, func() error { return services.StorageDriver.Close() }},
+	}
+	for _, closer := range closers {
+		if closer.enable {
+			shutdown.Register(lifecycle.Hook{Name: closer.name, Phase: lifecycle.PhaseClients, Stop: func(context.Context) error { return closer.close() }})
+		}
 	}
+}
 
-	// Add other service shutdowns here
+// seedInTurn runs seed while holding the seed lock, waiting for the lock while another
+// instance holds it
+func seedInTurn(ctx context.Context, cacheService *cache.Service, seed func(ctx context.Context) error) error {
+	waiting := false
+	for {
+		err := cacheService.WithLock(ctx, "seed", time.Minute, func(ctx context.Context, _ *cache.Lease) error {
+			return seed(ctx)
+		})
+		if !errors.Is(err, cache.ErrLockHeld) {
+			return err
+		}
+		if !waiting {
+			logger.Info("Waiting for another instance to finish seeding default data")
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(seedLockRetryInterval):
+		}
+	}
 }
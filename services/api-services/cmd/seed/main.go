@@ -0,0 +1,121 @@
+// Command seed writes the default data (permissions, default roles, organization and
+// application types, and demo data depending on the profile) to PostgreSQL. Run it after the API has migrated the schema:
+//
+//	seed [--profile minimal|full|demo] [--only permissions,organization_types] [--dry-run] [--force] [--check] [--config path/to/seed_config.yaml]
+//
+// The profile defaults to the one of APP_ENV: minimal in production, full in staging
+// and demo, which adds sample organizations and monitors, in development.
+//
+// Runs are recorded in the seed_histories table; seeders whose configuration has not
+// changed since their last run are skipped unless --force is given. --check reports the
+// drift between the configuration and the last runs without seeding, exiting with
+// status 2 when anything changed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/seeder"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+func main() {
+	profile := flag.String("profile", "", "seed profile: minimal, full or demo (default from APP_ENV)")
+	only := flag.String("only", "", "comma-separated seeders to run with their dependencies, ignoring the profile")
+	dryRun := flag.Bool("dry-run", false, "run the seeders in a transaction that is rolled back")
+	force := flag.Bool("force", false, "run seeders even when unchanged since their last run")
+	check := flag.Bool("check", false, "report configuration drift since the last runs without seeding")
+	configPath := flag.String("config", "", "path to the seed config YAML (default embedded config)")
+	flag.Parse()
+
+	opts := seeder.SeedOptions{Profile: *profile, DryRun: *dryRun, Force: *force}
+	for _, name := range strings.Split(*only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			opts.Only = append(opts.Only, name)
+		}
+	}
+
+	drifted, err := run(opts, *check, *configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		os.Exit(1)
+	}
+	if drifted {
+		os.Exit(2)
+	}
+}
+
+// run seeds the data selected by opts, or only reports drift when check is set, in which
+// case it returns whether any seeder changed since its last run
+func run(opts seeder.SeedOptions, check bool, configPath string) (bool, error) {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return false, err
+	}
+	if !appConfig.Postgres.Enable {
+		return false, fmt.Errorf("POSTGRES_ENABLE must be true to seed")
+	}
+
+	if err := logger.InitFromConfig(appConfig.Logging); err != nil {
+		return false, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer func() { _ = logger.Sync() }()
+
+	// An explicit config must load; only the implicit default falls back to the embedded one
+	if configPath != "" {
+		if _, err := os.Stat(configPath); err != nil {
+			return false, fmt.Errorf("seed config not found: %w", err)
+		}
+		if _, err := seeder.LoadSeedConfig(configPath); err != nil {
+			return false, err
+		}
+	}
+
+	opts.Release = appConfig.App.Version
+	if opts.Profile == "" {
+		opts.Profile = seeder.ProfileForMode(appConfig.App.Mode)
+	}
+
+	// The history table is migrated here too, so seeding does not depend on the API having started
+	postgresOpts := database.DefaultPostgresClientOptions()
+	postgresOpts.AutoMigrateModels = []interface{}{&models.SeedHistory{}}
+	postgresOpts.QueryTimeout = appConfig.Postgres.QueryTimeout
+
+	client, err := database.NewPostgresClient(appConfig.Postgres, postgresOpts)
+	if err != nil {
+		return false, fmt.Errorf("failed to initialize PostgreSQL client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if !check {
+		return false, seeder.SeedDefaultDataWithOptions(ctx, client.DB(), configPath, opts)
+	}
+
+	manager, err := seeder.NewDefaultSeedManager(client.DB(), configPath)
+	if err != nil {
+		return false, err
+	}
+	drifts, err := manager.Drift(ctx, opts)
+	if err != nil {
+		return false, err
+	}
+
+	drifted := false
+	for _, drift := range drifts {
+		fmt.Println(drift.String())
+		drifted = drifted || drift.HasChanges()
+	}
+	return drifted, nil
+}
@@ -0,0 +1,96 @@
+package activity
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// hiddenFields are the fields of audit events left out of the details of activity entries:
+// the organization, which every entry of a feed shares, the member who acted, who is
+// stored as the actor, and the address they acted from
+var hiddenFields = []string{"organization_id", "client_ip", "user_id"}
+
+// Recorder stores the audit events recorded for an organization as entries of its activity
+// feed. Entries are buffered in memory and written to Postgres in batches, so recording
+// never adds a database round trip to the request that caused the event.
+type Recorder struct {
+	writer  *database.BatchWriter[models.ActivityEntry]
+	dropped atomic.Int64
+}
+
+// NewRecorder creates a recorder writing to db. Call Start before recording and Close on shutdown.
+func NewRecorder(db *gorm.DB) *Recorder {
+	opts := database.DefaultBatchWriterOptions()
+	opts.Name = "activity_entries"
+
+	return &Recorder{
+		writer: database.NewBatchWriter[models.ActivityEntry](db, opts),
+	}
+}
+
+// Start launches the background flusher.
+func (r *Recorder) Start() {
+	r.writer.Start()
+}
+
+// Record enqueues the activity entry of an audit event without blocking, ignoring events
+// recorded without an organization. When the buffer is full the entry is dropped and
+// counted; the event is still in the audit log.
+func (r *Recorder) Record(event logger.AuditEntry) {
+	entry, ok := newEntry(event)
+	if !ok {
+		return
+	}
+	if r.writer.TryWrite(entry) {
+		return
+	}
+	if dropped := r.dropped.Add(1); dropped%1000 == 1 {
+		logger.Warn("Activity buffer full, dropping activity entries", logger.Int64("dropped_total", dropped))
+	}
+}
+
+// Close stops the flusher after writing any buffered entries, or when ctx expires.
+func (r *Recorder) Close(ctx context.Context) {
+	if err := r.writer.Close(ctx); err != nil {
+		logger.Warn("Activity recorder did not flush before shutdown deadline", logger.ErrorField(err))
+	}
+}
+
+// newEntry returns the activity entry of an audit event, reporting false when the event
+// was not recorded with the ID of an organization
+func newEntry(event logger.AuditEntry) (models.ActivityEntry, bool) {
+	value, _ := event.Fields["organization_id"].(string)
+	organizationID, err := uuid.Parse(value)
+	if err != nil {
+		return models.ActivityEntry{}, false
+	}
+
+	entry := models.ActivityEntry{
+		OrganizationID: organizationID,
+		Event:          string(event.Event),
+		OccurredAt:     event.Time,
+	}
+	entry.Category, _, _ = strings.Cut(entry.Event, ".")
+	if value, _ := event.Fields["user_id"].(string); value != "" {
+		if actorID, err := uuid.Parse(value); err == nil {
+			entry.ActorID = &actorID
+		}
+	}
+
+	entry.Details = make(map[string]any, len(event.Fields))
+	for key, value := range event.Fields {
+		entry.Details[key] = value
+	}
+	for _, key := range hiddenFields {
+		delete(entry.Details, key)
+	}
+	return entry, true
+}
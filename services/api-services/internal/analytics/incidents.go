@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+
+	"gorm.io/gorm"
+)
+
+// IncidentRecorder records resolved incidents in ClickHouse for the incident analytics.
+// Incidents are resolved and tagged far less often than requests are made, so records are
+// inserted one at a time from the outbox rather than buffered, which lets failed inserts be
+// retried. Records replace the earlier ones of their incident, so redeliveries are harmless.
+type IncidentRecorder struct {
+	incidents repositories.IncidentRepository
+	db        *gorm.DB
+}
+
+// NewIncidentRecorder creates a recorder reading incidents from db and writing their records
+// to clickhouse, which may be nil when ClickHouse is disabled; incidents are then not recorded.
+func NewIncidentRecorder(db, clickhouse *gorm.DB) *IncidentRecorder {
+	return &IncidentRecorder{
+		incidents: repositories.NewIncidentRepository(db),
+		db:        clickhouse,
+	}
+}
+
+// HandleIncident returns the handler of outbox.TopicIncidentRecord messages. Incidents that
+// were deleted, are open again or are suppressed under a parent incident are not recorded.
+func (r *IncidentRecorder) HandleIncident() outbox.Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		if r.db == nil {
+			return nil
+		}
+
+		var message outbox.IncidentMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return fmt.Errorf("invalid incident payload: %w", err)
+		}
+		incident, err := r.incidents.GetByID(ctx, message.IncidentID)
+		if err != nil {
+			if errors.Is(err, common.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if incident.Status != models.IncidentStatusResolved || incident.ResolvedAt == nil || incident.Suppressed {
+			return nil
+		}
+
+		record := models.NewIncidentRecord(incident, time.Now().UTC())
+		if err := r.db.WithContext(ctx).Table(repositories.IncidentRecordsTable).Create(&record).Error; err != nil {
+			return fmt.Errorf("failed to record incident: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package analytics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// Recorder buffers request logs in memory and writes them to ClickHouse in batches,
+// so recording never adds a database round trip to the request path.
+type Recorder struct {
+	writer  *database.BatchWriter[models.RequestLog]
+	dropped atomic.Int64
+}
+
+// NewRecorder creates a recorder writing to db. Call Start before recording and Close on shutdown.
+func NewRecorder(db *gorm.DB, cfg config.AnalyticsConfig) *Recorder {
+	opts := database.DefaultBatchWriterOptions()
+	opts.Name = "request_logs"
+	opts.BatchSize = cfg.BatchSize
+	opts.FlushInterval = cfg.FlushInterval
+	opts.BufferSize = cfg.BufferSize
+
+	return &Recorder{
+		writer: database.NewBatchWriter[models.RequestLog](db, opts),
+	}
+}
+
+// Start launches the background flusher.
+func (r *Recorder) Start() {
+	r.writer.Start()
+}
+
+// Record enqueues an entry without blocking. When the buffer is full the entry is dropped
+// and counted, since analytics must never slow down or fail API requests.
+func (r *Recorder) Record(entry models.RequestLog) {
+	if r.writer.TryWrite(entry) {
+		return
+	}
+	if dropped := r.dropped.Add(1); dropped%1000 == 1 {
+		logger.Warn("Analytics buffer full, dropping request logs", logger.Int64("dropped_total", dropped))
+	}
+}
+
+// Dropped returns the number of entries discarded because the buffer was full.
+func (r *Recorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Close stops the flusher after writing any buffered entries, or when ctx expires.
+func (r *Recorder) Close(ctx context.Context) {
+	if err := r.writer.Close(ctx); err != nil {
+		logger.Warn("Analytics recorder did not flush before shutdown deadline", logger.ErrorField(err))
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ActivityController handles the activity feeds of organizations
+type ActivityController struct {
+	activityService *services.ActivityService
+}
+
+// NewActivityController creates a new activity controller instance
+func NewActivityController(activityService *services.ActivityService) *ActivityController {
+	return &ActivityController{activityService: activityService}
+}
+
+// List handles GET /organizations/:organizationId/activity - The recent incidents, monitor
+// changes and configuration edits of the organization, newest first. category narrows the
+// feed to incident, monitor or config entries; the next page is requested with the cursor
+// returned in meta.cursor.next_cursor.
+func (ac *ActivityController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	page := utils.GetCursorParams(c, utils.DefaultCursorLimit, utils.MaxCursorLimit)
+
+	activity, pagination, err := ac.activityService.ListOrganizationActivity(c.Request.Context(), organizationID, c.Query("category"), page)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidActivityCategory):
+			utils.SendBadRequest(c, "Invalid activity category")
+		case errors.Is(err, utils.ErrInvalidCursor):
+			utils.SendBadRequest(c, "Invalid cursor")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to list organization activity", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	resp, err := utils.NewResponse[[]dtos.ActivityDto](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(activity).
+		WithMessage("Activity retrieved successfully").
+		WithCursor(pagination).
+		Send()
+}
@@ -0,0 +1,381 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/selfmonitor"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// emailSuppressionQueryOptions whitelists the suppression list filters, sorts and search columns
+var emailSuppressionQueryOptions = utils.QueryOptions{
+	Filters: map[string]string{
+		"reason":   "reason",
+		"provider": "provider",
+	},
+	Sorts: map[string]string{
+		"email":       "email",
+		"created_at":  "created_at",
+		"occurred_at": "occurred_at",
+	},
+	SearchFields: []string{"email"},
+	DefaultSort:  "-created_at",
+}
+
+// outboxMessageQueryOptions whitelists the outbox message filters and sorts
+var outboxMessageQueryOptions = utils.QueryOptions{
+	Filters: map[string]string{
+		"status": "status",
+		"topic":  "topic",
+	},
+	Sorts: map[string]string{
+		"created_at":   "created_at",
+		"available_at": "available_at",
+		"processed_at": "processed_at",
+		"attempts":     "attempts",
+	},
+	DefaultSort: "-created_at",
+}
+
+// AdminController serves operator endpoints
+type AdminController struct {
+	cacheService       *cache.Service
+	suppressionService *services.EmailSuppressionService
+	outboxService      *services.OutboxService
+	jobScheduler       *jobs.Scheduler
+	selfMonitor        *selfmonitor.Monitor
+}
+
+// NewAdminController creates a new admin controller instance. cacheService may be nil
+// when Redis is disabled, and jobScheduler and selfMonitor when background jobs do not run.
+func NewAdminController(
+	cacheService *cache.Service,
+	suppressionService *services.EmailSuppressionService,
+	outboxService *services.OutboxService,
+	jobScheduler *jobs.Scheduler,
+	selfMonitor *selfmonitor.Monitor,
+) *AdminController {
+	return &AdminController{
+		cacheService:       cacheService,
+		suppressionService: suppressionService,
+		outboxService:      outboxService,
+		jobScheduler:       jobScheduler,
+		selfMonitor:        selfMonitor,
+	}
+}
+
+// GetCacheMetrics handles GET /admin/cache/metrics - Operation counters of the cache
+// layers and the state of the Redis circuit breaker.
+func (ac *AdminController) GetCacheMetrics(c *gin.Context) {
+	if ac.cacheService == nil {
+		utils.SendError(c, http.StatusServiceUnavailable, "CACHE_DISABLED", "Cache is disabled")
+		return
+	}
+	utils.SendSuccess(c, ac.cacheService.Stats(), "Cache metrics retrieved")
+}
+
+// ListEmailSuppressions handles GET /admin/email/suppressions - List suppressed addresses
+// with filtering by reason or provider, sorting and search by address.
+func (ac *AdminController) ListEmailSuppressions(c *gin.Context) {
+	query, err := utils.GetQueryParams(c, emailSuppressionQueryOptions)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+	page := utils.GetPaginationParams(c, utils.DefaultPerPage, utils.MaxPerPage)
+
+	suppressions, total, err := ac.suppressionService.List(c.Request.Context(), query, page)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list email suppressions", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	resp, err := utils.NewResponse[[]models.EmailSuppression](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(suppressions).
+		WithMessage("Email suppressions retrieved successfully").
+		WithPagination(utils.NewPaginationMeta(page, total)).
+		Send()
+}
+
+// GetEmailSuppression handles GET /admin/email/suppressions/:email - Why an address is suppressed
+func (ac *AdminController) GetEmailSuppression(c *gin.Context) {
+	address := strings.TrimSpace(c.Param("email"))
+	if address == "" {
+		utils.SendBadRequest(c, "Missing email address")
+		return
+	}
+
+	suppression, err := ac.suppressionService.Get(c.Request.Context(), address)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Email address is not suppressed")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get email suppression", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, suppression, "Email suppression retrieved successfully")
+}
+
+// DeleteEmailSuppression handles DELETE /admin/email/suppressions/:email - Let an address
+// receive email again
+func (ac *AdminController) DeleteEmailSuppression(c *gin.Context) {
+	address := strings.TrimSpace(c.Param("email"))
+	if address == "" {
+		utils.SendBadRequest(c, "Missing email address")
+		return
+	}
+
+	if err := ac.suppressionService.Remove(c.Request.Context(), address); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Email address is not suppressed")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to remove email suppression", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditEmailSuppressionRemoved,
+		logger.Email("email", address),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendNoContent(c, "Email suppression removed successfully")
+}
+
+// GetLogLevel handles GET /admin/log/level - The current log level and when a temporary
+// level expires
+func (ac *AdminController) GetLogLevel(c *gin.Context) {
+	utils.SendSuccess(c, logger.GetLevel(), "Log level retrieved")
+}
+
+// SetLogLevel handles PUT /admin/log/level - Change the log level without a restart,
+// optionally only for a duration
+func (ac *AdminController) SetLogLevel(c *gin.Context) {
+	var req dtos.SetLogLevelRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	var ttl time.Duration
+	if req.Duration != "" {
+		parsed, err := time.ParseDuration(req.Duration)
+		if err != nil || parsed <= 0 {
+			utils.SendBadRequest(c, "Duration must be a positive duration such as 15m")
+			return
+		}
+		ttl = parsed
+	}
+
+	status, err := logger.SetLevel(req.Level, ttl)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+	logger.Audit(logger.AuditLogLevelChanged,
+		logger.String("level", status.Level),
+		logger.Duration("ttl", ttl),
+		logger.String("source", "admin_api"),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, status, "Log level updated")
+}
+
+// ExportAuditLog handles GET /admin/audit-log/export - A zip archive of every retained audit
+// entry with a manifest of their hashes, which logger.VerifyAuditArchive checks
+func (ac *AdminController) ExportAuditLog(c *gin.Context) {
+	// The archive is built in a temporary file so that failures can still be reported
+	file, err := os.CreateTemp("", "audit-export-*.zip")
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to create audit log archive", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	manifest, err := logger.ExportAuditLog(file)
+	if err != nil {
+		if errors.Is(err, logger.ErrAuditLogUnavailable) {
+			utils.SendError(c, http.StatusServiceUnavailable, "AUDIT_LOG_UNAVAILABLE", "Audit log is not written to a file")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to export audit log", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to read audit log archive", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	logger.Audit(logger.AuditLogExported,
+		logger.Int("entries", manifest.Entries),
+		logger.Int64("last_seq", manifest.LastSeq),
+		logger.Bool("verified", manifest.Verified),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	c.Header("Cache-Control", "no-store")
+	name := "audit-log-" + manifest.GeneratedAt.Format("20060102T150405Z") + ".zip"
+	utils.SendFile(c, name, utils.DispositionAttachment, manifest.GeneratedAt, file)
+}
+
+// ListJobs handles GET /admin/jobs - Run counters, failures and next run of the background jobs
+func (ac *AdminController) ListJobs(c *gin.Context) {
+	if ac.jobScheduler == nil {
+		utils.SendError(c, http.StatusServiceUnavailable, "JOBS_DISABLED", "Background jobs are not running")
+		return
+	}
+	utils.SendSuccess(c, ac.jobScheduler.Stats(), "Jobs retrieved")
+}
+
+// ListIncidents handles GET /admin/incidents - Internal incidents raised on this replica for
+// dependencies that kept failing their health checks, open ones first
+func (ac *AdminController) ListIncidents(c *gin.Context) {
+	if ac.selfMonitor == nil {
+		utils.SendError(c, http.StatusServiceUnavailable, "JOBS_DISABLED", "Background jobs are not running")
+		return
+	}
+	utils.SendSuccess(c, ac.selfMonitor.Incidents(), "Incidents retrieved")
+}
+
+// ListOutboxMessages handles GET /admin/outbox/messages - List queued, failed and sent
+// outbox messages with filtering by status or topic and sorting
+func (ac *AdminController) ListOutboxMessages(c *gin.Context) {
+	query, err := utils.GetQueryParams(c, outboxMessageQueryOptions)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+	page := utils.GetPaginationParams(c, utils.DefaultPerPage, utils.MaxPerPage)
+
+	messages, total, err := ac.outboxService.List(c.Request.Context(), query, page)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list outbox messages", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	resp, err := utils.NewResponse[[]models.OutboxMessage](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(messages).
+		WithMessage("Outbox messages retrieved successfully").
+		WithPagination(utils.NewPaginationMeta(page, total)).
+		Send()
+}
+
+// GetOutboxMessage handles GET /admin/outbox/messages/:id - A message with the errors of
+// its latest failed attempts
+func (ac *AdminController) GetOutboxMessage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid outbox message ID")
+		return
+	}
+
+	message, err := ac.outboxService.Get(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Outbox message not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get outbox message", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, message, "Outbox message retrieved successfully")
+}
+
+// RetryOutboxMessage handles POST /admin/outbox/messages/:id/retry - Deliver a failed or
+// backed-off message now, with a fresh set of attempts
+func (ac *AdminController) RetryOutboxMessage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid outbox message ID")
+		return
+	}
+
+	if err := ac.outboxService.Retry(c.Request.Context(), id); err != nil {
+		ac.sendOutboxChangeError(c, err, "Failed to retry outbox message")
+		return
+	}
+	logger.Audit(logger.AuditOutboxMessagesRetried,
+		logger.String("id", id.String()),
+		logger.Int64("count", 1),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, gin.H{"requeued": 1}, "Outbox message queued for delivery")
+}
+
+// RetryFailedOutboxMessages handles POST /admin/outbox/retry - Queue every failed message
+// again, only those of the topic given by ?topic= when set
+func (ac *AdminController) RetryFailedOutboxMessages(c *gin.Context) {
+	topic := strings.TrimSpace(c.Query("topic"))
+
+	count, err := ac.outboxService.RetryFailed(c.Request.Context(), topic)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to retry failed outbox messages", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditOutboxMessagesRetried,
+		logger.String("topic", topic),
+		logger.Int64("count", count),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, gin.H{"requeued": count}, "Failed outbox messages queued for delivery")
+}
+
+// DiscardOutboxMessage handles DELETE /admin/outbox/messages/:id - Drop a failed or pending
+// message so it is never delivered
+func (ac *AdminController) DiscardOutboxMessage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid outbox message ID")
+		return
+	}
+
+	if err := ac.outboxService.Discard(c.Request.Context(), id); err != nil {
+		ac.sendOutboxChangeError(c, err, "Failed to discard outbox message")
+		return
+	}
+	logger.Audit(logger.AuditOutboxMessageDiscarded,
+		logger.String("id", id.String()),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendNoContent(c, "Outbox message discarded successfully")
+}
+
+// sendOutboxChangeError responds to a failed retry or discard of one message
+func (ac *AdminController) sendOutboxChangeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		utils.SendNotFound(c, "Outbox message not found")
+	case errors.Is(err, common.ErrOutboxMessageSent):
+		utils.SendConflict(c, "Outbox message was already sent")
+	default:
+		logger.ErrorCtx(c.Request.Context(), message, logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+	}
+}
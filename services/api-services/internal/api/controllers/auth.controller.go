@@ -28,12 +28,14 @@ func NewAuthController(
 // SignUp handles POST /auth/signup - Register a new user with OTP verification
 func (ac *AuthController) SignUp(c *gin.Context) {
 	var req dtos.SignUpRequestDto
-	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("Invalid request payload", logger.ErrorField(err))
-		utils.SendError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
+	if req.Locale == "" {
+		req.Locale = utils.GetLocale(c)
+	}
+
 	response, err := ac.authService.SignUpByEmail(c.Request.Context(), &req)
 	if err != nil {
 		switch err {
@@ -42,22 +44,20 @@ func (ac *AuthController) SignUp(c *gin.Context) {
 		case common.ErrInvalidOTP:
 			utils.SendBadRequest(c, "Invalid or expired OTP")
 		default:
-			logger.Error("Failed to sign up user", logger.ErrorField(err))
+			logger.ErrorCtx(c.Request.Context(), "Failed to sign up user", logger.ErrorField(err))
 			utils.SendError(c, http.StatusInternalServerError, "SIGNUP_FAILED", "Failed to sign up user")
 		}
 		return
 	}
 
-	logger.Info("User signed up successfully")
+	logger.InfoCtx(c.Request.Context(), "User signed up successfully")
 	utils.SendCreated(c, response, "User signed up successfully")
 }
 
 // SignIn handles POST /auth/signin - Login user with OTP verification
 func (ac *AuthController) SignIn(c *gin.Context) {
 	var req dtos.SignInRequestDto
-	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("Invalid request payload", logger.ErrorField(err))
-		utils.SendError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -71,12 +71,86 @@ func (ac *AuthController) SignIn(c *gin.Context) {
 		case common.ErrEmailNotVerified:
 			utils.SendUnauthorizedWithDetail(c, "EMAIL_NOT_VERIFIED", "Email not verified")
 		default:
-			logger.Error("Failed to sign in user", logger.ErrorField(err))
+			logger.ErrorCtx(c.Request.Context(), "Failed to sign in user", logger.ErrorField(err))
 			utils.SendError(c, http.StatusInternalServerError, "SIGNIN_FAILED", "Failed to sign in user")
 		}
 		return
 	}
 
-	logger.Info("User signed in successfully")
+	logger.InfoCtx(c.Request.Context(), "User signed in successfully")
 	utils.SendSuccess(c, response, "User signed in successfully")
 }
+
+// ForgotPassword handles POST /auth/forgot-password - Send a password reset OTP
+func (ac *AuthController) ForgotPassword(c *gin.Context) {
+	var req dtos.ForgotPasswordRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := ac.authService.ForgotPassword(c.Request.Context(), &req); err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to initiate password reset", logger.ErrorField(err))
+		utils.SendError(c, http.StatusInternalServerError, "FORGOT_PASSWORD_FAILED", "Failed to initiate password reset")
+		return
+	}
+
+	// Same response whether or not the account exists, to avoid user enumeration.
+	utils.SendAccepted[any](c, nil, "If the account exists, a password reset code has been sent")
+}
+
+// SendPhoneVerification handles POST /auth/phone/verification - Text a verification code
+// to the phone number of the authenticated user
+func (ac *AuthController) SendPhoneVerification(c *gin.Context) {
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	if err := ac.authService.SendPhoneVerification(c.Request.Context(), userID); err != nil {
+		switch err {
+		case common.ErrPhoneNumberMissing:
+			utils.SendBadRequest(c, "No phone number on the account")
+		case common.ErrPhoneAlreadyVerified:
+			utils.SendConflict(c, "Phone number already verified")
+		case common.ErrUserNotFound:
+			utils.SendNotFound(c, "User not found")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to send phone verification", logger.ErrorField(err))
+			utils.SendError(c, http.StatusInternalServerError, "PHONE_VERIFICATION_FAILED", "Failed to send phone verification code")
+		}
+		return
+	}
+
+	utils.SendAccepted[any](c, nil, "Verification code sent")
+}
+
+// VerifyPhone handles POST /auth/phone/verify - Confirm the phone number of the
+// authenticated user with the code sent to it
+func (ac *AuthController) VerifyPhone(c *gin.Context) {
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.VerifyPhoneRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := ac.authService.VerifyPhone(c.Request.Context(), userID, &req); err != nil {
+		switch err {
+		case common.ErrInvalidOTP:
+			utils.SendBadRequest(c, "Invalid or expired OTP")
+		case common.ErrPhoneNumberMissing:
+			utils.SendBadRequest(c, "No phone number on the account")
+		case common.ErrUserNotFound:
+			utils.SendNotFound(c, "User not found")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to verify phone number", logger.ErrorField(err))
+			utils.SendError(c, http.StatusInternalServerError, "PHONE_VERIFICATION_FAILED", "Failed to verify phone number")
+		}
+		return
+	}
+
+	utils.SendSuccess[any](c, nil, "Phone number verified successfully")
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ChatOpsController handles the chat integrations of organizations and the slash commands
+// they receive
+type ChatOpsController struct {
+	chatOpsService *services.ChatOpsService
+}
+
+// NewChatOpsController creates a new chat ops controller instance
+func NewChatOpsController(chatOpsService *services.ChatOpsService) *ChatOpsController {
+	return &ChatOpsController{chatOpsService: chatOpsService}
+}
+
+// List handles GET /organizations/:organizationId/chat-integrations - The chat integrations
+// of the organization, without their verification keys
+func (cc *ChatOpsController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	integrations, err := cc.chatOpsService.List(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list chat integrations", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, integrations, "Integrations retrieved successfully")
+}
+
+// Create handles POST /organizations/:organizationId/chat-integrations - Add an integration
+// receiving the slash commands of the Slack or Discord app of the organization
+func (cc *ChatOpsController) Create(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.CreateChatIntegrationRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	integration, err := cc.chatOpsService.Create(c.Request.Context(), organizationID, userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidChatVerificationKey) {
+			utils.SendBadRequest(c, "Invalid verification key for this platform")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to create chat integration", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendCreated(c, integration, "Integration created successfully")
+}
+
+// Delete handles DELETE /organizations/:organizationId/chat-integrations/:integrationId -
+// Delete an integration, which stops accepting its commands at once
+func (cc *ChatOpsController) Delete(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	integrationID, err := uuid.Parse(c.Param("integrationId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid integration ID")
+		return
+	}
+
+	if err := cc.chatOpsService.Delete(c.Request.Context(), organizationID, integrationID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Integration not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to delete chat integration", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendNoContent(c, "Integration deleted successfully")
+}
+
+// Receive handles POST /integrations/chat/:integrationId - Slash command of a chat
+// integration, authenticated by the signature of its platform. The response is the message
+// format of the platform rather than the API envelope, since the platform shows it in chat.
+func (cc *ChatOpsController) Receive(c *gin.Context) {
+	integrationID, err := uuid.Parse(c.Param("integrationId"))
+	if err != nil {
+		utils.SendNotFound(c, "Integration not found")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if utils.IsRequestTooLarge(err) {
+			utils.SendPayloadTooLarge(c, "Command payload is too large")
+			return
+		}
+		utils.SendBadRequest(c, "Failed to read command payload")
+		return
+	}
+
+	response, err := cc.chatOpsService.Handle(c.Request.Context(), integrationID, c.Request.Header, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Integration not found")
+		case errors.Is(err, services.ErrInvalidChatSignature):
+			logger.WarnCtx(c.Request.Context(), "Rejected chat command with an invalid signature",
+				logger.String("integration_id", integrationID.String()),
+				logger.String("request_id", utils.GetRequestID(c)),
+				logger.ErrorField(err),
+			)
+			utils.SendUnauthorizedWithDetail(c, "INVALID_SIGNATURE", "Invalid request signature")
+		case errors.Is(err, services.ErrInvalidChatPayload):
+			utils.SendBadRequest(c, "Invalid command payload")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to run chat command",
+				logger.String("integration_id", integrationID.String()),
+				logger.String("request_id", utils.GetRequestID(c)),
+				logger.ErrorField(err),
+			)
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
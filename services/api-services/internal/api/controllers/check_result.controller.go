@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// defaultCheckResultRange is the window listed when no from parameter is given
+const defaultCheckResultRange = 24 * time.Hour
+
+// checkResultQueryOptions whitelists the check result filters. Results are always listed
+// newest first, so no sort is accepted.
+var checkResultQueryOptions = utils.QueryOptions{
+	Filters: map[string]string{
+		"status":   "status",
+		"region":   "region",
+		"probe_id": "probe_id",
+	},
+}
+
+// checkResultCSVColumns defines the columns of the check result CSV export
+var checkResultCSVColumns = []utils.CSVColumn[models.CheckResult]{
+	{Header: "checked_at", Value: func(r *models.CheckResult) string { return r.CheckedAt.UTC().Format(time.RFC3339Nano) }},
+	{Header: "probe_id", Value: func(r *models.CheckResult) string { return r.ProbeID }},
+	{Header: "region", Value: func(r *models.CheckResult) string { return r.Region }},
+	{Header: "status", Value: func(r *models.CheckResult) string { return r.Status }},
+	{Header: "latency_ms", Value: func(r *models.CheckResult) string { return strconv.FormatInt(r.LatencyMs, 10) }},
+	{Header: "status_code", Value: func(r *models.CheckResult) string { return strconv.Itoa(int(r.StatusCode)) }},
+	{Header: "error", Value: func(r *models.CheckResult) string { return r.Error }},
+}
+
+// CheckResultController handles check result queries
+type CheckResultController struct {
+	checkResultService *services.CheckResultService
+}
+
+// NewCheckResultController creates a new check result controller instance
+func NewCheckResultController(checkResultService *services.CheckResultService) *CheckResultController {
+	return &CheckResultController{checkResultService: checkResultService}
+}
+
+// List handles GET /organizations/:organizationId/monitors/:monitorId/results - Raw check results over a range.
+// from and to are RFC 3339 timestamps (default: the last 24 hours); filter[status], filter[region]
+// and filter[probe_id] take comma-separated values. Results are listed newest first, limit per
+// page, and the next page is requested with the cursor returned in meta.cursor.next_cursor.
+// With ?format=csv every matching result of the range is streamed as a CSV download instead.
+func (rc *CheckResultController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	query, err := utils.GetQueryParams(c, checkResultQueryOptions)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+
+	from, to, ok := timeRangeParams(c, defaultCheckResultRange)
+	if !ok {
+		return
+	}
+	if utils.WantsCSV(c) {
+		export, err := rc.checkResultService.ExportMonitorResults(c.Request.Context(), organizationID, monitorID, query, from, to)
+		if err != nil {
+			sendCheckResultError(c, err)
+			return
+		}
+		utils.StreamCSV(c, "check-results.csv", checkResultCSVColumns, export)
+		return
+	}
+	page := utils.GetCursorParams(c, utils.DefaultCursorLimit, utils.MaxCursorLimit)
+
+	results, pagination, err := rc.checkResultService.ListMonitorResults(c.Request.Context(), organizationID, monitorID, query, from, to, page)
+	if err != nil {
+		sendCheckResultError(c, err)
+		return
+	}
+
+	resp, err := utils.NewResponse[[]models.CheckResult](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(results).
+		WithMessage("Check results retrieved successfully").
+		WithCursor(pagination).
+		Send()
+}
+
+// sendCheckResultError sends the response of a failed check result query
+func sendCheckResultError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCheckResultRange):
+		utils.SendBadRequest(c, "Invalid check result range")
+	case errors.Is(err, utils.ErrInvalidCursor):
+		utils.SendBadRequest(c, "Invalid cursor")
+	case errors.Is(err, common.ErrNotFound):
+		utils.SendNotFound(c, "Monitor not found")
+	case errors.Is(err, repositories.ErrCheckResultStoreDisabled):
+		utils.SendError(c, http.StatusServiceUnavailable, "CHECK_RESULTS_UNAVAILABLE", "Check results are temporarily unavailable")
+	default:
+		logger.ErrorCtx(c.Request.Context(), "Failed to list check results", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+	}
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ConfigSyncController handles declarative configuration syncs of organizations
+type ConfigSyncController struct {
+	configSyncService *services.ConfigSyncService
+}
+
+// NewConfigSyncController creates a new config sync controller instance
+func NewConfigSyncController(configSyncService *services.ConfigSyncService) *ConfigSyncController {
+	return &ConfigSyncController{configSyncService: configSyncService}
+}
+
+// Sync handles PUT /organizations/:organizationId/config - Make the organization match a
+// declarative configuration document. With ?dry_run=true the plan is returned without
+// applying it.
+func (cc *ConfigSyncController) Sync(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	dryRun := false
+	if raw := c.Query("dry_run"); raw != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			utils.SendBadRequest(c, "Invalid dry_run parameter")
+			return
+		}
+	}
+
+	var req dtos.OrganizationConfigDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	plan, err := cc.configSyncService.Sync(c.Request.Context(), organizationID, &req, dryRun)
+	if err != nil {
+		var configErr *services.ConfigError
+		switch {
+		case errors.As(err, &configErr):
+			utils.SendBadRequest(c, "Invalid configuration document", configErr)
+		case errors.Is(err, common.ErrConflict):
+			utils.SendConflict(c, "Monitors were modified while the configuration was applied, try again")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to sync organization configuration", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	if dryRun {
+		utils.SendSuccess(c, plan, "Configuration plan computed successfully")
+		return
+	}
+	logger.Audit(logger.AuditConfigSynced,
+		logger.String("organization_id", organizationID.String()),
+		logger.Int("created", plan.Create),
+		logger.Int("updated", plan.Update),
+		logger.Int("deleted", plan.Delete),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, plan, "Configuration applied successfully")
+}
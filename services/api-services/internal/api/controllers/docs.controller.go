@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/openapi"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIPage loads Swagger UI from its CDN and points it at the generated spec.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>API Documentation</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "/docs/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+// swaggerUIContentSecurityPolicy relaxes the global CSP just enough for the Swagger UI page.
+const swaggerUIContentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com; " +
+	"style-src 'self' 'unsafe-inline' https://unpkg.com; img-src 'self' data: https://unpkg.com; frame-ancestors 'none'"
+
+// DocsController serves the OpenAPI document and Swagger UI.
+type DocsController struct {
+	spec *openapi.Builder
+}
+
+// NewDocsController creates a new instance of DocsController.
+func NewDocsController(spec *openapi.Builder) *DocsController {
+	return &DocsController{spec: spec}
+}
+
+// GetSwaggerUI handles GET /docs
+func (dc *DocsController) GetSwaggerUI(c *gin.Context) {
+	c.Header("Content-Security-Policy", swaggerUIContentSecurityPolicy)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+// GetSpec handles GET /docs/openapi.json
+func (dc *DocsController) GetSpec(c *gin.Context) {
+	data, err := dc.spec.JSON()
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to render OpenAPI document", logger.ErrorField(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render OpenAPI document"})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
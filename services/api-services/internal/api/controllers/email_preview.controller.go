@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+)
+
+const (
+	defaultCapturedEmailLimit = 50
+	maxCapturedEmailLimit     = 500
+)
+
+// emailPreviewContentSecurityPolicy lets captured emails use the inline styles and remote
+// images email templates rely on, while still blocking scripts
+const emailPreviewContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; img-src * data:; frame-ancestors 'self'"
+
+// EmailPreviewController serves the emails stored by the capture provider so templates
+// can be checked during development. It is never registered in production.
+type EmailPreviewController struct {
+	capture *email.CaptureEmailProvider
+}
+
+// NewEmailPreviewController creates a new email preview controller instance
+func NewEmailPreviewController(capture *email.CaptureEmailProvider) *EmailPreviewController {
+	return &EmailPreviewController{capture: capture}
+}
+
+// List handles GET /dev/emails - The most recently captured emails, newest first.
+// ?limit= caps how many are returned.
+func (ec *EmailPreviewController) List(c *gin.Context) {
+	limit := defaultCapturedEmailLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			utils.SendBadRequest(c, "Invalid limit")
+			return
+		}
+		limit = min(parsed, maxCapturedEmailLimit)
+	}
+
+	emails, err := ec.capture.List(c.Request.Context(), limit)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list captured emails", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, emails, "Captured emails retrieved successfully")
+}
+
+// Get handles GET /dev/emails/:id - A captured email with both bodies
+func (ec *EmailPreviewController) Get(c *gin.Context) {
+	captured, ok := ec.find(c)
+	if !ok {
+		return
+	}
+	utils.SendSuccess(c, captured, "Captured email retrieved successfully")
+}
+
+// PreviewHTML handles GET /dev/emails/:id/html - Render a captured email as the recipient
+// would see it. Emails without an HTML body show their plaintext body.
+func (ec *EmailPreviewController) PreviewHTML(c *gin.Context) {
+	captured, ok := ec.find(c)
+	if !ok {
+		return
+	}
+
+	body := captured.HTML
+	if body == "" {
+		body = "<pre>" + html.EscapeString(captured.Text) + "</pre>"
+	}
+	c.Header("Content-Security-Policy", emailPreviewContentSecurityPolicy)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(body))
+}
+
+// PreviewText handles GET /dev/emails/:id/text - The plaintext body of a captured email
+func (ec *EmailPreviewController) PreviewText(c *gin.Context) {
+	captured, ok := ec.find(c)
+	if !ok {
+		return
+	}
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(captured.Text))
+}
+
+// find loads the captured email named by the id parameter, answering the request when it
+// cannot be loaded
+func (ec *EmailPreviewController) find(c *gin.Context) (*email.CapturedEmail, bool) {
+	captured, err := ec.capture.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, email.ErrCapturedEmailNotFound) {
+			utils.SendNotFound(c, "Captured email not found")
+			return nil, false
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to read captured email", logger.String("id", c.Param("id")), logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return nil, false
+	}
+	return captured, true
+}
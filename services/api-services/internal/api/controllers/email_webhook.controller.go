@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+)
+
+// EmailWebhookController receives bounce and complaint notifications from email
+// providers. The routes are public; every payload is authenticated by its provider's
+// signature.
+type EmailWebhookController struct {
+	suppressionService *services.EmailSuppressionService
+	sendGridKey        *ecdsa.PublicKey
+	mailgunSigningKey  string
+	snsVerifier        *email.SNSVerifier
+}
+
+// NewEmailWebhookController creates a new email webhook controller instance. Providers
+// whose key or verifier is not set are not served.
+func NewEmailWebhookController(
+	suppressionService *services.EmailSuppressionService,
+	sendGridKey *ecdsa.PublicKey,
+	mailgunSigningKey string,
+	snsVerifier *email.SNSVerifier,
+) *EmailWebhookController {
+	return &EmailWebhookController{
+		suppressionService: suppressionService,
+		sendGridKey:        sendGridKey,
+		mailgunSigningKey:  mailgunSigningKey,
+		snsVerifier:        snsVerifier,
+	}
+}
+
+// SendGrid handles POST /webhooks/email/sendgrid - SendGrid signed event webhook
+func (ec *EmailWebhookController) SendGrid(c *gin.Context) {
+	payload, ok := ec.readPayload(c)
+	if !ok {
+		return
+	}
+
+	err := email.VerifySendGridSignature(ec.sendGridKey, payload,
+		c.GetHeader("X-Twilio-Email-Event-Webhook-Signature"),
+		c.GetHeader("X-Twilio-Email-Event-Webhook-Timestamp"),
+	)
+	if err != nil {
+		ec.rejectSignature(c, "sendgrid", err)
+		return
+	}
+
+	feedback, err := email.ParseSendGridEvents(payload)
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid webhook payload")
+		return
+	}
+	ec.record(c, feedback)
+}
+
+// Mailgun handles POST /webhooks/email/mailgun - Mailgun webhook
+func (ec *EmailWebhookController) Mailgun(c *gin.Context) {
+	payload, ok := ec.readPayload(c)
+	if !ok {
+		return
+	}
+
+	feedback, err := email.ParseMailgunWebhook(ec.mailgunSigningKey, payload)
+	if err != nil {
+		if errors.Is(err, email.ErrInvalidSignature) {
+			ec.rejectSignature(c, "mailgun", err)
+			return
+		}
+		utils.SendBadRequest(c, "Invalid webhook payload")
+		return
+	}
+	ec.record(c, feedback)
+}
+
+// SES handles POST /webhooks/email/ses - Amazon SES notifications delivered by SNS,
+// including the subscription confirmation sent when the endpoint is subscribed
+func (ec *EmailWebhookController) SES(c *gin.Context) {
+	payload, ok := ec.readPayload(c)
+	if !ok {
+		return
+	}
+
+	message, err := ec.snsVerifier.ParseSNSMessage(c.Request.Context(), payload)
+	if err != nil {
+		if errors.Is(err, email.ErrInvalidSignature) {
+			ec.rejectSignature(c, "ses", err)
+			return
+		}
+		logger.WarnCtx(c.Request.Context(), "Failed to verify SNS message",
+			logger.String("request_id", utils.GetRequestID(c)),
+			logger.ErrorField(err),
+		)
+		utils.SendBadRequest(c, "Invalid webhook payload")
+		return
+	}
+
+	if err := ec.snsVerifier.ConfirmSubscription(c.Request.Context(), message); err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to confirm SNS subscription",
+			logger.String("topic_arn", message.TopicARN),
+			logger.ErrorField(err),
+		)
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	feedback, err := email.ParseSESNotification(message)
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid webhook payload")
+		return
+	}
+	ec.record(c, feedback)
+}
+
+// readPayload reads the raw request body, which the signatures are computed over
+func (ec *EmailWebhookController) readPayload(c *gin.Context) ([]byte, bool) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		utils.SendBadRequest(c, "Failed to read webhook payload")
+		return nil, false
+	}
+	return payload, true
+}
+
+// rejectSignature answers a payload that failed verification
+func (ec *EmailWebhookController) rejectSignature(c *gin.Context, provider string, err error) {
+	logger.WarnCtx(c.Request.Context(), "Rejected email webhook with an invalid signature",
+		logger.String("provider", provider),
+		logger.String("request_id", utils.GetRequestID(c)),
+		logger.ErrorField(err),
+	)
+	utils.SendUnauthorizedWithDetail(c, "INVALID_SIGNATURE", "Invalid webhook signature")
+}
+
+// record suppresses the reported addresses. A failure answers with an error so the
+// provider retries the delivery.
+func (ec *EmailWebhookController) record(c *gin.Context, feedback []email.Feedback) {
+	if err := ec.suppressionService.Record(c.Request.Context(), feedback); err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to record email feedback",
+			logger.String("request_id", utils.GetRequestID(c)),
+			logger.ErrorField(err),
+		)
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, gin.H{"recorded": len(feedback)}, "Email feedback recorded")
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// GeneratedReportController handles the uptime report files organization members request
+type GeneratedReportController struct {
+	reportService *services.GeneratedReportService
+}
+
+// NewGeneratedReportController creates a new generated report controller instance
+func NewGeneratedReportController(reportService *services.GeneratedReportService) *GeneratedReportController {
+	return &GeneratedReportController{reportService: reportService}
+}
+
+// Create handles POST /organizations/:organizationId/reports - Request a PDF or CSV uptime
+// report for a range of days. The report is generated in the background; poll it until
+// it is ready to get its download link.
+func (rc *GeneratedReportController) Create(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.GenerateReportRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+	// The validator checked both dates parse
+	from, _ := time.Parse(time.DateOnly, req.From)
+	to, _ := time.Parse(time.DateOnly, req.To)
+
+	report, err := rc.reportService.Request(c.Request.Context(), organizationID, userID, req.Format, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidReportRange):
+			utils.SendBadRequest(c, err.Error())
+		case errors.Is(err, services.ErrTooManyPendingReports):
+			utils.SendConflict(c, "Too many reports are being generated, try again once they are ready")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to request report", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	utils.SendAccepted(c, report, "Report requested successfully")
+}
+
+// List handles GET /organizations/:organizationId/reports - The latest reports of the
+// organization, with download links for the ready ones
+func (rc *GeneratedReportController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	reports, err := rc.reportService.List(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list reports", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess(c, reports, "Reports retrieved successfully")
+}
+
+// Get handles GET /organizations/:organizationId/reports/:reportId - A report, with its
+// download link once it is ready
+func (rc *GeneratedReportController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	reportID, err := uuid.Parse(c.Param("reportId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid report ID")
+		return
+	}
+
+	report, err := rc.reportService.Get(c.Request.Context(), organizationID, reportID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Report not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get report", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess(c, report, "Report retrieved successfully")
+}
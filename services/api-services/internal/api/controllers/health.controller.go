@@ -3,30 +3,48 @@ package controllers
 import (
 	"context"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/lifecycle"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/sms"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/database"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
 )
 
-// ServiceStatus defines the health of an individual dependency.
+// healthCheckTimeout bounds the dependency checks of GET /health
+const healthCheckTimeout = 5 * time.Second
+
+// ServiceStatus defines the health of an individual dependency. LatencyMs is how long its
+// check took, and LastSuccessAt when it last passed, which is kept across failed checks.
 type ServiceStatus struct {
-	Status        string `json:"status"`
-	Error         string `json:"error,omitempty"`
-	ActiveClients int    `json:"active_clients,omitempty"`
+	Status        string     `json:"status"`
+	Error         string     `json:"error,omitempty"`
+	LatencyMs     float64    `json:"latency_ms"`
+	CheckedAt     time.Time  `json:"checked_at"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	ActiveClients int        `json:"active_clients,omitempty"`
+	// Pool reports connection pool usage for database dependencies
+	Pool *database.PoolStats `json:"pool,omitempty"`
+	// Providers reports the send counters and circuit state of each email provider
+	Providers []email.ProviderStats `json:"providers,omitempty"`
 }
 
-// HealthResponse defines the structured response for the health check endpoint.
+// HealthResponse defines the structured response for the health check endpoint. Cached is
+// set when it was served from the results of a previous request, checked at CheckedAt.
 type HealthResponse struct {
 	OverallStatus           string                   `json:"overall_status"`
 	OverallHealthPercentage float64                  `json:"overall_health_percentage"`
 	Services                map[string]ServiceStatus `json:"services"`
+	CheckedAt               time.Time                `json:"checked_at"`
+	Cached                  bool                     `json:"cached"`
 }
 
 // HealthController handles health-related API requests.
@@ -36,15 +54,30 @@ type HealthController struct {
 	CacheService     *cache.Service
 	StorageDriver    storage.Driver
 	EmailService     email.Service
+	SMSService       sms.Service
+	Startup          *lifecycle.Startup
+
+	// cacheTTL is how long the results of GET /health are reused
+	cacheTTL time.Duration
+	checks   singleflight.Group
+
+	mu          sync.Mutex
+	cached      *HealthResponse
+	lastSuccess map[string]time.Time
 }
 
-// NewHealthController creates a new instance of HealthController.
+// NewHealthController creates a new instance of HealthController. GET /health reuses its
+// results for cacheTTL, so that load balancers polling it do not check every dependency on
+// every request; 0 disables the cache.
 func NewHealthController(
 	postgresClient database.Client,
 	clickhouseClient database.Client,
 	cacheService *cache.Service,
 	storageDriver storage.Driver,
 	emailService email.Service,
+	smsService sms.Service,
+	startup *lifecycle.Startup,
+	cacheTTL time.Duration,
 ) *HealthController {
 	return &HealthController{
 		PostgresClient:   postgresClient,
@@ -52,126 +85,165 @@ func NewHealthController(
 		CacheService:     cacheService,
 		StorageDriver:    storageDriver,
 		EmailService:     emailService,
+		SMSService:       smsService,
+		Startup:          startup,
+		cacheTTL:         cacheTTL,
+		lastSuccess:      make(map[string]time.Time),
 	}
 }
 
-// GetHealth handles the GET /health endpoint by running concurrent checks.
+// GetHealth handles the GET /health endpoint by running concurrent checks. Results are
+// reused for the cache TTL, and concurrent requests missing the cache share one run of the
+// checks. Requests sent with Cache-Control: no-cache always run them.
 func (ctrl *HealthController) GetHealth(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
+	if !strings.Contains(c.GetHeader("Cache-Control"), "no-cache") {
+		if response, ok := ctrl.cachedHealth(); ok {
+			ctrl.sendHealth(c, response)
+			return
+		}
+	}
 
-	var wg sync.WaitGroup
-	resultsChan := make(chan struct {
-		Name   string
-		Status ServiceStatus
-	}, 5)
+	// The checks outlive the request that started them, since other requests may wait on them
+	ctx := context.WithoutCancel(c.Request.Context())
+	result, _, _ := ctrl.checks.Do("health", func() (any, error) {
+		response := ctrl.checkHealth(ctx)
+		ctrl.mu.Lock()
+		ctrl.cached = &response
+		ctrl.mu.Unlock()
+		return response, nil
+	})
+	ctrl.sendHealth(c, result.(HealthResponse))
+}
 
-	serviceChecks := 0
+// cachedHealth returns a copy of the last health results while they are fresh
+func (ctrl *HealthController) cachedHealth() (HealthResponse, bool) {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
 
-	if ctrl.PostgresClient != nil {
-		serviceChecks++
-		wg.Add(1)
-		go ctrl.checkPostgres(ctx, &wg, resultsChan)
+	if ctrl.cached == nil || ctrl.cacheTTL <= 0 || time.Since(ctrl.cached.CheckedAt) >= ctrl.cacheTTL {
+		return HealthResponse{}, false
 	}
+	response := *ctrl.cached
+	response.Cached = true
+	return response, true
+}
 
-	if ctrl.ClickHouseClient != nil {
-		serviceChecks++
-		wg.Add(1)
-		go ctrl.checkClickHouse(ctx, &wg, resultsChan)
+// sendHealth writes health results, with 503 when a dependency is down
+func (ctrl *HealthController) sendHealth(c *gin.Context, response HealthResponse) {
+	httpStatus := http.StatusOK
+	if response.OverallStatus != "healthy" {
+		httpStatus = http.StatusServiceUnavailable
 	}
+	c.JSON(httpStatus, response)
+}
+
+// dependencyCheck checks a dependency, filling the status and error of its ServiceStatus
+type dependencyCheck func(ctx context.Context) ServiceStatus
+
+// checkHealth runs the checks of every configured dependency concurrently, timing each
+func (ctrl *HealthController) checkHealth(ctx context.Context) HealthResponse {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
 
+	checks := make(map[string]dependencyCheck)
+	if ctrl.PostgresClient != nil {
+		checks["database"] = ctrl.checkPostgres
+	}
+	if ctrl.ClickHouseClient != nil {
+		checks["clickhouse"] = ctrl.checkClickHouse
+	}
 	if ctrl.CacheService != nil {
-		serviceChecks++
-		wg.Add(1)
-		go ctrl.checkCache(ctx, &wg, resultsChan)
+		checks["redis"] = ctrl.checkCache
 	}
-
 	if ctrl.StorageDriver != nil {
-		serviceChecks++
-		wg.Add(1)
-		go ctrl.checkStorage(ctx, &wg, resultsChan)
+		checks["storage"] = ctrl.checkStorage
 	}
-
 	if ctrl.EmailService != nil {
-		serviceChecks++
-		wg.Add(1)
-		go ctrl.checkEmail(ctx, &wg, resultsChan)
+		checks["email"] = ctrl.checkEmail
+	}
+	if ctrl.SMSService != nil {
+		checks["sms"] = ctrl.checkSMS
 	}
 
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		services = make(map[string]ServiceStatus, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			status := check(ctx)
+			status.CheckedAt = time.Now().UTC()
+			status.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+			mu.Lock()
+			services[name] = status
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
 
-	services := make(map[string]ServiceStatus)
 	healthyChecks := 0
-	isDegraded := false
-
-	for res := range resultsChan {
-		services[res.Name] = res.Status
-		if res.Status.Status == "up" {
+	ctrl.mu.Lock()
+	for name, status := range services {
+		if status.Status == "up" {
 			healthyChecks++
-		} else {
-			isDegraded = true
+			ctrl.lastSuccess[name] = status.CheckedAt
+		}
+		if lastSuccess, ok := ctrl.lastSuccess[name]; ok {
+			status.LastSuccessAt = &lastSuccess
+			services[name] = status
 		}
 	}
+	ctrl.mu.Unlock()
 
 	var overallHealth float64
-	if serviceChecks > 0 {
-		overallHealth = (float64(healthyChecks) / float64(serviceChecks)) * 100
+	if len(checks) > 0 {
+		overallHealth = (float64(healthyChecks) / float64(len(checks))) * 100
 	}
 
 	response := HealthResponse{
 		OverallStatus:           "healthy",
 		OverallHealthPercentage: overallHealth,
 		Services:                services,
+		CheckedAt:               time.Now().UTC(),
 	}
-	httpStatus := http.StatusOK
-
-	if isDegraded {
+	if healthyChecks < len(checks) {
 		response.OverallStatus = "degraded"
-		httpStatus = http.StatusServiceUnavailable
 	}
-
-	c.JSON(httpStatus, response)
+	return response
 }
 
 // checkPostgres performs the health check for the PostgreSQL database.
-func (ctrl *HealthController) checkPostgres(ctx context.Context, wg *sync.WaitGroup, resChan chan<- struct {
-	Name   string
-	Status ServiceStatus
-}) {
-	defer wg.Done()
+func (ctrl *HealthController) checkPostgres(ctx context.Context) ServiceStatus {
+	status := ServiceStatus{Status: "up", Pool: poolStats(ctrl.PostgresClient)}
 	if err := ctrl.PostgresClient.HealthCheck(ctx); err != nil {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"database", ServiceStatus{Status: "down", Error: err.Error()}}
-	} else {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"database", ServiceStatus{Status: "up"}}
+		status.Status = "down"
+		status.Error = err.Error()
 	}
+	return status
 }
 
 // checkClickHouse performs the health check for the ClickHouse database.
-func (ctrl *HealthController) checkClickHouse(ctx context.Context, wg *sync.WaitGroup, resChan chan<- struct {
-	Name   string
-	Status ServiceStatus
-}) {
-	defer wg.Done()
+func (ctrl *HealthController) checkClickHouse(ctx context.Context) ServiceStatus {
+	status := ServiceStatus{Status: "up", Pool: poolStats(ctrl.ClickHouseClient)}
 	if err := ctrl.ClickHouseClient.HealthCheck(ctx); err != nil {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"clickhouse", ServiceStatus{Status: "down", Error: err.Error()}}
-	} else {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"clickhouse", ServiceStatus{Status: "up"}}
+		status.Status = "down"
+		status.Error = err.Error()
 	}
+	return status
+}
+
+// poolStats returns the connection pool statistics of client, or nil when they are unavailable
+func poolStats(client database.Client) *database.PoolStats {
+	stats, err := client.PoolStats()
+	if err != nil {
+		return nil
+	}
+	return &stats
 }
 
 // GetLiveness provides a simple liveness probe for Kubernetes.
@@ -179,9 +251,33 @@ func (ctrl *HealthController) GetLiveness(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "alive"})
 }
 
+// GetStartup provides a startup probe for Kubernetes, reporting the progress of every
+// startup step. It answers 503 until they all finished.
+func (ctrl *HealthController) GetStartup(c *gin.Context) {
+	if ctrl.Startup == nil {
+		c.JSON(http.StatusOK, lifecycle.StartupProgress{Ready: true, Steps: []lifecycle.StepProgress{}})
+		return
+	}
+
+	progress := ctrl.Startup.Progress()
+	status := http.StatusOK
+	if !progress.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, progress)
+}
+
 // GetReadiness provides a readiness probe for Kubernetes.
-// Checks critical dependencies required to serve traffic.
+// Reports not ready until startup completed, then checks critical dependencies required to
+// serve traffic.
 func (ctrl *HealthController) GetReadiness(c *gin.Context) {
+	if ctrl.Startup != nil {
+		if pending := ctrl.Startup.Pending(); len(pending) > 0 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "starting", "pending": pending})
+			return
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
@@ -205,6 +301,12 @@ func (ctrl *HealthController) GetReadiness(c *gin.Context) {
 		}
 	}
 
+	if ctrl.StorageDriver != nil {
+		if err := ctrl.StorageDriver.HealthCheck(ctx); err != nil {
+			errors = append(errors, "storage: "+err.Error())
+		}
+	}
+
 	if len(errors) > 0 {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not_ready", "errors": errors})
 		return
@@ -214,61 +316,34 @@ func (ctrl *HealthController) GetReadiness(c *gin.Context) {
 }
 
 // checkCache performs the health check for the Redis cache.
-func (ctrl *HealthController) checkCache(ctx context.Context, wg *sync.WaitGroup, resChan chan<- struct {
-	Name   string
-	Status ServiceStatus
-}) {
-	defer wg.Done()
+func (ctrl *HealthController) checkCache(ctx context.Context) ServiceStatus {
 	if err := ctrl.CacheService.HealthCheck(ctx); err != nil {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"redis", ServiceStatus{Status: "down", Error: err.Error()}}
-	} else {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"redis", ServiceStatus{Status: "up"}}
+		return ServiceStatus{Status: "down", Error: err.Error()}
 	}
+	return ServiceStatus{Status: "up"}
 }
 
 // checkStorage performs the health check for the storage driver.
-func (ctrl *HealthController) checkStorage(ctx context.Context, wg *sync.WaitGroup, resChan chan<- struct {
-	Name   string
-	Status ServiceStatus
-}) {
-	defer wg.Done()
-	// Implement storage health check logic
-	// For local storage, we'll assume it's up if we can access it; adjust as needed
-	_, err := ctrl.StorageDriver.Exists(ctx, "health_check")
-	if err != nil {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"storage", ServiceStatus{Status: "down", Error: err.Error()}}
-	} else {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"storage", ServiceStatus{Status: "up"}}
+func (ctrl *HealthController) checkStorage(ctx context.Context) ServiceStatus {
+	if err := ctrl.StorageDriver.HealthCheck(ctx); err != nil {
+		return ServiceStatus{Status: "down", Error: err.Error()}
 	}
+	return ServiceStatus{Status: "up"}
 }
 
 // checkEmail performs the health check for the email service.
-func (ctrl *HealthController) checkEmail(ctx context.Context, wg *sync.WaitGroup, resChan chan<- struct {
-	Name   string
-	Status ServiceStatus
-}) {
-	defer wg.Done()
+func (ctrl *HealthController) checkEmail(ctx context.Context) ServiceStatus {
+	providers := ctrl.EmailService.Stats()
 	if err := ctrl.EmailService.HealthCheck(ctx); err != nil {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"email", ServiceStatus{Status: "down", Error: err.Error()}}
-	} else {
-		resChan <- struct {
-			Name   string
-			Status ServiceStatus
-		}{"email", ServiceStatus{Status: "up"}}
+		return ServiceStatus{Status: "down", Error: err.Error(), Providers: providers}
+	}
+	return ServiceStatus{Status: "up", Providers: providers}
+}
+
+// checkSMS performs the health check for the SMS service.
+func (ctrl *HealthController) checkSMS(ctx context.Context) ServiceStatus {
+	if err := ctrl.SMSService.HealthCheck(ctx); err != nil {
+		return ServiceStatus{Status: "down", Error: err.Error()}
 	}
+	return ServiceStatus{Status: "up"}
 }
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
+)
+
+// InboundIntegrationController handles the inbound integrations of organizations and the
+// alert webhooks they receive
+type InboundIntegrationController struct {
+	integrationService *services.InboundIntegrationService
+}
+
+// NewInboundIntegrationController creates a new inbound integration controller instance
+func NewInboundIntegrationController(integrationService *services.InboundIntegrationService) *InboundIntegrationController {
+	return &InboundIntegrationController{integrationService: integrationService}
+}
+
+// List handles GET /organizations/:organizationId/integrations - The inbound integrations
+// of the organization, without their tokens
+func (ic *InboundIntegrationController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	integrations, err := ic.integrationService.List(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list inbound integrations", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, integrations, "Integrations retrieved successfully")
+}
+
+// Create handles POST /organizations/:organizationId/integrations - Add an integration
+// receiving the alert webhooks of Alertmanager, Grafana or UptimeRobot. Its token is only
+// returned in this response.
+func (ic *InboundIntegrationController) Create(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.CreateInboundIntegrationRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	integration, err := ic.integrationService.Create(c.Request.Context(), organizationID, userID, &req)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to create inbound integration", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendCreated(c, integration, "Integration created successfully")
+}
+
+// Delete handles DELETE /organizations/:organizationId/integrations/:integrationId - Delete
+// an integration, which stops accepting its webhooks at once
+func (ic *InboundIntegrationController) Delete(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	integrationID, err := uuid.Parse(c.Param("integrationId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid integration ID")
+		return
+	}
+
+	if err := ic.integrationService.Delete(c.Request.Context(), organizationID, integrationID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Integration not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to delete inbound integration", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendNoContent(c, "Integration deleted successfully")
+}
+
+// Receive handles POST /integrations/inbound - Alert webhook of an inbound integration,
+// authenticated by its token as a bearer token or in the token query parameter, for tools
+// such as UptimeRobot that cannot set headers. The payload format is the one of the
+// provider of the integration.
+func (ic *InboundIntegrationController) Receive(c *gin.Context) {
+	token := security.ExtractTokenFromHeader(c)
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		utils.SendUnauthorizedWithDetail(c, "MISSING_INTEGRATION_TOKEN", "Authorization header or token query parameter is required")
+		return
+	}
+
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if utils.IsRequestTooLarge(err) {
+			utils.SendPayloadTooLarge(c, "Webhook payload is too large")
+			return
+		}
+		utils.SendBadRequest(c, "Failed to read webhook payload")
+		return
+	}
+
+	result, err := ic.integrationService.Receive(c.Request.Context(), token, payload)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidIntegrationToken):
+			utils.SendUnauthorizedWithDetail(c, "INVALID_INTEGRATION_TOKEN", "Integration token is either invalid or its integration was deleted")
+		case errors.Is(err, services.ErrInvalidIntegrationPayload):
+			utils.SendBadRequest(c, "Invalid webhook payload")
+		default:
+			// Answering with an error makes the sender retry the delivery
+			logger.ErrorCtx(c.Request.Context(), "Failed to receive inbound alerts",
+				logger.String("request_id", utils.GetRequestID(c)),
+				logger.ErrorField(err),
+			)
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendSuccess(c, result, "Alerts received")
+}
@@ -0,0 +1,294 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// incidentQueryOptions whitelists the incident list filters, sorts and search columns
+var incidentQueryOptions = utils.QueryOptions{
+	Filters: map[string]string{
+		"status":         "status",
+		"severity":       "severity",
+		"source":         "source",
+		"integration_id": "integration_id",
+		"monitor_id":     "monitor_id",
+		"parent_id":      "parent_id",
+		"suppressed":     "suppressed",
+		"assignee_id":    "assignee_id",
+		"team":           "team",
+	},
+	Sorts: map[string]string{
+		"started_at":  "started_at",
+		"resolved_at": "resolved_at",
+		"created_at":  "created_at",
+	},
+	SearchFields: []string{"title"},
+	DefaultSort:  "-started_at",
+}
+
+// incidentCSVColumns defines the columns of the incident CSV export
+var incidentCSVColumns = []utils.CSVColumn[models.Incident]{
+	{Header: "id", Value: func(i *models.Incident) string { return i.ID.String() }},
+	{Header: "ref", Value: func(i *models.Incident) string { return i.Ref() }},
+	{Header: "title", Value: func(i *models.Incident) string { return i.Title }},
+	{Header: "status", Value: func(i *models.Incident) string { return i.Status }},
+	{Header: "severity", Value: func(i *models.Incident) string { return i.Severity }},
+	{Header: "source", Value: func(i *models.Incident) string { return i.Source }},
+	{Header: "monitor_id", Value: func(i *models.Incident) string { return optionalUUID(i.MonitorID) }},
+	{Header: "assignee_id", Value: func(i *models.Incident) string { return optionalUUID(i.AssigneeID) }},
+	{Header: "team", Value: func(i *models.Incident) string { return i.Team }},
+	{Header: "cause", Value: func(i *models.Incident) string { return i.Cause }},
+	{Header: "started_at", Value: func(i *models.Incident) string { return i.StartedAt.UTC().Format(time.RFC3339) }},
+	{Header: "acknowledged_at", Value: func(i *models.Incident) string { return optionalTime(i.AcknowledgedAt) }},
+	{Header: "resolved_at", Value: func(i *models.Incident) string { return optionalTime(i.ResolvedAt) }},
+}
+
+// optionalUUID renders an optional ID of a CSV export, empty when unset
+func optionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// optionalTime renders an optional time of a CSV export, empty when unset
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// IncidentController handles the incidents of organizations
+type IncidentController struct {
+	incidentService *services.IncidentService
+}
+
+// NewIncidentController creates a new incident controller instance
+func NewIncidentController(incidentService *services.IncidentService) *IncidentController {
+	return &IncidentController{incidentService: incidentService}
+}
+
+// List handles GET /organizations/:organizationId/incidents - List the incidents of the organization.
+// With ?format=csv all matching incidents are streamed as a CSV download instead.
+func (ic *IncidentController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	query, err := utils.GetQueryParams(c, incidentQueryOptions)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+
+	if utils.WantsCSV(c) {
+		utils.StreamCSV(c, "incidents.csv", incidentCSVColumns, func(yield func(*models.Incident) error) error {
+			return ic.incidentService.ExportOrganizationIncidents(c.Request.Context(), organizationID, query, yield)
+		})
+		return
+	}
+	page := utils.GetPaginationParams(c, utils.DefaultPerPage, utils.MaxPerPage)
+
+	incidents, total, err := ic.incidentService.ListOrganizationIncidents(c.Request.Context(), organizationID, query, page)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list incidents", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	resp, err := utils.NewResponse[[]models.Incident](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(incidents).
+		WithMessage("Incidents retrieved successfully").
+		WithPagination(utils.NewPaginationMeta(page, total)).
+		Send()
+}
+
+// ListMine handles GET /me/incidents - The open incidents assigned to the caller across their
+// organizations, newest first
+func (ic *IncidentController) ListMine(c *gin.Context) {
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+	page := utils.GetPaginationParams(c, utils.DefaultPerPage, utils.MaxPerPage)
+
+	incidents, total, err := ic.incidentService.ListAssignedIncidents(c.Request.Context(), userID, page)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list assigned incidents", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	resp, err := utils.NewResponse[[]models.Incident](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(incidents).
+		WithMessage("Incidents retrieved successfully").
+		WithPagination(utils.NewPaginationMeta(page, total)).
+		Send()
+}
+
+// Workload handles GET /organizations/:organizationId/incidents/workload - How many incidents
+// are open for each member of the organization, and unassigned
+func (ic *IncidentController) Workload(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	workload, err := ic.incidentService.Workload(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to get incident workload", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, workload, "Incident workload retrieved successfully")
+}
+
+// Assign handles PUT /organizations/:organizationId/incidents/:incidentId/assignee - Assign an
+// incident to a member of the organization, or unassign it
+func (ic *IncidentController) Assign(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	incidentID, err := uuid.Parse(c.Param("incidentId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid incident ID")
+		return
+	}
+
+	var req dtos.AssignIncidentRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	incident, err := ic.incidentService.AssignOrganizationIncident(c.Request.Context(), organizationID, incidentID, req.AssigneeID, req.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Incident not found")
+		case errors.Is(err, common.ErrConflict):
+			utils.SendConflict(c, "Incident was modified by someone else, reload it and try again")
+		case errors.Is(err, services.ErrIncidentAssigneeNotMember):
+			utils.SendBadRequest(c, "Incident assignee must be a member of the organization")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to assign incident", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	auditIncidentAssigned(c, incident)
+	utils.SendSuccess(c, incident, "Incident assigned successfully")
+}
+
+// Claim handles POST /organizations/:organizationId/incidents/:incidentId/claim - Assign an
+// incident to the caller, unless someone else holds it
+func (ic *IncidentController) Claim(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	incidentID, err := uuid.Parse(c.Param("incidentId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid incident ID")
+		return
+	}
+
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	incident, err := ic.incidentService.ClaimOrganizationIncident(c.Request.Context(), organizationID, incidentID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Incident not found")
+		case errors.Is(err, services.ErrIncidentAlreadyClaimed):
+			utils.SendConflict(c, "Incident is assigned to someone else")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to claim incident", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	auditIncidentAssigned(c, incident)
+	utils.SendSuccess(c, incident, "Incident claimed successfully")
+}
+
+// auditIncidentAssigned records the assignment of an incident by the caller in the audit log
+func auditIncidentAssigned(c *gin.Context, incident *models.Incident) {
+	assigneeID := ""
+	if incident.AssigneeID != nil {
+		assigneeID = incident.AssigneeID.String()
+	}
+	logger.Audit(logger.AuditIncidentAssigned,
+		logger.String("organization_id", incident.OrganizationID.String()),
+		logger.String("incident_id", incident.ID.String()),
+		logger.String("title", incident.Title),
+		logger.String("assignee_id", assigneeID),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+}
+
+// Tag handles PUT /organizations/:organizationId/incidents/:incidentId/cause - Tag a resolved
+// incident with its root cause, or untag it
+func (ic *IncidentController) Tag(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	incidentID, err := uuid.Parse(c.Param("incidentId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid incident ID")
+		return
+	}
+
+	var req dtos.TagIncidentCauseRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	incident, err := ic.incidentService.TagOrganizationIncident(c.Request.Context(), organizationID, incidentID, req.Cause, req.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Incident not found")
+		case errors.Is(err, services.ErrIncidentNotResolved):
+			utils.SendConflict(c, "Only resolved incidents can be tagged with a cause")
+		case errors.Is(err, common.ErrConflict):
+			utils.SendConflict(c, "Incident was modified by someone else, reload it and try again")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to tag incident cause", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendSuccess(c, incident, "Incident cause updated successfully")
+}
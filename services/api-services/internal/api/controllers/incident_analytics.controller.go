@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// defaultIncidentAnalyticsRange is the window reported when no from parameter is given
+const defaultIncidentAnalyticsRange = 30 * 24 * time.Hour
+
+// IncidentAnalyticsController handles the analytics of the resolved incidents of organizations
+type IncidentAnalyticsController struct {
+	analyticsService *services.IncidentAnalyticsService
+}
+
+// NewIncidentAnalyticsController creates a new incident analytics controller instance
+func NewIncidentAnalyticsController(analyticsService *services.IncidentAnalyticsService) *IncidentAnalyticsController {
+	return &IncidentAnalyticsController{analyticsService: analyticsService}
+}
+
+// Get handles GET /organizations/:organizationId/incidents/analytics - How many incidents were
+// resolved over a range and their mean time to resolve, overall, by cause and by monitor.
+// from and to are RFC 3339 timestamps (default: the last 30 days).
+func (ac *IncidentAnalyticsController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	from, to, ok := timeRangeParams(c, defaultIncidentAnalyticsRange)
+	if !ok {
+		return
+	}
+
+	analytics, err := ac.analyticsService.GetOrganizationAnalytics(c.Request.Context(), organizationID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidIncidentAnalyticsRange):
+			utils.SendBadRequest(c, "Invalid incident analytics range")
+		case errors.Is(err, repositories.ErrIncidentAnalyticsDisabled):
+			utils.SendError(c, http.StatusServiceUnavailable, "ANALYTICS_UNAVAILABLE", "Incident analytics are temporarily unavailable")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to get incident analytics", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	utils.SendSuccess(c, analytics, "Incident analytics retrieved successfully")
+}
@@ -0,0 +1,337 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/checker"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
+)
+
+// monitorQueryOptions whitelists the monitor list filters, sorts and search columns
+var monitorQueryOptions = utils.QueryOptions{
+	Filters: map[string]string{
+		"status":         "status",
+		"type":           "type",
+		"environment_id": "environment_id",
+		"owner_user_id":  "owner_user_id",
+		"owner_team":     "owner_team",
+	},
+	Sorts: map[string]string{
+		"name":            "name",
+		"status":          "status",
+		"created_at":      "created_at",
+		"updated_at":      "updated_at",
+		"last_checked_at": "last_checked_at",
+	},
+	SearchFields: []string{"name", "target"},
+	DefaultSort:  "-created_at",
+}
+
+// monitorCSVColumns defines the columns of the monitor CSV export
+var monitorCSVColumns = []utils.CSVColumn[models.Monitor]{
+	{Header: "id", Value: func(m *models.Monitor) string { return m.ID.String() }},
+	{Header: "name", Value: func(m *models.Monitor) string { return m.Name }},
+	{Header: "type", Value: func(m *models.Monitor) string { return m.Type }},
+	{Header: "target", Value: func(m *models.Monitor) string { return m.Target }},
+	{Header: "status", Value: func(m *models.Monitor) string { return m.Status }},
+	{Header: "interval_seconds", Value: func(m *models.Monitor) string { return strconv.Itoa(m.IntervalSeconds) }},
+	{Header: "timeout_seconds", Value: func(m *models.Monitor) string { return strconv.Itoa(m.TimeoutSeconds) }},
+	{Header: "owner_team", Value: func(m *models.Monitor) string { return m.OwnerTeam }},
+	{Header: "last_checked_at", Value: func(m *models.Monitor) string {
+		if m.LastCheckedAt == nil {
+			return ""
+		}
+		return m.LastCheckedAt.UTC().Format(time.RFC3339)
+	}},
+	{Header: "created_at", Value: func(m *models.Monitor) string { return m.CreatedAt.UTC().Format(time.RFC3339) }},
+}
+
+// MonitorController handles monitor-related HTTP requests
+type MonitorController struct {
+	monitorService *services.MonitorService
+	urlSigner      *urlsigner.Signer
+	linkTTL        time.Duration
+}
+
+// NewMonitorController creates a new monitor controller instance.
+// urlSigner and linkTTL are used to issue signed export download links.
+func NewMonitorController(monitorService *services.MonitorService, urlSigner *urlsigner.Signer, linkTTL time.Duration) *MonitorController {
+	return &MonitorController{
+		monitorService: monitorService,
+		urlSigner:      urlSigner,
+		linkTTL:        linkTTL,
+	}
+}
+
+// List handles GET /organizations/:organizationId/monitors - List monitors with filtering, sorting and search.
+// With ?format=csv or ?format=ndjson all matching monitors are streamed as a CSV or
+// newline-delimited JSON download instead.
+func (mc *MonitorController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	query, err := utils.GetQueryParams(c, monitorQueryOptions)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+
+	if utils.WantsCSV(c) {
+		mc.streamCSV(c, organizationID, query)
+		return
+	}
+	if utils.WantsNDJSON(c) {
+		utils.StreamNDJSON(c, "monitors.ndjson", func(yield func(*models.Monitor) error) error {
+			return mc.monitorService.ExportOrganizationMonitors(c.Request.Context(), organizationID, query, yield)
+		})
+		return
+	}
+	page := utils.GetPaginationParams(c, utils.DefaultPerPage, utils.MaxPerPage)
+
+	monitors, total, err := mc.monitorService.ListOrganizationMonitors(c.Request.Context(), organizationID, query, page)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list monitors", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	resp, err := utils.NewResponse[[]models.Monitor](c)
+	if err != nil {
+		return
+	}
+	resp.WithData(monitors).
+		WithMessage("Monitors retrieved successfully").
+		WithPagination(utils.NewPaginationMeta(page, total)).
+		Send()
+}
+
+// CreateExportLink handles POST /organizations/:organizationId/monitors/export-link - Issue a signed,
+// expiring CSV download link that carries the current filter, sort and search parameters
+func (mc *MonitorController) CreateExportLink(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	if _, err := utils.GetQueryParams(c, monitorQueryOptions); err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+
+	query := c.Request.URL.Query()
+	query.Del("format")
+	link, err := utils.NewSignedLink(mc.urlSigner, MonitorExportDownloadPath(organizationID), query, mc.linkTTL)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to sign monitor export link", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendCreated(c, link, "Export link created successfully")
+}
+
+// DownloadExport handles GET /downloads/organizations/:organizationId/monitors.csv - Stream a CSV export.
+// Access is granted by the URL signature, so this route is not behind the auth middleware.
+func (mc *MonitorController) DownloadExport(c *gin.Context) {
+	organizationID, err := uuid.Parse(c.Param("organizationId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	// The signature stands in for membership, so scope the request here instead
+	c.Request = c.Request.WithContext(tenant.WithOrganization(c.Request.Context(), organizationID))
+
+	query, err := utils.GetQueryParams(c, monitorQueryOptions)
+	if err != nil {
+		utils.SendBadRequest(c, err.Error())
+		return
+	}
+
+	mc.streamCSV(c, organizationID, query)
+}
+
+// MonitorExportDownloadPath returns the signed download path for an organization's monitor export
+func MonitorExportDownloadPath(organizationID uuid.UUID) string {
+	return "/downloads/organizations/" + organizationID.String() + "/monitors.csv"
+}
+
+func (mc *MonitorController) streamCSV(c *gin.Context, organizationID uuid.UUID, query utils.QueryParams) {
+	utils.StreamCSV(c, "monitors.csv", monitorCSVColumns, func(yield func(*models.Monitor) error) error {
+		return mc.monitorService.ExportOrganizationMonitors(c.Request.Context(), organizationID, query, yield)
+	})
+}
+
+// Get handles GET /organizations/:organizationId/monitors/:monitorId - Get a single monitor
+func (mc *MonitorController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	monitor, err := mc.monitorService.GetOrganizationMonitor(c.Request.Context(), organizationID, monitorID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Monitor not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get monitor", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess(c, monitor, "Monitor retrieved successfully")
+}
+
+// Update handles PATCH /organizations/:organizationId/monitors/:monitorId - Update a monitor.
+// The request carries the version the client last read so concurrent edits conflict instead
+// of silently overwriting each other.
+func (mc *MonitorController) Update(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	var req dtos.UpdateMonitorRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	monitor, err := mc.monitorService.UpdateOrganizationMonitor(c.Request.Context(), organizationID, monitorID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Monitor not found")
+		case errors.Is(err, common.ErrConflict):
+			utils.SendConflict(c, "Monitor was modified by someone else, reload it and try again")
+		case errors.Is(err, services.ErrInvalidMonitorTimeout):
+			utils.SendBadRequest(c, "Monitor timeout must be shorter than its interval")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to update monitor", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	logger.Audit(logger.AuditMonitorUpdated,
+		logger.String("organization_id", organizationID.String()),
+		logger.String("monitor_id", monitor.ID.String()),
+		logger.String("name", monitor.Name),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, monitor, "Monitor updated successfully")
+}
+
+// SetOwner handles PUT /organizations/:organizationId/monitors/:monitorId/owner - Replace the
+// member and team owning a monitor, who its incidents are assigned to
+func (mc *MonitorController) SetOwner(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	var req dtos.SetMonitorOwnerRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	monitor, err := mc.monitorService.SetOrganizationMonitorOwner(c.Request.Context(), organizationID, monitorID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Monitor not found")
+		case errors.Is(err, common.ErrConflict):
+			utils.SendConflict(c, "Monitor was modified by someone else, reload it and try again")
+		case errors.Is(err, services.ErrMonitorOwnerNotMember):
+			utils.SendBadRequest(c, "Monitor owner must be a member of the organization")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to set monitor owner", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	ownerUserID := ""
+	if monitor.OwnerUserID != nil {
+		ownerUserID = monitor.OwnerUserID.String()
+	}
+	logger.Audit(logger.AuditMonitorOwnerChanged,
+		logger.String("organization_id", organizationID.String()),
+		logger.String("monitor_id", monitor.ID.String()),
+		logger.String("name", monitor.Name),
+		logger.String("owner_user_id", ownerUserID),
+		logger.String("owner_team", monitor.OwnerTeam),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, monitor, "Monitor owner updated successfully")
+}
+
+// Validate handles POST /organizations/:organizationId/monitors/validate - Run the check of
+// monitor settings once without saving them and return its full result
+func (mc *MonitorController) Validate(c *gin.Context) {
+	if _, ok := utils.GetOrganizationID(c); !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.ValidateMonitorRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := mc.monitorService.ValidateCheck(c.Request.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, checker.ErrInvalidTarget):
+			utils.SendBadRequest(c, "Invalid target for this monitor type")
+		case errors.Is(err, checker.ErrBlockedAddress):
+			utils.SendBadRequest(c, "The target resolves to a non-public address")
+		case errors.Is(err, checker.ErrUnsupportedType):
+			utils.SendError(c, http.StatusUnprocessableEntity, "CHECK_UNSUPPORTED", "This monitor type cannot be checked from the API")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to validate monitor check", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendSuccess(c, result, "Monitor check completed")
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/checker"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// MonitorCheckController handles the checks of monitors requested out of schedule
+type MonitorCheckController struct {
+	checkService *services.MonitorCheckService
+}
+
+// NewMonitorCheckController creates a new monitor check controller instance
+func NewMonitorCheckController(checkService *services.MonitorCheckService) *MonitorCheckController {
+	return &MonitorCheckController{checkService: checkService}
+}
+
+// CheckNow handles POST /organizations/:organizationId/monitors/:monitorId/check-now - Queue
+// an immediate check of the monitor, whose result is recorded like the ones of probes
+func (mc *MonitorCheckController) CheckNow(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	monitor, err := mc.checkService.RequestCheck(c.Request.Context(), organizationID, monitorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Monitor not found")
+		case errors.Is(err, services.ErrMonitorPaused):
+			utils.SendConflict(c, "Paused monitors are not checked")
+		case errors.Is(err, checker.ErrUnsupportedType):
+			utils.SendError(c, http.StatusUnprocessableEntity, "CHECK_UNSUPPORTED", "This monitor type cannot be checked from the API")
+		case errors.Is(err, services.ErrMonitorCheckTooSoon):
+			c.Header("Retry-After", strconv.Itoa(int(services.MonitorCheckCooldown.Seconds())))
+			utils.SendError(c, http.StatusTooManyRequests, "CHECK_RATE_LIMITED", "A check of this monitor was requested recently, try again later")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to request monitor check", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendAccepted(c, monitor, "Monitor check queued")
+}
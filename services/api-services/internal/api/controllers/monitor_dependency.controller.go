@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// MonitorDependencyController handles the dependencies between monitors
+type MonitorDependencyController struct {
+	dependencyService *services.MonitorDependencyService
+}
+
+// NewMonitorDependencyController creates a new monitor dependency controller instance
+func NewMonitorDependencyController(dependencyService *services.MonitorDependencyService) *MonitorDependencyController {
+	return &MonitorDependencyController{dependencyService: dependencyService}
+}
+
+// Get handles GET /organizations/:organizationId/monitors/:monitorId/dependencies - The
+// monitors a monitor depends on and the ones depending on it
+func (dc *MonitorDependencyController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	dependencies, err := dc.dependencyService.Get(c.Request.Context(), organizationID, monitorID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Monitor not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get monitor dependencies", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, dependencies, "Monitor dependencies retrieved successfully")
+}
+
+// Set handles PUT /organizations/:organizationId/monitors/:monitorId/dependencies - Replace
+// the monitors a monitor depends on. While one of them is down, the monitor going down is
+// grouped under its incident instead of being alerted on.
+func (dc *MonitorDependencyController) Set(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	var req dtos.SetMonitorDependenciesRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	dependencies, err := dc.dependencyService.Set(c.Request.Context(), organizationID, monitorID, req.DependsOn)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Monitor not found")
+		case errors.Is(err, services.ErrDependencyMonitorNotFound):
+			utils.SendBadRequest(c, "Dependency monitor not found")
+		case errors.Is(err, services.ErrDependencyCycle):
+			utils.SendConflict(c, "Monitor dependencies cannot form a cycle")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to update monitor dependencies", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendSuccess(c, dependencies, "Monitor dependencies updated successfully")
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/discovery"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// MonitorDiscoveryController handles the discovery of monitors from sitemaps and OpenAPI
+// documents
+type MonitorDiscoveryController struct {
+	discoveryService *services.MonitorDiscoveryService
+}
+
+// NewMonitorDiscoveryController creates a new monitor discovery controller instance
+func NewMonitorDiscoveryController(discoveryService *services.MonitorDiscoveryService) *MonitorDiscoveryController {
+	return &MonitorDiscoveryController{discoveryService: discoveryService}
+}
+
+// Discover handles POST /organizations/:organizationId/monitors/discover - Preview the
+// monitors proposed for the endpoints of a site's sitemap or an API's OpenAPI document
+func (dc *MonitorDiscoveryController) Discover(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.DiscoverMonitorsRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	preview, err := dc.discoveryService.Discover(c.Request.Context(), organizationID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, discovery.ErrInvalidURL), errors.Is(err, discovery.ErrBlockedAddress):
+			utils.SendBadRequest(c, "The URL cannot be used for discovery")
+		case errors.Is(err, discovery.ErrNoDocument):
+			utils.SendError(c, http.StatusUnprocessableEntity, "NO_DISCOVERY_DOCUMENT", "No sitemap or OpenAPI document was found at this URL")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to discover monitors", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendSuccess(c, preview, "Monitors discovered successfully")
+}
+
+// Import handles POST /organizations/:organizationId/monitors/import - Create the monitors
+// picked from a discovery preview
+func (dc *MonitorDiscoveryController) Import(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.ImportMonitorsRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := dc.discoveryService.Import(c.Request.Context(), organizationID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMonitorTimeout) {
+			utils.SendBadRequest(c, "Monitor timeout must be shorter than its interval")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to import monitors", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditMonitorsImported,
+		logger.String("organization_id", organizationID.String()),
+		logger.Int("created", len(result.Created)),
+		logger.Int("skipped", len(result.Skipped)),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendCreated(c, result, "Monitors imported successfully")
+}
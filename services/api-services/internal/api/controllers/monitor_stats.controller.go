@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// defaultStatsRange is the window reported when no from parameter is given
+const defaultStatsRange = 24 * time.Hour
+
+// MonitorStatsController handles monitor uptime and latency statistics requests
+type MonitorStatsController struct {
+	statsService *services.MonitorStatsService
+}
+
+// NewMonitorStatsController creates a new monitor stats controller instance
+func NewMonitorStatsController(statsService *services.MonitorStatsService) *MonitorStatsController {
+	return &MonitorStatsController{statsService: statsService}
+}
+
+// Get handles GET /organizations/:organizationId/monitors/:monitorId/stats - Uptime and latency over a range.
+// from and to are RFC 3339 timestamps (default: the last 24 hours); resolution is minute, hour or day
+// and is picked from the range when omitted.
+func (sc *MonitorStatsController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+
+	from, to, ok := timeRangeParams(c, defaultStatsRange)
+	if !ok {
+		return
+	}
+
+	stats, err := sc.statsService.GetMonitorStats(c.Request.Context(), organizationID, monitorID, from, to, c.Query("resolution"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidStatsRange):
+			utils.SendBadRequest(c, "Invalid stats range or resolution")
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Monitor not found")
+		case errors.Is(err, repositories.ErrMonitorStatsDisabled):
+			utils.SendError(c, http.StatusServiceUnavailable, "STATS_UNAVAILABLE", "Monitor statistics are temporarily unavailable")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to get monitor stats", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	utils.SendSuccess(c, stats, "Monitor stats retrieved successfully")
+}
+
+// timeRangeParams parses the from and to RFC 3339 query parameters. to defaults to now and
+// from to defaultRange before to. When either is malformed it sends the error response and
+// returns false.
+func timeRangeParams(c *gin.Context, defaultRange time.Duration) (from, to time.Time, ok bool) {
+	var err error
+	to = time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			utils.SendBadRequest(c, "Invalid from or to timestamp, expected RFC 3339")
+			return from, to, false
+		}
+	}
+	from = to.Add(-defaultRange)
+	if raw := c.Query("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			utils.SendBadRequest(c, "Invalid from or to timestamp, expected RFC 3339")
+			return from, to, false
+		}
+	}
+	return from, to, true
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// OrganizationSettingsController handles the default settings of organizations
+type OrganizationSettingsController struct {
+	settingsService *services.OrganizationSettingsService
+}
+
+// NewOrganizationSettingsController creates a new organization settings controller instance
+func NewOrganizationSettingsController(settingsService *services.OrganizationSettingsService) *OrganizationSettingsController {
+	return &OrganizationSettingsController{settingsService: settingsService}
+}
+
+// Get handles GET /organizations/:organizationId/settings - The defaults of the organization
+// and its data retention
+func (sc *OrganizationSettingsController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	settings, err := sc.settingsService.Get(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to get organization settings", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, settings, "Organization settings retrieved successfully")
+}
+
+// Put handles PUT /organizations/:organizationId/settings - Replace the defaults new monitors
+// of the organization get and how its alerts escalate
+func (sc *OrganizationSettingsController) Put(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.UpdateOrganizationSettingsRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	settings, err := sc.settingsService.Update(c.Request.Context(), organizationID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDefaultTimeout) {
+			utils.SendBadRequest(c, "Default timeout must be shorter than the default interval")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to update organization settings", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditOrganizationSettingsChanged,
+		logger.String("organization_id", organizationID.String()),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, settings, "Organization settings updated successfully")
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ProbeController handles the status of the probe network
+type ProbeController struct {
+	probeService *services.ProbeService
+}
+
+// NewProbeController creates a new probe controller instance
+func NewProbeController(probeService *services.ProbeService) *ProbeController {
+	return &ProbeController{probeService: probeService}
+}
+
+// Network handles GET /probes - The status of every probe region and probe, and when they
+// last reported results
+func (pc *ProbeController) Network(c *gin.Context) {
+	network, err := pc.probeService.Network(c.Request.Context())
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to get probe network status", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, network, "Probe network status retrieved successfully")
+}
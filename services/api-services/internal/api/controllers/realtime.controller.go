@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// RealtimeController upgrades dashboard connections to WebSockets
+type RealtimeController struct {
+	hub                    *realtime.Hub
+	organizationRepository repositories.OrganizationRepository
+	upgrader               websocket.Upgrader
+	origins                atomic.Pointer[map[string]struct{}]
+}
+
+// NewRealtimeController creates a new realtime controller instance.
+// allowedOrigins restricts which browser origins may open a connection; "*" allows any.
+func NewRealtimeController(
+	hub *realtime.Hub,
+	organizationRepository repositories.OrganizationRepository,
+	allowedOrigins []string,
+) *RealtimeController {
+	rc := &RealtimeController{
+		hub:                    hub,
+		organizationRepository: organizationRepository,
+	}
+	rc.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				// Non-browser clients do not send an Origin header
+				return true
+			}
+			origins := *rc.origins.Load()
+			if _, ok := origins["*"]; ok {
+				return true
+			}
+			_, ok := origins[origin]
+			return ok
+		},
+	}
+	rc.SetAllowedOrigins(allowedOrigins)
+
+	return rc
+}
+
+// SetAllowedOrigins replaces the browser origins allowed to open new connections
+func (rc *RealtimeController) SetAllowedOrigins(allowedOrigins []string) {
+	origins := make(map[string]struct{}, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origins[origin] = struct{}{}
+	}
+	rc.origins.Store(&origins)
+}
+
+// Connect handles GET /ws?organization_id= - Subscribe to an organization's live updates
+func (rc *RealtimeController) Connect(c *gin.Context) {
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Query("organization_id"))
+	if err != nil {
+		utils.SendBadRequest(c, "A valid organization_id query parameter is required")
+		return
+	}
+
+	isMember, err := rc.organizationRepository.IsMember(c.Request.Context(), organizationID, userID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to check organization membership", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	if !isMember {
+		utils.SendForbidden(c, "You are not a member of this organization")
+		return
+	}
+
+	conn, err := rc.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written an error response
+		logger.WarnCtx(c.Request.Context(), "WebSocket upgrade failed", logger.ErrorField(err))
+		return
+	}
+
+	rc.hub.Serve(conn, organizationID, userID)
+}
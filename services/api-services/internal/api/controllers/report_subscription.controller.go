@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ReportSubscriptionController handles the uptime report subscriptions of organization members
+type ReportSubscriptionController struct {
+	subscriptionService *services.ReportSubscriptionService
+}
+
+// NewReportSubscriptionController creates a new report subscription controller instance
+func NewReportSubscriptionController(subscriptionService *services.ReportSubscriptionService) *ReportSubscriptionController {
+	return &ReportSubscriptionController{subscriptionService: subscriptionService}
+}
+
+// Get handles GET /organizations/:organizationId/reports/subscription - The caller's report subscription
+func (rc *ReportSubscriptionController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	subscription, err := rc.subscriptionService.Get(c.Request.Context(), organizationID, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Not subscribed to uptime reports")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get report subscription", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess(c, subscription, "Report subscription retrieved successfully")
+}
+
+// Put handles PUT /organizations/:organizationId/reports/subscription - Subscribe the caller to
+// weekly or monthly uptime reports, or change the frequency of the subscription
+func (rc *ReportSubscriptionController) Put(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.ReportSubscriptionRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	subscription, err := rc.subscriptionService.Subscribe(c.Request.Context(), organizationID, userID, req.Frequency)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to save report subscription", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess(c, subscription, "Report subscription saved successfully")
+}
+
+// Delete handles DELETE /organizations/:organizationId/reports/subscription - Unsubscribe the caller
+func (rc *ReportSubscriptionController) Delete(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	if err := rc.subscriptionService.Unsubscribe(c.Request.Context(), organizationID, userID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Not subscribed to uptime reports")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to delete report subscription", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess[any](c, nil, "Unsubscribed from uptime reports")
+}
+
+// Unsubscribe handles POST /reports/unsubscribe - Unsubscribe with the token of an unsubscribe link.
+// The token stands in for authentication, so this route is public.
+func (rc *ReportSubscriptionController) Unsubscribe(c *gin.Context) {
+	var req dtos.UnsubscribeReportRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := rc.subscriptionService.UnsubscribeByToken(c.Request.Context(), req.Token); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Subscription not found or already removed")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to unsubscribe from reports", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+		utils.SendInternalServerError(c)
+		return
+	}
+
+	utils.SendSuccess[any](c, nil, "Unsubscribed from uptime reports")
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// RetentionPolicyController handles how long the data of organizations is kept. Members
+// read the retention of their organization; operators change it.
+type RetentionPolicyController struct {
+	policyService *services.RetentionPolicyService
+}
+
+// NewRetentionPolicyController creates a new retention policy controller instance
+func NewRetentionPolicyController(policyService *services.RetentionPolicyService) *RetentionPolicyController {
+	return &RetentionPolicyController{policyService: policyService}
+}
+
+// Get handles GET /organizations/:organizationId/retention and
+// GET /admin/organizations/:organizationId/retention - The retention of an organization
+func (rc *RetentionPolicyController) Get(c *gin.Context) {
+	organizationID, ok := retentionOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	retention, err := rc.policyService.Get(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to get retention policy", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, retention, "Retention policy retrieved successfully")
+}
+
+// Put handles PUT /admin/organizations/:organizationId/retention - Override how many days
+// the check results, rollups and resolved incidents of an organization are kept
+func (rc *RetentionPolicyController) Put(c *gin.Context) {
+	organizationID, ok := retentionOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.SetRetentionPolicyRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	retention, err := rc.policyService.Set(c.Request.Context(), organizationID, req.CheckResultsDays, req.RollupsDays, req.IncidentsDays)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to save retention policy", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditRetentionPolicyChanged,
+		logger.String("organization_id", organizationID.String()),
+		logger.Int("check_results_days", retention.CheckResultsDays),
+		logger.Int("rollups_days", retention.RollupsDays),
+		logger.Int("incidents_days", retention.IncidentsDays),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, retention, "Retention policy updated successfully")
+}
+
+// Delete handles DELETE /admin/organizations/:organizationId/retention - Apply the default
+// retention to an organization again
+func (rc *RetentionPolicyController) Delete(c *gin.Context) {
+	organizationID, ok := retentionOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	if err := rc.policyService.Reset(c.Request.Context(), organizationID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Organization has no retention policy")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to remove retention policy", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditRetentionPolicyChanged,
+		logger.String("organization_id", organizationID.String()),
+		logger.Bool("reset", true),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendNoContent(c, "Retention policy removed successfully")
+}
+
+// retentionOrganizationID returns the organization checked by the membership middleware,
+// or the one in the path of the operator routes
+func retentionOrganizationID(c *gin.Context) (uuid.UUID, bool) {
+	if organizationID, ok := utils.GetOrganizationID(c); ok {
+		return organizationID, true
+	}
+	organizationID, err := uuid.Parse(c.Param("organizationId"))
+	return organizationID, err == nil
+}
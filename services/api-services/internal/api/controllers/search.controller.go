@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// SearchController handles full-text search requests
+type SearchController struct {
+	searchService *services.SearchService
+}
+
+// NewSearchController creates a new search controller instance
+func NewSearchController(searchService *services.SearchService) *SearchController {
+	return &SearchController{searchService: searchService}
+}
+
+// Search handles GET /search - Ranked results of every type across the caller's organizations.
+// q is the search text; organization_id optionally restricts results to one organization.
+func (sc *SearchController) Search(c *gin.Context) {
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var organizationID *uuid.UUID
+	if raw := c.Query("organization_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			utils.SendBadRequest(c, "Invalid organization ID")
+			return
+		}
+		organizationID = &id
+	}
+
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit <= 0 {
+			utils.SendBadRequest(c, "Invalid limit")
+			return
+		}
+	}
+
+	results, err := sc.searchService.Search(c.Request.Context(), userID, organizationID, strings.TrimSpace(c.Query("q")), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidSearchQuery):
+			utils.SendBadRequest(c, "Search query must be between 2 and 200 characters")
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendForbidden(c, "You are not a member of this organization")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to search", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+
+	utils.SendSuccess(c, results, "Search results retrieved successfully")
+}
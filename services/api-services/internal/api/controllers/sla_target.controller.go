@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// SLATargetController handles the SLA targets of organizations and reports their error
+// budget and burn rate
+type SLATargetController struct {
+	targetService *services.SLATargetService
+}
+
+// NewSLATargetController creates a new SLA target controller instance
+func NewSLATargetController(targetService *services.SLATargetService) *SLATargetController {
+	return &SLATargetController{targetService: targetService}
+}
+
+// List handles GET /organizations/:organizationId/sla-targets - The SLA targets of the
+// organization with the state of their current period
+func (sc *SLATargetController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	targets, err := sc.targetService.List(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list SLA targets", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, targets, "SLA targets retrieved successfully")
+}
+
+// Get handles GET /organizations/:organizationId/sla-targets/:targetId - An SLA target
+func (sc *SLATargetController) Get(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	targetID, err := uuid.Parse(c.Param("targetId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid SLA target ID")
+		return
+	}
+
+	target, err := sc.targetService.Get(c.Request.Context(), organizationID, targetID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "SLA target not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get SLA target", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, target, "SLA target retrieved successfully")
+}
+
+// Create handles POST /organizations/:organizationId/sla-targets - Define an SLA target
+// for a monitor, an environment or the whole organization
+func (sc *SLATargetController) Create(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.CreateSLATargetRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	target, err := sc.targetService.Create(c.Request.Context(), organizationID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrSLAScopeNotFound) {
+			utils.SendBadRequest(c, "Monitor or environment not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to create SLA target", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendCreated(c, target, "SLA target created successfully")
+}
+
+// Update handles PATCH /organizations/:organizationId/sla-targets/:targetId - Rename an SLA
+// target or change its target or period
+func (sc *SLATargetController) Update(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	targetID, err := uuid.Parse(c.Param("targetId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid SLA target ID")
+		return
+	}
+
+	var req dtos.UpdateSLATargetRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	target, err := sc.targetService.Update(c.Request.Context(), organizationID, targetID, &req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "SLA target not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to update SLA target", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, target, "SLA target updated successfully")
+}
+
+// Delete handles DELETE /organizations/:organizationId/sla-targets/:targetId - Remove an SLA target
+func (sc *SLATargetController) Delete(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	targetID, err := uuid.Parse(c.Param("targetId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid SLA target ID")
+		return
+	}
+
+	if err := sc.targetService.Delete(c.Request.Context(), organizationID, targetID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "SLA target not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to delete SLA target", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendNoContent(c, "SLA target deleted successfully")
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// StatusController serves the monitor status and stats a status token grants access to.
+// Its routes must run after StatusTokenMiddleware.
+type StatusController struct {
+	tokenService *services.StatusTokenService
+}
+
+// NewStatusController creates a new status controller instance
+func NewStatusController(tokenService *services.StatusTokenService) *StatusController {
+	return &StatusController{tokenService: tokenService}
+}
+
+// statusToken returns the status token resolved by StatusTokenMiddleware
+func statusToken(c *gin.Context) (*models.StatusToken, bool) {
+	value, ok := c.Get(string(common.StatusTokenContextKey))
+	if !ok {
+		return nil, false
+	}
+	token, ok := value.(*models.StatusToken)
+	return token, ok
+}
+
+// Monitors handles GET /status/monitors - The current status of the monitors of the token
+func (sc *StatusController) Monitors(c *gin.Context) {
+	token, ok := statusToken(c)
+	if !ok {
+		utils.SendUnauthorized(c)
+		return
+	}
+
+	monitors, err := sc.tokenService.ListMonitors(c.Request.Context(), token)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list status token monitors", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, monitors, "Monitors retrieved successfully")
+}
+
+// MonitorStats handles GET /status/monitors/:monitorId/stats - Uptime and latency of a
+// monitor of the token, with the parameters of the organization stats endpoint
+func (sc *StatusController) MonitorStats(c *gin.Context) {
+	token, ok := statusToken(c)
+	if !ok {
+		utils.SendUnauthorized(c)
+		return
+	}
+
+	monitorID, err := uuid.Parse(c.Param("monitorId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid monitor ID")
+		return
+	}
+	from, to, ok := timeRangeParams(c, defaultStatsRange)
+	if !ok {
+		return
+	}
+
+	stats, err := sc.tokenService.GetMonitorStats(c.Request.Context(), token, monitorID, from, to, c.Query("resolution"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidStatsRange):
+			utils.SendBadRequest(c, "Invalid stats range or resolution")
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Monitor not found")
+		case errors.Is(err, repositories.ErrMonitorStatsDisabled):
+			utils.SendError(c, http.StatusServiceUnavailable, "STATS_UNAVAILABLE", "Monitor statistics are temporarily unavailable")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to get status token monitor stats", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendSuccess(c, stats, "Monitor stats retrieved successfully")
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// StatusTokenController handles the read-only status tokens of organizations
+type StatusTokenController struct {
+	tokenService *services.StatusTokenService
+}
+
+// NewStatusTokenController creates a new status token controller instance
+func NewStatusTokenController(tokenService *services.StatusTokenService) *StatusTokenController {
+	return &StatusTokenController{tokenService: tokenService}
+}
+
+// List handles GET /organizations/:organizationId/status-tokens - The status tokens of the
+// organization, without their secrets
+func (tc *StatusTokenController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	tokens, err := tc.tokenService.List(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list status tokens", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, tokens, "Status tokens retrieved successfully")
+}
+
+// Create handles POST /organizations/:organizationId/status-tokens - Issue a read-only token
+// for the status and stats of monitors. The token is only returned in this response.
+func (tc *StatusTokenController) Create(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.CreateStatusTokenRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	token, err := tc.tokenService.Create(c.Request.Context(), organizationID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrStatusTokenMonitorNotFound):
+			utils.SendBadRequest(c, "Monitor not found")
+		case errors.Is(err, services.ErrInvalidStatusTokenExpiry):
+			utils.SendBadRequest(c, "Status token expiry must be in the future")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to create status token", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendCreated(c, token, "Status token created successfully")
+}
+
+// Revoke handles DELETE /organizations/:organizationId/status-tokens/:tokenId - Revoke a
+// status token, which stops granting access at once
+func (tc *StatusTokenController) Revoke(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	tokenID, err := uuid.Parse(c.Param("tokenId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid status token ID")
+		return
+	}
+
+	if err := tc.tokenService.Revoke(c.Request.Context(), organizationID, tokenID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Status token not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to revoke status token", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendNoContent(c, "Status token revoked successfully")
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+)
+
+// StorageController serves stored assets through signed URLs
+type StorageController struct {
+	storageDriver storage.Driver
+}
+
+// NewStorageController creates a new storage controller instance
+func NewStorageController(storageDriver storage.Driver) *StorageController {
+	return &StorageController{storageDriver: storageDriver}
+}
+
+// Download handles GET <storage base path>/*key - Stream a stored asset. Access is granted
+// by the URL signature issued by the storage driver, so this route is not behind the auth
+// middleware.
+func (sc *StorageController) Download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if key == "" {
+		utils.SendBadRequest(c, "Missing asset key")
+		return
+	}
+
+	reader, err := sc.storageDriver.Download(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			utils.SendNotFound(c, "Asset not found")
+			return
+		}
+		logger.WarnCtx(c.Request.Context(), "Failed to open stored asset",
+			logger.String("key", key),
+			logger.String("request_id", utils.GetRequestID(c)),
+			logger.ErrorField(err),
+		)
+		utils.SendBadRequest(c, "Invalid asset key")
+		return
+	}
+	defer reader.Close()
+
+	// Files support range requests and conditional GETs; other readers are streamed whole
+	utils.SendReader(c, path.Base(key), utils.DispositionInline, reader)
+}
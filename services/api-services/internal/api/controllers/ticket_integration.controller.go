@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/ticketing"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// TicketIntegrationController handles the ticket integrations of organizations and the
+// tickets their incidents are filed as
+type TicketIntegrationController struct {
+	ticketService *services.TicketIntegrationService
+}
+
+// NewTicketIntegrationController creates a new ticket integration controller instance
+func NewTicketIntegrationController(ticketService *services.TicketIntegrationService) *TicketIntegrationController {
+	return &TicketIntegrationController{ticketService: ticketService}
+}
+
+// List handles GET /organizations/:organizationId/ticket-integrations - The ticket
+// integrations of the organization, without their API tokens
+func (tc *TicketIntegrationController) List(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	integrations, err := tc.ticketService.List(c.Request.Context(), organizationID)
+	if err != nil {
+		logger.ErrorCtx(c.Request.Context(), "Failed to list ticket integrations", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, integrations, "Integrations retrieved successfully")
+}
+
+// Create handles POST /organizations/:organizationId/ticket-integrations - Add an
+// integration filing the incidents of the organization in Jira or Linear
+func (tc *TicketIntegrationController) Create(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.CreateTicketIntegrationRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	integration, err := tc.ticketService.Create(c.Request.Context(), organizationID, userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTicketAccount) {
+			utils.SendBadRequest(c, "Invalid account settings for this provider")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to create ticket integration", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendCreated(c, integration, "Integration created successfully")
+}
+
+// Delete handles DELETE /organizations/:organizationId/ticket-integrations/:integrationId -
+// Delete an integration; the tickets filed through it are no longer resolved
+func (tc *TicketIntegrationController) Delete(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	integrationID, err := uuid.Parse(c.Param("integrationId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid integration ID")
+		return
+	}
+
+	if err := tc.ticketService.Delete(c.Request.Context(), organizationID, integrationID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Integration not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to delete ticket integration", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendNoContent(c, "Integration deleted successfully")
+}
+
+// ListIncidentTickets handles GET /organizations/:organizationId/incidents/:incidentId/tickets -
+// The issues the incident was filed as
+func (tc *TicketIntegrationController) ListIncidentTickets(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	incidentID, err := uuid.Parse(c.Param("incidentId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid incident ID")
+		return
+	}
+
+	tickets, err := tc.ticketService.ListIncidentTickets(c.Request.Context(), organizationID, incidentID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Incident not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to list incident tickets", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, tickets, "Incident tickets retrieved successfully")
+}
+
+// FileIncident handles POST /organizations/:organizationId/incidents/:incidentId/tickets -
+// File the incident as an issue through a ticket integration of the organization
+func (tc *TicketIntegrationController) FileIncident(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+	incidentID, err := uuid.Parse(c.Param("incidentId"))
+	if err != nil {
+		utils.SendBadRequest(c, "Invalid incident ID")
+		return
+	}
+
+	var req dtos.CreateIncidentTicketRequestDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	ticket, err := tc.ticketService.FileIncident(c.Request.Context(), organizationID, incidentID, req.IntegrationID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			utils.SendNotFound(c, "Incident or integration not found")
+		case errors.Is(err, ticketing.ErrAlreadyFiled):
+			utils.SendConflict(c, "Incident is already filed through this integration")
+		case errors.Is(err, ticketing.ErrTrackerFailed):
+			logger.WarnCtx(c.Request.Context(), "Issue tracker failed to file incident", logger.ErrorField(err))
+			utils.SendError(c, http.StatusBadGateway, "TRACKER_FAILED", "The issue tracker failed to file the incident")
+		default:
+			logger.ErrorCtx(c.Request.Context(), "Failed to file incident", logger.ErrorField(err))
+			utils.SendInternalServerError(c)
+		}
+		return
+	}
+	utils.SendCreated(c, ticket, "Incident filed successfully")
+}
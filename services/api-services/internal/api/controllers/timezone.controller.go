@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// TimezoneController handles the time zones of users and organizations
+type TimezoneController struct {
+	timezoneService *services.TimezoneService
+}
+
+// NewTimezoneController creates a new timezone controller instance
+func NewTimezoneController(timezoneService *services.TimezoneService) *TimezoneController {
+	return &TimezoneController{timezoneService: timezoneService}
+}
+
+// UpdateUser handles PUT /me/timezone - Set the time zone times are displayed to the caller in
+func (tc *TimezoneController) UpdateUser(c *gin.Context) {
+	userID, err := utils.GetAuthUser(c)
+	if err != nil {
+		return
+	}
+
+	var req dtos.TimezoneDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := tc.timezoneService.SetUserTimezone(c.Request.Context(), userID, req.Timezone); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "User not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to update user timezone", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, req, "Timezone updated successfully")
+}
+
+// GetOrganization handles GET /organizations/:organizationId/timezone - The time zone the
+// report periods of the organization follow
+func (tc *TimezoneController) GetOrganization(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	timezone, err := tc.timezoneService.GetOrganizationTimezone(c.Request.Context(), organizationID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Organization not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to get organization timezone", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	utils.SendSuccess(c, dtos.TimezoneDto{Timezone: timezone}, "Timezone retrieved successfully")
+}
+
+// UpdateOrganization handles PUT /organizations/:organizationId/timezone - Set the time zone
+// the report periods of the organization follow
+func (tc *TimezoneController) UpdateOrganization(c *gin.Context) {
+	organizationID, ok := utils.GetOrganizationID(c)
+	if !ok {
+		utils.SendBadRequest(c, "Invalid organization ID")
+		return
+	}
+
+	var req dtos.TimezoneDto
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := tc.timezoneService.SetOrganizationTimezone(c.Request.Context(), organizationID, req.Timezone); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			utils.SendNotFound(c, "Organization not found")
+			return
+		}
+		logger.ErrorCtx(c.Request.Context(), "Failed to update organization timezone", logger.ErrorField(err))
+		utils.SendInternalServerError(c)
+		return
+	}
+	logger.Audit(logger.AuditOrganizationTimezoneChanged,
+		logger.String("organization_id", organizationID.String()),
+		logger.String("timezone", req.Timezone),
+		logger.String("user_id", utils.GetUserIDFromContext(c)),
+		logger.String("client_ip", utils.GetClientIP(c)),
+	)
+	utils.SendSuccess(c, req, "Timezone updated successfully")
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+// ActivityDto is an entry of the activity feed of an organization, such as an incident
+// opening or a member editing a monitor. Type is the audit event, e.g. incident.opened or
+// monitor.updated, and Category the part before its dot. ActorID is the member who acted,
+// empty for changes made by the application or by operators. Details holds the fields the
+// event was recorded with, such as incident_id or name.
+type ActivityDto struct {
+	ID         int64          `json:"id"`
+	Type       string         `json:"type"`
+	Category   string         `json:"category"`
+	ActorID    string         `json:"actor_id"`
+	Details    map[string]any `json:"details"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
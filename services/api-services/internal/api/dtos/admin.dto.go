@@ -0,0 +1,18 @@
+package dtos
+
+// SetLogLevelRequestDto changes the log level at runtime. With a duration, such as "15m",
+// the previous level comes back once it passes.
+type SetLogLevelRequestDto struct {
+	Level    string `json:"level" binding:"required,oneof=debug info warn error dpanic panic fatal"`
+	Duration string `json:"duration"`
+}
+
+// SetRetentionPolicyRequestDto overrides how many days the data of an organization is
+// kept. A missing or null number keeps the default for that data. Rollups cannot outlive
+// the two year TTL of their ClickHouse table; incidents are kept in Postgres for up to
+// IncidentsDays, and their analytics records for at most the same two years.
+type SetRetentionPolicyRequestDto struct {
+	CheckResultsDays *int `json:"check_results_days" binding:"omitempty,min=1,max=3650"`
+	RollupsDays      *int `json:"rollups_days" binding:"omitempty,min=1,max=730"`
+	IncidentsDays    *int `json:"incidents_days" binding:"omitempty,min=1,max=3650"`
+}
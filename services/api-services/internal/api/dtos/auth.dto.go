@@ -22,6 +22,11 @@ type SignUpRequestDto struct {
     LastName  string `json:"last_name" validate:"required"`
     Email     string `json:"email" validate:"required,email"`
     Password  string `json:"password" validate:"required,min=8"`
+    // Locale is the language of the notifications sent to the user; the Accept-Language
+    // header is used when it is empty
+    Locale    string `json:"locale,omitempty"`
+    // Timezone is the IANA time zone times are displayed to the user in, UTC when empty
+    Timezone  string `json:"timezone,omitempty" validate:"omitempty,timezone"`
 }
 
 type SignUpResponseDto struct{}
@@ -40,3 +45,7 @@ type VerifyEmailRequest struct {
     Email string `json:"email" validate:"required,email"`
     OTP   string `json:"otp" validate:"required"`
 }
+
+type VerifyPhoneRequest struct {
+    OTP string `json:"otp" validate:"required"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+// CreateChatIntegrationRequestDto creates an integration receiving the slash commands of
+// the Slack or Discord app of an organization. VerificationKey is the signing secret of the
+// Slack app, or the hex encoded public key of the Discord application.
+type CreateChatIntegrationRequestDto struct {
+	Name            string `json:"name" binding:"required,min=1,max=100"`
+	Platform        string `json:"platform" binding:"required,oneof=slack discord"`
+	VerificationKey string `json:"verification_key" binding:"required,max=255"`
+}
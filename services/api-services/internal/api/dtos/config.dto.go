@@ -0,0 +1,59 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// Configuration sync plan actions
+const (
+	ConfigActionCreate = "create"
+	ConfigActionUpdate = "update"
+	ConfigActionDelete = "delete"
+)
+
+// OrganizationConfigDto is the declarative configuration of an organization. Syncing it
+// makes the organization match it: monitors it lists are created or updated, and monitors
+// it does not list are deleted, so an empty list deletes every monitor. Monitors are
+// matched by name, which must be unique.
+type OrganizationConfigDto struct {
+	Monitors []MonitorConfigDto `json:"monitors" binding:"required,max=1000,dive"`
+}
+
+// MonitorConfigDto is the desired state of a monitor in an OrganizationConfigDto. An
+// omitted interval, timeout or regions list stands for the default of the organization
+// settings, for monitors that exist as well as new ones.
+type MonitorConfigDto struct {
+	Name            string   `json:"name" binding:"required,min=1,max=100"`
+	Type            string   `json:"type" binding:"required,oneof=http tcp ping"`
+	Target          string   `json:"target" binding:"required,min=1,max=2048"`
+	IntervalSeconds int      `json:"interval_seconds" binding:"omitempty,min=10,max=86400"`
+	TimeoutSeconds  int      `json:"timeout_seconds" binding:"omitempty,min=1,max=300"`
+	Regions         []string `json:"regions" binding:"omitempty,max=20,dive,min=1,max=50"`
+	Paused          bool     `json:"paused"`
+}
+
+// ConfigPlanDto lists the changes syncing a configuration makes, or made when it was
+// applied
+type ConfigPlanDto struct {
+	DryRun    bool              `json:"dry_run"`
+	Applied   bool              `json:"applied"`
+	Changes   []ConfigChangeDto `json:"changes"`
+	Create    int               `json:"create"`
+	Update    int               `json:"update"`
+	Delete    int               `json:"delete"`
+	Unchanged int               `json:"unchanged"`
+}
+
+// ConfigChangeDto is a change of a ConfigPlanDto. ID is empty for monitors to create, and
+// Fields lists the values an update changes.
+type ConfigChangeDto struct {
+	Action   string                        `json:"action"`
+	Resource string                        `json:"resource"`
+	Name     string                        `json:"name"`
+	ID       *uuid.UUID                    `json:"id,omitempty"`
+	Fields   map[string]ConfigFieldDiffDto `json:"fields,omitempty"`
+}
+
+// ConfigFieldDiffDto is the current and desired value of a field
+type ConfigFieldDiffDto struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
@@ -0,0 +1,50 @@
+package dtos
+
+import "github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+
+// DiscoverMonitorsRequestDto asks for the monitors proposed for a site or API. URL is the
+// root of the site or the URL of its sitemap or OpenAPI document.
+type DiscoverMonitorsRequestDto struct {
+	URL    string `json:"url" binding:"required,url,max=2048"`
+	Source string `json:"source" binding:"omitempty,oneof=auto sitemap openapi"`
+	Limit  int    `json:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// DiscoveredMonitorDto is a monitor proposed for a discovered endpoint. Exists is set when
+// the organization already monitors its target.
+type DiscoveredMonitorDto struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	Exists bool   `json:"exists"`
+}
+
+// MonitorDiscoveryDto is the preview of a discovery: the document read and the monitors
+// proposed, to be edited and sent back to import them
+type MonitorDiscoveryDto struct {
+	Source      string                 `json:"source"`
+	DocumentURL string                 `json:"document_url"`
+	Monitors    []DiscoveredMonitorDto `json:"monitors"`
+	Truncated   bool                   `json:"truncated"`
+}
+
+// ImportMonitorsRequestDto creates the monitors picked from a discovery preview
+type ImportMonitorsRequestDto struct {
+	Monitors []ImportMonitorDto `json:"monitors" binding:"required,min=1,max=200,dive"`
+}
+
+// ImportMonitorDto is an HTTP monitor to import. The interval, timeout and regions default
+// to the ones of the organization settings.
+type ImportMonitorDto struct {
+	Name            string `json:"name" binding:"required,min=1,max=100"`
+	Target          string `json:"target" binding:"required,http_url,max=2048"`
+	IntervalSeconds int    `json:"interval_seconds" binding:"omitempty,min=10,max=86400"`
+	TimeoutSeconds  int    `json:"timeout_seconds" binding:"omitempty,min=1,max=300"`
+}
+
+// ImportMonitorsResultDto lists the monitors an import created. Monitors whose target the
+// organization already monitors are skipped.
+type ImportMonitorsResultDto struct {
+	Created []models.Monitor `json:"created"`
+	Skipped []string         `json:"skipped"`
+}
@@ -0,0 +1,26 @@
+package dtos
+
+import "github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+
+// CreateInboundIntegrationRequestDto creates an inbound integration receiving the alert
+// webhooks of an external monitoring tool
+type CreateInboundIntegrationRequestDto struct {
+	Name     string `json:"name" binding:"required,min=1,max=100"`
+	Provider string `json:"provider" binding:"required,oneof=alertmanager grafana uptimerobot"`
+}
+
+// CreatedInboundIntegrationDto is a new inbound integration with its webhook token, which is
+// only ever returned in this response
+type CreatedInboundIntegrationDto struct {
+	models.InboundIntegration
+	Token string `json:"token"`
+}
+
+// InboundAlertResultDto reports what a received webhook did: how many of its alerts opened
+// or resolved an incident, and how many changed nothing, such as repeated notifications
+type InboundAlertResultDto struct {
+	Received int `json:"received"`
+	Opened   int `json:"opened"`
+	Resolved int `json:"resolved"`
+	Ignored  int `json:"ignored"`
+}
@@ -0,0 +1,19 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// AssignIncidentRequestDto assigns an incident to a member of its organization. A null
+// assignee unassigns it. Version must be the version the client last read; the assignment
+// is rejected when the incident changed since.
+type AssignIncidentRequestDto struct {
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	Version    int64      `json:"version" binding:"required,min=1"`
+}
+
+// TagIncidentCauseRequestDto tags a resolved incident with its root cause. An empty cause
+// untags it. Version must be the version the client last read; the tag is rejected when
+// the incident changed since.
+type TagIncidentCauseRequestDto struct {
+	Cause   string `json:"cause" binding:"omitempty,oneof=deploy dns provider_outage network certificate capacity configuration other"`
+	Version int64  `json:"version" binding:"required,min=1"`
+}
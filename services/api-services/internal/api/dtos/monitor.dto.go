@@ -0,0 +1,46 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// UpdateMonitorRequestDto changes the editable settings of a monitor. Omitted fields are
+// left unchanged. Version must be the version the client last read; the update is
+// rejected with 409 Conflict when the monitor was modified since.
+type UpdateMonitorRequestDto struct {
+	Version         int64   `json:"version" binding:"required,min=1"`
+	Name            *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Target          *string `json:"target" binding:"omitempty,min=1,max=2048"`
+	IntervalSeconds *int    `json:"interval_seconds" binding:"omitempty,min=10,max=86400"`
+	TimeoutSeconds  *int    `json:"timeout_seconds" binding:"omitempty,min=1,max=300"`
+	// Regions replaces the regions of the monitor when given; an empty list checks it from
+	// every region
+	Regions []string `json:"regions" binding:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// SetMonitorOwnerRequestDto replaces the owner of a monitor. The owning user must be a
+// member of the organization; omitting both fields leaves the monitor without owner.
+type SetMonitorOwnerRequestDto struct {
+	OwnerUserID *uuid.UUID `json:"owner_user_id"`
+	OwnerTeam   string     `json:"owner_team" binding:"max=100"`
+}
+
+// SetMonitorDependenciesRequestDto replaces the monitors a monitor depends on. An empty
+// list removes every dependency.
+type SetMonitorDependenciesRequestDto struct {
+	DependsOn []uuid.UUID `json:"depends_on" binding:"max=20"`
+}
+
+// MonitorDependenciesDto lists the monitors a monitor depends on and the ones depending on it
+type MonitorDependenciesDto struct {
+	MonitorID  uuid.UUID   `json:"monitor_id"`
+	DependsOn  []uuid.UUID `json:"depends_on"`
+	Dependents []uuid.UUID `json:"dependents"`
+}
+
+// ValidateMonitorRequestDto is the check settings of a monitor to try once without saving
+// it. The timeout defaults to the built-in monitor timeout and is capped lower than the one
+// of monitors, since the request waits for the check.
+type ValidateMonitorRequestDto struct {
+	Type           string `json:"type" binding:"required,oneof=http tcp ping"`
+	Target         string `json:"target" binding:"required,min=1,max=2048"`
+	TimeoutSeconds *int   `json:"timeout_seconds" binding:"omitempty,min=1,max=30"`
+}
@@ -0,0 +1,35 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+)
+
+// UpdateOrganizationSettingsRequestDto replaces the settings of an organization. Omitted
+// fields fall back to the built-in defaults: checks every 60 seconds with a 10 second
+// timeout from every region, and no escalation.
+type UpdateOrganizationSettingsRequestDto struct {
+	DefaultIntervalSeconds  *int     `json:"default_interval_seconds" binding:"omitempty,min=10,max=86400"`
+	DefaultTimeoutSeconds   *int     `json:"default_timeout_seconds" binding:"omitempty,min=1,max=300"`
+	DefaultRegions          []string `json:"default_regions" binding:"omitempty,max=20,dive,min=1,max=50"`
+	EscalationAfterMinutes  *int     `json:"escalation_after_minutes" binding:"omitempty,min=1,max=10080"`
+	EscalationRepeatMinutes *int     `json:"escalation_repeat_minutes" binding:"omitempty,min=1,max=10080"`
+}
+
+// OrganizationSettingsDto is the settings of an organization with the built-in defaults
+// applied. The data retention is set by operators and read-only here.
+type OrganizationSettingsDto struct {
+	OrganizationID  uuid.UUID              `json:"organization_id"`
+	MonitorDefaults models.MonitorDefaults `json:"monitor_defaults"`
+	Escalation      EscalationSettingsDto  `json:"escalation"`
+	DataRetention   models.DataRetention   `json:"data_retention"`
+	// Custom reports whether the organization overrides a built-in default
+	Custom bool `json:"custom"`
+}
+
+// EscalationSettingsDto is how the alerts of the incidents of an organization escalate.
+// Zero minutes disables escalation, or repeating it.
+type EscalationSettingsDto struct {
+	AfterMinutes  int `json:"after_minutes"`
+	RepeatMinutes int `json:"repeat_minutes"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+// ProbeNetworkDto is the status of the probe network: of every region, and of every probe
+type ProbeNetworkDto struct {
+	Regions []ProbeRegionDto `json:"regions"`
+	Probes  []ProbeDto       `json:"probes"`
+}
+
+// ProbeRegionDto is the status of a probe region: online when all its probes are, offline
+// when none is and degraded otherwise. LastSeenAt is when a probe of the region last
+// reported results.
+type ProbeRegionDto struct {
+	Region       string    `json:"region"`
+	Status       string    `json:"status"`
+	Probes       int       `json:"probes"`
+	OnlineProbes int       `json:"online_probes"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
+// ProbeDto is the status of a probe and when it last reported results
+type ProbeDto struct {
+	ProbeID    string    `json:"probe_id"`
+	Region     string    `json:"region"`
+	Status     string    `json:"status"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
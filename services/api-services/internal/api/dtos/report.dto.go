@@ -0,0 +1,19 @@
+package dtos
+
+// ReportSubscriptionRequestDto opts the caller in to uptime report emails of an organization
+type ReportSubscriptionRequestDto struct {
+	Frequency string `json:"frequency" binding:"required,oneof=weekly monthly"`
+}
+
+// UnsubscribeReportRequestDto opts out the subscriber of the report the token was sent with
+type UnsubscribeReportRequestDto struct {
+	Token string `json:"token" binding:"required,len=64,hexadecimal"`
+}
+
+// GenerateReportRequestDto requests an uptime report file of the organization covering the
+// days from From to To, both included, as YYYY-MM-DD dates in the time zone of the organization
+type GenerateReportRequestDto struct {
+	Format string `json:"format" binding:"required,oneof=pdf csv"`
+	From   string `json:"from" binding:"required,datetime=2006-01-02"`
+	To     string `json:"to" binding:"required,datetime=2006-01-02"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// CreateSLATargetRequestDto defines an SLA target of an organization. It covers one
+// monitor, the monitors of one environment, or every monitor when neither is set.
+type CreateSLATargetRequestDto struct {
+	Name          string     `json:"name" binding:"required,min=1,max=100"`
+	Target        float64    `json:"target" binding:"required,gt=0,lt=100"`
+	Period        string     `json:"period" binding:"required,oneof=weekly monthly"`
+	MonitorID     *uuid.UUID `json:"monitor_id" binding:"omitempty,excluded_with=EnvironmentID"`
+	EnvironmentID *uuid.UUID `json:"environment_id"`
+}
+
+// UpdateSLATargetRequestDto changes an SLA target. Omitted fields are left unchanged; the
+// monitors a target covers cannot change.
+type UpdateSLATargetRequestDto struct {
+	Name   *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Target *float64 `json:"target" binding:"omitempty,gt=0,lt=100"`
+	Period *string  `json:"period" binding:"omitempty,oneof=weekly monthly"`
+}
@@ -0,0 +1,23 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+)
+
+// CreateStatusTokenRequestDto creates a read-only status token for monitors of an
+// organization. The token never expires when ExpiresAt is omitted.
+type CreateStatusTokenRequestDto struct {
+	Name       string      `json:"name" binding:"required,min=1,max=100"`
+	MonitorIDs []uuid.UUID `json:"monitor_ids" binding:"required,min=1,max=100"`
+	ExpiresAt  *time.Time  `json:"expires_at"`
+}
+
+// CreatedStatusTokenDto is a new status token with its secret, which is only ever returned
+// in this response
+type CreatedStatusTokenDto struct {
+	models.StatusToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// CreateTicketIntegrationRequestDto creates an integration filing the incidents of an
+// organization in its Jira project or Linear team. Jira integrations give the site of the
+// account, such as https://example.atlassian.net, the email of the user the API token
+// belongs to and the key of the project; Linear integrations give an API key as the token
+// and the ID of the team as the project. With AutoCreate every incident opened is filed.
+type CreateTicketIntegrationRequestDto struct {
+	Name       string `json:"name" binding:"required,min=1,max=100"`
+	Provider   string `json:"provider" binding:"required,oneof=jira linear"`
+	BaseURL    string `json:"base_url" binding:"required_if=Provider jira,omitempty,url,max=255"`
+	Email      string `json:"email" binding:"required_if=Provider jira,omitempty,email,max=255"`
+	APIToken   string `json:"api_token" binding:"required,max=512"`
+	Project    string `json:"project" binding:"required,max=100"`
+	IssueType  string `json:"issue_type" binding:"max=50"`
+	AutoCreate bool   `json:"auto_create"`
+}
+
+// CreateIncidentTicketRequestDto files an incident through a ticket integration of its organization
+type CreateIncidentTicketRequestDto struct {
+	IntegrationID uuid.UUID `json:"integration_id" binding:"required"`
+}
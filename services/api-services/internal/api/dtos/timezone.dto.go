@@ -0,0 +1,6 @@
+package dtos
+
+// TimezoneDto is the IANA time zone of a user or an organization, e.g. Europe/Paris
+type TimezoneDto struct {
+	Timezone string `json:"timezone" binding:"required,timezone"`
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// AdminTokenMiddleware only lets through requests carrying token as a bearer token. It
+// guards operator endpoints that are not tied to a user account.
+func AdminTokenMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			logger.Warn("Rejected admin request", logger.String("path", c.FullPath()), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendUnauthorized(c)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
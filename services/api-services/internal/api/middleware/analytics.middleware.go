@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/analytics"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+)
+
+// AnalyticsMiddleware records every request handled by the group into the analytics recorder.
+// A nil recorder makes the middleware a no-op.
+func AnalyticsMiddleware(recorder *analytics.Recorder) gin.HandlerFunc {
+	if recorder == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		entry := models.RequestLog{
+			Timestamp: start.UTC(),
+			RequestID: utils.GetRequestID(c),
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Status:    int32(c.Writer.Status()),
+			LatencyMs: time.Since(start).Milliseconds(),
+			BytesOut:  int64(max(c.Writer.Size(), 0)),
+			ClientIP:  utils.GetClientIP(c),
+			UserAgent: utils.GetUserAgent(c),
+		}
+		if organizationID, ok := utils.GetOrganizationID(c); ok {
+			entry.OrganizationID = organizationID
+		}
+		if userID, err := uuid.Parse(utils.GetUserIDFromContext(c)); err == nil {
+			entry.UserID = userID
+		}
+		if apiKeyID, ok := c.Get(string(common.APIKeyIDContextKey)); ok {
+			entry.APIKeyID, _ = apiKeyID.(string)
+		}
+
+		recorder.Record(entry)
+	}
+}
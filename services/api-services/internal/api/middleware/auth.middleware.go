@@ -8,20 +8,20 @@ import (
 	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
 )
 
-// AuthMiddleware is a Gin middleware that verifies JWT authentication.
-func AuthMiddleware(appKey string) gin.HandlerFunc {
+// AuthMiddleware is a Gin middleware that verifies JWT authentication against any of the application keys.
+func AuthMiddleware(appKeys []string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr := security.ExtractTokenFromHeader(c)
 		if tokenStr == "" {
-			utils.SendUnauthorizedWithDetail(c, "Missing or invalid Authorization header", "Authorization header is required")
+			utils.SendUnauthorizedWithDetail(c, "MISSING_AUTHORIZATION_HEADER", "Authorization header is required")
 			c.Abort()
 			return
 		}
 
-		payload, err := security.VerifyToken(tokenStr, appKey)
+		payload, err := security.VerifyToken(tokenStr, appKeys...)
 		if err != nil {
 			logger.Warn("Invalid JWT token", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
-			utils.SendUnauthorizedWithDetail(c, "Invalid or expired token", "Token is either invalid or expired")
+			utils.SendUnauthorizedWithDetail(c, "INVALID_TOKEN", "Token is either invalid or expired")
 			c.Abort()
 			return
 		}
@@ -34,7 +34,7 @@ func AuthMiddleware(appKey string) gin.HandlerFunc {
 }
 
 // OptionalAuthMiddleware is a Gin middleware that verifies JWT if present, but allows unauthenticated requests.
-func OptionalAuthMiddleware(appKey string) gin.HandlerFunc {
+func OptionalAuthMiddleware(appKeys []string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr := security.ExtractTokenFromHeader(c)
 		if tokenStr == "" {
@@ -42,7 +42,7 @@ func OptionalAuthMiddleware(appKey string) gin.HandlerFunc {
 			return
 		}
 
-		payload, err := security.VerifyToken(tokenStr, appKey)
+		payload, err := security.VerifyToken(tokenStr, appKeys...)
 		if err != nil {
 			logger.Warn("Invalid JWT token in optional auth", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
 			c.Next()
@@ -55,3 +55,32 @@ func OptionalAuthMiddleware(appKey string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// WebSocketAuthMiddleware verifies JWT authentication for WebSocket upgrades, accepting the
+// token from the Authorization header or, since browsers cannot set it, the access_token query parameter.
+func WebSocketAuthMiddleware(appKeys []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenStr := security.ExtractTokenFromHeader(c)
+		if tokenStr == "" {
+			tokenStr = security.ExtractTokenFromQuery(c)
+		}
+		if tokenStr == "" {
+			utils.SendUnauthorizedWithDetail(c, "MISSING_ACCESS_TOKEN", "Authorization header or access_token query parameter is required")
+			c.Abort()
+			return
+		}
+
+		payload, err := security.VerifyToken(tokenStr, appKeys...)
+		if err != nil {
+			logger.Warn("Invalid JWT token on WebSocket upgrade", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendUnauthorizedWithDetail(c, "INVALID_TOKEN", "Token is either invalid or expired")
+			c.Abort()
+			return
+		}
+
+		c.Set(string(common.AuthorizationPayloadContextKey), payload)
+		c.Set(string(common.UserIDContextKey), payload.UserID.String())
+
+		c.Next()
+	}
+}
@@ -1 +1,52 @@
 package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// OrganizationParam is the route parameter holding the organization ID.
+const OrganizationParam = "organizationId"
+
+// OrganizationMemberMiddleware ensures the authenticated user belongs to the organization in
+// the route and stores its ID in the context. It must run after AuthMiddleware.
+// The request context is also scoped to the organization so queries on
+// tenant-owned models cannot reach another organization's rows.
+func OrganizationMemberMiddleware(organizationRepository repositories.OrganizationRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetAuthUser(c)
+		if err != nil {
+			c.Abort()
+			return
+		}
+
+		organizationID, err := uuid.Parse(c.Param(OrganizationParam))
+		if err != nil {
+			utils.SendBadRequest(c, "Invalid organization ID")
+			c.Abort()
+			return
+		}
+
+		isMember, err := organizationRepository.IsMember(c.Request.Context(), organizationID, userID)
+		if err != nil {
+			logger.Error("Failed to check organization membership", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+			utils.SendInternalServerError(c)
+			c.Abort()
+			return
+		}
+		if !isMember {
+			utils.SendForbidden(c, "You are not a member of this organization")
+			c.Abort()
+			return
+		}
+
+		c.Set(string(common.OrganizationIDContextKey), organizationID)
+		c.Request = c.Request.WithContext(tenant.WithOrganization(c.Request.Context(), organizationID))
+		c.Next()
+	}
+}
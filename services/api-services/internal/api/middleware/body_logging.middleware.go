@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const redactedValue = "[REDACTED]"
+
+// sensitiveBodyKeys lists JSON keys whose values are never written to the logs.
+// Keys are matched case-insensitively after stripping '_' and '-'.
+var sensitiveBodyKeys = map[string]struct{}{
+	"password":        {},
+	"newpassword":     {},
+	"oldpassword":     {},
+	"token":           {},
+	"accesstoken":     {},
+	"refreshtoken":    {},
+	"otp":             {},
+	"code":            {},
+	"secret":          {},
+	"apikey":          {},
+	"apitoken":        {},
+	"captchatoken":    {},
+	"verificationkey": {},
+}
+
+// sensitiveHeaders lists request headers that are redacted from body logs.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", CaptchaTokenHeader}
+
+// BodyLoggingOptions configures BodyLoggingMiddleware.
+type BodyLoggingOptions struct {
+	Enabled      bool
+	MaxBodyBytes int
+	// SkipPaths holds route templates (as returned by c.FullPath()) that are never body-logged.
+	SkipPaths []string
+}
+
+// bodyLogWriter tees the response body into a bounded buffer.
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body  *bytes.Buffer
+	limit int
+}
+
+func (w *bodyLogWriter) Write(b []byte) (int, error) {
+	if remaining := w.limit - w.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			w.body.Write(b[:remaining])
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// BodyLoggingMiddleware logs request and response bodies at debug level with sensitive fields redacted.
+// It is intended for development troubleshooting; when disabled it is a no-op.
+func BodyLoggingMiddleware(opts BodyLoggingOptions) gin.HandlerFunc {
+	if !opts.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 4096
+	}
+
+	skip := make(map[string]struct{}, len(opts.SkipPaths))
+	for _, path := range opts.SkipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if _, ok := skip[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+
+		var requestBody []byte
+		if c.Request.Body != nil {
+			// Read at most MaxBodyBytes for logging and replay everything to the handler.
+			head := make([]byte, opts.MaxBodyBytes)
+			n, _ := io.ReadFull(c.Request.Body, head)
+			requestBody = head[:n]
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+		}
+
+		writer := &bodyLogWriter{
+			ResponseWriter: c.Writer,
+			body:           &bytes.Buffer{},
+			limit:          opts.MaxBodyBytes,
+		}
+		c.Writer = writer
+
+		c.Next()
+
+		logger.Debug("HTTP exchange",
+			logger.String("request_id", utils.GetRequestID(c)),
+			logger.String("method", c.Request.Method),
+			logger.String("path", c.Request.URL.Path),
+			logger.Any("request_headers", redactHeaders(c)),
+			logger.String("request_body", redactBody(requestBody)),
+			logger.Int("status", c.Writer.Status()),
+			logger.String("response_body", redactBody(writer.body.Bytes())),
+		)
+	}
+}
+
+// redactHeaders returns the request headers with credentials masked.
+func redactHeaders(c *gin.Context) map[string]string {
+	headers := make(map[string]string, len(c.Request.Header))
+	for key, values := range c.Request.Header {
+		headers[key] = strings.Join(values, ",")
+	}
+	for _, key := range sensitiveHeaders {
+		if _, ok := headers[key]; ok {
+			headers[key] = redactedValue
+		}
+	}
+	return headers
+}
+
+// redactBody masks sensitive fields in a JSON body. Non-JSON or truncated bodies are
+// summarised rather than logged, since they cannot be redacted reliably.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "[non-JSON or truncated body omitted]"
+	}
+
+	redacted, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return "[unserializable body omitted]"
+	}
+	return string(redacted)
+}
+
+// redactValue walks a decoded JSON value and replaces sensitive keys in place.
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, inner := range val {
+			if isSensitiveKey(key) {
+				val[key] = redactedValue
+				continue
+			}
+			val[key] = redactValue(inner)
+		}
+		return val
+	case []any:
+		for i, inner := range val {
+			val[i] = redactValue(inner)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// isSensitiveKey normalises a JSON key and checks it against sensitiveBodyKeys.
+func isSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	_, ok := sensitiveBodyKeys[normalized]
+	return ok
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/captcha"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CaptchaTokenHeader is the request header carrying the CAPTCHA challenge response token.
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// CaptchaMiddleware is a Gin middleware that rejects requests without a valid CAPTCHA token.
+// A nil verifier disables the check, so routes can be wired unconditionally.
+func CaptchaMiddleware(verifier captcha.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if verifier == nil {
+			c.Next()
+			return
+		}
+
+		token := c.GetHeader(CaptchaTokenHeader)
+		if token == "" {
+			utils.SendError(c, http.StatusBadRequest, "CAPTCHA_REQUIRED", "CAPTCHA verification is required")
+			c.Abort()
+			return
+		}
+
+		if err := verifier.Verify(c.Request.Context(), token, utils.GetClientIP(c)); err != nil {
+			if errors.Is(err, captcha.ErrVerifyRequest) {
+				logger.Error("CAPTCHA provider unavailable",
+					logger.String("provider", verifier.Name()),
+					logger.String("request_id", utils.GetRequestID(c)),
+					logger.ErrorField(err),
+				)
+				utils.SendError(c, http.StatusServiceUnavailable, "CAPTCHA_UNAVAILABLE", "CAPTCHA verification is temporarily unavailable")
+				c.Abort()
+				return
+			}
+
+			logger.Warn("CAPTCHA verification failed",
+				logger.String("provider", verifier.Name()),
+				logger.String("request_id", utils.GetRequestID(c)),
+				logger.String("client_ip", utils.GetClientIP(c)),
+				logger.ErrorField(err),
+			)
+			utils.SendError(c, http.StatusForbidden, "CAPTCHA_FAILED", "CAPTCHA verification failed")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSMiddleware applies a CORS policy that can be replaced while the server runs,
+// so allowed origins can change without a restart
+type CORSMiddleware struct {
+	handler atomic.Pointer[gin.HandlerFunc]
+}
+
+// NewCORSMiddleware creates a CORS middleware applying cfg
+func NewCORSMiddleware(cfg cors.Config) *CORSMiddleware {
+	m := &CORSMiddleware{}
+	m.Update(cfg)
+	return m
+}
+
+// Update replaces the policy applied to subsequent requests. It panics when cfg is
+// invalid, like cors.New.
+func (m *CORSMiddleware) Update(cfg cors.Config) {
+	handler := cors.New(cfg)
+	m.handler.Store(&handler)
+}
+
+// Handler returns the middleware applying the current policy
+func (m *CORSMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		(*m.handler.Load())(c)
+	}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// DeprecatedRouteUsage is how many requests a deprecated route served
+type DeprecatedRouteUsage struct {
+	Method   string
+	Path     string
+	Sunset   time.Time
+	Requests uint64
+}
+
+// deprecatedRoute is a deprecated route with its response headers and request counter
+type deprecatedRoute struct {
+	route    config.DeprecatedRoute
+	headers  map[string]string
+	requests atomic.Uint64
+}
+
+// Deprecations marks the responses of deprecated routes and counts their requests. It is
+// safe for concurrent use.
+type Deprecations struct {
+	routes map[string]*deprecatedRoute
+}
+
+// NewDeprecations creates the deprecations of routes
+func NewDeprecations(routes []config.DeprecatedRoute) *Deprecations {
+	d := &Deprecations{routes: make(map[string]*deprecatedRoute, len(routes))}
+	for _, route := range routes {
+		// Deprecation is a structured field date (RFC 9745), Sunset an HTTP date (RFC 8594)
+		headers := map[string]string{"Deprecation": fmt.Sprintf("@%d", route.Deprecated.Unix())}
+		if !route.Sunset.IsZero() {
+			headers["Sunset"] = route.Sunset.UTC().Format(http.TimeFormat)
+		}
+		if route.Link != "" {
+			headers["Link"] = fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, route.Link)
+		}
+		d.routes[route.Method+" "+route.Path] = &deprecatedRoute{route: route, headers: headers}
+	}
+	return d
+}
+
+// Middleware sets the deprecation headers on the responses of deprecated routes and counts
+// their requests. Routes are matched by method and registered path, so it must be used on
+// the router rather than on a group that does not contain them all.
+func (d *Deprecations) Middleware() gin.HandlerFunc {
+	if len(d.routes) == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		deprecated, ok := d.routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		for name, value := range deprecated.headers {
+			c.Header(name, value)
+		}
+		deprecated.requests.Add(1)
+		c.Next()
+
+		// Clients of a deprecated route keep calling it, so each route is logged at a limited
+		// rate, once the handlers have run and identified the caller
+		if allowed, suppressed := logger.Allow("deprecated:" + deprecated.route.Method + " " + deprecated.route.Path); allowed {
+			logger.InfoCtx(c.Request.Context(), "Deprecated route called",
+				logger.String("method", deprecated.route.Method),
+				logger.String("route", deprecated.route.Path),
+				logger.String("user_id", utils.GetUserIDFromContext(c)),
+				logger.String("user_agent", c.Request.UserAgent()),
+				logger.Int("suppressed", suppressed),
+			)
+		}
+	}
+}
+
+// Usage returns the request counts of the deprecated routes, sorted by path and method
+func (d *Deprecations) Usage() []DeprecatedRouteUsage {
+	usage := make([]DeprecatedRouteUsage, 0, len(d.routes))
+	for _, deprecated := range d.routes {
+		usage = append(usage, DeprecatedRouteUsage{
+			Method:   deprecated.route.Method,
+			Path:     deprecated.route.Path,
+			Sunset:   deprecated.route.Sunset,
+			Requests: deprecated.requests.Load(),
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Path != usage[j].Path {
+			return usage[i].Path < usage[j].Path
+		}
+		return usage[i].Method < usage[j].Method
+	})
+	return usage
+}
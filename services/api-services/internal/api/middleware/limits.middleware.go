@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitMiddleware rejects request bodies larger than maxBytes with a 413 response.
+// Declared lengths are rejected up front; streamed bodies are capped via http.MaxBytesReader.
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			utils.SendPayloadTooLarge(c, "Request body too large", fmt.Sprintf("maximum allowed size is %d bytes", maxBytes))
+			c.Abort()
+			return
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+
+		c.Next()
+	}
+}
+
+// routeTimeout is the timeout of one route and the requests of it running without a deadline
+type routeTimeout struct {
+	timeout time.Duration
+	exempt  func(c *gin.Context) bool
+}
+
+// RequestTimeouts holds the request timeout of every route: a default one, overridden for
+// single routes. Routes are set up before the router serves requests, which then only read
+// them.
+type RequestTimeouts struct {
+	timeout time.Duration
+	routes  map[string]*routeTimeout
+}
+
+// NewRequestTimeouts creates the request timeouts of timeout, overridden by routes
+func NewRequestTimeouts(timeout time.Duration, routes []config.RouteTimeout) *RequestTimeouts {
+	t := &RequestTimeouts{timeout: timeout, routes: make(map[string]*routeTimeout, len(routes))}
+	for _, route := range routes {
+		t.route(route.Method, route.Path).timeout = route.Timeout
+	}
+	return t
+}
+
+// Exempt runs the requests of the route for which exempt reports true, such as streamed
+// exports, without a deadline. path is the route as it is registered.
+func (t *RequestTimeouts) Exempt(method, path string, exempt func(c *gin.Context) bool) {
+	t.route(method, path).exempt = exempt
+}
+
+// route returns the timeout of the route, adding it with the default timeout when missing
+func (t *RequestTimeouts) route(method, path string) *routeTimeout {
+	key := method + " " + path
+	route, ok := t.routes[key]
+	if !ok {
+		route = &routeTimeout{timeout: t.timeout}
+		t.routes[key] = route
+	}
+	return route
+}
+
+// Middleware attaches the deadline of the route to the request context so downstream calls
+// are cancelled. If the deadline expires before the handler writes a response, a 408 response
+// is sent instead. Handlers must honour c.Request.Context() for the deadline to take effect.
+// Routes are matched by method and registered path, so it must be used on a group containing
+// them.
+func (t *RequestTimeouts) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := t.timeout
+		if route, ok := t.routes[c.Request.Method+" "+c.FullPath()]; ok {
+			if route.exempt != nil && route.exempt(c) {
+				c.Next()
+				return
+			}
+			timeout = route.timeout
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			logger.Warn("Request timed out",
+				logger.String("request_id", utils.GetRequestID(c)),
+				logger.String("method", c.Request.Method),
+				logger.String("path", c.Request.URL.Path),
+				logger.Duration("timeout", timeout),
+			)
+			utils.SendRequestTimeout(c, "Request timed out", fmt.Sprintf("request exceeded %s", timeout))
+			c.Abort()
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware resolves the response locale from the Accept-Language header once per request
+// so API messages and validation errors are translated consistently.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Match(c.GetHeader("Accept-Language"))
+
+		c.Set(string(common.LocaleContextKey), locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
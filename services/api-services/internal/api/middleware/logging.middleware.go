@@ -16,7 +16,7 @@ func LoggingMiddleware() gin.HandlerFunc {
 		requestID := utils.GetRequestID(c)
 		clientIP := utils.GetClientIP(c)
 
-		logger.Info("Request started",
+		logger.InfoCtx(c.Request.Context(), "Request started",
 			logger.String("request_id", requestID),
 			logger.String("method", c.Request.Method),
 			logger.String("path", c.Request.URL.Path),
@@ -34,7 +34,7 @@ func LoggingMiddleware() gin.HandlerFunc {
 			}
 		}
 
-		logger.Info("Request completed",
+		logger.InfoCtx(c.Request.Context(), "Request completed",
 			logger.String("request_id", requestID),
 			logger.String("method", c.Request.Method),
 			logger.String("path", c.Request.URL.Path),
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ResponseCacheHeader tells clients whether a response was served from the response cache
+const ResponseCacheHeader = "X-Cache"
+
+// responseCacheMaxBody is the largest response body kept in the cache
+const responseCacheMaxBody = 256 << 10
+
+// cachedResponse is a response stored by ResponseCache
+type cachedResponse struct {
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// ResponseCache caches the successful responses of the read routes it is used on in
+// Redis, for a short TTL. Entries are keyed by route, query string, locale and auth scope:
+// the organization of the route when it has one, the authenticated user otherwise. Writes
+// bump the generation of their scope, which is part of the keys, so the superseded
+// entries are never read again and simply expire. When the cache is unavailable requests
+// are served as if it were disabled.
+type ResponseCache struct {
+	cache *cache.Service
+	ttl   time.Duration
+}
+
+// NewResponseCache creates a response cache keeping entries for ttl. A nil cacheService or
+// a ttl of zero disables it.
+func NewResponseCache(cacheService *cache.Service, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{cache: cacheService, ttl: ttl}
+}
+
+func (rc *ResponseCache) enabled() bool {
+	return rc != nil && rc.cache != nil && rc.ttl > 0
+}
+
+// Cache serves GET requests from the cache and caches the 200 responses of the ones it
+// misses. Responses marked no-store, such as streamed exports, and requests sent with
+// Cache-Control: no-cache bypass it.
+func (rc *ResponseCache) Cache() gin.HandlerFunc {
+	if !rc.enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || strings.Contains(c.GetHeader("Cache-Control"), "no-cache") {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		scope := rc.scope(c)
+		generation, ok := rc.generation(ctx, scope)
+		if !ok {
+			c.Next()
+			return
+		}
+		key := rc.key(c, scope, generation)
+
+		var cached cachedResponse
+		if err := rc.cache.Get(ctx, key, &cached); err == nil {
+			c.Header(ResponseCacheHeader, "HIT")
+			c.Data(http.StatusOK, cached.ContentType, refreshMeta(c, cached.Body))
+			c.Abort()
+			return
+		}
+
+		c.Header(ResponseCacheHeader, "MISS")
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		header := recorder.Header()
+		if recorder.Status() != http.StatusOK || recorder.overflow || recorder.body.Len() == 0 ||
+			strings.Contains(header.Get("Cache-Control"), "no-store") || header.Get("Set-Cookie") != "" {
+			return
+		}
+		entry := cachedResponse{ContentType: header.Get("Content-Type"), Body: recorder.body.Bytes()}
+		if err := rc.cache.Set(ctx, key, entry, rc.ttl); err != nil {
+			logger.WarnCtx(ctx, "Failed to cache response", logger.String("route", c.FullPath()), logger.ErrorField(err))
+		}
+	}
+}
+
+// Invalidate drops the cached responses of the scope of the request once a write to it
+// succeeded. Reads pass through untouched, so it can be used on a whole route group to
+// cover every write of the scope.
+func (rc *ResponseCache) Invalidate() gin.HandlerFunc {
+	if !rc.enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		c.Next()
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if status := c.Writer.Status(); status >= http.StatusOK && status < http.StatusMultipleChoices {
+			rc.bump(c.Request.Context(), rc.scope(c))
+		}
+	}
+}
+
+// scope returns the auth scope of a request: its organization, its user, or public
+func (rc *ResponseCache) scope(c *gin.Context) string {
+	if organizationID, ok := utils.GetOrganizationID(c); ok {
+		return "org:" + organizationID.String()
+	}
+	if userID, ok := c.Get(string(common.UserIDContextKey)); ok {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return "public"
+}
+
+// key returns the cache key of a request. The query parameters are sorted so that the same
+// query written differently shares an entry.
+func (rc *ResponseCache) key(c *gin.Context, scope string, generation int64) string {
+	query := url.Values(c.Request.URL.Query()).Encode()
+	hash := sha256.Sum256([]byte(c.Request.URL.Path + "?" + query))
+	return fmt.Sprintf("resp:%s:g%d:%s:%s", scope, generation, utils.GetLocale(c), hex.EncodeToString(hash[:16]))
+}
+
+func generationKey(scope string) string {
+	return "resp:generation:" + scope
+}
+
+// generation reads the generation of scope; a missing counter reads as zero. It reports
+// false when the cache cannot be reached.
+func (rc *ResponseCache) generation(ctx context.Context, scope string) (int64, bool) {
+	var value int64
+	if err := rc.cache.Get(ctx, generationKey(scope), &value); err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		return 0, false
+	}
+	return value, true
+}
+
+// bump increments the generation of scope, logging failures since the write itself
+// already succeeded
+func (rc *ResponseCache) bump(ctx context.Context, scope string) {
+	if _, err := rc.cache.Increment(ctx, generationKey(scope)); err != nil {
+		logger.WarnCtx(ctx, "Failed to invalidate response cache", logger.String("scope", scope), logger.ErrorField(err))
+	}
+}
+
+// refreshMeta replaces the request-specific metadata of a cached standard response body with
+// the one of the current request. Other bodies are returned as they are.
+func refreshMeta(c *gin.Context, body []byte) []byte {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return body
+	}
+	var meta utils.Meta
+	if raw, ok := envelope["meta"]; !ok || json.Unmarshal(raw, &meta) != nil {
+		return body
+	}
+
+	meta.RequestID = utils.GetRequestID(c)
+	meta.UserID = utils.GetUserIDFromContext(c)
+	meta.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	meta.DurationMs = 0
+	if start, ok := c.Request.Context().Value(common.RequestStartTimeKey).(time.Time); ok {
+		meta.DurationMs = time.Since(start).Milliseconds()
+	}
+
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return body
+	}
+	envelope["meta"] = raw
+	refreshed, err := json.Marshal(envelope)
+	if err != nil {
+		return body
+	}
+	return refreshed
+}
+
+// responseRecorder copies the body written to a response, up to responseCacheMaxBody
+type responseRecorder struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.record(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.record([]byte(s))
+	return r.ResponseWriter.WriteString(s)
+}
+
+func (r *responseRecorder) record(data []byte) {
+	if r.overflow {
+		return
+	}
+	if r.body.Len()+len(data) > responseCacheMaxBody {
+		r.overflow = true
+		r.body.Reset()
+		return
+	}
+	r.body.Write(data)
+}
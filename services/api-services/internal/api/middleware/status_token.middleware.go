@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
+)
+
+// StatusTokenQueryParam is the query parameter a status token can be passed in when the
+// Authorization header cannot be set, such as in embedded widgets
+const StatusTokenQueryParam = "token"
+
+// StatusTokenAuthenticator resolves the status token of a request
+type StatusTokenAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*models.StatusToken, error)
+}
+
+// StatusTokenMiddleware only lets through requests carrying a valid status token, as a
+// bearer token or in the token query parameter, and stores it in the context. The request
+// context is scoped to the organization of the token.
+func StatusTokenMiddleware(authenticator StatusTokenAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := security.ExtractTokenFromHeader(c)
+		if raw == "" {
+			raw = c.Query(StatusTokenQueryParam)
+		}
+		if raw == "" {
+			utils.SendUnauthorizedWithDetail(c, "MISSING_STATUS_TOKEN", "Authorization header or token query parameter is required")
+			c.Abort()
+			return
+		}
+
+		token, err := authenticator.Authenticate(c.Request.Context(), raw)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidStatusToken) {
+				utils.SendUnauthorizedWithDetail(c, "INVALID_STATUS_TOKEN", "Status token is either invalid, revoked or expired")
+			} else {
+				logger.Error("Failed to authenticate status token", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
+				utils.SendInternalServerError(c)
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(string(common.StatusTokenContextKey), token)
+		c.Set(string(common.APIKeyIDContextKey), token.ID.String())
+		c.Set(string(common.OrganizationIDContextKey), token.OrganizationID)
+		c.Request = c.Request.WithContext(tenant.WithOrganization(c.Request.Context(), token.OrganizationID))
+		c.Next()
+	}
+}
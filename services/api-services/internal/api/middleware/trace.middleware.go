@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// traceparentHeader is the W3C Trace Context header set by traced callers and proxies
+const traceparentHeader = "traceparent"
+
+// TraceMiddleware stores the trace propagated in the "traceparent" header in the request
+// context, so entries logged with that context carry its trace_id and span_id and can be
+// matched with the caller's trace.
+func TraceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if trace, ok := logger.ParseTraceparent(c.GetHeader(traceparentHeader)); ok {
+			c.Request = c.Request.WithContext(logger.ContextWithTrace(c.Request.Context(), trace))
+		}
+		c.Next()
+	}
+}
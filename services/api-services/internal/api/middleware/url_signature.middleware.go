@@ -1,9 +1,8 @@
 package middleware
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
 )
@@ -12,15 +11,15 @@ import (
 // It reconstructs the URL from the request (path + query) and validates it.
 func URLSignatureMiddleware(signer *urlsigner.Signer) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		fullURL := c.Request.URL.String()
+		fullURL := c.Request.URL.RequestURI()
 
 		valid, err := signer.Validate(fullURL)
 		if err != nil || !valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired URL signature"})
-
 			if err != nil {
-				logger.Error("URL validation error", logger.ErrorField(err))
+				logger.Warn("URL validation error", logger.ErrorField(err), logger.String("request_id", utils.GetRequestID(c)))
 			}
+			utils.SendUnauthorizedWithDetail(c, "INVALID_SIGNATURE", "Invalid or expired URL signature")
+			c.Abort()
 			return
 		}
 
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityEntry is an audit event recorded for an organization, listed newest first in
+// its activity feed. IDs increase with every entry so they order and page the feed across
+// replicas. Category is the prefix of the event, e.g. incident for incident.opened.
+type ActivityEntry struct {
+	ID             int64          `json:"id" gorm:"primaryKey;autoIncrement;index:idx_activity_entries_feed,priority:2;index:idx_activity_entries_category,priority:3"`
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null;index:idx_activity_entries_feed,priority:1;index:idx_activity_entries_category,priority:1"`
+	Category       string         `json:"category" gorm:"type:varchar(50);not null;index:idx_activity_entries_category,priority:2"`
+	Event          string         `json:"event" gorm:"type:varchar(100);not null"`
+	ActorID        *uuid.UUID     `json:"actor_id" gorm:"type:uuid"`
+	Details        map[string]any `json:"details" gorm:"type:jsonb;serializer:json"`
+	OccurredAt     time.Time      `json:"occurred_at" gorm:"not null;index"`
+}
+
+// OrganizationOwned marks ActivityEntry rows as belonging to a single organization for tenant scoping.
+func (ActivityEntry) OrganizationOwned() {}
@@ -20,8 +20,12 @@ type Application struct {
 	DeletedAt         gorm.DeletedAt  `json:"deleted_at" gorm:"index"`
 }
 
+// OrganizationOwned marks Application rows as belonging to a single organization for tenant scoping.
+func (Application) OrganizationOwned() {}
+
 type ApplicationType struct {
 	Model
-	Name        string  `json:"name" gorm:"type:varchar(100);not null"`
+	Name        string  `json:"name" gorm:"type:varchar(100);unique;not null"`
 	Description *string `json:"description" gorm:"type:varchar(100);not null"`
+	IsSystem    bool    `json:"is_system" gorm:"not null;default:false"` // managed by the seeder
 }
@@ -10,15 +10,20 @@ type Role struct {
 	Model
 	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null;index"`
 	Name           string         `json:"name" gorm:"type:varchar(50);not null"`
+	IsSystem       bool           `json:"is_system" gorm:"not null;default:false"` // managed by the seeder
 	Permissions    []Permission   `json:"permissions" gorm:"many2many:role_permissions;"`
 	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
+// OrganizationOwned marks Role rows as belonging to a single organization for tenant scoping.
+func (Role) OrganizationOwned() {}
+
 // Permission represents an action a role or user can perform.
 type Permission struct {
 	Model
 	Name        string         `json:"name" gorm:"type:varchar(50);unique;not null"` // e.g., "user:assign"
 	Description *string        `json:"description,omitempty" gorm:"type:text"`
+	IsSystem    bool           `json:"is_system" gorm:"not null;default:false"` // managed by the seeder
 	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
@@ -52,3 +57,6 @@ type Policy struct {
 	Effect         string                 `json:"effect" gorm:"type:varchar(10);not null"`
 	DeletedAt      gorm.DeletedAt         `json:"deleted_at" gorm:"index"`
 }
+
+// OrganizationOwned marks Policy rows as belonging to a single organization for tenant scoping.
+func (Policy) OrganizationOwned() {}
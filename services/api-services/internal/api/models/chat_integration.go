@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatIntegration receives the slash commands of the Slack or Discord app of an
+// organization, such as "/uptime status payments". Requests are authenticated by the
+// signature of the platform, verified with the signing secret of the Slack app or the public
+// key of the Discord application; the key is encrypted at rest. Deleting an integration
+// soft deletes it.
+type ChatIntegration struct {
+	Model
+	OrganizationID  uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null;index"`
+	Name            string         `json:"name" gorm:"type:varchar(100);not null"`
+	Platform        string         `json:"platform" gorm:"type:varchar(20);not null"`
+	VerificationKey string         `json:"-" gorm:"type:varchar(512);not null;serializer:encrypted"`
+	CreatedBy       uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	LastReceivedAt  *time.Time     `json:"last_received_at" gorm:"default:null"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// OrganizationOwned marks ChatIntegration rows as belonging to a single organization for tenant scoping.
+func (ChatIntegration) OrganizationOwned() {}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CheckResult is a single check outcome reported by a probe. It is stored in ClickHouse, or
+// in a partitioned Postgres table when ClickHouse is disabled, so it does not embed Model
+// (Postgres-specific UUID defaults) and has no soft delete.
+type CheckResult struct {
+	MonitorID      uuid.UUID `json:"monitor_id" gorm:"type:UUID"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:UUID"`
+	ProbeID        string    `json:"probe_id" gorm:"type:LowCardinality(String)"`
+	Region         string    `json:"region" gorm:"type:LowCardinality(String)"`
+	Status         string    `json:"status" gorm:"type:LowCardinality(String)"`
+	LatencyMs      int64     `json:"latency_ms" gorm:"type:Int64"`
+	StatusCode     int32     `json:"status_code" gorm:"type:Int32"`
+	Error          string    `json:"error,omitempty" gorm:"type:String"`
+	CheckedAt      time.Time `json:"checked_at" gorm:"type:DateTime64(3, 'UTC')"`
+}
+
+// OrganizationOwned marks CheckResult rows as belonging to a single organization for tenant scoping.
+func (CheckResult) OrganizationOwned() {}
+
+// TableOptions returns the ClickHouse engine clause used when migrating the table.
+func (CheckResult) TableOptions() string {
+	return "ENGINE = MergeTree() PARTITION BY toYYYYMM(checked_at) ORDER BY (organization_id, monitor_id, checked_at)"
+}
+
+// CheckResultCursor is the position of a check result in the newest first order results
+// are listed in. Results of a monitor checked at the same instant are told apart by probe.
+type CheckResultCursor struct {
+	CheckedAt time.Time `json:"checked_at"`
+	ProbeID   string    `json:"probe_id"`
+}
+
+// Cursor returns the position of the result
+func (r *CheckResult) Cursor() CheckResultCursor {
+	return CheckResultCursor{CheckedAt: r.CheckedAt, ProbeID: r.ProbeID}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// EmailSuppression is an address that hard-bounced, complained about our mail or
+// unsubscribed. The email service refuses to send to suppressed addresses.
+type EmailSuppression struct {
+	Model
+	Email      string    `json:"email" gorm:"type:varchar(320);not null;uniqueIndex"`
+	Reason     string    `json:"reason" gorm:"type:varchar(20);not null"` // bounce, complaint or unsubscribe
+	Provider   string    `json:"provider" gorm:"type:varchar(50);not null"`
+	Detail     *string   `json:"detail" gorm:"type:text"`
+	OccurredAt time.Time `json:"occurred_at" gorm:"not null"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Uptime report file formats
+const (
+	ReportFormatPDF = "pdf"
+	ReportFormatCSV = "csv"
+)
+
+// Generated report statuses
+const (
+	ReportStatusPending = "pending"
+	ReportStatusReady   = "ready"
+	ReportStatusFailed  = "failed"
+)
+
+// GeneratedReport is an uptime report file a member requested for a range of days. The
+// report generation job renders pending reports into the storage driver; ready reports
+// are downloaded through signed links until ExpiresAt, when the file is deleted.
+type GeneratedReport struct {
+	Model
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;index"`
+	RequestedBy    *uuid.UUID `json:"requested_by" gorm:"type:uuid;index"`
+	Format         string     `json:"format" gorm:"type:varchar(10);not null"`
+	// From and To are the first and last days covered
+	From        time.Time  `json:"from" gorm:"type:date;not null"`
+	To          time.Time  `json:"to" gorm:"type:date;not null"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	Attempts    int        `json:"-" gorm:"not null;default:0"`
+	Error       *string    `json:"error" gorm:"type:text"`
+	StorageKey  *string    `json:"-" gorm:"type:varchar(255)"`
+	Size        int64      `json:"size" gorm:"not null;default:0"`
+	CompletedAt *time.Time `json:"completed_at" gorm:"default:null"`
+	ExpiresAt   *time.Time `json:"expires_at" gorm:"default:null;index"`
+
+	// DownloadURL is the signed link to the file of a ready report, set when it is read
+	DownloadURL string `json:"download_url,omitempty" gorm:"-"`
+}
+
+// OrganizationOwned marks GeneratedReport rows as belonging to a single organization for tenant scoping.
+func (GeneratedReport) OrganizationOwned() {}
+
+// PendingReport is a report waiting to be generated, with the name and time zone of the
+// organization it is about
+type PendingReport struct {
+	GeneratedReport
+	OrganizationName     string
+	OrganizationTimezone string
+}
+
+// FileName returns the name the report file is downloaded as
+func (r *GeneratedReport) FileName() string {
+	return "uptime-report-" + r.From.Format(time.DateOnly) + "-" + r.To.Format(time.DateOnly) + "." + r.Format
+}
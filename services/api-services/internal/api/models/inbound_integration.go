@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InboundIntegration receives the alert webhooks of an external monitoring tool and turns
+// them into incidents of its organization. Webhooks authenticate with its token, of which
+// only the SHA-256 hash is stored; the token itself is returned once, when it is created.
+// Deleting an integration soft deletes it.
+type InboundIntegration struct {
+	Model
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null;index"`
+	Name           string         `json:"name" gorm:"type:varchar(100);not null"`
+	Provider       string         `json:"provider" gorm:"type:varchar(20);not null"`
+	TokenHash      string         `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	TokenPrefix    string         `json:"token_prefix" gorm:"type:varchar(16);not null"`
+	CreatedBy      uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	LastReceivedAt *time.Time     `json:"last_received_at" gorm:"default:null"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// OrganizationOwned marks InboundIntegration rows as belonging to a single organization for tenant scoping.
+func (InboundIntegration) OrganizationOwned() {}
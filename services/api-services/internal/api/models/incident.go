@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Incident statuses
+const (
+	IncidentStatusOpen     = "open"
+	IncidentStatusResolved = "resolved"
+)
+
+// IncidentRefPrefix and IncidentRefLength make up the short reference of incidents: the
+// prefix followed by the first hex digits of their ID
+const (
+	IncidentRefPrefix = "INC-"
+	IncidentRefLength = 8
+)
+
+// Sources of the incidents opened by the application itself: monitors going down, and
+// monitors spending the error budget of an SLA target too fast
+const (
+	IncidentSourceMonitor = "monitor"
+	IncidentSourceSLA     = "sla"
+)
+
+// Root causes resolved incidents are tagged with
+const (
+	IncidentCauseDeploy         = "deploy"
+	IncidentCauseDNS            = "dns"
+	IncidentCauseProviderOutage = "provider_outage"
+	IncidentCauseNetwork        = "network"
+	IncidentCauseCertificate    = "certificate"
+	IncidentCauseCapacity       = "capacity"
+	IncidentCauseConfiguration  = "configuration"
+	IncidentCauseOther          = "other"
+)
+
+// Incident is an outage or degradation an organization is tracking. Incidents raised by an
+// inbound integration carry its source and the fingerprint the external tool identifies the
+// alert by, so that repeated and resolving notifications update the same incident.
+// Incidents of monitors are fingerprinted by monitor. The incident of a monitor that went
+// down while a monitor it depends on was down is suppressed: it is not alerted on and is
+// grouped under the incident of that monitor, its parent. Incidents of monitors are
+// assigned to the owner of the monitor and carry its team. The version is bumped by the
+// edits of members, such as assigning the incident or tagging its cause; the application
+// opening and resolving incidents leaves it alone.
+type Incident struct {
+	Model
+	Versioning
+	OrganizationID uuid.UUID         `json:"organization_id" gorm:"type:uuid;not null;index:idx_incidents_fingerprint,priority:1"`
+	IntegrationID  *uuid.UUID        `json:"integration_id" gorm:"type:uuid;index"`
+	MonitorID      *uuid.UUID        `json:"monitor_id" gorm:"type:uuid;index"`
+	ParentID       *uuid.UUID        `json:"parent_id" gorm:"type:uuid;index"`
+	Suppressed     bool              `json:"suppressed" gorm:"not null;default:false"`
+	AssigneeID     *uuid.UUID        `json:"assignee_id" gorm:"type:uuid;index"`
+	Team           string            `json:"team" gorm:"type:varchar(100);not null;default:''"`
+	Source         string            `json:"source" gorm:"type:varchar(20);not null;index:idx_incidents_fingerprint,priority:2"`
+	Fingerprint    string            `json:"fingerprint" gorm:"type:varchar(255);not null;index:idx_incidents_fingerprint,priority:3"`
+	Title          string            `json:"title" gorm:"type:varchar(255);not null"`
+	Description    string            `json:"description" gorm:"type:text;not null;default:''"`
+	Severity       string            `json:"severity" gorm:"type:varchar(20);not null;default:'critical'"`
+	Status         string            `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	URL            string            `json:"url" gorm:"type:varchar(2048);not null;default:''"`
+	Labels         map[string]string `json:"labels" gorm:"type:jsonb;serializer:json"`
+	StartedAt      time.Time         `json:"started_at" gorm:"not null"`
+	ResolvedAt     *time.Time        `json:"resolved_at" gorm:"default:null"`
+	// AcknowledgedBy names who acknowledged the incident, such as a chat user
+	AcknowledgedAt *time.Time `json:"acknowledged_at" gorm:"default:null"`
+	AcknowledgedBy string     `json:"acknowledged_by" gorm:"type:varchar(255);not null;default:''"`
+	// Hints are the root-cause hints noted when the incident opened, such as probe outages
+	Hints []string `json:"hints" gorm:"type:jsonb;serializer:json"`
+	// Cause is the root cause the incident was tagged with once resolved, empty when untagged
+	Cause string `json:"cause" gorm:"type:varchar(30);not null;default:'';index"`
+}
+
+// Ref is the short reference of the incident shown in chat, such as INC-1A2B3C4D
+func (i *Incident) Ref() string {
+	return IncidentRefPrefix + strings.ToUpper(i.ID.String()[:IncidentRefLength])
+}
+
+// OrganizationOwned marks Incident rows as belonging to a single organization for tenant scoping.
+func (Incident) OrganizationOwned() {}
+
+// IncidentWorkload is how many incidents of an organization are open for an assignee, nil
+// for the unassigned ones, and when the oldest of them started
+type IncidentWorkload struct {
+	AssigneeID      *uuid.UUID `json:"assignee_id"`
+	OpenIncidents   int64      `json:"open_incidents"`
+	OldestStartedAt time.Time  `json:"oldest_started_at"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IncidentRecord is a resolved incident as recorded for analytics. It is stored in
+// ClickHouse, where a later record of the same incident, such as once it is tagged with its
+// cause, replaces the earlier ones; reads use FINAL. MonitorID is uuid.Nil for incidents not
+// opened by a monitor and Cause is empty for untagged incidents.
+type IncidentRecord struct {
+	IncidentID      uuid.UUID `json:"incident_id" gorm:"type:UUID"`
+	OrganizationID  uuid.UUID `json:"organization_id" gorm:"type:UUID"`
+	MonitorID       uuid.UUID `json:"monitor_id" gorm:"type:UUID"`
+	Source          string    `json:"source" gorm:"type:LowCardinality(String)"`
+	Severity        string    `json:"severity" gorm:"type:LowCardinality(String)"`
+	Cause           string    `json:"cause" gorm:"type:LowCardinality(String)"`
+	StartedAt       time.Time `json:"started_at" gorm:"type:DateTime64(3, 'UTC')"`
+	ResolvedAt      time.Time `json:"resolved_at" gorm:"type:DateTime64(3, 'UTC')"`
+	DurationSeconds int64     `json:"duration_seconds" gorm:"type:Int64"`
+	RecordedAt      time.Time `json:"recorded_at" gorm:"type:DateTime64(3, 'UTC')"`
+}
+
+// TableOptions returns the ClickHouse engine clause used when migrating the table.
+func (IncidentRecord) TableOptions() string {
+	return "ENGINE = ReplacingMergeTree(recorded_at) PARTITION BY toYYYYMM(started_at) ORDER BY (organization_id, incident_id) TTL toDateTime(started_at) + INTERVAL 2 YEAR"
+}
+
+// NewIncidentRecord returns the record of a resolved incident
+func NewIncidentRecord(incident *Incident, recordedAt time.Time) IncidentRecord {
+	record := IncidentRecord{
+		IncidentID:     incident.ID,
+		OrganizationID: incident.OrganizationID,
+		Source:         incident.Source,
+		Severity:       incident.Severity,
+		Cause:          incident.Cause,
+		StartedAt:      incident.StartedAt,
+		RecordedAt:     recordedAt,
+	}
+	if incident.MonitorID != nil {
+		record.MonitorID = *incident.MonitorID
+	}
+	if incident.ResolvedAt != nil {
+		record.ResolvedAt = *incident.ResolvedAt
+		record.DurationSeconds = int64(incident.ResolvedAt.Sub(incident.StartedAt).Seconds())
+	}
+	return record
+}
+
+// IncidentStatsPoint aggregates resolved incidents: how many there were and their mean time
+// to resolve
+type IncidentStatsPoint struct {
+	Incidents   uint64  `json:"incidents" gorm:"column:incidents"`
+	MTTRSeconds float64 `json:"mttr_seconds" gorm:"column:mttr_seconds"`
+}
+
+// IncidentCauseStats aggregates the resolved incidents tagged with a cause, or untagged for
+// an empty cause
+type IncidentCauseStats struct {
+	Cause              string `json:"cause" gorm:"column:cause"`
+	IncidentStatsPoint `gorm:"embedded"`
+}
+
+// IncidentMonitorStats aggregates the resolved incidents of a monitor
+type IncidentMonitorStats struct {
+	MonitorID          uuid.UUID `json:"monitor_id" gorm:"column:monitor_id"`
+	IncidentStatsPoint `gorm:"embedded"`
+}
+
+// IncidentAnalytics is the report of the incidents of an organization resolved over a
+// time range
+type IncidentAnalytics struct {
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Summary   IncidentStatsPoint     `json:"summary"`
+	ByCause   []IncidentCauseStats   `json:"by_cause"`
+	ByMonitor []IncidentMonitorStats `json:"by_monitor"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Monitor types
+const (
+	MonitorTypeHTTP = "http"
+	MonitorTypeTCP  = "tcp"
+	MonitorTypePing = "ping"
+)
+
+// Monitor statuses
+const (
+	MonitorStatusPending  = "pending"
+	MonitorStatusUp       = "up"
+	MonitorStatusDown     = "down"
+	MonitorStatusDegraded = "degraded"
+	MonitorStatusPaused   = "paused"
+)
+
+// Monitor represents a check target belonging to an organization.
+// A monitor can optionally be attached to an application environment.
+type Monitor struct {
+	Model
+	Versioning
+	OrganizationID  uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;index"`
+	EnvironmentID   *uuid.UUID `json:"environment_id" gorm:"type:uuid;index"`
+	Name            string     `json:"name" gorm:"type:varchar(100);not null"`
+	Type            string     `json:"type" gorm:"type:varchar(20);not null;default:'http'"`
+	Target          string     `json:"target" gorm:"type:varchar(2048);not null"`
+	IntervalSeconds int        `json:"interval_seconds" gorm:"not null;default:60"`
+	TimeoutSeconds  int        `json:"timeout_seconds" gorm:"not null;default:10"`
+	// Regions are the probe regions checking the monitor; none checks it from every region
+	Regions       []string   `json:"regions" gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	LastCheckedAt *time.Time `json:"last_checked_at" gorm:"default:null"`
+	// CheckRequestedAt is when a check out of schedule was last requested from the API
+	CheckRequestedAt *time.Time `json:"check_requested_at" gorm:"default:null"`
+	// OwnerUserID is the member responsible for the monitor, whom its incidents are assigned
+	// to, and OwnerTeam the team owning it, such as payments-team
+	OwnerUserID *uuid.UUID     `json:"owner_user_id" gorm:"type:uuid;index"`
+	OwnerTeam   string         `json:"owner_team" gorm:"type:varchar(100);not null;default:'';index"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	Organization Organization `json:"-" gorm:"foreignKey:OrganizationID"`
+}
+
+// OrganizationOwned marks Monitor rows as belonging to a single organization for tenant scoping.
+func (Monitor) OrganizationOwned() {}
+
+// SystemColumns implements SystemManaged: the status and check times written by the
+// checkers and check requests. Pausing and resuming go through MonitorRepository.SetPaused.
+func (Monitor) SystemColumns() []string {
+	return []string{"status", "last_checked_at", "check_requested_at"}
+}
+
+// IsPaused reports whether the monitor is excluded from scheduling.
+func (m *Monitor) IsPaused() bool {
+	return m.Status == MonitorStatusPaused
+}
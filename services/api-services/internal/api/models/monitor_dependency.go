@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonitorDependency declares that a monitor depends on another one, such as an API on the
+// load balancer of its database. While a monitor it depends on is down, the monitor going
+// down is not alerted on separately but grouped under the incident of that monitor.
+type MonitorDependency struct {
+	MonitorID      uuid.UUID `json:"monitor_id" gorm:"type:uuid;primaryKey"`
+	DependsOnID    uuid.UUID `json:"depends_on_id" gorm:"type:uuid;primaryKey;index"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// OrganizationOwned marks MonitorDependency rows as belonging to a single organization for tenant scoping.
+func (MonitorDependency) OrganizationOwned() {}
+
+// DependencyGraph maps each monitor to the monitors it depends on
+type DependencyGraph map[uuid.UUID][]uuid.UUID
+
+// NewDependencyGraph builds the graph of dependencies
+func NewDependencyGraph(dependencies []MonitorDependency) DependencyGraph {
+	graph := make(DependencyGraph, len(dependencies))
+	for _, d := range dependencies {
+		graph[d.MonitorID] = append(graph[d.MonitorID], d.DependsOnID)
+	}
+	return graph
+}
+
+// Ancestors returns every monitor id depends on, directly or through other monitors,
+// nearest first
+func (g DependencyGraph) Ancestors(id uuid.UUID) []uuid.UUID {
+	var ancestors []uuid.UUID
+	seen := map[uuid.UUID]bool{id: true}
+	queue := []uuid.UUID{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, parent := range g[current] {
+			if !seen[parent] {
+				seen[parent] = true
+				ancestors = append(ancestors, parent)
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return ancestors
+}
+
+// DependsOn reports whether id depends on ancestor, directly or through other monitors
+func (g DependencyGraph) DependsOn(id, ancestor uuid.UUID) bool {
+	for _, a := range g.Ancestors(id) {
+		if a == ancestor {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rollup resolutions for monitor statistics, each backed by a ClickHouse materialized view.
+const (
+	StatsResolutionMinute = "minute"
+	StatsResolutionHour   = "hour"
+	StatsResolutionDay    = "day"
+)
+
+// MonitorStatsPoint aggregates the check results of a monitor over one bucket or a whole range.
+type MonitorStatsPoint struct {
+	Bucket         time.Time `json:"bucket" gorm:"column:bucket"`
+	TotalChecks    uint64    `json:"total_checks" gorm:"column:total_checks"`
+	UpChecks       uint64    `json:"up_checks" gorm:"column:up_checks"`
+	DegradedChecks uint64    `json:"degraded_checks" gorm:"column:degraded_checks"`
+	DownChecks     uint64    `json:"down_checks" gorm:"column:down_checks"`
+	Availability   float64   `json:"availability" gorm:"-"`
+	AvgLatencyMs   float64   `json:"avg_latency_ms" gorm:"column:avg_latency_ms"`
+	MinLatencyMs   int64     `json:"min_latency_ms" gorm:"column:min_latency_ms"`
+	MaxLatencyMs   int64     `json:"max_latency_ms" gorm:"column:max_latency_ms"`
+	P50LatencyMs   float64   `json:"p50_latency_ms" gorm:"column:p50_latency_ms"`
+	P95LatencyMs   float64   `json:"p95_latency_ms" gorm:"column:p95_latency_ms"`
+	P99LatencyMs   float64   `json:"p99_latency_ms" gorm:"column:p99_latency_ms"`
+}
+
+// ComputeAvailability sets Availability to the percentage of checks that were up or degraded.
+func (p *MonitorStatsPoint) ComputeAvailability() {
+	if p.TotalChecks == 0 {
+		p.Availability = 0
+		return
+	}
+	p.Availability = float64(p.UpChecks+p.DegradedChecks) / float64(p.TotalChecks) * 100
+}
+
+// MonitorStatsSummary aggregates the check results of one monitor of an organization
+type MonitorStatsSummary struct {
+	MonitorID         uuid.UUID `json:"monitor_id" gorm:"column:monitor_id"`
+	MonitorStatsPoint `gorm:"embedded"`
+}
+
+// MonitorStats is the uptime and latency report of a monitor over a time range.
+type MonitorStats struct {
+	MonitorID  uuid.UUID           `json:"monitor_id"`
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Resolution string              `json:"resolution"`
+	Summary    MonitorStatsPoint   `json:"summary"`
+	Series     []MonitorStatsPoint `json:"series"`
+}
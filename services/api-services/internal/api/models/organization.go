@@ -14,15 +14,21 @@ type Organization struct {
 	OwnerID      uuid.UUID        `json:"owner_id" gorm:"type:uuid;index"`
 	Owner        *User            `json:"owner" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	Name         string           `json:"name" gorm:"type:varchar(100);not null"`
+	Timezone     string           `json:"timezone" gorm:"type:varchar(64);not null;default:'UTC'"`
 	Icon         *string          `json:"icon" gorm:"type:varchar(100);not null"`
 	TypeID       uuid.UUID        `json:"type_id" gorm:"type:uuid;not null;index"`
-	Type         OrganizationType `json:"type" gorm:"foreignKey:OrganizationTypeID"`
+	Type         OrganizationType `json:"type" gorm:"foreignKey:TypeID"`
 	Users        []User           `json:"users" gorm:"many2many:organization_users;"`
 	Policies     []Policy         `json:"policies" gorm:"foreignKey:OrganizationID"`
 	Applications []Application    `json:"applications" gorm:"foreignKey:OrganizationID"`
 	DeletedAt    gorm.DeletedAt   `json:"deleted_at" gorm:"index"`
 }
 
+// Location returns the time zone the schedules and reports of the organization follow
+func (o *Organization) Location() *time.Location {
+	return LoadTimezone(o.Timezone)
+}
+
 // OrganizationUser represents the pivot table for Organization-User relationship.
 type OrganizationUser struct {
 	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index:idx_organization_users,priority:1"`
@@ -32,6 +38,7 @@ type OrganizationUser struct {
 
 type OrganizationType struct {
 	Model
-	Name        string  `json:"name" gorm:"type:varchar(100);not null"`
+	Name        string  `json:"name" gorm:"type:varchar(100);unique;not null"`
 	Description *string `json:"description" gorm:"type:varchar(100);not null"`
+	IsSystem    bool    `json:"is_system" gorm:"not null;default:false"` // managed by the seeder
 }
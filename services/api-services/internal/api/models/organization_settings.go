@@ -0,0 +1,61 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Built-in monitor defaults of organizations that do not set their own
+const (
+	DefaultMonitorIntervalSeconds = 60
+	DefaultMonitorTimeoutSeconds  = 10
+)
+
+// OrganizationSettings holds the defaults of an organization: the settings its new monitors
+// get when they leave them unset, and how the alerts of its incidents escalate. A nil
+// field falls back to the built-in default.
+type OrganizationSettings struct {
+	Model
+	OrganizationID         uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex"`
+	DefaultIntervalSeconds *int      `json:"default_interval_seconds" gorm:"default:null"`
+	DefaultTimeoutSeconds  *int      `json:"default_timeout_seconds" gorm:"default:null"`
+	DefaultRegions         []string  `json:"default_regions" gorm:"type:jsonb;serializer:json"`
+	// EscalationAfterMinutes is how long an incident stays open before its alert escalates
+	EscalationAfterMinutes *int `json:"escalation_after_minutes" gorm:"default:null"`
+	// EscalationRepeatMinutes is how often an escalated alert is repeated while the
+	// incident stays open
+	EscalationRepeatMinutes *int `json:"escalation_repeat_minutes" gorm:"default:null"`
+}
+
+// OrganizationOwned marks OrganizationSettings rows as belonging to a single organization for tenant scoping.
+func (OrganizationSettings) OrganizationOwned() {}
+
+// MonitorDefaults are the settings new monitors of an organization get when they leave
+// them unset. No regions checks monitors from every region.
+type MonitorDefaults struct {
+	IntervalSeconds int      `json:"interval_seconds"`
+	TimeoutSeconds  int      `json:"timeout_seconds"`
+	Regions         []string `json:"regions"`
+}
+
+// MonitorDefaults returns the monitor defaults of the settings, which may be nil, over the
+// built-in ones
+func (s *OrganizationSettings) MonitorDefaults() MonitorDefaults {
+	defaults := MonitorDefaults{
+		IntervalSeconds: DefaultMonitorIntervalSeconds,
+		TimeoutSeconds:  DefaultMonitorTimeoutSeconds,
+		Regions:         []string{},
+	}
+	if s == nil {
+		return defaults
+	}
+	if s.DefaultIntervalSeconds != nil {
+		defaults.IntervalSeconds = *s.DefaultIntervalSeconds
+	}
+	if s.DefaultTimeoutSeconds != nil {
+		defaults.TimeoutSeconds = *s.DefaultTimeoutSeconds
+	}
+	if len(s.DefaultRegions) > 0 {
+		defaults.Regions = s.DefaultRegions
+	}
+	return defaults
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Outbox message statuses
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed"
+)
+
+// OutboxMessage is a side effect (email, webhook, notification) recorded in the same
+// transaction as the domain change that caused it and delivered later by the outbox relay.
+type OutboxMessage struct {
+	Model
+	Topic       string          `json:"topic" gorm:"type:varchar(100);not null;index"`
+	Payload     json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	Status      string          `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_messages_due,priority:1"`
+	Attempts    int             `json:"attempts" gorm:"not null;default:0"`
+	AvailableAt time.Time       `json:"available_at" gorm:"not null;index:idx_outbox_messages_due,priority:2"`
+	LastError   *string         `json:"last_error" gorm:"type:text"`
+	ProcessedAt *time.Time      `json:"processed_at" gorm:"default:null;index"`
+	// Errors holds the latest failed attempts, oldest first
+	Errors []OutboxError `json:"errors" gorm:"type:jsonb;serializer:json"`
+}
+
+// OutboxError is a failed delivery attempt of an outbox message
+type OutboxError struct {
+	Attempt    int       `json:"attempt"`
+	Error      string    `json:"error"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// APIProbeID is the probe the results of the checks requested from the API are reported by.
+// It is not a probe of the network and is not tracked as one.
+const APIProbeID = "api"
+
+// Statuses of probes and probe regions. Regions with some of their probes offline are degraded.
+const (
+	ProbeStatusOnline   = "online"
+	ProbeStatusDegraded = "degraded"
+	ProbeStatusOffline  = "offline"
+)
+
+// ProbeOfflineAfter is how long a probe may go without reporting results before it is
+// considered offline
+const ProbeOfflineAfter = 5 * time.Minute
+
+// Probe is a probe agent of the network, known from the check results it reports. Probes
+// are global, shared by every organization.
+type Probe struct {
+	Model
+	ProbeID    string    `json:"probe_id" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Region     string    `json:"region" gorm:"type:varchar(50);not null;index"`
+	LastSeenAt time.Time `json:"last_seen_at" gorm:"not null"`
+}
+
+// Status returns whether the probe reported results in the ProbeOfflineAfter before now
+func (p *Probe) Status(now time.Time) string {
+	if now.Sub(p.LastSeenAt) > ProbeOfflineAfter {
+		return ProbeStatusOffline
+	}
+	return ProbeStatusOnline
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurgeAuditLog records a row permanently removed by the retention purge job.
+// Rows removed because their parent was purged reference it through ParentTable and ParentID.
+type PurgeAuditLog struct {
+	Model
+	SourceTable   string     `json:"source_table" gorm:"type:varchar(100);not null;index:idx_purge_audit_logs_record,priority:1"`
+	RecordID      uuid.UUID  `json:"record_id" gorm:"type:uuid;not null;index:idx_purge_audit_logs_record,priority:2"`
+	ParentTable   *string    `json:"parent_table" gorm:"type:varchar(100)"`
+	ParentID      *uuid.UUID `json:"parent_id" gorm:"type:uuid;index"`
+	SoftDeletedAt *time.Time `json:"soft_deleted_at" gorm:"default:null"`
+	PurgedAt      time.Time  `json:"purged_at" gorm:"not null;index"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Uptime report frequencies
+const (
+	ReportFrequencyWeekly  = "weekly"
+	ReportFrequencyMonthly = "monthly"
+)
+
+// ReportSubscription opts an organization member in to uptime report emails. Reports
+// cover whole weeks or months; LastPeriodEnd is the end of the last period reported,
+// so a period is never sent twice.
+type ReportSubscription struct {
+	Model
+	OrganizationID   uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex:idx_report_subscriptions_member,priority:1"`
+	UserID           uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_report_subscriptions_member,priority:2;index"`
+	Frequency        string     `json:"frequency" gorm:"type:varchar(10);not null;default:'weekly'"`
+	UnsubscribeToken string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	LastPeriodEnd    *time.Time `json:"last_period_end" gorm:"default:null"`
+}
+
+// OrganizationOwned marks ReportSubscription rows as belonging to a single organization for tenant scoping.
+func (ReportSubscription) OrganizationOwned() {}
+
+// DueReportSubscription is a subscription owed a report, with the recipient and the name
+// of the organization the report is about
+type DueReportSubscription struct {
+	ReportSubscription
+	Email            string
+	Locale           *string
+	OrganizationName string
+}
+
+// LastReportPeriod returns the last whole period of frequency before now: the previous
+// Monday-to-Monday week or the previous calendar month, starting and ending at midnight
+// in loc.
+func LastReportPeriod(frequency string, now time.Time, loc *time.Location) (from, to time.Time) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if frequency == ReportFrequencyMonthly {
+		to = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		return to.AddDate(0, -1, 0), to
+	}
+
+	// Weeks start on Monday; time.Weekday counts from Sunday
+	to = today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	return to.AddDate(0, 0, -7), to
+}
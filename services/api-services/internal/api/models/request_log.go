@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestLog is a single API request recorded for usage analytics and abuse detection.
+// It is stored in ClickHouse; OrganizationID and UserID are uuid.Nil when not applicable.
+type RequestLog struct {
+	Timestamp      time.Time `json:"timestamp" gorm:"type:DateTime64(3, 'UTC')"`
+	RequestID      string    `json:"request_id" gorm:"type:String"`
+	Method         string    `json:"method" gorm:"type:LowCardinality(String)"`
+	Route          string    `json:"route" gorm:"type:LowCardinality(String)"`
+	Path           string    `json:"path" gorm:"type:String"`
+	Status         int32     `json:"status" gorm:"type:Int32"`
+	LatencyMs      int64     `json:"latency_ms" gorm:"type:Int64"`
+	BytesOut       int64     `json:"bytes_out" gorm:"type:Int64"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:UUID"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:UUID"`
+	APIKeyID       string    `json:"api_key_id" gorm:"type:String"`
+	ClientIP       string    `json:"client_ip" gorm:"type:String"`
+	UserAgent      string    `json:"user_agent" gorm:"type:String"`
+}
+
+// TableOptions returns the ClickHouse engine clause used when migrating the table.
+func (RequestLog) TableOptions() string {
+	return "ENGINE = MergeTree() PARTITION BY toYYYYMMDD(timestamp) ORDER BY (route, timestamp) TTL toDateTime(timestamp) + INTERVAL 90 DAY"
+}
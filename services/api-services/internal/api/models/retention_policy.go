@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionPolicy overrides how many days the data of an organization is kept. A nil
+// window falls back to the default of the deployment.
+type RetentionPolicy struct {
+	Model
+	OrganizationID   uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex"`
+	CheckResultsDays *int      `json:"check_results_days" gorm:"default:null"`
+	RollupsDays      *int      `json:"rollups_days" gorm:"default:null"`
+	IncidentsDays    *int      `json:"incidents_days" gorm:"default:null"`
+}
+
+// OrganizationOwned marks RetentionPolicy rows as belonging to a single organization for tenant scoping.
+func (RetentionPolicy) OrganizationOwned() {}
+
+// DataRetention is the number of days the data of an organization is kept, with its
+// overrides applied. 0 keeps the data.
+type DataRetention struct {
+	OrganizationID   uuid.UUID `json:"organization_id"`
+	CheckResultsDays int       `json:"check_results_days"`
+	RollupsDays      int       `json:"rollups_days"`
+	IncidentsDays    int       `json:"incidents_days"`
+	// Custom reports whether the organization overrides a default
+	Custom bool `json:"custom"`
+}
+
+// NewDataRetention returns the retention of an organization: the windows of policy, which
+// may be nil, over the default windows, rounded up to whole days
+func NewDataRetention(organizationID uuid.UUID, policy *RetentionPolicy, checkResultsWindow, rollupsWindow, incidentsWindow time.Duration) DataRetention {
+	retention := DataRetention{
+		OrganizationID:   organizationID,
+		CheckResultsDays: windowDays(checkResultsWindow),
+		RollupsDays:      windowDays(rollupsWindow),
+		IncidentsDays:    windowDays(incidentsWindow),
+	}
+	if policy == nil {
+		return retention
+	}
+	if policy.CheckResultsDays != nil {
+		retention.CheckResultsDays = *policy.CheckResultsDays
+		retention.Custom = true
+	}
+	if policy.RollupsDays != nil {
+		retention.RollupsDays = *policy.RollupsDays
+		retention.Custom = true
+	}
+	if policy.IncidentsDays != nil {
+		retention.IncidentsDays = *policy.IncidentsDays
+		retention.Custom = true
+	}
+	return retention
+}
+
+// windowDays converts a retention window to whole days, rounding up
+func windowDays(window time.Duration) int {
+	const day = 24 * time.Hour
+	return int((window + day - 1) / day)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "github.com/google/uuid"
+
+// Search result types
+const (
+	SearchResultTypeMonitor  = "monitor"
+	SearchResultTypeIncident = "incident"
+)
+
+// SearchResult is one full-text search hit. Results of every type share this shape
+// so they can be ranked together.
+type SearchResult struct {
+	Type           string    `json:"type"`
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Title          string    `json:"title"`
+	Subtitle       string    `json:"subtitle"`
+	Rank           float64   `json:"rank"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "encoding/json"
+
+// SeedHistory records a run of one seeder. Checksum covers the whole seed set, and Items
+// maps every seeded entry to its own checksum so later runs can report what changed.
+type SeedHistory struct {
+	Model
+	Seeder   string          `json:"seeder" gorm:"type:varchar(100);not null;index"`
+	Checksum string          `json:"checksum" gorm:"type:varchar(64);not null"`
+	Items    json.RawMessage `json:"items" gorm:"type:jsonb;not null"`
+	Release  string          `json:"release" gorm:"type:varchar(50)"`
+}
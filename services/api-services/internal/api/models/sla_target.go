@@ -0,0 +1,131 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLA target statuses, from best to worst
+const (
+	SLAStatusPending  = "pending"
+	SLAStatusOK       = "ok"
+	SLAStatusAtRisk   = "at_risk"
+	SLAStatusBreached = "breached"
+)
+
+// SLATarget is the availability an organization commits to over every week or calendar
+// month, for one monitor, the monitors of one environment, or all of its monitors when
+// neither is set. The evaluation fields hold the state of the current period and are
+// refreshed by the SLA evaluation job.
+type SLATarget struct {
+	Model
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;index"`
+	MonitorID      *uuid.UUID `json:"monitor_id" gorm:"type:uuid;index"`
+	EnvironmentID  *uuid.UUID `json:"environment_id" gorm:"type:uuid;index"`
+	Name           string     `json:"name" gorm:"type:varchar(100);not null"`
+	// Target is the availability percentage to meet, e.g. 99.9
+	Target float64 `json:"target" gorm:"not null"`
+	Period string  `json:"period" gorm:"type:varchar(10);not null;default:'monthly'"`
+
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	PeriodStart  *time.Time `json:"period_start" gorm:"default:null"`
+	Availability *float64   `json:"availability" gorm:"default:null"`
+	// BudgetConsumed is the share of the error budget of the period already spent; 1 or
+	// more means the target can no longer be met
+	BudgetConsumed float64 `json:"budget_consumed" gorm:"not null;default:0"`
+	// BurnRate is how many times faster than the target allows the budget is spent
+	// over the recent window
+	BurnRate    float64    `json:"burn_rate" gorm:"not null;default:0"`
+	EvaluatedAt *time.Time `json:"evaluated_at" gorm:"default:null"`
+	// AlertedStatus is the worst status already notified in the current period
+	AlertedStatus string `json:"-" gorm:"type:varchar(20);not null;default:''"`
+}
+
+// OrganizationOwned marks SLATarget rows as belonging to a single organization for tenant scoping.
+func (SLATarget) OrganizationOwned() {}
+
+// EvaluatedSLATarget is an SLA target due for evaluation, with the owner of its
+// organization, who is notified of breaches
+type EvaluatedSLATarget struct {
+	SLATarget
+	OrganizationName string
+	OwnerEmail       *string
+	OwnerLocale      *string
+}
+
+// SLAEvaluation is the state of an SLA target over its current period
+type SLAEvaluation struct {
+	Status         string
+	PeriodStart    time.Time
+	Availability   *float64
+	BudgetConsumed float64
+	BurnRate       float64
+}
+
+// CurrentSLAPeriod returns the week, starting on Monday, or the calendar month, in UTC,
+// that now falls in
+func CurrentSLAPeriod(period string, now time.Time) (from, to time.Time) {
+	_, from = LastReportPeriod(period, now, time.UTC)
+	if period == ReportFrequencyMonthly {
+		return from, from.AddDate(0, 1, 0)
+	}
+	return from, from.AddDate(0, 0, 7)
+}
+
+// EvaluateSLA returns the state of a target of availability target (a percentage) at now.
+// period and recent aggregate the checks of the period so far and of the recent window.
+// The error budget is the downtime the target allows over the whole period; the share
+// of checks that failed estimates the share of the elapsed time that was down. The target
+// is at risk once atRiskBudget of the budget is spent or the recent burn rate reaches
+// fastBurnRate, and breached once the whole budget is spent.
+func EvaluateSLA(target float64, periodName string, now time.Time, period, recent MonitorStatsPoint, atRiskBudget, fastBurnRate float64) SLAEvaluation {
+	from, to := CurrentSLAPeriod(periodName, now)
+	evaluation := SLAEvaluation{Status: SLAStatusPending, PeriodStart: from}
+	if period.TotalChecks == 0 {
+		return evaluation
+	}
+
+	period.ComputeAvailability()
+	availability := period.Availability
+	evaluation.Availability = &availability
+
+	allowed := 1 - target/100
+	errorRate := float64(period.DownChecks) / float64(period.TotalChecks)
+	elapsed := float64(now.Sub(from)) / float64(to.Sub(from))
+	evaluation.BurnRate = BudgetBurnRate(target, recent)
+	evaluation.BudgetConsumed = errorRate * elapsed / allowed
+
+	switch {
+	case evaluation.BudgetConsumed >= 1:
+		evaluation.Status = SLAStatusBreached
+	case evaluation.BudgetConsumed >= atRiskBudget || evaluation.BurnRate >= fastBurnRate:
+		evaluation.Status = SLAStatusAtRisk
+	default:
+		evaluation.Status = SLAStatusOK
+	}
+	return evaluation
+}
+
+// BudgetBurnRate returns how many times faster than a target of availability target (a
+// percentage) allows the checks of stats spent the error budget, zero without checks
+func BudgetBurnRate(target float64, stats MonitorStatsPoint) float64 {
+	if stats.TotalChecks == 0 {
+		return 0
+	}
+	return float64(stats.DownChecks) / float64(stats.TotalChecks) / (1 - target/100)
+}
+
+// SLAStatusSeverity ranks statuses so that a worse status ranks higher
+func SLAStatusSeverity(status string) int {
+	switch status {
+	case SLAStatusOK:
+		return 1
+	case SLAStatusAtRisk:
+		return 2
+	case SLAStatusBreached:
+		return 3
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusToken grants read-only access to the status and uptime metrics of selected monitors
+// of an organization, so that customers can embed live availability in their own dashboards
+// without an account. Only the SHA-256 hash of the token is stored; the token itself is
+// returned once, when it is created. Revoking a token soft deletes it.
+type StatusToken struct {
+	Model
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null;index"`
+	Name           string         `json:"name" gorm:"type:varchar(100);not null"`
+	TokenHash      string         `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	TokenPrefix    string         `json:"token_prefix" gorm:"type:varchar(16);not null"`
+	MonitorIDs     []uuid.UUID    `json:"monitor_ids" gorm:"type:jsonb;serializer:json;not null"`
+	ExpiresAt      *time.Time     `json:"expires_at" gorm:"default:null"`
+	CreatedBy      uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// OrganizationOwned marks StatusToken rows as belonging to a single organization for tenant scoping.
+func (StatusToken) OrganizationOwned() {}
+
+// IsExpired reports whether the token stopped granting access before now
+func (t *StatusToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// Covers reports whether the token grants access to a monitor
+func (t *StatusToken) Covers(monitorID uuid.UUID) bool {
+	return slices.Contains(t.MonitorIDs, monitorID)
+}
+
+// PublicMonitorStatus is the state of a monitor shown to status token holders. It leaves
+// out the target and settings of the monitor, which stay private to the organization.
+type PublicMonitorStatus struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	Type          string     `json:"type"`
+	Status        string     `json:"status"`
+	LastCheckedAt *time.Time `json:"last_checked_at"`
+}
+
+// NewPublicMonitorStatus returns the public state of a monitor
+func NewPublicMonitorStatus(m *Monitor) PublicMonitorStatus {
+	return PublicMonitorStatus{
+		ID:            m.ID,
+		Name:          m.Name,
+		Type:          m.Type,
+		Status:        m.Status,
+		LastCheckedAt: m.LastCheckedAt,
+	}
+}
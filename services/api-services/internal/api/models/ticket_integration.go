@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Statuses of incident tickets
+const (
+	IncidentTicketStatusOpen     = "open"
+	IncidentTicketStatusResolved = "resolved"
+)
+
+// TicketIntegration files the incidents of an organization as issues in its Jira project or
+// Linear team. With AutoCreate every incident opened is filed, otherwise incidents are filed
+// on demand; either way the issue is moved to a done state when the incident is resolved.
+// Deleting an integration soft deletes it.
+type TicketIntegration struct {
+	Model
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index"`
+	Name           string    `json:"name" gorm:"type:varchar(100);not null"`
+	Provider       string    `json:"provider" gorm:"type:varchar(20);not null"`
+	// BaseURL is the site of a Jira account; Linear integrations leave it empty
+	BaseURL  string `json:"base_url" gorm:"type:varchar(255);not null;default:''"`
+	Email    string `json:"email" gorm:"type:varchar(255);not null;default:''"`
+	APIToken string `json:"-" gorm:"type:text;not null;serializer:encrypted"` // Encrypted at rest
+	// Project is the key of the Jira project or the ID of the Linear team issues are filed in
+	Project     string         `json:"project" gorm:"type:varchar(100);not null"`
+	IssueType   string         `json:"issue_type" gorm:"type:varchar(50);not null;default:''"`
+	AutoCreate  bool           `json:"auto_create" gorm:"not null;default:false"`
+	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	LastError   string         `json:"last_error" gorm:"type:text;not null;default:''"`
+	LastErrorAt *time.Time     `json:"last_error_at" gorm:"default:null"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// OrganizationOwned marks TicketIntegration rows as belonging to a single organization for tenant scoping.
+func (TicketIntegration) OrganizationOwned() {}
+
+// IncidentTicket is the issue an incident was filed as through a ticket integration. An
+// incident is filed at most once per integration.
+type IncidentTicket struct {
+	Model
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index"`
+	IncidentID     uuid.UUID `json:"incident_id" gorm:"type:uuid;not null;uniqueIndex:idx_incident_tickets_incident_integration"`
+	IntegrationID  uuid.UUID `json:"integration_id" gorm:"type:uuid;not null;uniqueIndex:idx_incident_tickets_incident_integration"`
+	Provider       string    `json:"provider" gorm:"type:varchar(20);not null"`
+	// ExternalID identifies the issue in the API of the tracker
+	ExternalID string `json:"external_id" gorm:"type:varchar(100);not null"`
+	// Key is the reference people know the issue by, such as OPS-42
+	Key        string     `json:"key" gorm:"type:varchar(100);not null"`
+	URL        string     `json:"url" gorm:"type:varchar(500);not null;default:''"`
+	Status     string     `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	ResolvedAt *time.Time `json:"resolved_at" gorm:"default:null"`
+}
+
+// OrganizationOwned marks IncidentTicket rows as belonging to a single organization for tenant scoping.
+func (IncidentTicket) OrganizationOwned() {}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// DefaultTimezone is the time zone of the users and organizations that have not chosen one
+const DefaultTimezone = "UTC"
+
+// LoadTimezone returns the location of an IANA time zone name. Names are validated when they
+// are stored, so an empty or unknown one falls back to UTC rather than failing.
+func LoadTimezone(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -20,6 +20,8 @@ type User struct {
 	EmailVerifiedAt       *time.Time      `json:"email_verified_at" gorm:"default:null"`
 	DateOfBirth           *time.Time      `json:"date_of_birth" gorm:"default:null"`
 	ProfilePictureUrl     *string         `json:"profile_picture_url" gorm:"default:null"`
+	Locale                *string         `json:"locale" gorm:"type:varchar(35);default:null"`
+	Timezone              *string         `json:"timezone" gorm:"type:varchar(64);default:null"`
 	Preferences           json.RawMessage `json:"preferences" gorm:"type:jsonb"`
 	DeletedAt             gorm.DeletedAt  `json:"-" gorm:"index"`
 
@@ -32,6 +34,24 @@ func (u *User) EmailVerified() bool {
 	return u.EmailVerifiedAt != nil
 }
 
+// PreferredLocale returns the language the user receives notifications in, empty when the
+// user has not chosen one.
+func (u *User) PreferredLocale() string {
+	if u.Locale == nil {
+		return ""
+	}
+	return *u.Locale
+}
+
+// Location returns the time zone times are displayed to the user in, UTC when the user has
+// not chosen one.
+func (u *User) Location() *time.Location {
+	if u.Timezone == nil {
+		return time.UTC
+	}
+	return LoadTimezone(*u.Timezone)
+}
+
 // BeforeCreate hook to hash password with Argon2id.
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if len(u.HashedPassword) > 0 {
@@ -0,0 +1,32 @@
+package models
+
+// Versioned is implemented by models protected by optimistic locking. Repositories only
+// update such a row when its stored version still matches the one that was read.
+type Versioned interface {
+	CurrentVersion() int64
+	SetVersion(version int64)
+}
+
+// SystemManaged is implemented by versioned models with columns the application writes on
+// its own, such as the status recorded by the checkers. Those writes do not bump the
+// version, so versioned updates leave these columns alone rather than writing back the
+// values that were read.
+type SystemManaged interface {
+	SystemColumns() []string
+}
+
+// Versioning adds an optimistic locking version to a model. Clients echo the version
+// they read when updating, and a mismatch means someone else changed the row first.
+type Versioning struct {
+	Version int64 `json:"version" gorm:"not null;default:1"`
+}
+
+// CurrentVersion implements Versioned
+func (v *Versioning) CurrentVersion() int64 {
+	return v.Version
+}
+
+// SetVersion implements Versioned
+func (v *Versioning) SetVersion(version int64) {
+	v.Version = version
+}
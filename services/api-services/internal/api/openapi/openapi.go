@@ -0,0 +1,414 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version is the OpenAPI specification version emitted by the builder.
+const Version = "3.0.3"
+
+// Document is the root OpenAPI document.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Paths      map[string]map[string]*Endpoint `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server describes a base URL the API is reachable on.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Components holds reusable schemas and security schemes.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes an authentication mechanism.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Endpoint is an OpenAPI operation object.
+type Endpoint struct {
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter describes a path, query or header parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes a JSON request payload.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a single response.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType wraps a schema for a content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema subset sufficient for the API's DTOs.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+}
+
+// Operation is the code-first description of a route, registered next to the route itself.
+type Operation struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Secured     bool
+	Query       []Parameter
+	// Request is a zero value of the request DTO; nil means no body.
+	Request any
+	// Responses maps an HTTP status to a zero value of the data payload (nil for no data).
+	Responses map[int]any
+}
+
+// Builder accumulates operations and renders the OpenAPI document.
+type Builder struct {
+	mu       sync.Mutex
+	doc      *Document
+	rendered []byte
+}
+
+// NewBuilder creates a Builder for the API described by info.
+func NewBuilder(info Info, servers ...Server) *Builder {
+	return &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Servers: servers,
+			Paths:   make(map[string]map[string]*Endpoint),
+			Components: Components{
+				Schemas: make(map[string]*Schema),
+				SecuritySchemes: map[string]*SecurityScheme{
+					"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				},
+			},
+		},
+	}
+}
+
+// Register documents a route. The path uses Gin syntax (":id") and is converted to OpenAPI ("{id}").
+func (b *Builder) Register(method, path string, op Operation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	oasPath, pathParams := convertPath(path)
+	endpoint := &Endpoint{
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: operationID(method, oasPath),
+		Tags:        op.Tags,
+		Parameters:  append(pathParams, op.Query...),
+		Responses:   make(map[string]*Response),
+	}
+
+	if op.Secured {
+		endpoint.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	if op.Request != nil {
+		endpoint.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: b.schemaFor(reflect.TypeOf(op.Request))}},
+		}
+	}
+
+	for status, data := range op.Responses {
+		endpoint.Responses[strconv.Itoa(status)] = &Response{
+			Description: statusDescription(status),
+			Content:     map[string]MediaType{"application/json": {Schema: b.envelope(data)}},
+		}
+	}
+	if len(endpoint.Responses) == 0 {
+		endpoint.Responses["200"] = &Response{Description: statusDescription(200)}
+	}
+
+	if b.doc.Paths[oasPath] == nil {
+		b.doc.Paths[oasPath] = make(map[string]*Endpoint)
+	}
+	b.doc.Paths[oasPath][strings.ToLower(method)] = endpoint
+	b.rendered = nil
+}
+
+// JSON renders the document, caching the result until the next Register call.
+func (b *Builder) JSON() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rendered != nil {
+		return b.rendered, nil
+	}
+
+	data, err := json.MarshalIndent(b.doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	b.rendered = data
+	return data, nil
+}
+
+// envelope wraps a data schema in the standard GenericResponse shape produced by utils.ResponseBuilder.
+func (b *Builder) envelope(data any) *Schema {
+	if _, ok := b.doc.Components.Schemas["ErrorDetails"]; !ok {
+		b.doc.Components.Schemas["ErrorDetails"] = &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"code":    {Type: "string"},
+				"message": {Type: "string"},
+				"details": {},
+			},
+		}
+		b.doc.Components.Schemas["Meta"] = &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"request_id":  {Type: "string"},
+				"timestamp":   {Type: "string", Format: "date-time"},
+				"version":     {Type: "string"},
+				"duration_ms": {Type: "integer", Format: "int64"},
+				"user_id":     {Type: "string"},
+				"pagination":  {Type: "object"},
+			},
+		}
+	}
+
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean"},
+			"message": {Type: "string"},
+			"error":   {Ref: "#/components/schemas/ErrorDetails"},
+			"meta":    {Ref: "#/components/schemas/Meta"},
+		},
+		Required: []string{"success", "meta"},
+	}
+	if data != nil {
+		schema.Properties["data"] = b.schemaFor(reflect.TypeOf(data))
+	}
+	return schema
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaFor derives a schema from a Go type using its json and validate struct tags.
+// Named structs are emitted once under components/schemas and referenced elsewhere.
+func (b *Builder) schemaFor(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: nullable}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid", Nullable: nullable}
+	case rawType:
+		return &Schema{Nullable: nullable}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string", Nullable: nullable}
+	case reflect.Bool:
+		return &Schema{Type: "boolean", Nullable: nullable}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Nullable: nullable}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Nullable: nullable}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem()), Nullable: nullable}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem()), Nullable: nullable}
+	case reflect.Interface:
+		return &Schema{Nullable: nullable}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := t.Name()
+		if _, ok := b.doc.Components.Schemas[name]; !ok {
+			// Reserve the name before recursing so self-referencing types terminate.
+			b.doc.Components.Schemas[name] = &Schema{Type: "object"}
+			b.doc.Components.Schemas[name] = b.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema builds an inline object schema, flattening embedded structs like encoding/json does.
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name := strings.Split(jsonTag, ",")[0]
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := b.structSchema(embedded)
+				for k, v := range inner.Properties {
+					schema.Properties[k] = v
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := b.schemaFor(field.Type)
+		required := applyValidateTag(prop, field.Tag.Get("validate"))
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// applyValidateTag maps go-playground/validator rules onto the schema and reports whether the field is required.
+func applyValidateTag(schema *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max", "len":
+			if schema.Type != "string" {
+				continue
+			}
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			if key != "max" {
+				schema.MinLength = &n
+			}
+			if key != "min" {
+				schema.MaxLength = &n
+			}
+		}
+	}
+	return required
+}
+
+// convertPath turns "/users/:id" into "/users/{id}" and returns the path parameters.
+func convertPath(path string) (string, []Parameter) {
+	segments := strings.Split(path, "/")
+	var params []Parameter
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			name := seg[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable operationId such as "post_api_v1_auth_signup".
+func operationID(method, path string) string {
+	replacer := strings.NewReplacer("/", "_", "{", "", "}", "", "-", "_")
+	return strings.ToLower(method) + strings.TrimRight(replacer.Replace(path), "_")
+}
+
+// statusDescription returns a short description for a status code.
+func statusDescription(status int) string {
+	switch {
+	case status >= 200 && status < 300:
+		return "Successful response"
+	case status >= 400 && status < 500:
+		return "Client error"
+	case status >= 500:
+		return "Server error"
+	default:
+		return "Response"
+	}
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// ActivityRepository defines the interface for activity feed data operations
+type ActivityRepository interface {
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, category string, beforeID int64, limit int) ([]models.ActivityEntry, error)
+}
+
+// activityRepository implements ActivityRepository interface
+type activityRepository struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository creates a new instance of activityRepository
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+// ListByOrganization lists up to limit activity entries of an organization, newest first,
+// among those with an ID below beforeID, or all of them when beforeID is 0. An empty
+// category lists every category.
+func (r *activityRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID, category string, beforeID int64, limit int) ([]models.ActivityEntry, error) {
+	query := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var entries []models.ActivityEntry
+	if err := query.Order("id DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list activity entries: %w", err)
+	}
+	return entries, nil
+}
@@ -1 +1,79 @@
 package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"gorm.io/gorm"
+)
+
+// permissionCatalogueKey identifies the cached list of every permission
+const permissionCatalogueKey = "catalogue"
+
+// PermissionRepository defines the interface for permission data operations
+type PermissionRepository interface {
+	Repository[models.Permission]
+	// ListAll returns the whole permission catalogue ordered by name
+	ListAll(ctx context.Context) ([]models.Permission, error)
+}
+
+// permissionRepository implements PermissionRepository interface
+type permissionRepository struct {
+	*BaseRepository[models.Permission]
+}
+
+// NewPermissionRepository creates a new instance of permissionRepository
+func NewPermissionRepository(db *gorm.DB) PermissionRepository {
+	return &permissionRepository{
+		BaseRepository: NewBaseRepository[models.Permission](db, "permission"),
+	}
+}
+
+// ListAll returns the whole permission catalogue ordered by name
+func (pr *permissionRepository) ListAll(ctx context.Context) ([]models.Permission, error) {
+	return pr.List(ctx, OrderBy("name ASC"))
+}
+
+// cachedPermissionRepository serves the permission catalogue from a read-through cache
+type cachedPermissionRepository struct {
+	PermissionRepository
+	cached *CachedRepository[models.Permission]
+}
+
+// NewCachedPermissionRepository wraps inner so the catalogue and single permissions are
+// served from cacheService for up to ttl. Writes made through the returned repository
+// invalidate them.
+func NewCachedPermissionRepository(inner PermissionRepository, cacheService *cache.Service, ttl time.Duration) PermissionRepository {
+	return &cachedPermissionRepository{
+		PermissionRepository: inner,
+		cached:               NewCachedRepository[models.Permission](inner, cacheService, "permission", ttl),
+	}
+}
+
+// GetByID retrieves a permission through the cache
+func (cr *cachedPermissionRepository) GetByID(ctx context.Context, id uuid.UUID, scopes ...Scope) (*models.Permission, error) {
+	return cr.cached.GetByID(ctx, id, scopes...)
+}
+
+// ListAll returns the permission catalogue through the cache
+func (cr *cachedPermissionRepository) ListAll(ctx context.Context) ([]models.Permission, error) {
+	return cr.cached.ListCached(ctx, permissionCatalogueKey, OrderBy("name ASC"))
+}
+
+// Create inserts a permission and invalidates the cached catalogue
+func (cr *cachedPermissionRepository) Create(ctx context.Context, permission *models.Permission) error {
+	return cr.cached.Create(ctx, permission)
+}
+
+// Update saves a permission and invalidates its cached copies
+func (cr *cachedPermissionRepository) Update(ctx context.Context, permission *models.Permission) error {
+	return cr.cached.Update(ctx, permission)
+}
+
+// SoftDelete deletes a permission and invalidates its cached copies
+func (cr *cachedPermissionRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return cr.cached.SoftDelete(ctx, id)
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository defines the data operations shared by every entity repository.
+// Entity repositories embed it in their interface and add their own queries.
+type Repository[T any] interface {
+	Create(ctx context.Context, entity *T) error
+	GetByID(ctx context.Context, id uuid.UUID, scopes ...Scope) (*T, error)
+	List(ctx context.Context, scopes ...Scope) ([]T, error)
+	Update(ctx context.Context, entity *T) error
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+	Count(ctx context.Context, scopes ...Scope) (int64, error)
+}
+
+// BaseRepository implements Repository with GORM. Models with a gorm.DeletedAt field
+// are soft deleted and excluded from reads automatically.
+type BaseRepository[T any] struct {
+	db   *gorm.DB
+	name string
+}
+
+// NewBaseRepository creates a base repository; name is the entity name used in error messages
+func NewBaseRepository[T any](db *gorm.DB, name string) *BaseRepository[T] {
+	return &BaseRepository[T]{db: db, name: name}
+}
+
+// Create inserts a new entity
+func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
+	if err := database.Conn(ctx, r.db).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to create %s: %w", r.name, err)
+	}
+	return nil
+}
+
+// GetByID retrieves an entity by ID, returning common.ErrNotFound when it does not exist
+func (r *BaseRepository[T]) GetByID(ctx context.Context, id uuid.UUID, scopes ...Scope) (*T, error) {
+	var entity T
+	err := database.Conn(ctx, r.db).
+		Scopes(scopes...).
+		Where("id = ?", id).
+		First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", r.name, err)
+	}
+	return &entity, nil
+}
+
+// List retrieves the entities matching the scopes; combine with Paginate to bound the result
+func (r *BaseRepository[T]) List(ctx context.Context, scopes ...Scope) ([]T, error) {
+	var entities []T
+	if err := database.Conn(ctx, r.db).Scopes(scopes...).Find(&entities).Error; err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", r.name, err)
+	}
+	return entities, nil
+}
+
+// Update saves every field of the entity. Entities implementing models.Versioned are only
+// updated while the stored version matches theirs, otherwise common.ErrConflict is returned,
+// and the system columns of models.SystemManaged ones are left as stored.
+func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) error {
+	if versioned, ok := any(entity).(models.Versioned); ok {
+		return r.updateVersioned(ctx, entity, versioned)
+	}
+
+	if err := database.Conn(ctx, r.db).Save(entity).Error; err != nil {
+		return fmt.Errorf("failed to update %s: %w", r.name, err)
+	}
+	return nil
+}
+
+// updateVersioned writes entity with its version incremented, guarded by the version it was
+// read at. The system columns of models.SystemManaged entities are not written.
+func (r *BaseRepository[T]) updateVersioned(ctx context.Context, entity *T, versioned models.Versioned) error {
+	expected := versioned.CurrentVersion()
+	versioned.SetVersion(expected + 1)
+
+	omit := []string{"id", "created_at", clause.Associations}
+	if managed, ok := any(entity).(models.SystemManaged); ok {
+		omit = append(omit, managed.SystemColumns()...)
+	}
+	result := database.Conn(ctx, r.db).
+		Model(entity).
+		Select("*").
+		Omit(omit...).
+		Where("version = ?", expected).
+		Updates(entity)
+	if result.Error != nil {
+		versioned.SetVersion(expected)
+		return fmt.Errorf("failed to update %s: %w", r.name, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		versioned.SetVersion(expected)
+		return common.ErrConflict
+	}
+	return nil
+}
+
+// SoftDelete deletes an entity by ID, soft deleting when the model supports it
+func (r *BaseRepository[T]) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	var entity T
+	if err := database.Conn(ctx, r.db).Where("id = ?", id).Delete(&entity).Error; err != nil {
+		return fmt.Errorf("failed to delete %s: %w", r.name, err)
+	}
+	return nil
+}
+
+// Count returns the number of entities matching the scopes
+func (r *BaseRepository[T]) Count(ctx context.Context, scopes ...Scope) (int64, error) {
+	var count int64
+	var entity T
+	if err := database.Conn(ctx, r.db).Model(&entity).Scopes(scopes...).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", r.name, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// CachedRepository is an opt-in read-through cache over a Repository, meant for hot lookups.
+//
+// Entries are keyed by entity, ID or list key, and the tenant scope of the context, so a
+// lookup never returns a row another organization's query could not see. Instead of
+// deleting keys, writes bump a per-ID version and a per-entity list generation that are
+// part of the keys; superseded entries simply expire. When the cache is unavailable
+// reads fall through to the wrapped repository.
+type CachedRepository[T any] struct {
+	Repository[T]
+	cache  *cache.Service
+	entity string
+	ttl    time.Duration
+}
+
+// NewCachedRepository wraps inner with a cache. entity namespaces the keys and should be
+// unique per model; ttl bounds how long an entry lives.
+func NewCachedRepository[T any](inner Repository[T], cacheService *cache.Service, entity string, ttl time.Duration) *CachedRepository[T] {
+	return &CachedRepository[T]{
+		Repository: inner,
+		cache:      cacheService,
+		entity:     entity,
+		ttl:        ttl,
+	}
+}
+
+// GetByID returns the entity from the cache, loading it on a miss. Lookups with scopes
+// cannot be keyed and always go to the repository. Missing entities are cached too.
+func (r *CachedRepository[T]) GetByID(ctx context.Context, id uuid.UUID, scopes ...Scope) (*T, error) {
+	if len(scopes) > 0 {
+		return r.Repository.GetByID(ctx, id, scopes...)
+	}
+
+	version, ok := r.counter(ctx, r.versionKey(id))
+	if !ok {
+		return r.Repository.GetByID(ctx, id)
+	}
+
+	entity, err := readThrough(ctx, r.cache, r.idKey(ctx, id, version), r.ttl, func() (*T, error) {
+		entity, err := r.Repository.GetByID(ctx, id)
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, nil
+		}
+		return entity, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, common.ErrNotFound
+	}
+	return entity, nil
+}
+
+// ListCached returns the entities matching scopes, cached under listKey. listKey must
+// uniquely describe the scopes, for example the normalized query string of a request.
+func (r *CachedRepository[T]) ListCached(ctx context.Context, listKey string, scopes ...Scope) ([]T, error) {
+	generation, ok := r.counter(ctx, r.generationKey())
+	if !ok {
+		return r.Repository.List(ctx, scopes...)
+	}
+
+	hash := sha256.Sum256([]byte(listKey))
+	key := fmt.Sprintf("repo:%s:list:g%d:%s:%s", r.entity, generation, scopeKey(ctx), hex.EncodeToString(hash[:16]))
+	return readThrough(ctx, r.cache, key, r.ttl, func() ([]T, error) {
+		return r.Repository.List(ctx, scopes...)
+	})
+}
+
+// Prime stores entities as GetByID would after loading them, so the first lookups under
+// the tenant scope of ctx are hits. It is meant for warming the cache on startup.
+func (r *CachedRepository[T]) Prime(ctx context.Context, entities []T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(entities))
+	versionKeys := make([]string, 0, len(entities))
+	for i := range entities {
+		id, ok := entityID(&entities[i])
+		if !ok {
+			return fmt.Errorf("cannot prime %s cache: entity has no ID", r.entity)
+		}
+		ids = append(ids, id)
+		versionKeys = append(versionKeys, r.versionKey(id))
+	}
+
+	// Missing version counters read as zero, as in counter
+	versions, err := cache.GetMany[int64](ctx, r.cache, versionKeys)
+	if err != nil {
+		return fmt.Errorf("failed to read %s cache versions: %w", r.entity, err)
+	}
+
+	values := make(map[string]interface{}, len(entities))
+	for i, id := range ids {
+		values[r.idKey(ctx, id, versions[versionKeys[i]])] = &entities[i]
+	}
+	if err := r.cache.SetMany(ctx, values, r.ttl); err != nil {
+		return fmt.Errorf("failed to prime %s cache: %w", r.entity, err)
+	}
+	return nil
+}
+
+// Create inserts the entity and invalidates cached lists
+func (r *CachedRepository[T]) Create(ctx context.Context, entity *T) error {
+	if err := r.Repository.Create(ctx, entity); err != nil {
+		return err
+	}
+	r.bump(ctx, r.generationKey())
+	return nil
+}
+
+// Update saves the entity and invalidates its cached copies and cached lists
+func (r *CachedRepository[T]) Update(ctx context.Context, entity *T) error {
+	if err := r.Repository.Update(ctx, entity); err != nil {
+		return err
+	}
+	if id, ok := entityID(entity); ok {
+		r.Invalidate(ctx, id)
+	}
+	return nil
+}
+
+// SoftDelete deletes the entity and invalidates its cached copies and cached lists
+func (r *CachedRepository[T]) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	if err := r.Repository.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+	r.Invalidate(ctx, id)
+	return nil
+}
+
+// Invalidate drops every cached copy of an entity and every cached list. Repositories
+// writing the entity outside Update and SoftDelete must call it after the write.
+func (r *CachedRepository[T]) Invalidate(ctx context.Context, id uuid.UUID) {
+	r.bump(ctx, r.versionKey(id))
+	r.bump(ctx, r.generationKey())
+}
+
+func (r *CachedRepository[T]) idKey(ctx context.Context, id uuid.UUID, version int64) string {
+	return fmt.Sprintf("repo:%s:id:%s:v%d:%s", r.entity, id, version, scopeKey(ctx))
+}
+
+func (r *CachedRepository[T]) versionKey(id uuid.UUID) string {
+	return fmt.Sprintf("repo:%s:version:%s", r.entity, id)
+}
+
+func (r *CachedRepository[T]) generationKey() string {
+	return fmt.Sprintf("repo:%s:generation", r.entity)
+}
+
+// counter reads a version or generation counter; a missing counter reads as zero.
+// It reports false when the cache cannot be reached.
+func (r *CachedRepository[T]) counter(ctx context.Context, key string) (int64, bool) {
+	var value int64
+	if err := r.cache.Get(ctx, key, &value); err != nil {
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			return 0, false
+		}
+	}
+	return value, true
+}
+
+// bump increments a version or generation counter, logging failures since the write
+// itself already succeeded
+func (r *CachedRepository[T]) bump(ctx context.Context, key string) {
+	if _, err := r.cache.Increment(ctx, key); err != nil {
+		logger.Warn("Failed to invalidate repository cache",
+			logger.String("entity", r.entity),
+			logger.String("key", key),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// readThrough loads key with cache.Service.GetOrSet. Repository errors are returned as
+// is; any other failure, such as an unreachable cache or a cached error, falls back to
+// calling fetch directly so the cache never makes a lookup fail.
+func readThrough[V any](ctx context.Context, cacheService *cache.Service, key string, ttl time.Duration, fetch func() (V, error)) (V, error) {
+	var (
+		value    V
+		fetched  bool
+		fetchErr error
+	)
+	err := cacheService.GetOrSet(ctx, key, &value, ttl, func() (interface{}, error) {
+		fetched = true
+		result, err := fetch()
+		fetchErr = err
+		return result, err
+	})
+	if err == nil {
+		return value, nil
+	}
+	if fetched && fetchErr != nil {
+		var zero V
+		return zero, fetchErr
+	}
+	return fetch()
+}
+
+// scopeKey identifies the tenant scope of ctx, since scoped queries may see fewer rows
+func scopeKey(ctx context.Context) string {
+	if organizationID, ok := tenant.OrganizationFromContext(ctx); ok {
+		return "org:" + organizationID.String()
+	}
+	return "all"
+}
+
+// entityID returns the ID field of an entity embedding models.Model
+func entityID[T any](entity *T) (uuid.UUID, bool) {
+	value := reflect.Indirect(reflect.ValueOf(entity))
+	if value.Kind() != reflect.Struct {
+		return uuid.Nil, false
+	}
+	field := value.FieldByName("ID")
+	if !field.IsValid() {
+		return uuid.Nil, false
+	}
+	id, ok := field.Interface().(uuid.UUID)
+	return id, ok
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// ChatIntegrationRepository defines the interface for chat integration data operations
+type ChatIntegrationRepository interface {
+	Repository[models.ChatIntegration]
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.ChatIntegration, error)
+	MarkReceived(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// chatIntegrationRepository implements ChatIntegrationRepository interface
+type chatIntegrationRepository struct {
+	*BaseRepository[models.ChatIntegration]
+	db *gorm.DB
+}
+
+// NewChatIntegrationRepository creates a new instance of chatIntegrationRepository
+func NewChatIntegrationRepository(db *gorm.DB) ChatIntegrationRepository {
+	return &chatIntegrationRepository{
+		BaseRepository: NewBaseRepository[models.ChatIntegration](db, "chat integration"),
+		db:             db,
+	}
+}
+
+// ListByOrganization lists the chat integrations of an organization, newest first
+func (r *chatIntegrationRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.ChatIntegration, error) {
+	var integrations []models.ChatIntegration
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id DESC").
+		Find(&integrations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// MarkReceived records when a chat integration last received a command
+func (r *chatIntegrationRepository) MarkReceived(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.ChatIntegration{}).
+		Where("id = ?", id).
+		UpdateColumn("last_received_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark chat integration received: %w", err)
+	}
+	return nil
+}
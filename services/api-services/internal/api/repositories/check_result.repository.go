@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// ErrCheckResultStoreDisabled is returned when neither ClickHouse nor the Postgres fallback is configured.
+var ErrCheckResultStoreDisabled = errors.New("check result storage is not configured")
+
+// CheckResultsTable is the table check results are written to in both stores
+const CheckResultsTable = "check_results"
+
+// CheckResultPartitionMigrations returns the objects backing the Postgres fallback store.
+// The table is range partitioned by month on checked_at; partitions are created and
+// dropped by partitions.Manager, and the index is inherited by every partition.
+func CheckResultPartitionMigrations() []database.SQLObjectToCreate {
+	return []database.SQLObjectToCreate{
+		{
+			Description: CheckResultsTable + " partitioned table",
+			Query: `CREATE TABLE IF NOT EXISTS ` + CheckResultsTable + ` (
+	monitor_id uuid NOT NULL,
+	organization_id uuid NOT NULL,
+	probe_id text NOT NULL,
+	region text NOT NULL,
+	status text NOT NULL,
+	latency_ms bigint NOT NULL,
+	status_code integer NOT NULL,
+	error text NOT NULL DEFAULT '',
+	checked_at timestamptz NOT NULL
+) PARTITION BY RANGE (checked_at)`,
+		},
+		{
+			Description: CheckResultsTable + " monitor index",
+			Query:       `CREATE INDEX IF NOT EXISTS idx_check_results_monitor ON ` + CheckResultsTable + ` (organization_id, monitor_id, checked_at DESC)`,
+		},
+	}
+}
+
+// CheckResultRepository defines the interface for check result storage
+type CheckResultRepository interface {
+	InsertBatch(ctx context.Context, results []models.CheckResult) error
+	ListByMonitor(ctx context.Context, organizationID, monitorID uuid.UUID, filter Scope, from, to time.Time, after *models.CheckResultCursor, limit int) ([]models.CheckResult, error)
+	StreamByMonitor(ctx context.Context, organizationID, monitorID uuid.UUID, filter Scope, from, to time.Time, limit int, fn func(*models.CheckResult) error) error
+}
+
+// checkResultRepository implements CheckResultRepository on a batch writer, which inserts
+// into ClickHouse or into the Postgres fallback table, and reads from ClickHouse
+type checkResultRepository struct {
+	writer *database.BatchWriter[models.CheckResult]
+	db     *gorm.DB
+}
+
+// NewCheckResultRepository creates a new instance of checkResultRepository.
+// writer may be nil when no store is configured; writes then fail with ErrCheckResultStoreDisabled.
+// db is the ClickHouse connection results are read from; it may be nil when ClickHouse is
+// disabled, and reads then fail with ErrCheckResultStoreDisabled.
+func NewCheckResultRepository(writer *database.BatchWriter[models.CheckResult], db *gorm.DB) CheckResultRepository {
+	return &checkResultRepository{writer: writer, db: db}
+}
+
+// InsertBatch queues results on the batch writer, blocking while its buffer is full
+func (cr *checkResultRepository) InsertBatch(ctx context.Context, results []models.CheckResult) error {
+	if cr.writer == nil {
+		return ErrCheckResultStoreDisabled
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	if err := cr.writer.Write(ctx, results...); err != nil {
+		return fmt.Errorf("failed to queue check results: %w", err)
+	}
+	return nil
+}
+
+// ListByMonitor returns up to limit results of a monitor checked within [from, to) and
+// matching filter, newest first. after is the cursor of the last result of the previous
+// page, or nil for the first page.
+func (cr *checkResultRepository) ListByMonitor(ctx context.Context, organizationID, monitorID uuid.UUID, filter Scope, from, to time.Time, after *models.CheckResultCursor, limit int) ([]models.CheckResult, error) {
+	if cr.db == nil {
+		return nil, ErrCheckResultStoreDisabled
+	}
+
+	query := cr.db.WithContext(ctx).
+		Table(CheckResultsTable).
+		Scopes(ByOrganization(organizationID), filter).
+		Where("monitor_id = ?", monitorID).
+		Where("checked_at >= ? AND checked_at < ?", from, to)
+	if after != nil {
+		// The plain bound on checked_at lets ClickHouse skip granules with the sorting key
+		query = query.
+			Where("checked_at <= ?", after.CheckedAt).
+			Where("checked_at < ? OR probe_id < ?", after.CheckedAt, after.ProbeID)
+	}
+
+	var results []models.CheckResult
+	err := query.
+		Order("checked_at DESC, probe_id DESC").
+		Limit(limit).
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check results: %w", err)
+	}
+	return results, nil
+}
+
+// StreamByMonitor iterates over up to limit results of a monitor checked within [from, to)
+// and matching filter, newest first, one row at a time. Iteration stops at the first error
+// returned by fn.
+func (cr *checkResultRepository) StreamByMonitor(ctx context.Context, organizationID, monitorID uuid.UUID, filter Scope, from, to time.Time, limit int, fn func(*models.CheckResult) error) error {
+	if cr.db == nil {
+		return ErrCheckResultStoreDisabled
+	}
+
+	db := cr.db.WithContext(ctx)
+	rows, err := db.
+		Table(CheckResultsTable).
+		Scopes(ByOrganization(organizationID), filter).
+		Where("monitor_id = ?", monitorID).
+		Where("checked_at >= ? AND checked_at < ?", from, to).
+		Order("checked_at DESC, probe_id DESC").
+		Limit(limit).
+		Rows()
+	if err != nil {
+		return fmt.Errorf("failed to query check results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result models.CheckResult
+		if err := db.ScanRows(rows, &result); err != nil {
+			return fmt.Errorf("failed to scan check result: %w", err)
+		}
+		if err := fn(&result); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
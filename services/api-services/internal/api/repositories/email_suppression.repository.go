@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailSuppressionRepository defines the interface for email suppression list operations.
+// It satisfies email.SuppressionList.
+type EmailSuppressionRepository interface {
+	Suppress(ctx context.Context, suppression *models.EmailSuppression) error
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+	GetByEmail(ctx context.Context, address string) (*models.EmailSuppression, error)
+	List(ctx context.Context, filter, order Scope, limit, offset int) ([]models.EmailSuppression, int64, error)
+	Delete(ctx context.Context, address string) error
+}
+
+// emailSuppressionRepository implements EmailSuppressionRepository interface
+type emailSuppressionRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailSuppressionRepository creates a new instance of emailSuppressionRepository
+func NewEmailSuppressionRepository(db *gorm.DB) EmailSuppressionRepository {
+	return &emailSuppressionRepository{db: db}
+}
+
+// Suppress adds the address of suppression to the list, replacing the reason of an
+// address already on it
+func (r *emailSuppressionRepository) Suppress(ctx context.Context, suppression *models.EmailSuppression) error {
+	suppression.Email = normalizeEmail(suppression.Email)
+	err := database.Conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "email"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "provider", "detail", "occurred_at", "updated_at"}),
+		}).
+		Create(suppression).Error
+	if err != nil {
+		return fmt.Errorf("failed to suppress email address: %w", err)
+	}
+	return nil
+}
+
+// IsSuppressed checks whether address is on the suppression list
+func (r *emailSuppressionRepository) IsSuppressed(ctx context.Context, address string) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Model(&models.EmailSuppression{}).
+		Where("email = ?", normalizeEmail(address)).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetByEmail retrieves the suppression of address
+func (r *emailSuppressionRepository) GetByEmail(ctx context.Context, address string) (*models.EmailSuppression, error) {
+	var suppression models.EmailSuppression
+	err := database.Conn(ctx, r.db).
+		Where("email = ?", normalizeEmail(address)).
+		First(&suppression).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get email suppression: %w", err)
+	}
+	return &suppression, nil
+}
+
+// List lists suppressed addresses with caller-provided filter and order scopes, returning
+// the page and the total number of matching addresses
+func (r *emailSuppressionRepository) List(ctx context.Context, filter, order Scope, limit, offset int) ([]models.EmailSuppression, int64, error) {
+	query := database.Conn(ctx, r.db).
+		Model(&models.EmailSuppression{}).
+		Scopes(filter).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count email suppressions: %w", err)
+	}
+
+	var suppressions []models.EmailSuppression
+	err := query.
+		Scopes(order).
+		Order("id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&suppressions).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list email suppressions: %w", err)
+	}
+	return suppressions, total, nil
+}
+
+// Delete removes address from the suppression list
+func (r *emailSuppressionRepository) Delete(ctx context.Context, address string) error {
+	result := database.Conn(ctx, r.db).
+		Where("email = ?", normalizeEmail(address)).
+		Delete(&models.EmailSuppression{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete email suppression: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// normalizeEmail makes addresses comparable regardless of case and surrounding space
+func normalizeEmail(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// GeneratedReportRepository defines the interface for generated report data operations
+type GeneratedReportRepository interface {
+	Repository[models.GeneratedReport]
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, limit int) ([]models.GeneratedReport, error)
+	CountPending(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	ListPending(ctx context.Context, limit int) ([]models.PendingReport, error)
+	MarkReady(ctx context.Context, id uuid.UUID, storageKey string, size int64, completedAt, expiresAt time.Time) error
+	MarkAttemptFailed(ctx context.Context, id uuid.UUID, reason string, maxAttempts int, expiresAt time.Time) error
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]models.GeneratedReport, error)
+}
+
+// generatedReportRepository implements GeneratedReportRepository interface
+type generatedReportRepository struct {
+	*BaseRepository[models.GeneratedReport]
+	db *gorm.DB
+}
+
+// NewGeneratedReportRepository creates a new instance of generatedReportRepository
+func NewGeneratedReportRepository(db *gorm.DB) GeneratedReportRepository {
+	return &generatedReportRepository{
+		BaseRepository: NewBaseRepository[models.GeneratedReport](db, "generated report"),
+		db:             db,
+	}
+}
+
+// ListByOrganization lists up to limit reports of an organization, newest first
+func (r *generatedReportRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID, limit int) ([]models.GeneratedReport, error) {
+	var reports []models.GeneratedReport
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&reports).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list generated reports: %w", err)
+	}
+	return reports, nil
+}
+
+// CountPending returns how many reports of an organization wait to be generated
+func (r *generatedReportRepository) CountPending(ctx context.Context, organizationID uuid.UUID) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Model(&models.GeneratedReport{}).
+		Where("organization_id = ? AND status = ?", organizationID, models.ReportStatusPending).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending reports: %w", err)
+	}
+	return count, nil
+}
+
+// ListPending lists up to limit reports waiting to be generated, oldest first. Reports of
+// deleted organizations are skipped.
+func (r *generatedReportRepository) ListPending(ctx context.Context, limit int) ([]models.PendingReport, error) {
+	var reports []models.PendingReport
+	err := database.Conn(ctx, r.db).
+		Table("generated_reports").
+		Select("generated_reports.*, organizations.name AS organization_name, organizations.timezone AS organization_timezone").
+		Joins("JOIN organizations ON organizations.id = generated_reports.organization_id AND organizations.deleted_at IS NULL").
+		Where("generated_reports.status = ?", models.ReportStatusPending).
+		Order("generated_reports.created_at ASC, generated_reports.id ASC").
+		Limit(limit).
+		Scan(&reports).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending reports: %w", err)
+	}
+	return reports, nil
+}
+
+// MarkReady records that the file of a report was stored at storageKey
+func (r *generatedReportRepository) MarkReady(ctx context.Context, id uuid.UUID, storageKey string, size int64, completedAt, expiresAt time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.GeneratedReport{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       models.ReportStatusReady,
+			"storage_key":  storageKey,
+			"size":         size,
+			"error":        nil,
+			"completed_at": completedAt,
+			"expires_at":   expiresAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark report ready: %w", err)
+	}
+	return nil
+}
+
+// MarkAttemptFailed records a failed attempt to generate a report. Once maxAttempts
+// attempts failed the report fails for good and is kept until expiresAt.
+func (r *generatedReportRepository) MarkAttemptFailed(ctx context.Context, id uuid.UUID, reason string, maxAttempts int, expiresAt time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.GeneratedReport{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts": gorm.Expr("attempts + 1"),
+			"error":    reason,
+			"status": gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ? ELSE status END",
+				maxAttempts, models.ReportStatusFailed),
+			"expires_at": gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ? ELSE expires_at END",
+				maxAttempts, expiresAt),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record failed report attempt: %w", err)
+	}
+	return nil
+}
+
+// ListExpired lists up to limit ready or failed reports that expired before now
+func (r *generatedReportRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]models.GeneratedReport, error) {
+	var reports []models.GeneratedReport
+	err := database.Conn(ctx, r.db).
+		Where("expires_at < ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&reports).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired reports: %w", err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// InboundIntegrationRepository defines the interface for inbound integration data operations
+type InboundIntegrationRepository interface {
+	Repository[models.InboundIntegration]
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.InboundIntegration, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.InboundIntegration, error)
+	MarkReceived(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// inboundIntegrationRepository implements InboundIntegrationRepository interface
+type inboundIntegrationRepository struct {
+	*BaseRepository[models.InboundIntegration]
+	db *gorm.DB
+}
+
+// NewInboundIntegrationRepository creates a new instance of inboundIntegrationRepository
+func NewInboundIntegrationRepository(db *gorm.DB) InboundIntegrationRepository {
+	return &inboundIntegrationRepository{
+		BaseRepository: NewBaseRepository[models.InboundIntegration](db, "inbound integration"),
+		db:             db,
+	}
+}
+
+// ListByOrganization lists the inbound integrations of an organization, newest first
+func (r *inboundIntegrationRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.InboundIntegration, error) {
+	var integrations []models.InboundIntegration
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id DESC").
+		Find(&integrations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inbound integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// GetByHash retrieves the inbound integration with the given token hash, returning
+// common.ErrNotFound when there is none or it was deleted
+func (r *inboundIntegrationRepository) GetByHash(ctx context.Context, tokenHash string) (*models.InboundIntegration, error) {
+	var integration models.InboundIntegration
+	err := database.Conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&integration).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get inbound integration: %w", err)
+	}
+	return &integration, nil
+}
+
+// MarkReceived records when an inbound integration last received a webhook
+func (r *inboundIntegrationRepository) MarkReceived(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.InboundIntegration{}).
+		Where("id = ?", id).
+		UpdateColumn("last_received_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark inbound integration received: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// IncidentRepository defines the interface for incident data operations
+type IncidentRepository interface {
+	Repository[models.Incident]
+	GetOpenByFingerprint(ctx context.Context, organizationID uuid.UUID, source, fingerprint string) (*models.Incident, error)
+	ListOpenBySource(ctx context.Context, organizationID uuid.UUID, source string) ([]models.Incident, error)
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit, offset int) ([]models.Incident, int64, error)
+	StreamByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit int, fn func(*models.Incident) error) error
+	ListOpenByAssignee(ctx context.Context, organizationIDs []uuid.UUID, assigneeID uuid.UUID, limit, offset int) ([]models.Incident, int64, error)
+	CountOpenByAssignee(ctx context.Context, organizationID uuid.UUID) ([]models.IncidentWorkload, error)
+	UpdateAssignee(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID, version int64) error
+	Claim(ctx context.Context, id, userID uuid.UUID) (bool, error)
+	ListOpenByIDPrefix(ctx context.Context, organizationID uuid.UUID, prefix string, limit int) ([]models.Incident, error)
+	Acknowledge(ctx context.Context, id uuid.UUID, by string, at time.Time) (bool, error)
+	UpdateCause(ctx context.Context, id uuid.UUID, cause string, version int64) error
+	Resolve(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+// incidentRepository implements IncidentRepository interface
+type incidentRepository struct {
+	*BaseRepository[models.Incident]
+	db *gorm.DB
+}
+
+// NewIncidentRepository creates a new instance of incidentRepository
+func NewIncidentRepository(db *gorm.DB) IncidentRepository {
+	return &incidentRepository{
+		BaseRepository: NewBaseRepository[models.Incident](db, "incident"),
+		db:             db,
+	}
+}
+
+// GetOpenByFingerprint retrieves the open incident an external source raised for an alert,
+// returning common.ErrNotFound when there is none
+func (r *incidentRepository) GetOpenByFingerprint(ctx context.Context, organizationID uuid.UUID, source, fingerprint string) (*models.Incident, error) {
+	var incident models.Incident
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ? AND source = ? AND fingerprint = ? AND status = ?", organizationID, source, fingerprint, models.IncidentStatusOpen).
+		Order("started_at DESC").
+		First(&incident).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get open incident: %w", err)
+	}
+	return &incident, nil
+}
+
+// ListOpenBySource lists the open incidents of an organization raised by a source
+func (r *incidentRepository) ListOpenBySource(ctx context.Context, organizationID uuid.UUID, source string) ([]models.Incident, error) {
+	var incidents []models.Incident
+	err := database.Conn(ctx, r.db).
+		Scopes(ByOrganization(organizationID)).
+		Where("source = ? AND status = ?", source, models.IncidentStatusOpen).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open incidents by source: %w", err)
+	}
+	return incidents, nil
+}
+
+// ListByOrganization lists an organization's incidents with caller-provided filter and order scopes,
+// returning the page and the total number of matching incidents
+func (r *incidentRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit, offset int) ([]models.Incident, int64, error) {
+	query := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Scopes(ByOrganization(organizationID), filter).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count incidents: %w", err)
+	}
+
+	var incidents []models.Incident
+	err := query.
+		Scopes(order).
+		Order("id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	return incidents, total, nil
+}
+
+// StreamByOrganization iterates over up to limit matching incidents one row at a time, without
+// loading the result set into memory. Iteration stops at the first error returned by fn.
+func (r *incidentRepository) StreamByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit int, fn func(*models.Incident) error) error {
+	db := database.Conn(ctx, r.db)
+	rows, err := db.
+		Model(&models.Incident{}).
+		Scopes(ByOrganization(organizationID), filter, order).
+		Order("id ASC").
+		Limit(limit).
+		Rows()
+	if err != nil {
+		return fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var incident models.Incident
+		if err := db.ScanRows(rows, &incident); err != nil {
+			return fmt.Errorf("failed to scan incident: %w", err)
+		}
+		if err := fn(&incident); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ListOpenByAssignee lists the open incidents of the organizations assigned to a user, newest
+// first, returning the page and the total number of them
+func (r *incidentRepository) ListOpenByAssignee(ctx context.Context, organizationIDs []uuid.UUID, assigneeID uuid.UUID, limit, offset int) ([]models.Incident, int64, error) {
+	if len(organizationIDs) == 0 {
+		return []models.Incident{}, 0, nil
+	}
+
+	query := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Where("organization_id IN ? AND assignee_id = ? AND status = ?", organizationIDs, assigneeID, models.IncidentStatusOpen).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count assigned incidents: %w", err)
+	}
+
+	var incidents []models.Incident
+	err := query.
+		Order("started_at DESC").
+		Order("id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list assigned incidents: %w", err)
+	}
+	return incidents, total, nil
+}
+
+// CountOpenByAssignee counts the open incidents of an organization per assignee, the
+// unassigned ones under a nil assignee, busiest assignee first
+func (r *incidentRepository) CountOpenByAssignee(ctx context.Context, organizationID uuid.UUID) ([]models.IncidentWorkload, error) {
+	var workload []models.IncidentWorkload
+	err := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Select("assignee_id, COUNT(*) AS open_incidents, MIN(started_at) AS oldest_started_at").
+		Scopes(ByOrganization(organizationID)).
+		Where("status = ?", models.IncidentStatusOpen).
+		Group("assignee_id").
+		Order("open_incidents DESC").
+		Order("assignee_id ASC").
+		Scan(&workload).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count incidents by assignee: %w", err)
+	}
+	return workload, nil
+}
+
+// UpdateAssignee assigns an incident to a user, or unassigns it when assigneeID is nil,
+// bumping its version. It fails with common.ErrConflict when the incident is no longer at
+// version.
+func (r *incidentRepository) UpdateAssignee(ctx context.Context, id uuid.UUID, assigneeID *uuid.UUID, version int64) error {
+	result := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(map[string]any{"assignee_id": assigneeID, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return fmt.Errorf("failed to assign incident: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrConflict
+	}
+	return nil
+}
+
+// Claim assigns an incident to a user unless it is assigned to someone else, reporting
+// whether the user holds it. The version is bumped when the assignee changes.
+func (r *incidentRepository) Claim(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Where("id = ? AND (assignee_id IS NULL OR assignee_id = ?)", id, userID).
+		Updates(map[string]any{
+			"assignee_id": userID,
+			"version":     gorm.Expr("CASE WHEN assignee_id IS NULL THEN version + 1 ELSE version END"),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim incident: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListOpenByIDPrefix lists up to limit open incidents of an organization whose ID starts
+// with prefix, a lowercase hex string, so that short references can be resolved
+func (r *incidentRepository) ListOpenByIDPrefix(ctx context.Context, organizationID uuid.UUID, prefix string, limit int) ([]models.Incident, error) {
+	var incidents []models.Incident
+	err := database.Conn(ctx, r.db).
+		Scopes(ByOrganization(organizationID)).
+		Where("status = ? AND CAST(id AS text) LIKE ?", models.IncidentStatusOpen, prefix+"%").
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents by reference: %w", err)
+	}
+	return incidents, nil
+}
+
+// Acknowledge records who acknowledged an open incident, bumping its version, and reports
+// whether it was not acknowledged yet
+func (r *incidentRepository) Acknowledge(ctx context.Context, id uuid.UUID, by string, at time.Time) (bool, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Where("id = ? AND status = ? AND acknowledged_at IS NULL", id, models.IncidentStatusOpen).
+		Updates(map[string]any{"acknowledged_at": at, "acknowledged_by": by, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acknowledge incident: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateCause tags a resolved incident with its root cause, or untags it when cause is
+// empty, bumping its version. It fails with common.ErrConflict when the incident is no
+// longer resolved at version.
+func (r *incidentRepository) UpdateCause(ctx context.Context, id uuid.UUID, cause string, version int64) error {
+	result := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Where("id = ? AND status = ? AND version = ?", id, models.IncidentStatusResolved, version).
+		Updates(map[string]any{"cause": cause, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return fmt.Errorf("failed to tag incident cause: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrConflict
+	}
+	return nil
+}
+
+// Resolve resolves an open incident at the given time, reporting whether it was still
+// open. Only the status columns are written, so edits members made since the incident was
+// read are kept, and the version is left alone.
+func (r *incidentRepository) Resolve(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&models.Incident{}).
+		Where("id = ? AND status = ?", id, models.IncidentStatusOpen).
+		Updates(map[string]any{"status": models.IncidentStatusResolved, "resolved_at": at})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to resolve incident: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"gorm.io/gorm"
+)
+
+// ErrIncidentAnalyticsDisabled is returned when ClickHouse is not configured.
+var ErrIncidentAnalyticsDisabled = errors.New("incident analytics are not configured")
+
+// IncidentRecordsTable is the ClickHouse table resolved incidents are recorded in
+const IncidentRecordsTable = "incident_records"
+
+// maxIncidentMonitors bounds the monitors reported, those with the most incidents
+const maxIncidentMonitors = 50
+
+// incidentStatsColumns merges incident records into an IncidentStatsPoint. Empty ranges
+// yield a NaN mean, which is reported as zero.
+const incidentStatsColumns = `count() AS incidents,
+	ifNotFinite(avg(duration_seconds), 0) AS mttr_seconds`
+
+// IncidentAnalyticsRepository defines the interface for reading aggregated incident records
+type IncidentAnalyticsRepository interface {
+	Summary(ctx context.Context, organizationID uuid.UUID, from, to time.Time) (*models.IncidentStatsPoint, error)
+	ByCause(ctx context.Context, organizationID uuid.UUID, from, to time.Time) ([]models.IncidentCauseStats, error)
+	ByMonitor(ctx context.Context, organizationID uuid.UUID, from, to time.Time) ([]models.IncidentMonitorStats, error)
+}
+
+// incidentAnalyticsRepository implements IncidentAnalyticsRepository on the ClickHouse
+// incident records
+type incidentAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewIncidentAnalyticsRepository creates a new instance of incidentAnalyticsRepository.
+// db may be nil when ClickHouse is disabled; reads then fail with ErrIncidentAnalyticsDisabled.
+func NewIncidentAnalyticsRepository(db *gorm.DB) IncidentAnalyticsRepository {
+	return &incidentAnalyticsRepository{db: db}
+}
+
+// Summary aggregates the incidents of an organization resolved within [from, to)
+func (r *incidentAnalyticsRepository) Summary(ctx context.Context, organizationID uuid.UUID, from, to time.Time) (*models.IncidentStatsPoint, error) {
+	query, err := r.recordsQuery(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary models.IncidentStatsPoint
+	if err := query.Select(incidentStatsColumns).Scan(&summary).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident summary: %w", err)
+	}
+	return &summary, nil
+}
+
+// ByCause aggregates the incidents of an organization resolved within [from, to) by cause,
+// most frequent first
+func (r *incidentAnalyticsRepository) ByCause(ctx context.Context, organizationID uuid.UUID, from, to time.Time) ([]models.IncidentCauseStats, error) {
+	query, err := r.recordsQuery(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []models.IncidentCauseStats
+	err = query.
+		Select("cause, " + incidentStatsColumns).
+		Group("cause").
+		Order("incidents DESC, cause ASC").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incidents by cause: %w", err)
+	}
+	return stats, nil
+}
+
+// ByMonitor aggregates the incidents of the monitors of an organization resolved within
+// [from, to), for the monitors with the most incidents first
+func (r *incidentAnalyticsRepository) ByMonitor(ctx context.Context, organizationID uuid.UUID, from, to time.Time) ([]models.IncidentMonitorStats, error) {
+	query, err := r.recordsQuery(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []models.IncidentMonitorStats
+	err = query.
+		Select("monitor_id, "+incidentStatsColumns).
+		Where("monitor_id != ?", uuid.Nil).
+		Group("monitor_id").
+		Order("incidents DESC, monitor_id ASC").
+		Limit(maxIncidentMonitors).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incidents by monitor: %w", err)
+	}
+	return stats, nil
+}
+
+// recordsQuery selects the latest record of every incident of an organization resolved
+// within [from, to)
+func (r *incidentAnalyticsRepository) recordsQuery(ctx context.Context, organizationID uuid.UUID, from, to time.Time) (*gorm.DB, error) {
+	if r.db == nil {
+		return nil, ErrIncidentAnalyticsDisabled
+	}
+
+	return r.db.WithContext(ctx).
+		Table(IncidentRecordsTable+" FINAL").
+		Where("organization_id = ?", organizationID).
+		Where("resolved_at >= ? AND resolved_at < ?", from, to), nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IncidentTicketRepository defines the interface for incident ticket data operations
+type IncidentTicketRepository interface {
+	Repository[models.IncidentTicket]
+	CreateIfAbsent(ctx context.Context, ticket *models.IncidentTicket) (bool, error)
+	GetByIncidentAndIntegration(ctx context.Context, incidentID, integrationID uuid.UUID) (*models.IncidentTicket, error)
+	ListByIncident(ctx context.Context, incidentID uuid.UUID) ([]models.IncidentTicket, error)
+	ListOpenByIncident(ctx context.Context, incidentID uuid.UUID) ([]models.IncidentTicket, error)
+	MarkResolved(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// incidentTicketRepository implements IncidentTicketRepository interface
+type incidentTicketRepository struct {
+	*BaseRepository[models.IncidentTicket]
+	db *gorm.DB
+}
+
+// NewIncidentTicketRepository creates a new instance of incidentTicketRepository
+func NewIncidentTicketRepository(db *gorm.DB) IncidentTicketRepository {
+	return &incidentTicketRepository{
+		BaseRepository: NewBaseRepository[models.IncidentTicket](db, "incident ticket"),
+		db:             db,
+	}
+}
+
+// CreateIfAbsent creates the ticket unless its incident already has one for its
+// integration, and reports whether it did
+func (r *incidentTicketRepository) CreateIfAbsent(ctx context.Context, ticket *models.IncidentTicket) (bool, error) {
+	result := database.Conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "incident_id"}, {Name: "integration_id"}},
+			DoNothing: true,
+		}).
+		Create(ticket)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create incident ticket: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByIncidentAndIntegration retrieves the ticket an incident was filed as through an integration
+func (r *incidentTicketRepository) GetByIncidentAndIntegration(ctx context.Context, incidentID, integrationID uuid.UUID) (*models.IncidentTicket, error) {
+	var ticket models.IncidentTicket
+	err := database.Conn(ctx, r.db).
+		Where("incident_id = ? AND integration_id = ?", incidentID, integrationID).
+		First(&ticket).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get incident ticket: %w", err)
+	}
+	return &ticket, nil
+}
+
+// ListByIncident lists the tickets an incident was filed as, oldest first
+func (r *incidentTicketRepository) ListByIncident(ctx context.Context, incidentID uuid.UUID) ([]models.IncidentTicket, error) {
+	var tickets []models.IncidentTicket
+	err := database.Conn(ctx, r.db).
+		Where("incident_id = ?", incidentID).
+		Order("created_at ASC, id ASC").
+		Find(&tickets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident tickets: %w", err)
+	}
+	return tickets, nil
+}
+
+// ListOpenByIncident lists the tickets of an incident that are not resolved yet
+func (r *incidentTicketRepository) ListOpenByIncident(ctx context.Context, incidentID uuid.UUID) ([]models.IncidentTicket, error) {
+	var tickets []models.IncidentTicket
+	err := database.Conn(ctx, r.db).
+		Where("incident_id = ? AND status = ?", incidentID, models.IncidentTicketStatusOpen).
+		Order("created_at ASC, id ASC").
+		Find(&tickets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open incident tickets: %w", err)
+	}
+	return tickets, nil
+}
+
+// MarkResolved records that the issue of a ticket was moved to a done state
+func (r *incidentTicketRepository) MarkResolved(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.IncidentTicket{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"status": models.IncidentTicketStatusResolved, "resolved_at": at}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark incident ticket resolved: %w", err)
+	}
+	return nil
+}
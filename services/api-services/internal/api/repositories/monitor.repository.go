@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"gorm.io/gorm"
+)
+
+// monitorCacheEntity namespaces the cache keys of monitors
+const monitorCacheEntity = "monitor"
+
+// primeBatchSize is how many monitors PrimeMonitorCache loads per query
+const primeBatchSize = 500
+
+// MonitorRepository defines the interface for monitor data operations
+type MonitorRepository interface {
+	Repository[models.Monitor]
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Monitor, error)
+	ListActive(ctx context.Context, organizationID *uuid.UUID, limit, offset int) ([]models.Monitor, error)
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit, offset int) ([]models.Monitor, int64, error)
+	StreamByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit int, fn func(*models.Monitor) error) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string, checkedAt time.Time) error
+	RequestCheck(ctx context.Context, id uuid.UUID, at time.Time, cooldown time.Duration) (bool, error)
+	SetPaused(ctx context.Context, id uuid.UUID, paused bool) error
+	SearchByOrganization(ctx context.Context, organizationID uuid.UUID, term string, limit int) ([]models.Monitor, error)
+}
+
+// monitorRepository implements MonitorRepository interface
+type monitorRepository struct {
+	*BaseRepository[models.Monitor]
+	db *gorm.DB
+}
+
+// NewMonitorRepository creates a new instance of monitorRepository
+func NewMonitorRepository(db *gorm.DB) MonitorRepository {
+	return &monitorRepository{
+		BaseRepository: NewBaseRepository[models.Monitor](db, "monitor"),
+		db:             db,
+	}
+}
+
+// GetByIDs retrieves the monitors matching the given IDs; missing IDs are silently skipped
+func (mr *monitorRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Monitor, error) {
+	var monitors []models.Monitor
+	if len(ids) == 0 {
+		return monitors, nil
+	}
+	if err := database.Conn(ctx, mr.db).Where("id IN ?", ids).Find(&monitors).Error; err != nil {
+		return nil, fmt.Errorf("failed to get monitors: %w", err)
+	}
+	return monitors, nil
+}
+
+// ListActive lists monitors that are not paused, ordered by creation time
+func (mr *monitorRepository) ListActive(ctx context.Context, organizationID *uuid.UUID, limit, offset int) ([]models.Monitor, error) {
+	var monitors []models.Monitor
+	query := database.Conn(ctx, mr.db).
+		Where("status <> ?", models.MonitorStatusPaused)
+	if organizationID != nil {
+		query = query.Where("organization_id = ?", *organizationID)
+	}
+
+	err := query.
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&monitors).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitors: %w", err)
+	}
+	return monitors, nil
+}
+
+// ListByOrganization lists an organization's monitors with caller-provided filter and order scopes,
+// returning the page and the total number of matching monitors
+func (mr *monitorRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit, offset int) ([]models.Monitor, int64, error) {
+	query := database.Conn(ctx, mr.db).
+		Model(&models.Monitor{}).
+		Scopes(ByOrganization(organizationID), filter).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count monitors: %w", err)
+	}
+
+	var monitors []models.Monitor
+	err := query.
+		Scopes(order).
+		Order("id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&monitors).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list monitors: %w", err)
+	}
+	return monitors, total, nil
+}
+
+// StreamByOrganization iterates over up to limit matching monitors one row at a time, without
+// loading the result set into memory. Iteration stops at the first error returned by fn.
+func (mr *monitorRepository) StreamByOrganization(ctx context.Context, organizationID uuid.UUID, filter, order Scope, limit int, fn func(*models.Monitor) error) error {
+	db := database.Conn(ctx, mr.db)
+	rows, err := db.
+		Model(&models.Monitor{}).
+		Scopes(ByOrganization(organizationID), filter, order).
+		Order("id ASC").
+		Limit(limit).
+		Rows()
+	if err != nil {
+		return fmt.Errorf("failed to query monitors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var monitor models.Monitor
+		if err := db.ScanRows(rows, &monitor); err != nil {
+			return fmt.Errorf("failed to scan monitor: %w", err)
+		}
+		if err := fn(&monitor); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// UpdateStatus records the latest status of a monitor without touching other columns
+func (mr *monitorRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, checkedAt time.Time) error {
+	err := database.Conn(ctx, mr.db).
+		Model(&models.Monitor{}).
+		Where("id = ? AND status <> ?", id, models.MonitorStatusPaused).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"last_checked_at": checkedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update monitor status: %w", err)
+	}
+	return nil
+}
+
+// SetPaused pauses a monitor, or resumes a paused one as pending until its next check. The
+// status is not written by Update, since the checkers record it too.
+func (mr *monitorRepository) SetPaused(ctx context.Context, id uuid.UUID, paused bool) error {
+	query := database.Conn(ctx, mr.db).Model(&models.Monitor{}).Where("id = ?", id)
+	status := models.MonitorStatusPaused
+	if !paused {
+		query = query.Where("status = ?", models.MonitorStatusPaused)
+		status = models.MonitorStatusPending
+	}
+	if err := query.UpdateColumn("status", status).Error; err != nil {
+		return fmt.Errorf("failed to set monitor paused: %w", err)
+	}
+	return nil
+}
+
+// RequestCheck records that a check of a monitor out of schedule was requested at, unless
+// one was requested less than cooldown before, and reports whether it did. Paused monitors
+// are not checked.
+func (mr *monitorRepository) RequestCheck(ctx context.Context, id uuid.UUID, at time.Time, cooldown time.Duration) (bool, error) {
+	result := database.Conn(ctx, mr.db).
+		Model(&models.Monitor{}).
+		Where("id = ? AND status <> ?", id, models.MonitorStatusPaused).
+		Where("check_requested_at IS NULL OR check_requested_at <= ?", at.Add(-cooldown)).
+		UpdateColumn("check_requested_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to request monitor check: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SearchByOrganization lists up to limit monitors of an organization owned by the team
+// named term or whose name contains it, by name
+func (mr *monitorRepository) SearchByOrganization(ctx context.Context, organizationID uuid.UUID, term string, limit int) ([]models.Monitor, error) {
+	var monitors []models.Monitor
+	err := database.Conn(ctx, mr.db).
+		Scopes(ByOrganization(organizationID)).
+		Where("(owner_team = ? OR name ILIKE ?)", term, "%"+utils.EscapeLike(term)+"%").
+		Order("name ASC").
+		Limit(limit).
+		Find(&monitors).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search monitors: %w", err)
+	}
+	return monitors, nil
+}
+
+// cachedMonitorRepository serves single monitor lookups from a read-through cache
+type cachedMonitorRepository struct {
+	MonitorRepository
+	cached *CachedRepository[models.Monitor]
+}
+
+// NewCachedMonitorRepository wraps inner so GetByID is served from cacheService for up to ttl.
+// Every write made through the returned repository, including status updates, invalidates
+// the cached monitor.
+func NewCachedMonitorRepository(inner MonitorRepository, cacheService *cache.Service, ttl time.Duration) MonitorRepository {
+	return &cachedMonitorRepository{
+		MonitorRepository: inner,
+		cached:            NewCachedRepository[models.Monitor](inner, cacheService, monitorCacheEntity, ttl),
+	}
+}
+
+// PrimeMonitorCache loads up to limit active monitors, the ones the checkers schedule, into
+// the cache read by NewCachedMonitorRepository with the same ttl. It returns how many
+// monitors were cached.
+func PrimeMonitorCache(ctx context.Context, inner MonitorRepository, cacheService *cache.Service, ttl time.Duration, limit int) (int, error) {
+	cached := NewCachedRepository[models.Monitor](inner, cacheService, monitorCacheEntity, ttl)
+
+	primed := 0
+	for primed < limit {
+		batch := min(primeBatchSize, limit-primed)
+		monitors, err := inner.ListActive(ctx, nil, batch, primed)
+		if err != nil {
+			return primed, err
+		}
+		if err := cached.Prime(ctx, monitors); err != nil {
+			return primed, err
+		}
+		primed += len(monitors)
+		if len(monitors) < batch {
+			break
+		}
+	}
+	return primed, nil
+}
+
+// GetByID retrieves a monitor through the cache
+func (cr *cachedMonitorRepository) GetByID(ctx context.Context, id uuid.UUID, scopes ...Scope) (*models.Monitor, error) {
+	return cr.cached.GetByID(ctx, id, scopes...)
+}
+
+// Create inserts a monitor and invalidates cached lists
+func (cr *cachedMonitorRepository) Create(ctx context.Context, monitor *models.Monitor) error {
+	return cr.cached.Create(ctx, monitor)
+}
+
+// Update saves a monitor and invalidates its cached copies
+func (cr *cachedMonitorRepository) Update(ctx context.Context, monitor *models.Monitor) error {
+	return cr.cached.Update(ctx, monitor)
+}
+
+// SoftDelete deletes a monitor and invalidates its cached copies
+func (cr *cachedMonitorRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return cr.cached.SoftDelete(ctx, id)
+}
+
+// RequestCheck records a check request of a monitor and invalidates its cached copies
+func (cr *cachedMonitorRepository) RequestCheck(ctx context.Context, id uuid.UUID, at time.Time, cooldown time.Duration) (bool, error) {
+	requested, err := cr.MonitorRepository.RequestCheck(ctx, id, at, cooldown)
+	if err != nil || !requested {
+		return requested, err
+	}
+	cr.cached.Invalidate(ctx, id)
+	return true, nil
+}
+
+// SetPaused pauses or resumes a monitor and invalidates its cached copies
+func (cr *cachedMonitorRepository) SetPaused(ctx context.Context, id uuid.UUID, paused bool) error {
+	if err := cr.MonitorRepository.SetPaused(ctx, id, paused); err != nil {
+		return err
+	}
+	cr.cached.Invalidate(ctx, id)
+	return nil
+}
+
+// UpdateStatus records the latest status of a monitor and invalidates its cached copies
+func (cr *cachedMonitorRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, checkedAt time.Time) error {
+	if err := cr.MonitorRepository.UpdateStatus(ctx, id, status, checkedAt); err != nil {
+		return err
+	}
+	cr.cached.Invalidate(ctx, id)
+	return nil
+}
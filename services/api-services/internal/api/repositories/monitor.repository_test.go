@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestMonitorUpdateLeavesSystemColumns checks the statement of a versioned monitor update:
+// guarded by the version read, and leaving the columns the checkers write alone
+func TestMonitorUpdateLeavesSystemColumns(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statement string
+	err = db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitor := &models.Monitor{Name: "api", Status: models.MonitorStatusUp}
+	monitor.ID = uuid.New()
+	monitor.Version = 3
+	// A dry run affects no rows, so the update reports a conflict
+	if err := NewMonitorRepository(db).Update(context.Background(), monitor); !errors.Is(err, common.ErrConflict) {
+		t.Fatalf("Update() error = %v, want %v", err, common.ErrConflict)
+	}
+
+	set, where, _ := strings.Cut(statement, " WHERE ")
+	for _, column := range []string{`"status"`, `"last_checked_at"`, `"check_requested_at"`} {
+		if strings.Contains(set, column) {
+			t.Errorf("update sets %s: %s", column, statement)
+		}
+	}
+	for _, column := range []string{`"name"`, `"version"`} {
+		if !strings.Contains(set, column) {
+			t.Errorf("update does not set %s: %s", column, statement)
+		}
+	}
+	if !strings.Contains(where, "version = ") {
+		t.Errorf("update is not guarded by the version: %s", statement)
+	}
+	if monitor.Version != 3 {
+		t.Errorf("Version = %d after a conflict, want 3", monitor.Version)
+	}
+}
+
+// TestMonitorEditKeepsConcurrentStatus edits a monitor read before a checker recorded its
+// status, against the PostgreSQL database of TEST_POSTGRES_DSN
+func TestMonitorEditKeepsConcurrentStatus(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Monitor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	repo := NewMonitorRepository(db)
+	monitor := &models.Monitor{OrganizationID: uuid.New(), Name: "api", Target: "https://example.com", Status: models.MonitorStatusPending}
+	if err := repo.Create(ctx, monitor); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(&models.Monitor{}, "id = ?", monitor.ID) })
+
+	stale, err := repo.GetByID(ctx, monitor.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[0] = repo.UpdateStatus(ctx, monitor.ID, models.MonitorStatusDown, checkedAt)
+	}()
+	go func() {
+		defer wg.Done()
+		stale.Name = "api renamed"
+		errs[1] = repo.Update(ctx, stale)
+	}()
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := repo.GetByID(ctx, monitor.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != "api renamed" {
+		t.Errorf("Name = %q, want the edit", stored.Name)
+	}
+	if stored.Status != models.MonitorStatusDown || stored.LastCheckedAt == nil || !stored.LastCheckedAt.Equal(checkedAt) {
+		t.Errorf("status = %q checked at %v, want the status recorded by the checker", stored.Status, stored.LastCheckedAt)
+	}
+	if stored.Version != monitor.Version+1 {
+		t.Errorf("Version = %d, want %d", stored.Version, monitor.Version+1)
+	}
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// MonitorDependencyRepository defines the interface for monitor dependency data operations
+type MonitorDependencyRepository interface {
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.MonitorDependency, error)
+	ListDependents(ctx context.Context, monitorID uuid.UUID) ([]uuid.UUID, error)
+	Replace(ctx context.Context, organizationID, monitorID uuid.UUID, dependsOn []uuid.UUID) error
+}
+
+// monitorDependencyRepository implements MonitorDependencyRepository interface
+type monitorDependencyRepository struct {
+	db *gorm.DB
+}
+
+// NewMonitorDependencyRepository creates a new instance of monitorDependencyRepository
+func NewMonitorDependencyRepository(db *gorm.DB) MonitorDependencyRepository {
+	return &monitorDependencyRepository{db: db}
+}
+
+// ListByOrganization lists every dependency between the monitors of an organization
+func (r *monitorDependencyRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.MonitorDependency, error) {
+	var dependencies []models.MonitorDependency
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&dependencies).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitor dependencies: %w", err)
+	}
+	return dependencies, nil
+}
+
+// ListDependents returns the IDs of the monitors that depend directly on a monitor
+func (r *monitorDependencyRepository) ListDependents(ctx context.Context, monitorID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := database.Conn(ctx, r.db).
+		Model(&models.MonitorDependency{}).
+		Where("depends_on_id = ?", monitorID).
+		Order("created_at ASC").
+		Pluck("monitor_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitor dependents: %w", err)
+	}
+	return ids, nil
+}
+
+// Replace sets the monitors a monitor depends on. Run it in a transaction so the previous
+// dependencies are never lost without the new ones.
+func (r *monitorDependencyRepository) Replace(ctx context.Context, organizationID, monitorID uuid.UUID, dependsOn []uuid.UUID) error {
+	conn := database.Conn(ctx, r.db)
+	if err := conn.Where("monitor_id = ?", monitorID).Delete(&models.MonitorDependency{}).Error; err != nil {
+		return fmt.Errorf("failed to delete monitor dependencies: %w", err)
+	}
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	dependencies := make([]models.MonitorDependency, 0, len(dependsOn))
+	for _, id := range dependsOn {
+		dependencies = append(dependencies, models.MonitorDependency{MonitorID: monitorID, DependsOnID: id, OrganizationID: organizationID})
+	}
+	if err := conn.Create(&dependencies).Error; err != nil {
+		return fmt.Errorf("failed to create monitor dependencies: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// ErrMonitorStatsDisabled is returned when ClickHouse is not configured.
+var ErrMonitorStatsDisabled = errors.New("monitor statistics are not configured")
+
+// checkResultRollup describes a pre-aggregated check result table fed by a materialized view
+type checkResultRollup struct {
+	table     string
+	bucket    string
+	retention string
+}
+
+// checkResultRollups maps each stats resolution to its rollup table
+var checkResultRollups = map[string]checkResultRollup{
+	models.StatsResolutionMinute: {table: "check_results_1m", bucket: "toStartOfMinute(checked_at)", retention: "INTERVAL 7 DAY"},
+	models.StatsResolutionHour:   {table: "check_results_1h", bucket: "toStartOfHour(checked_at)", retention: "INTERVAL 45 DAY"},
+	models.StatsResolutionDay:    {table: "check_results_1d", bucket: "toStartOfDay(checked_at)", retention: "INTERVAL 2 YEAR"},
+}
+
+// CheckResultRollupTables returns the rollup tables of check results, finest first
+func CheckResultRollupTables() []string {
+	return []string{
+		checkResultRollups[models.StatsResolutionMinute].table,
+		checkResultRollups[models.StatsResolutionHour].table,
+		checkResultRollups[models.StatsResolutionDay].table,
+	}
+}
+
+// statsColumns merges rollup rows into a MonitorStatsPoint. Empty ranges yield NaN
+// averages and quantiles, which are reported as zero.
+const statsColumns = `sum(total_checks) AS total_checks,
+	sum(up_checks) AS up_checks,
+	sum(degraded_checks) AS degraded_checks,
+	sum(down_checks) AS down_checks,
+	ifNotFinite(sum(latency_sum_ms) / sum(total_checks), 0) AS avg_latency_ms,
+	min(latency_min_ms) AS min_latency_ms,
+	max(latency_max_ms) AS max_latency_ms,
+	ifNotFinite(arrayElement(quantilesTDigestMerge(0.5, 0.95, 0.99)(latency_quantiles), 1), 0) AS p50_latency_ms,
+	ifNotFinite(arrayElement(quantilesTDigestMerge(0.5, 0.95, 0.99)(latency_quantiles), 2), 0) AS p95_latency_ms,
+	ifNotFinite(arrayElement(quantilesTDigestMerge(0.5, 0.95, 0.99)(latency_quantiles), 3), 0) AS p99_latency_ms`
+
+// CheckResultRollupMigrations returns the DDL creating the rollup tables and the
+// materialized views that populate them from check_results on every insert.
+// Views only see rows inserted after they exist; history is not backfilled.
+func CheckResultRollupMigrations() []database.SQLObjectToCreate {
+	resolutions := []string{models.StatsResolutionMinute, models.StatsResolutionHour, models.StatsResolutionDay}
+
+	objects := make([]database.SQLObjectToCreate, 0, len(resolutions)*2)
+	for _, resolution := range resolutions {
+		rollup := checkResultRollups[resolution]
+		objects = append(objects,
+			database.SQLObjectToCreate{
+				Description: rollup.table + " table",
+				Query: fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	organization_id UUID,
+	monitor_id UUID,
+	bucket DateTime('UTC'),
+	total_checks SimpleAggregateFunction(sum, UInt64),
+	up_checks SimpleAggregateFunction(sum, UInt64),
+	degraded_checks SimpleAggregateFunction(sum, UInt64),
+	down_checks SimpleAggregateFunction(sum, UInt64),
+	latency_sum_ms SimpleAggregateFunction(sum, Int64),
+	latency_min_ms SimpleAggregateFunction(min, Int64),
+	latency_max_ms SimpleAggregateFunction(max, Int64),
+	latency_quantiles AggregateFunction(quantilesTDigest(0.5, 0.95, 0.99), Int64)
+) ENGINE = AggregatingMergeTree()
+PARTITION BY toYYYYMM(bucket)
+ORDER BY (organization_id, monitor_id, bucket)
+TTL bucket + %s`, rollup.table, rollup.retention),
+			},
+			database.SQLObjectToCreate{
+				Description: rollup.table + " materialized view",
+				Query: fmt.Sprintf(`CREATE MATERIALIZED VIEW IF NOT EXISTS %s_mv TO %s AS
+SELECT
+	organization_id,
+	monitor_id,
+	%s AS bucket,
+	count() AS total_checks,
+	countIf(status = '%s') AS up_checks,
+	countIf(status = '%s') AS degraded_checks,
+	countIf(status = '%s') AS down_checks,
+	sum(latency_ms) AS latency_sum_ms,
+	min(latency_ms) AS latency_min_ms,
+	max(latency_ms) AS latency_max_ms,
+	quantilesTDigestState(0.5, 0.95, 0.99)(latency_ms) AS latency_quantiles
+FROM check_results
+GROUP BY organization_id, monitor_id, bucket`,
+					rollup.table, rollup.table, rollup.bucket,
+					models.MonitorStatusUp, models.MonitorStatusDegraded, models.MonitorStatusDown),
+			},
+		)
+	}
+	return objects
+}
+
+// MonitorStatsRepository defines the interface for reading aggregated check results
+type MonitorStatsRepository interface {
+	Summary(ctx context.Context, organizationID, monitorID uuid.UUID, resolution string, from, to time.Time) (*models.MonitorStatsPoint, error)
+	Series(ctx context.Context, organizationID, monitorID uuid.UUID, resolution string, from, to time.Time) ([]models.MonitorStatsPoint, error)
+	OrganizationSummaries(ctx context.Context, organizationID uuid.UUID, resolution string, from, to time.Time) ([]models.MonitorStatsSummary, error)
+}
+
+// monitorStatsRepository implements MonitorStatsRepository on the ClickHouse rollups
+type monitorStatsRepository struct {
+	db *gorm.DB
+}
+
+// NewMonitorStatsRepository creates a new instance of monitorStatsRepository.
+// db may be nil when ClickHouse is disabled; reads then fail with ErrMonitorStatsDisabled.
+func NewMonitorStatsRepository(db *gorm.DB) MonitorStatsRepository {
+	return &monitorStatsRepository{db: db}
+}
+
+// Summary aggregates every bucket in [from, to) into a single point
+func (sr *monitorStatsRepository) Summary(ctx context.Context, organizationID, monitorID uuid.UUID, resolution string, from, to time.Time) (*models.MonitorStatsPoint, error) {
+	query, err := sr.rollupQuery(ctx, organizationID, monitorID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary models.MonitorStatsPoint
+	if err := query.Select(statsColumns).Scan(&summary).Error; err != nil {
+		return nil, fmt.Errorf("failed to get monitor stats summary: %w", err)
+	}
+	summary.Bucket = from
+	summary.ComputeAvailability()
+	return &summary, nil
+}
+
+// Series returns one point per non-empty bucket in [from, to), oldest first
+func (sr *monitorStatsRepository) Series(ctx context.Context, organizationID, monitorID uuid.UUID, resolution string, from, to time.Time) ([]models.MonitorStatsPoint, error) {
+	query, err := sr.rollupQuery(ctx, organizationID, monitorID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []models.MonitorStatsPoint
+	err = query.
+		Select("bucket, " + statsColumns).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&points).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monitor stats series: %w", err)
+	}
+	for i := range points {
+		points[i].ComputeAvailability()
+	}
+	return points, nil
+}
+
+// OrganizationSummaries aggregates every bucket in [from, to) into one point per monitor
+// of the organization that has check results in the range
+func (sr *monitorStatsRepository) OrganizationSummaries(ctx context.Context, organizationID uuid.UUID, resolution string, from, to time.Time) ([]models.MonitorStatsSummary, error) {
+	query, err := sr.organizationRollupQuery(ctx, organizationID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []models.MonitorStatsSummary
+	err = query.
+		Select("monitor_id, " + statsColumns).
+		Group("monitor_id").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization monitor stats: %w", err)
+	}
+	for i := range summaries {
+		summaries[i].Bucket = from
+		summaries[i].ComputeAvailability()
+	}
+	return summaries, nil
+}
+
+// rollupQuery selects the rollup rows of one monitor within [from, to)
+func (sr *monitorStatsRepository) rollupQuery(ctx context.Context, organizationID, monitorID uuid.UUID, resolution string, from, to time.Time) (*gorm.DB, error) {
+	query, err := sr.organizationRollupQuery(ctx, organizationID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return query.Where("monitor_id = ?", monitorID), nil
+}
+
+// organizationRollupQuery selects the rollup rows of every monitor of an organization
+// within [from, to)
+func (sr *monitorStatsRepository) organizationRollupQuery(ctx context.Context, organizationID uuid.UUID, resolution string, from, to time.Time) (*gorm.DB, error) {
+	if sr.db == nil {
+		return nil, ErrMonitorStatsDisabled
+	}
+
+	rollup, ok := checkResultRollups[resolution]
+	if !ok {
+		return nil, fmt.Errorf("unsupported stats resolution %q", resolution)
+	}
+
+	return sr.db.WithContext(ctx).
+		Table(rollup.table).
+		Where("organization_id = ?", organizationID).
+		Where("bucket >= ? AND bucket < ?", from, to), nil
+}
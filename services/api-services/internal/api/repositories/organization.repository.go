@@ -1 +1,86 @@
 package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository defines the interface for organization data operations
+type OrganizationRepository interface {
+	IsMember(ctx context.Context, organizationID, userID uuid.UUID) (bool, error)
+	ListIDsByMember(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) error
+}
+
+// organizationRepository implements OrganizationRepository interface
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new instance of organizationRepository
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+// IsMember checks whether a user belongs to an organization
+func (or *organizationRepository) IsMember(ctx context.Context, organizationID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, or.db).
+		Model(&models.OrganizationUser{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization membership: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListIDsByMember returns the IDs of every organization the user belongs to
+func (or *organizationRepository) ListIDsByMember(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := database.Conn(ctx, or.db).
+		Model(&models.OrganizationUser{}).
+		Where("user_id = ?", userID).
+		Pluck("organization_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user organizations: %w", err)
+	}
+	return ids, nil
+}
+
+// GetByID retrieves an organization, returning common.ErrNotFound when there is none or it
+// was deleted
+func (or *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	var organization models.Organization
+	if err := database.Conn(ctx, or.db).Where("id = ?", id).First(&organization).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &organization, nil
+}
+
+// UpdateTimezone sets the time zone of an organization, returning common.ErrNotFound when
+// there is none or it was deleted
+func (or *organizationRepository) UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) error {
+	result := database.Conn(ctx, or.db).
+		Model(&models.Organization{}).
+		Where("id = ?", id).
+		Update("timezone", timezone)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update organization timezone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
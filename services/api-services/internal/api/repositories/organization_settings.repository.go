@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationSettingsRepository defines the interface for organization settings operations
+type OrganizationSettingsRepository interface {
+	Get(ctx context.Context, organizationID uuid.UUID) (*models.OrganizationSettings, error)
+	Upsert(ctx context.Context, settings *models.OrganizationSettings) error
+}
+
+// organizationSettingsRepository implements OrganizationSettingsRepository interface
+type organizationSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationSettingsRepository creates a new instance of organizationSettingsRepository
+func NewOrganizationSettingsRepository(db *gorm.DB) OrganizationSettingsRepository {
+	return &organizationSettingsRepository{db: db}
+}
+
+// Get retrieves the settings of an organization
+func (r *organizationSettingsRepository) Get(ctx context.Context, organizationID uuid.UUID) (*models.OrganizationSettings, error) {
+	var settings models.OrganizationSettings
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		First(&settings).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// Upsert creates the settings of their organization or replaces the existing ones
+func (r *organizationSettingsRepository) Upsert(ctx context.Context, settings *models.OrganizationSettings) error {
+	err := database.Conn(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "organization_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"default_interval_seconds", "default_timeout_seconds", "default_regions",
+					"escalation_after_minutes", "escalation_repeat_minutes", "updated_at",
+				}),
+			},
+			clause.Returning{},
+		).
+		Create(settings).Error
+	if err != nil {
+		return fmt.Errorf("failed to save organization settings: %w", err)
+	}
+	return nil
+}
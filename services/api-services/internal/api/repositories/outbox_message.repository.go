@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// OutboxMessageRepository defines the interface for inspecting and recovering outbox messages
+type OutboxMessageRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.OutboxMessage, error)
+	List(ctx context.Context, filter, order Scope, limit, offset int) ([]models.OutboxMessage, int64, error)
+	Requeue(ctx context.Context, id uuid.UUID) error
+	RequeueFailed(ctx context.Context, topic string) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// outboxMessageRepository implements OutboxMessageRepository interface
+type outboxMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxMessageRepository creates a new instance of outboxMessageRepository
+func NewOutboxMessageRepository(db *gorm.DB) OutboxMessageRepository {
+	return &outboxMessageRepository{db: db}
+}
+
+// GetByID retrieves an outbox message with its error history
+func (r *outboxMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.OutboxMessage, error) {
+	var message models.OutboxMessage
+	err := database.Conn(ctx, r.db).
+		Where("id = ?", id).
+		First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get outbox message: %w", err)
+	}
+	return &message, nil
+}
+
+// List lists outbox messages with caller-provided filter and order scopes, returning the
+// page and the total number of matching messages
+func (r *outboxMessageRepository) List(ctx context.Context, filter, order Scope, limit, offset int) ([]models.OutboxMessage, int64, error) {
+	query := database.Conn(ctx, r.db).
+		Model(&models.OutboxMessage{}).
+		Scopes(filter).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count outbox messages: %w", err)
+	}
+
+	var messages []models.OutboxMessage
+	err := query.
+		Scopes(order).
+		Order("id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&messages).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	return messages, total, nil
+}
+
+// Requeue makes a pending or failed message due now with a fresh set of attempts. Its
+// error history is kept. Sent messages fail with common.ErrOutboxMessageSent.
+func (r *outboxMessageRepository) Requeue(ctx context.Context, id uuid.UUID) error {
+	result := database.Conn(ctx, r.db).
+		Model(&models.OutboxMessage{}).
+		Where("id = ? AND status IN ?", id, []string{models.OutboxStatusPending, models.OutboxStatusFailed}).
+		Updates(requeueColumns())
+	if result.Error != nil {
+		return fmt.Errorf("failed to requeue outbox message: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.unchangedError(ctx, id)
+	}
+	return nil
+}
+
+// RequeueFailed requeues every failed message, only those of topic when it is set, and
+// returns how many were requeued
+func (r *outboxMessageRepository) RequeueFailed(ctx context.Context, topic string) (int64, error) {
+	query := database.Conn(ctx, r.db).
+		Model(&models.OutboxMessage{}).
+		Where("status = ?", models.OutboxStatusFailed)
+	if topic != "" {
+		query = query.Where("topic = ?", topic)
+	}
+
+	result := query.Updates(requeueColumns())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to requeue failed outbox messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// Delete discards a pending or failed message. Sent messages fail with
+// common.ErrOutboxMessageSent, they are removed by the relay once past retention.
+func (r *outboxMessageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := database.Conn(ctx, r.db).
+		Where("id = ? AND status IN ?", id, []string{models.OutboxStatusPending, models.OutboxStatusFailed}).
+		Delete(&models.OutboxMessage{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete outbox message: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.unchangedError(ctx, id)
+	}
+	return nil
+}
+
+// requeueColumns are the columns reset when a message is queued again
+func requeueColumns() map[string]any {
+	return map[string]any{
+		"status":       models.OutboxStatusPending,
+		"attempts":     0,
+		"available_at": time.Now().UTC(),
+		"processed_at": nil,
+	}
+}
+
+// unchangedError explains why a conditional change of message id matched no row
+func (r *outboxMessageRepository) unchangedError(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return common.ErrOutboxMessageSent
+}
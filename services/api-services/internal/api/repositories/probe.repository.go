@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProbeRepository defines the interface for probe data operations
+type ProbeRepository interface {
+	List(ctx context.Context) ([]models.Probe, error)
+	Touch(ctx context.Context, probes []models.Probe) error
+}
+
+// probeRepository implements ProbeRepository interface
+type probeRepository struct {
+	db *gorm.DB
+}
+
+// NewProbeRepository creates a new instance of probeRepository
+func NewProbeRepository(db *gorm.DB) ProbeRepository {
+	return &probeRepository{db: db}
+}
+
+// List lists every probe, by region and probe
+func (r *probeRepository) List(ctx context.Context) ([]models.Probe, error) {
+	var probes []models.Probe
+	err := database.Conn(ctx, r.db).
+		Order("region ASC, probe_id ASC").
+		Find(&probes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list probes: %w", err)
+	}
+	return probes, nil
+}
+
+// Touch records that probes were seen at their LastSeenAt, in their region, adding the ones
+// seen for the first time
+func (r *probeRepository) Touch(ctx context.Context, probes []models.Probe) error {
+	if len(probes) == 0 {
+		return nil
+	}
+	err := database.Conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "probe_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"region":       gorm.Expr("excluded.region"),
+				"last_seen_at": gorm.Expr("GREATEST(probes.last_seen_at, excluded.last_seen_at)"),
+				"updated_at":   gorm.Expr("excluded.updated_at"),
+			}),
+		}).
+		Create(&probes).Error
+	if err != nil {
+		return fmt.Errorf("failed to record probes: %w", err)
+	}
+	return nil
+}
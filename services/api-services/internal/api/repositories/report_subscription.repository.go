@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReportSubscriptionRepository defines the interface for uptime report subscription operations
+type ReportSubscriptionRepository interface {
+	Upsert(ctx context.Context, subscription *models.ReportSubscription) error
+	Get(ctx context.Context, organizationID, userID uuid.UUID) (*models.ReportSubscription, error)
+	Delete(ctx context.Context, organizationID, userID uuid.UUID) error
+	DeleteByToken(ctx context.Context, token string) error
+	ListTimezones(ctx context.Context, frequency string) ([]string, error)
+	ListDue(ctx context.Context, frequency, timezone string, periodEnd time.Time, afterID uuid.UUID, limit int) ([]models.DueReportSubscription, error)
+	MarkSent(ctx context.Context, id uuid.UUID, periodEnd time.Time) error
+}
+
+// reportSubscriptionRepository implements ReportSubscriptionRepository interface
+type reportSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewReportSubscriptionRepository creates a new instance of reportSubscriptionRepository
+func NewReportSubscriptionRepository(db *gorm.DB) ReportSubscriptionRepository {
+	return &reportSubscriptionRepository{db: db}
+}
+
+// Upsert creates the subscription of its member, or changes the frequency and last
+// reported period of the existing one. The unsubscribe token of an existing subscription
+// is kept so links in reports already sent keep working.
+func (r *reportSubscriptionRepository) Upsert(ctx context.Context, subscription *models.ReportSubscription) error {
+	err := database.Conn(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"frequency", "last_period_end", "updated_at"}),
+			},
+			clause.Returning{},
+		).
+		Create(subscription).Error
+	if err != nil {
+		return fmt.Errorf("failed to save report subscription: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves the subscription of a member of an organization
+func (r *reportSubscriptionRepository) Get(ctx context.Context, organizationID, userID uuid.UUID) (*models.ReportSubscription, error) {
+	var subscription models.ReportSubscription
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get report subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// Delete removes the subscription of a member of an organization
+func (r *reportSubscriptionRepository) Delete(ctx context.Context, organizationID, userID uuid.UUID) error {
+	result := database.Conn(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&models.ReportSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete report subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByToken removes the subscription whose unsubscribe link carries token
+func (r *reportSubscriptionRepository) DeleteByToken(ctx context.Context, token string) error {
+	result := database.Conn(ctx, r.db).
+		Where("unsubscribe_token = ?", token).
+		Delete(&models.ReportSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete report subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// ListTimezones lists the time zones of the organizations with subscriptions of frequency,
+// whose report periods end at their own midnight
+func (r *reportSubscriptionRepository) ListTimezones(ctx context.Context, frequency string) ([]string, error) {
+	var timezones []string
+	err := database.Conn(ctx, r.db).
+		Table("report_subscriptions").
+		Joins("JOIN organizations ON organizations.id = report_subscriptions.organization_id AND organizations.deleted_at IS NULL").
+		Where("report_subscriptions.frequency = ?", frequency).
+		Distinct().
+		Pluck("organizations.timezone", &timezones).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report subscription timezones: %w", err)
+	}
+	return timezones, nil
+}
+
+// ListDue lists up to limit subscriptions of frequency to organizations in timezone, ordered
+// by ID after afterID, that have not been sent the period ending at periodEnd. Subscriptions
+// of members who left the organization, of deleted users or organizations and of unverified
+// addresses are skipped.
+func (r *reportSubscriptionRepository) ListDue(ctx context.Context, frequency, timezone string, periodEnd time.Time, afterID uuid.UUID, limit int) ([]models.DueReportSubscription, error) {
+	var due []models.DueReportSubscription
+	err := database.Conn(ctx, r.db).
+		Table("report_subscriptions").
+		Select("report_subscriptions.*, users.email AS email, users.locale AS locale, organizations.name AS organization_name").
+		Joins("JOIN users ON users.id = report_subscriptions.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN organizations ON organizations.id = report_subscriptions.organization_id AND organizations.deleted_at IS NULL").
+		Joins("JOIN organization_users ON organization_users.organization_id = report_subscriptions.organization_id AND organization_users.user_id = report_subscriptions.user_id").
+		Where("users.email IS NOT NULL AND users.email_verified_at IS NOT NULL").
+		Where("report_subscriptions.frequency = ? AND organizations.timezone = ?", frequency, timezone).
+		Where("(report_subscriptions.last_period_end IS NULL OR report_subscriptions.last_period_end < ?)", periodEnd).
+		Where("report_subscriptions.id > ?", afterID).
+		Order("report_subscriptions.id ASC").
+		Limit(limit).
+		Scan(&due).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due report subscriptions: %w", err)
+	}
+	return due, nil
+}
+
+// MarkSent records that the period ending at periodEnd was reported to the subscription
+func (r *reportSubscriptionRepository) MarkSent(ctx context.Context, id uuid.UUID, periodEnd time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.ReportSubscription{}).
+		Where("id = ?", id).
+		Update("last_period_end", periodEnd).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark report sent: %w", err)
+	}
+	return nil
+}
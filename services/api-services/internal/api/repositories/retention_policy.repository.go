@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RetentionPolicyRepository defines the interface for organization retention policy operations
+type RetentionPolicyRepository interface {
+	Get(ctx context.Context, organizationID uuid.UUID) (*models.RetentionPolicy, error)
+	List(ctx context.Context) ([]models.RetentionPolicy, error)
+	Upsert(ctx context.Context, policy *models.RetentionPolicy) error
+	Delete(ctx context.Context, organizationID uuid.UUID) error
+}
+
+// retentionPolicyRepository implements RetentionPolicyRepository interface
+type retentionPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionPolicyRepository creates a new instance of retentionPolicyRepository
+func NewRetentionPolicyRepository(db *gorm.DB) RetentionPolicyRepository {
+	return &retentionPolicyRepository{db: db}
+}
+
+// Get retrieves the retention policy of an organization
+func (r *retentionPolicyRepository) Get(ctx context.Context, organizationID uuid.UUID) (*models.RetentionPolicy, error) {
+	var policy models.RetentionPolicy
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get retention policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// List retrieves the retention policy of every organization that has one
+func (r *retentionPolicyRepository) List(ctx context.Context) ([]models.RetentionPolicy, error) {
+	var policies []models.RetentionPolicy
+	if err := database.Conn(ctx, r.db).Order("organization_id ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list retention policies: %w", err)
+	}
+	return policies, nil
+}
+
+// Upsert creates the retention policy of its organization or replaces the windows of the
+// existing one
+func (r *retentionPolicyRepository) Upsert(ctx context.Context, policy *models.RetentionPolicy) error {
+	err := database.Conn(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organization_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"check_results_days", "rollups_days", "incidents_days", "updated_at"}),
+			},
+			clause.Returning{},
+		).
+		Create(policy).Error
+	if err != nil {
+		return fmt.Errorf("failed to save retention policy: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the retention policy of an organization
+func (r *retentionPolicyRepository) Delete(ctx context.Context, organizationID uuid.UUID) error {
+	result := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Delete(&models.RetentionPolicy{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete retention policy: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
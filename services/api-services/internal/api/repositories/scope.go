@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Scope is a reusable query modifier passed to repository list methods.
+type Scope = func(*gorm.DB) *gorm.DB
+
+// ByOrganization restricts a query to rows owned by the organization
+func ByOrganization(organizationID uuid.UUID) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("organization_id = ?", organizationID)
+	}
+}
+
+// Paginate limits a query to one page; a non-positive limit leaves the query unbounded
+func Paginate(limit, offset int) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if limit > 0 {
+			db = db.Limit(limit)
+		}
+		if offset > 0 {
+			db = db.Offset(offset)
+		}
+		return db
+	}
+}
+
+// OrderBy sorts a query by a trusted column expression such as "created_at DESC"
+func OrderBy(order string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(order)
+	}
+}
+
+// WithDeleted includes soft-deleted rows in a query
+func WithDeleted() Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// searchSource describes a table searchable through the /search endpoint. document is
+// the tsvector expression matched against the query; it must be identical in the index
+// and the query so Postgres uses the index. live is the condition rows must meet to be
+// listed, such as not being soft deleted, empty when every row is.
+type searchSource struct {
+	resultType string
+	table      string
+	document   string
+	title      string
+	subtitle   string
+	live       string
+}
+
+// searchSources lists every searchable table; add status pages here once they land
+var searchSources = []searchSource{
+	{
+		resultType: models.SearchResultTypeMonitor,
+		table:      "monitors",
+		// Targets are split on punctuation so "example" matches "https://api.example.com/health".
+		document: `(setweight(to_tsvector('simple', coalesce(name, '')), 'A') || ` +
+			`setweight(to_tsvector('simple', regexp_replace(coalesce(target, ''), '[^[:alnum:]]+', ' ', 'g')), 'B'))`,
+		title:    "name",
+		subtitle: "target",
+		live:     "deleted_at IS NULL",
+	},
+	{
+		resultType: models.SearchResultTypeIncident,
+		table:      "incidents",
+		document: `(setweight(to_tsvector('simple', coalesce(title, '')), 'A') || ` +
+			`setweight(to_tsvector('simple', coalesce(description, '')), 'B'))`,
+		title:    "title",
+		subtitle: "status",
+	},
+}
+
+// searchTermPattern extracts the words of a query; everything else is ignored so user
+// input can never break the tsquery syntax
+var searchTermPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// SearchIndexMigrations returns the DDL creating a GIN index over each search document
+func SearchIndexMigrations() []database.SQLObjectToCreate {
+	objects := make([]database.SQLObjectToCreate, 0, len(searchSources))
+	for _, source := range searchSources {
+		objects = append(objects, database.SQLObjectToCreate{
+			Description: source.table + " search index",
+			Query:       fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_search ON %s USING GIN (%s)", source.table, source.table, source.document),
+		})
+	}
+	return objects
+}
+
+// SearchRepository defines the interface for full-text search across entities
+type SearchRepository interface {
+	Search(ctx context.Context, organizationIDs []uuid.UUID, query string, limit int) ([]models.SearchResult, error)
+}
+
+// searchRepository implements SearchRepository with Postgres full-text search
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new instance of searchRepository
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search returns up to limit results from the organizations, best match first. Every word
+// of the query must match, and the last word also matches as a prefix for search-as-you-type.
+func (sr *searchRepository) Search(ctx context.Context, organizationIDs []uuid.UUID, query string, limit int) ([]models.SearchResult, error) {
+	tsQuery := prefixTSQuery(query)
+	if tsQuery == "" || len(organizationIDs) == 0 {
+		return []models.SearchResult{}, nil
+	}
+
+	selects := make([]string, 0, len(searchSources))
+	args := make([]interface{}, 0, len(searchSources)*2+1)
+	for _, source := range searchSources {
+		where := "organization_id IN ? AND " + source.document + " @@ q.query"
+		if source.live != "" {
+			where = source.live + " AND " + where
+		}
+		selects = append(selects, fmt.Sprintf(
+			`SELECT '%s' AS type, id, organization_id, %s AS title, %s AS subtitle, ts_rank_cd(%s, q.query) AS rank
+FROM %s, to_tsquery('simple', ?) AS q(query)
+WHERE %s`,
+			source.resultType, source.title, source.subtitle, source.document, source.table, where,
+		))
+		args = append(args, tsQuery, organizationIDs)
+	}
+	args = append(args, limit)
+
+	sql := strings.Join(selects, "\nUNION ALL\n") + "\nORDER BY rank DESC, title ASC\nLIMIT ?"
+
+	results := []models.SearchResult{}
+	if err := database.Conn(ctx, sr.db).Raw(sql, args...).Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	return results, nil
+}
+
+// prefixTSQuery turns free text into a tsquery requiring every word, with the last word
+// matched as a prefix: "api exam" becomes "api & exam:*"
+func prefixTSQuery(query string) string {
+	terms := searchTermPattern.FindAllString(strings.ToLower(query), -1)
+	if len(terms) == 0 {
+		return ""
+	}
+	terms[len(terms)-1] += ":*"
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// SLATargetRepository defines the interface for SLA target data operations
+type SLATargetRepository interface {
+	Repository[models.SLATarget]
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.SLATarget, error)
+	HasEnvironment(ctx context.Context, organizationID, environmentID uuid.UUID) (bool, error)
+	ListForEvaluation(ctx context.Context, afterID uuid.UUID, limit int) ([]models.EvaluatedSLATarget, error)
+	SaveEvaluation(ctx context.Context, id uuid.UUID, evaluation models.SLAEvaluation, evaluatedAt time.Time, alertedStatus string) error
+}
+
+// slaTargetRepository implements SLATargetRepository interface
+type slaTargetRepository struct {
+	*BaseRepository[models.SLATarget]
+	db *gorm.DB
+}
+
+// NewSLATargetRepository creates a new instance of slaTargetRepository
+func NewSLATargetRepository(db *gorm.DB) SLATargetRepository {
+	return &slaTargetRepository{
+		BaseRepository: NewBaseRepository[models.SLATarget](db, "SLA target"),
+		db:             db,
+	}
+}
+
+// ListByOrganization lists the SLA targets of an organization, oldest first
+func (r *slaTargetRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.SLATarget, error) {
+	var targets []models.SLATarget
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC, id ASC").
+		Find(&targets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLA targets: %w", err)
+	}
+	return targets, nil
+}
+
+// HasEnvironment reports whether an environment of an application of the organization
+// exists with the given ID
+func (r *slaTargetRepository) HasEnvironment(ctx context.Context, organizationID, environmentID uuid.UUID) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Table("environments").
+		Joins("JOIN applications ON applications.id = environments.application_id AND applications.deleted_at IS NULL").
+		Where("environments.id = ? AND environments.deleted_at IS NULL", environmentID).
+		Where("applications.organization_id = ?", organizationID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to look up environment: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListForEvaluation lists up to limit SLA targets, ordered by ID after afterID, with the
+// owner of their organization. Targets of deleted organizations are skipped; the owner
+// email is nil when the owner is deleted or has no verified address.
+func (r *slaTargetRepository) ListForEvaluation(ctx context.Context, afterID uuid.UUID, limit int) ([]models.EvaluatedSLATarget, error) {
+	var targets []models.EvaluatedSLATarget
+	err := database.Conn(ctx, r.db).
+		Table("sla_targets").
+		Select("sla_targets.*, organizations.name AS organization_name, users.email AS owner_email, users.locale AS owner_locale").
+		Joins("JOIN organizations ON organizations.id = sla_targets.organization_id AND organizations.deleted_at IS NULL").
+		Joins("LEFT JOIN users ON users.id = organizations.owner_id AND users.deleted_at IS NULL AND users.email_verified_at IS NOT NULL").
+		Where("sla_targets.id > ?", afterID).
+		Order("sla_targets.id ASC").
+		Limit(limit).
+		Scan(&targets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLA targets to evaluate: %w", err)
+	}
+	return targets, nil
+}
+
+// SaveEvaluation stores the state of the current period of an SLA target and the worst
+// status notified in it
+func (r *slaTargetRepository) SaveEvaluation(ctx context.Context, id uuid.UUID, evaluation models.SLAEvaluation, evaluatedAt time.Time, alertedStatus string) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.SLATarget{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":          evaluation.Status,
+			"period_start":    evaluation.PeriodStart,
+			"availability":    evaluation.Availability,
+			"budget_consumed": evaluation.BudgetConsumed,
+			"burn_rate":       evaluation.BurnRate,
+			"evaluated_at":    evaluatedAt,
+			"alerted_status":  alertedStatus,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save SLA evaluation: %w", err)
+	}
+	return nil
+}
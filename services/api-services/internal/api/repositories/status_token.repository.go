@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// StatusTokenRepository defines the interface for status token data operations
+type StatusTokenRepository interface {
+	Repository[models.StatusToken]
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.StatusToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.StatusToken, error)
+}
+
+// statusTokenRepository implements StatusTokenRepository interface
+type statusTokenRepository struct {
+	*BaseRepository[models.StatusToken]
+	db *gorm.DB
+}
+
+// NewStatusTokenRepository creates a new instance of statusTokenRepository
+func NewStatusTokenRepository(db *gorm.DB) StatusTokenRepository {
+	return &statusTokenRepository{
+		BaseRepository: NewBaseRepository[models.StatusToken](db, "status token"),
+		db:             db,
+	}
+}
+
+// ListByOrganization lists the status tokens of an organization, newest first
+func (r *statusTokenRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.StatusToken, error) {
+	var tokens []models.StatusToken
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// GetByHash retrieves the status token with the given hash, returning common.ErrNotFound
+// when there is none or it was revoked
+func (r *statusTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.StatusToken, error) {
+	var token models.StatusToken
+	err := database.Conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get status token: %w", err)
+	}
+	return &token, nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"gorm.io/gorm"
+)
+
+// TicketIntegrationRepository defines the interface for ticket integration data operations
+type TicketIntegrationRepository interface {
+	Repository[models.TicketIntegration]
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.TicketIntegration, error)
+	ListAutoCreate(ctx context.Context, organizationID uuid.UUID) ([]models.TicketIntegration, error)
+	RecordError(ctx context.Context, id uuid.UUID, message string, at time.Time) error
+}
+
+// ticketIntegrationRepository implements TicketIntegrationRepository interface
+type ticketIntegrationRepository struct {
+	*BaseRepository[models.TicketIntegration]
+	db *gorm.DB
+}
+
+// NewTicketIntegrationRepository creates a new instance of ticketIntegrationRepository
+func NewTicketIntegrationRepository(db *gorm.DB) TicketIntegrationRepository {
+	return &ticketIntegrationRepository{
+		BaseRepository: NewBaseRepository[models.TicketIntegration](db, "ticket integration"),
+		db:             db,
+	}
+}
+
+// ListByOrganization lists the ticket integrations of an organization, newest first
+func (r *ticketIntegrationRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]models.TicketIntegration, error) {
+	var integrations []models.TicketIntegration
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id DESC").
+		Find(&integrations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// ListAutoCreate lists the ticket integrations of an organization filing every incident opened
+func (r *ticketIntegrationRepository) ListAutoCreate(ctx context.Context, organizationID uuid.UUID) ([]models.TicketIntegration, error) {
+	var integrations []models.TicketIntegration
+	err := database.Conn(ctx, r.db).
+		Where("organization_id = ? AND auto_create", organizationID).
+		Order("created_at ASC, id ASC").
+		Find(&integrations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auto create ticket integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// RecordError records the last call to the tracker of a ticket integration that failed
+func (r *ticketIntegrationRepository) RecordError(ctx context.Context, id uuid.UUID, message string, at time.Time) error {
+	err := database.Conn(ctx, r.db).
+		Model(&models.TicketIntegration{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"last_error": message, "last_error_at": at}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record ticket integration error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,908 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/openapi"
+	"github.com/samaasi/uptime-application/services/api-services/internal/checker"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/lifecycle"
+)
+
+// registerAPIDocs documents the routes registered in SetupRoutes.
+// Keep this in sync when adding or changing endpoints.
+func registerAPIDocs(spec *openapi.Builder) {
+	spec.Register(http.MethodPost, "/api/v1/auth/signup", openapi.Operation{
+		Summary:     "Register a new user",
+		Description: "Creates an account and sends an email verification code. Requires the X-Captcha-Token header when CAPTCHA is enabled.",
+		Tags:        []string{"auth"},
+		Request:     dtos.SignUpRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:  models.User{},
+			http.StatusConflict: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/auth/signin", openapi.Operation{
+		Summary:     "Sign in with email and password",
+		Description: "Requires the X-Captcha-Token header when CAPTCHA is enabled.",
+		Tags:        []string{"auth"},
+		Request:     dtos.SignInRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:           dtos.SignInResponseDto{},
+			http.StatusUnauthorized: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/auth/forgot-password", openapi.Operation{
+		Summary:     "Request a password reset code",
+		Description: "Always returns 202 to avoid revealing whether the account exists.",
+		Tags:        []string{"auth"},
+		Request:     dtos.ForgotPasswordRequest{},
+		Responses: map[int]any{
+			http.StatusAccepted: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/auth/phone/verification", openapi.Operation{
+		Summary:     "Send a phone verification code",
+		Description: "Texts a verification code to the phone number of the caller. Answers 400 when the account has no phone number and 409 when it is already verified. Served when SMS_ENABLE is set.",
+		Tags:        []string{"auth"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusAccepted:   nil,
+			http.StatusBadRequest: nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/auth/phone/verify", openapi.Operation{
+		Summary:     "Verify the phone number",
+		Description: "Confirms the phone number of the caller with the code texted to it. Served when SMS_ENABLE is set.",
+		Tags:        []string{"auth"},
+		Secured:     true,
+		Request:     dtos.VerifyPhoneRequest{},
+		Responses: map[int]any{
+			http.StatusOK:         nil,
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/monitors", openapi.Operation{
+		Summary:     "List monitors",
+		Description: "Supports filter[status|type|environment_id|owner_user_id|owner_team]=a,b, sort=-created_at,name (fields: name, status, created_at, updated_at, last_checked_at), q= search on name and target, and page/per_page pagination. format=csv streams all matching monitors as a CSV download.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Query:       listQueryParameters("status", "type", "environment_id", "owner_user_id", "owner_team"),
+		Responses: map[int]any{
+			http.StatusOK:         []models.Monitor{},
+			http.StatusBadRequest: nil,
+			http.StatusForbidden:  nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/monitors/export-link", openapi.Operation{
+		Summary:     "Create a signed CSV export link",
+		Description: "Accepts the same filter, sort and q parameters as the list endpoint and returns an expiring link that downloads the export without authentication.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Query:       listQueryParameters("status", "type", "environment_id", "owner_user_id", "owner_team"),
+		Responses: map[int]any{
+			http.StatusCreated:    utils.SignedLink{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/monitors/validate", openapi.Operation{
+		Summary:     "Try a monitor check",
+		Description: "Runs the check of monitor settings once, the way probes run it, and returns the full result without saving anything: status, latency, status code and error, plus the response headers, the start of the body and the certificate expiry of HTTP checks. Targets resolving to addresses that are not public are rejected with 400. Ping checks need raw sockets and are rejected with 422.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Request:     dtos.ValidateMonitorRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:                  checker.Result{},
+			http.StatusBadRequest:          nil,
+			http.StatusUnprocessableEntity: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/monitors/discover", openapi.Operation{
+		Summary:     "Discover monitors",
+		Description: "Fetches the sitemap or OpenAPI document of a site or API and previews an HTTP monitor for every page of the site, or every GET operation of the API without path parameters or required authentication. url is the site root, where documents are looked for at their usual paths, or the document itself. Only public addresses are fetched. Nothing is created: send the monitors to keep to the import endpoint.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Request:     dtos.DiscoverMonitorsRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:                  dtos.MonitorDiscoveryDto{},
+			http.StatusBadRequest:          nil,
+			http.StatusUnprocessableEntity: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/monitors/import", openapi.Operation{
+		Summary:     "Import discovered monitors",
+		Description: "Creates the HTTP monitors picked from a discovery preview. Monitors whose target is already monitored are skipped.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Request:     dtos.ImportMonitorsRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    dtos.ImportMonitorsResultDto{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/downloads/organizations/:organizationId/monitors.csv", openapi.Operation{
+		Summary:     "Download a signed monitor CSV export",
+		Description: "Only valid with the exp and sig parameters issued by the export-link endpoint.",
+		Tags:        []string{"monitors"},
+		Responses: map[int]any{
+			http.StatusOK:           nil,
+			http.StatusUnauthorized: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/monitors/:monitorId", openapi.Operation{
+		Summary: "Get a monitor",
+		Tags:    []string{"monitors"},
+		Secured: true,
+		Query:   []openapi.Parameter{fieldsParameter()},
+		Responses: map[int]any{
+			http.StatusOK:        models.Monitor{},
+			http.StatusNotFound:  nil,
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPatch, "/api/v1/organizations/:organizationId/monitors/:monitorId", openapi.Operation{
+		Summary:     "Update a monitor",
+		Description: "Send the version returned when the monitor was read. The update is rejected with 409 when the monitor changed since, so concurrent edits do not overwrite each other.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Request:     dtos.UpdateMonitorRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         models.Monitor{},
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/monitors/:monitorId/owner", openapi.Operation{
+		Summary:     "Set the monitor owner",
+		Description: "Replaces the member and team owning a monitor, such as payments-team. The incidents the monitor opens are assigned to its owner and carry its team, and its status changes name them. The owner must be a member of the organization; send null and an empty team to remove the owner.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Request:     dtos.SetMonitorOwnerRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         models.Monitor{},
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/monitors/:monitorId/check-now", openapi.Operation{
+		Summary:     "Check a monitor now",
+		Description: "Queues an immediate check of the monitor out of schedule, such as to verify that it recovered after a fix. The check runs from the API, and its result is recorded like the ones of probes, from the api region, updating the status and incidents of the monitor. A monitor can be checked once every 30 seconds; sooner requests are rejected with 429 and Retry-After. Paused monitors are rejected with 409, and ping monitors with 422.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusAccepted:            models.Monitor{},
+			http.StatusNotFound:            nil,
+			http.StatusConflict:            nil,
+			http.StatusUnprocessableEntity: nil,
+			http.StatusTooManyRequests:     nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/monitors/:monitorId/stats", openapi.Operation{
+		Summary:     "Get monitor uptime and latency stats",
+		Description: "Reads pre-aggregated ClickHouse rollups. Defaults to the last 24 hours; the resolution is picked from the range (minute up to 1 day, hour up to 31 days, day beyond) unless given explicitly.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "from", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "to", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "resolution", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []string{models.StatsResolutionMinute, models.StatsResolutionHour, models.StatsResolutionDay}}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:                 models.MonitorStats{},
+			http.StatusBadRequest:         nil,
+			http.StatusNotFound:           nil,
+			http.StatusServiceUnavailable: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/monitors/:monitorId/dependencies", openapi.Operation{
+		Summary: "Get monitor dependencies",
+		Tags:    []string{"monitors"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:       dtos.MonitorDependenciesDto{},
+			http.StatusNotFound: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/monitors/:monitorId/dependencies", openapi.Operation{
+		Summary:     "Set monitor dependencies",
+		Description: "Replaces the monitors a monitor depends on, such as an API on the load balancer of its database. While one of them is down, the monitor going down gets a suppressed incident grouped under the incident of that monitor instead of alerting on its own. Dependencies cannot form a cycle.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Request:     dtos.SetMonitorDependenciesRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         dtos.MonitorDependenciesDto{},
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/monitors/:monitorId/results", openapi.Operation{
+		Summary:     "List monitor check results",
+		Description: "Reads raw check results from ClickHouse, newest first. Defaults to the last 24 hours. Supports filter[status|region|probe_id]=a,b and cursor pagination: pass meta.cursor.next_cursor back as cursor to get the next page. format=csv streams every matching result of the range as a CSV download.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "from", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "to", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "filter[status]", In: "query", Description: "Comma-separated values to match", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "filter[region]", In: "query", Description: "Comma-separated values to match", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "filter[probe_id]", In: "query", Description: "Comma-separated values to match", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "cursor", In: "query", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+			fieldsParameter(),
+			{Name: "format", In: "query", Description: "Set to csv to download all matching rows as CSV", Schema: &openapi.Schema{Type: "string", Enum: []string{"csv"}}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:                 []models.CheckResult{},
+			http.StatusBadRequest:         nil,
+			http.StatusNotFound:           nil,
+			http.StatusServiceUnavailable: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/config", openapi.Operation{
+		Summary:     "Sync the organization configuration",
+		Description: "Diffs a declarative document of monitors against the organization, matching monitors by name, and applies the creates, updates and deletes in a single transaction. Monitors the document does not list are deleted. With dry_run=true the plan is returned without applying it.",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "dry_run", In: "query", Schema: &openapi.Schema{Type: "boolean"}},
+		},
+		Request: dtos.OrganizationConfigDto{},
+		Responses: map[int]any{
+			http.StatusOK:         dtos.ConfigPlanDto{},
+			http.StatusBadRequest: nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/status-tokens", openapi.Operation{
+		Summary: "List status tokens",
+		Tags:    []string{"status"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.StatusToken{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/status-tokens", openapi.Operation{
+		Summary:     "Create a status token",
+		Description: "Issues a read-only token for the status and stats of the given monitors, to embed availability in dashboards without an account. The token is only returned in this response.",
+		Tags:        []string{"status"},
+		Secured:     true,
+		Request:     dtos.CreateStatusTokenRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    dtos.CreatedStatusTokenDto{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodDelete, "/api/v1/organizations/:organizationId/status-tokens/:tokenId", openapi.Operation{
+		Summary: "Revoke a status token",
+		Tags:    []string{"status"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusNoContent: nil,
+			http.StatusNotFound:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/status/monitors", openapi.Operation{
+		Summary:     "Get the status of the monitors of a status token",
+		Description: "Authorized by a status token, as a bearer token or in the token query parameter.",
+		Tags:        []string{"status"},
+		Query: []openapi.Parameter{
+			{Name: "token", In: "query", Schema: &openapi.Schema{Type: "string"}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:           []models.PublicMonitorStatus{},
+			http.StatusUnauthorized: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/status/monitors/:monitorId/stats", openapi.Operation{
+		Summary:     "Get the uptime and latency stats of a monitor of a status token",
+		Description: "Authorized by a status token, as a bearer token or in the token query parameter. Accepts the parameters of the organization stats endpoint.",
+		Tags:        []string{"status"},
+		Query: []openapi.Parameter{
+			{Name: "token", In: "query", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "from", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "to", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "resolution", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []string{models.StatsResolutionMinute, models.StatsResolutionHour, models.StatsResolutionDay}}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:                 models.MonitorStats{},
+			http.StatusBadRequest:         nil,
+			http.StatusUnauthorized:       nil,
+			http.StatusNotFound:           nil,
+			http.StatusServiceUnavailable: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/integrations", openapi.Operation{
+		Summary: "List inbound integrations",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.InboundIntegration{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/integrations", openapi.Operation{
+		Summary:     "Create an inbound integration",
+		Description: "Adds an integration receiving the alert webhooks of Prometheus Alertmanager, Grafana or UptimeRobot, which open and resolve incidents. The webhook token is only returned in this response.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Request:     dtos.CreateInboundIntegrationRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    dtos.CreatedInboundIntegrationDto{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodDelete, "/api/v1/organizations/:organizationId/integrations/:integrationId", openapi.Operation{
+		Summary: "Delete an inbound integration",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusNoContent: nil,
+			http.StatusNotFound:  nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/integrations/inbound", openapi.Operation{
+		Summary:     "Receive the alerts of an inbound integration",
+		Description: "Webhook URL of inbound integrations, authorized by the integration token as a bearer token or in the token query parameter. The payload is the webhook format of the integration provider. A firing alert opens an incident unless one is already open for it, and a resolved alert resolves it.",
+		Tags:        []string{"incidents"},
+		Query: []openapi.Parameter{
+			{Name: "token", In: "query", Schema: &openapi.Schema{Type: "string"}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:           dtos.InboundAlertResultDto{},
+			http.StatusBadRequest:   nil,
+			http.StatusUnauthorized: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/chat-integrations", openapi.Operation{
+		Summary: "List chat integrations",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.ChatIntegration{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/chat-integrations", openapi.Operation{
+		Summary:     "Create a chat integration",
+		Description: "Adds an integration receiving the slash commands of the Slack or Discord app of the organization. verification_key is the signing secret of the Slack app, or the hex public key of the Discord application. Point the slash command, or the interactions endpoint of the Discord application, at /api/v1/integrations/chat/{id}.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Request:     dtos.CreateChatIntegrationRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    models.ChatIntegration{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodDelete, "/api/v1/organizations/:organizationId/chat-integrations/:integrationId", openapi.Operation{
+		Summary: "Delete a chat integration",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusNoContent: nil,
+			http.StatusNotFound:  nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/integrations/chat/:integrationId", openapi.Operation{
+		Summary:     "Run a chat slash command",
+		Description: "Slash command endpoint of chat integrations, authorized by the request signature of Slack or Discord. Understands status [name or team], incidents, ack <incident> such as ack INC-1A2B3C4D, and help. The response is the message format of the platform, shown only to whoever typed the command.",
+		Tags:        []string{"incidents"},
+		Responses: map[int]any{
+			http.StatusOK:           nil,
+			http.StatusBadRequest:   nil,
+			http.StatusUnauthorized: nil,
+			http.StatusNotFound:     nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/incidents", openapi.Operation{
+		Summary:     "List incidents",
+		Description: "Supports filter[status|severity|source|integration_id|monitor_id|parent_id|suppressed|assignee_id|team]=a,b, sort=-started_at (fields: started_at, resolved_at, created_at), q= search on title, and page/per_page pagination. format=csv streams all matching incidents as a CSV download. Incidents of monitors that went down while a monitor they depend on was down are suppressed and list the incident they are grouped under as parent_id. Incidents of monitors are assigned to the owner of the monitor and carry its team. Monitors spending the error budget of an SLA target too fast get warning incidents with source sla.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Query:       listQueryParameters("status", "severity", "source", "integration_id", "monitor_id", "parent_id", "suppressed", "assignee_id", "team"),
+		Responses: map[int]any{
+			http.StatusOK:         []models.Incident{},
+			http.StatusBadRequest: nil,
+			http.StatusForbidden:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/incidents/workload", openapi.Operation{
+		Summary:     "Get the incident workload",
+		Description: "How many incidents of the organization are open for each assignee, busiest first, and when the oldest of them started. Unassigned incidents are counted under a null assignee_id.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.IncidentWorkload{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/incidents/analytics", openapi.Operation{
+		Summary:     "Get incident analytics",
+		Description: "How many incidents of the organization were resolved over the range and their mean time to resolve in seconds, overall, by cause and for the 50 monitors with the most incidents. Untagged incidents are counted under an empty cause; suppressed incidents are not counted. Read from ClickHouse, where incidents are recorded shortly after they are resolved or tagged. Defaults to the last 30 days.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "from", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+			{Name: "to", In: "query", Schema: &openapi.Schema{Type: "string", Format: "date-time"}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:                 models.IncidentAnalytics{},
+			http.StatusBadRequest:         nil,
+			http.StatusForbidden:          nil,
+			http.StatusServiceUnavailable: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/incidents/:incidentId/assignee", openapi.Operation{
+		Summary:     "Assign an incident",
+		Description: "Assigns the incident to a member of the organization, or unassigns it when assignee_id is null. Send the version returned when the incident was read; the assignment is rejected with 409 when the incident changed since. Dashboards are notified with an incident.assigned event.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Request:     dtos.AssignIncidentRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         models.Incident{},
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/incidents/:incidentId/claim", openapi.Operation{
+		Summary:     "Claim an incident",
+		Description: "Assigns the incident to the caller. Rejected with 409 when it is assigned to someone else, who has to hand it over by reassigning it.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:       models.Incident{},
+			http.StatusNotFound: nil,
+			http.StatusConflict: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/incidents/:incidentId/cause", openapi.Operation{
+		Summary:     "Tag the cause of an incident",
+		Description: "Tags a resolved incident with its root cause: deploy, dns, provider_outage, network, certificate, capacity, configuration or other. An empty cause untags it. Send the version returned when the incident was read. Rejected with 409 while the incident is open or when it changed since.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Request:     dtos.TagIncidentCauseRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         models.Incident{},
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/ticket-integrations", openapi.Operation{
+		Summary: "List ticket integrations",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.TicketIntegration{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/ticket-integrations", openapi.Operation{
+		Summary:     "Create a ticket integration",
+		Description: "Adds an integration filing incidents as Jira or Linear issues. Jira needs base_url (the site, such as https://example.atlassian.net), email, api_token and the project key, with issue_type defaulting to Bug. Linear needs an API key as api_token and the team ID as project. With auto_create every incident opened is filed; issues are moved to a done state when their incident is resolved.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Request:     dtos.CreateTicketIntegrationRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    models.TicketIntegration{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodDelete, "/api/v1/organizations/:organizationId/ticket-integrations/:integrationId", openapi.Operation{
+		Summary: "Delete a ticket integration",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusNoContent: nil,
+			http.StatusNotFound:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/incidents/:incidentId/tickets", openapi.Operation{
+		Summary: "List the tickets of an incident",
+		Tags:    []string{"incidents"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:       []models.IncidentTicket{},
+			http.StatusNotFound: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/incidents/:incidentId/tickets", openapi.Operation{
+		Summary:     "File an incident as an issue",
+		Description: "Files the incident through a ticket integration of the organization. Rejected with 409 when it was already filed through it, and with 502 when the issue tracker fails.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Request:     dtos.CreateIncidentTicketRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    models.IncidentTicket{},
+			http.StatusNotFound:   nil,
+			http.StatusConflict:   nil,
+			http.StatusBadGateway: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/probes", openapi.Operation{
+		Summary:     "Get the probe network status",
+		Description: "The status of every probe and probe region, and when they last reported check results. A probe is offline when it has not reported results for 5 minutes; a region is online when all its probes are, offline when none is, and degraded otherwise. Incidents of monitors opened while regions checking them are offline carry a hint such as \"eu-west probe offline, results from us-east only\".",
+		Tags:        []string{"monitors"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:           dtos.ProbeNetworkDto{},
+			http.StatusUnauthorized: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/me/incidents", openapi.Operation{
+		Summary:     "List the caller's open incidents",
+		Description: "The open incidents assigned to the caller across their organizations, newest first, with page/per_page pagination.",
+		Tags:        []string{"incidents"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "page", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+			{Name: "per_page", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+		},
+		Responses: map[int]any{
+			http.StatusOK: []models.Incident{},
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/me/timezone", openapi.Operation{
+		Summary:     "Set the caller's time zone",
+		Description: "An IANA time zone such as Europe/Paris, which times are displayed to the caller in.",
+		Tags:        []string{"users"},
+		Secured:     true,
+		Request:     dtos.TimezoneDto{},
+		Responses: map[int]any{
+			http.StatusOK:         dtos.TimezoneDto{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/activity", openapi.Operation{
+		Summary:     "List the organization activity",
+		Description: "The incidents opened, resolved and assigned, the monitors updated, imported or given a new owner, and the configuration synced or edited in the organization, newest first, recorded from the audit events of the organization. Filter with category=incident|monitor|config, and pass meta.cursor.next_cursor back as cursor to get the next page.",
+		Tags:        []string{"organizations"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "category", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []string{"incident", "monitor", "config"}}},
+			{Name: "cursor", In: "query", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+			fieldsParameter(),
+		},
+		Responses: map[int]any{
+			http.StatusOK:         []dtos.ActivityDto{},
+			http.StatusBadRequest: nil,
+			http.StatusForbidden:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/timezone", openapi.Operation{
+		Summary: "Get the organization time zone",
+		Tags:    []string{"organizations"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:        dtos.TimezoneDto{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/timezone", openapi.Operation{
+		Summary:     "Set the organization time zone",
+		Description: "An IANA time zone such as Europe/Paris. Weekly and monthly uptime reports and requested report files cover days starting at midnight in this time zone. Defaults to UTC.",
+		Tags:        []string{"organizations"},
+		Secured:     true,
+		Request:     dtos.TimezoneDto{},
+		Responses: map[int]any{
+			http.StatusOK:         dtos.TimezoneDto{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/retention", openapi.Operation{
+		Summary:     "Get the data retention",
+		Description: "How many days raw check results, their rollups and resolved incidents of the organization are kept before the data retention job deletes them, 0 when they are kept. Custom is true when operators override a default of the deployment for the organization.",
+		Tags:        []string{"organizations"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:         models.DataRetention{},
+			http.StatusBadRequest: nil,
+			http.StatusForbidden:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/settings", openapi.Operation{
+		Summary:     "Get the organization settings",
+		Description: "The interval, timeout and regions new monitors get when they leave them unset, how alerts escalate, and the data retention set by operators.",
+		Tags:        []string{"organizations"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:        dtos.OrganizationSettingsDto{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/settings", openapi.Operation{
+		Summary:     "Set the organization settings",
+		Description: "Replaces the defaults of the organization; omitted fields fall back to checks every 60 seconds with a 10 second timeout from every region, and no escalation. Monitors imported or synced afterwards get the new defaults, existing ones keep their settings until they are synced again.",
+		Tags:        []string{"organizations"},
+		Secured:     true,
+		Request:     dtos.UpdateOrganizationSettingsRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         dtos.OrganizationSettingsDto{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/sla-targets", openapi.Operation{
+		Summary:     "List SLA targets",
+		Description: "The SLA targets of the organization with the state of their current week or calendar month: status (pending, ok, at_risk or breached), availability, the share of the error budget consumed and the recent burn rate, as of the last evaluation.",
+		Tags:        []string{"sla"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.SLATarget{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/sla-targets", openapi.Operation{
+		Summary:     "Create an SLA target",
+		Description: "An availability percentage to meet over every weekly or monthly period, for one monitor (monitor_id), the monitors of one environment (environment_id), or every monitor of the organization when neither is set. The owner of the organization is notified when the target is at risk or breached.",
+		Tags:        []string{"sla"},
+		Secured:     true,
+		Request:     dtos.CreateSLATargetRequestDto{},
+		Responses: map[int]any{
+			http.StatusCreated:    models.SLATarget{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/sla-targets/:targetId", openapi.Operation{
+		Summary: "Get an SLA target",
+		Tags:    []string{"sla"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusOK:       models.SLATarget{},
+			http.StatusNotFound: nil,
+		},
+	})
+
+	spec.Register(http.MethodPatch, "/api/v1/organizations/:organizationId/sla-targets/:targetId", openapi.Operation{
+		Summary:     "Update an SLA target",
+		Description: "Changes the name, target or period; omitted fields are left unchanged. The monitors a target covers cannot change.",
+		Tags:        []string{"sla"},
+		Secured:     true,
+		Request:     dtos.UpdateSLATargetRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         models.SLATarget{},
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+		},
+	})
+
+	spec.Register(http.MethodDelete, "/api/v1/organizations/:organizationId/sla-targets/:targetId", openapi.Operation{
+		Summary: "Delete an SLA target",
+		Tags:    []string{"sla"},
+		Secured: true,
+		Responses: map[int]any{
+			http.StatusNoContent: nil,
+			http.StatusNotFound:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/reports/subscription", openapi.Operation{
+		Summary:     "Get the report subscription",
+		Description: "The uptime report emails the caller receives for the organization. Served when REPORTS_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:       models.ReportSubscription{},
+			http.StatusNotFound: nil,
+		},
+	})
+
+	spec.Register(http.MethodPut, "/api/v1/organizations/:organizationId/reports/subscription", openapi.Operation{
+		Summary:     "Subscribe to uptime reports",
+		Description: "Subscribes the caller to weekly or monthly uptime report emails of the organization, or changes the frequency of their subscription. Served when REPORTS_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Secured:     true,
+		Request:     dtos.ReportSubscriptionRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         models.ReportSubscription{},
+			http.StatusBadRequest: nil,
+		},
+	})
+
+	spec.Register(http.MethodDelete, "/api/v1/organizations/:organizationId/reports/subscription", openapi.Operation{
+		Summary:     "Unsubscribe from uptime reports",
+		Description: "Served when REPORTS_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:       nil,
+			http.StatusNotFound: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/reports/unsubscribe", openapi.Operation{
+		Summary:     "Unsubscribe with an unsubscribe link",
+		Description: "The token of the unsubscribe link sent with every report email stands in for authentication. Served when REPORTS_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Request:     dtos.UnsubscribeReportRequestDto{},
+		Responses: map[int]any{
+			http.StatusOK:         nil,
+			http.StatusBadRequest: nil,
+			http.StatusNotFound:   nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/reports", openapi.Operation{
+		Summary:     "List report files",
+		Description: "The latest uptime report files requested in the organization, with a signed download link for the ready ones. Served when REPORT_FILES_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:        []models.GeneratedReport{},
+			http.StatusForbidden: nil,
+		},
+	})
+
+	spec.Register(http.MethodPost, "/api/v1/organizations/:organizationId/reports", openapi.Operation{
+		Summary:     "Request a report file",
+		Description: "Queues a PDF or CSV uptime report covering the days from from to to, both included, in the time zone of the organization. The range must end no later than today and cover at most REPORT_FILES_MAX_DAYS days. The report is generated in the background: poll it until it is ready to get its download link. Answers 409 while the organization already waits for REPORT_FILES_MAX_PENDING reports. Served when REPORT_FILES_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Secured:     true,
+		Request:     dtos.GenerateReportRequestDto{},
+		Responses: map[int]any{
+			http.StatusAccepted:   models.GeneratedReport{},
+			http.StatusBadRequest: nil,
+			http.StatusConflict:   nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/organizations/:organizationId/reports/:reportId", openapi.Operation{
+		Summary:     "Get a report file",
+		Description: "The report with a download link signed for REPORT_FILES_LINK_TTL once it is ready. Served when REPORT_FILES_ENABLE is set.",
+		Tags:        []string{"reports"},
+		Secured:     true,
+		Responses: map[int]any{
+			http.StatusOK:       models.GeneratedReport{},
+			http.StatusNotFound: nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/search", openapi.Operation{
+		Summary:     "Search across organizations",
+		Description: "Full-text search over monitors, by name and target, and incidents, by title and description, in every organization of the caller, ranked by relevance. Results carry their type, monitor or incident; incidents have their status as subtitle. Every word must match; the last word also matches as a prefix.",
+		Tags:        []string{"search"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "q", In: "query", Required: true, Schema: &openapi.Schema{Type: "string"}},
+			{Name: "organization_id", In: "query", Schema: &openapi.Schema{Type: "string", Format: "uuid"}},
+			{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+		},
+		Responses: map[int]any{
+			http.StatusOK:         []models.SearchResult{},
+			http.StatusBadRequest: nil,
+			http.StatusForbidden:  nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/api/v1/ws", openapi.Operation{
+		Summary:     "Subscribe to live dashboard updates",
+		Description: "Upgrades to a WebSocket that streams monitor status changes, new and assigned incidents and alert acknowledgments for the organization. Browsers may pass the JWT in the access_token query parameter.",
+		Tags:        []string{"realtime"},
+		Secured:     true,
+		Query: []openapi.Parameter{
+			{Name: "organization_id", In: "query", Required: true, Schema: &openapi.Schema{Type: "string", Format: "uuid"}},
+			{Name: "access_token", In: "query", Schema: &openapi.Schema{Type: "string"}},
+		},
+		Responses: map[int]any{
+			http.StatusSwitchingProtocols: nil,
+			http.StatusForbidden:          nil,
+		},
+	})
+
+	spec.Register(http.MethodGet, "/health", openapi.Operation{
+		Summary: "Aggregated dependency health",
+		Tags:    []string{"health"},
+	})
+	spec.Register(http.MethodGet, "/livez", openapi.Operation{
+		Summary: "Liveness probe",
+		Tags:    []string{"health"},
+	})
+	spec.Register(http.MethodGet, "/readyz", openapi.Operation{
+		Summary:     "Readiness probe",
+		Description: "Answers 503 until startup completed, then while a critical dependency is unhealthy.",
+		Tags:        []string{"health"},
+	})
+	spec.Register(http.MethodGet, "/startupz", openapi.Operation{
+		Summary:     "Startup probe",
+		Description: "Reports the progress of every startup step: migrations, seeders, cache warm-up and the job scheduler. Answers 503 until they all finished. It is served, like /livez, while the instance is still starting.",
+		Tags:        []string{"health"},
+		Responses: map[int]any{
+			http.StatusOK:                 lifecycle.StartupProgress{},
+			http.StatusServiceUnavailable: lifecycle.StartupProgress{},
+		},
+	})
+}
+
+// listQueryParameters documents the standard pagination, sort, search and filter parameters.
+func listQueryParameters(filters ...string) []openapi.Parameter {
+	str := func() *openapi.Schema { return &openapi.Schema{Type: "string"} }
+	params := []openapi.Parameter{
+		{Name: "page", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+		{Name: "per_page", In: "query", Schema: &openapi.Schema{Type: "integer"}},
+		{Name: "sort", In: "query", Description: "Comma-separated fields; prefix with - for descending", Schema: str()},
+		{Name: "q", In: "query", Description: "Case-insensitive search term", Schema: str()},
+		fieldsParameter(),
+		{Name: "format", In: "query", Description: "Set to csv to download all matching rows as CSV", Schema: &openapi.Schema{Type: "string", Enum: []string{"csv"}}},
+	}
+	for _, f := range filters {
+		params = append(params, openapi.Parameter{
+			Name:        "filter[" + f + "]",
+			In:          "query",
+			Description: "Comma-separated values to match",
+			Schema:      str(),
+		})
+	}
+	return params
+}
+
+// fieldsParameter documents the sparse fieldset parameter supported by every GET endpoint.
+func fieldsParameter() openapi.Parameter {
+	return openapi.Parameter{
+		Name:        "fields",
+		In:          "query",
+		Description: "Comma-separated top-level fields to include in data, e.g. id,name,status",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+}
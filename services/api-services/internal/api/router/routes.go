@@ -1,24 +1,42 @@
 package router
 
 import (
-	"time"
+	"crypto/ecdsa"
+	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/gin-contrib/cors"
+	"github.com/samaasi/uptime-application/services/api-services/internal/analytics"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/controllers"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/middleware"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/openapi"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
 	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/discovery"
+	"github.com/samaasi/uptime-application/services/api-services/internal/metrics"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/internal/selfmonitor"
+	"github.com/samaasi/uptime-application/services/api-services/internal/ticketing"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/captcha"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/lifecycle"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/sms"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/otp"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
-
-	//"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func SetupRoutes(
@@ -28,18 +46,25 @@ func SetupRoutes(
 	cacheService *cache.Service,
 	storageDriver storage.Driver,
 	emailService email.Service,
+	smsService sms.Service,
+	realtimeHub *realtime.Hub,
+	analyticsRecorder *analytics.Recorder,
+	jobScheduler *jobs.Scheduler,
+	selfMonitor *selfmonitor.Monitor,
+	outboxRelay *outbox.Relay,
+	checkResults *database.BatchWriter[models.CheckResult],
+	configReloader *config.Reloader,
+	startup *lifecycle.Startup,
 ) (*gin.Engine, error) {
 
-	// Initialize the signer with a secret
-	// urlSigner := urlsigner.New(appConfig.App.Key,
-	// 	urlsigner.WithExpiresParam("exp"),
-	// 	urlsigner.WithSignatureParam("sig"),
-	// 	urlsigner.WithClockSkewGrace(30*time.Second),
-	// )
-	// protected.Use(URLSignatureMiddleware(urlSigner))
+	// The first application key signs, the previous ones still verify during a rotation
+	appKeys := appConfig.App.Keys()
+
+	// Initialize the signer used for expiring download links
+	urlSigner := urlsigner.NewFromConfig(appConfig.URLSigner, appKeys)
 
 	// Initialize JWT service for token creation/verification
-	jwtService, err := security.NewJWTService(appConfig.App.Key, appConfig.App.JWTExpiration)
+	jwtService, err := security.NewJWTService(appKeys, appConfig.App.JWTExpiration)
 	if err != nil {
 		return nil, err
 	}
@@ -47,10 +72,37 @@ func SetupRoutes(
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(postgresClient.DB())
 	otpRepo := repositories.NewOTPRepository(cacheService)
+	organizationRepo := repositories.NewOrganizationRepository(postgresClient.DB())
+	monitorRepo := repositories.NewMonitorRepository(postgresClient.DB())
+	if cacheService != nil && appConfig.Redis.RepositoryCacheTTL > 0 {
+		monitorRepo = repositories.NewCachedMonitorRepository(monitorRepo, cacheService, appConfig.Redis.RepositoryCacheTTL)
+	}
+	monitorStatsRepo := repositories.NewMonitorStatsRepository(clickhouseDB(clickhouseClient))
+	// Probes write check results over gRPC; the HTTP API only reads them
+	checkResultRepo := repositories.NewCheckResultRepository(nil, clickhouseDB(clickhouseClient))
+	searchRepo := repositories.NewSearchRepository(postgresClient.DB())
 
 	// Initialize services
-	otpService := services.NewUserOTPManagerService(otpRepo, otp.NewOTPService(otp.DefaultOTPConfig()))
-	authService := services.NewAuthService(userRepo, otpService, emailService, jwtService)
+	otpGenerator := otp.NewOTPService(otp.DefaultOTPConfig())
+	otpService := services.NewUserOTPManagerService(otpRepo, otpGenerator)
+	authService := services.NewAuthService(
+		userRepo,
+		otpService,
+		database.NewTransactor(postgresClient.DB()),
+		outbox.NewPublisher(postgresClient.DB()),
+		jwtService,
+	)
+	monitorService := services.NewMonitorService(monitorRepo, organizationRepo)
+	monitorStatsService := services.NewMonitorStatsService(monitorRepo, monitorStatsRepo)
+	checkResultService := services.NewCheckResultService(monitorRepo, checkResultRepo, nil, nil, nil)
+	searchService := services.NewSearchService(organizationRepo, searchRepo)
+	emailSuppressionService := services.NewEmailSuppressionService(repositories.NewEmailSuppressionRepository(postgresClient.DB()))
+	reportSubscriptionService := services.NewReportSubscriptionService(repositories.NewReportSubscriptionRepository(postgresClient.DB()), organizationRepo)
+	outboxService := services.NewOutboxService(repositories.NewOutboxMessageRepository(postgresClient.DB()))
+	retentionPolicyService := services.NewRetentionPolicyService(repositories.NewRetentionPolicyRepository(postgresClient.DB()),
+		appConfig.Retention.CheckResultsWindow, appConfig.Retention.RollupsWindow, appConfig.Retention.IncidentsWindow)
+	organizationSettingsService := services.NewOrganizationSettingsService(repositories.NewOrganizationSettingsRepository(postgresClient.DB()), retentionPolicyService)
+	configSyncService := services.NewConfigSyncService(monitorRepo, organizationSettingsService, database.NewTransactor(postgresClient.DB()))
 
 	// Initialize controllers
 	healthController := controllers.NewHealthController(
@@ -59,56 +111,466 @@ func SetupRoutes(
 		cacheService,
 		storageDriver,
 		emailService,
+		smsService,
+		startup,
+		appConfig.Server.HealthCacheTTL,
 	)
 	authController := controllers.NewAuthController(authService)
+	monitorController := controllers.NewMonitorController(monitorService, urlSigner, appConfig.URLSigner.DefaultTTL)
+	monitorCheckController := controllers.NewMonitorCheckController(services.NewMonitorCheckService(
+		monitorRepo, database.NewTransactor(postgresClient.DB()), outbox.NewPublisher(postgresClient.DB()), nil))
+	monitorStatsController := controllers.NewMonitorStatsController(monitorStatsService)
+	checkResultController := controllers.NewCheckResultController(checkResultService)
+	configSyncController := controllers.NewConfigSyncController(configSyncService)
+	activityController := controllers.NewActivityController(services.NewActivityService(repositories.NewActivityRepository(postgresClient.DB())))
+	searchController := controllers.NewSearchController(searchService)
+	adminController := controllers.NewAdminController(cacheService, emailSuppressionService, outboxService, jobScheduler, selfMonitor)
+	storageController := controllers.NewStorageController(storageDriver)
+	reportSubscriptionController := controllers.NewReportSubscriptionController(reportSubscriptionService)
+	retentionPolicyController := controllers.NewRetentionPolicyController(retentionPolicyService)
+	organizationSettingsController := controllers.NewOrganizationSettingsController(organizationSettingsService)
+	generatedReportController := controllers.NewGeneratedReportController(services.NewGeneratedReportService(
+		repositories.NewGeneratedReportRepository(postgresClient.DB()), organizationRepo, storageDriver,
+		appConfig.ReportFiles.LinkTTL, appConfig.ReportFiles.MaxDays, appConfig.ReportFiles.MaxPending))
+	slaTargetController := controllers.NewSLATargetController(services.NewSLATargetService(repositories.NewSLATargetRepository(postgresClient.DB()), monitorRepo))
+	statusTokenService := services.NewStatusTokenService(repositories.NewStatusTokenRepository(postgresClient.DB()), monitorRepo, monitorStatsService)
+	statusTokenController := controllers.NewStatusTokenController(statusTokenService)
+	statusController := controllers.NewStatusController(statusTokenService)
+	timezoneController := controllers.NewTimezoneController(services.NewTimezoneService(userRepo, organizationRepo))
+	monitorDependencyRepo := repositories.NewMonitorDependencyRepository(postgresClient.DB())
+	probeService := services.NewProbeService(repositories.NewProbeRepository(postgresClient.DB()))
+	probeController := controllers.NewProbeController(probeService)
+	incidentService := services.NewIncidentService(repositories.NewIncidentRepository(postgresClient.DB()), monitorDependencyRepo, monitorRepo, organizationRepo, probeService,
+		realtimeHub, outbox.NewPublisher(postgresClient.DB()))
+	incidentController := controllers.NewIncidentController(incidentService)
+	incidentAnalyticsController := controllers.NewIncidentAnalyticsController(
+		services.NewIncidentAnalyticsService(repositories.NewIncidentAnalyticsRepository(clickhouseDB(clickhouseClient))))
+	inboundIntegrationController := controllers.NewInboundIntegrationController(
+		services.NewInboundIntegrationService(repositories.NewInboundIntegrationRepository(postgresClient.DB()), incidentService))
+	monitorDiscoveryController := controllers.NewMonitorDiscoveryController(
+		services.NewMonitorDiscoveryService(discovery.New(), monitorRepo, organizationSettingsService, database.NewTransactor(postgresClient.DB())))
+	monitorDependencyController := controllers.NewMonitorDependencyController(
+		services.NewMonitorDependencyService(monitorDependencyRepo, monitorRepo, database.NewTransactor(postgresClient.DB())))
+	chatOpsController := controllers.NewChatOpsController(
+		services.NewChatOpsService(repositories.NewChatIntegrationRepository(postgresClient.DB()), monitorRepo, incidentService))
+	ticketIntegrationController := controllers.NewTicketIntegrationController(services.NewTicketIntegrationService(
+		repositories.NewTicketIntegrationRepository(postgresClient.DB()),
+		repositories.NewIncidentTicketRepository(postgresClient.DB()),
+		repositories.NewIncidentRepository(postgresClient.DB()),
+		ticketing.NewSyncer(postgresClient.DB()),
+	))
+
+	corsConfig := getCORSConfig(appConfig)
+	corsMiddleware := middleware.NewCORSMiddleware(corsConfig)
+	realtimeController := controllers.NewRealtimeController(realtimeHub, organizationRepo, websocketOrigins(corsConfig))
+
+	// Browser origins are reloadable, apply them to new requests and connections
+	configReloader.OnChange(func(previous, current *config.Config) {
+		if reflect.DeepEqual(previous.CORS, current.CORS) {
+			return
+		}
+		corsConfig := getCORSConfig(current)
+		corsMiddleware.Update(corsConfig)
+		realtimeController.SetAllowedOrigins(websocketOrigins(corsConfig))
+	})
+
+	// Initialize CAPTCHA verifier (nil when disabled, which makes the middleware a no-op)
+	var captchaVerifier captcha.Verifier
+	if appConfig.Captcha.Enable {
+		captchaVerifier, err = captcha.NewVerifier(appConfig.Captcha)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deprecatedRoutes, err := appConfig.Deprecation.ParseRoutes()
+	if err != nil {
+		return nil, err
+	}
+	deprecations := middleware.NewDeprecations(deprecatedRoutes)
+	routeTimeouts, err := appConfig.Server.ParseRouteTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	// Streamed exports run as long as the client keeps reading, so they get no deadline
+	requestTimeouts := middleware.NewRequestTimeouts(appConfig.Server.RequestTimeout, routeTimeouts)
+	requestTimeouts.Exempt(http.MethodGet, "/api/v1/organizations/:"+middleware.OrganizationParam+"/monitors", utils.WantsExport)
+	requestTimeouts.Exempt(http.MethodGet, "/api/v1/organizations/:"+middleware.OrganizationParam+"/monitors/:monitorId/results", utils.WantsCSV)
+	requestTimeouts.Exempt(http.MethodGet, "/api/v1/organizations/:"+middleware.OrganizationParam+"/incidents", utils.WantsCSV)
 
 	// --- Create Gin Router ---
 	router := gin.New()
 
 	// --- Global Middlewares ---
 	router.Use(gin.Recovery())
+	router.Use(middleware.TraceMiddleware())
 	router.Use(middleware.LoggingMiddleware())
-	router.Use(cors.New(getCORSConfig(appConfig)))
+	router.Use(middleware.SecurityHeadersMiddleware(middleware.SecurityHeadersOptions{
+		Enabled:               appConfig.Security.HeadersEnable,
+		HSTS:                  appConfig.App.Mode == config.AppModeProduction,
+		HSTSMaxAge:            appConfig.Security.HSTSMaxAge,
+		HSTSIncludeSubdomains: appConfig.Security.HSTSIncludeSubdomains,
+		HSTSPreload:           appConfig.Security.HSTSPreload,
+		ContentSecurityPolicy: appConfig.Security.ContentSecurityPolicy,
+		ReferrerPolicy:        appConfig.Security.ReferrerPolicy,
+		PermissionsPolicy:     appConfig.Security.PermissionsPolicy,
+	}))
+	router.Use(deprecations.Middleware())
+	router.Use(corsMiddleware.Handler())
+	router.Use(middleware.LocaleMiddleware())
+	router.Use(middleware.BodyLimitMiddleware(appConfig.Server.MaxBodyBytes))
 
 	// --- Routes ---
 	// Health routes (public)
 	router.GET("/health", healthController.GetHealth)
 	router.GET("/livez", healthController.GetLiveness)
 	router.GET("/readyz", healthController.GetReadiness)
+	router.GET("/startupz", healthController.GetStartup)
+
+	// Prometheus metrics
+	if appConfig.Metrics.Enable {
+		registry := metrics.NewRegistry()
+		registry.MustRegister(metrics.NewDBPoolCollector(map[string]database.Client{
+			"postgres":   postgresClient,
+			"clickhouse": clickhouseClient,
+		}))
+		if cacheService != nil {
+			registry.MustRegister(metrics.NewCacheCollector(cacheService))
+		}
+		if emailService != nil {
+			registry.MustRegister(metrics.NewEmailCollector(emailService))
+		}
+		if appConfig.Logging.SinkType != "" {
+			registry.MustRegister(metrics.NewLogSinkCollector())
+		}
+		if jobScheduler != nil {
+			registry.MustRegister(metrics.NewJobsCollector(jobScheduler))
+		}
+		if len(deprecatedRoutes) > 0 {
+			registry.MustRegister(metrics.NewDeprecationCollector(deprecations))
+		}
+		registry.MustRegister(metrics.NewOTPCollector(otpGenerator))
+		registry.MustRegister(metrics.NewDomainCollector(postgresClient.DB()))
+		if outboxRelay != nil {
+			registry.MustRegister(metrics.NewOutboxCollector(outboxRelay))
+		}
+		if checkResults != nil {
+			registry.MustRegister(metrics.NewBatchWriterCollector(map[string]func() database.BatchWriterStats{
+				"check_results": checkResults.Stats,
+			}))
+		}
+		router.GET(appConfig.Metrics.Path, metrics.Handler(registry, appConfig.Metrics.AuthToken))
+	}
+
+	// Operator endpoints (only served when an admin token is configured)
+	if appConfig.Admin.Token != "" {
+		admin := router.Group("/admin")
+		admin.Use(middleware.AdminTokenMiddleware(appConfig.Admin.Token))
+		{
+			admin.GET("/cache/metrics", adminController.GetCacheMetrics)
+			admin.GET("/log/level", adminController.GetLogLevel)
+			admin.PUT("/log/level", adminController.SetLogLevel)
+			admin.GET("/email/suppressions", adminController.ListEmailSuppressions)
+			admin.GET("/email/suppressions/:email", adminController.GetEmailSuppression)
+			admin.DELETE("/email/suppressions/:email", adminController.DeleteEmailSuppression)
+			admin.GET("/audit-log/export", adminController.ExportAuditLog)
+			admin.GET("/jobs", adminController.ListJobs)
+			admin.GET("/incidents", adminController.ListIncidents)
+			admin.GET("/outbox/messages", adminController.ListOutboxMessages)
+			admin.GET("/outbox/messages/:id", adminController.GetOutboxMessage)
+			admin.POST("/outbox/messages/:id/retry", adminController.RetryOutboxMessage)
+			admin.DELETE("/outbox/messages/:id", adminController.DiscardOutboxMessage)
+			admin.POST("/outbox/retry", adminController.RetryFailedOutboxMessages)
+			admin.GET("/organizations/:"+middleware.OrganizationParam+"/retention", retentionPolicyController.Get)
+			admin.PUT("/organizations/:"+middleware.OrganizationParam+"/retention", retentionPolicyController.Put)
+			admin.DELETE("/organizations/:"+middleware.OrganizationParam+"/retention", retentionPolicyController.Delete)
+		}
+	}
+
+	// Bounce and complaint webhooks of the email providers. Payloads are authenticated by
+	// each provider's signature, so only providers with a verification key are served.
+	if appConfig.Email.Webhooks.Enabled() {
+		if err := registerEmailWebhooks(router, appConfig.Email.Webhooks, emailSuppressionService); err != nil {
+			return nil, err
+		}
+	}
+
+	// API documentation (non-production only)
+	if appConfig.App.Mode != config.AppModeProduction {
+		spec := openapi.NewBuilder(openapi.Info{
+			Title:   appConfig.App.Name + " API",
+			Version: appConfig.App.Version,
+		})
+		registerAPIDocs(spec)
+
+		docsController := controllers.NewDocsController(spec)
+		router.GET("/docs", docsController.GetSwaggerUI)
+		router.GET("/docs/openapi.json", docsController.GetSpec)
+	}
+
+	// Captured email previews (non-production only; config validation also rejects capture
+	// in production)
+	if appConfig.Email.Capture.Enable && appConfig.App.Mode != config.AppModeProduction {
+		emailPreviewController := controllers.NewEmailPreviewController(
+			email.NewCaptureEmailProvider(storageDriver, appConfig.Email.DefaultFromAddress))
+		devEmails := router.Group("/dev/emails")
+		{
+			devEmails.GET("", emailPreviewController.List)
+			devEmails.GET("/:id", emailPreviewController.Get)
+			devEmails.GET("/:id/html", emailPreviewController.PreviewHTML)
+			devEmails.GET("/:id/text", emailPreviewController.PreviewText)
+		}
+	}
+
+	// Signed downloads (access is granted by the URL signature rather than a session)
+	downloads := router.Group("/downloads")
+	downloads.Use(middleware.AnalyticsMiddleware(analyticsRecorder))
+	downloads.Use(middleware.URLSignatureMiddleware(urlSigner))
+	{
+		downloads.GET("/organizations/:"+middleware.OrganizationParam+"/monitors.csv", monitorController.DownloadExport)
+	}
+
+	// Locally stored assets, served under the storage base URL through signed URLs. A base
+	// URL without a path would shadow every other route, so it is not served.
+	if localStorage, ok := storageDriver.(*storage.LocalStorageDriver); ok && strings.Trim(localStorage.URLPath(), "/") != "" {
+		assets := router.Group(strings.TrimSuffix(localStorage.URLPath(), "/"))
+		assets.Use(middleware.AnalyticsMiddleware(analyticsRecorder))
+		assets.Use(middleware.URLSignatureMiddleware(urlSigner))
+		{
+			assets.GET("/*key", storageController.Download)
+		}
+	}
+
+	// WebSocket live updates. Registered outside the API group so long-lived
+	// connections are not cut off by the request timeout.
+	router.GET("/api/v1/ws", middleware.WebSocketAuthMiddleware(appKeys), realtimeController.Connect)
 
 	// API routes
 	api := router.Group("/api/v1")
+	api.Use(middleware.AnalyticsMiddleware(analyticsRecorder))
+	api.Use(requestTimeouts.Middleware())
+	api.Use(middleware.BodyLoggingMiddleware(middleware.BodyLoggingOptions{
+		Enabled:      appConfig.Logging.HTTPBodies && appConfig.App.Mode != config.AppModeProduction,
+		MaxBodyBytes: appConfig.Logging.HTTPBodyMaxBytes,
+		SkipPaths:    appConfig.Logging.HTTPBodySkipPaths,
+	}))
 	{
 		// Authentication routes
 		auth := api.Group("/auth")
 		{
-			auth.POST("/signup", authController.SignUp)
-			auth.POST("/signin", authController.SignIn)
+			captchaGuard := middleware.CaptchaMiddleware(captchaVerifier)
+
+			auth.POST("/signup", captchaGuard, authController.SignUp)
+			auth.POST("/signin", captchaGuard, authController.SignIn)
+			auth.POST("/forgot-password", captchaGuard, authController.ForgotPassword)
+
+			// Phone verification texts codes, so it is only served with SMS enabled
+			if appConfig.SMS.Enable {
+				phone := auth.Group("/phone")
+				phone.Use(middleware.AuthMiddleware(appKeys))
+				{
+					phone.POST("/verification", authController.SendPhoneVerification)
+					phone.POST("/verify", authController.VerifyPhone)
+				}
+			}
+		}
+
+		// Search across every organization of the caller
+		api.GET("/search", middleware.AuthMiddleware(appKeys), searchController.Search)
+
+		// Settings of the caller
+		api.PUT("/me/timezone", middleware.AuthMiddleware(appKeys), timezoneController.UpdateUser)
+		api.GET("/me/incidents", middleware.AuthMiddleware(appKeys), incidentController.ListMine)
+
+		// Status of the probe network shared by every organization
+		api.GET("/probes", middleware.AuthMiddleware(appKeys), probeController.Network)
+
+		// Unsubscribe links of uptime reports carry a token instead of a session
+		if appConfig.Reports.Enable {
+			api.POST("/reports/unsubscribe", reportSubscriptionController.Unsubscribe)
+		}
+
+		// Read-only status data for embedding, authorized by a status token instead of a
+		// session. Responses differ per token, so they are never served from the response cache.
+		status := api.Group("/status")
+		status.Use(middleware.StatusTokenMiddleware(statusTokenService))
+		{
+			status.GET("/monitors", statusController.Monitors)
+			status.GET("/monitors/:monitorId/stats", statusController.MonitorStats)
 		}
 
-		// Protected routes group (add later)
+		// Alert webhooks of external tools, authorized by the token of their inbound integration
+		api.POST("/integrations/inbound", inboundIntegrationController.Receive)
+
+		// Slash commands of chat apps, authorized by the signature of their platform
+		api.POST("/integrations/chat/:integrationId", chatOpsController.Receive)
+
+		// Organization-scoped routes (authenticated members only). Heavy reads are served
+		// from the response cache for a few seconds, and every write to the organization
+		// invalidates it.
+		responseCache := middleware.NewResponseCache(cacheService, appConfig.Redis.ResponseCacheTTL)
+		organization := api.Group("/organizations/:" + middleware.OrganizationParam)
+		organization.Use(middleware.AuthMiddleware(appKeys))
+		organization.Use(middleware.OrganizationMemberMiddleware(organizationRepo))
+		organization.Use(responseCache.Invalidate())
+		{
+			organization.GET("/monitors", responseCache.Cache(), monitorController.List)
+			organization.POST("/monitors/export-link", monitorController.CreateExportLink)
+			organization.POST("/monitors/validate", monitorController.Validate)
+			organization.POST("/monitors/discover", monitorDiscoveryController.Discover)
+			organization.POST("/monitors/import", monitorDiscoveryController.Import)
+			organization.GET("/monitors/:monitorId", responseCache.Cache(), monitorController.Get)
+			organization.PATCH("/monitors/:monitorId", monitorController.Update)
+			organization.PUT("/monitors/:monitorId/owner", monitorController.SetOwner)
+			organization.POST("/monitors/:monitorId/check-now", monitorCheckController.CheckNow)
+			organization.GET("/monitors/:monitorId/stats", responseCache.Cache(), monitorStatsController.Get)
+			organization.GET("/monitors/:monitorId/results", checkResultController.List)
+			organization.GET("/monitors/:monitorId/dependencies", monitorDependencyController.Get)
+			organization.PUT("/monitors/:monitorId/dependencies", monitorDependencyController.Set)
+			organization.PUT("/config", configSyncController.Sync)
+			organization.GET("/retention", retentionPolicyController.Get)
+			organization.GET("/settings", organizationSettingsController.Get)
+			organization.PUT("/settings", organizationSettingsController.Put)
+			organization.GET("/activity", activityController.List)
+			organization.GET("/timezone", timezoneController.GetOrganization)
+			organization.PUT("/timezone", timezoneController.UpdateOrganization)
+			organization.GET("/status-tokens", statusTokenController.List)
+			organization.POST("/status-tokens", statusTokenController.Create)
+			organization.DELETE("/status-tokens/:tokenId", statusTokenController.Revoke)
+			organization.GET("/integrations", inboundIntegrationController.List)
+			organization.POST("/integrations", inboundIntegrationController.Create)
+			organization.DELETE("/integrations/:integrationId", inboundIntegrationController.Delete)
+			organization.GET("/chat-integrations", chatOpsController.List)
+			organization.POST("/chat-integrations", chatOpsController.Create)
+			organization.DELETE("/chat-integrations/:integrationId", chatOpsController.Delete)
+			organization.GET("/ticket-integrations", ticketIntegrationController.List)
+			organization.POST("/ticket-integrations", ticketIntegrationController.Create)
+			organization.DELETE("/ticket-integrations/:integrationId", ticketIntegrationController.Delete)
+			organization.GET("/incidents", incidentController.List)
+			organization.GET("/incidents/workload", incidentController.Workload)
+			organization.GET("/incidents/analytics", incidentAnalyticsController.Get)
+			organization.PUT("/incidents/:incidentId/assignee", incidentController.Assign)
+			organization.POST("/incidents/:incidentId/claim", incidentController.Claim)
+			organization.PUT("/incidents/:incidentId/cause", incidentController.Tag)
+			organization.GET("/incidents/:incidentId/tickets", ticketIntegrationController.ListIncidentTickets)
+			organization.POST("/incidents/:incidentId/tickets", ticketIntegrationController.FileIncident)
+			organization.GET("/sla-targets", responseCache.Cache(), slaTargetController.List)
+			organization.POST("/sla-targets", slaTargetController.Create)
+			organization.GET("/sla-targets/:targetId", responseCache.Cache(), slaTargetController.Get)
+			organization.PATCH("/sla-targets/:targetId", slaTargetController.Update)
+			organization.DELETE("/sla-targets/:targetId", slaTargetController.Delete)
+
+			if appConfig.Reports.Enable {
+				organization.GET("/reports/subscription", reportSubscriptionController.Get)
+				organization.PUT("/reports/subscription", reportSubscriptionController.Put)
+				organization.DELETE("/reports/subscription", reportSubscriptionController.Delete)
+			}
+			if appConfig.ReportFiles.Enable {
+				organization.GET("/reports", generatedReportController.List)
+				organization.POST("/reports", generatedReportController.Create)
+				organization.GET("/reports/:reportId", generatedReportController.Get)
+			}
+		}
 	}
 
+	warnUnknownDeprecatedRoutes(router, deprecatedRoutes)
+	warnUnknownRouteTimeouts(router, routeTimeouts)
+
 	return router, nil
 }
 
+// warnUnknownDeprecatedRoutes logs the deprecated routes that match no registered route,
+// which are most likely misspelled
+func warnUnknownDeprecatedRoutes(router *gin.Engine, deprecatedRoutes []config.DeprecatedRoute) {
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for _, route := range deprecatedRoutes {
+		if !registered[route.Method+" "+route.Path] {
+			logger.Warn("Deprecated route matches no registered route", logger.String("method", route.Method), logger.String("route", route.Path))
+		}
+	}
+}
+
+// warnUnknownRouteTimeouts logs the route timeouts that match no registered route, which are
+// most likely misspelled
+func warnUnknownRouteTimeouts(router *gin.Engine, routeTimeouts []config.RouteTimeout) {
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for _, route := range routeTimeouts {
+		if !registered[route.Method+" "+route.Path] {
+			logger.Warn("Route timeout matches no registered route", logger.String("method", route.Method), logger.String("route", route.Path))
+		}
+	}
+}
+
+// clickhouseDB returns the ClickHouse connection, or nil when ClickHouse is disabled
+func clickhouseDB(client database.Client) *gorm.DB {
+	if client == nil {
+		return nil
+	}
+	return client.DB()
+}
+
 func getCORSConfig(appConfig *config.Config) cors.Config {
 	baseConfig := cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.CaptchaTokenHeader},
+		ExposeHeaders:    []string{"Content-Length", "Content-Disposition", "X-Request-ID", "Deprecation", "Sunset", "Link", middleware.ResponseCacheHeader},
+		AllowCredentials: appConfig.CORS.AllowCredentials,
+		MaxAge:           appConfig.CORS.MaxAge,
 	}
 
-	if appConfig.App.Mode == "production" {
-		if appConfig.App.FrontendURL == "" {
-			panic("CORS configuration error: APP_FRONTEND_URL cannot be empty in production mode")
-		}
-		baseConfig.AllowOrigins = []string{appConfig.App.FrontendURL}
+	origins := appConfig.CORSOrigins()
+	if len(origins) == 0 && appConfig.App.Mode != config.AppModeProduction {
+		origins = []string{"http://localhost:3000"}
+	}
+
+	if len(origins) == 1 && origins[0] == "*" {
+		baseConfig.AllowAllOrigins = true
 	} else {
-		baseConfig.AllowOrigins = []string{"http://localhost:3000"}
+		baseConfig.AllowOrigins = origins
 	}
 
 	return baseConfig
 }
+
+// websocketOrigins returns the origins allowed to open WebSocket connections under corsConfig
+func websocketOrigins(corsConfig cors.Config) []string {
+	if corsConfig.AllowAllOrigins {
+		return []string{"*"}
+	}
+	return corsConfig.AllowOrigins
+}
+
+// registerEmailWebhooks serves the webhook of every configured email provider
+func registerEmailWebhooks(router *gin.Engine, cfg config.EmailWebhookConfig, suppressionService *services.EmailSuppressionService) error {
+	var sendGridKey *ecdsa.PublicKey
+	if cfg.SendGridPublicKey != "" {
+		key, err := email.ParseSendGridPublicKey(cfg.SendGridPublicKey)
+		if err != nil {
+			return err
+		}
+		sendGridKey = key
+	}
+	var snsVerifier *email.SNSVerifier
+	if cfg.SESEnable {
+		snsVerifier = email.NewSNSVerifier(cfg.SESTopicARNs)
+	}
+	controller := controllers.NewEmailWebhookController(suppressionService, sendGridKey, cfg.MailgunSigningKey, snsVerifier)
+
+	webhooks := router.Group("/webhooks/email")
+	if sendGridKey != nil {
+		webhooks.POST("/sendgrid", controller.SendGrid)
+	}
+	if cfg.MailgunSigningKey != "" {
+		webhooks.POST("/mailgun", controller.Mailgun)
+	}
+	if snsVerifier != nil {
+		webhooks.POST("/ses", controller.SES)
+	}
+	return nil
+}
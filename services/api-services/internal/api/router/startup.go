@@ -0,0 +1,56 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/lifecycle"
+)
+
+// startupRetryAfter is the Retry-After, in seconds, of the requests answered while starting
+const startupRetryAfter = "5"
+
+// StartupHandler lets the HTTP server listen before the application is set up, so that the
+// liveness and startup probes answer while migrations and seeders run instead of the
+// instance looking dead. Until Serve is called it answers /livez, /startupz and /readyz
+// itself and every other request with 503; afterwards it hands every request to the
+// application router.
+type StartupHandler struct {
+	startup *lifecycle.Startup
+	handler atomic.Pointer[http.Handler]
+}
+
+// NewStartupHandler creates a handler reporting the progress of startup
+func NewStartupHandler(startup *lifecycle.Startup) *StartupHandler {
+	return &StartupHandler{startup: startup}
+}
+
+// Serve hands every request received from now on to handler
+func (h *StartupHandler) Serve(handler http.Handler) {
+	h.handler.Store(&handler)
+}
+
+func (h *StartupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler := h.handler.Load(); handler != nil {
+		(*handler).ServeHTTP(w, r)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/livez":
+		writeStartupJSON(w, http.StatusOK, map[string]any{"status": "alive"})
+	case "/startupz":
+		writeStartupJSON(w, http.StatusServiceUnavailable, h.startup.Progress())
+	default:
+		w.Header().Set("Retry-After", startupRetryAfter)
+		writeStartupJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "starting", "pending": h.startup.Pending()})
+	}
+}
+
+func writeStartupJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
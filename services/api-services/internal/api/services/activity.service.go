@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+)
+
+// Categories of the activity feed, the prefixes of the audit events it lists
+const (
+	ActivityCategoryIncident = "incident"
+	ActivityCategoryMonitor  = "monitor"
+	ActivityCategoryConfig   = "config"
+)
+
+// ErrInvalidActivityCategory is returned when filtering the activity feed on an unknown category
+var ErrInvalidActivityCategory = errors.New("invalid activity category")
+
+// activityCursor is the position of an entry in the newest first order of the activity feed
+type activityCursor struct {
+	ID int64 `json:"id"`
+}
+
+// ActivityService lists the activity feed of organizations: the incidents opened, resolved
+// and assigned, the monitors edited and the configuration changed. Entries are the audit
+// events recorded with the organization_id of an organization, stored in Postgres by the
+// activity recorder so every replica lists the same feed.
+type ActivityService struct {
+	activityRepository repositories.ActivityRepository
+}
+
+func NewActivityService(activityRepository repositories.ActivityRepository) *ActivityService {
+	return &ActivityService{activityRepository: activityRepository}
+}
+
+// ListOrganizationActivity returns a page of the activity of an organization, newest first,
+// with the cursor of the next page. An empty category lists every category. It fails with
+// utils.ErrInvalidCursor when page.Cursor was not issued by a previous call.
+func (s *ActivityService) ListOrganizationActivity(ctx context.Context, organizationID uuid.UUID, category string, page utils.CursorParams) ([]dtos.ActivityDto, *utils.CursorPagination, error) {
+	switch category {
+	case "", ActivityCategoryIncident, ActivityCategoryMonitor, ActivityCategoryConfig:
+	default:
+		return nil, nil, ErrInvalidActivityCategory
+	}
+
+	var before activityCursor
+	if page.Cursor != "" {
+		if err := utils.DecodeCursor(page.Cursor, &before); err != nil {
+			return nil, nil, err
+		}
+		if before.ID <= 0 {
+			return nil, nil, utils.ErrInvalidCursor
+		}
+	}
+
+	// One extra entry tells whether another page follows
+	entries, err := s.activityRepository.ListByOrganization(ctx, organizationID, category, before.ID, page.Limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pagination := &utils.CursorPagination{Limit: page.Limit}
+	if len(entries) > page.Limit {
+		entries = entries[:page.Limit]
+		pagination.HasMore = true
+		if pagination.NextCursor, err = utils.EncodeCursor(activityCursor{ID: entries[len(entries)-1].ID}); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode activity cursor: %w", err)
+		}
+	}
+
+	activity := make([]dtos.ActivityDto, 0, len(entries))
+	for _, entry := range entries {
+		activity = append(activity, newActivityDto(entry))
+	}
+	return activity, pagination, nil
+}
+
+// newActivityDto returns the activity feed entry of a stored activity entry
+func newActivityDto(entry models.ActivityEntry) dtos.ActivityDto {
+	var actorID string
+	if entry.ActorID != nil {
+		actorID = entry.ActorID.String()
+	}
+	return dtos.ActivityDto{
+		ID:         entry.ID,
+		Type:       entry.Event,
+		Category:   entry.Category,
+		ActorID:    actorID,
+		Details:    entry.Details,
+		OccurredAt: entry.OccurredAt,
+	}
+}
@@ -2,14 +2,20 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
 	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/i18n"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
-	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+	emailnotifier "github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
@@ -20,20 +26,25 @@ import (
 type AuthService struct {
 	userRepository repositories.UserRepository
 	otpService     *UserOTPManagerService
-	emailService   email.Service
+	transactor     database.Transactor
+	outbox         *outbox.Publisher
 	jwtService     *security.JWTService
 }
 
+// NewAuthService creates an AuthService. Emails are queued through the outbox publisher
+// and sent by the relay, so they survive a crash right after the request commits.
 func NewAuthService(
 	userRepository repositories.UserRepository,
 	otpService *UserOTPManagerService,
-	emailService email.Service,
+	transactor database.Transactor,
+	outbox *outbox.Publisher,
 	jwtService *security.JWTService,
 ) *AuthService {
 	return &AuthService{
 		userRepository: userRepository,
 		otpService:     otpService,
-		emailService:   emailService,
+		transactor:     transactor,
+		outbox:         outbox,
 		jwtService:     jwtService,
 	}
 }
@@ -42,7 +53,7 @@ func NewAuthService(
 func (s *AuthService) SignUpByEmail(ctx context.Context, req *dtos.SignUpRequestDto) (*models.User, error) {
 	existingUser, err := s.userRepository.GetByEmail(ctx, req.Email)
 	if err != nil && err != gorm.ErrRecordNotFound {
-		logger.Error("Failed to check existing user", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to check existing user", logger.Email("email", req.Email), logger.ErrorField(err))
 		return nil, common.ErrInternalServer
 	}
 
@@ -50,31 +61,44 @@ func (s *AuthService) SignUpByEmail(ctx context.Context, req *dtos.SignUpRequest
 		return nil, common.ErrEmailAlreadyRegistered
 	}
 
+	// Only locales with translations are stored, so notifications never fall back silently
+	locale := i18n.Match(req.Locale)
 	user := &models.User{
 		FirstName:      req.FirstName,
 		LastName:       req.LastName,
 		Email:          &req.Email,
 		HashedPassword: req.Password,
+		Locale:         &locale,
+		Timezone:       utils.StringPtr(req.Timezone),
 	}
 
-	if err := s.userRepository.Create(ctx, user); err != nil {
-		logger.Error("Failed to create user", logger.String("email", req.Email), logger.ErrorField(err))
-		return nil, common.ErrInternalServer
-	}
+	// The user and its verification email are committed together
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Create(ctx, user); err != nil {
+			logger.ErrorCtx(ctx, "Failed to create user", logger.Email("email", req.Email), logger.ErrorField(err))
+			return err
+		}
 
-	// Generate OTP for email verification
-	otpToken, err := s.otpService.GenerateAndSaveOTP(ctx, common.OTPTypeEmailVerification, req.Email)
+		// Generate OTP for email verification
+		otpToken, ttl, err := s.otpService.GenerateAndSaveOTP(ctx, common.OTPTypeEmailVerification, req.Email)
+		if err != nil {
+			logger.ErrorCtx(ctx, "Failed to generate OTP", logger.Email("email", req.Email), logger.ErrorField(err))
+			return err
+		}
+
+		// Queue verification email
+		if err := s.outbox.PublishTemplatedEmail(ctx, req.Email, emailnotifier.TemplateOTP, locale, emailnotifier.OTPData{Code: otpToken, ExpiresIn: ttl}); err != nil {
+			logger.ErrorCtx(ctx, "Failed to queue verification email", logger.Email("email", req.Email), logger.ErrorField(err))
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		logger.Error("Failed to generate OTP", logger.String("email", req.Email), logger.ErrorField(err))
 		return nil, common.ErrInternalServer
 	}
 
-	// Send verification email
-	if err := s.emailService.SendEmail(ctx, req.Email, "Email Verification OTP", fmt.Sprintf("Your OTP for email verification is: %s", otpToken)); err != nil {
-		logger.Error("Failed to send verification email", logger.String("email", req.Email), logger.ErrorField(err))
-	}
-
-	logger.Info("User registered successfully", logger.String("user_id", user.ID.String()), logger.String("email", req.Email))
+	logger.InfoCtx(ctx, "User registered successfully", logger.String("user_id", user.ID.String()), logger.Email("email", req.Email))
+	logger.Audit(logger.AuditSignUp, logger.String("user_id", user.ID.String()), logger.Email("email", req.Email))
 	return user, nil
 }
 
@@ -83,13 +107,15 @@ func (s *AuthService) SignIn(ctx context.Context, req *dtos.SignInRequestDto) (*
 	user, err := s.userRepository.GetByEmail(ctx, req.Email)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
+			logger.Audit(logger.AuditSignInFailed, logger.Email("email", req.Email), logger.String("reason", "unknown_email"))
 			return nil, common.ErrInvalidCredentials
 		}
-		logger.Error("Failed to get user", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to get user", logger.Email("email", req.Email), logger.ErrorField(err))
 		return nil, common.ErrInternalServer
 	}
 
 	if !security.VerifyPassword(user.HashedPassword, req.Password) {
+		logger.Audit(logger.AuditSignInFailed, logger.String("user_id", user.ID.String()), logger.String("reason", "invalid_password"))
 		return nil, common.ErrInvalidCredentials
 	}
 
@@ -102,7 +128,7 @@ func (s *AuthService) SignIn(ctx context.Context, req *dtos.SignInRequestDto) (*
 
 	accessToken, err := s.jwtService.CreateToken(payload)
 	if err != nil {
-		logger.Error("Failed to sign JWT token", logger.String("user_id", user.ID.String()), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to sign JWT token", logger.String("user_id", user.ID.String()), logger.ErrorField(err))
 		return nil, common.ErrInternalServer
 	}
 
@@ -117,37 +143,39 @@ func (s *AuthService) SignIn(ctx context.Context, req *dtos.SignInRequestDto) (*
 	if user.Email != nil {
 		emailVal = *user.Email
 	}
-	logger.Info("User signed in successfully", logger.String("user_id", user.ID.String()), logger.String("email", emailVal))
+	logger.InfoCtx(ctx, "User signed in successfully", logger.String("user_id", user.ID.String()), logger.Email("email", emailVal))
+	logger.Audit(logger.AuditSignIn, logger.String("user_id", user.ID.String()))
 	return response, nil
 }
 
 // ForgotPassword initiates password reset process
 func (s *AuthService) ForgotPassword(ctx context.Context, req *dtos.ForgotPasswordRequest) error {
 	// Check if user exists
-	_, err := s.userRepository.GetByEmail(ctx, req.Email)
+	user, err := s.userRepository.GetByEmail(ctx, req.Email)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			// Don't reveal if user exists or not
 			return nil
 		}
-		logger.Error("Failed to get user", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to get user", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
 	// Generate OTP for password reset
-	otp, err := s.otpService.GenerateAndSaveOTP(ctx, common.OTPTypePasswordReset, req.Email)
+	otp, ttl, err := s.otpService.GenerateAndSaveOTP(ctx, common.OTPTypePasswordReset, req.Email)
 	if err != nil {
-		logger.Error("Failed to generate OTP", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to generate OTP", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
-	// Send password reset email
-	if err := s.emailService.SendEmail(ctx, req.Email, "Password Reset OTP", fmt.Sprintf("Your OTP for password reset is: %s", otp)); err != nil {
-		logger.Error("Failed to send password reset email", logger.String("email", req.Email), logger.ErrorField(err))
+	// Queue password reset email
+	if err := s.outbox.PublishTemplatedEmail(ctx, req.Email, emailnotifier.TemplatePasswordReset, user.PreferredLocale(), emailnotifier.OTPData{Code: otp, ExpiresIn: ttl}); err != nil {
+		logger.ErrorCtx(ctx, "Failed to queue password reset email", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
-	logger.Info("Password reset initiated", logger.String("email", req.Email))
+	logger.InfoCtx(ctx, "Password reset initiated", logger.Email("email", req.Email))
+	logger.Audit(logger.AuditPasswordResetRequested, logger.String("user_id", user.ID.String()))
 	return nil
 }
 
@@ -156,7 +184,7 @@ func (s *AuthService) ResetPassword(ctx context.Context, req *dtos.ResetPassword
 	// Verify OTP
 	verified, err := s.otpService.VerifyOTP(ctx, common.OTPTypePasswordReset, req.Email, req.OTP)
 	if err != nil || !verified {
-		logger.Error("Invalid OTP for password reset", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Invalid OTP for password reset", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInvalidOTP
 	}
 
@@ -166,14 +194,14 @@ func (s *AuthService) ResetPassword(ctx context.Context, req *dtos.ResetPassword
 		if err == gorm.ErrRecordNotFound {
 			return common.ErrUserNotFound
 		}
-		logger.Error("Failed to get user", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to get user", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
 	// Hash new password
 	hashedPassword, err := security.HashPassword(req.NewPassword, nil)
 	if err != nil {
-		logger.Error("Failed to hash password", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to hash password", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
@@ -182,14 +210,15 @@ func (s *AuthService) ResetPassword(ctx context.Context, req *dtos.ResetPassword
 	user.UpdatedAt = time.Now()
 
 	if err := s.userRepository.Update(ctx, user); err != nil {
-		logger.Error("Failed to update user password", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to update user password", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
 	// OTP is automatically deleted by the VerifyOTP method
 	// No need to manually delete it
 
-	logger.Info("Password reset successfully", logger.String("email", req.Email))
+	logger.InfoCtx(ctx, "Password reset successfully", logger.Email("email", req.Email))
+	logger.Audit(logger.AuditPasswordReset, logger.String("user_id", user.ID.String()))
 	return nil
 }
 
@@ -198,7 +227,7 @@ func (s *AuthService) VerifyEmail(ctx context.Context, req *dtos.VerifyEmailRequ
 	// Verify OTP
 	verified, err := s.otpService.VerifyOTP(ctx, common.OTPTypeEmailVerification, req.Email, req.OTP)
 	if err != nil || !verified {
-		logger.Error("Invalid OTP for email verification", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Invalid OTP for email verification", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInvalidOTP
 	}
 
@@ -208,7 +237,7 @@ func (s *AuthService) VerifyEmail(ctx context.Context, req *dtos.VerifyEmailRequ
 		if err == gorm.ErrRecordNotFound {
 			return common.ErrUserNotFound
 		}
-		logger.Error("Failed to get user", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to get user", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
@@ -218,56 +247,121 @@ func (s *AuthService) VerifyEmail(ctx context.Context, req *dtos.VerifyEmailRequ
 	user.UpdatedAt = now
 
 	if err := s.userRepository.Update(ctx, user); err != nil {
-		logger.Error("Failed to update user email verification", logger.String("email", req.Email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to update user email verification", logger.Email("email", req.Email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
 	// OTP is automatically deleted by the VerifyOTP method
 	// No need to manually delete it
 
-	logger.Info("Email verified successfully", logger.String("email", req.Email))
+	logger.InfoCtx(ctx, "Email verified successfully", logger.Email("email", req.Email))
+	logger.Audit(logger.AuditEmailVerified, logger.String("user_id", user.ID.String()))
 	return nil
 }
 
 // ResendOTP resends OTP for various operations
 func (s *AuthService) ResendOTP(ctx context.Context, otpType common.OTPType, email string) error {
 	// Check if user exists
-	_, err := s.userRepository.GetByEmail(ctx, email)
+	user, err := s.userRepository.GetByEmail(ctx, email)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return common.ErrUserNotFound
 		}
-		logger.Error("Failed to get user", logger.String("email", email), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to get user", logger.Email("email", email), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
 	// Generate new OTP
-	otp, err := s.otpService.GenerateAndSaveOTP(ctx, otpType, email)
+	otp, ttl, err := s.otpService.GenerateAndSaveOTP(ctx, otpType, email)
 	if err != nil {
-		logger.Error("Failed to generate OTP", logger.String("email", email), logger.String("type", string(otpType)), logger.ErrorField(err))
+		logger.ErrorCtx(ctx, "Failed to generate OTP", logger.Email("email", email), logger.String("type", string(otpType)), logger.ErrorField(err))
+		return common.ErrInternalServer
+	}
+
+	// Determine the email template based on OTP type
+	template := emailnotifier.TemplateOTP
+	if otpType == common.OTPTypePasswordReset {
+		template = emailnotifier.TemplatePasswordReset
+	}
+
+	// Queue email
+	if err := s.outbox.PublishTemplatedEmail(ctx, email, template, user.PreferredLocale(), emailnotifier.OTPData{Code: otp, ExpiresIn: ttl}); err != nil {
+		logger.ErrorCtx(ctx, "Failed to queue OTP email", logger.Email("email", email), logger.String("type", string(otpType)), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
-	// Determine email subject and message based on OTP type
-	var subject, message string
-	switch otpType {
-	case common.OTPTypeEmailVerification:
-		subject = "Email Verification"
-		message = fmt.Sprintf("Your verification code is: %s", otp)
-	case common.OTPTypePasswordReset:
-		subject = "Password Reset"
-		message = fmt.Sprintf("Your password reset code is: %s", otp)
-	default:
-		subject = "OTP Code"
-		message = fmt.Sprintf("Your OTP code is: %s", otp)
+	logger.InfoCtx(ctx, "OTP resent successfully", logger.Email("email", email), logger.String("type", string(otpType)))
+	return nil
+}
+
+// SendPhoneVerification texts a verification code to the phone number of the user
+func (s *AuthService) SendPhoneVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.phoneUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PhoneNumberVerifiedAt != nil {
+		return common.ErrPhoneAlreadyVerified
+	}
+
+	code, ttl, err := s.otpService.GenerateAndSaveOTP(ctx, common.OTPTypePhoneVerification, *user.PhoneNumber)
+	if err != nil {
+		logger.ErrorCtx(ctx, "Failed to generate phone verification OTP", logger.String("user_id", userID.String()), logger.ErrorField(err))
+		return common.ErrInternalServer
 	}
 
-	// Send email
-	if err := s.emailService.SendEmail(ctx, email, subject, message); err != nil {
-		logger.Error("Failed to send OTP email", logger.String("email", email), logger.String("type", string(otpType)), logger.ErrorField(err))
+	body := i18n.T(i18n.Match(user.PreferredLocale()), "sms.phone_verification", map[string]string{
+		"code":    code,
+		"minutes": strconv.Itoa(int(ttl.Minutes())),
+	})
+	if err := s.outbox.PublishSMS(ctx, *user.PhoneNumber, body); err != nil {
+		logger.ErrorCtx(ctx, "Failed to queue phone verification SMS", logger.String("user_id", userID.String()), logger.ErrorField(err))
 		return common.ErrInternalServer
 	}
 
-	logger.Info("OTP resent successfully", logger.String("email", email), logger.String("type", string(otpType)))
+	logger.InfoCtx(ctx, "Phone verification code sent", logger.String("user_id", userID.String()))
 	return nil
 }
+
+// VerifyPhone marks the phone number of the user as verified when code matches the one
+// sent by SendPhoneVerification
+func (s *AuthService) VerifyPhone(ctx context.Context, userID uuid.UUID, req *dtos.VerifyPhoneRequest) error {
+	user, err := s.phoneUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	verified, err := s.otpService.VerifyOTP(ctx, common.OTPTypePhoneVerification, *user.PhoneNumber, req.OTP)
+	if err != nil || !verified {
+		logger.ErrorCtx(ctx, "Invalid OTP for phone verification", logger.String("user_id", userID.String()), logger.ErrorField(err))
+		return common.ErrInvalidOTP
+	}
+
+	now := time.Now()
+	user.PhoneNumberVerifiedAt = &now
+	user.UpdatedAt = now
+	if err := s.userRepository.Update(ctx, user); err != nil {
+		logger.ErrorCtx(ctx, "Failed to update user phone verification", logger.String("user_id", userID.String()), logger.ErrorField(err))
+		return common.ErrInternalServer
+	}
+
+	logger.InfoCtx(ctx, "Phone number verified successfully", logger.String("user_id", userID.String()))
+	logger.Audit(logger.AuditPhoneVerified, logger.String("user_id", userID.String()))
+	return nil
+}
+
+// phoneUser loads the user and checks that it has a phone number
+func (s *AuthService) phoneUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, common.ErrUserNotFound
+		}
+		logger.ErrorCtx(ctx, "Failed to get user", logger.String("user_id", userID.String()), logger.ErrorField(err))
+		return nil, common.ErrInternalServer
+	}
+	if user.PhoneNumber == nil || *user.PhoneNumber == "" {
+		return nil, common.ErrPhoneNumberMissing
+	}
+	return user, nil
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/chatops"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// maxChatListLength is the most monitors or incidents a chat reply lists
+const maxChatListLength = 15
+
+// chatHelp lists the commands chat integrations understand
+const chatHelp = "Commands:\n" +
+	"• `status` lists the monitors that are down or degraded\n" +
+	"• `status <name or team>` shows the monitors whose name contains it or owned by the team\n" +
+	"• `incidents` lists the open incidents\n" +
+	"• `ack <incident>` acknowledges an open incident, such as `ack INC-1A2B3C4D`"
+
+var (
+	// ErrInvalidChatVerificationKey is returned when creating a chat integration with a key
+	// that cannot verify the requests of its platform
+	ErrInvalidChatVerificationKey = errors.New("invalid chat verification key")
+
+	// ErrInvalidChatSignature is returned for chat requests that fail verification
+	ErrInvalidChatSignature = errors.New("invalid chat request signature")
+
+	// ErrInvalidChatPayload is returned for chat requests that cannot be parsed
+	ErrInvalidChatPayload = errors.New("invalid chat request payload")
+)
+
+// ChatOpsService manages the chat integrations of organizations and runs the slash
+// commands they receive
+type ChatOpsService struct {
+	integrationRepository repositories.ChatIntegrationRepository
+	monitorRepository     repositories.MonitorRepository
+	incidentService       *IncidentService
+}
+
+func NewChatOpsService(integrationRepository repositories.ChatIntegrationRepository, monitorRepository repositories.MonitorRepository, incidentService *IncidentService) *ChatOpsService {
+	return &ChatOpsService{
+		integrationRepository: integrationRepository,
+		monitorRepository:     monitorRepository,
+		incidentService:       incidentService,
+	}
+}
+
+// List returns the chat integrations of an organization
+func (s *ChatOpsService) List(ctx context.Context, organizationID uuid.UUID) ([]models.ChatIntegration, error) {
+	return s.integrationRepository.ListByOrganization(ctx, organizationID)
+}
+
+// Create adds a chat integration to an organization. It fails with
+// ErrInvalidChatVerificationKey when the key cannot verify the requests of the platform.
+func (s *ChatOpsService) Create(ctx context.Context, organizationID, userID uuid.UUID, req *dtos.CreateChatIntegrationRequestDto) (*models.ChatIntegration, error) {
+	key := strings.TrimSpace(req.VerificationKey)
+	if err := chatops.ValidateKey(req.Platform, key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChatVerificationKey, err)
+	}
+
+	integration := models.ChatIntegration{
+		OrganizationID:  organizationID,
+		Name:            req.Name,
+		Platform:        req.Platform,
+		VerificationKey: key,
+		CreatedBy:       userID,
+	}
+	if err := s.integrationRepository.Create(ctx, &integration); err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+// Delete deletes a chat integration of an organization, which stops accepting its commands
+// at once
+func (s *ChatOpsService) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	integration, err := s.integrationRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if integration.OrganizationID != organizationID {
+		return common.ErrNotFound
+	}
+	return s.integrationRepository.SoftDelete(ctx, id)
+}
+
+// Handle verifies a slash command received by a chat integration with the signature headers
+// of its platform, runs it against the organization of the integration and returns the
+// response body of its platform. It fails with common.ErrNotFound for unknown integrations,
+// ErrInvalidChatSignature for requests the platform did not sign and ErrInvalidChatPayload
+// for requests that cannot be parsed.
+func (s *ChatOpsService) Handle(ctx context.Context, id uuid.UUID, header http.Header, body []byte) (any, error) {
+	integration, err := s.integrationRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := chatops.Verify(integration.Platform, integration.VerificationKey, header, body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChatSignature, err)
+	}
+	command, err := chatops.Parse(integration.Platform, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChatPayload, err)
+	}
+	if command.Ping {
+		return chatops.Pong(), nil
+	}
+
+	ctx = tenant.WithOrganization(ctx, integration.OrganizationID)
+	text, err := s.run(ctx, integration, command)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.integrationRepository.MarkReceived(ctx, integration.ID, time.Now().UTC()); err != nil {
+		logger.WarnCtx(ctx, "Failed to record chat integration command",
+			logger.String("integration_id", integration.ID.String()),
+			logger.ErrorField(err),
+		)
+	}
+	return chatops.Reply(integration.Platform, text), nil
+}
+
+// run runs a command for the organization of the integration and returns the text replying
+// to it
+func (s *ChatOpsService) run(ctx context.Context, integration *models.ChatIntegration, command *chatops.Command) (string, error) {
+	switch command.Name {
+	case "status":
+		return s.status(ctx, integration.OrganizationID, strings.Join(command.Args, " "))
+	case "incidents":
+		return s.incidents(ctx, integration.OrganizationID)
+	case "ack":
+		if len(command.Args) != 1 {
+			return "Usage: `ack <incident>`, such as `ack INC-1A2B3C4D`", nil
+		}
+		return s.acknowledge(ctx, integration, command.Args[0], command.User)
+	case "", "help":
+		return chatHelp, nil
+	default:
+		return fmt.Sprintf("Unknown command `%s`.\n%s", command.Name, chatHelp), nil
+	}
+}
+
+// status describes the monitors matching term, or the ones down or degraded without term
+func (s *ChatOpsService) status(ctx context.Context, organizationID uuid.UUID, term string) (string, error) {
+	if term == "" {
+		query := utils.QueryParams{
+			Filters: map[string][]string{"status": {models.MonitorStatusDown, models.MonitorStatusDegraded}},
+			Sorts:   []utils.SortField{{Column: "name"}},
+		}
+		monitors, total, err := s.monitorRepository.ListByOrganization(ctx, organizationID, query.FilterScope(), query.OrderScope(), maxChatListLength, 0)
+		if err != nil {
+			return "", err
+		}
+		if total == 0 {
+			return "All monitors are up.", nil
+		}
+		return fmt.Sprintf("%d monitors down or degraded:\n%s", total, monitorLines(monitors, total)), nil
+	}
+
+	monitors, err := s.monitorRepository.SearchByOrganization(ctx, organizationID, term, maxChatListLength+1)
+	if err != nil {
+		return "", err
+	}
+	if len(monitors) == 0 {
+		return fmt.Sprintf("No monitor matches `%s`.", term), nil
+	}
+	total := int64(len(monitors))
+	if len(monitors) > maxChatListLength {
+		monitors = monitors[:maxChatListLength]
+	}
+	return monitorLines(monitors, total), nil
+}
+
+// incidents lists the open incidents of the organization, newest first
+func (s *ChatOpsService) incidents(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	query := utils.QueryParams{
+		Filters: map[string][]string{"status": {models.IncidentStatusOpen}},
+		Sorts:   []utils.SortField{{Column: "started_at", Desc: true}},
+	}
+	incidents, total, err := s.incidentService.ListOrganizationIncidents(ctx, organizationID, query, utils.Params{Page: 1, PerPage: maxChatListLength})
+	if err != nil {
+		return "", err
+	}
+	if total == 0 {
+		return "No open incidents.", nil
+	}
+
+	lines := make([]string, 0, len(incidents)+1)
+	for _, incident := range incidents {
+		line := fmt.Sprintf("• %s %s (%s, since %s)", incident.Ref(), incident.Title, incident.Severity, incident.StartedAt.UTC().Format("Jan 2 15:04 MST"))
+		if incident.AcknowledgedAt != nil {
+			line += ", acknowledged by " + incident.AcknowledgedBy
+		}
+		lines = append(lines, line)
+	}
+	if more := total - int64(len(incidents)); more > 0 {
+		lines = append(lines, fmt.Sprintf("…and %d more", more))
+	}
+	return fmt.Sprintf("%d open incidents:\n%s", total, strings.Join(lines, "\n")), nil
+}
+
+// acknowledge acknowledges the open incident ref refers to on behalf of a chat user
+func (s *ChatOpsService) acknowledge(ctx context.Context, integration *models.ChatIntegration, ref, user string) (string, error) {
+	by := integration.Platform
+	if user != "" {
+		by = user + " (" + integration.Platform + ")"
+	}
+
+	incident, err := s.incidentService.AcknowledgeOrganizationIncident(ctx, integration.OrganizationID, ref, by)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidIncidentRef):
+			return fmt.Sprintf("`%s` is not an incident reference, such as INC-1A2B3C4D.", ref), nil
+		case errors.Is(err, common.ErrNotFound):
+			return fmt.Sprintf("No open incident matches `%s`.", ref), nil
+		case errors.Is(err, ErrAmbiguousIncidentRef):
+			return fmt.Sprintf("`%s` matches several open incidents, use a longer reference.", ref), nil
+		case errors.Is(err, ErrIncidentAlreadyAcknowledged):
+			return fmt.Sprintf("`%s` is already acknowledged.", ref), nil
+		default:
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s %s acknowledged by %s.", incident.Ref(), incident.Title, by), nil
+}
+
+// monitorLines lists monitors with their status and owning team, noting how many of the
+// total matches were left out
+func monitorLines(monitors []models.Monitor, total int64) string {
+	lines := make([]string, 0, len(monitors)+1)
+	for _, monitor := range monitors {
+		line := fmt.Sprintf("• %s: %s", monitor.Name, monitor.Status)
+		if monitor.OwnerTeam != "" {
+			line += " (owned by " + monitor.OwnerTeam + ")"
+		}
+		lines = append(lines, line)
+	}
+	if more := total - int64(len(monitors)); more > 0 {
+		lines = append(lines, fmt.Sprintf("…and %d more", more))
+	}
+	return strings.Join(lines, "\n")
+}
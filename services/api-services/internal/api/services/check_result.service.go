@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ErrInvalidCheckResultRange is returned for check result ranges that are empty
+var ErrInvalidCheckResultRange = errors.New("invalid check result range")
+
+// maxCheckResultExportRows caps a single CSV export of check results
+const maxCheckResultExportRows = 100000
+
+// CheckResultService handles ingestion of probe check results and queries over them
+type CheckResultService struct {
+	monitorRepository     repositories.MonitorRepository
+	checkResultRepository repositories.CheckResultRepository
+	incidentService       *IncidentService
+	probeService          *ProbeService
+	publisher             realtime.Publisher
+}
+
+// NewCheckResultService creates the service. incidentService may be nil to not track the
+// incidents of monitors, probeService nil to not track the probes reporting results, and
+// publisher nil to disable live updates.
+func NewCheckResultService(
+	monitorRepository repositories.MonitorRepository,
+	checkResultRepository repositories.CheckResultRepository,
+	incidentService *IncidentService,
+	probeService *ProbeService,
+	publisher realtime.Publisher,
+) *CheckResultService {
+	return &CheckResultService{
+		monitorRepository:     monitorRepository,
+		checkResultRepository: checkResultRepository,
+		incidentService:       incidentService,
+		probeService:          probeService,
+		publisher:             publisher,
+	}
+}
+
+// isValidCheckStatus reports whether a probe-reported status is accepted.
+func isValidCheckStatus(status string) bool {
+	switch status {
+	case models.MonitorStatusUp, models.MonitorStatusDown, models.MonitorStatusDegraded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Ingest validates and stores a batch of results, then updates each monitor's latest status
+// and the incidents of the monitors that changed status. Results for unknown monitors or
+// with invalid statuses are rejected individually.
+func (s *CheckResultService) Ingest(ctx context.Context, results []models.CheckResult) (accepted int, rejected int, err error) {
+	if len(results) == 0 {
+		return 0, 0, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(results))
+	seen := make(map[uuid.UUID]struct{}, len(results))
+	for _, r := range results {
+		if _, ok := seen[r.MonitorID]; !ok {
+			seen[r.MonitorID] = struct{}{}
+			ids = append(ids, r.MonitorID)
+		}
+	}
+
+	monitors, err := s.monitorRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to resolve monitors: %w", err)
+	}
+	monitorsByID := make(map[uuid.UUID]models.Monitor, len(monitors))
+	for _, m := range monitors {
+		monitorsByID[m.ID] = m
+	}
+
+	valid := make([]models.CheckResult, 0, len(results))
+	latest := make(map[uuid.UUID]models.CheckResult)
+	for _, r := range results {
+		monitor, ok := monitorsByID[r.MonitorID]
+		if !ok || !isValidCheckStatus(r.Status) {
+			rejected++
+			continue
+		}
+
+		r.OrganizationID = monitor.OrganizationID
+		if r.CheckedAt.IsZero() {
+			r.CheckedAt = time.Now().UTC()
+		}
+		valid = append(valid, r)
+
+		if prev, ok := latest[r.MonitorID]; !ok || r.CheckedAt.After(prev.CheckedAt) {
+			latest[r.MonitorID] = r
+		}
+	}
+
+	if err := s.checkResultRepository.InsertBatch(ctx, valid); err != nil {
+		return 0, rejected, err
+	}
+	if s.probeService != nil {
+		s.probeService.Seen(ctx, valid)
+	}
+
+	var transitions []MonitorTransition
+	for monitorID, r := range latest {
+		if err := s.monitorRepository.UpdateStatus(ctx, monitorID, r.Status, r.CheckedAt); err != nil {
+			logger.ErrorCtx(ctx, "Failed to update monitor status after ingestion",
+				logger.String("monitor_id", monitorID.String()),
+				logger.ErrorField(err),
+			)
+			continue
+		}
+
+		if monitor := monitorsByID[monitorID]; monitor.Status != r.Status && !monitor.IsPaused() {
+			transitions = append(transitions, MonitorTransition{Monitor: monitor, Status: r.Status, At: r.CheckedAt})
+		}
+	}
+
+	// Statuses are all updated first so that monitors going down together see each other
+	var suppressed map[uuid.UUID]uuid.UUID
+	if s.incidentService != nil && len(transitions) > 0 {
+		suppressed = s.incidentService.RecordMonitorTransitions(ctx, transitions)
+	}
+	for _, t := range transitions {
+		var suppressedBy *uuid.UUID
+		if parentID, ok := suppressed[t.Monitor.ID]; ok {
+			suppressedBy = &parentID
+		}
+		s.publishStatusChange(ctx, &t.Monitor, latest[t.Monitor.ID], suppressedBy)
+	}
+
+	return len(valid), rejected, nil
+}
+
+// publishStatusChange notifies dashboards of a monitor transition; failures are only logged.
+// suppressedBy is the monitor the incident of a monitor going down was grouped under, if any.
+func (s *CheckResultService) publishStatusChange(ctx context.Context, monitor *models.Monitor, result models.CheckResult, suppressedBy *uuid.UUID) {
+	if s.publisher == nil {
+		return
+	}
+
+	event := realtime.NewEvent(realtime.EventMonitorStatusChanged, monitor.OrganizationID, realtime.MonitorStatusChange{
+		MonitorID:      monitor.ID,
+		PreviousStatus: monitor.Status,
+		Status:         result.Status,
+		CheckedAt:      result.CheckedAt,
+		SuppressedBy:   suppressedBy,
+		OwnerUserID:    monitor.OwnerUserID,
+		OwnerTeam:      monitor.OwnerTeam,
+	})
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		logger.WarnCtx(ctx, "Failed to publish monitor status change",
+			logger.String("monitor_id", monitor.ID.String()),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// ListMonitorResults returns a page of the results of an organization's monitor checked
+// within [from, to) and matching query, newest first, with the cursor of the next page.
+// It fails with utils.ErrInvalidCursor when page.Cursor was not issued by a previous call.
+func (s *CheckResultService) ListMonitorResults(ctx context.Context, organizationID, monitorID uuid.UUID, query utils.QueryParams, from, to time.Time, page utils.CursorParams) ([]models.CheckResult, *utils.CursorPagination, error) {
+	if !from.Before(to) {
+		return nil, nil, ErrInvalidCheckResultRange
+	}
+
+	var after *models.CheckResultCursor
+	if page.Cursor != "" {
+		after = &models.CheckResultCursor{}
+		if err := utils.DecodeCursor(page.Cursor, after); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	monitor, err := s.monitorRepository.GetByID(ctx, monitorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if monitor.OrganizationID != organizationID {
+		return nil, nil, common.ErrNotFound
+	}
+
+	// One extra result tells whether another page follows
+	results, err := s.checkResultRepository.ListByMonitor(ctx, organizationID, monitorID, query.FilterScope(), from.UTC(), to.UTC(), after, page.Limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pagination := &utils.CursorPagination{Limit: page.Limit}
+	if len(results) > page.Limit {
+		results = results[:page.Limit]
+		pagination.HasMore = true
+		if pagination.NextCursor, err = utils.EncodeCursor(results[len(results)-1].Cursor()); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode check result cursor: %w", err)
+		}
+	}
+	return results, pagination, nil
+}
+
+// ExportMonitorResults checks the range and the monitor of an export of the results of an
+// organization's monitor checked within [from, to) and matching query. It returns the
+// function streaming them to fn, newest first, up to maxCheckResultExportRows, so that
+// these errors are reported before the export starts.
+func (s *CheckResultService) ExportMonitorResults(ctx context.Context, organizationID, monitorID uuid.UUID, query utils.QueryParams, from, to time.Time) (func(fn func(*models.CheckResult) error) error, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidCheckResultRange
+	}
+
+	monitor, err := s.monitorRepository.GetByID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+
+	return func(fn func(*models.CheckResult) error) error {
+		return s.checkResultRepository.StreamByMonitor(ctx, organizationID, monitorID, query.FilterScope(), from.UTC(), to.UTC(), maxCheckResultExportRows, fn)
+	}, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+)
+
+// configResourceMonitor is the resource name of monitors in configuration plans
+const configResourceMonitor = "monitor"
+
+// ErrInvalidConfig is matched by every ConfigError
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ConfigError is returned when a configuration document cannot be synced, naming the
+// monitor at fault
+type ConfigError struct {
+	Monitor string `json:"monitor"`
+	Reason  string `json:"reason"`
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid configuration of monitor %q: %s", e.Monitor, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrInvalidConfig) match every ConfigError
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// ConfigSyncService syncs organizations with declarative configuration documents
+type ConfigSyncService struct {
+	monitorRepository repositories.MonitorRepository
+	settingsService   *OrganizationSettingsService
+	transactor        database.Transactor
+}
+
+func NewConfigSyncService(monitorRepository repositories.MonitorRepository, settingsService *OrganizationSettingsService, transactor database.Transactor) *ConfigSyncService {
+	return &ConfigSyncService{
+		monitorRepository: monitorRepository,
+		settingsService:   settingsService,
+		transactor:        transactor,
+	}
+}
+
+// monitorChange is a planned change with the monitor it writes
+type monitorChange struct {
+	change  dtos.ConfigChangeDto
+	monitor *models.Monitor
+}
+
+// Sync diffs config against the monitors of an organization and, unless dryRun is set,
+// applies the changes in a single transaction. It returns the plan in both cases. It fails
+// with a ConfigError when the document is inconsistent, and with common.ErrConflict when a
+// monitor is modified while the changes are applied.
+func (s *ConfigSyncService) Sync(ctx context.Context, organizationID uuid.UUID, config *dtos.OrganizationConfigDto, dryRun bool) (*dtos.ConfigPlanDto, error) {
+	current, err := s.monitorRepository.List(ctx, repositories.ByOrganization(organizationID), repositories.OrderBy("created_at ASC"))
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := s.settingsService.MonitorDefaults(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	desired := make([]dtos.MonitorConfigDto, len(config.Monitors))
+	for i, want := range config.Monitors {
+		desired[i] = withMonitorDefaults(want, defaults)
+	}
+	changes, unchanged, err := planMonitors(organizationID, current, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &dtos.ConfigPlanDto{DryRun: dryRun, Changes: make([]dtos.ConfigChangeDto, 0, len(changes)), Unchanged: unchanged}
+	for _, c := range changes {
+		switch c.change.Action {
+		case dtos.ConfigActionCreate:
+			plan.Create++
+		case dtos.ConfigActionUpdate:
+			plan.Update++
+		case dtos.ConfigActionDelete:
+			plan.Delete++
+		}
+	}
+	if dryRun || len(changes) == 0 {
+		for _, c := range changes {
+			plan.Changes = append(plan.Changes, c.change)
+		}
+		return plan, nil
+	}
+
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		for _, c := range changes {
+			switch c.change.Action {
+			case dtos.ConfigActionCreate:
+				err = s.monitorRepository.Create(ctx, c.monitor)
+			case dtos.ConfigActionUpdate:
+				err = s.monitorRepository.Update(ctx, c.monitor)
+				if _, ok := c.change.Fields["paused"]; ok && err == nil {
+					err = s.monitorRepository.SetPaused(ctx, c.monitor.ID, c.monitor.IsPaused())
+				}
+			case dtos.ConfigActionDelete:
+				err = s.monitorRepository.SoftDelete(ctx, c.monitor.ID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range changes {
+		if c.change.Action == dtos.ConfigActionCreate {
+			c.change.ID = &c.monitor.ID
+		}
+		plan.Changes = append(plan.Changes, c.change)
+	}
+	plan.Applied = true
+	return plan, nil
+}
+
+// planMonitors diffs the desired monitors against the current ones, matching them by name.
+// Creates and updates follow the document order and deletes come last, sorted by name. It
+// also returns how many monitors are already up to date.
+func planMonitors(organizationID uuid.UUID, current []models.Monitor, desired []dtos.MonitorConfigDto) ([]monitorChange, int, error) {
+	byName := make(map[string]*models.Monitor, len(current))
+	for i := range current {
+		if _, ok := byName[current[i].Name]; ok {
+			return nil, 0, &ConfigError{Monitor: current[i].Name, Reason: "several existing monitors have this name, rename them before syncing"}
+		}
+		byName[current[i].Name] = &current[i]
+	}
+
+	var changes []monitorChange
+	unchanged := 0
+	seen := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		if seen[want.Name] {
+			return nil, 0, &ConfigError{Monitor: want.Name, Reason: "the name is used by several monitors of the document"}
+		}
+		seen[want.Name] = true
+		if want.TimeoutSeconds >= want.IntervalSeconds {
+			return nil, 0, &ConfigError{Monitor: want.Name, Reason: ErrInvalidMonitorTimeout.Error()}
+		}
+
+		monitor, ok := byName[want.Name]
+		if !ok {
+			monitor = &models.Monitor{OrganizationID: organizationID, Status: models.MonitorStatusPending}
+			applyMonitorConfig(monitor, want)
+			changes = append(changes, monitorChange{
+				change:  dtos.ConfigChangeDto{Action: dtos.ConfigActionCreate, Resource: configResourceMonitor, Name: want.Name},
+				monitor: monitor,
+			})
+			continue
+		}
+
+		if monitor.Type != want.Type {
+			return nil, 0, &ConfigError{Monitor: want.Name, Reason: "the type of a monitor cannot change, rename it to replace the monitor"}
+		}
+		fields := diffMonitorConfig(monitor, want)
+		if len(fields) == 0 {
+			unchanged++
+			continue
+		}
+		applyMonitorConfig(monitor, want)
+		changes = append(changes, monitorChange{
+			change:  dtos.ConfigChangeDto{Action: dtos.ConfigActionUpdate, Resource: configResourceMonitor, Name: want.Name, ID: &monitor.ID, Fields: fields},
+			monitor: monitor,
+		})
+	}
+
+	var deletes []monitorChange
+	for name, monitor := range byName {
+		if !seen[name] {
+			deletes = append(deletes, monitorChange{
+				change:  dtos.ConfigChangeDto{Action: dtos.ConfigActionDelete, Resource: configResourceMonitor, Name: name, ID: &monitor.ID},
+				monitor: monitor,
+			})
+		}
+	}
+	sort.Slice(deletes, func(i, j int) bool { return deletes[i].change.Name < deletes[j].change.Name })
+
+	return append(changes, deletes...), unchanged, nil
+}
+
+// withMonitorDefaults fills the settings want leaves unset with defaults
+func withMonitorDefaults(want dtos.MonitorConfigDto, defaults models.MonitorDefaults) dtos.MonitorConfigDto {
+	if want.IntervalSeconds == 0 {
+		want.IntervalSeconds = defaults.IntervalSeconds
+	}
+	if want.TimeoutSeconds == 0 {
+		want.TimeoutSeconds = defaults.TimeoutSeconds
+	}
+	if want.Regions == nil {
+		want.Regions = defaults.Regions
+	} else {
+		want.Regions = uniqueRegions(want.Regions)
+	}
+	return want
+}
+
+// diffMonitorConfig returns the fields of monitor that differ from want
+func diffMonitorConfig(monitor *models.Monitor, want dtos.MonitorConfigDto) map[string]dtos.ConfigFieldDiffDto {
+	fields := make(map[string]dtos.ConfigFieldDiffDto)
+	if monitor.Target != want.Target {
+		fields["target"] = dtos.ConfigFieldDiffDto{From: monitor.Target, To: want.Target}
+	}
+	if monitor.IntervalSeconds != want.IntervalSeconds {
+		fields["interval_seconds"] = dtos.ConfigFieldDiffDto{From: monitor.IntervalSeconds, To: want.IntervalSeconds}
+	}
+	if monitor.TimeoutSeconds != want.TimeoutSeconds {
+		fields["timeout_seconds"] = dtos.ConfigFieldDiffDto{From: monitor.TimeoutSeconds, To: want.TimeoutSeconds}
+	}
+	if !slices.Equal(monitor.Regions, want.Regions) {
+		fields["regions"] = dtos.ConfigFieldDiffDto{From: monitor.Regions, To: want.Regions}
+	}
+	if monitor.IsPaused() != want.Paused {
+		fields["paused"] = dtos.ConfigFieldDiffDto{From: monitor.IsPaused(), To: want.Paused}
+	}
+	return fields
+}
+
+// applyMonitorConfig sets the fields of monitor to want. Pausing sets the paused status and
+// resuming resets it to pending until the next check.
+func applyMonitorConfig(monitor *models.Monitor, want dtos.MonitorConfigDto) {
+	monitor.Name = want.Name
+	monitor.Type = want.Type
+	monitor.Target = want.Target
+	monitor.IntervalSeconds = want.IntervalSeconds
+	monitor.TimeoutSeconds = want.TimeoutSeconds
+	monitor.Regions = want.Regions
+	switch {
+	case want.Paused:
+		monitor.Status = models.MonitorStatusPaused
+	case monitor.IsPaused():
+		monitor.Status = models.MonitorStatusPending
+	}
+}
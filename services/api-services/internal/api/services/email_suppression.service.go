@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+)
+
+// EmailSuppressionService manages the addresses the email service refuses to send to:
+// hard bounces, complaints and unsubscribes reported by the providers
+type EmailSuppressionService struct {
+	suppressionRepository repositories.EmailSuppressionRepository
+}
+
+func NewEmailSuppressionService(suppressionRepository repositories.EmailSuppressionRepository) *EmailSuppressionService {
+	return &EmailSuppressionService{
+		suppressionRepository: suppressionRepository,
+	}
+}
+
+// Record suppresses the address of every feedback entry so it receives no further email
+func (s *EmailSuppressionService) Record(ctx context.Context, feedback []email.Feedback) error {
+	for _, entry := range feedback {
+		if strings.TrimSpace(entry.Address) == "" {
+			continue
+		}
+
+		suppression := &models.EmailSuppression{
+			Email:      entry.Address,
+			Reason:     string(entry.Kind),
+			Provider:   entry.Provider,
+			OccurredAt: entry.OccurredAt,
+		}
+		if entry.Detail != "" {
+			detail := entry.Detail
+			suppression.Detail = &detail
+		}
+		if err := s.suppressionRepository.Suppress(ctx, suppression); err != nil {
+			return err
+		}
+
+		logger.InfoCtx(ctx, "Email address suppressed",
+			logger.Email("email", suppression.Email),
+			logger.String("reason", suppression.Reason),
+			logger.String("provider", suppression.Provider),
+		)
+	}
+	return nil
+}
+
+// List returns a page of suppressed addresses and the total match count
+func (s *EmailSuppressionService) List(ctx context.Context, query utils.QueryParams, page utils.Params) ([]models.EmailSuppression, int64, error) {
+	return s.suppressionRepository.List(ctx, query.FilterScope(), query.OrderScope(), page.PerPage, page.Offset)
+}
+
+// Get returns the suppression of address, or common.ErrNotFound when it is not suppressed
+func (s *EmailSuppressionService) Get(ctx context.Context, address string) (*models.EmailSuppression, error) {
+	return s.suppressionRepository.GetByEmail(ctx, address)
+}
+
+// Remove takes address off the suppression list so it receives email again. It fails with
+// common.ErrNotFound when the address is not suppressed.
+func (s *EmailSuppressionService) Remove(ctx context.Context, address string) error {
+	if err := s.suppressionRepository.Delete(ctx, address); err != nil {
+		return err
+	}
+	logger.InfoCtx(ctx, "Email address removed from the suppression list", logger.Email("email", address))
+	return nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+)
+
+// maxListedReports caps how many reports of an organization are listed
+const maxListedReports = 100
+
+var (
+	// ErrInvalidReportRange is returned when a report would cover no day, days in the
+	// future or more days than allowed
+	ErrInvalidReportRange = errors.New("invalid report range")
+	// ErrTooManyPendingReports is returned when an organization already waits for the
+	// most reports it may request at once
+	ErrTooManyPendingReports = errors.New("too many pending reports")
+)
+
+// GeneratedReportService queues the uptime report files members request, which the
+// report generation job renders, and signs the links to download them
+type GeneratedReportService struct {
+	reportRepository       repositories.GeneratedReportRepository
+	organizationRepository repositories.OrganizationRepository
+	storageDriver          storage.Driver
+	linkTTL                time.Duration
+	maxDays                int
+	maxPending             int
+}
+
+func NewGeneratedReportService(reportRepository repositories.GeneratedReportRepository, organizationRepository repositories.OrganizationRepository, storageDriver storage.Driver, linkTTL time.Duration, maxDays, maxPending int) *GeneratedReportService {
+	return &GeneratedReportService{
+		reportRepository:       reportRepository,
+		organizationRepository: organizationRepository,
+		storageDriver:          storageDriver,
+		linkTTL:                linkTTL,
+		maxDays:                maxDays,
+		maxPending:             maxPending,
+	}
+}
+
+// Request queues a report of an organization in format covering the days from from to to,
+// both included, in the time zone of the organization. Today may be included; its report
+// stops at the time it is generated.
+func (s *GeneratedReportService) Request(ctx context.Context, organizationID, userID uuid.UUID, format string, from, to time.Time) (*models.GeneratedReport, error) {
+	organization, err := s.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(organization.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) || to.After(today) {
+		return nil, fmt.Errorf("%w: the range must end after it starts and no later than today", ErrInvalidReportRange)
+	}
+	if days := int(to.Sub(from)/(24*time.Hour)) + 1; days > s.maxDays {
+		return nil, fmt.Errorf("%w: a report covers at most %d days", ErrInvalidReportRange, s.maxDays)
+	}
+
+	pending, err := s.reportRepository.CountPending(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if pending >= int64(s.maxPending) {
+		return nil, ErrTooManyPendingReports
+	}
+
+	report := &models.GeneratedReport{
+		OrganizationID: organizationID,
+		RequestedBy:    &userID,
+		Format:         format,
+		From:           from,
+		To:             to,
+		Status:         models.ReportStatusPending,
+	}
+	if err := s.reportRepository.Create(ctx, report); err != nil {
+		return nil, err
+	}
+	logger.InfoCtx(ctx, "Report requested",
+		logger.String("organization_id", organizationID.String()),
+		logger.String("report_id", report.ID.String()),
+		logger.String("format", format),
+	)
+	return report, nil
+}
+
+// List returns the latest reports of an organization with the download links of the ready ones
+func (s *GeneratedReportService) List(ctx context.Context, organizationID uuid.UUID) ([]models.GeneratedReport, error) {
+	reports, err := s.reportRepository.ListByOrganization(ctx, organizationID, maxListedReports)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reports {
+		if err := s.sign(ctx, &reports[i]); err != nil {
+			return nil, err
+		}
+	}
+	return reports, nil
+}
+
+// Get returns a report of the organization, with its download link once it is ready
+func (s *GeneratedReportService) Get(ctx context.Context, organizationID, id uuid.UUID) (*models.GeneratedReport, error) {
+	report, err := s.reportRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if report.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	if err := s.sign(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// sign sets the download link of a ready report
+func (s *GeneratedReportService) sign(ctx context.Context, report *models.GeneratedReport) error {
+	if report.Status != models.ReportStatusReady || report.StorageKey == nil {
+		return nil
+	}
+	link, err := s.storageDriver.GenerateSignedURL(ctx, *report.StorageKey, "GET", s.linkTTL)
+	if err != nil {
+		return fmt.Errorf("failed to sign report link: %w", err)
+	}
+	report.DownloadURL = link
+	return nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/integrations"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	// integrationTokenPrefix marks inbound integration tokens so that leaked ones are easy to recognize
+	integrationTokenPrefix = "uit_"
+
+	// integrationTokenSecretLength is the number of hex characters after the prefix
+	integrationTokenSecretLength = 48
+
+	// integrationTokenDisplayLength is how much of a token is kept to tell tokens apart
+	integrationTokenDisplayLength = len(integrationTokenPrefix) + 8
+)
+
+var (
+	// ErrInvalidIntegrationToken is returned for inbound integration tokens that are unknown
+	// or whose integration was deleted
+	ErrInvalidIntegrationToken = errors.New("invalid integration token")
+
+	// ErrInvalidIntegrationPayload is returned for webhook payloads the provider of an
+	// integration does not send
+	ErrInvalidIntegrationPayload = errors.New("invalid integration payload")
+)
+
+// InboundIntegrationService manages the inbound integrations of organizations and turns
+// the alerts they receive into incidents
+type InboundIntegrationService struct {
+	integrationRepository repositories.InboundIntegrationRepository
+	incidentService       *IncidentService
+}
+
+func NewInboundIntegrationService(integrationRepository repositories.InboundIntegrationRepository, incidentService *IncidentService) *InboundIntegrationService {
+	return &InboundIntegrationService{
+		integrationRepository: integrationRepository,
+		incidentService:       incidentService,
+	}
+}
+
+// List returns the inbound integrations of an organization
+func (s *InboundIntegrationService) List(ctx context.Context, organizationID uuid.UUID) ([]models.InboundIntegration, error) {
+	return s.integrationRepository.ListByOrganization(ctx, organizationID)
+}
+
+// Create adds an inbound integration to an organization. The returned token is the only
+// copy of its secret.
+func (s *InboundIntegrationService) Create(ctx context.Context, organizationID, userID uuid.UUID, req *dtos.CreateInboundIntegrationRequestDto) (*dtos.CreatedInboundIntegrationDto, error) {
+	secret, err := utils.GenerateRandomString(integrationTokenSecretLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate integration token: %w", err)
+	}
+	raw := integrationTokenPrefix + secret
+
+	integration := models.InboundIntegration{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		Provider:       req.Provider,
+		TokenHash:      hashSecretToken(raw),
+		TokenPrefix:    raw[:integrationTokenDisplayLength],
+		CreatedBy:      userID,
+	}
+	if err := s.integrationRepository.Create(ctx, &integration); err != nil {
+		return nil, err
+	}
+	return &dtos.CreatedInboundIntegrationDto{InboundIntegration: integration, Token: raw}, nil
+}
+
+// Delete deletes an inbound integration of an organization, which stops accepting its
+// webhooks at once. The incidents it opened are kept.
+func (s *InboundIntegrationService) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	integration, err := s.integrationRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if integration.OrganizationID != organizationID {
+		return common.ErrNotFound
+	}
+	return s.integrationRepository.SoftDelete(ctx, id)
+}
+
+// Receive authenticates a webhook by the token of its integration and applies the alerts
+// of its payload to the incidents of the integration's organization. It fails with
+// ErrInvalidIntegrationToken for unknown tokens and ErrInvalidIntegrationPayload for
+// payloads the provider of the integration does not send.
+func (s *InboundIntegrationService) Receive(ctx context.Context, raw string, payload []byte) (*dtos.InboundAlertResultDto, error) {
+	if !strings.HasPrefix(raw, integrationTokenPrefix) || len(raw) != len(integrationTokenPrefix)+integrationTokenSecretLength {
+		return nil, ErrInvalidIntegrationToken
+	}
+	integration, err := s.integrationRepository.GetByHash(ctx, hashSecretToken(raw))
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, ErrInvalidIntegrationToken
+		}
+		return nil, err
+	}
+	ctx = tenant.WithOrganization(ctx, integration.OrganizationID)
+
+	alerts, err := integrations.Parse(integration.Provider, payload)
+	if err != nil {
+		if errors.Is(err, integrations.ErrInvalidPayload) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidIntegrationPayload, err)
+		}
+		return nil, err
+	}
+
+	result, err := s.incidentService.IngestAlerts(ctx, integration, alerts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.integrationRepository.MarkReceived(ctx, integration.ID, time.Now().UTC()); err != nil {
+		logger.WarnCtx(ctx, "Failed to record inbound integration delivery",
+			logger.String("integration_id", integration.ID.String()),
+			logger.ErrorField(err),
+		)
+	}
+	return result, nil
+}
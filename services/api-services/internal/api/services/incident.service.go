@@ -0,0 +1,558 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/integrations"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+var (
+	// ErrIncidentAssigneeNotMember is returned when an incident is assigned to a user who is
+	// not a member of its organization
+	ErrIncidentAssigneeNotMember = errors.New("incident assignee is not a member of the organization")
+
+	// ErrIncidentAlreadyClaimed is returned when claiming an incident assigned to someone else
+	ErrIncidentAlreadyClaimed = errors.New("incident is assigned to someone else")
+
+	// ErrInvalidIncidentRef is returned for incident references that are neither an ID nor
+	// a short reference such as INC-1A2B3C4D
+	ErrInvalidIncidentRef = errors.New("invalid incident reference")
+
+	// ErrAmbiguousIncidentRef is returned when a short reference matches several incidents
+	ErrAmbiguousIncidentRef = errors.New("incident reference matches several incidents")
+
+	// ErrIncidentAlreadyAcknowledged is returned when acknowledging an incident twice
+	ErrIncidentAlreadyAcknowledged = errors.New("incident is already acknowledged")
+
+	// ErrIncidentNotResolved is returned when tagging the cause of an incident still open
+	ErrIncidentNotResolved = errors.New("incident is not resolved")
+)
+
+// minIncidentRefLength is the fewest hex digits of an ID a short reference may have
+const minIncidentRefLength = 4
+
+// maxIncidentExportRows caps a single CSV export of incidents
+const maxIncidentExportRows = 100000
+
+// IncidentService manages the incidents of organizations
+type IncidentService struct {
+	incidentRepository     repositories.IncidentRepository
+	dependencyRepository   repositories.MonitorDependencyRepository
+	monitorRepository      repositories.MonitorRepository
+	organizationRepository repositories.OrganizationRepository
+	probeService           *ProbeService
+	publisher              realtime.Publisher
+	outbox                 *outbox.Publisher
+}
+
+func NewIncidentService(
+	incidentRepository repositories.IncidentRepository,
+	dependencyRepository repositories.MonitorDependencyRepository,
+	monitorRepository repositories.MonitorRepository,
+	organizationRepository repositories.OrganizationRepository,
+	probeService *ProbeService,
+	publisher realtime.Publisher,
+	outbox *outbox.Publisher,
+) *IncidentService {
+	return &IncidentService{
+		incidentRepository:     incidentRepository,
+		dependencyRepository:   dependencyRepository,
+		monitorRepository:      monitorRepository,
+		organizationRepository: organizationRepository,
+		probeService:           probeService,
+		publisher:              publisher,
+		outbox:                 outbox,
+	}
+}
+
+// MonitorTransition is a monitor changing status after a check
+type MonitorTransition struct {
+	// Monitor is the monitor as it was before the check
+	Monitor models.Monitor
+	Status  string
+	At      time.Time
+}
+
+// ListOrganizationIncidents returns a page of an organization's incidents and the total match count
+func (s *IncidentService) ListOrganizationIncidents(ctx context.Context, organizationID uuid.UUID, query utils.QueryParams, page utils.Params) ([]models.Incident, int64, error) {
+	return s.incidentRepository.ListByOrganization(ctx, organizationID, query.FilterScope(), query.OrderScope(), page.PerPage, page.Offset)
+}
+
+// ExportOrganizationIncidents streams every incident matching query to fn, up to maxIncidentExportRows
+func (s *IncidentService) ExportOrganizationIncidents(ctx context.Context, organizationID uuid.UUID, query utils.QueryParams, fn func(*models.Incident) error) error {
+	return s.incidentRepository.StreamByOrganization(ctx, organizationID, query.FilterScope(), query.OrderScope(), maxIncidentExportRows, fn)
+}
+
+// ListAssignedIncidents returns a page of the open incidents assigned to a user in the
+// organizations they are a member of, newest first, and the total match count
+func (s *IncidentService) ListAssignedIncidents(ctx context.Context, userID uuid.UUID, page utils.Params) ([]models.Incident, int64, error) {
+	organizationIDs, err := s.organizationRepository.ListIDsByMember(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.incidentRepository.ListOpenByAssignee(ctx, organizationIDs, userID, page.PerPage, page.Offset)
+}
+
+// Workload returns how many incidents of the organization are open for each assignee, and
+// for nobody
+func (s *IncidentService) Workload(ctx context.Context, organizationID uuid.UUID) ([]models.IncidentWorkload, error) {
+	return s.incidentRepository.CountOpenByAssignee(ctx, organizationID)
+}
+
+// AssignOrganizationIncident assigns an incident of the organization to a member, or
+// unassigns it when assigneeID is nil. It fails with ErrIncidentAssigneeNotMember when the
+// assignee is not a member of the organization, and with common.ErrConflict when the
+// incident changed after the client read version.
+func (s *IncidentService) AssignOrganizationIncident(ctx context.Context, organizationID, id uuid.UUID, assigneeID *uuid.UUID, version int64) (*models.Incident, error) {
+	incident, err := s.getOrganizationIncident(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Version != version {
+		return nil, common.ErrConflict
+	}
+	if assigneeID != nil {
+		member, err := s.organizationRepository.IsMember(ctx, organizationID, *assigneeID)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, ErrIncidentAssigneeNotMember
+		}
+	}
+
+	if err := s.incidentRepository.UpdateAssignee(ctx, id, assigneeID, version); err != nil {
+		return nil, err
+	}
+	incident.AssigneeID = assigneeID
+	incident.Version++
+	s.publish(ctx, realtime.EventIncidentAssigned, incident)
+	return incident, nil
+}
+
+// ClaimOrganizationIncident assigns an incident of the organization to the member taking it
+// on. It fails with ErrIncidentAlreadyClaimed when someone else holds the incident, who has
+// to hand it over by reassigning it.
+func (s *IncidentService) ClaimOrganizationIncident(ctx context.Context, organizationID, id, userID uuid.UUID) (*models.Incident, error) {
+	incident, err := s.getOrganizationIncident(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.incidentRepository.Claim(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrIncidentAlreadyClaimed
+	}
+	if incident.AssigneeID != nil && *incident.AssigneeID == userID {
+		return incident, nil
+	}
+	incident.AssigneeID = &userID
+	incident.Version++
+	s.publish(ctx, realtime.EventIncidentAssigned, incident)
+	return incident, nil
+}
+
+// AcknowledgeOrganizationIncident records that someone, such as a chat user, acknowledged
+// the open incident of the organization ref refers to, either by ID or by short reference
+// such as INC-1A2B3C4D. It fails with common.ErrNotFound when no open incident matches,
+// ErrAmbiguousIncidentRef when several do and ErrIncidentAlreadyAcknowledged when it was
+// already acknowledged.
+func (s *IncidentService) AcknowledgeOrganizationIncident(ctx context.Context, organizationID uuid.UUID, ref, by string) (*models.Incident, error) {
+	prefix, ok := incidentRefPrefix(ref)
+	if !ok {
+		return nil, ErrInvalidIncidentRef
+	}
+	incidents, err := s.incidentRepository.ListOpenByIDPrefix(ctx, organizationID, prefix, 2)
+	if err != nil {
+		return nil, err
+	}
+	switch len(incidents) {
+	case 0:
+		return nil, common.ErrNotFound
+	case 1:
+	default:
+		return nil, ErrAmbiguousIncidentRef
+	}
+
+	incident := &incidents[0]
+	if incident.AcknowledgedAt != nil {
+		return nil, ErrIncidentAlreadyAcknowledged
+	}
+	now := time.Now().UTC()
+	acknowledged, err := s.incidentRepository.Acknowledge(ctx, incident.ID, by, now)
+	if err != nil {
+		return nil, err
+	}
+	if !acknowledged {
+		return nil, ErrIncidentAlreadyAcknowledged
+	}
+
+	incident.AcknowledgedAt = &now
+	incident.AcknowledgedBy = by
+	incident.Version++
+	s.publish(ctx, realtime.EventAlertAcknowledged, incident)
+	return incident, nil
+}
+
+// TagOrganizationIncident tags a resolved incident of the organization with its root cause,
+// one of the models.IncidentCause values, or untags it when cause is empty. The incident is
+// recorded again for the incident analytics. It fails with ErrIncidentNotResolved when the
+// incident is still open, and with common.ErrConflict when it changed after the client read
+// version.
+func (s *IncidentService) TagOrganizationIncident(ctx context.Context, organizationID, id uuid.UUID, cause string, version int64) (*models.Incident, error) {
+	incident, err := s.getOrganizationIncident(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Status != models.IncidentStatusResolved {
+		return nil, ErrIncidentNotResolved
+	}
+	if incident.Version != version {
+		return nil, common.ErrConflict
+	}
+
+	if err := s.incidentRepository.UpdateCause(ctx, id, cause, version); err != nil {
+		return nil, err
+	}
+	incident.Cause = cause
+	incident.Version++
+	s.queue(ctx, outbox.TopicIncidentRecord, incident)
+	return incident, nil
+}
+
+// getOrganizationIncident returns an incident of the organization, common.ErrNotFound when
+// it belongs to another one
+func (s *IncidentService) getOrganizationIncident(ctx context.Context, organizationID, id uuid.UUID) (*models.Incident, error) {
+	incident, err := s.incidentRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	return incident, nil
+}
+
+// IngestAlerts applies the alerts an inbound integration received. A firing alert opens an
+// incident unless one is already open for its fingerprint, and a resolved alert resolves
+// the open incident of its fingerprint. Dashboards are notified of every incident opened or
+// resolved.
+func (s *IncidentService) IngestAlerts(ctx context.Context, integration *models.InboundIntegration, alerts []integrations.Alert) (*dtos.InboundAlertResultDto, error) {
+	result := &dtos.InboundAlertResultDto{Received: len(alerts)}
+	for _, alert := range alerts {
+		open, err := s.incidentRepository.GetOpenByFingerprint(ctx, integration.OrganizationID, integration.Provider, alert.Fingerprint)
+		if err != nil && !errors.Is(err, common.ErrNotFound) {
+			return nil, err
+		}
+
+		switch {
+		case alert.Resolved && open != nil:
+			resolvedAt := time.Now().UTC()
+			if alert.EndedAt != nil {
+				resolvedAt = *alert.EndedAt
+			}
+			resolved, err := s.incidentRepository.Resolve(ctx, open.ID, resolvedAt)
+			if err != nil {
+				return nil, err
+			}
+			if !resolved {
+				result.Ignored++
+				continue
+			}
+			open.Status = models.IncidentStatusResolved
+			open.ResolvedAt = &resolvedAt
+			result.Resolved++
+			auditIncident(logger.AuditIncidentResolved, open)
+			s.publish(ctx, realtime.EventIncidentResolved, open)
+			s.queue(ctx, outbox.TopicIncidentResolved, open)
+			s.queue(ctx, outbox.TopicIncidentRecord, open)
+
+		case !alert.Resolved && open == nil:
+			incident := &models.Incident{
+				OrganizationID: integration.OrganizationID,
+				IntegrationID:  &integration.ID,
+				Source:         integration.Provider,
+				Fingerprint:    alert.Fingerprint,
+				Title:          alert.Title,
+				Description:    alert.Description,
+				Severity:       alert.Severity,
+				Status:         models.IncidentStatusOpen,
+				URL:            alert.URL,
+				Labels:         alert.Labels,
+				StartedAt:      alert.StartedAt,
+			}
+			if err := s.incidentRepository.Create(ctx, incident); err != nil {
+				return nil, err
+			}
+			result.Opened++
+			auditIncident(logger.AuditIncidentOpened, incident)
+			s.publish(ctx, realtime.EventIncidentCreated, incident)
+			s.queue(ctx, outbox.TopicIncidentOpened, incident)
+
+		default:
+			result.Ignored++
+		}
+	}
+	return result, nil
+}
+
+// RecordMonitorTransitions opens an incident for every monitor going down and resolves the
+// open incident of every monitor coming back. A monitor going down while a monitor it
+// depends on is down gets a suppressed incident, grouped under the incident of that
+// monitor, and dashboards are not notified of it. It returns the monitors that were
+// suppressed, mapped to the monitor they were grouped under. Failures are only logged so
+// that they never hold up ingestion.
+func (s *IncidentService) RecordMonitorTransitions(ctx context.Context, transitions []MonitorTransition) map[uuid.UUID]uuid.UUID {
+	suppressed := make(map[uuid.UUID]uuid.UUID)
+
+	var failures []monitorFailure
+	graphs := make(map[uuid.UUID]models.DependencyGraph)
+	for _, t := range transitions {
+		wasDown := t.Monitor.Status == models.MonitorStatusDown
+		isDown := t.Status == models.MonitorStatusDown
+		switch {
+		case wasDown && !isDown:
+			s.resolveMonitorIncident(ctx, &t.Monitor, t.At)
+		case !wasDown && isDown:
+			graph, ok := graphs[t.Monitor.OrganizationID]
+			if !ok {
+				dependencies, err := s.dependencyRepository.ListByOrganization(ctx, t.Monitor.OrganizationID)
+				if err != nil {
+					logger.ErrorCtx(ctx, "Failed to load monitor dependencies",
+						logger.String("organization_id", t.Monitor.OrganizationID.String()),
+						logger.ErrorField(err),
+					)
+				}
+				graph = models.NewDependencyGraph(dependencies)
+				graphs[t.Monitor.OrganizationID] = graph
+			}
+			downAncestors, err := s.downMonitors(ctx, graph.Ancestors(t.Monitor.ID))
+			if err != nil {
+				logger.ErrorCtx(ctx, "Failed to load dependency monitors",
+					logger.String("monitor_id", t.Monitor.ID.String()),
+					logger.ErrorField(err),
+				)
+			}
+			failures = append(failures, monitorFailure{transition: t, downAncestors: downAncestors})
+		}
+	}
+
+	// Monitors are opened before the ones depending on them, so that a whole chain going
+	// down in the same batch is grouped under the monitor at its root
+	sort.SliceStable(failures, func(i, j int) bool {
+		return len(failures[i].downAncestors) < len(failures[j].downAncestors)
+	})
+	for _, f := range failures {
+		if parentMonitorID, ok := s.openMonitorIncident(ctx, f); ok {
+			suppressed[f.transition.Monitor.ID] = parentMonitorID
+		}
+	}
+	return suppressed
+}
+
+// monitorFailure is a monitor going down with the monitors it depends on that are down,
+// nearest first
+type monitorFailure struct {
+	transition    MonitorTransition
+	downAncestors []uuid.UUID
+}
+
+// downMonitors returns the ids that are monitors currently down, keeping their order
+func (s *IncidentService) downMonitors(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	monitors, err := s.monitorRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	down := make(map[uuid.UUID]bool, len(monitors))
+	for _, monitor := range monitors {
+		if monitor.Status == models.MonitorStatusDown {
+			down[monitor.ID] = true
+		}
+	}
+
+	var result []uuid.UUID
+	for _, id := range ids {
+		if down[id] {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// openMonitorIncident opens the incident of a monitor going down unless one is already open.
+// When a monitor it depends on has an open incident the new one is suppressed and grouped
+// under the root of that incident, and the monitor it was grouped under is returned.
+func (s *IncidentService) openMonitorIncident(ctx context.Context, f monitorFailure) (uuid.UUID, bool) {
+	monitor := &f.transition.Monitor
+	fingerprint := monitor.ID.String()
+	if _, err := s.incidentRepository.GetOpenByFingerprint(ctx, monitor.OrganizationID, models.IncidentSourceMonitor, fingerprint); !errors.Is(err, common.ErrNotFound) {
+		if err != nil {
+			logger.ErrorCtx(ctx, "Failed to get open monitor incident", logger.String("monitor_id", fingerprint), logger.ErrorField(err))
+		}
+		return uuid.Nil, false
+	}
+
+	incident := &models.Incident{
+		OrganizationID: monitor.OrganizationID,
+		MonitorID:      &monitor.ID,
+		Source:         models.IncidentSourceMonitor,
+		Fingerprint:    fingerprint,
+		Title:          monitor.Name + " is down",
+		Severity:       integrations.SeverityCritical,
+		Status:         models.IncidentStatusOpen,
+		AssigneeID:     monitor.OwnerUserID,
+		Team:           monitor.OwnerTeam,
+		StartedAt:      f.transition.At,
+	}
+
+	var parentMonitorID uuid.UUID
+	for _, ancestorID := range f.downAncestors {
+		parent, err := s.incidentRepository.GetOpenByFingerprint(ctx, monitor.OrganizationID, models.IncidentSourceMonitor, ancestorID.String())
+		if err != nil {
+			if !errors.Is(err, common.ErrNotFound) {
+				logger.ErrorCtx(ctx, "Failed to get open monitor incident", logger.String("monitor_id", ancestorID.String()), logger.ErrorField(err))
+			}
+			continue
+		}
+		incident.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			incident.ParentID = parent.ParentID
+		}
+		incident.Suppressed = true
+		parentMonitorID = ancestorID
+		break
+	}
+
+	if hint := s.probeOutageHint(ctx, monitor); hint != "" {
+		incident.Hints = []string{hint}
+	}
+
+	if err := s.incidentRepository.Create(ctx, incident); err != nil {
+		logger.ErrorCtx(ctx, "Failed to open monitor incident", logger.String("monitor_id", fingerprint), logger.ErrorField(err))
+		return uuid.Nil, false
+	}
+	if incident.Suppressed {
+		return parentMonitorID, true
+	}
+	auditIncident(logger.AuditIncidentOpened, incident)
+	s.publish(ctx, realtime.EventIncidentCreated, incident)
+	s.queue(ctx, outbox.TopicIncidentOpened, incident)
+	return uuid.Nil, false
+}
+
+// resolveMonitorIncident resolves the open incident of a monitor that came back
+func (s *IncidentService) resolveMonitorIncident(ctx context.Context, monitor *models.Monitor, at time.Time) {
+	incident, err := s.incidentRepository.GetOpenByFingerprint(ctx, monitor.OrganizationID, models.IncidentSourceMonitor, monitor.ID.String())
+	if err != nil {
+		if !errors.Is(err, common.ErrNotFound) {
+			logger.ErrorCtx(ctx, "Failed to get open monitor incident", logger.String("monitor_id", monitor.ID.String()), logger.ErrorField(err))
+		}
+		return
+	}
+
+	resolved, err := s.incidentRepository.Resolve(ctx, incident.ID, at)
+	if err != nil {
+		logger.ErrorCtx(ctx, "Failed to resolve monitor incident", logger.String("monitor_id", monitor.ID.String()), logger.ErrorField(err))
+		return
+	}
+	if !resolved {
+		return
+	}
+	incident.Status = models.IncidentStatusResolved
+	incident.ResolvedAt = &at
+	s.queue(ctx, outbox.TopicIncidentResolved, incident)
+	s.queue(ctx, outbox.TopicIncidentRecord, incident)
+	if !incident.Suppressed {
+		auditIncident(logger.AuditIncidentResolved, incident)
+		s.publish(ctx, realtime.EventIncidentResolved, incident)
+	}
+}
+
+// probeOutageHint returns the hint of the probe regions checking a monitor that are offline,
+// empty when none is or probes are not tracked. Failures are only logged.
+func (s *IncidentService) probeOutageHint(ctx context.Context, monitor *models.Monitor) string {
+	if s.probeService == nil {
+		return ""
+	}
+	hint, err := s.probeService.OutageHint(ctx, monitor.Regions)
+	if err != nil {
+		logger.WarnCtx(ctx, "Failed to check probe outages", logger.String("monitor_id", monitor.ID.String()), logger.ErrorField(err))
+	}
+	return hint
+}
+
+// publish notifies dashboards of an incident change; failures are only logged.
+func (s *IncidentService) publish(ctx context.Context, eventType string, incident *models.Incident) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, realtime.NewEvent(eventType, incident.OrganizationID, incident)); err != nil {
+		logger.WarnCtx(ctx, "Failed to publish incident event",
+			logger.String("incident_id", incident.ID.String()),
+			logger.String("event", eventType),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// queue records an incident opened, resolved or tagged in the outbox for the side effects
+// that follow it, such as filing tickets; failures are only logged.
+func (s *IncidentService) queue(ctx context.Context, topic string, incident *models.Incident) {
+	if s.outbox == nil {
+		return
+	}
+	if err := s.outbox.PublishIncident(ctx, topic, incident.ID, incident.OrganizationID); err != nil {
+		logger.WarnCtx(ctx, "Failed to queue incident message",
+			logger.String("incident_id", incident.ID.String()),
+			logger.String("topic", topic),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// auditIncident records an incident change in the audit log, which the activity feed of
+// its organization is read from
+func auditIncident(event logger.AuditEvent, incident *models.Incident, fields ...logger.Field) {
+	logger.Audit(event, append([]logger.Field{
+		logger.String("organization_id", incident.OrganizationID.String()),
+		logger.String("incident_id", incident.ID.String()),
+		logger.String("source", incident.Source),
+		logger.String("title", incident.Title),
+	}, fields...)...)
+}
+
+// incidentRefPrefix returns the lowercase hex prefix of the IDs ref refers to: the whole ID,
+// or the digits of a short reference with or without its INC- prefix
+func incidentRefPrefix(ref string) (string, bool) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if id, err := uuid.Parse(ref); err == nil {
+		return id.String(), true
+	}
+
+	ref = strings.TrimPrefix(ref, strings.ToLower(models.IncidentRefPrefix))
+	if len(ref) < minIncidentRefLength || len(ref) > models.IncidentRefLength {
+		return "", false
+	}
+	for _, r := range ref {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", false
+		}
+	}
+	return ref, true
+}
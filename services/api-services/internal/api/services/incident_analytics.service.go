@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+)
+
+// maxIncidentAnalyticsRange bounds a single incident analytics request to the retention of
+// the incident records
+const maxIncidentAnalyticsRange = 2 * 365 * 24 * time.Hour
+
+// ErrInvalidIncidentAnalyticsRange is returned for incident analytics ranges that are empty
+// or too long
+var ErrInvalidIncidentAnalyticsRange = errors.New("invalid incident analytics range")
+
+// IncidentAnalyticsService serves the analytics of resolved incidents from ClickHouse
+type IncidentAnalyticsService struct {
+	analyticsRepository repositories.IncidentAnalyticsRepository
+}
+
+func NewIncidentAnalyticsService(analyticsRepository repositories.IncidentAnalyticsRepository) *IncidentAnalyticsService {
+	return &IncidentAnalyticsService{analyticsRepository: analyticsRepository}
+}
+
+// GetOrganizationAnalytics returns how many incidents of an organization were resolved over
+// [from, to) and their mean time to resolve, overall, by cause and by monitor. Incidents are
+// recorded once resolved, so the latest may be missing for a few seconds.
+func (s *IncidentAnalyticsService) GetOrganizationAnalytics(ctx context.Context, organizationID uuid.UUID, from, to time.Time) (*models.IncidentAnalytics, error) {
+	if !from.Before(to) || to.Sub(from) > maxIncidentAnalyticsRange {
+		return nil, ErrInvalidIncidentAnalyticsRange
+	}
+	from, to = from.UTC(), to.UTC()
+
+	summary, err := s.analyticsRepository.Summary(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byCause, err := s.analyticsRepository.ByCause(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byMonitor, err := s.analyticsRepository.ByMonitor(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if byCause == nil {
+		byCause = []models.IncidentCauseStats{}
+	}
+	if byMonitor == nil {
+		byMonitor = []models.IncidentMonitorStats{}
+	}
+
+	return &models.IncidentAnalytics{
+		From:      from,
+		To:        to,
+		Summary:   *summary,
+		ByCause:   byCause,
+		ByMonitor: byMonitor,
+	}, nil
+}
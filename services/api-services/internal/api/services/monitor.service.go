@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/checker"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+)
+
+const (
+	defaultMonitorPageSize = 100
+	maxMonitorPageSize     = 1000
+
+	// maxMonitorExportRows caps a single CSV export.
+	maxMonitorExportRows = 100000
+)
+
+var (
+	// ErrInvalidMonitorTimeout is returned when a monitor's timeout is not shorter than its interval
+	ErrInvalidMonitorTimeout = errors.New("monitor timeout must be shorter than its interval")
+
+	// ErrMonitorOwnerNotMember is returned when the user owning a monitor is not a member of
+	// its organization
+	ErrMonitorOwnerNotMember = errors.New("monitor owner is not a member of the organization")
+)
+
+// MonitorService handles monitor business logic
+type MonitorService struct {
+	monitorRepository      repositories.MonitorRepository
+	organizationRepository repositories.OrganizationRepository
+}
+
+func NewMonitorService(monitorRepository repositories.MonitorRepository, organizationRepository repositories.OrganizationRepository) *MonitorService {
+	return &MonitorService{
+		monitorRepository:      monitorRepository,
+		organizationRepository: organizationRepository,
+	}
+}
+
+// ListActiveMonitors returns up to limit schedulable monitors starting at offset.
+// It also reports whether more results are available.
+func (s *MonitorService) ListActiveMonitors(ctx context.Context, organizationID *uuid.UUID, limit, offset int) ([]models.Monitor, bool, error) {
+	if limit <= 0 {
+		limit = defaultMonitorPageSize
+	}
+	if limit > maxMonitorPageSize {
+		limit = maxMonitorPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Fetch one extra row to detect whether another page exists.
+	monitors, err := s.monitorRepository.ListActive(ctx, organizationID, limit+1, offset)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(monitors) > limit
+	if hasMore {
+		monitors = monitors[:limit]
+	}
+	return monitors, hasMore, nil
+}
+
+// GetMonitor returns a monitor by ID
+func (s *MonitorService) GetMonitor(ctx context.Context, id uuid.UUID) (*models.Monitor, error) {
+	return s.monitorRepository.GetByID(ctx, id)
+}
+
+// ListOrganizationMonitors returns a page of an organization's monitors and the total match count
+func (s *MonitorService) ListOrganizationMonitors(ctx context.Context, organizationID uuid.UUID, query utils.QueryParams, page utils.Params) ([]models.Monitor, int64, error) {
+	return s.monitorRepository.ListByOrganization(ctx, organizationID, query.FilterScope(), query.OrderScope(), page.PerPage, page.Offset)
+}
+
+// ExportOrganizationMonitors streams every monitor matching query to fn, up to maxMonitorExportRows
+func (s *MonitorService) ExportOrganizationMonitors(ctx context.Context, organizationID uuid.UUID, query utils.QueryParams, fn func(*models.Monitor) error) error {
+	return s.monitorRepository.StreamByOrganization(ctx, organizationID, query.FilterScope(), query.OrderScope(), maxMonitorExportRows, fn)
+}
+
+// GetOrganizationMonitor returns a monitor only if it belongs to the organization
+func (s *MonitorService) GetOrganizationMonitor(ctx context.Context, organizationID, id uuid.UUID) (*models.Monitor, error) {
+	monitor, err := s.monitorRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	return monitor, nil
+}
+
+// UpdateOrganizationMonitor applies req to a monitor of the organization. It fails with
+// common.ErrConflict when the monitor changed after the client read req.Version.
+func (s *MonitorService) UpdateOrganizationMonitor(ctx context.Context, organizationID, id uuid.UUID, req *dtos.UpdateMonitorRequestDto) (*models.Monitor, error) {
+	monitor, err := s.GetOrganizationMonitor(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.Version != req.Version {
+		return nil, common.ErrConflict
+	}
+
+	if req.Name != nil {
+		monitor.Name = *req.Name
+	}
+	if req.Target != nil {
+		monitor.Target = *req.Target
+	}
+	if req.IntervalSeconds != nil {
+		monitor.IntervalSeconds = *req.IntervalSeconds
+	}
+	if req.TimeoutSeconds != nil {
+		monitor.TimeoutSeconds = *req.TimeoutSeconds
+	}
+	if req.Regions != nil {
+		monitor.Regions = uniqueRegions(req.Regions)
+	}
+	if monitor.TimeoutSeconds >= monitor.IntervalSeconds {
+		return nil, ErrInvalidMonitorTimeout
+	}
+
+	if err := s.monitorRepository.Update(ctx, monitor); err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
+
+// SetOrganizationMonitorOwner replaces the owner of a monitor of the organization. It fails
+// with ErrMonitorOwnerNotMember when the owning user is not a member of the organization.
+func (s *MonitorService) SetOrganizationMonitorOwner(ctx context.Context, organizationID, id uuid.UUID, req *dtos.SetMonitorOwnerRequestDto) (*models.Monitor, error) {
+	monitor, err := s.GetOrganizationMonitor(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.OwnerUserID != nil {
+		member, err := s.organizationRepository.IsMember(ctx, organizationID, *req.OwnerUserID)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, ErrMonitorOwnerNotMember
+		}
+	}
+
+	monitor.OwnerUserID = req.OwnerUserID
+	monitor.OwnerTeam = strings.TrimSpace(req.OwnerTeam)
+	if err := s.monitorRepository.Update(ctx, monitor); err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
+
+// ValidateCheck runs the check of a monitor once without saving anything, so that its
+// settings can be tried before the monitor is created. It fails with
+// checker.ErrUnsupportedType for types the API cannot check and checker.ErrInvalidTarget for
+// targets that do not suit the type.
+func (s *MonitorService) ValidateCheck(ctx context.Context, req *dtos.ValidateMonitorRequestDto) (*checker.Result, error) {
+	timeout := models.DefaultMonitorTimeoutSeconds
+	if req.TimeoutSeconds != nil {
+		timeout = *req.TimeoutSeconds
+	}
+	return checker.Run(ctx, checker.Check{
+		Type:    req.Type,
+		Target:  strings.TrimSpace(req.Target),
+		Timeout: time.Duration(timeout) * time.Second,
+	})
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/checker"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// MonitorCheckCooldown is how long after a check of a monitor was requested another one may be
+const MonitorCheckCooldown = 30 * time.Second
+
+var (
+	// ErrMonitorCheckTooSoon is returned when a check of a monitor is requested less than
+	// MonitorCheckCooldown after the previous one
+	ErrMonitorCheckTooSoon = errors.New("a check of this monitor was requested recently")
+
+	// ErrMonitorPaused is returned when requesting a check of a paused monitor
+	ErrMonitorPaused = errors.New("monitor is paused")
+)
+
+// MonitorCheckService runs checks of monitors out of schedule, such as to verify that a
+// monitor recovered after a fix. Checks are queued through the outbox and run by the relay
+// from the API, whose results are ingested like those of probes.
+type MonitorCheckService struct {
+	monitorRepository  repositories.MonitorRepository
+	transactor         database.Transactor
+	outbox             *outbox.Publisher
+	checkResultService *CheckResultService
+}
+
+// NewMonitorCheckService creates the service. checkResultService ingests the results of the
+// checks run and may be nil for a service that only queues them.
+func NewMonitorCheckService(
+	monitorRepository repositories.MonitorRepository,
+	transactor database.Transactor,
+	outbox *outbox.Publisher,
+	checkResultService *CheckResultService,
+) *MonitorCheckService {
+	return &MonitorCheckService{
+		monitorRepository:  monitorRepository,
+		transactor:         transactor,
+		outbox:             outbox,
+		checkResultService: checkResultService,
+	}
+}
+
+// RequestCheck queues a check of a monitor of the organization. It fails with
+// checker.ErrUnsupportedType for monitors the API cannot check, ErrMonitorPaused for paused
+// monitors and ErrMonitorCheckTooSoon when a check was requested less than
+// MonitorCheckCooldown before.
+func (s *MonitorCheckService) RequestCheck(ctx context.Context, organizationID, id uuid.UUID) (*models.Monitor, error) {
+	monitor, err := s.monitorRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	if monitor.Type != models.MonitorTypeHTTP && monitor.Type != models.MonitorTypeTCP {
+		return nil, fmt.Errorf("%w: %q", checker.ErrUnsupportedType, monitor.Type)
+	}
+	if monitor.IsPaused() {
+		return nil, ErrMonitorPaused
+	}
+
+	now := time.Now().UTC()
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		requested, err := s.monitorRepository.RequestCheck(ctx, monitor.ID, now, MonitorCheckCooldown)
+		if err != nil {
+			return err
+		}
+		if !requested {
+			return ErrMonitorCheckTooSoon
+		}
+		return s.outbox.PublishMonitorCheck(ctx, monitor.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	monitor.CheckRequestedAt = &now
+	return monitor, nil
+}
+
+// HandleCheck returns the handler of outbox.TopicMonitorCheck messages, running the check of
+// the monitor and ingesting its result. Checks of monitors deleted or paused since, or whose
+// target resolves to an address that is not public, are dropped.
+func (s *MonitorCheckService) HandleCheck() outbox.Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var message outbox.MonitorCheckMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return fmt.Errorf("invalid monitor check payload: %w", err)
+		}
+
+		monitor, err := s.monitorRepository.GetByID(ctx, message.MonitorID)
+		if err != nil {
+			if errors.Is(err, common.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if monitor.IsPaused() {
+			return nil
+		}
+
+		result, err := checker.Run(ctx, checker.Check{
+			Type:    monitor.Type,
+			Target:  monitor.Target,
+			Timeout: time.Duration(monitor.TimeoutSeconds) * time.Second,
+		})
+		if err != nil {
+			logger.WarnCtx(ctx, "Dropping requested monitor check",
+				logger.String("monitor_id", monitor.ID.String()),
+				logger.ErrorField(err),
+			)
+			return nil
+		}
+
+		_, _, err = s.checkResultService.Ingest(ctx, []models.CheckResult{{
+			MonitorID:  monitor.ID,
+			ProbeID:    models.APIProbeID,
+			Region:     models.APIProbeID,
+			Status:     result.Status,
+			LatencyMs:  result.LatencyMs,
+			StatusCode: int32(result.StatusCode),
+			Error:      result.Error,
+			CheckedAt:  result.CheckedAt,
+		}})
+		return err
+	}
+}
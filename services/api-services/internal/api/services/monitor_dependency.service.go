@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+)
+
+var (
+	// ErrDependencyMonitorNotFound is returned when a monitor to depend on is not one of the
+	// organization
+	ErrDependencyMonitorNotFound = errors.New("dependency monitor not found")
+
+	// ErrDependencyCycle is returned when a monitor would depend on itself, directly or
+	// through other monitors
+	ErrDependencyCycle = errors.New("monitor dependencies would form a cycle")
+)
+
+// MonitorDependencyService manages which monitors the monitors of an organization depend on
+type MonitorDependencyService struct {
+	dependencyRepository repositories.MonitorDependencyRepository
+	monitorRepository    repositories.MonitorRepository
+	transactor           database.Transactor
+}
+
+func NewMonitorDependencyService(dependencyRepository repositories.MonitorDependencyRepository, monitorRepository repositories.MonitorRepository, transactor database.Transactor) *MonitorDependencyService {
+	return &MonitorDependencyService{
+		dependencyRepository: dependencyRepository,
+		monitorRepository:    monitorRepository,
+		transactor:           transactor,
+	}
+}
+
+// Get returns the dependencies of a monitor of an organization
+func (s *MonitorDependencyService) Get(ctx context.Context, organizationID, monitorID uuid.UUID) (*dtos.MonitorDependenciesDto, error) {
+	if err := s.checkMonitor(ctx, organizationID, monitorID); err != nil {
+		return nil, err
+	}
+
+	dependencies, err := s.dependencyRepository.ListByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	dependents, err := s.dependencyRepository.ListDependents(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependsOn := models.NewDependencyGraph(dependencies)[monitorID]
+	if dependsOn == nil {
+		dependsOn = []uuid.UUID{}
+	}
+	if dependents == nil {
+		dependents = []uuid.UUID{}
+	}
+	return &dtos.MonitorDependenciesDto{MonitorID: monitorID, DependsOn: dependsOn, Dependents: dependents}, nil
+}
+
+// Set replaces the monitors a monitor of an organization depends on. It fails with
+// ErrDependencyMonitorNotFound when one of them is not a monitor of the organization and
+// with ErrDependencyCycle when the monitor would end up depending on itself.
+func (s *MonitorDependencyService) Set(ctx context.Context, organizationID, monitorID uuid.UUID, dependsOn []uuid.UUID) (*dtos.MonitorDependenciesDto, error) {
+	if err := s.checkMonitor(ctx, organizationID, monitorID); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(dependsOn))
+	seen := make(map[uuid.UUID]bool, len(dependsOn))
+	for _, id := range dependsOn {
+		if id == monitorID {
+			return nil, ErrDependencyCycle
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	monitors, err := s.monitorRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	found := 0
+	for _, monitor := range monitors {
+		if monitor.OrganizationID == organizationID {
+			found++
+		}
+	}
+	if found != len(ids) {
+		return nil, ErrDependencyMonitorNotFound
+	}
+
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		dependencies, err := s.dependencyRepository.ListByOrganization(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		graph := models.NewDependencyGraph(dependencies)
+		for _, id := range ids {
+			if graph.DependsOn(id, monitorID) {
+				return ErrDependencyCycle
+			}
+		}
+		return s.dependencyRepository.Replace(ctx, organizationID, monitorID, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, organizationID, monitorID)
+}
+
+// checkMonitor returns common.ErrNotFound unless monitorID is a monitor of the organization
+func (s *MonitorDependencyService) checkMonitor(ctx context.Context, organizationID, monitorID uuid.UUID) error {
+	monitor, err := s.monitorRepository.GetByID(ctx, monitorID)
+	if err != nil {
+		return err
+	}
+	if monitor.OrganizationID != organizationID {
+		return common.ErrNotFound
+	}
+	return nil
+}
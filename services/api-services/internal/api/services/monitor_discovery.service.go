@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/discovery"
+)
+
+// defaultDiscoveryLimit is how many monitors a discovery proposes when no limit is given
+const defaultDiscoveryLimit = 50
+
+// MonitorDiscoveryService proposes monitors for the endpoints listed in the sitemaps and
+// OpenAPI documents of sites, and imports the ones picked
+type MonitorDiscoveryService struct {
+	discoverer        *discovery.Discoverer
+	monitorRepository repositories.MonitorRepository
+	settingsService   *OrganizationSettingsService
+	transactor        database.Transactor
+}
+
+func NewMonitorDiscoveryService(discoverer *discovery.Discoverer, monitorRepository repositories.MonitorRepository, settingsService *OrganizationSettingsService, transactor database.Transactor) *MonitorDiscoveryService {
+	return &MonitorDiscoveryService{
+		discoverer:        discoverer,
+		monitorRepository: monitorRepository,
+		settingsService:   settingsService,
+		transactor:        transactor,
+	}
+}
+
+// Discover previews the HTTP monitors proposed for the endpoints of the site or API at
+// req.URL, flagging the ones the organization already monitors. Nothing is created.
+func (s *MonitorDiscoveryService) Discover(ctx context.Context, organizationID uuid.UUID, req *dtos.DiscoverMonitorsRequestDto) (*dtos.MonitorDiscoveryDto, error) {
+	source := req.Source
+	if source == "" {
+		source = discovery.SourceAuto
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDiscoveryLimit
+	}
+
+	result, err := s.discoverer.Discover(ctx, req.URL, source, limit)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.monitoredTargets(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &dtos.MonitorDiscoveryDto{
+		Source:      result.Source,
+		DocumentURL: result.DocumentURL,
+		Monitors:    make([]dtos.DiscoveredMonitorDto, 0, len(result.Endpoints)),
+		Truncated:   result.Truncated,
+	}
+	for _, endpoint := range result.Endpoints {
+		preview.Monitors = append(preview.Monitors, dtos.DiscoveredMonitorDto{
+			Name:   endpoint.Name,
+			Type:   models.MonitorTypeHTTP,
+			Target: endpoint.Target,
+			Exists: existing[endpoint.Target],
+		})
+	}
+	return preview, nil
+}
+
+// Import creates the HTTP monitors picked from a discovery preview in a single transaction.
+// Monitors whose target the organization already monitors, or that repeat a target of the
+// request, are skipped. It fails with ErrInvalidMonitorTimeout when a timeout is not
+// shorter than its interval.
+func (s *MonitorDiscoveryService) Import(ctx context.Context, organizationID uuid.UUID, req *dtos.ImportMonitorsRequestDto) (*dtos.ImportMonitorsResultDto, error) {
+	existing, err := s.monitoredTargets(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := s.settingsService.MonitorDefaults(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.ImportMonitorsResultDto{Created: []models.Monitor{}, Skipped: []string{}}
+	for _, m := range req.Monitors {
+		if existing[m.Target] {
+			result.Skipped = append(result.Skipped, m.Target)
+			continue
+		}
+		existing[m.Target] = true
+
+		monitor := models.Monitor{
+			OrganizationID:  organizationID,
+			Name:            m.Name,
+			Type:            models.MonitorTypeHTTP,
+			Target:          m.Target,
+			IntervalSeconds: m.IntervalSeconds,
+			TimeoutSeconds:  m.TimeoutSeconds,
+			Regions:         defaults.Regions,
+			Status:          models.MonitorStatusPending,
+		}
+		if monitor.IntervalSeconds == 0 {
+			monitor.IntervalSeconds = defaults.IntervalSeconds
+		}
+		if monitor.TimeoutSeconds == 0 {
+			monitor.TimeoutSeconds = defaults.TimeoutSeconds
+		}
+		if monitor.TimeoutSeconds >= monitor.IntervalSeconds {
+			return nil, ErrInvalidMonitorTimeout
+		}
+		result.Created = append(result.Created, monitor)
+	}
+	if len(result.Created) == 0 {
+		return result, nil
+	}
+
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		for i := range result.Created {
+			if err := s.monitorRepository.Create(ctx, &result.Created[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// monitoredTargets returns the targets of the HTTP monitors of an organization
+func (s *MonitorDiscoveryService) monitoredTargets(ctx context.Context, organizationID uuid.UUID) (map[string]bool, error) {
+	monitors, err := s.monitorRepository.List(ctx, repositories.ByOrganization(organizationID))
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]bool, len(monitors))
+	for _, monitor := range monitors {
+		if monitor.Type == models.MonitorTypeHTTP {
+			targets[monitor.Target] = true
+		}
+	}
+	return targets, nil
+}
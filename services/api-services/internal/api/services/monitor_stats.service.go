@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+)
+
+const (
+	// maxStatsRange bounds a single stats request to the day rollup retention
+	maxStatsRange = 2 * 365 * 24 * time.Hour
+
+	// maxStatsPoints bounds the series length when a resolution is requested explicitly
+	maxStatsPoints = 1500
+)
+
+// ErrInvalidStatsRange is returned for stats ranges that are empty, too long or too fine-grained
+var ErrInvalidStatsRange = errors.New("invalid stats range")
+
+// statsResolutionSteps is the bucket width of each resolution
+var statsResolutionSteps = map[string]time.Duration{
+	models.StatsResolutionMinute: time.Minute,
+	models.StatsResolutionHour:   time.Hour,
+	models.StatsResolutionDay:    24 * time.Hour,
+}
+
+// MonitorStatsService serves uptime and latency statistics from the ClickHouse rollups
+type MonitorStatsService struct {
+	monitorRepository repositories.MonitorRepository
+	statsRepository   repositories.MonitorStatsRepository
+}
+
+func NewMonitorStatsService(monitorRepository repositories.MonitorRepository, statsRepository repositories.MonitorStatsRepository) *MonitorStatsService {
+	return &MonitorStatsService{
+		monitorRepository: monitorRepository,
+		statsRepository:   statsRepository,
+	}
+}
+
+// StatsResolutionFor picks the coarsest rollup that still gives a useful series for the range:
+// minutes up to a day, hours up to a month and days beyond that.
+func StatsResolutionFor(from, to time.Time) string {
+	switch span := to.Sub(from); {
+	case span <= 24*time.Hour:
+		return models.StatsResolutionMinute
+	case span <= 31*24*time.Hour:
+		return models.StatsResolutionHour
+	default:
+		return models.StatsResolutionDay
+	}
+}
+
+// GetMonitorStats returns the summary and series of an organization's monitor over [from, to).
+// An empty resolution is chosen from the range; the bounds are aligned to bucket boundaries.
+func (s *MonitorStatsService) GetMonitorStats(ctx context.Context, organizationID, monitorID uuid.UUID, from, to time.Time, resolution string) (*models.MonitorStats, error) {
+	if !from.Before(to) || to.Sub(from) > maxStatsRange {
+		return nil, ErrInvalidStatsRange
+	}
+	if resolution == "" {
+		resolution = StatsResolutionFor(from, to)
+	}
+	step, ok := statsResolutionSteps[resolution]
+	if !ok || to.Sub(from)/step > maxStatsPoints {
+		return nil, ErrInvalidStatsRange
+	}
+
+	monitor, err := s.monitorRepository.GetByID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if monitor.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+
+	from = from.UTC().Truncate(step)
+	to = to.UTC()
+
+	summary, err := s.statsRepository.Summary(ctx, organizationID, monitorID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.statsRepository.Series(ctx, organizationID, monitorID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MonitorStats{
+		MonitorID:  monitorID,
+		From:       from,
+		To:         to,
+		Resolution: resolution,
+		Summary:    *summary,
+		Series:     series,
+	}, nil
+}
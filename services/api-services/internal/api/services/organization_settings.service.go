@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ErrInvalidDefaultTimeout is returned when the default monitor timeout of an organization
+// is not shorter than its default interval
+var ErrInvalidDefaultTimeout = errors.New("default monitor timeout must be shorter than the default interval")
+
+// OrganizationSettingsService manages the defaults of organizations, which new monitors
+// get when they leave settings unset
+type OrganizationSettingsService struct {
+	settingsRepository repositories.OrganizationSettingsRepository
+	retentionService   *RetentionPolicyService
+}
+
+func NewOrganizationSettingsService(settingsRepository repositories.OrganizationSettingsRepository, retentionService *RetentionPolicyService) *OrganizationSettingsService {
+	return &OrganizationSettingsService{
+		settingsRepository: settingsRepository,
+		retentionService:   retentionService,
+	}
+}
+
+// Get returns the settings of an organization with the built-in defaults applied
+func (s *OrganizationSettingsService) Get(ctx context.Context, organizationID uuid.UUID) (*dtos.OrganizationSettingsDto, error) {
+	settings, err := s.find(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toDto(ctx, organizationID, settings)
+}
+
+// Update replaces the settings of an organization. It fails with ErrInvalidDefaultTimeout
+// when the default timeout, once the built-in defaults are applied, is not shorter than the
+// default interval.
+func (s *OrganizationSettingsService) Update(ctx context.Context, organizationID uuid.UUID, req *dtos.UpdateOrganizationSettingsRequestDto) (*dtos.OrganizationSettingsDto, error) {
+	settings := &models.OrganizationSettings{
+		OrganizationID:          organizationID,
+		DefaultIntervalSeconds:  req.DefaultIntervalSeconds,
+		DefaultTimeoutSeconds:   req.DefaultTimeoutSeconds,
+		DefaultRegions:          uniqueRegions(req.DefaultRegions),
+		EscalationAfterMinutes:  req.EscalationAfterMinutes,
+		EscalationRepeatMinutes: req.EscalationRepeatMinutes,
+	}
+	if defaults := settings.MonitorDefaults(); defaults.TimeoutSeconds >= defaults.IntervalSeconds {
+		return nil, ErrInvalidDefaultTimeout
+	}
+
+	if err := s.settingsRepository.Upsert(ctx, settings); err != nil {
+		return nil, err
+	}
+	logger.InfoCtx(ctx, "Organization settings updated", logger.String("organization_id", organizationID.String()))
+	return s.toDto(ctx, organizationID, settings)
+}
+
+// MonitorDefaults returns the settings new monitors of an organization get when they leave
+// them unset
+func (s *OrganizationSettingsService) MonitorDefaults(ctx context.Context, organizationID uuid.UUID) (models.MonitorDefaults, error) {
+	settings, err := s.find(ctx, organizationID)
+	if err != nil {
+		return models.MonitorDefaults{}, err
+	}
+	return settings.MonitorDefaults(), nil
+}
+
+// find returns the settings of an organization, nil when it has none
+func (s *OrganizationSettingsService) find(ctx context.Context, organizationID uuid.UUID) (*models.OrganizationSettings, error) {
+	settings, err := s.settingsRepository.Get(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// toDto applies the built-in defaults to settings, which may be nil, and adds the data
+// retention of the organization
+func (s *OrganizationSettingsService) toDto(ctx context.Context, organizationID uuid.UUID, settings *models.OrganizationSettings) (*dtos.OrganizationSettingsDto, error) {
+	retention, err := s.retentionService.Get(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &dtos.OrganizationSettingsDto{
+		OrganizationID:  organizationID,
+		MonitorDefaults: settings.MonitorDefaults(),
+		DataRetention:   retention,
+	}
+	if settings == nil {
+		return dto, nil
+	}
+	if settings.EscalationAfterMinutes != nil {
+		dto.Escalation.AfterMinutes = *settings.EscalationAfterMinutes
+	}
+	if settings.EscalationRepeatMinutes != nil {
+		dto.Escalation.RepeatMinutes = *settings.EscalationRepeatMinutes
+	}
+	dto.Custom = settings.DefaultIntervalSeconds != nil || settings.DefaultTimeoutSeconds != nil || len(settings.DefaultRegions) > 0 ||
+		settings.EscalationAfterMinutes != nil || settings.EscalationRepeatMinutes != nil
+	return dto, nil
+}
+
+// uniqueRegions returns regions without duplicates, in their order, and never nil
+func uniqueRegions(regions []string) []string {
+	unique := make([]string, 0, len(regions))
+	seen := make(map[string]bool, len(regions))
+	for _, region := range regions {
+		if !seen[region] {
+			seen[region] = true
+			unique = append(unique, region)
+		}
+	}
+	return unique
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/common"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
@@ -23,37 +24,38 @@ func NewUserOTPManagerService(repo otp.Repository, secSvc *otp.OTPService) *User
 }
 
 // GenerateAndSaveOTP: generate domain OTP via security service and persist via repo.
-func (s *UserOTPManagerService) GenerateAndSaveOTP(ctx context.Context, otpType common.OTPType, identifier string) (string, error) {
+// Returns the code and how long it stays valid.
+func (s *UserOTPManagerService) GenerateAndSaveOTP(ctx context.Context, otpType common.OTPType, identifier string) (string, time.Duration, error) {
 	otpObj, ttl, err := s.secSvc.Generate(identifier, otpType)
 	if err != nil {
-		logger.Error("service: failed to generate OTP",
+		logger.ErrorCtx(ctx, "service: failed to generate OTP",
 			logger.String("identifier", identifier),
 			logger.String("otp_type", string(otpType)),
 			logger.ErrorField(err))
-		return "", fmt.Errorf("failed to generate otp: %w", err)
+		return "", 0, fmt.Errorf("failed to generate otp: %w", err)
 	}
 
 	if err := s.repo.SaveOTP(ctx, otpObj, ttl); err != nil {
-		logger.Error("service: failed to save OTP",
+		logger.ErrorCtx(ctx, "service: failed to save OTP",
 			logger.String("identifier", identifier),
 			logger.String("otp_type", string(otpType)),
 			logger.ErrorField(err))
-		return "", fmt.Errorf("failed to persist otp: %w", err)
+		return "", 0, fmt.Errorf("failed to persist otp: %w", err)
 	}
 
-	logger.Info("service: otp generated and persisted",
+	logger.InfoCtx(ctx, "service: otp generated and persisted",
 		logger.String("identifier", identifier),
 		logger.String("otp_type", string(otpType)),
 	)
 
-	return otpObj.Code, nil
+	return otpObj.Code, ttl, nil
 }
 
 // VerifyOTP: orchestrates retrieval, calls secSvc.Validate (domain rules), persists changes & cleans up as required.
 func (s *UserOTPManagerService) VerifyOTP(ctx context.Context, otpType common.OTPType, identifier string, code string) (bool, error) {
 	storedOTP, err := s.repo.GetOTP(ctx, string(otpType), identifier)
 	if err != nil {
-		logger.Warn("service: otp not found or repo error",
+		logger.WarnCtx(ctx, "service: otp not found or repo error",
 			logger.String("identifier", identifier),
 			logger.String("otp_type", string(otpType)),
 			logger.ErrorField(err))
@@ -65,32 +67,32 @@ func (s *UserOTPManagerService) VerifyOTP(ctx context.Context, otpType common.OT
 		switch err {
 		case common.ErrInvalidOTP:
 			if updateErr := s.repo.UpdateOTP(ctx, storedOTP); updateErr != nil {
-				logger.Error("service: failed to update OTP attempts",
+				logger.ErrorCtx(ctx, "service: failed to update OTP attempts",
 					logger.String("identifier", identifier),
 					logger.String("otp_type", string(otpType)),
 					logger.ErrorField(updateErr))
 			}
-			logger.Warn("service: invalid otp provided",
+			logger.WarnCtx(ctx, "service: invalid otp provided",
 				logger.String("identifier", identifier),
 				logger.String("otp_type", string(otpType)))
 			return false, common.ErrInvalidOTP
 		case common.ErrTooManyAttempts:
 			// attempts reached: remove OTP
 			_ = s.repo.DeleteOTP(ctx, string(otpType), identifier)
-			logger.Warn("service: too many attempts - otp deleted",
+			logger.WarnCtx(ctx, "service: too many attempts - otp deleted",
 				logger.String("identifier", identifier),
 				logger.String("otp_type", string(otpType)))
 			return false, common.ErrTooManyAttempts
 		case common.ErrOTPExpired:
 			_ = s.repo.DeleteOTP(ctx, string(otpType), identifier)
-			logger.Warn("service: otp expired and removed",
+			logger.WarnCtx(ctx, "service: otp expired and removed",
 				logger.String("identifier", identifier),
 				logger.String("otp_type", string(otpType)))
 			return false, common.ErrOTPExpired
 		case common.ErrOTPAlreadyUsed:
 			return false, common.ErrOTPAlreadyUsed
 		default:
-			logger.Error("service: validation returned unexpected error",
+			logger.ErrorCtx(ctx, "service: validation returned unexpected error",
 				logger.String("identifier", identifier),
 				logger.String("otp_type", string(otpType)),
 				logger.ErrorField(err))
@@ -100,14 +102,14 @@ func (s *UserOTPManagerService) VerifyOTP(ctx context.Context, otpType common.OT
 
 	// success: OTP was marked Used inside Validate; persist or delete as desired.
 	if dErr := s.repo.DeleteOTP(ctx, string(otpType), identifier); dErr != nil {
-		logger.Error("service: failed to delete OTP after successful verification",
+		logger.ErrorCtx(ctx, "service: failed to delete OTP after successful verification",
 			logger.String("identifier", identifier),
 			logger.String("otp_type", string(otpType)),
 			logger.ErrorField(dErr),
 		)
 	}
 
-	logger.Info("service: otp verified successfully",
+	logger.InfoCtx(ctx, "service: otp verified successfully",
 		logger.String("identifier", identifier),
 		logger.String("otp_type", string(otpType)),
 	)
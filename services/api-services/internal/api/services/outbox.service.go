@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// OutboxService lets operators inspect outbox messages and recover the ones the relay
+// gave up on, by queueing them again or discarding them
+type OutboxService struct {
+	messageRepository repositories.OutboxMessageRepository
+}
+
+func NewOutboxService(messageRepository repositories.OutboxMessageRepository) *OutboxService {
+	return &OutboxService{
+		messageRepository: messageRepository,
+	}
+}
+
+// List returns a page of outbox messages and the total match count
+func (s *OutboxService) List(ctx context.Context, query utils.QueryParams, page utils.Params) ([]models.OutboxMessage, int64, error) {
+	return s.messageRepository.List(ctx, query.FilterScope(), query.OrderScope(), page.PerPage, page.Offset)
+}
+
+// Get returns an outbox message with its error history, or common.ErrNotFound
+func (s *OutboxService) Get(ctx context.Context, id uuid.UUID) (*models.OutboxMessage, error) {
+	return s.messageRepository.GetByID(ctx, id)
+}
+
+// Retry queues a pending or failed message for immediate delivery with a fresh set of
+// attempts. It fails with common.ErrNotFound or common.ErrOutboxMessageSent.
+func (s *OutboxService) Retry(ctx context.Context, id uuid.UUID) error {
+	if err := s.messageRepository.Requeue(ctx, id); err != nil {
+		return err
+	}
+	logger.InfoCtx(ctx, "Outbox message requeued", logger.String("id", id.String()))
+	return nil
+}
+
+// RetryFailed queues every failed message again, only those of topic when it is set, and
+// returns how many were queued
+func (s *OutboxService) RetryFailed(ctx context.Context, topic string) (int64, error) {
+	count, err := s.messageRepository.RequeueFailed(ctx, topic)
+	if err != nil {
+		return 0, err
+	}
+	logger.InfoCtx(ctx, "Failed outbox messages requeued", logger.String("topic", topic), logger.Int64("count", count))
+	return count, nil
+}
+
+// Discard deletes a pending or failed message so it is never delivered. It fails with
+// common.ErrNotFound or common.ErrOutboxMessageSent.
+func (s *OutboxService) Discard(ctx context.Context, id uuid.UUID) error {
+	if err := s.messageRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	logger.InfoCtx(ctx, "Outbox message discarded", logger.String("id", id.String()))
+	return nil
+}
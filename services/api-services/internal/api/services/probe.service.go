@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// probeTouchInterval is how often a replica records that a probe reporting results was seen
+const probeTouchInterval = 30 * time.Second
+
+// ProbeService tracks the probes of the network from the check results they report
+type ProbeService struct {
+	probeRepository repositories.ProbeRepository
+
+	mu      sync.Mutex
+	touched map[string]time.Time
+}
+
+func NewProbeService(probeRepository repositories.ProbeRepository) *ProbeService {
+	return &ProbeService{
+		probeRepository: probeRepository,
+		touched:         make(map[string]time.Time),
+	}
+}
+
+// Seen records that the probes of results were seen now, at most once every
+// probeTouchInterval per probe. Failures are only logged so that they never hold up ingestion.
+func (s *ProbeService) Seen(ctx context.Context, results []models.CheckResult) {
+	now := time.Now().UTC()
+	var probes []models.Probe
+
+	s.mu.Lock()
+	for _, result := range results {
+		if result.ProbeID == "" || result.ProbeID == models.APIProbeID {
+			continue
+		}
+		if last, ok := s.touched[result.ProbeID]; ok && now.Sub(last) < probeTouchInterval {
+			continue
+		}
+		s.touched[result.ProbeID] = now
+		probes = append(probes, models.Probe{ProbeID: result.ProbeID, Region: result.Region, LastSeenAt: now})
+	}
+	s.mu.Unlock()
+
+	if err := s.probeRepository.Touch(ctx, probes); err != nil {
+		logger.WarnCtx(ctx, "Failed to record probes", logger.ErrorField(err))
+	}
+}
+
+// Network returns the status of every probe region and probe
+func (s *ProbeService) Network(ctx context.Context) (*dtos.ProbeNetworkDto, error) {
+	probes, err := s.probeRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	network := &dtos.ProbeNetworkDto{Regions: []dtos.ProbeRegionDto{}, Probes: make([]dtos.ProbeDto, 0, len(probes))}
+	for _, probe := range probes {
+		status := probe.Status(now)
+		network.Probes = append(network.Probes, dtos.ProbeDto{
+			ProbeID:    probe.ProbeID,
+			Region:     probe.Region,
+			Status:     status,
+			LastSeenAt: probe.LastSeenAt,
+		})
+
+		// Probes are listed by region, so the probes of a region are next to each other
+		last := len(network.Regions) - 1
+		if last < 0 || network.Regions[last].Region != probe.Region {
+			network.Regions = append(network.Regions, dtos.ProbeRegionDto{Region: probe.Region})
+			last++
+		}
+		region := &network.Regions[last]
+		region.Probes++
+		if status == models.ProbeStatusOnline {
+			region.OnlineProbes++
+		}
+		if probe.LastSeenAt.After(region.LastSeenAt) {
+			region.LastSeenAt = probe.LastSeenAt
+		}
+	}
+
+	for i := range network.Regions {
+		region := &network.Regions[i]
+		switch region.OnlineProbes {
+		case region.Probes:
+			region.Status = models.ProbeStatusOnline
+		case 0:
+			region.Status = models.ProbeStatusOffline
+		default:
+			region.Status = models.ProbeStatusDegraded
+		}
+	}
+	return network, nil
+}
+
+// OutageHint returns the root-cause hint of the probe regions checking a monitor that are
+// offline, such as "eu-west probe offline, results from us-east only", or an empty hint
+// when none is. No regions stands for every region.
+func (s *ProbeService) OutageHint(ctx context.Context, regions []string) (string, error) {
+	network, err := s.Network(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var offline, online []string
+	for _, region := range network.Regions {
+		if len(regions) > 0 && !slices.Contains(regions, region.Region) {
+			continue
+		}
+		if region.Status == models.ProbeStatusOffline {
+			offline = append(offline, region.Region)
+		} else {
+			online = append(online, region.Region)
+		}
+	}
+
+	switch {
+	case len(offline) == 0:
+		return "", nil
+	case len(offline) == 1 && len(online) == 0:
+		return offline[0] + " probe offline, no results from any region", nil
+	case len(offline) == 1:
+		return offline[0] + " probe offline, results from " + joinAnd(online) + " only", nil
+	case len(online) == 0:
+		return joinAnd(offline) + " probes offline, no results from any region", nil
+	default:
+		return joinAnd(offline) + " probes offline, results from " + joinAnd(online) + " only", nil
+	}
+}
+
+// joinAnd joins items as in "a, b and c"
+func joinAnd(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
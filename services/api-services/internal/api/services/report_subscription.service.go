@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+)
+
+// unsubscribeTokenLength is the number of hex characters of an unsubscribe token
+const unsubscribeTokenLength = 64
+
+// ReportSubscriptionService manages which organization members receive uptime report emails
+type ReportSubscriptionService struct {
+	subscriptionRepository repositories.ReportSubscriptionRepository
+	organizationRepository repositories.OrganizationRepository
+}
+
+func NewReportSubscriptionService(subscriptionRepository repositories.ReportSubscriptionRepository, organizationRepository repositories.OrganizationRepository) *ReportSubscriptionService {
+	return &ReportSubscriptionService{
+		subscriptionRepository: subscriptionRepository,
+		organizationRepository: organizationRepository,
+	}
+}
+
+// Get returns the subscription of a member of an organization
+func (s *ReportSubscriptionService) Get(ctx context.Context, organizationID, userID uuid.UUID) (*models.ReportSubscription, error) {
+	return s.subscriptionRepository.Get(ctx, organizationID, userID)
+}
+
+// Subscribe opts a member in to reports of frequency, or changes the frequency of an
+// existing subscription. The first report covers the first period that ends afterwards, in
+// the time zone of the organization.
+func (s *ReportSubscriptionService) Subscribe(ctx context.Context, organizationID, userID uuid.UUID, frequency string) (*models.ReportSubscription, error) {
+	organization, err := s.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := utils.GenerateRandomString(unsubscribeTokenLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate unsubscribe token: %w", err)
+	}
+
+	_, lastPeriodEnd := models.LastReportPeriod(frequency, time.Now(), organization.Location())
+	subscription := &models.ReportSubscription{
+		OrganizationID:   organizationID,
+		UserID:           userID,
+		Frequency:        frequency,
+		UnsubscribeToken: token,
+		LastPeriodEnd:    &lastPeriodEnd,
+	}
+	if err := s.subscriptionRepository.Upsert(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Unsubscribe opts a member of an organization out of reports
+func (s *ReportSubscriptionService) Unsubscribe(ctx context.Context, organizationID, userID uuid.UUID) error {
+	return s.subscriptionRepository.Delete(ctx, organizationID, userID)
+}
+
+// UnsubscribeByToken opts out the subscriber of the report whose unsubscribe link carries token
+func (s *ReportSubscriptionService) UnsubscribeByToken(ctx context.Context, token string) error {
+	return s.subscriptionRepository.DeleteByToken(ctx, token)
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// RetentionPolicyService manages how long the check results, rollups and resolved
+// incidents of each organization are kept. Organizations without a policy get the default windows.
+type RetentionPolicyService struct {
+	policyRepository   repositories.RetentionPolicyRepository
+	checkResultsWindow time.Duration
+	rollupsWindow      time.Duration
+	incidentsWindow    time.Duration
+}
+
+func NewRetentionPolicyService(policyRepository repositories.RetentionPolicyRepository, checkResultsWindow, rollupsWindow, incidentsWindow time.Duration) *RetentionPolicyService {
+	return &RetentionPolicyService{
+		policyRepository:   policyRepository,
+		checkResultsWindow: checkResultsWindow,
+		rollupsWindow:      rollupsWindow,
+		incidentsWindow:    incidentsWindow,
+	}
+}
+
+// Get returns the retention applied to the data of an organization
+func (s *RetentionPolicyService) Get(ctx context.Context, organizationID uuid.UUID) (models.DataRetention, error) {
+	policy, err := s.policyRepository.Get(ctx, organizationID)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return models.DataRetention{}, err
+	}
+	return models.NewDataRetention(organizationID, policy, s.checkResultsWindow, s.rollupsWindow, s.incidentsWindow), nil
+}
+
+// Set overrides the retention of an organization. A nil number of days keeps the default
+// for that data; without any override the policy is removed.
+func (s *RetentionPolicyService) Set(ctx context.Context, organizationID uuid.UUID, checkResultsDays, rollupsDays, incidentsDays *int) (models.DataRetention, error) {
+	if checkResultsDays == nil && rollupsDays == nil && incidentsDays == nil {
+		if err := s.Reset(ctx, organizationID); err != nil && !errors.Is(err, common.ErrNotFound) {
+			return models.DataRetention{}, err
+		}
+		return models.NewDataRetention(organizationID, nil, s.checkResultsWindow, s.rollupsWindow, s.incidentsWindow), nil
+	}
+
+	policy := &models.RetentionPolicy{
+		OrganizationID:   organizationID,
+		CheckResultsDays: checkResultsDays,
+		RollupsDays:      rollupsDays,
+		IncidentsDays:    incidentsDays,
+	}
+	if err := s.policyRepository.Upsert(ctx, policy); err != nil {
+		return models.DataRetention{}, err
+	}
+	logger.InfoCtx(ctx, "Retention policy updated", logger.String("organization_id", organizationID.String()))
+	return models.NewDataRetention(organizationID, policy, s.checkResultsWindow, s.rollupsWindow, s.incidentsWindow), nil
+}
+
+// Reset removes the policy of an organization so the default windows apply again. It
+// fails with common.ErrNotFound when the organization has no policy.
+func (s *RetentionPolicyService) Reset(ctx context.Context, organizationID uuid.UUID) error {
+	if err := s.policyRepository.Delete(ctx, organizationID); err != nil {
+		return err
+	}
+	logger.InfoCtx(ctx, "Retention policy removed", logger.String("organization_id", organizationID.String()))
+	return nil
+}
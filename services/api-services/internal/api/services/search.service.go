@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 200
+)
+
+// ErrInvalidSearchQuery is returned for search queries that are too short or too long
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+// SearchService handles full-text search across the caller's organizations
+type SearchService struct {
+	organizationRepository repositories.OrganizationRepository
+	searchRepository       repositories.SearchRepository
+}
+
+func NewSearchService(organizationRepository repositories.OrganizationRepository, searchRepository repositories.SearchRepository) *SearchService {
+	return &SearchService{
+		organizationRepository: organizationRepository,
+		searchRepository:       searchRepository,
+	}
+}
+
+// Search returns the results matching query in every organization the user belongs to,
+// or only in organizationID when given. Searching an organization the user is not a
+// member of fails with common.ErrNotFound.
+func (s *SearchService) Search(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, query string, limit int) ([]models.SearchResult, error) {
+	if length := utf8.RuneCountInString(query); length < minSearchQueryLength || length > maxSearchQueryLength {
+		return nil, ErrInvalidSearchQuery
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	organizationIDs, err := s.organizationRepository.ListIDsByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if organizationID != nil {
+		if !slices.Contains(organizationIDs, *organizationID) {
+			return nil, common.ErrNotFound
+		}
+		organizationIDs = []uuid.UUID{*organizationID}
+	}
+
+	return s.searchRepository.Search(ctx, organizationIDs, query, limit)
+}
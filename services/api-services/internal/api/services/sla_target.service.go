@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ErrSLAScopeNotFound is returned when the monitor or environment of an SLA target is not
+// one of its organization
+var ErrSLAScopeNotFound = errors.New("SLA target monitor or environment not found")
+
+// SLATargetService manages the SLA targets of organizations. Their status, error budget
+// and burn rate are kept up to date by the SLA evaluation job.
+type SLATargetService struct {
+	targetRepository  repositories.SLATargetRepository
+	monitorRepository repositories.MonitorRepository
+}
+
+func NewSLATargetService(targetRepository repositories.SLATargetRepository, monitorRepository repositories.MonitorRepository) *SLATargetService {
+	return &SLATargetService{
+		targetRepository:  targetRepository,
+		monitorRepository: monitorRepository,
+	}
+}
+
+// List returns the SLA targets of an organization
+func (s *SLATargetService) List(ctx context.Context, organizationID uuid.UUID) ([]models.SLATarget, error) {
+	return s.targetRepository.ListByOrganization(ctx, organizationID)
+}
+
+// Get returns an SLA target only if it belongs to the organization
+func (s *SLATargetService) Get(ctx context.Context, organizationID, id uuid.UUID) (*models.SLATarget, error) {
+	target, err := s.targetRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if target.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	return target, nil
+}
+
+// Create adds an SLA target to an organization. It fails with ErrSLAScopeNotFound when
+// the monitor or environment it covers is not one of the organization.
+func (s *SLATargetService) Create(ctx context.Context, organizationID uuid.UUID, req *dtos.CreateSLATargetRequestDto) (*models.SLATarget, error) {
+	switch {
+	case req.MonitorID != nil:
+		monitor, err := s.monitorRepository.GetByID(ctx, *req.MonitorID)
+		if errors.Is(err, common.ErrNotFound) || (err == nil && monitor.OrganizationID != organizationID) {
+			return nil, ErrSLAScopeNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	case req.EnvironmentID != nil:
+		found, err := s.targetRepository.HasEnvironment(ctx, organizationID, *req.EnvironmentID)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, ErrSLAScopeNotFound
+		}
+	}
+
+	target := &models.SLATarget{
+		OrganizationID: organizationID,
+		MonitorID:      req.MonitorID,
+		EnvironmentID:  req.EnvironmentID,
+		Name:           req.Name,
+		Target:         req.Target,
+		Period:         req.Period,
+		Status:         models.SLAStatusPending,
+	}
+	if err := s.targetRepository.Create(ctx, target); err != nil {
+		return nil, err
+	}
+	logger.InfoCtx(ctx, "SLA target created",
+		logger.String("organization_id", organizationID.String()),
+		logger.String("sla_target_id", target.ID.String()),
+	)
+	return target, nil
+}
+
+// Update applies req to an SLA target of the organization. Changing the target or the
+// period discards the evaluation of the current period, which is redone on the next pass.
+func (s *SLATargetService) Update(ctx context.Context, organizationID, id uuid.UUID, req *dtos.UpdateSLATargetRequestDto) (*models.SLATarget, error) {
+	target, err := s.Get(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		target.Name = *req.Name
+	}
+	if (req.Target != nil && *req.Target != target.Target) || (req.Period != nil && *req.Period != target.Period) {
+		if req.Target != nil {
+			target.Target = *req.Target
+		}
+		if req.Period != nil {
+			target.Period = *req.Period
+		}
+		target.Status = models.SLAStatusPending
+		target.PeriodStart = nil
+		target.Availability = nil
+		target.BudgetConsumed = 0
+		target.BurnRate = 0
+		target.EvaluatedAt = nil
+		target.AlertedStatus = ""
+	}
+
+	if err := s.targetRepository.Update(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// Delete removes an SLA target of the organization
+func (s *SLATargetService) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	if _, err := s.Get(ctx, organizationID, id); err != nil {
+		return err
+	}
+	if err := s.targetRepository.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+	logger.InfoCtx(ctx, "SLA target deleted",
+		logger.String("organization_id", organizationID.String()),
+		logger.String("sla_target_id", id.String()),
+	)
+	return nil
+}
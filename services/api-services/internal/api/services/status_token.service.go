@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+)
+
+const (
+	// statusTokenPrefix marks status tokens so that leaked ones are easy to recognize
+	statusTokenPrefix = "ust_"
+
+	// statusTokenSecretLength is the number of hex characters after the prefix
+	statusTokenSecretLength = 48
+
+	// statusTokenDisplayLength is how much of a token is kept to tell tokens apart
+	statusTokenDisplayLength = len(statusTokenPrefix) + 8
+)
+
+var (
+	// ErrInvalidStatusToken is returned for status tokens that are unknown, revoked or expired
+	ErrInvalidStatusToken = errors.New("invalid status token")
+
+	// ErrStatusTokenMonitorNotFound is returned when a monitor of a new status token is not
+	// one of its organization
+	ErrStatusTokenMonitorNotFound = errors.New("status token monitor not found")
+
+	// ErrInvalidStatusTokenExpiry is returned when a new status token would already be expired
+	ErrInvalidStatusTokenExpiry = errors.New("status token expiry must be in the future")
+)
+
+// StatusTokenService manages the read-only status tokens of organizations and serves the
+// monitor status and stats they grant access to
+type StatusTokenService struct {
+	tokenRepository   repositories.StatusTokenRepository
+	monitorRepository repositories.MonitorRepository
+	statsService      *MonitorStatsService
+}
+
+func NewStatusTokenService(tokenRepository repositories.StatusTokenRepository, monitorRepository repositories.MonitorRepository, statsService *MonitorStatsService) *StatusTokenService {
+	return &StatusTokenService{
+		tokenRepository:   tokenRepository,
+		monitorRepository: monitorRepository,
+		statsService:      statsService,
+	}
+}
+
+// List returns the status tokens of an organization
+func (s *StatusTokenService) List(ctx context.Context, organizationID uuid.UUID) ([]models.StatusToken, error) {
+	return s.tokenRepository.ListByOrganization(ctx, organizationID)
+}
+
+// Create issues a status token for monitors of an organization. The returned token is the
+// only copy of its secret. It fails with ErrStatusTokenMonitorNotFound when a monitor is not
+// one of the organization.
+func (s *StatusTokenService) Create(ctx context.Context, organizationID, userID uuid.UUID, req *dtos.CreateStatusTokenRequestDto) (*dtos.CreatedStatusTokenDto, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidStatusTokenExpiry
+	}
+
+	monitorIDs := make([]uuid.UUID, 0, len(req.MonitorIDs))
+	seen := make(map[uuid.UUID]bool, len(req.MonitorIDs))
+	for _, id := range req.MonitorIDs {
+		if !seen[id] {
+			seen[id] = true
+			monitorIDs = append(monitorIDs, id)
+		}
+	}
+	monitors, err := s.monitorRepository.GetByIDs(ctx, monitorIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := 0
+	for _, monitor := range monitors {
+		if monitor.OrganizationID == organizationID {
+			found++
+		}
+	}
+	if found != len(monitorIDs) {
+		return nil, ErrStatusTokenMonitorNotFound
+	}
+
+	secret, err := utils.GenerateRandomString(statusTokenSecretLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate status token: %w", err)
+	}
+	raw := statusTokenPrefix + secret
+
+	token := models.StatusToken{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		TokenHash:      hashSecretToken(raw),
+		TokenPrefix:    raw[:statusTokenDisplayLength],
+		MonitorIDs:     monitorIDs,
+		ExpiresAt:      req.ExpiresAt,
+		CreatedBy:      userID,
+	}
+	if err := s.tokenRepository.Create(ctx, &token); err != nil {
+		return nil, err
+	}
+	return &dtos.CreatedStatusTokenDto{StatusToken: token, Token: raw}, nil
+}
+
+// Revoke deletes a status token of an organization, which stops granting access at once
+func (s *StatusTokenService) Revoke(ctx context.Context, organizationID, id uuid.UUID) error {
+	token, err := s.tokenRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if token.OrganizationID != organizationID {
+		return common.ErrNotFound
+	}
+	return s.tokenRepository.SoftDelete(ctx, id)
+}
+
+// Authenticate returns the status token whose secret is raw, failing with
+// ErrInvalidStatusToken when it is unknown, revoked or expired
+func (s *StatusTokenService) Authenticate(ctx context.Context, raw string) (*models.StatusToken, error) {
+	if !strings.HasPrefix(raw, statusTokenPrefix) || len(raw) != len(statusTokenPrefix)+statusTokenSecretLength {
+		return nil, ErrInvalidStatusToken
+	}
+
+	token, err := s.tokenRepository.GetByHash(ctx, hashSecretToken(raw))
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, ErrInvalidStatusToken
+		}
+		return nil, err
+	}
+	if token.IsExpired(time.Now()) {
+		return nil, ErrInvalidStatusToken
+	}
+	return token, nil
+}
+
+// ListMonitors returns the public state of the monitors a token grants access to, in the
+// order they were given when it was created. Deleted monitors are left out.
+func (s *StatusTokenService) ListMonitors(ctx context.Context, token *models.StatusToken) ([]models.PublicMonitorStatus, error) {
+	monitors, err := s.monitorRepository.GetByIDs(ctx, token.MonitorIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.Monitor, len(monitors))
+	for i := range monitors {
+		if monitors[i].OrganizationID == token.OrganizationID {
+			byID[monitors[i].ID] = &monitors[i]
+		}
+	}
+
+	statuses := make([]models.PublicMonitorStatus, 0, len(byID))
+	for _, id := range token.MonitorIDs {
+		if monitor, ok := byID[id]; ok {
+			statuses = append(statuses, models.NewPublicMonitorStatus(monitor))
+		}
+	}
+	return statuses, nil
+}
+
+// GetMonitorStats returns the stats of a monitor the token grants access to, like
+// MonitorStatsService.GetMonitorStats. Other monitors are reported as common.ErrNotFound.
+func (s *StatusTokenService) GetMonitorStats(ctx context.Context, token *models.StatusToken, monitorID uuid.UUID, from, to time.Time, resolution string) (*models.MonitorStats, error) {
+	if !token.Covers(monitorID) {
+		return nil, common.ErrNotFound
+	}
+	return s.statsService.GetMonitorStats(ctx, token.OrganizationID, monitorID, from, to, resolution)
+}
+
+// hashSecretToken returns the hash that secret tokens, such as status tokens and inbound
+// integration tokens, are stored and looked up by
+func hashSecretToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/dtos"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/ticketing"
+)
+
+// ErrInvalidTicketAccount is returned when creating a ticket integration whose account
+// settings cannot reach its tracker
+var ErrInvalidTicketAccount = errors.New("invalid ticket integration account")
+
+// TicketIntegrationService manages the ticket integrations of organizations and the
+// tickets their incidents were filed as
+type TicketIntegrationService struct {
+	integrationRepository repositories.TicketIntegrationRepository
+	ticketRepository      repositories.IncidentTicketRepository
+	incidentRepository    repositories.IncidentRepository
+	syncer                *ticketing.Syncer
+}
+
+func NewTicketIntegrationService(
+	integrationRepository repositories.TicketIntegrationRepository,
+	ticketRepository repositories.IncidentTicketRepository,
+	incidentRepository repositories.IncidentRepository,
+	syncer *ticketing.Syncer,
+) *TicketIntegrationService {
+	return &TicketIntegrationService{
+		integrationRepository: integrationRepository,
+		ticketRepository:      ticketRepository,
+		incidentRepository:    incidentRepository,
+		syncer:                syncer,
+	}
+}
+
+// List returns the ticket integrations of an organization
+func (s *TicketIntegrationService) List(ctx context.Context, organizationID uuid.UUID) ([]models.TicketIntegration, error) {
+	return s.integrationRepository.ListByOrganization(ctx, organizationID)
+}
+
+// Create adds a ticket integration to an organization. It fails with ErrInvalidTicketAccount
+// when the account settings are not enough to reach the tracker.
+func (s *TicketIntegrationService) Create(ctx context.Context, organizationID, userID uuid.UUID, req *dtos.CreateTicketIntegrationRequestDto) (*models.TicketIntegration, error) {
+	integration := models.TicketIntegration{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		Provider:       req.Provider,
+		APIToken:       strings.TrimSpace(req.APIToken),
+		Project:        strings.TrimSpace(req.Project),
+		AutoCreate:     req.AutoCreate,
+		CreatedBy:      userID,
+	}
+	if req.Provider == ticketing.ProviderJira {
+		integration.BaseURL = strings.TrimRight(strings.TrimSpace(req.BaseURL), "/")
+		integration.Email = strings.TrimSpace(req.Email)
+		integration.IssueType = strings.TrimSpace(req.IssueType)
+	}
+	if _, err := ticketing.NewClient(integration.Provider, ticketing.AccountOf(&integration)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTicketAccount, err)
+	}
+
+	if err := s.integrationRepository.Create(ctx, &integration); err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+// Delete deletes a ticket integration of an organization. The tickets already filed through
+// it stay, but are no longer resolved with their incidents.
+func (s *TicketIntegrationService) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	if _, err := s.getOrganizationIntegration(ctx, organizationID, id); err != nil {
+		return err
+	}
+	return s.integrationRepository.SoftDelete(ctx, id)
+}
+
+// ListIncidentTickets returns the tickets an incident of an organization was filed as
+func (s *TicketIntegrationService) ListIncidentTickets(ctx context.Context, organizationID, incidentID uuid.UUID) ([]models.IncidentTicket, error) {
+	if _, err := s.getOrganizationIncident(ctx, organizationID, incidentID); err != nil {
+		return nil, err
+	}
+	return s.ticketRepository.ListByIncident(ctx, incidentID)
+}
+
+// FileIncident files an incident of an organization through one of its ticket integrations.
+// It fails with ticketing.ErrAlreadyFiled when the incident was already filed through it and
+// wraps ticketing.ErrTrackerFailed when the tracker fails.
+func (s *TicketIntegrationService) FileIncident(ctx context.Context, organizationID, incidentID, integrationID uuid.UUID) (*models.IncidentTicket, error) {
+	incident, err := s.getOrganizationIncident(ctx, organizationID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	integration, err := s.getOrganizationIntegration(ctx, organizationID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.syncer.File(ctx, integration, incident)
+}
+
+// getOrganizationIntegration returns a ticket integration when it belongs to the organization
+func (s *TicketIntegrationService) getOrganizationIntegration(ctx context.Context, organizationID, id uuid.UUID) (*models.TicketIntegration, error) {
+	integration, err := s.integrationRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if integration.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	return integration, nil
+}
+
+// getOrganizationIncident returns an incident when it belongs to the organization
+func (s *TicketIntegrationService) getOrganizationIncident(ctx context.Context, organizationID, id uuid.UUID) (*models.Incident, error) {
+	incident, err := s.incidentRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.OrganizationID != organizationID {
+		return nil, common.ErrNotFound
+	}
+	return incident, nil
+}
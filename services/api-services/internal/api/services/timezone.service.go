@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+)
+
+// TimezoneService manages the time zones of users and organizations. The time zone of an
+// organization sets when its report periods start and end; the one of a user how times
+// are displayed to them.
+type TimezoneService struct {
+	userRepository         repositories.UserRepository
+	organizationRepository repositories.OrganizationRepository
+}
+
+func NewTimezoneService(userRepository repositories.UserRepository, organizationRepository repositories.OrganizationRepository) *TimezoneService {
+	return &TimezoneService{
+		userRepository:         userRepository,
+		organizationRepository: organizationRepository,
+	}
+}
+
+// GetOrganizationTimezone returns the time zone of an organization
+func (s *TimezoneService) GetOrganizationTimezone(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	organization, err := s.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		return "", err
+	}
+	return organization.Location().String(), nil
+}
+
+// SetOrganizationTimezone sets the time zone of an organization, which the next report
+// periods follow
+func (s *TimezoneService) SetOrganizationTimezone(ctx context.Context, organizationID uuid.UUID, timezone string) error {
+	return s.organizationRepository.UpdateTimezone(ctx, organizationID, timezone)
+}
+
+// SetUserTimezone sets the time zone of a user
+func (s *TimezoneService) SetUserTimezone(ctx context.Context, userID uuid.UUID, timezone string) error {
+	return s.userRepository.UpdateTimezone(ctx, userID, timezone)
+}
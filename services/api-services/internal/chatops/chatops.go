@@ -0,0 +1,114 @@
+// Package chatops verifies and parses the slash commands chat platforms send, such as
+// "/uptime status payments" typed in Slack, so that responders can query and act on the
+// monitors and incidents of their organization without leaving the chat.
+package chatops
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/webhookverify"
+)
+
+// Supported platforms
+const (
+	PlatformSlack   = "slack"
+	PlatformDiscord = "discord"
+)
+
+var (
+	// ErrUnsupportedPlatform is returned for platforms this package does not know
+	ErrUnsupportedPlatform = errors.New("unsupported chat platform")
+	// ErrInvalidSignature is returned for requests that fail verification
+	ErrInvalidSignature = webhookverify.ErrInvalidSignature
+	// ErrInvalidPayload is returned for requests that cannot be parsed
+	ErrInvalidPayload = errors.New("invalid chat request payload")
+)
+
+// signature is what a platform signs a request with: when it was sent and the signature
+// of the timestamp and body
+type signature struct {
+	Timestamp string
+	Value     string
+}
+
+// Command is a slash command a chat user typed, such as "status payments", split into its
+// name and arguments. Ping is set instead for the liveness checks of Discord, which expect
+// a pong and no command to run.
+type Command struct {
+	Name string
+	Args []string
+	// User names who typed the command, as the platform displays them
+	User string
+	Ping bool
+}
+
+// Verify checks that a request was signed by the platform with key, the signing secret of
+// the Slack app or the hex encoded public key of the Discord application, using the
+// signature headers the platform sets
+func Verify(platform, key string, header http.Header, body []byte) error {
+	switch platform {
+	case PlatformSlack:
+		sig := signature{Timestamp: header.Get("X-Slack-Request-Timestamp"), Value: header.Get("X-Slack-Signature")}
+		return verifySlack(key, sig, body, time.Now())
+	case PlatformDiscord:
+		sig := signature{Timestamp: header.Get("X-Signature-Timestamp"), Value: header.Get("X-Signature-Ed25519")}
+		return verifyDiscord(key, sig, body)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedPlatform, platform)
+	}
+}
+
+// ValidateKey checks that key can verify the requests of the platform
+func ValidateKey(platform, key string) error {
+	switch platform {
+	case PlatformSlack:
+		if strings.TrimSpace(key) == "" {
+			return errors.New("Slack signing secret is empty")
+		}
+		return nil
+	case PlatformDiscord:
+		_, err := parseDiscordPublicKey(key)
+		return err
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedPlatform, platform)
+	}
+}
+
+// Parse returns the command of a verified request body
+func Parse(platform string, body []byte) (*Command, error) {
+	switch platform {
+	case PlatformSlack:
+		return parseSlack(body)
+	case PlatformDiscord:
+		return parseDiscord(body)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedPlatform, platform)
+	}
+}
+
+// Reply returns the response body answering a command with text, shown only to the user
+// who typed it
+func Reply(platform, text string) any {
+	if platform == PlatformDiscord {
+		return discordReply(text)
+	}
+	return slackReply(text)
+}
+
+// Pong returns the response body answering a Discord liveness check
+func Pong() any {
+	return discordPong()
+}
+
+// splitText splits the text of a command into its name, lowercased, and arguments
+func splitText(text string) (string, []string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return strings.ToLower(fields[0]), fields[1:]
+}
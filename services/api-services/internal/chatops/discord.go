@@ -0,0 +1,139 @@
+package chatops
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/webhookverify"
+)
+
+// Discord interaction and response types
+const (
+	discordInteractionPing    = 1
+	discordInteractionCommand = 2
+
+	discordResponsePong    = 1
+	discordResponseMessage = 4
+
+	// discordOptionSubcommand is the option type of subcommands, such as status in
+	// "/uptime status"
+	discordOptionSubcommand = 1
+
+	// discordFlagEphemeral shows a message only to the user who typed the command
+	discordFlagEphemeral = 64
+)
+
+// parseDiscordPublicKey decodes the hex encoded public key of a Discord application
+func parseDiscordPublicKey(key string) (ed25519.PublicKey, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Discord public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Discord public key must be %d bytes", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// verifyDiscord checks the signature of a Discord interaction with the hex encoded public
+// key of the application
+func verifyDiscord(publicKey string, sig signature, body []byte) error {
+	key, err := parseDiscordPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	return webhookverify.VerifyDiscord(key, sig.Timestamp, sig.Value, body)
+}
+
+// discordOption is an option of an application command, either a subcommand with options
+// of its own or a value
+type discordOption struct {
+	Name    string          `json:"name"`
+	Type    int             `json:"type"`
+	Value   any             `json:"value"`
+	Options []discordOption `json:"options"`
+}
+
+// discordUser is the part of a Discord user used here
+type discordUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// discordInteraction is the part of a Discord interaction used here. Member is set in
+// servers and User in direct messages.
+type discordInteraction struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string          `json:"name"`
+		Options []discordOption `json:"options"`
+	} `json:"data"`
+	Member *struct {
+		User discordUser `json:"user"`
+	} `json:"member"`
+	User *discordUser `json:"user"`
+}
+
+// parseDiscord parses a Discord interaction. The command is its subcommand, such as status,
+// with the values of its options as arguments; without subcommand the values of the options
+// are read as the text of the command, like Slack's.
+func parseDiscord(body []byte) (*Command, error) {
+	var interaction discordInteraction
+	if err := json.Unmarshal(body, &interaction); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	switch interaction.Type {
+	case discordInteractionPing:
+		return &Command{Ping: true}, nil
+	case discordInteractionCommand:
+	default:
+		return nil, fmt.Errorf("%w: unsupported interaction type %d", ErrInvalidPayload, interaction.Type)
+	}
+
+	command := &Command{}
+	var words []string
+	for _, option := range interaction.Data.Options {
+		if option.Type == discordOptionSubcommand {
+			command.Name = strings.ToLower(option.Name)
+			for _, arg := range option.Options {
+				command.Args = append(command.Args, strings.Fields(fmt.Sprint(arg.Value))...)
+			}
+			break
+		}
+		words = append(words, fmt.Sprint(option.Value))
+	}
+	if command.Name == "" {
+		command.Name, command.Args = splitText(strings.Join(words, " "))
+	}
+
+	switch {
+	case interaction.Member != nil:
+		command.User = interaction.Member.User.Username
+	case interaction.User != nil:
+		command.User = interaction.User.Username
+	}
+	return command, nil
+}
+
+// discordResponse is the response to an interaction
+type discordResponse struct {
+	Type int                  `json:"type"`
+	Data *discordResponseData `json:"data,omitempty"`
+}
+
+type discordResponseData struct {
+	Content string `json:"content"`
+	Flags   int    `json:"flags"`
+}
+
+func discordReply(text string) any {
+	return discordResponse{Type: discordResponseMessage, Data: &discordResponseData{Content: text, Flags: discordFlagEphemeral}}
+}
+
+func discordPong() any {
+	return discordResponse{Type: discordResponsePong}
+}
@@ -0,0 +1,43 @@
+package chatops
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/webhookverify"
+)
+
+// verifySlack checks the signature of a Slack request with the signing secret of the app
+func verifySlack(signingSecret string, sig signature, body []byte, now time.Time) error {
+	return webhookverify.VerifySlack(signingSecret, sig.Timestamp, sig.Value, body, now)
+}
+
+// parseSlack parses the form Slack posts for a slash command, whose text is what the user
+// typed after the command
+func parseSlack(body []byte) (*Command, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if form.Get("command") == "" {
+		return nil, fmt.Errorf("%w: missing command", ErrInvalidPayload)
+	}
+
+	name, args := splitText(form.Get("text"))
+	user := form.Get("user_name")
+	if user == "" {
+		user = form.Get("user_id")
+	}
+	return &Command{Name: name, Args: args, User: user}, nil
+}
+
+// slackResponse is the message answering a slash command
+type slackResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+func slackReply(text string) any {
+	return slackResponse{ResponseType: "ephemeral", Text: text}
+}
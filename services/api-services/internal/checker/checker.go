@@ -0,0 +1,170 @@
+// Package checker runs the check of a monitor once from the API, the way probes run it, so
+// that users can try the settings of a monitor before saving it. Only public addresses are
+// reached, since targets are given by users.
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+)
+
+// maxBodyPreview is how much of the body of an HTTP response is returned
+const maxBodyPreview = 2048
+
+// maxRedirects is how many redirects an HTTP check follows
+const maxRedirects = 5
+
+var (
+	// ErrUnsupportedType is returned for monitor types that cannot be checked from the API,
+	// such as ping, which needs raw sockets the API does not have
+	ErrUnsupportedType = errors.New("monitor type cannot be checked from the API")
+	// ErrInvalidTarget is returned for targets that do not suit the monitor type: an http or
+	// https URL for HTTP monitors, host:port for TCP monitors
+	ErrInvalidTarget = errors.New("invalid monitor target")
+	// ErrBlockedAddress is returned for targets resolving to addresses that are not public,
+	// which only probes inside the network of the target can check
+	ErrBlockedAddress = httpclient.ErrBlockedAddress
+)
+
+// Check is what to check and how long to wait for it
+type Check struct {
+	Type    string
+	Target  string
+	Timeout time.Duration
+}
+
+// Result is the outcome of a check. Status is up or down, as a probe would report it, and
+// Error says why a check is down. HTTP checks also return the response headers, the start
+// of the body and when the certificate of an https target expires.
+type Result struct {
+	Status            string              `json:"status"`
+	LatencyMs         int64               `json:"latency_ms"`
+	StatusCode        int                 `json:"status_code"`
+	Error             string              `json:"error,omitempty"`
+	CheckedAt         time.Time           `json:"checked_at"`
+	RemoteAddress     string              `json:"remote_address,omitempty"`
+	Headers           map[string][]string `json:"headers,omitempty"`
+	BodyPreview       string              `json:"body_preview,omitempty"`
+	CertificateExpiry *time.Time          `json:"certificate_expiry,omitempty"`
+}
+
+// Run runs check once. A target that cannot be reached gives a down result rather than an
+// error; errors are only returned for checks that cannot be run at all, including the ones
+// of targets that resolve to addresses that are not public.
+func Run(ctx context.Context, check Check) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	switch check.Type {
+	case models.MonitorTypeHTTP:
+		return runHTTP(ctx, check)
+	case models.MonitorTypeTCP:
+		return runTCP(ctx, check)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, check.Type)
+	}
+}
+
+// runHTTP requests the target and reports it down on a failure or an error status
+func runHTTP(ctx context.Context, check Check) (*Result, error) {
+	target, err := url.Parse(check.Target)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, ErrInvalidTarget
+	}
+
+	transport := httpclient.NewPublicTransport()
+	transport.ResponseHeaderTimeout = check.Timeout
+	transport.DisableKeepAlives = true
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, ErrInvalidTarget
+	}
+	req.Header.Set("User-Agent", "uptime-check/1.0")
+
+	result := &Result{CheckedAt: time.Now().UTC()}
+	start := time.Now()
+	resp, err := client.Do(req)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if errors.Is(err, ErrBlockedAddress) {
+		return nil, ErrBlockedAddress
+	}
+	if err != nil {
+		return down(result, err), nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodyPreview))
+	if utf8.Valid(body) {
+		result.BodyPreview = string(body)
+	}
+	result.StatusCode = resp.StatusCode
+	result.Headers = resp.Header
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		expiry := resp.TLS.PeerCertificates[0].NotAfter.UTC()
+		result.CertificateExpiry = &expiry
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return down(result, fmt.Errorf("unexpected status %s", resp.Status)), nil
+	}
+	result.Status = models.MonitorStatusUp
+	return result, nil
+}
+
+// runTCP opens a connection to the target and reports it down when it cannot
+func runTCP(ctx context.Context, check Check) (*Result, error) {
+	if _, _, err := net.SplitHostPort(check.Target); err != nil {
+		return nil, ErrInvalidTarget
+	}
+
+	result := &Result{CheckedAt: time.Now().UTC()}
+	start := time.Now()
+	conn, err := httpclient.NewPublicDialer()(ctx, "tcp", check.Target)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if errors.Is(err, ErrBlockedAddress) {
+		return nil, ErrBlockedAddress
+	}
+	if err != nil {
+		return down(result, err), nil
+	}
+	result.RemoteAddress = conn.RemoteAddr().String()
+	_ = conn.Close()
+
+	result.Status = models.MonitorStatusUp
+	return result, nil
+}
+
+// down marks result down because of err, naming timeouts and TLS failures plainly
+func down(result *Result, err error) *Result {
+	result.Status = models.MonitorStatusDown
+	var certErr *tls.CertificateVerificationError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		result.Error = "timed out"
+	case errors.As(err, &certErr):
+		result.Error = "invalid certificate: " + certErr.Err.Error()
+	default:
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -6,6 +6,10 @@ const (
 
 	UserIDContextKey               ContextKey = "userID"
 	AuthorizationPayloadContextKey ContextKey = "authorizationPayload"
+	OrganizationIDContextKey       ContextKey = "organizationID"
+	LocaleContextKey               ContextKey = "locale"
+	APIKeyIDContextKey             ContextKey = "apiKeyID"
+	StatusTokenContextKey          ContextKey = "statusToken"
 
 	OTPCacheKeyPrefix                = "otp:"
 	OTPTypePasswordReset     OTPType = "password_reset"
@@ -7,6 +7,7 @@ import (
 var (
 	ErrNotFound        = errors.New("record not found")
 	ErrDuplicateEntry  = errors.New("duplicate entry")
+	ErrConflict        = errors.New("record was modified concurrently")
 	ErrOTPNotFound     = errors.New("OTP not found")
 	ErrOTPExpired      = errors.New("OTP expired")
 	ErrOTPAlreadyUsed  = errors.New("OTP already used")
@@ -19,6 +20,8 @@ var (
 	ErrPhoneNotVerified       = errors.New("phone not verified")
 	ErrEmailAlreadyRegistered = errors.New("email address already registered")
 	ErrPhoneAlreadyRegistered = errors.New("phone number already registered")
+	ErrPhoneNumberMissing     = errors.New("phone number not set")
+	ErrPhoneAlreadyVerified   = errors.New("phone number already verified")
 	ErrUserNotFound           = errors.New("user not found")
 	ErrPasswordMismatch       = errors.New("password mismatch")
 	ErrOTPAlreadySent         = errors.New("OTP already sent, please wait before retrying")
@@ -39,4 +42,6 @@ var (
 	ErrSessionNotFound      = errors.New("session not found")
 	ErrBadRequest           = errors.New("bad request")
 	ErrInternalServer       = errors.New("internal server error")
+
+	ErrOutboxMessageSent = errors.New("outbox message already sent")
 )
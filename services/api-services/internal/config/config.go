@@ -1,46 +1,114 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"maps"
+	"net/mail"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap/zapcore"
 )
 
 var (
 	cfg  *Config
 	once sync.Once
+
+	// processEnv holds the variables the process started with, before the .env files
+	processEnv map[string]string
+	// fileEnvKeys are the variables last set from the .env files
+	fileEnvKeys map[string]bool
 )
 
 // App modes
 const (
 	AppModeDevelopment = "development"
+	AppModeStaging     = "staging"
 	AppModeProduction  = "production"
 )
 
 // Config is the top-level struct that holds all configuration for the application.
 type Config struct {
-	App          AppConfig          `envconfig:"APP"`
-	Postgres     PostgresConfig     `envconfig:"POSTGRES"`
-	Redis        RedisConfig        `envconfig:"REDIS"`
-	ClickHouse   ClickHouseConfig   `envconfig:"CLICKHOUSE"`
-	Email        EmailConfig        `envconfig:"EMAIL"`
-	LocalStorage LocalStorageConfig `envconfig:"LOCAL_STORAGE"`
-	Logging      LoggingConfig      `envconfig:"LOG"`
+	App            AppConfig            `envconfig:"APP"`
+	Server         ServerConfig         `envconfig:"SERVER"`
+	Postgres       PostgresConfig       `envconfig:"POSTGRES"`
+	Redis          RedisConfig          `envconfig:"REDIS"`
+	ClickHouse     ClickHouseConfig     `envconfig:"CLICKHOUSE"`
+	Email          EmailConfig          `envconfig:"EMAIL"`
+	SMS            SMSConfig            `envconfig:"SMS"`
+	LocalStorage   LocalStorageConfig   `envconfig:"LOCAL_STORAGE"`
+	Logging        LoggingConfig        `envconfig:"LOG"`
+	Captcha        CaptchaConfig        `envconfig:"CAPTCHA"`
+	GRPC           GRPCConfig           `envconfig:"GRPC"`
+	CORS           CORSConfig           `envconfig:"CORS"`
+	Security       SecurityConfig       `envconfig:"SECURITY"`
+	URLSigner      URLSignerConfig      `envconfig:"URL_SIGNER"`
+	Encryption     EncryptionConfig     `envconfig:"ENCRYPTION"`
+	Analytics      AnalyticsConfig      `envconfig:"ANALYTICS"`
+	Retention      RetentionConfig      `envconfig:"RETENTION"`
+	Outbox         OutboxConfig         `envconfig:"OUTBOX"`
+	Queue          QueueConfig          `envconfig:"QUEUE"`
+	Metrics        MetricsConfig        `envconfig:"METRICS"`
+	CheckResults   CheckResultsConfig   `envconfig:"CHECK_RESULTS"`
+	Admin          AdminConfig          `envconfig:"ADMIN"`
+	StorageCleanup StorageCleanupConfig `envconfig:"STORAGE_CLEANUP"`
+	Reports        ReportsConfig        `envconfig:"REPORTS"`
+	ReportFiles    ReportFilesConfig    `envconfig:"REPORT_FILES"`
+	SLA            SLAConfig            `envconfig:"SLA"`
+	Jobs           JobsConfig           `envconfig:"JOBS"`
+	Deprecation    DeprecationConfig    `envconfig:"DEPRECATION"`
 }
 
 // AppConfig holds general application settings.
 type AppConfig struct {
 	Name          string        `envconfig:"NAME" default:"UptimeApplication"`
-	Key           string        `envconfig:"KEY" required:"true"`
+	Key           string        `envconfig:"KEY" required:"true" secret:"true"`
 	Port          string        `envconfig:"PORT" required:"true" default:"5005"`
 	Mode          string        `envconfig:"ENV" default:"development"`
 	FrontendURL   string        `envconfig:"FRONTEND_URL"`
 	JWTExpiration time.Duration `envconfig:"JWT_EXPIRATION" default:"1h"`
 	Version       string        `envconfig:"VERSION" default:"1.0.0"`
+	DefaultLocale string        `envconfig:"DEFAULT_LOCALE" default:"en"`
+	// PreviousKeys still verify the tokens and signed URLs issued with earlier keys while
+	// Key signs new ones, so the key can be rotated without invalidating them at once
+	PreviousKeys []string `envconfig:"PREVIOUS_KEYS" secret:"true"`
+	// ConfigReloadInterval is how often the .env files are checked for changes to reload.
+	// 0 disables the check; SIGHUP always reloads them.
+	ConfigReloadInterval time.Duration `envconfig:"CONFIG_RELOAD_INTERVAL" default:"0s"`
+}
+
+// ServerConfig holds HTTP server limits and timeouts.
+type ServerConfig struct {
+	ReadTimeout       time.Duration `envconfig:"READ_TIMEOUT" default:"15s"`
+	ReadHeaderTimeout time.Duration `envconfig:"READ_HEADER_TIMEOUT" default:"5s"`
+	WriteTimeout      time.Duration `envconfig:"WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `envconfig:"IDLE_TIMEOUT" default:"60s"`
+	RequestTimeout    time.Duration `envconfig:"REQUEST_TIMEOUT" default:"10s"`
+	MaxBodyBytes      int64         `envconfig:"MAX_BODY_BYTES" default:"1048576"`
+	// RouteTimeouts override RequestTimeout for single API routes, each "METHOD /path|timeout"
+	// with the path as it is registered, e.g.
+	// "POST /api/v1/organizations/:organizationId/monitors/discover|30s"
+	RouteTimeouts []string `envconfig:"ROUTE_TIMEOUTS"`
+	// ShutdownTimeout bounds the whole graceful shutdown, ShutdownHookTimeout each step of it
+	ShutdownTimeout     time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"25s"`
+	ShutdownHookTimeout time.Duration `envconfig:"SHUTDOWN_HOOK_TIMEOUT" default:"10s"`
+	// HealthCacheTTL is how long the dependency checks of GET /health are reused; 0 runs
+	// them on every request
+	HealthCacheTTL time.Duration `envconfig:"HEALTH_CACHE_TTL" default:"5s"`
+}
+
+// RouteTimeout is a parsed ServerConfig route timeout
+type RouteTimeout struct {
+	Method  string
+	Path    string
+	Timeout time.Duration
 }
 
 // PostgresConfig holds the configuration for the PostgreSQL database connection.
@@ -48,10 +116,22 @@ type PostgresConfig struct {
 	Enable   bool   `envconfig:"ENABLE" default:"true"`
 	Host     string `envconfig:"HOST" required:"true"`
 	User     string `envconfig:"USERNAME" required:"true"`
-	Password string `envconfig:"PASSWORD" required:"true"`
+	Password string `envconfig:"PASSWORD" required:"true" secret:"true"`
 	Name     string `envconfig:"DATABASE" required:"true"`
 	Port     int    `envconfig:"PORT" default:"5432"`
 	SSLMode  string `envconfig:"SSL_MODE" default:"disable"`
+
+	// ReplicaDSNs lists read replicas; SELECTs outside transactions are routed
+	// to a healthy replica and fall back to the primary otherwise.
+	ReplicaDSNs                []string      `envconfig:"REPLICA_DSNS" secret:"true"`
+	ReplicaHealthCheckInterval time.Duration `envconfig:"REPLICA_HEALTH_CHECK_INTERVAL" default:"10s"`
+
+	// SeedOnStartup seeds default data when the API starts. Otherwise run cmd/seed.
+	SeedOnStartup bool `envconfig:"SEED_ON_STARTUP" default:"false"`
+
+	// QueryTimeout bounds the statements of background work, whose contexts have no deadline
+	// of their own; 0 leaves them unbounded
+	QueryTimeout time.Duration `envconfig:"QUERY_TIMEOUT" default:"30s"`
 }
 
 // RedisConfig holds the configuration for the Redis connection.
@@ -59,11 +139,24 @@ type RedisConfig struct {
 	Enable       bool          `envconfig:"ENABLE" default:"true"`
 	Host         string        `envconfig:"HOST" default:"127.0.0.1"`
 	Port         int           `envconfig:"PORT" default:"6379"`
-	Password     string        `envconfig:"PASSWORD" default:""`
+	Password     string        `envconfig:"PASSWORD" default:"" secret:"true"`
 	DB           int           `envconfig:"DB" default:"0"`
 	PoolSize     int           `envconfig:"POOL_SIZE" default:"100"`
 	MinIdleConns int           `envconfig:"MIN_IDLE_CONNS" default:"10"`
 	MaxConnAge   time.Duration `envconfig:"MAX_CONN_AGE" default:"1h"`
+	// RepositoryCacheTTL bounds read-through repository cache entries; zero disables the cache
+	RepositoryCacheTTL time.Duration `envconfig:"REPOSITORY_CACHE_TTL" default:"1m"`
+	// ResponseCacheTTL bounds the cached responses of heavy read endpoints; zero disables
+	// the response cache
+	ResponseCacheTTL time.Duration `envconfig:"RESPONSE_CACHE_TTL" default:"15s"`
+	// LocalCacheSize is how many hot entries each replica keeps in memory in front of Redis;
+	// zero disables the local layer. LocalCacheTTL bounds staleness if an invalidation is missed.
+	LocalCacheSize int           `envconfig:"LOCAL_CACHE_SIZE" default:"0"`
+	LocalCacheTTL  time.Duration `envconfig:"LOCAL_CACHE_TTL" default:"5s"`
+	// WarmOnStartup preloads the permission catalogue and up to WarmMaxMonitors active
+	// monitors into the repository cache on boot; it requires RepositoryCacheTTL.
+	WarmOnStartup   bool `envconfig:"WARM_ON_STARTUP" default:"true"`
+	WarmMaxMonitors int  `envconfig:"WARM_MAX_MONITORS" default:"10000"`
 }
 
 // ClickHouseConfig holds the configuration for the ClickHouse database connection.
@@ -72,7 +165,7 @@ type ClickHouseConfig struct {
 	Host     string `envconfig:"HOST" default:"127.0.0.1"`
 	Port     int    `envconfig:"PORT" default:"9000"`
 	Username string `envconfig:"USERNAME" default:""`
-	Password string `envconfig:"PASSWORD" default:""`
+	Password string `envconfig:"PASSWORD" default:"" secret:"true"`
 	Database string `envconfig:"DATABASE" default:"default"`
 	Secure   bool   `envconfig:"SECURE" default:"false"`
 
@@ -84,15 +177,61 @@ type ClickHouseConfig struct {
 	HealthCheckTimeout time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"3s"`
 	MaxRetries         int           `envconfig:"MAX_RETRIES" default:"5"`
 	RetryInterval      time.Duration `envconfig:"RETRY_INTERVAL" default:"2s"`
+	// QueryTimeout bounds the statements of background work, whose contexts have no deadline
+	// of their own; 0 leaves them unbounded
+	QueryTimeout time.Duration `envconfig:"QUERY_TIMEOUT" default:"1m"`
+
+	// Batched writes; every insert is buffered and flushed by size or interval
+	WriteBatchSize     int           `envconfig:"WRITE_BATCH_SIZE" default:"1000"`
+	WriteFlushInterval time.Duration `envconfig:"WRITE_FLUSH_INTERVAL" default:"2s"`
+	WriteBufferSize    int           `envconfig:"WRITE_BUFFER_SIZE" default:"20000"`
+	WriteMaxRetries    int           `envconfig:"WRITE_MAX_RETRIES" default:"3"`
 }
 
 // EmailConfig holds the configuration for email services.
 type EmailConfig struct {
-	Enable             bool       `envconfig:"ENABLE" default:"false"`
-	DefaultFromAddress string     `envconfig:"DEFAULT_FROM_ADDRESS" default:"no-reply@example.com"`
-	DefaultProvider    string     `envconfig:"DEFAULT_PROVIDER" default:""`
-	ProviderOrder      string     `envconfig:"PROVIDER_ORDER" default:""`
-	SMTP               SMTPConfig `envconfig:"SMTP"`
+	Enable             bool               `envconfig:"ENABLE" default:"false"`
+	DefaultFromAddress string             `envconfig:"DEFAULT_FROM_ADDRESS" default:"no-reply@example.com"`
+	DefaultProvider    string             `envconfig:"DEFAULT_PROVIDER" default:""`
+	ProviderOrder      string             `envconfig:"PROVIDER_ORDER" default:""`
+	ProductName        string             `envconfig:"PRODUCT_NAME" default:"Uptime"` // Shown in email templates
+	SMTP               SMTPConfig         `envconfig:"SMTP"`
+	Webhooks           EmailWebhookConfig `envconfig:"WEBHOOK"`
+	Capture            EmailCaptureConfig `envconfig:"CAPTURE"`
+}
+
+// EmailCaptureConfig enables the capture provider, which stores rendered emails in the
+// storage driver instead of sending them so templates can be previewed during development.
+// Select it with EMAIL_DEFAULT_PROVIDER=capture or by disabling the other providers.
+type EmailCaptureConfig struct {
+	Enable bool `envconfig:"ENABLE" default:"false"`
+}
+
+// EmailWebhookConfig enables the bounce and complaint webhooks of each provider. A
+// provider's endpoint is only served once its verification key is set.
+type EmailWebhookConfig struct {
+	SendGridPublicKey string   `envconfig:"SENDGRID_PUBLIC_KEY"` // Base64 verification key of the signed event webhook
+	MailgunSigningKey string   `envconfig:"MAILGUN_SIGNING_KEY" secret:"true"`
+	SESEnable         bool     `envconfig:"SES_ENABLE" default:"false"`
+	SESTopicARNs      []string `envconfig:"SES_TOPIC_ARNS"` // SNS topics accepted; required when SES_ENABLE is set
+}
+
+// Enabled reports whether any provider webhook is configured
+func (c EmailWebhookConfig) Enabled() bool {
+	return c.SendGridPublicKey != "" || c.MailgunSigningKey != "" || c.SESEnable
+}
+
+// Validate checks that the SES endpoint only accepts deliveries from listed topics
+func (c EmailWebhookConfig) Validate() error {
+	if !c.SESEnable {
+		return nil
+	}
+	for _, arn := range c.SESTopicARNs {
+		if strings.TrimSpace(arn) != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("EMAIL_WEBHOOK_SES_TOPIC_ARNS is required when EMAIL_WEBHOOK_SES_ENABLE is true")
 }
 
 // SMTPConfig holds SMTP-specific configuration.
@@ -101,10 +240,35 @@ type SMTPConfig struct {
 	Host        string `envconfig:"HOST"`
 	Port        int    `envconfig:"PORT"`
 	Username    string `envconfig:"USERNAME"`
-	Password    string `envconfig:"PASSWORD"`
+	Password    string `envconfig:"PASSWORD" secret:"true"`
 	FromAddress string `envconfig:"FROM_ADDRESS"`
 }
 
+// SMSConfig holds the configuration for SMS services.
+type SMSConfig struct {
+	Enable          bool         `envconfig:"ENABLE" default:"false"`
+	DefaultProvider string       `envconfig:"DEFAULT_PROVIDER" default:""`
+	ProviderOrder   string       `envconfig:"PROVIDER_ORDER" default:""`
+	Twilio          TwilioConfig `envconfig:"TWILIO"`
+	Vonage          VonageConfig `envconfig:"VONAGE"`
+}
+
+// TwilioConfig holds Twilio-specific configuration.
+type TwilioConfig struct {
+	Enable     bool   `envconfig:"ENABLE" default:"false"`
+	AccountSID string `envconfig:"ACCOUNT_SID"`
+	AuthToken  string `envconfig:"AUTH_TOKEN" secret:"true"`
+	FromNumber string `envconfig:"FROM_NUMBER"`
+}
+
+// VonageConfig holds Vonage-specific configuration.
+type VonageConfig struct {
+	Enable     bool   `envconfig:"ENABLE" default:"false"`
+	APIKey     string `envconfig:"API_KEY" secret:"true"`
+	APISecret  string `envconfig:"API_SECRET" secret:"true"`
+	FromNumber string `envconfig:"FROM_NUMBER"` // Number or alphanumeric sender ID
+}
+
 // LocalStorageConfig holds configuration for local file storage.
 type LocalStorageConfig struct {
 	Enable  bool   `envconfig:"ENABLE" default:"true"`
@@ -125,6 +289,308 @@ type LoggingConfig struct {
 	MaxBackups int  `envconfig:"MAX_BACKUPS" default:"3"`
 	MaxAge     int  `envconfig:"MAX_AGE" default:"30"`
 	Compress   bool `envconfig:"COMPRESS" default:"true"`
+
+	// HTTP body logging is a debugging aid and is never enabled in production mode.
+	HTTPBodies        bool     `envconfig:"HTTP_BODIES" default:"false"`
+	HTTPBodyMaxBytes  int      `envconfig:"HTTP_BODY_MAX_BYTES" default:"4096"`
+	HTTPBodySkipPaths []string `envconfig:"HTTP_BODY_SKIP_PATHS"`
+
+	// Sampling keeps, per level and per message, the first Initial entries of every
+	// SamplingTick and then every Thereafter-th one; a Thereafter of 0 drops the rest.
+	// Errors and above are never sampled.
+	SamplingEnable          bool          `envconfig:"SAMPLING_ENABLE" default:"true"`
+	SamplingTick            time.Duration `envconfig:"SAMPLING_TICK" default:"1s"`
+	SamplingDebugInitial    int           `envconfig:"SAMPLING_DEBUG_INITIAL" default:"10"`
+	SamplingDebugThereafter int           `envconfig:"SAMPLING_DEBUG_THEREAFTER" default:"100"`
+	SamplingInfoInitial     int           `envconfig:"SAMPLING_INFO_INITIAL" default:"100"`
+	SamplingInfoThereafter  int           `envconfig:"SAMPLING_INFO_THEREAFTER" default:"100"`
+	SamplingWarnInitial     int           `envconfig:"SAMPLING_WARN_INITIAL" default:"100"`
+	SamplingWarnThereafter  int           `envconfig:"SAMPLING_WARN_THEREAFTER" default:"10"`
+
+	// High-frequency events, such as failures while a circuit breaker is open, are logged
+	// at most RateLimitBurst times per RateLimitInterval each. An interval of 0 disables it.
+	RateLimitInterval time.Duration `envconfig:"RATE_LIMIT_INTERVAL" default:"10s"`
+	RateLimitBurst    int           `envconfig:"RATE_LIMIT_BURST" default:"5"`
+
+	// Values of fields with these keys are redacted in addition to the built-in ones, such
+	// as password and token
+	RedactKeys []string `envconfig:"REDACT_KEYS"`
+
+	// The audit log records security-relevant actions to its own outputs, never sampled,
+	// each entry hash-chained to the previous one. A signing key turns the hashes into
+	// HMACs so the chain cannot be rebuilt after editing the file.
+	AuditEnable      bool     `envconfig:"AUDIT_ENABLE" default:"false"`
+	AuditOutputPaths []string `envconfig:"AUDIT_OUTPUT_PATHS" default:"logs/audit.log"`
+	AuditSigningKey  string   `envconfig:"AUDIT_SIGNING_KEY" secret:"true"`
+
+	// SinkType ships logs to "loki" or an "otlp" collector over HTTP in addition to the
+	// outputs above. Entries are buffered and dropped when the buffer is full so a slow
+	// backend never blocks the application.
+	SinkType          string            `envconfig:"SINK_TYPE"`
+	SinkURL           string            `envconfig:"SINK_URL"`
+	SinkHeaders       map[string]string `envconfig:"SINK_HEADERS" secret:"true"`
+	SinkServiceName   string            `envconfig:"SINK_SERVICE_NAME" default:"api-services"`
+	SinkLabels        map[string]string `envconfig:"SINK_LABELS"`
+	SinkBufferSize    int               `envconfig:"SINK_BUFFER_SIZE" default:"10000"`
+	SinkBatchSize     int               `envconfig:"SINK_BATCH_SIZE" default:"500"`
+	SinkFlushInterval time.Duration     `envconfig:"SINK_FLUSH_INTERVAL" default:"2s"`
+	SinkTimeout       time.Duration     `envconfig:"SINK_TIMEOUT" default:"5s"`
+}
+
+// Log sink types
+const (
+	LogSinkLoki = "loki"
+	LogSinkOTLP = "otlp"
+)
+
+// CaptchaConfig holds configuration for bot protection on public auth endpoints.
+type CaptchaConfig struct {
+	Enable    bool          `envconfig:"ENABLE" default:"false"`
+	Provider  string        `envconfig:"PROVIDER" default:"turnstile"`
+	SecretKey string        `envconfig:"SECRET_KEY" secret:"true"`
+	VerifyURL string        `envconfig:"VERIFY_URL"`
+	MinScore  float64       `envconfig:"MIN_SCORE" default:"0.5"`
+	Timeout   time.Duration `envconfig:"TIMEOUT" default:"5s"`
+}
+
+// CORSConfig holds cross-origin settings for browser clients.
+// AllowedOrigins is a comma-separated list; when empty, APP_FRONTEND_URL is used.
+type CORSConfig struct {
+	AllowedOrigins   []string      `envconfig:"ALLOWED_ORIGINS"`
+	AllowCredentials bool          `envconfig:"ALLOW_CREDENTIALS" default:"true"`
+	MaxAge           time.Duration `envconfig:"MAX_AGE" default:"12h"`
+}
+
+// SecurityConfig holds the security headers applied to every response.
+type SecurityConfig struct {
+	HeadersEnable         bool          `envconfig:"HEADERS_ENABLE" default:"true"`
+	HSTSMaxAge            time.Duration `envconfig:"HSTS_MAX_AGE" default:"8760h"`
+	HSTSIncludeSubdomains bool          `envconfig:"HSTS_INCLUDE_SUBDOMAINS" default:"true"`
+	HSTSPreload           bool          `envconfig:"HSTS_PRELOAD" default:"false"`
+	ContentSecurityPolicy string        `envconfig:"CONTENT_SECURITY_POLICY" default:"default-src 'none'; frame-ancestors 'none'"`
+	ReferrerPolicy        string        `envconfig:"REFERRER_POLICY" default:"no-referrer"`
+	PermissionsPolicy     string        `envconfig:"PERMISSIONS_POLICY" default:"geolocation=(), microphone=(), camera=()"`
+}
+
+// URLSignerConfig holds settings for signed, expiring download links.
+// Secret defaults to APP_KEY when empty.
+type URLSignerConfig struct {
+	Secret         string        `envconfig:"SECRET" secret:"true"`
+	ExpiresParam   string        `envconfig:"EXPIRES_PARAM" default:"exp"`
+	SignatureParam string        `envconfig:"SIGNATURE_PARAM" default:"sig"`
+	ClockSkewGrace time.Duration `envconfig:"CLOCK_SKEW_GRACE" default:"30s"`
+	DefaultTTL     time.Duration `envconfig:"DEFAULT_TTL" default:"15m"`
+	MaxTTL         time.Duration `envconfig:"MAX_TTL" default:"24h"`
+	// PreviousSecrets still validate URLs signed with earlier secrets
+	PreviousSecrets []string `envconfig:"PREVIOUS_SECRETS" secret:"true"`
+}
+
+// EncryptionConfig holds the keys encrypting secrets stored in the database, such as the
+// tokens of integrations. Key is a base64 256-bit data key; when empty, keys are derived
+// from APP_KEY and APP_PREVIOUS_KEYS. With KMSURL set, Key and PreviousKeys are data keys
+// wrapped by the Vault transit key KMSKeyName, unwrapped at startup.
+type EncryptionConfig struct {
+	Key string `envconfig:"KEY" secret:"true"`
+	// PreviousKeys still decrypt the values encrypted before a rotation
+	PreviousKeys []string      `envconfig:"PREVIOUS_KEYS" secret:"true"`
+	KMSURL       string        `envconfig:"KMS_URL"`
+	KMSToken     string        `envconfig:"KMS_TOKEN" secret:"true"`
+	KMSKeyName   string        `envconfig:"KMS_KEY_NAME"`
+	KMSTimeout   time.Duration `envconfig:"KMS_TIMEOUT" default:"10s"`
+}
+
+// AnalyticsConfig holds settings for recording API requests into ClickHouse.
+type AnalyticsConfig struct {
+	Enable        bool          `envconfig:"ENABLE" default:"false"`
+	BatchSize     int           `envconfig:"BATCH_SIZE" default:"500"`
+	FlushInterval time.Duration `envconfig:"FLUSH_INTERVAL" default:"5s"`
+	BufferSize    int           `envconfig:"BUFFER_SIZE" default:"10000"`
+}
+
+// RetentionConfig controls how long soft-deleted rows are kept before the purge job
+// removes them permanently. A zero window disables purging for that table.
+//
+// The data windows are the defaults for how long check results, their rollups and
+// resolved incidents are kept, which organizations may override, and how long the purge
+// audit log and the activity feeds of organizations are kept. They are enforced every
+// DataInterval; a zero window keeps that data.
+type RetentionConfig struct {
+	Enable              bool          `envconfig:"ENABLE" default:"true"`
+	Interval            time.Duration `envconfig:"INTERVAL" default:"1h"`
+	BatchSize           int           `envconfig:"BATCH_SIZE" default:"500"`
+	UsersWindow         time.Duration `envconfig:"USERS_WINDOW" default:"720h"`
+	OrganizationsWindow time.Duration `envconfig:"ORGANIZATIONS_WINDOW" default:"720h"`
+	MonitorsWindow      time.Duration `envconfig:"MONITORS_WINDOW" default:"720h"`
+	DataInterval        time.Duration `envconfig:"DATA_INTERVAL" default:"24h"`
+	CheckResultsWindow  time.Duration `envconfig:"CHECK_RESULTS_WINDOW" default:"720h"`
+	RollupsWindow       time.Duration `envconfig:"ROLLUPS_WINDOW" default:"17520h"`
+	IncidentsWindow     time.Duration `envconfig:"INCIDENTS_WINDOW" default:"17520h"`
+	AuditLogsWindow     time.Duration `envconfig:"AUDIT_LOGS_WINDOW" default:"0s"`
+	ActivityWindow      time.Duration `envconfig:"ACTIVITY_WINDOW" default:"2160h"`
+}
+
+// StorageCleanupConfig controls the job deleting stored files that no database record
+// references anymore. Files younger than GracePeriod are kept, since an upload is stored
+// before the record pointing to it is saved. DryRun only logs what would be deleted.
+type StorageCleanupConfig struct {
+	Enable      bool          `envconfig:"ENABLE" default:"false"`
+	Interval    time.Duration `envconfig:"INTERVAL" default:"24h"`
+	GracePeriod time.Duration `envconfig:"GRACE_PERIOD" default:"24h"`
+	DryRun      bool          `envconfig:"DRY_RUN" default:"false"`
+}
+
+// ReportsConfig controls the weekly and monthly uptime report emails sent to the
+// organization members who opted in. Reports are built from the ClickHouse rollups.
+// UnsubscribeURL is the page unsubscribe links point to, with the subscription token in a
+// "token" query parameter; it defaults to APP_FRONTEND_URL/reports/unsubscribe.
+type ReportsConfig struct {
+	Enable         bool          `envconfig:"ENABLE" default:"false"`
+	Interval       time.Duration `envconfig:"INTERVAL" default:"1h"`
+	BatchSize      int           `envconfig:"BATCH_SIZE" default:"100"`
+	SLATarget      float64       `envconfig:"SLA_TARGET" default:"99.9"`
+	UnsubscribeURL string        `envconfig:"UNSUBSCRIBE_URL"`
+}
+
+// ReportFilesConfig controls the uptime report files members request for a range of days.
+// Pending reports are rendered every Interval into the storage driver, where they are
+// kept for TTL; LinkTTL is how long the signed download links last.
+type ReportFilesConfig struct {
+	Enable      bool          `envconfig:"ENABLE" default:"false"`
+	Interval    time.Duration `envconfig:"INTERVAL" default:"1m"`
+	BatchSize   int           `envconfig:"BATCH_SIZE" default:"10"`
+	MaxAttempts int           `envconfig:"MAX_ATTEMPTS" default:"3"`
+	TTL         time.Duration `envconfig:"TTL" default:"168h"`
+	LinkTTL     time.Duration `envconfig:"LINK_TTL" default:"15m"`
+	MaxDays     int           `envconfig:"MAX_DAYS" default:"366"`
+	MaxPending  int           `envconfig:"MAX_PENDING" default:"5"`
+}
+
+// SLAConfig controls the evaluation of the SLA targets of organizations against the
+// ClickHouse rollups. A target is at risk once AtRiskBudget of its error budget is spent
+// or when the error rate over the last FastBurnWindow spends the budget FastBurnRate
+// times faster than the target allows.
+//
+// BurnAlerts open a warning incident for a monitor a target covers once it spends a share
+// of the error budget of the period within a window, before the target is breached. Each
+// alert is "share/window", e.g. "2%/1h"; see ParseBurnAlerts. Empty disables them.
+type SLAConfig struct {
+	Enable         bool          `envconfig:"ENABLE" default:"false"`
+	Interval       time.Duration `envconfig:"INTERVAL" default:"5m"`
+	BatchSize      int           `envconfig:"BATCH_SIZE" default:"100"`
+	AtRiskBudget   float64       `envconfig:"AT_RISK_BUDGET" default:"0.75"`
+	FastBurnRate   float64       `envconfig:"FAST_BURN_RATE" default:"14.4"`
+	FastBurnWindow time.Duration `envconfig:"FAST_BURN_WINDOW" default:"1h"`
+	BurnAlerts     []string      `envconfig:"BURN_ALERTS" default:"2%/1h,5%/6h"`
+}
+
+// BurnAlert is a parsed SLAConfig burn alert
+type BurnAlert struct {
+	// Budget is the share of the error budget of the period, between 0 and 1
+	Budget float64
+	Window time.Duration
+}
+
+// Bounds of the windows of burn alerts. A burn alert only fires while the last twelfth of
+// its window burns as fast, which has to span a minute rollup, and windows are read from
+// the minute rollups, kept for 7 days.
+const (
+	minBurnAlertWindow = 12 * time.Minute
+	maxBurnAlertWindow = 7 * 24 * time.Hour
+)
+
+// JobsConfig controls the scheduler running background jobs. Timeout bounds the runs of
+// jobs without their own. Schedules replaces the schedule of jobs by name with a cron
+// expression, e.g. "retention_purge:0 3 * * *".
+type JobsConfig struct {
+	Workers   int               `envconfig:"WORKERS" default:"4"`
+	Timeout   time.Duration     `envconfig:"TIMEOUT" default:"30m"`
+	Schedules map[string]string `envconfig:"SCHEDULES"`
+}
+
+// DeprecationConfig marks API routes as deprecated so that their responses carry the
+// Deprecation, Sunset and Link headers. Each route is "METHOD /path|deprecated|sunset|link"
+// with the path as it is registered and dates as YYYY-MM-DD in UTC, e.g.
+// "GET /api/v1/organizations/:organizationId/monitors|2026-10-01|2027-04-01|https://docs.example.com/v2".
+// The sunset date and the link are optional.
+type DeprecationConfig struct {
+	Routes []string `envconfig:"ROUTES"`
+}
+
+// DeprecatedRoute is a parsed DeprecationConfig route
+type DeprecatedRoute struct {
+	Method     string
+	Path       string
+	Deprecated time.Time
+	// Sunset is when the route may stop responding; zero when it is not planned yet
+	Sunset time.Time
+	// Link points to the documentation of the replacement, if any
+	Link string
+}
+
+// OutboxConfig controls the relay delivering side effects recorded in the outbox table.
+// Failed deliveries are retried with exponential backoff until MaxAttempts is reached.
+type OutboxConfig struct {
+	Enable       bool          `envconfig:"ENABLE" default:"true"`
+	PollInterval time.Duration `envconfig:"POLL_INTERVAL" default:"1s"`
+	BatchSize    int           `envconfig:"BATCH_SIZE" default:"50"`
+	MaxAttempts  int           `envconfig:"MAX_ATTEMPTS" default:"10"`
+	RetryBackoff time.Duration `envconfig:"RETRY_BACKOFF" default:"5s"`
+	MaxBackoff   time.Duration `envconfig:"MAX_BACKOFF" default:"1h"`
+	Retention    time.Duration `envconfig:"RETENTION" default:"168h"`
+}
+
+// QueueConfig controls the Redis Streams job queue. When enabled, the outbox relay hands
+// monitor checks and notifications over to the queue, and every replica consumes them in
+// the Group consumer group. Jobs pending longer than ClaimIdle are claimed by another
+// consumer, and jobs failing MaxAttempts deliveries move to the Stream:dead stream.
+type QueueConfig struct {
+	Enable         bool          `envconfig:"ENABLE" default:"false"`
+	Stream         string        `envconfig:"STREAM" default:"uptime:jobs"`
+	Group          string        `envconfig:"GROUP" default:"api-services"`
+	BatchSize      int64         `envconfig:"BATCH_SIZE" default:"10"`
+	Block          time.Duration `envconfig:"BLOCK" default:"5s"`
+	ClaimIdle      time.Duration `envconfig:"CLAIM_IDLE" default:"1m"`
+	MaxAttempts    int64         `envconfig:"MAX_ATTEMPTS" default:"5"`
+	HandlerTimeout time.Duration `envconfig:"HANDLER_TIMEOUT" default:"30s"`
+	MaxLen         int64         `envconfig:"MAX_LEN" default:"100000"`
+}
+
+// MetricsConfig controls the Prometheus metrics endpoint. When AuthToken is set,
+// scrapers must send it as a bearer token.
+type MetricsConfig struct {
+	Enable    bool   `envconfig:"ENABLE" default:"true"`
+	Path      string `envconfig:"PATH" default:"/metrics"`
+	AuthToken string `envconfig:"AUTH_TOKEN" secret:"true"`
+}
+
+// AdminConfig controls the operator endpoints under /admin. They are only served when
+// Token is set, and callers must send it as a bearer token. A dependency failing
+// AlertThreshold health checks in a row raises an internal incident, which AlertEmails
+// are notified of along with its recovery.
+type AdminConfig struct {
+	Token          string   `envconfig:"TOKEN" secret:"true"`
+	AlertEmails    []string `envconfig:"ALERT_EMAILS"`
+	AlertThreshold int      `envconfig:"ALERT_THRESHOLD" default:"3"`
+}
+
+// CheckResultsConfig controls the Postgres fallback store used for check results when
+// ClickHouse is disabled. Results go to a table partitioned by month; partitions are
+// created PartitionsAhead months in advance and dropped once older than Retention.
+// A zero Retention keeps every partition.
+type CheckResultsConfig struct {
+	PostgresFallback    bool          `envconfig:"POSTGRES_FALLBACK" default:"true"`
+	PartitionsAhead     int           `envconfig:"PARTITIONS_AHEAD" default:"2"`
+	Retention           time.Duration `envconfig:"RETENTION" default:"2160h"`
+	MaintenanceInterval time.Duration `envconfig:"MAINTENANCE_INTERVAL" default:"6h"`
+}
+
+// GRPCConfig holds configuration for the internal gRPC server used by probes and workers.
+type GRPCConfig struct {
+	Enable              bool          `envconfig:"ENABLE" default:"false"`
+	Port                string        `envconfig:"PORT" default:"5006"`
+	AuthToken           string        `envconfig:"AUTH_TOKEN" secret:"true"`
+	MaxRecvMsgSize      int           `envconfig:"MAX_RECV_MSG_SIZE" default:"4194304"`
+	HealthCheckInterval time.Duration `envconfig:"HEALTH_CHECK_INTERVAL" default:"15s"`
 }
 
 // DSN generates the Data Source Name for a PostgreSQL connection.
@@ -154,6 +620,8 @@ func GetConfig() (*Config, error) {
 }
 
 func loadConfig() (*Config, error) {
+	processEnv = environ()
+
 	userConfig, err := godotenv.Read(".env")
 	if err != nil && !os.IsNotExist(err) {
 		fmt.Printf("warning: could not read .env: %v", err)
@@ -170,16 +638,85 @@ func loadConfig() (*Config, error) {
 		env = AppModeDevelopment
 	}
 
-	if err := godotenv.Load(fmt.Sprintf(".env.%s", env)); err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to load .env.%s: %w", env, err)
+	if err := applyEnvFiles(env, userConfig); err != nil {
+		return nil, err
 	}
 
-	if userConfig != nil {
-		for key, value := range userConfig {
+	return processConfig()
+}
+
+// reloadConfig reads the .env files for mode again and returns the configuration they
+// produce, without replacing the one returned by GetConfig
+func reloadConfig(mode string) (*Config, error) {
+	userConfig, err := godotenv.Read(".env")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read .env: %w", err)
+	}
+
+	if err := applyEnvFiles(mode, userConfig); err != nil {
+		return nil, err
+	}
+
+	return processConfig()
+}
+
+// applyEnvFiles sets the variables of .env.<mode> the process environment does not set,
+// then those of userConfig, read from .env, which override everything, then the settings
+// of the APP_CONFIG_FILE config file no variable sets. Variables set by a previous call
+// that the files no longer define get their process value back.
+func applyEnvFiles(mode string, userConfig map[string]string) error {
+	modeConfig, err := godotenv.Read(fmt.Sprintf(".env.%s", mode))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load .env.%s: %w", mode, err)
+	}
+
+	values := make(map[string]string, len(modeConfig)+len(userConfig))
+	for key, value := range modeConfig {
+		if _, ok := processEnv[key]; !ok {
+			values[key] = value
+		}
+	}
+	maps.Copy(values, userConfig)
+
+	// The config file sits below every environment variable, whichever file sets it
+	configFile, ok := values[ConfigFileEnv]
+	if !ok {
+		configFile = processEnv[ConfigFileEnv]
+	}
+	if configFile != "" {
+		fileConfig, err := readConfigFile(configFile)
+		if err != nil {
+			return err
+		}
+		for key, value := range fileConfig {
+			_, inProcess := processEnv[key]
+			if _, inEnvFiles := values[key]; !inProcess && !inEnvFiles {
+				values[key] = value
+			}
+		}
+	}
+
+	for key := range fileEnvKeys {
+		if _, ok := values[key]; ok {
+			continue
+		}
+		if value, ok := processEnv[key]; ok {
 			_ = os.Setenv(key, value)
+		} else {
+			_ = os.Unsetenv(key)
 		}
 	}
 
+	fileEnvKeys = make(map[string]bool, len(values))
+	for key, value := range values {
+		_ = os.Setenv(key, value)
+		fileEnvKeys[key] = true
+	}
+	return nil
+}
+
+// processConfig builds the configuration from the environment and validates it
+func processConfig() (*Config, error) {
 	var c Config
 	if err := envconfig.Process("", &c); err != nil {
 		return nil, fmt.Errorf("failed to process configuration from environment: %w", err)
@@ -192,12 +729,41 @@ func loadConfig() (*Config, error) {
 	return &c, nil
 }
 
+// environ returns the variables of the process environment
+func environ() map[string]string {
+	env := make(map[string]string)
+	for _, entry := range os.Environ() {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			env[key] = value
+		}
+	}
+	return env
+}
+
 // Validate checks for complex configuration rules.
 func (c *Config) Validate() error {
 	switch c.App.Mode {
-	case AppModeDevelopment, AppModeProduction:
+	case AppModeDevelopment, AppModeStaging, AppModeProduction:
 	default:
-		return fmt.Errorf("invalid APP_ENV: %q, must be one of '%s', or '%s'", c.App.Mode, AppModeDevelopment, AppModeProduction)
+		return fmt.Errorf("invalid APP_ENV: %q, must be one of '%s', '%s', or '%s'", c.App.Mode, AppModeDevelopment, AppModeStaging, AppModeProduction)
+	}
+
+	for _, key := range c.App.PreviousKeys {
+		if key == "" || key == c.App.Key {
+			return fmt.Errorf("invalid APP_PREVIOUS_KEYS: keys must be non-empty and differ from APP_KEY")
+		}
+	}
+
+	if c.App.ConfigReloadInterval < 0 {
+		return fmt.Errorf("invalid APP_CONFIG_RELOAD_INTERVAL: cannot be negative")
+	}
+
+	if err := c.Logging.Validate(); err != nil {
+		return fmt.Errorf("logging config invalid: %w", err)
+	}
+
+	if err := c.Server.Validate(); err != nil {
+		return fmt.Errorf("server config invalid: %w", err)
 	}
 
 	if c.Postgres.Enable {
@@ -218,11 +784,261 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.Email.Webhooks.Validate(); err != nil {
+		return fmt.Errorf("email config invalid: %w", err)
+	}
+
+	if c.Email.Capture.Enable && c.App.Mode == AppModeProduction {
+		return fmt.Errorf("email config invalid: EMAIL_CAPTURE_ENABLE is not allowed in production mode")
+	}
+
+	if c.SMS.Enable {
+		if err := c.SMS.Validate(); err != nil {
+			return fmt.Errorf("sms config invalid: %w", err)
+		}
+	}
+
+	if c.Captcha.Enable {
+		if err := c.Captcha.Validate(); err != nil {
+			return fmt.Errorf("captcha config invalid: %w", err)
+		}
+	}
+
+	if err := c.CORS.Validate(); err != nil {
+		return fmt.Errorf("cors config invalid: %w", err)
+	}
+	if c.App.Mode == AppModeProduction && len(c.CORSOrigins()) == 0 {
+		return fmt.Errorf("cors config invalid: CORS_ALLOWED_ORIGINS or APP_FRONTEND_URL is required in production mode")
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		return fmt.Errorf("security config invalid: hsts max age cannot be negative")
+	}
+
+	if c.Analytics.Enable {
+		if !c.ClickHouse.Enable {
+			return fmt.Errorf("analytics config invalid: CLICKHOUSE_ENABLE must be true when analytics is enabled")
+		}
+		if err := c.Analytics.Validate(); err != nil {
+			return fmt.Errorf("analytics config invalid: %w", err)
+		}
+	}
+
+	if c.Retention.Enable {
+		if !c.Postgres.Enable {
+			return fmt.Errorf("retention config invalid: POSTGRES_ENABLE must be true when retention is enabled")
+		}
+		if err := c.Retention.Validate(); err != nil {
+			return fmt.Errorf("retention config invalid: %w", err)
+		}
+	}
+
+	if c.Outbox.Enable {
+		if !c.Postgres.Enable {
+			return fmt.Errorf("outbox config invalid: POSTGRES_ENABLE must be true when the outbox is enabled")
+		}
+		if err := c.Outbox.Validate(); err != nil {
+			return fmt.Errorf("outbox config invalid: %w", err)
+		}
+	}
+
+	if c.Queue.Enable {
+		if !c.Redis.Enable || !c.Outbox.Enable {
+			return fmt.Errorf("queue config invalid: REDIS_ENABLE and OUTBOX_ENABLE must be true when the queue is enabled")
+		}
+		if err := c.Queue.Validate(); err != nil {
+			return fmt.Errorf("queue config invalid: %w", err)
+		}
+	}
+
+	if c.StorageCleanup.Enable {
+		if !c.Postgres.Enable {
+			return fmt.Errorf("storage cleanup config invalid: POSTGRES_ENABLE must be true when storage cleanup is enabled")
+		}
+		if err := c.StorageCleanup.Validate(); err != nil {
+			return fmt.Errorf("storage cleanup config invalid: %w", err)
+		}
+	}
+
+	if c.Reports.Enable {
+		if !c.Postgres.Enable || !c.ClickHouse.Enable {
+			return fmt.Errorf("reports config invalid: POSTGRES_ENABLE and CLICKHOUSE_ENABLE must be true when reports are enabled")
+		}
+		if !c.Outbox.Enable {
+			return fmt.Errorf("reports config invalid: OUTBOX_ENABLE must be true when reports are enabled")
+		}
+		if c.ReportUnsubscribeURL() == "" {
+			return fmt.Errorf("reports config invalid: REPORTS_UNSUBSCRIBE_URL or APP_FRONTEND_URL is required")
+		}
+		if err := c.Reports.Validate(); err != nil {
+			return fmt.Errorf("reports config invalid: %w", err)
+		}
+	}
+
+	if c.ReportFiles.Enable {
+		if !c.Postgres.Enable || !c.ClickHouse.Enable {
+			return fmt.Errorf("report files config invalid: POSTGRES_ENABLE and CLICKHOUSE_ENABLE must be true when report files are enabled")
+		}
+		if err := c.ReportFiles.Validate(); err != nil {
+			return fmt.Errorf("report files config invalid: %w", err)
+		}
+		if c.ReportFiles.LinkTTL > c.URLSigner.MaxTTL {
+			return fmt.Errorf("report files config invalid: REPORT_FILES_LINK_TTL cannot exceed URL_SIGNER_MAX_TTL")
+		}
+	}
+
+	if c.SLA.Enable {
+		if !c.Postgres.Enable || !c.ClickHouse.Enable {
+			return fmt.Errorf("sla config invalid: POSTGRES_ENABLE and CLICKHOUSE_ENABLE must be true when SLA evaluation is enabled")
+		}
+		if !c.Outbox.Enable {
+			return fmt.Errorf("sla config invalid: OUTBOX_ENABLE must be true when SLA evaluation is enabled")
+		}
+		if err := c.SLA.Validate(); err != nil {
+			return fmt.Errorf("sla config invalid: %w", err)
+		}
+	}
+
+	if err := c.Jobs.Validate(); err != nil {
+		return fmt.Errorf("jobs config invalid: %w", err)
+	}
+
+	if _, err := c.Deprecation.ParseRoutes(); err != nil {
+		return fmt.Errorf("deprecation config invalid: %w", err)
+	}
+
+	if c.Metrics.Enable {
+		if err := c.Metrics.Validate(); err != nil {
+			return fmt.Errorf("metrics config invalid: %w", err)
+		}
+	}
+
+	if c.CheckResults.PostgresFallback && !c.ClickHouse.Enable && c.Postgres.Enable {
+		if err := c.CheckResults.Validate(); err != nil {
+			return fmt.Errorf("check results config invalid: %w", err)
+		}
+	}
+
+	if err := c.URLSigner.Validate(); err != nil {
+		return fmt.Errorf("url signer config invalid: %w", err)
+	}
+
+	if err := c.Encryption.Validate(); err != nil {
+		return fmt.Errorf("encryption config invalid: %w", err)
+	}
+
+	if err := c.Admin.Validate(); err != nil {
+		return fmt.Errorf("admin config invalid: %w", err)
+	}
+
+	if c.GRPC.Enable {
+		if err := c.GRPC.Validate(); err != nil {
+			return fmt.Errorf("grpc config invalid: %w", err)
+		}
+		if c.GRPC.Port == c.App.Port {
+			return fmt.Errorf("grpc port cannot be the same as the HTTP port")
+		}
+	}
+
+	return nil
+}
+
+// Validate ServerConfig checks that server limits and timeouts are usable.
+func (s *ServerConfig) Validate() error {
+	if s.ReadTimeout < 0 || s.ReadHeaderTimeout < 0 || s.WriteTimeout < 0 || s.IdleTimeout < 0 {
+		return fmt.Errorf("server timeouts cannot be negative")
+	}
+	if s.RequestTimeout <= 0 {
+		return fmt.Errorf("server request timeout must be positive")
+	}
+	if s.WriteTimeout > 0 && s.RequestTimeout > s.WriteTimeout {
+		return fmt.Errorf("server request timeout cannot exceed write timeout")
+	}
+	if _, err := s.ParseRouteTimeouts(); err != nil {
+		return err
+	}
+	if s.MaxBodyBytes <= 0 {
+		return fmt.Errorf("server max body bytes must be a positive integer")
+	}
+	if s.ShutdownTimeout <= 0 || s.ShutdownHookTimeout <= 0 {
+		return fmt.Errorf("server shutdown timeouts must be positive")
+	}
+	if s.ShutdownHookTimeout > s.ShutdownTimeout {
+		return fmt.Errorf("server shutdown hook timeout cannot exceed shutdown timeout")
+	}
+	if s.HealthCacheTTL < 0 {
+		return fmt.Errorf("server health cache TTL cannot be negative")
+	}
+	return nil
+}
+
+// Validate LoggingConfig checks the level, the sampling caps and the rate limit.
+func (l *LoggingConfig) Validate() error {
+	if _, err := zapcore.ParseLevel(l.Level); err != nil {
+		return fmt.Errorf("invalid log level %q", l.Level)
+	}
+	if l.SamplingEnable {
+		if l.SamplingTick <= 0 {
+			return fmt.Errorf("log sampling tick must be positive")
+		}
+		caps := []int{
+			l.SamplingDebugInitial, l.SamplingDebugThereafter,
+			l.SamplingInfoInitial, l.SamplingInfoThereafter,
+			l.SamplingWarnInitial, l.SamplingWarnThereafter,
+		}
+		for _, limit := range caps {
+			if limit < 0 {
+				return fmt.Errorf("log sampling caps cannot be negative")
+			}
+		}
+	}
+	if l.RateLimitInterval < 0 {
+		return fmt.Errorf("log rate limit interval cannot be negative")
+	}
+	if l.RateLimitInterval > 0 && l.RateLimitBurst <= 0 {
+		return fmt.Errorf("log rate limit burst must be a positive integer")
+	}
+
+	if l.AuditEnable && len(l.AuditOutputPaths) == 0 {
+		return fmt.Errorf("LOG_AUDIT_OUTPUT_PATHS is required when the audit log is enabled")
+	}
+
+	switch l.SinkType {
+	case "":
+		return nil
+	case LogSinkLoki, LogSinkOTLP:
+	default:
+		return fmt.Errorf("invalid LOG_SINK_TYPE: %q, must be '%s' or '%s'", l.SinkType, LogSinkLoki, LogSinkOTLP)
+	}
+	sinkURL, err := url.Parse(l.SinkURL)
+	if err != nil || (sinkURL.Scheme != "http" && sinkURL.Scheme != "https") || sinkURL.Host == "" {
+		return fmt.Errorf("log sink URL must be an absolute http(s) URL")
+	}
+	if l.SinkBufferSize <= 0 || l.SinkBatchSize <= 0 {
+		return fmt.Errorf("log sink buffer and batch sizes must be positive integers")
+	}
+	if l.SinkBatchSize > l.SinkBufferSize {
+		return fmt.Errorf("log sink batch size cannot exceed the buffer size")
+	}
+	if l.SinkFlushInterval <= 0 || l.SinkTimeout <= 0 {
+		return fmt.Errorf("log sink flush interval and timeout must be positive")
+	}
 	return nil
 }
 
 // Validate methods to other config structs as needed
 func (p *PostgresConfig) Validate() error {
+	for i, dsn := range p.ReplicaDSNs {
+		if strings.TrimSpace(dsn) == "" {
+			return fmt.Errorf("postgres replica DSN #%d is empty", i+1)
+		}
+	}
+	if len(p.ReplicaDSNs) > 0 && p.ReplicaHealthCheckInterval <= 0 {
+		return fmt.Errorf("postgres replica health check interval must be positive")
+	}
+	if p.QueryTimeout < 0 {
+		return fmt.Errorf("postgres query timeout cannot be negative")
+	}
 	return nil
 }
 
@@ -249,6 +1065,21 @@ func (r *RedisConfig) Validate() error {
 	if r.MaxConnAge < 0 {
 		return fmt.Errorf("redis max connection age cannot be negative")
 	}
+	if r.RepositoryCacheTTL < 0 {
+		return fmt.Errorf("redis repository cache TTL cannot be negative")
+	}
+	if r.ResponseCacheTTL < 0 {
+		return fmt.Errorf("redis response cache TTL cannot be negative")
+	}
+	if r.LocalCacheSize < 0 {
+		return fmt.Errorf("redis local cache size cannot be negative")
+	}
+	if r.LocalCacheSize > 0 && r.LocalCacheTTL <= 0 {
+		return fmt.Errorf("redis local cache TTL must be positive when the local cache is enabled")
+	}
+	if r.WarmMaxMonitors < 0 {
+		return fmt.Errorf("redis warm-up max monitors cannot be negative")
+	}
 	return nil
 }
 
@@ -263,6 +1094,517 @@ func (ch *ClickHouseConfig) Validate() error {
 	if ch.Database == "" {
 		return fmt.Errorf("clickhouse database is required when enabled")
 	}
+	if ch.WriteBatchSize <= 0 {
+		return fmt.Errorf("clickhouse write batch size must be a positive integer")
+	}
+	if ch.WriteBufferSize < ch.WriteBatchSize {
+		return fmt.Errorf("clickhouse write buffer size cannot be smaller than write batch size")
+	}
+	if ch.WriteFlushInterval <= 0 {
+		return fmt.Errorf("clickhouse write flush interval must be positive")
+	}
+	if ch.WriteMaxRetries < 0 {
+		return fmt.Errorf("clickhouse write max retries cannot be negative")
+	}
+	if ch.QueryTimeout < 0 {
+		return fmt.Errorf("clickhouse query timeout cannot be negative")
+	}
+	return nil
+}
+
+// Validate SMSConfig checks that an enabled SMS service has at least one fully configured provider.
+func (s *SMSConfig) Validate() error {
+	if !s.Twilio.Enable && !s.Vonage.Enable {
+		return fmt.Errorf("at least one of SMS_TWILIO_ENABLE or SMS_VONAGE_ENABLE is required when SMS is enabled")
+	}
+	if s.Twilio.Enable && (s.Twilio.AccountSID == "" || s.Twilio.AuthToken == "" || s.Twilio.FromNumber == "") {
+		return fmt.Errorf("twilio account sid, auth token and from number are required when twilio is enabled")
+	}
+	if s.Vonage.Enable && (s.Vonage.APIKey == "" || s.Vonage.APISecret == "" || s.Vonage.FromNumber == "") {
+		return fmt.Errorf("vonage api key, api secret and from number are required when vonage is enabled")
+	}
+	return nil
+}
+
+// Validate CaptchaConfig checks if CAPTCHA configuration is valid when enabled.
+func (cc *CaptchaConfig) Validate() error {
+	switch cc.Provider {
+	case "turnstile", "recaptcha":
+	default:
+		return fmt.Errorf("captcha provider %q is not supported, must be 'turnstile' or 'recaptcha'", cc.Provider)
+	}
+	if cc.SecretKey == "" {
+		return fmt.Errorf("captcha secret key is required when enabled")
+	}
+	if cc.MinScore < 0 || cc.MinScore > 1 {
+		return fmt.Errorf("captcha min score must be between 0 and 1")
+	}
+	if cc.Timeout <= 0 {
+		return fmt.Errorf("captcha timeout must be positive")
+	}
+	return nil
+}
+
+// Validate CORSConfig checks that every allowed origin is a bare scheme://host[:port].
+func (cc *CORSConfig) Validate() error {
+	for _, origin := range cc.AllowedOrigins {
+		if origin == "*" {
+			if cc.AllowCredentials {
+				return fmt.Errorf("wildcard origin cannot be combined with credentials")
+			}
+			continue
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			return fmt.Errorf("invalid allowed origin %q, expected scheme://host[:port]", origin)
+		}
+	}
+	if cc.MaxAge < 0 {
+		return fmt.Errorf("cors max age cannot be negative")
+	}
+	return nil
+}
+
+// Keys returns the application keys, the one signing new tokens and URLs first.
+func (a *AppConfig) Keys() []string {
+	return append([]string{a.Key}, a.PreviousKeys...)
+}
+
+// CORSOrigins returns the configured browser origins, falling back to the single frontend URL.
+func (c *Config) CORSOrigins() []string {
+	origins := make([]string, 0, len(c.CORS.AllowedOrigins))
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	if len(origins) == 0 && c.App.FrontendURL != "" {
+		origins = append(origins, strings.TrimRight(c.App.FrontendURL, "/"))
+	}
+	return origins
+}
+
+// ReportUnsubscribeURL returns the page uptime report unsubscribe links point to, falling
+// back to the unsubscribe page of the frontend.
+func (c *Config) ReportUnsubscribeURL() string {
+	if c.Reports.UnsubscribeURL != "" {
+		return c.Reports.UnsubscribeURL
+	}
+	if c.App.FrontendURL != "" {
+		return strings.TrimRight(c.App.FrontendURL, "/") + "/reports/unsubscribe"
+	}
+	return ""
+}
+
+// Validate AnalyticsConfig checks batching limits.
+func (a *AnalyticsConfig) Validate() error {
+	if a.BatchSize <= 0 {
+		return fmt.Errorf("analytics batch size must be a positive integer")
+	}
+	if a.BufferSize < a.BatchSize {
+		return fmt.Errorf("analytics buffer size cannot be smaller than batch size")
+	}
+	if a.FlushInterval <= 0 {
+		return fmt.Errorf("analytics flush interval must be positive")
+	}
+	return nil
+}
+
+// Validate RetentionConfig checks the schedule and retention windows.
+func (r *RetentionConfig) Validate() error {
+	if r.Interval <= 0 {
+		return fmt.Errorf("retention interval must be positive")
+	}
+	if r.BatchSize <= 0 {
+		return fmt.Errorf("retention batch size must be a positive integer")
+	}
+	if r.UsersWindow < 0 || r.OrganizationsWindow < 0 || r.MonitorsWindow < 0 {
+		return fmt.Errorf("retention windows cannot be negative")
+	}
+	if r.DataInterval <= 0 {
+		return fmt.Errorf("retention data interval must be positive")
+	}
+	if r.CheckResultsWindow < 0 || r.RollupsWindow < 0 || r.IncidentsWindow < 0 || r.AuditLogsWindow < 0 || r.ActivityWindow < 0 {
+		return fmt.Errorf("retention data windows cannot be negative")
+	}
+	return nil
+}
+
+// Validate StorageCleanupConfig checks the schedule and grace period.
+func (s *StorageCleanupConfig) Validate() error {
+	if s.Interval <= 0 {
+		return fmt.Errorf("storage cleanup interval must be positive")
+	}
+	if s.GracePeriod < time.Hour {
+		return fmt.Errorf("storage cleanup grace period must be at least 1h")
+	}
+	return nil
+}
+
+// Validate ReportsConfig checks the schedule and the SLA target.
+func (r *ReportsConfig) Validate() error {
+	if r.Interval <= 0 {
+		return fmt.Errorf("reports interval must be positive")
+	}
+	if r.BatchSize <= 0 {
+		return fmt.Errorf("reports batch size must be a positive integer")
+	}
+	if r.SLATarget <= 0 || r.SLATarget > 100 {
+		return fmt.Errorf("reports SLA target must be a percentage above 0 and at most 100")
+	}
+	return nil
+}
+
+// Validate ReportFilesConfig checks the schedule, the lifetimes and the request limits.
+func (r *ReportFilesConfig) Validate() error {
+	if r.Interval <= 0 {
+		return fmt.Errorf("report files interval must be positive")
+	}
+	if r.BatchSize <= 0 {
+		return fmt.Errorf("report files batch size must be a positive integer")
+	}
+	if r.MaxAttempts <= 0 {
+		return fmt.Errorf("report files max attempts must be a positive integer")
+	}
+	if r.TTL <= 0 || r.LinkTTL <= 0 {
+		return fmt.Errorf("report files TTL and link TTL must be positive")
+	}
+	if r.MaxDays <= 0 {
+		return fmt.Errorf("report files max days must be a positive integer")
+	}
+	if r.MaxPending <= 0 {
+		return fmt.Errorf("report files max pending must be a positive integer")
+	}
+	return nil
+}
+
+// Validate SLAConfig checks the schedule and the alerting thresholds.
+func (s *SLAConfig) Validate() error {
+	if s.Interval <= 0 {
+		return fmt.Errorf("sla interval must be positive")
+	}
+	if s.BatchSize <= 0 {
+		return fmt.Errorf("sla batch size must be a positive integer")
+	}
+	if s.AtRiskBudget <= 0 || s.AtRiskBudget >= 1 {
+		return fmt.Errorf("sla at-risk budget must be a fraction between 0 and 1")
+	}
+	if s.FastBurnRate <= 1 {
+		return fmt.Errorf("sla fast burn rate must be above 1")
+	}
+	if s.FastBurnWindow < time.Minute {
+		return fmt.Errorf("sla fast burn window must be at least one minute")
+	}
+	if _, err := s.ParseBurnAlerts(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseBurnAlerts parses the burn alerts, such as "2%/1h" for 2% of the error budget spent
+// within an hour, rejecting malformed entries, shares outside (0%, 100%], windows shorter
+// than 12 minutes or longer than 7 days and windows listed twice.
+func (s *SLAConfig) ParseBurnAlerts() ([]BurnAlert, error) {
+	alerts := make([]BurnAlert, 0, len(s.BurnAlerts))
+	seen := make(map[time.Duration]bool, len(s.BurnAlerts))
+	for _, entry := range s.BurnAlerts {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		share, window, ok := strings.Cut(entry, "/")
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(share), "%"), 64)
+		if !ok || err != nil || !strings.HasSuffix(strings.TrimSpace(share), "%") {
+			return nil, fmt.Errorf("sla burn alert %q must be \"share%%/window\", e.g. \"2%%/1h\"", entry)
+		}
+		if percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("sla burn alert %q must spend between 0%% and 100%% of the budget", entry)
+		}
+		alert := BurnAlert{Budget: percent / 100}
+		if alert.Window, err = time.ParseDuration(strings.TrimSpace(window)); err != nil {
+			return nil, fmt.Errorf("sla burn alert %q has an invalid window", entry)
+		}
+		if alert.Window < minBurnAlertWindow || alert.Window > maxBurnAlertWindow {
+			return nil, fmt.Errorf("sla burn alert %q must have a window between %s and %s", entry, minBurnAlertWindow, maxBurnAlertWindow)
+		}
+
+		if seen[alert.Window] {
+			return nil, fmt.Errorf("sla burn alert window %s is listed twice", alert.Window)
+		}
+		seen[alert.Window] = true
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
+
+// Validate JobsConfig checks the worker count and timeout. Schedules are parsed when the
+// jobs are registered.
+func (j *JobsConfig) Validate() error {
+	if j.Workers <= 0 {
+		return fmt.Errorf("jobs workers must be a positive integer")
+	}
+	if j.Timeout < 0 {
+		return fmt.Errorf("jobs timeout cannot be negative")
+	}
+	return nil
+}
+
+// ParseRoutes parses the deprecated routes, rejecting malformed entries, sunsets that do not
+// come after the deprecation and routes listed twice.
+func (d *DeprecationConfig) ParseRoutes() ([]DeprecatedRoute, error) {
+	routes := make([]DeprecatedRoute, 0, len(d.Routes))
+	seen := make(map[string]bool, len(d.Routes))
+	for _, entry := range d.Routes {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.Split(entry, "|")
+		if len(fields) < 2 || len(fields) > 4 {
+			return nil, fmt.Errorf("deprecated route %q must be \"METHOD /path|deprecated[|sunset[|link]]\"", entry)
+		}
+		method, routePath, ok := strings.Cut(strings.TrimSpace(fields[0]), " ")
+		routePath = strings.TrimSpace(routePath)
+		if !ok || method == "" || !strings.HasPrefix(routePath, "/") {
+			return nil, fmt.Errorf("deprecated route %q must start with a method and a path", entry)
+		}
+
+		route := DeprecatedRoute{Method: strings.ToUpper(method), Path: routePath}
+		var err error
+		if route.Deprecated, err = time.Parse(time.DateOnly, strings.TrimSpace(fields[1])); err != nil {
+			return nil, fmt.Errorf("deprecated route %q has an invalid deprecation date", entry)
+		}
+		if len(fields) > 2 && strings.TrimSpace(fields[2]) != "" {
+			if route.Sunset, err = time.Parse(time.DateOnly, strings.TrimSpace(fields[2])); err != nil {
+				return nil, fmt.Errorf("deprecated route %q has an invalid sunset date", entry)
+			}
+			if !route.Sunset.After(route.Deprecated) {
+				return nil, fmt.Errorf("deprecated route %q must sunset after its deprecation", entry)
+			}
+		}
+		if len(fields) > 3 {
+			route.Link = strings.TrimSpace(fields[3])
+			if u, err := url.Parse(route.Link); route.Link != "" && (err != nil || u.Scheme == "" || u.Host == "") {
+				return nil, fmt.Errorf("deprecated route %q has an invalid link", entry)
+			}
+		}
+
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			return nil, fmt.Errorf("route %q is deprecated twice", key)
+		}
+		seen[key] = true
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// ParseRouteTimeouts parses the route timeouts, rejecting malformed entries, timeouts that are
+// not positive or exceed the write timeout and routes listed twice.
+func (s *ServerConfig) ParseRouteTimeouts() ([]RouteTimeout, error) {
+	routes := make([]RouteTimeout, 0, len(s.RouteTimeouts))
+	seen := make(map[string]bool, len(s.RouteTimeouts))
+	for _, entry := range s.RouteTimeouts {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, timeout, ok := strings.Cut(entry, "|")
+		if !ok {
+			return nil, fmt.Errorf("route timeout %q must be \"METHOD /path|timeout\"", entry)
+		}
+		method, routePath, ok := strings.Cut(strings.TrimSpace(route), " ")
+		routePath = strings.TrimSpace(routePath)
+		if !ok || method == "" || !strings.HasPrefix(routePath, "/") {
+			return nil, fmt.Errorf("route timeout %q must start with a method and a path", entry)
+		}
+
+		parsed := RouteTimeout{Method: strings.ToUpper(method), Path: routePath}
+		var err error
+		if parsed.Timeout, err = time.ParseDuration(strings.TrimSpace(timeout)); err != nil || parsed.Timeout <= 0 {
+			return nil, fmt.Errorf("route timeout %q must have a positive duration", entry)
+		}
+		if s.WriteTimeout > 0 && parsed.Timeout > s.WriteTimeout {
+			return nil, fmt.Errorf("route timeout %q cannot exceed write timeout", entry)
+		}
+
+		key := parsed.Method + " " + parsed.Path
+		if seen[key] {
+			return nil, fmt.Errorf("route %q has two timeouts", key)
+		}
+		seen[key] = true
+		routes = append(routes, parsed)
+	}
+	return routes, nil
+}
+
+// Validate OutboxConfig checks the polling schedule and retry policy.
+func (o *OutboxConfig) Validate() error {
+	if o.PollInterval <= 0 {
+		return fmt.Errorf("outbox poll interval must be positive")
+	}
+	if o.BatchSize <= 0 {
+		return fmt.Errorf("outbox batch size must be a positive integer")
+	}
+	if o.MaxAttempts <= 0 {
+		return fmt.Errorf("outbox max attempts must be a positive integer")
+	}
+	if o.RetryBackoff <= 0 || o.MaxBackoff < o.RetryBackoff {
+		return fmt.Errorf("outbox retry backoff must be positive and not exceed the max backoff")
+	}
+	if o.Retention < 0 {
+		return fmt.Errorf("outbox retention cannot be negative")
+	}
+	return nil
+}
+
+// Validate QueueConfig checks the stream names, batching and retry policy.
+func (q *QueueConfig) Validate() error {
+	if q.Stream == "" || q.Group == "" {
+		return fmt.Errorf("queue stream and group are required")
+	}
+	if q.BatchSize <= 0 {
+		return fmt.Errorf("queue batch size must be a positive integer")
+	}
+	if q.Block <= 0 || q.ClaimIdle <= 0 || q.HandlerTimeout <= 0 {
+		return fmt.Errorf("queue block, claim idle and handler timeout must be positive")
+	}
+	if q.ClaimIdle <= q.HandlerTimeout {
+		return fmt.Errorf("queue claim idle must exceed the handler timeout so running jobs are not claimed")
+	}
+	if q.MaxAttempts <= 0 {
+		return fmt.Errorf("queue max attempts must be a positive integer")
+	}
+	if q.MaxLen < 0 {
+		return fmt.Errorf("queue max length cannot be negative")
+	}
+	return nil
+}
+
+// Validate MetricsConfig checks the endpoint path.
+func (m *MetricsConfig) Validate() error {
+	if !strings.HasPrefix(m.Path, "/") {
+		return fmt.Errorf("metrics path must start with /")
+	}
+	return nil
+}
+
+// Validate AdminConfig checks that a configured token is long enough to resist guessing, and
+// the alert recipients and threshold.
+func (a *AdminConfig) Validate() error {
+	if a.Token != "" && len(a.Token) < 32 {
+		return fmt.Errorf("admin token must be at least 32 characters")
+	}
+	for _, address := range a.AlertEmails {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid admin alert email %q", address)
+		}
+	}
+	if a.AlertThreshold <= 0 {
+		return fmt.Errorf("admin alert threshold must be a positive integer")
+	}
+	return nil
+}
+
+// Validate CheckResultsConfig checks the partition schedule and retention window.
+func (r *CheckResultsConfig) Validate() error {
+	if r.PartitionsAhead < 1 {
+		return fmt.Errorf("check results partitions ahead must be at least 1")
+	}
+	if r.MaintenanceInterval <= 0 {
+		return fmt.Errorf("check results maintenance interval must be positive")
+	}
+	// Partitions span a month, so a shorter window would drop the partition being written to
+	if r.Retention < 0 || (r.Retention > 0 && r.Retention < 31*24*time.Hour) {
+		return fmt.Errorf("check results retention must be zero or at least 744h")
+	}
+	return nil
+}
+
+// Validate URLSignerConfig checks parameter names and link lifetimes.
+func (u *URLSignerConfig) Validate() error {
+	if u.ExpiresParam == "" || u.SignatureParam == "" {
+		return fmt.Errorf("url signer parameter names cannot be empty")
+	}
+	if len(u.PreviousSecrets) > 0 && u.Secret == "" {
+		return fmt.Errorf("url signer previous secrets require a secret, use APP_PREVIOUS_KEYS to rotate the application key")
+	}
+	for _, secret := range u.PreviousSecrets {
+		if secret == "" || secret == u.Secret {
+			return fmt.Errorf("url signer previous secrets must be non-empty and differ from the secret")
+		}
+	}
+	if u.ExpiresParam == u.SignatureParam {
+		return fmt.Errorf("url signer expires and signature parameters must differ")
+	}
+	if u.ClockSkewGrace < 0 {
+		return fmt.Errorf("url signer clock skew grace cannot be negative")
+	}
+	if u.DefaultTTL <= 0 || u.MaxTTL <= 0 {
+		return fmt.Errorf("url signer ttl values must be positive")
+	}
+	if u.DefaultTTL > u.MaxTTL {
+		return fmt.Errorf("url signer default ttl cannot exceed max ttl")
+	}
+	return nil
+}
+
+// Validate EncryptionConfig checks the data keys, and the KMS unwrapping them when set.
+func (e *EncryptionConfig) Validate() error {
+	if len(e.PreviousKeys) > 0 && e.Key == "" {
+		return fmt.Errorf("encryption previous keys require a key, use APP_PREVIOUS_KEYS to rotate the application key")
+	}
+	for _, key := range e.PreviousKeys {
+		if key == "" || key == e.Key {
+			return fmt.Errorf("encryption previous keys must be non-empty and differ from the key")
+		}
+	}
+
+	if e.KMSURL == "" {
+		for _, key := range append([]string{e.Key}, e.PreviousKeys...) {
+			if key == "" {
+				continue
+			}
+			if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 32 {
+				return fmt.Errorf("encryption keys must be 32 bytes encoded in base64")
+			}
+		}
+		return nil
+	}
+
+	if e.Key == "" {
+		return fmt.Errorf("encryption key is required with a KMS, as a data key wrapped by it")
+	}
+	if e.KMSToken == "" || e.KMSKeyName == "" {
+		return fmt.Errorf("encryption KMS token and key name are required with a KMS url")
+	}
+	if u, err := url.Parse(e.KMSURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("encryption KMS url must be an absolute http(s) url")
+	}
+	if e.KMSTimeout <= 0 {
+		return fmt.Errorf("encryption KMS timeout must be positive")
+	}
+	return nil
+}
+
+// Validate GRPCConfig checks if gRPC configuration is valid when enabled.
+func (g *GRPCConfig) Validate() error {
+	if g.Port == "" {
+		return fmt.Errorf("grpc port is required when enabled")
+	}
+	if g.AuthToken == "" {
+		return fmt.Errorf("grpc auth token is required when enabled")
+	}
+	if g.MaxRecvMsgSize <= 0 {
+		return fmt.Errorf("grpc max receive message size must be a positive integer")
+	}
+	if g.HealthCheckInterval <= 0 {
+		return fmt.Errorf("grpc health check interval must be positive")
+	}
 	return nil
 }
 
@@ -270,8 +1612,8 @@ func (ch *ClickHouseConfig) Validate() error {
 func (p *PostgresConfig) String() string {
 	redacted := *p
 	redacted.Password = "[REDACTED]"
-	return fmt.Sprintf("{Enable:%t Host:%s User:%s Name:%s Port:%d SSLMode:%s}",
-		redacted.Enable, redacted.Host, redacted.User, redacted.Name, redacted.Port, redacted.SSLMode)
+	return fmt.Sprintf("{Enable:%t Host:%s User:%s Name:%s Port:%d SSLMode:%s Replicas:%d}",
+		redacted.Enable, redacted.Host, redacted.User, redacted.Name, redacted.Port, redacted.SSLMode, len(redacted.ReplicaDSNs))
 }
 
 // String implements the fmt.Stringer interface to provide a redacted version of RedisConfig.
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the variable holding the path of the optional configuration file
+const ConfigFileEnv = "APP_CONFIG_FILE"
+
+// readConfigFile reads the YAML, TOML or JSON configuration file at path and returns the
+// environment variables its settings stand for. Sections and keys are the lowercase
+// names of the variables they set, so
+//
+//	log:
+//	  level: debug
+//	cors:
+//	  allowed_origins: [https://app.example.com]
+//
+// sets LOG_LEVEL and CORS_ALLOWED_ORIGINS. Lists and maps become the comma-separated
+// values envconfig expects.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var settings map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &settings)
+	case ".toml":
+		err = toml.Unmarshal(data, &settings)
+	case ".json":
+		err = json.Unmarshal(data, &settings)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s, expected .yaml, .yml, .toml or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	env := make(map[string]string)
+	if err := flattenSettings(settings, reflect.TypeOf(Config{}), "", "", env); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if _, ok := env["APP_ENV"]; ok {
+		return nil, fmt.Errorf("invalid config file %s: app.env selects the .env files and must be set with APP_ENV", path)
+	}
+	return env, nil
+}
+
+// flattenSettings adds the variables of settings to env, matching keys to the envconfig
+// tags of the struct type t. prefix is the variable prefix of t and path its location in
+// the file, for errors.
+func flattenSettings(settings map[string]any, t reflect.Type, prefix, path string, env map[string]string) error {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag := field.Tag.Get("envconfig"); tag != "" {
+			fields[strings.ToLower(tag)] = field
+		}
+	}
+
+	for key, value := range settings {
+		location := key
+		if path != "" {
+			location = path + "." + key
+		}
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("unknown setting %s", location)
+		}
+		if value == nil {
+			// An empty key or section leaves its defaults alone
+			continue
+		}
+		name := field.Tag.Get("envconfig")
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			section, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s must be a section", location)
+			}
+			if err := flattenSettings(section, field.Type, name, location, env); err != nil {
+				return err
+			}
+			continue
+		}
+
+		formatted, err := formatSetting(value, field.Type.Kind())
+		if err != nil {
+			return fmt.Errorf("%s %w", location, err)
+		}
+		env[name] = formatted
+	}
+	return nil
+}
+
+// formatSetting formats value the way envconfig parses a field of kind
+func formatSetting(value any, kind reflect.Kind) (string, error) {
+	switch kind {
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if !ok {
+			return "", fmt.Errorf("must be a list")
+		}
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			part, err := formatItem(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ","), nil
+	case reflect.Map:
+		entries, ok := value.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("must be a map")
+		}
+		parts := make([]string, 0, len(entries))
+		for key, entry := range entries {
+			part, err := formatItem(entry)
+			if err != nil {
+				return "", err
+			}
+			if strings.ContainsAny(key, ",:") {
+				return "", fmt.Errorf("key %q cannot contain ',' or ':'", key)
+			}
+			parts = append(parts, key+":"+part)
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, ","), nil
+	default:
+		return formatScalar(value)
+	}
+}
+
+// formatScalar formats a single value
+func formatScalar(value any) (string, error) {
+	switch value.(type) {
+	case map[string]any, []any:
+		return "", fmt.Errorf("must be a single value")
+	}
+	return fmt.Sprint(value), nil
+}
+
+// formatItem formats an item of a list or map, which cannot contain the separator
+func formatItem(value any) (string, error) {
+	formatted, err := formatScalar(value)
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(formatted, ",") {
+		return "", fmt.Errorf("value %q cannot contain ','", formatted)
+	}
+	return formatted, nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// redactedSetting replaces the value of a secret setting
+const redactedSetting = "[REDACTED]"
+
+// Setting is one effective setting, named by its environment variable
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// Settings returns every setting of c in declaration order, formatted the way envconfig
+// reads them. Fields tagged secret:"true" are redacted when set, as are the passwords of
+// URLs, so the result is safe to print.
+func (c *Config) Settings() []Setting {
+	var settings []Setting
+	collectSettings(reflect.ValueOf(*c), "", &settings)
+	return settings
+}
+
+// collectSettings appends the settings of the struct v, whose variables start with prefix
+func collectSettings(v reflect.Value, prefix string, settings *[]Setting) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("envconfig")
+		if tag == "" {
+			continue
+		}
+		name := tag
+		if prefix != "" {
+			name = prefix + "_" + tag
+		}
+
+		value := v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			collectSettings(value, name, settings)
+			continue
+		}
+
+		formatted := formatValue(value)
+		if field.Tag.Get("secret") == "true" && !value.IsZero() {
+			formatted = redactedSetting
+		}
+		*settings = append(*settings, Setting{Name: name, Value: formatted})
+	}
+}
+
+// formatValue formats v as envconfig expects it, masking URL passwords
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		entries := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			entries = append(entries, fmt.Sprint(key.Interface())+":"+formatValue(v.MapIndex(key)))
+		}
+		sort.Strings(entries)
+		return strings.Join(entries, ",")
+	case reflect.String:
+		return redactURLPassword(v.String())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// redactURLPassword masks the password of value when it is a URL carrying one
+func redactURLPassword(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+	return u.Redacted()
+}
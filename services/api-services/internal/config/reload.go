@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ChangeHandler is notified after a reload changed at least one reloadable setting. It
+// must not modify either configuration.
+type ChangeHandler func(previous, current *Config)
+
+// ReloadResult describes what a reload changed
+type ReloadResult struct {
+	// Changed lists the environment variables of the reloadable settings that changed
+	Changed []string
+	// RestartRequired reports that settings only read at startup changed too; they keep
+	// their current value until the application restarts
+	RestartRequired bool
+}
+
+// Reloader re-reads the .env files and the config file and applies the settings that are safe to change
+// while running: the log level and log rate limit, and the CORS policy. Every other
+// setting keeps the value the application started with.
+type Reloader struct {
+	current atomic.Pointer[Config]
+
+	mu       sync.Mutex
+	handlers []ChangeHandler
+	modTimes map[string]time.Time
+}
+
+// NewReloader creates a reloader starting from initial
+func NewReloader(initial *Config) *Reloader {
+	r := &Reloader{}
+	r.current.Store(initial)
+	r.modTimes = r.readModTimes()
+	return r
+}
+
+// Current returns the configuration with the latest reloaded settings
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// OnChange registers handler to be notified of every reload that changes a setting
+func (r *Reloader) OnChange(handler ChangeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers = append(r.handlers, handler)
+}
+
+// Reload reads the .env files and the config file again and applies the reloadable settings that changed,
+// notifying the registered handlers. An invalid configuration is rejected as a whole.
+func (r *Reloader) Reload() (ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.current.Load()
+	r.modTimes = r.readModTimes()
+	fresh, err := reloadConfig(previous.App.Mode)
+	if err != nil {
+		return ReloadResult{}, fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	next := *previous
+	result := ReloadResult{Changed: applyReloadable(&next, fresh)}
+	result.RestartRequired = !reflect.DeepEqual(next, *fresh)
+	if len(result.Changed) == 0 {
+		return result, nil
+	}
+
+	r.current.Store(&next)
+	for _, handler := range r.handlers {
+		handler(previous, &next)
+	}
+	return result, nil
+}
+
+// Modified reports whether one of the .env files or the config file changed since the
+// last reload or check
+func (r *Reloader) Modified() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTimes := r.readModTimes()
+	modified := !maps.Equal(modTimes, r.modTimes)
+	r.modTimes = modTimes
+	return modified
+}
+
+// readModTimes returns the modification time of each .env file and the config file that exist
+func (r *Reloader) readModTimes() map[string]time.Time {
+	paths := []string{".env", fmt.Sprintf(".env.%s", r.current.Load().App.Mode)}
+	if configFile := os.Getenv(ConfigFileEnv); configFile != "" {
+		paths = append(paths, configFile)
+	}
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+// applyReloadable copies the reloadable settings of src that differ into dst and returns
+// their environment variables
+func applyReloadable(dst, src *Config) []string {
+	var changed []string
+	if dst.Logging.Level != src.Logging.Level {
+		dst.Logging.Level = src.Logging.Level
+		changed = append(changed, "LOG_LEVEL")
+	}
+	if dst.Logging.RateLimitInterval != src.Logging.RateLimitInterval {
+		dst.Logging.RateLimitInterval = src.Logging.RateLimitInterval
+		changed = append(changed, "LOG_RATE_LIMIT_INTERVAL")
+	}
+	if dst.Logging.RateLimitBurst != src.Logging.RateLimitBurst {
+		dst.Logging.RateLimitBurst = src.Logging.RateLimitBurst
+		changed = append(changed, "LOG_RATE_LIMIT_BURST")
+	}
+	if !slices.Equal(dst.CORS.AllowedOrigins, src.CORS.AllowedOrigins) {
+		dst.CORS.AllowedOrigins = src.CORS.AllowedOrigins
+		changed = append(changed, "CORS_ALLOWED_ORIGINS")
+	}
+	if dst.CORS.AllowCredentials != src.CORS.AllowCredentials {
+		dst.CORS.AllowCredentials = src.CORS.AllowCredentials
+		changed = append(changed, "CORS_ALLOW_CREDENTIALS")
+	}
+	if dst.CORS.MaxAge != src.CORS.MaxAge {
+		dst.CORS.MaxAge = src.CORS.MaxAge
+		changed = append(changed, "CORS_MAX_AGE")
+	}
+	return changed
+}
@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// ErrBatchWriterClosed is returned when writing to a writer that has been closed
+var ErrBatchWriterClosed = errors.New("batch writer is closed")
+
+// BatchWriterOptions configures buffering, flushing and retries for a BatchWriter
+type BatchWriterOptions struct {
+	// Name identifies the writer in logs, usually the destination table
+	Name string
+	// BatchSize flushes as soon as this many rows are buffered
+	BatchSize int
+	// FlushInterval flushes a partial batch after this long
+	FlushInterval time.Duration
+	// BufferSize bounds the rows queued in memory; Write blocks once it is full
+	BufferSize int
+	// MaxRetries is the number of extra attempts for a failed batch before it is dropped
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled on each attempt
+	RetryBackoff time.Duration
+	// WriteTimeout bounds a single insert attempt
+	WriteTimeout time.Duration
+}
+
+// DefaultBatchWriterOptions provides defaults suited to ClickHouse inserts, which Postgres
+// batches of the same size handle as well
+func DefaultBatchWriterOptions() BatchWriterOptions {
+	return BatchWriterOptions{
+		BatchSize:     1000,
+		FlushInterval: 2 * time.Second,
+		BufferSize:    10000,
+		MaxRetries:    3,
+		RetryBackoff:  500 * time.Millisecond,
+		WriteTimeout:  10 * time.Second,
+	}
+}
+
+// ClickHouseWriterOptions builds writer options for a ClickHouse table from the shared write settings
+func ClickHouseWriterOptions(name string, cfg config.ClickHouseConfig) BatchWriterOptions {
+	opts := DefaultBatchWriterOptions()
+	opts.Name = name
+	opts.BatchSize = cfg.WriteBatchSize
+	opts.FlushInterval = cfg.WriteFlushInterval
+	opts.BufferSize = cfg.WriteBufferSize
+	opts.MaxRetries = cfg.WriteMaxRetries
+	return opts
+}
+
+// BatchWriterStats reports counters for a BatchWriter
+type BatchWriterStats struct {
+	Written int64
+	Failed  int64
+	Pending int
+}
+
+// BatchWriter buffers rows in memory and inserts them in batches when either the batch
+// size or the flush interval is reached. ClickHouse handles few large inserts far better
+// than many small ones, so every ClickHouse write should go through a writer; Postgres
+// tables written at a high rate, such as the check result fallback, use one as well.
+//
+// Write applies backpressure by blocking while the buffer is full; TryWrite never blocks
+// and reports whether the row was accepted. Batches that keep failing after MaxRetries
+// are logged and dropped so memory stays bounded.
+type BatchWriter[T any] struct {
+	db      *gorm.DB
+	store   string
+	options BatchWriterOptions
+
+	rows    chan T
+	written atomic.Int64
+	failed  atomic.Int64
+
+	mu       sync.RWMutex
+	closed   bool
+	closing  chan struct{}
+	inflight sync.WaitGroup
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewBatchWriter creates a writer inserting into db. Call Start before writing and Close on shutdown.
+func NewBatchWriter[T any](db *gorm.DB, opts BatchWriterOptions) *BatchWriter[T] {
+	defaults := DefaultBatchWriterOptions()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaults.BatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaults.FlushInterval
+	}
+	if opts.BufferSize < opts.BatchSize {
+		opts.BufferSize = opts.BatchSize
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaults.RetryBackoff
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = defaults.WriteTimeout
+	}
+
+	// The store names the database in logs, as writers insert into ClickHouse or Postgres
+	store := "unknown"
+	if db != nil && db.Dialector != nil {
+		store = db.Dialector.Name()
+	}
+
+	return &BatchWriter[T]{
+		db:      db,
+		store:   store,
+		options: opts,
+		rows:    make(chan T, opts.BufferSize),
+		closing: make(chan struct{}),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start launches the background flusher
+func (w *BatchWriter[T]) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Write queues rows for insertion, blocking while the buffer is full until ctx is done.
+// A nil error means the rows were buffered, not that they are already stored.
+func (w *BatchWriter[T]) Write(ctx context.Context, rows ...T) error {
+	if !w.acquire() {
+		return ErrBatchWriterClosed
+	}
+	defer w.inflight.Done()
+
+	for i, row := range rows {
+		select {
+		case w.rows <- row:
+		case <-w.closing:
+			return fmt.Errorf("%s writer buffered %d of %d rows: %w", w.options.Name, i, len(rows), ErrBatchWriterClosed)
+		case <-ctx.Done():
+			return fmt.Errorf("%s writer buffered %d of %d rows: %w", w.options.Name, i, len(rows), ctx.Err())
+		}
+	}
+	return nil
+}
+
+// TryWrite queues a row without blocking and reports whether it was accepted
+func (w *BatchWriter[T]) TryWrite(row T) bool {
+	if !w.acquire() {
+		return false
+	}
+	defer w.inflight.Done()
+
+	select {
+	case w.rows <- row:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquire registers an in-flight write, failing once the writer is closed
+func (w *BatchWriter[T]) acquire() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return false
+	}
+	w.inflight.Add(1)
+	return true
+}
+
+// Stats returns the number of rows written, dropped after failed retries and still pending
+func (w *BatchWriter[T]) Stats() BatchWriterStats {
+	return BatchWriterStats{
+		Written: w.written.Load(),
+		Failed:  w.failed.Load(),
+		Pending: len(w.rows),
+	}
+}
+
+// Close stops accepting rows and flushes everything buffered, or gives up when ctx expires
+func (w *BatchWriter[T]) Close(ctx context.Context) error {
+	w.mu.Lock()
+	alreadyClosed := w.closed
+	w.closed = true
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		if !alreadyClosed {
+			// Release blocked writers, then let the flusher drain once nothing can enqueue.
+			close(w.closing)
+			w.inflight.Wait()
+			close(w.stopCh)
+		}
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s writer did not flush before shutdown deadline: %w", w.options.Name, ctx.Err())
+	}
+}
+
+func (w *BatchWriter[T]) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]T, 0, w.options.BatchSize)
+	for {
+		select {
+		case row := <-w.rows:
+			batch = append(batch, row)
+			if len(batch) >= w.options.BatchSize {
+				batch = w.flush(batch)
+			}
+		case <-ticker.C:
+			batch = w.flush(batch)
+		case <-w.stopCh:
+			// Every writer has returned by now, so draining empties the buffer for good.
+			for {
+				select {
+				case row := <-w.rows:
+					batch = append(batch, row)
+					if len(batch) >= w.options.BatchSize {
+						batch = w.flush(batch)
+					}
+				default:
+					w.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush inserts the batch, retrying with exponential backoff, and returns an emptied slice for reuse
+func (w *BatchWriter[T]) flush(batch []T) []T {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	backoff := w.options.RetryBackoff
+	var err error
+	for attempt := 0; attempt <= w.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if err = w.insert(batch); err == nil {
+			w.written.Add(int64(len(batch)))
+			return batch[:0]
+		}
+
+		logger.Warn("Batch insert failed",
+			logger.String("writer", w.options.Name),
+			logger.String("store", w.store),
+			logger.Int("batch_size", len(batch)),
+			logger.Int("attempt", attempt+1),
+			logger.ErrorField(err),
+		)
+	}
+
+	w.failed.Add(int64(len(batch)))
+	logger.Error("Dropping batch after retries",
+		logger.String("writer", w.options.Name),
+		logger.String("store", w.store),
+		logger.Int("batch_size", len(batch)),
+		logger.ErrorField(err),
+	)
+	return batch[:0]
+}
+
+func (w *BatchWriter[T]) insert(batch []T) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.options.WriteTimeout)
+	defer cancel()
+
+	return w.db.WithContext(ctx).CreateInBatches(batch, len(batch)).Error
+}
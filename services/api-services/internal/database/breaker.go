@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+
+	"gorm.io/gorm"
+)
+
+// breakerPlugin fails GORM statements fast with resilience.ErrCircuitOpen while the
+// database is unreachable, instead of letting every request wait for a connection timeout.
+// Only connection failures count against the database; query errors such as constraint
+// violations or missing rows do not.
+type breakerPlugin struct {
+	breaker *resilience.Breaker
+}
+
+// newBreakerPlugin creates the plugin for the database called name
+func newBreakerPlugin(name string, threshold int, timeout time.Duration) *breakerPlugin {
+	return &breakerPlugin{
+		breaker: resilience.NewBreaker(resilience.BreakerOptions{
+			Name:      name,
+			Threshold: threshold,
+			Timeout:   timeout,
+			IsFailure: isConnectionFailure,
+		}),
+	}
+}
+
+// Name returns the plugin name
+func (p *breakerPlugin) Name() string {
+	return "resilience:circuit_breaker"
+}
+
+// Initialize wraps every statement type with the breaker
+func (p *breakerPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Create().Before("*").Register("resilience:allow_create", p.allow),
+		cb.Create().After("*").Register("resilience:record_create", p.record),
+		cb.Query().Before("*").Register("resilience:allow_query", p.allow),
+		cb.Query().After("*").Register("resilience:record_query", p.record),
+		cb.Update().Before("*").Register("resilience:allow_update", p.allow),
+		cb.Update().After("*").Register("resilience:record_update", p.record),
+		cb.Delete().Before("*").Register("resilience:allow_delete", p.allow),
+		cb.Delete().After("*").Register("resilience:record_delete", p.record),
+		cb.Row().Before("*").Register("resilience:allow_row", p.allow),
+		cb.Row().After("*").Register("resilience:record_row", p.record),
+		cb.Raw().Before("*").Register("resilience:allow_raw", p.allow),
+		cb.Raw().After("*").Register("resilience:record_raw", p.record),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register circuit breaker callbacks: %w", err)
+	}
+	return nil
+}
+
+// allow aborts the statement while the circuit is open
+func (p *breakerPlugin) allow(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	if err := p.breaker.Allow(); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// record reports the outcome of the statement
+func (p *breakerPlugin) record(db *gorm.DB) {
+	if db.DryRun || errors.Is(db.Error, resilience.ErrCircuitOpen) {
+		return
+	}
+	p.breaker.Record(db.Error)
+}
+
+// isConnectionFailure reports whether err means the database could not be reached or
+// did not answer in time, as opposed to rejecting the statement
+func isConnectionFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -3,12 +3,12 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 
 	"gorm.io/driver/clickhouse"
@@ -38,6 +38,16 @@ type ClickHouseClientOptions struct {
 	EnableDebugLogs    bool
 	SlowQueryThreshold time.Duration
 	AutoMigrateModels  []interface{}
+	// SQLObjects are created after the models are migrated, e.g. materialized views
+	SQLObjects []SQLObjectToCreate
+	// Statements fail fast with resilience.ErrCircuitOpen after CircuitBreakerThreshold
+	// consecutive connection failures, for CircuitBreakerTimeout
+	EnableCircuitBreaker    bool
+	CircuitBreakerThreshold int
+	CircuitBreakerTimeout   time.Duration
+	// QueryTimeout bounds the statements run with a context that has no deadline; 0 leaves
+	// them unbounded
+	QueryTimeout time.Duration
 }
 
 // NewClickHouseClient creates a new ClickHouse client with enhanced initialization
@@ -99,17 +109,57 @@ func (c *ClickHouseClient) initialize(cfg config.ClickHouseConfig) error {
 	sqlDB.SetConnMaxLifetime(c.options.ConnMaxLifetime)
 	sqlDB.SetConnMaxIdleTime(c.options.ConnMaxIdleTime)
 
-	if len(c.options.AutoMigrateModels) > 0 {
-		if err := db.AutoMigrate(c.options.AutoMigrateModels...); err != nil {
+	if len(c.options.AutoMigrateModels) > 0 || len(c.options.SQLObjects) > 0 {
+		if err := c.performMigrations(db); err != nil {
 			_ = sqlDB.Close()
 			return fmt.Errorf("migrations failed: %w", err)
 		}
 	}
 
+	if err := db.Use(tenant.NewPlugin()); err != nil {
+		_ = sqlDB.Close()
+		return fmt.Errorf("failed to register tenant scoping: %w", err)
+	}
+
+	if c.options.EnableCircuitBreaker {
+		plugin := newBreakerPlugin("clickhouse", c.options.CircuitBreakerThreshold, c.options.CircuitBreakerTimeout)
+		if err := db.Use(plugin); err != nil {
+			_ = sqlDB.Close()
+			return fmt.Errorf("failed to register circuit breaker: %w", err)
+		}
+	}
+
+	if c.options.QueryTimeout > 0 {
+		if err := db.Use(newTimeoutPlugin(c.options.QueryTimeout)); err != nil {
+			_ = sqlDB.Close()
+			return fmt.Errorf("failed to register query timeout: %w", err)
+		}
+	}
+
 	c.db = db
 	return nil
 }
 
+// performMigrations migrates each model, applying its engine clause when it implements TableOptioner.
+func (c *ClickHouseClient) performMigrations(db *gorm.DB) error {
+	for _, model := range c.options.AutoMigrateModels {
+		tx := db
+		if opt, ok := model.(TableOptioner); ok {
+			tx = db.Set("gorm:table_options", opt.TableOptions())
+		}
+		if err := tx.AutoMigrate(model); err != nil {
+			return fmt.Errorf("failed to migrate %T: %w", model, err)
+		}
+	}
+
+	for _, object := range c.options.SQLObjects {
+		if err := db.Exec(object.Query).Error; err != nil {
+			return fmt.Errorf("failed to create %s: %w", object.Description, err)
+		}
+	}
+	return nil
+}
+
 func (c *ClickHouseClient) createConnection(cfg config.ClickHouseConfig) (*gorm.DB, error) {
 	gormConfig := &gorm.Config{
 		Logger:               c.configureGormLogger(),
@@ -210,44 +260,25 @@ func (c *ClickHouseClient) HealthCheck(ctx context.Context) error {
 	return sqlDB.PingContext(ctx)
 }
 
-// DebugDbInfo outputs detailed information about the database connection
-func (c *ClickHouseClient) DebugDbInfo(ctx context.Context) {
+// PoolStats returns a snapshot of the connection pool
+func (c *ClickHouseClient) PoolStats() (PoolStats, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	if c.closed {
-		log.Println("ClickHouse client is closed")
-		return
+		return PoolStats{}, fmt.Errorf("clickhouse client is closed")
 	}
 
 	if c.db == nil {
-		log.Println("ClickHouse client is disabled")
-		return
+		return PoolStats{}, fmt.Errorf("clickhouse client is not initialized")
 	}
 
 	sqlDB, err := c.db.DB()
 	if err != nil {
-		log.Printf("Failed to get DB info: %v", err)
-		return
+		return PoolStats{}, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
 
-	stats := sqlDB.Stats()
-	log.Printf("ClickHouse Connection Pool Stats:\n"+
-		"  Open Connections: %d\n"+
-		"  In Use: %d\n"+
-		"  Idle: %d\n"+
-		"  Wait Count: %d\n"+
-		"  Wait Duration: %v\n"+
-		"  Max Idle Closed: %d\n"+
-		"  Max Lifetime Closed: %d",
-		stats.OpenConnections,
-		stats.InUse,
-		stats.Idle,
-		stats.WaitCount,
-		stats.WaitDuration,
-		stats.MaxIdleClosed,
-		stats.MaxLifetimeClosed,
-	)
+	return NewPoolStats(sqlDB.Stats()), nil
 }
 
 // Close safely shuts down the database connection with thread safety
@@ -283,5 +314,10 @@ func DefaultClickHouseClientOptions() *ClickHouseClientOptions {
 		EnableDebugLogs:    false,
 		SlowQueryThreshold: 200 * time.Millisecond,
 		AutoMigrateModels:  []interface{}{},
+
+		EnableCircuitBreaker:    true,
+		CircuitBreakerThreshold: 5,
+		CircuitBreakerTimeout:   30 * time.Second,
+		QueryTimeout:            time.Minute,
 	}
 }
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// EncryptedSerializer names the GORM serializer encrypting string fields at rest, set with
+// `gorm:"serializer:encrypted"`. Their columns only hold ciphertext, so they cannot be
+// queried or indexed by value. Values are bound to their table, column and primary key, so
+// the primary key must be set before writing and selected ahead of them when reading.
+const EncryptedSerializer = "encrypted"
+
+// encryptFieldsBatchSize is how many rows EncryptFields rewrites at a time
+const encryptFieldsBatchSize = 100
+
+// ErrFieldEncryptionUnset is returned when reading or writing an encrypted field before
+// SetFieldCipher was called
+var ErrFieldEncryptionUnset = errors.New("field encryption is not configured")
+
+// fieldCipher encrypts the fields of the encrypted serializer
+var fieldCipher atomic.Pointer[security.FieldCipher]
+
+func init() {
+	schema.RegisterSerializer(EncryptedSerializer, encryptedSerializer{})
+}
+
+// SetFieldCipher sets the cipher of the encrypted fields, once at startup
+func SetFieldCipher(c *security.FieldCipher) {
+	fieldCipher.Store(c)
+}
+
+// encryptedSerializer encrypts string fields with the field cipher. Values stored before
+// their field was encrypted are read as they are, until EncryptFields rewrites them.
+type encryptedSerializer struct{}
+
+// Scan decrypts the column value into the field
+func (encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported value %T of encrypted field %s", dbValue, field.Name)
+	}
+
+	if value != "" {
+		c := fieldCipher.Load()
+		if c == nil {
+			return ErrFieldEncryptionUnset
+		}
+		additionalData, err := encryptedFieldData(ctx, field, dst)
+		if err != nil {
+			return err
+		}
+		plaintext, err := c.Decrypt(value, additionalData)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		value = plaintext
+	}
+	field.ReflectValueOf(ctx, dst).SetString(value)
+	return nil
+}
+
+// Value encrypts the field value for its column
+func (encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encrypted field %s must be a string", field.Name)
+	}
+	if value == "" {
+		return "", nil
+	}
+	c := fieldCipher.Load()
+	if c == nil {
+		return nil, ErrFieldEncryptionUnset
+	}
+	additionalData, err := encryptedFieldData(ctx, field, dst)
+	if err != nil {
+		return nil, err
+	}
+	return c.Encrypt(value, additionalData)
+}
+
+// encryptedFieldData returns the additional data binding the value of field to its row:
+// the table, the column and the primary key of the row
+func encryptedFieldData(ctx context.Context, field *schema.Field, dst reflect.Value) ([]byte, error) {
+	primaryKey := field.Schema.PrioritizedPrimaryField
+	if primaryKey == nil {
+		return nil, fmt.Errorf("encrypted field %s needs a primary key", field.Name)
+	}
+	id, zero := primaryKey.ValueOf(ctx, dst)
+	if zero {
+		return nil, fmt.Errorf("encrypted field %s needs the primary key of its row", field.Name)
+	}
+	return fmt.Appendf(nil, "%s\x00%s\x00%v", field.Schema.Table, field.DBName, id), nil
+}
+
+// EncryptFields rewrites the encrypted fields of models that are stored in plain text or
+// with a previous key, so that previous keys can be retired once it ran. Soft deleted rows
+// are included and only the encrypted columns change. It returns how many values were
+// rewritten.
+func EncryptFields(ctx context.Context, db *gorm.DB, models ...interface{}) (int64, error) {
+	c := fieldCipher.Load()
+	if c == nil {
+		return 0, ErrFieldEncryptionUnset
+	}
+
+	var rewritten int64
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return rewritten, fmt.Errorf("failed to parse %T: %w", model, err)
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.TagSettings["SERIALIZER"] != EncryptedSerializer {
+				continue
+			}
+			rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+			err := db.WithContext(ctx).Unscoped().
+				Select(stmt.Schema.PrioritizedPrimaryField.DBName, field.DBName).
+				Where(stmt.Quote(field.DBName)+" <> '' AND "+stmt.Quote(field.DBName)+" NOT LIKE ?", c.Prefix()+"%").
+				FindInBatches(rows.Interface(), encryptFieldsBatchSize, func(tx *gorm.DB, _ int) error {
+					batch := rows.Elem()
+					for i := 0; i < batch.Len(); i++ {
+						row := batch.Index(i).Addr().Interface()
+						err := db.WithContext(ctx).Session(&gorm.Session{SkipHooks: true}).Unscoped().
+							Model(row).Select(field.DBName).Updates(row).Error
+						if err != nil {
+							return err
+						}
+					}
+					rewritten += int64(batch.Len())
+					return nil
+				}).Error
+			if err != nil {
+				return rewritten, fmt.Errorf("failed to encrypt %s.%s: %w", stmt.Schema.Table, field.DBName, err)
+			}
+		}
+	}
+	return rewritten, nil
+}
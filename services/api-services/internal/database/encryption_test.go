@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
+	"gorm.io/gorm/schema"
+)
+
+// encryptedRow is a model with an encrypted field
+type encryptedRow struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Secret string    `gorm:"serializer:encrypted"`
+	Other  string    `gorm:"serializer:encrypted"`
+}
+
+// setTestFieldCipher sets a cipher of a random key for the test
+func setTestFieldCipher(t *testing.T) {
+	t.Helper()
+	key := make([]byte, security.FieldKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	c, err := security.NewFieldCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := fieldCipher.Swap(c)
+	t.Cleanup(func() { fieldCipher.Store(previous) })
+}
+
+// encryptedRowFields returns the encrypted fields of encryptedRow
+func encryptedRowFields(t *testing.T) (secret, other *schema.Field) {
+	t.Helper()
+	s, err := schema.Parse(&encryptedRow{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.LookUpField("Secret"), s.LookUpField("Other")
+}
+
+// writeField returns the column value the serializer writes for the field of row
+func writeField(t *testing.T, field *schema.Field, row *encryptedRow, value string) string {
+	t.Helper()
+	stored, err := encryptedSerializer{}.Value(context.Background(), field, reflect.ValueOf(row).Elem(), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stored.(string)
+}
+
+// readField scans a column value into the field of row
+func readField(field *schema.Field, row *encryptedRow, stored string) error {
+	return encryptedSerializer{}.Scan(context.Background(), field, reflect.ValueOf(row).Elem(), stored)
+}
+
+func TestEncryptedSerializerRoundTrip(t *testing.T) {
+	setTestFieldCipher(t)
+	secret, _ := encryptedRowFields(t)
+
+	row := &encryptedRow{ID: uuid.New()}
+	stored := writeField(t, secret, row, "token")
+	if stored == "token" {
+		t.Fatal("value was stored in plain text")
+	}
+
+	read := &encryptedRow{ID: row.ID}
+	if err := readField(secret, read, stored); err != nil {
+		t.Fatal(err)
+	}
+	if read.Secret != "token" {
+		t.Errorf("Secret = %q, want %q", read.Secret, "token")
+	}
+}
+
+func TestEncryptedSerializerBindsValuesToTheirRow(t *testing.T) {
+	setTestFieldCipher(t)
+	secret, other := encryptedRowFields(t)
+
+	row := &encryptedRow{ID: uuid.New()}
+	stored := writeField(t, secret, row, "token")
+
+	// A value copied to another row or column does not decrypt
+	if err := readField(secret, &encryptedRow{ID: uuid.New()}, stored); !errors.Is(err, security.ErrFieldDecryption) {
+		t.Errorf("reading the value in another row: error = %v, want %v", err, security.ErrFieldDecryption)
+	}
+	if err := readField(other, &encryptedRow{ID: row.ID}, stored); !errors.Is(err, security.ErrFieldDecryption) {
+		t.Errorf("reading the value in another column: error = %v, want %v", err, security.ErrFieldDecryption)
+	}
+	// Without its primary key a value can be neither written nor read
+	if _, err := (encryptedSerializer{}).Value(context.Background(), secret, reflect.ValueOf(&encryptedRow{}).Elem(), "token"); err == nil {
+		t.Error("writing a value without the primary key succeeded")
+	}
+	if err := readField(secret, &encryptedRow{}, stored); err == nil {
+		t.Error("reading a value without the primary key succeeded")
+	}
+}
@@ -2,8 +2,11 @@ package database
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+
 	"gorm.io/gorm"
 )
 
@@ -13,10 +16,13 @@ type Client interface {
 	WithContext(ctx context.Context) *gorm.DB
 	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
 	HealthCheck(ctx context.Context) error
-	DebugDbInfo(ctx context.Context)
+	PoolStats() (PoolStats, error)
 	Close() error
 }
 
+// ErrCacheMiss is returned by CacheClient.Get when the key does not exist
+var ErrCacheMiss = errors.New("key not found in cache")
+
 // CacheClient defines the interface for our Redis client.
 type CacheClient interface {
 	Get(ctx context.Context, key string) ([]byte, error)
@@ -25,10 +31,40 @@ type CacheClient interface {
 	Delete(ctx context.Context, key string) error
 	Increment(ctx context.Context, key string) (int64, error)
 	Decrement(ctx context.Context, key string) (int64, error)
+	IncrementWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	GetTTL(ctx context.Context, key string) (time.Duration, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	SetNX(ctx context.Context, key string, value []byte, exp time.Duration) (bool, error)
+	MGet(ctx context.Context, keys ...string) ([][]byte, error)
+	MSet(ctx context.Context, values map[string][]byte, exp time.Duration) error
+	Publish(ctx context.Context, channel string, payload []byte) error
+	Subscribe(ctx context.Context, handler func(channel string, payload []byte), patterns ...string) error
+	AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (int64, bool, error)
+	CheckLock(ctx context.Context, key, token string, fence int64) (bool, error)
+	RenewLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	ReleaseLock(ctx context.Context, key, token string) (bool, error)
+	Stats() CacheStats
 	HealthCheck(ctx context.Context) error
 	Close() error
 }
 
+// CacheStats are the cumulative operation counters of a CacheClient
+type CacheStats struct {
+	Requests            int64            `json:"requests"`
+	Errors              int64            `json:"errors"`
+	Hits                int64            `json:"hits"`
+	Misses              int64            `json:"misses"`
+	Latency             time.Duration    `json:"latency_ns"`
+	CircuitState        resilience.State `json:"circuit_state"`
+	ConsecutiveFailures int              `json:"consecutive_failures"`
+}
+
+// TableOptioner is implemented by models that need engine-specific table options
+// (e.g. a ClickHouse ENGINE clause) when auto-migrated.
+type TableOptioner interface {
+	TableOptions() string
+}
+
 // Row represents a single row result
 type Row interface {
 	Scan(dest ...interface{}) error
@@ -0,0 +1,39 @@
+package database
+
+import "database/sql"
+
+// PoolStats is a snapshot of a client's connection pool, reported by the health
+// endpoint and exported as Prometheus metrics.
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDurationMs     int64         `json:"wait_duration_ms"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+	Replicas           *ReplicaStats `json:"replicas,omitempty"`
+}
+
+// ReplicaStats reports how many configured read replicas are serving reads
+type ReplicaStats struct {
+	Total   int `json:"total"`
+	Healthy int `json:"healthy"`
+}
+
+// NewPoolStats converts database/sql pool statistics
+func NewPoolStats(stats sql.DBStats) PoolStats {
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/driver/postgres"
@@ -18,10 +19,11 @@ import (
 
 // PostgresClient implements the Client interface for PostgreSQL with enhanced features
 type PostgresClient struct {
-	db      *gorm.DB
-	options *PostgresClientOptions
-	mu      sync.RWMutex
-	closed  bool
+	db       *gorm.DB
+	replicas *replicaSet
+	options  *PostgresClientOptions
+	mu       sync.RWMutex
+	closed   bool
 }
 
 // PostgresClientOptions holds comprehensive configuration for the Postgres client
@@ -40,6 +42,16 @@ type PostgresClientOptions struct {
 	AutoMigrateModels  []interface{}
 	StatementCacheSize int
 	PreparedStatements bool
+	// SQLObjects are created after the models are migrated, e.g. expression indexes
+	SQLObjects []SQLObjectToCreate
+	// Statements fail fast with resilience.ErrCircuitOpen after CircuitBreakerThreshold
+	// consecutive connection failures, for CircuitBreakerTimeout
+	EnableCircuitBreaker    bool
+	CircuitBreakerThreshold int
+	CircuitBreakerTimeout   time.Duration
+	// QueryTimeout bounds the statements run with a context that has no deadline; 0 leaves
+	// them unbounded
+	QueryTimeout time.Duration
 }
 
 // NewPostgresClient creates a new PostgreSQL client with enhanced initialization
@@ -103,13 +115,43 @@ func (c *PostgresClient) initialize(cfg config.PostgresConfig) error {
 	sqlDB.SetConnMaxIdleTime(c.options.ConnMaxIdleTime)
 
 	// Perform migrations if needed
-	if len(c.options.AutoMigrateModels) > 0 {
+	if len(c.options.AutoMigrateModels) > 0 || len(c.options.SQLObjects) > 0 {
 		if err := c.performSafeMigrations(db); err != nil {
 			_ = sqlDB.Close()
 			return fmt.Errorf("migrations failed: %w", err)
 		}
 	}
 
+	if err := db.Use(tenant.NewPlugin()); err != nil {
+		_ = sqlDB.Close()
+		return fmt.Errorf("failed to register tenant scoping: %w", err)
+	}
+
+	if c.options.EnableCircuitBreaker {
+		plugin := newBreakerPlugin("postgres", c.options.CircuitBreakerThreshold, c.options.CircuitBreakerTimeout)
+		if err := db.Use(plugin); err != nil {
+			_ = sqlDB.Close()
+			return fmt.Errorf("failed to register circuit breaker: %w", err)
+		}
+	}
+
+	if c.options.QueryTimeout > 0 {
+		if err := db.Use(newTimeoutPlugin(c.options.QueryTimeout)); err != nil {
+			_ = sqlDB.Close()
+			return fmt.Errorf("failed to register query timeout: %w", err)
+		}
+	}
+
+	// Route reads to replicas only after migrations have run on the primary
+	if len(cfg.ReplicaDSNs) > 0 {
+		replicas, err := c.setupReplicas(db, cfg.ReplicaDSNs, cfg.ReplicaHealthCheckInterval)
+		if err != nil {
+			_ = sqlDB.Close()
+			return fmt.Errorf("replica setup failed: %w", err)
+		}
+		c.replicas = replicas
+	}
+
 	c.db = db
 	return nil
 }
@@ -184,7 +226,16 @@ func (c *PostgresClient) performSafeMigrations(db *gorm.DB) error {
 				return err
 			}
 
-			return tx.AutoMigrate(c.options.AutoMigrateModels...)
+			if err := tx.AutoMigrate(c.options.AutoMigrateModels...); err != nil {
+				return err
+			}
+
+			for _, object := range c.options.SQLObjects {
+				if err := tx.Exec(object.Query).Error; err != nil {
+					return fmt.Errorf("failed to create %s: %w", object.Description, err)
+				}
+			}
+			return nil
 		})
 
 		if err == nil {
@@ -254,44 +305,32 @@ func (c *PostgresClient) Transaction(ctx context.Context, fn func(tx *gorm.DB) e
 	})
 }
 
-// DebugDbInfo outputs detailed information about the database connection
-func (c *PostgresClient) DebugDbInfo(ctx context.Context) {
+// PoolStats returns a snapshot of the primary connection pool and read replica health
+func (c *PostgresClient) PoolStats() (PoolStats, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	if c.closed {
-		log.Println("Postgres client is closed")
-		return
+		return PoolStats{}, fmt.Errorf("postgres client is closed")
 	}
 
 	if c.db == nil {
-		log.Println("Postgres client is disabled")
-		return
+		return PoolStats{}, fmt.Errorf("postgres client is not initialized")
 	}
 
 	sqlDB, err := c.db.DB()
 	if err != nil {
-		log.Printf("Failed to get DB info: %v", err)
-		return
-	}
-
-	stats := sqlDB.Stats()
-	log.Printf("Postgres Connection Pool Stats:\n"+
-		"  Open Connections: %d\n"+
-		"  In Use: %d\n"+
-		"  Idle: %d\n"+
-		"  Wait Count: %d\n"+
-		"  Wait Duration: %v\n"+
-		"  Max Idle Closed: %d\n"+
-		"  Max Lifetime Closed: %d",
-		stats.OpenConnections,
-		stats.InUse,
-		stats.Idle,
-		stats.WaitCount,
-		stats.WaitDuration,
-		stats.MaxIdleClosed,
-		stats.MaxLifetimeClosed,
-	)
+		return PoolStats{}, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	stats := NewPoolStats(sqlDB.Stats())
+	if c.replicas != nil {
+		stats.Replicas = &ReplicaStats{
+			Total:   len(c.replicas.replicas),
+			Healthy: c.replicas.healthy(),
+		}
+	}
+	return stats, nil
 }
 
 // HealthCheck verifies database connectivity with timeout
@@ -336,6 +375,12 @@ func (c *PostgresClient) Close() error {
 	}
 
 	c.closed = true
+
+	if c.replicas != nil {
+		if err := c.replicas.close(); err != nil {
+			log.Printf("Failed to close Postgres replicas: %v", err)
+		}
+	}
 	return sqlDB.Close()
 }
 
@@ -356,5 +401,10 @@ func DefaultPostgresClientOptions() *PostgresClientOptions {
 		AutoMigrateModels:  []interface{}{},
 		StatementCacheSize: 100,
 		PreparedStatements: true,
+
+		EnableCircuitBreaker:    true,
+		CircuitBreakerThreshold: 5,
+		CircuitBreakerTimeout:   30 * time.Second,
+		QueryTimeout:            30 * time.Second,
 	}
 }
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Primary forces the statement onto the primary connection, bypassing replica
+// routing. Use it for reads that must observe a write made moments earlier.
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
+
+// replicaConn tracks a single read replica and its last observed health
+type replicaConn struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicaSet routes reads across healthy replicas and falls back to the
+// primary when none are available. It implements dbresolver.Policy.
+type replicaSet struct {
+	primary  *sql.DB
+	replicas []*replicaConn
+	next     atomic.Uint64
+	timeout  time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Resolve picks the next healthy replica in round-robin order, or the primary
+// when every replica is failing its health checks.
+func (s *replicaSet) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	n := len(s.replicas)
+	if n > 0 {
+		start := int(s.next.Add(1) % uint64(n))
+		for i := 0; i < n; i++ {
+			r := s.replicas[(start+i)%n]
+			if r.healthy.Load() {
+				return r.db
+			}
+		}
+	}
+
+	for _, pool := range pools {
+		if db, ok := pool.(*sql.DB); ok && db == s.primary {
+			return pool
+		}
+	}
+	return pools[0]
+}
+
+// healthy reports how many replicas are currently accepting reads
+func (s *replicaSet) healthy() int {
+	count := 0
+	for _, r := range s.replicas {
+		if r.healthy.Load() {
+			count++
+		}
+	}
+	return count
+}
+
+// check pings every replica and updates its health, logging transitions
+func (s *replicaSet) check() {
+	for _, r := range s.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		err := r.db.PingContext(ctx)
+		cancel()
+
+		wasHealthy := r.healthy.Swap(err == nil)
+		switch {
+		case err != nil && wasHealthy:
+			log.Printf("Postgres replica %s marked unhealthy, reads fall back to the primary: %v", r.name, err)
+		case err == nil && !wasHealthy:
+			log.Printf("Postgres replica %s is healthy again", r.name)
+		}
+	}
+}
+
+// monitor re-checks replica health on every tick until the set is closed
+func (s *replicaSet) monitor(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.check()
+			}
+		}
+	}()
+}
+
+// close stops the health monitor and closes every replica pool
+func (s *replicaSet) close() error {
+	close(s.stop)
+	s.wg.Wait()
+
+	var firstErr error
+	for _, r := range s.replicas {
+		if err := r.db.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close replica %s: %w", r.name, err)
+		}
+	}
+	return firstErr
+}
+
+// setupReplicas opens the configured replicas and registers the dbresolver
+// plugin so SELECTs outside transactions are served by a healthy replica.
+// A replica that is unreachable at startup is registered as unhealthy and
+// picked up by the health monitor once it recovers.
+func (c *PostgresClient) setupReplicas(db *gorm.DB, dsns []string, interval time.Duration) (*replicaSet, error) {
+	primary, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary sql.DB: %w", err)
+	}
+
+	set := &replicaSet{
+		primary: primary,
+		timeout: c.options.HealthCheckTimeout,
+		stop:    make(chan struct{}),
+	}
+
+	// The primary is always a candidate so the policy is consulted even with a
+	// single replica; dbresolver skips the policy when only one pool exists.
+	dialectors := []gorm.Dialector{postgres.New(postgres.Config{
+		Conn:                 primary,
+		PreferSimpleProtocol: true,
+	})}
+
+	for i, dsn := range dsns {
+		replicaDB, err := sql.Open("pgx", dsn)
+		if err != nil {
+			_ = set.close()
+			return nil, fmt.Errorf("failed to open replica %d: %w", i+1, err)
+		}
+
+		replicaDB.SetMaxIdleConns(c.options.MaxIdleConns)
+		replicaDB.SetMaxOpenConns(c.options.MaxOpenConns)
+		replicaDB.SetConnMaxLifetime(c.options.ConnMaxLifetime)
+		replicaDB.SetConnMaxIdleTime(c.options.ConnMaxIdleTime)
+
+		replica := &replicaConn{name: fmt.Sprintf("#%d", i+1), db: replicaDB}
+		ctx, cancel := context.WithTimeout(context.Background(), c.options.ConnectTimeout)
+		if err := replicaDB.PingContext(ctx); err != nil {
+			log.Printf("Postgres replica %s unreachable at startup: %v", replica.name, err)
+		} else {
+			replica.healthy.Store(true)
+		}
+		cancel()
+
+		set.replicas = append(set.replicas, replica)
+		dialectors = append(dialectors, postgres.New(postgres.Config{
+			Conn:                 replicaDB,
+			PreferSimpleProtocol: true,
+		}))
+	}
+
+	if err := db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   set,
+	})); err != nil {
+		_ = set.close()
+		return nil, fmt.Errorf("failed to register read replicas: %w", err)
+	}
+
+	set.monitor(interval)
+	log.Printf("Postgres read replicas configured: %d/%d healthy", set.healthy(), len(set.replicas))
+
+	return set, nil
+}
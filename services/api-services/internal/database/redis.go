@@ -4,21 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
 
 	"github.com/go-redis/redis/v8"
 )
 
-const (
-	CircuitClosed = iota
-	CircuitOpen
-	CircuitHalfOpen
-)
-
 // RedisClientOptions holds comprehensive configuration for the Redis client.
 type RedisClientOptions struct {
 	ConnectTimeout          time.Duration
@@ -47,7 +44,8 @@ func DefaultRedisClientOptions() *RedisClientOptions {
 	}
 }
 
-// redisMetrics holds metrics for Redis operations.
+// redisMetrics holds metrics for Redis operations. The window fields are logged and reset
+// periodically; totals accumulate for the lifetime of the client.
 type redisMetrics struct {
 	requests      int64
 	errors        int64
@@ -55,18 +53,18 @@ type redisMetrics struct {
 	misses        int64
 	latency       time.Duration
 	lastResetTime time.Time
+
+	totals CacheStats
 }
 
 // RedisClient implements the CacheClient interface for Redis with enhanced features.
 type RedisClient struct {
-	client          *redis.Client
-	options         *RedisClientOptions
-	mu              sync.RWMutex
-	closed          bool
-	metrics         *redisMetrics
-	circuitState    int
-	failureCount    int
-	lastFailureTime time.Time
+	client  *redis.Client
+	options *RedisClientOptions
+	mu      sync.RWMutex
+	closed  bool
+	metrics *redisMetrics
+	breaker *resilience.Breaker
 }
 
 // NewRedisClient creates a new RedisClient instance.
@@ -89,8 +87,12 @@ func NewRedisClient(cfg config.RedisConfig, opts *RedisClientOptions) (*RedisCli
 		metrics: &redisMetrics{
 			lastResetTime: time.Now(),
 		},
-		circuitState: CircuitClosed,
-		failureCount: 0,
+		breaker: resilience.NewBreaker(resilience.BreakerOptions{
+			Name:      "redis",
+			Threshold: opts.CircuitBreakerThreshold,
+			Timeout:   opts.CircuitBreakerTimeout,
+			IsFailure: func(err error) bool { return !errors.Is(err, redis.Nil) },
+		}),
 	}
 
 	return client, nil
@@ -102,7 +104,7 @@ func (c *RedisClient) Set(ctx context.Context, key string, value []byte, duratio
 	var err error
 
 	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
-		err = errors.New("circuit breaker open")
+		err = resilience.ErrCircuitOpen
 	} else {
 		cmd := c.client.Set(ctx, key, value, duration)
 		err = cmd.Err()
@@ -111,11 +113,9 @@ func (c *RedisClient) Set(ctx context.Context, key string, value []byte, duratio
 	if err != nil {
 		c.recordMetrics(time.Since(start), "Set_Error")
 		c.handleCircuitBreaker(err)
-		logger.Error("Redis Set failed",
+		logFailure("Set", err,
 			logger.String("key", key),
 			logger.Duration("duration", duration),
-			logger.ErrorField(err),
-			logger.String("op", "Set"),
 		)
 		return fmt.Errorf("redis set operation failed for key %s: %w", key, err)
 	}
@@ -132,7 +132,7 @@ func (c *RedisClient) Get(ctx context.Context, key string) ([]byte, error) {
 	var val []byte
 
 	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
-		err = errors.New("circuit breaker open")
+		err = resilience.ErrCircuitOpen
 	} else {
 		cmd := c.client.Get(ctx, key)
 		val, err = cmd.Bytes()
@@ -142,14 +142,12 @@ func (c *RedisClient) Get(ctx context.Context, key string) ([]byte, error) {
 		if errors.Is(err, redis.Nil) {
 			c.recordMetrics(time.Since(start), "Get_Miss")
 			c.resetCircuitBreaker()
-			return nil, errors.New("key not found in mock cache")
+			return nil, ErrCacheMiss
 		}
 		c.recordMetrics(time.Since(start), "Get_Error")
 		c.handleCircuitBreaker(err)
-		logger.Error("Redis Get failed",
+		logFailure("Get", err,
 			logger.String("key", key),
-			logger.ErrorField(err),
-			logger.String("op", "Get"),
 		)
 		return nil, fmt.Errorf("redis get operation failed for key %s: %w", key, err)
 	}
@@ -165,7 +163,7 @@ func (c *RedisClient) Delete(ctx context.Context, key string) error {
 	var err error
 
 	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
-		err = errors.New("circuit breaker open")
+		err = resilience.ErrCircuitOpen
 	} else {
 		cmd := c.client.Del(ctx, key)
 		err = cmd.Err()
@@ -174,10 +172,8 @@ func (c *RedisClient) Delete(ctx context.Context, key string) error {
 	if err != nil {
 		c.recordMetrics(time.Since(start), "Delete_Error")
 		c.handleCircuitBreaker(err)
-		logger.Error("Redis Delete failed",
+		logFailure("Delete", err,
 			logger.String("key", key),
-			logger.ErrorField(err),
-			logger.String("op", "Delete"),
 		)
 		return fmt.Errorf("redis delete operation failed for key %s: %w", key, err)
 	}
@@ -194,7 +190,7 @@ func (c *RedisClient) Update(ctx context.Context, key string, value []byte) erro
 	var err error
 
 	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
-		err = errors.New("circuit breaker open")
+		err = resilience.ErrCircuitOpen
 	} else {
 		cmd := c.client.Do(ctx, "SET", key, value, "KEEPTTL")
 		err = cmd.Err()
@@ -203,10 +199,8 @@ func (c *RedisClient) Update(ctx context.Context, key string, value []byte) erro
 	if err != nil {
 		c.recordMetrics(time.Since(start), "Update_Error")
 		c.handleCircuitBreaker(err)
-		logger.Error("Redis Update failed",
+		logFailure("Update", err,
 			logger.String("key", key),
-			logger.ErrorField(err),
-			logger.String("op", "Update"),
 		)
 		return fmt.Errorf("redis update operation failed for key %s: %w", key, err)
 	}
@@ -224,7 +218,7 @@ func (c *RedisClient) Increment(ctx context.Context, key string) (int64, error)
 	var err error
 
 	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
-		err = errors.New("circuit breaker open")
+		err = resilience.ErrCircuitOpen
 	} else {
 		cmd := c.client.Incr(ctx, key)
 		result, err = cmd.Result()
@@ -233,10 +227,8 @@ func (c *RedisClient) Increment(ctx context.Context, key string) (int64, error)
 	if err != nil {
 		c.recordMetrics(time.Since(start), "Increment_Error")
 		c.handleCircuitBreaker(err)
-		logger.Error("Redis Increment failed",
+		logFailure("Increment", err,
 			logger.String("key", key),
-			logger.ErrorField(err),
-			logger.String("op", "Increment"),
 		)
 		return 0, fmt.Errorf("redis increment operation failed for key %s: %w", key, err)
 	}
@@ -258,7 +250,7 @@ func (c *RedisClient) Decrement(ctx context.Context, key string) (int64, error)
 	var err error
 
 	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
-		err = errors.New("circuit breaker open")
+		err = resilience.ErrCircuitOpen
 	} else {
 		cmd := c.client.Decr(ctx, key)
 		result, err = cmd.Result()
@@ -267,10 +259,8 @@ func (c *RedisClient) Decrement(ctx context.Context, key string) (int64, error)
 	if err != nil {
 		c.recordMetrics(time.Since(start), "Decrement_Error")
 		c.handleCircuitBreaker(err)
-		logger.Error("Redis Decrement failed",
+		logFailure("Decrement", err,
 			logger.String("key", key),
-			logger.ErrorField(err),
-			logger.String("op", "Decrement"),
 		)
 		return 0, fmt.Errorf("redis decrement operation failed for key %s: %w", key, err)
 	}
@@ -284,6 +274,376 @@ func (c *RedisClient) Decrement(ctx context.Context, key string) (int64, error)
 	return result, nil
 }
 
+// incrementWithTTLScript increments a counter and sets its expiry when the counter has none,
+// which covers both a new key and one left without an expiry by a plain INCR
+var incrementWithTTLScript = redis.NewScript(`
+local value = redis.call("INCR", KEYS[1])
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return value`)
+
+// IncrementWithTTL atomically increments the value of a key by 1 and returns the new value.
+// The key expires ttl after the increment that created it; later increments keep that
+// expiry, so the counter covers a fixed window.
+func (c *RedisClient) IncrementWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	start := time.Now()
+	var result int64
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		result, err = incrementWithTTLScript.Run(ctx, c.client, []string{key}, ttl.Milliseconds()).Int64()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), "IncrementWithTTL_Error")
+		c.handleCircuitBreaker(err)
+		logFailure("IncrementWithTTL", err,
+			logger.String("key", key),
+			logger.Duration("ttl", ttl),
+		)
+		return 0, fmt.Errorf("redis increment with ttl operation failed for key %s: %w", key, err)
+	}
+
+	c.recordMetrics(time.Since(start), "IncrementWithTTL_Success")
+	c.resetCircuitBreaker()
+	return result, nil
+}
+
+// GetTTL returns the remaining time to live of a key, zero if it never expires. It returns
+// ErrCacheMiss if the key does not exist.
+func (c *RedisClient) GetTTL(ctx context.Context, key string) (time.Duration, error) {
+	start := time.Now()
+	var ttl time.Duration
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		cmd := c.client.PTTL(ctx, key)
+		ttl, err = cmd.Result()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), "GetTTL_Error")
+		c.handleCircuitBreaker(err)
+		logFailure("GetTTL", err,
+			logger.String("key", key),
+		)
+		return 0, fmt.Errorf("redis get ttl operation failed for key %s: %w", key, err)
+	}
+
+	c.recordMetrics(time.Since(start), "GetTTL_Success")
+	c.resetCircuitBreaker()
+
+	// go-redis reports the -2 (missing) and -1 (no expiry) replies unscaled
+	switch ttl {
+	case -2:
+		return 0, ErrCacheMiss
+	case -1:
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// Expire sets the time to live of an existing key, reporting false if the key does not exist.
+func (c *RedisClient) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	start := time.Now()
+	var ok bool
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		cmd := c.client.PExpire(ctx, key, ttl)
+		ok, err = cmd.Result()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), "Expire_Error")
+		c.handleCircuitBreaker(err)
+		logFailure("Expire", err,
+			logger.String("key", key),
+			logger.Duration("ttl", ttl),
+		)
+		return false, fmt.Errorf("redis expire operation failed for key %s: %w", key, err)
+	}
+
+	c.recordMetrics(time.Since(start), "Expire_Success")
+	c.resetCircuitBreaker()
+	return ok, nil
+}
+
+// SetNX stores a value under a key that does not exist yet, reporting false without
+// writing anything if it does. A zero duration means the key never expires.
+func (c *RedisClient) SetNX(ctx context.Context, key string, value []byte, duration time.Duration) (bool, error) {
+	start := time.Now()
+	var ok bool
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		cmd := c.client.SetNX(ctx, key, value, duration)
+		ok, err = cmd.Result()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), "SetNX_Error")
+		c.handleCircuitBreaker(err)
+		logFailure("SetNX", err,
+			logger.String("key", key),
+			logger.Duration("duration", duration),
+		)
+		return false, fmt.Errorf("redis setnx operation failed for key %s: %w", key, err)
+	}
+
+	c.recordMetrics(time.Since(start), "SetNX_Success")
+	c.resetCircuitBreaker()
+	return ok, nil
+}
+
+// MGet retrieves the values of keys in a single round trip. The result is aligned with
+// keys; missing keys have a nil value.
+func (c *RedisClient) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+	var raw []interface{}
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		cmd := c.client.MGet(ctx, keys...)
+		raw, err = cmd.Result()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), "MGet_Error")
+		c.handleCircuitBreaker(err)
+		logFailure("MGet", err,
+			logger.Int("keys", len(keys)),
+		)
+		return nil, fmt.Errorf("redis mget operation failed for %d keys: %w", len(keys), err)
+	}
+
+	values := make([][]byte, len(keys))
+	for i, value := range raw {
+		if s, ok := value.(string); ok {
+			values[i] = []byte(s)
+		}
+	}
+
+	c.recordMetrics(time.Since(start), "MGet_Success")
+	c.resetCircuitBreaker()
+	return values, nil
+}
+
+// MSet stores every value with the same expiration in a single pipelined round trip.
+// Unlike Redis MSET each key gets the expiration; a zero duration means no expiration.
+func (c *RedisClient) MSet(ctx context.Context, values map[string][]byte, duration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, err := c.pipelined(ctx, "MSet", func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			pipe.Set(ctx, key, value, duration)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis mset operation failed for %d keys: %w", len(values), err)
+	}
+	return nil
+}
+
+// Pipelined queues the commands issued by fn and sends them in a single round trip,
+// returning the executed commands in order. The commands are not run atomically.
+func (c *RedisClient) Pipelined(ctx context.Context, fn func(pipe redis.Pipeliner) error) ([]redis.Cmder, error) {
+	cmds, err := c.pipelined(ctx, "Pipeline", fn)
+	if err != nil {
+		return cmds, fmt.Errorf("redis pipeline failed: %w", err)
+	}
+	return cmds, nil
+}
+
+// pipelined runs a pipeline with the circuit breaker and metrics under the name op
+func (c *RedisClient) pipelined(ctx context.Context, op string, fn func(pipe redis.Pipeliner) error) ([]redis.Cmder, error) {
+	start := time.Now()
+	var cmds []redis.Cmder
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		cmds, err = c.client.Pipelined(ctx, fn)
+	}
+
+	// A missing key in a pipelined GET is not a failure of the pipeline
+	if err != nil && !errors.Is(err, redis.Nil) {
+		c.recordMetrics(time.Since(start), op+"_Error")
+		c.handleCircuitBreaker(err)
+		logFailure(op, err,
+			logger.Int("commands", len(cmds)),
+		)
+		return cmds, err
+	}
+
+	c.recordMetrics(time.Since(start), op+"_Success")
+	c.resetCircuitBreaker()
+	return cmds, nil
+}
+
+// Publish sends a message to a Redis pub/sub channel.
+func (c *RedisClient) Publish(ctx context.Context, channel string, payload []byte) error {
+	start := time.Now()
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		err = c.client.Publish(ctx, channel, payload).Err()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), "Publish_Error")
+		c.handleCircuitBreaker(err)
+		logFailure("Publish", err,
+			logger.String("channel", channel),
+		)
+		return fmt.Errorf("redis publish failed for channel %s: %w", channel, err)
+	}
+
+	c.recordMetrics(time.Since(start), "Publish_Success")
+	c.resetCircuitBreaker()
+	return nil
+}
+
+// Subscribe listens on the channels matching the given patterns and invokes handler for
+// every message. It blocks until ctx is cancelled or the subscription fails.
+func (c *RedisClient) Subscribe(ctx context.Context, handler func(channel string, payload []byte), patterns ...string) error {
+	pubsub := c.client.PSubscribe(ctx, patterns...)
+	defer func() {
+		if err := pubsub.Close(); err != nil {
+			logger.Warn("Failed to close Redis subscription", logger.ErrorField(err))
+		}
+	}()
+
+	// Wait for the subscription confirmation so connection errors surface immediately.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("redis subscribe failed: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return errors.New("redis subscription channel closed")
+			}
+			handler(msg.Channel, []byte(msg.Payload))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Lock scripts compare the stored token before touching the key, so a holder whose lease
+// expired cannot renew or release a lock another holder has taken since. Acquiring the
+// lock increments a fencing counter kept next to it, which checks compare to the fence
+// of the lease.
+var (
+	acquireLockScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0`)
+	checkLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] and redis.call("GET", KEYS[2]) == ARGV[2] then
+	return 1
+end
+return 0`)
+	renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// AcquireLock sets key to token for ttl unless it is already held. On success it returns
+// a fencing token from a counter kept next to the key, strictly increasing across holders.
+func (c *RedisClient) AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (int64, bool, error) {
+	fence, err := c.runLockScript(ctx, "AcquireLock", key, acquireLockScript,
+		[]string{key, key + ":fence"}, token, ttl.Milliseconds())
+	if err != nil {
+		return 0, false, err
+	}
+	return fence, fence > 0, nil
+}
+
+// CheckLock reports whether the lock on key is still held with token and fence is still
+// the latest fencing token issued for it
+func (c *RedisClient) CheckLock(ctx context.Context, key, token string, fence int64) (bool, error) {
+	held, err := c.runLockScript(ctx, "CheckLock", key, checkLockScript,
+		[]string{key, key + ":fence"}, token, strconv.FormatInt(fence, 10))
+	return held == 1, err
+}
+
+// RenewLock extends the lock on key to ttl if it is still held with token
+func (c *RedisClient) RenewLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	renewed, err := c.runLockScript(ctx, "RenewLock", key, renewLockScript, []string{key}, token, ttl.Milliseconds())
+	return renewed == 1, err
+}
+
+// ReleaseLock deletes the lock on key if it is still held with token
+func (c *RedisClient) ReleaseLock(ctx context.Context, key, token string) (bool, error) {
+	released, err := c.runLockScript(ctx, "ReleaseLock", key, releaseLockScript, []string{key}, token)
+	return released == 1, err
+}
+
+// runLockScript runs one of the lock scripts with the circuit breaker and metrics
+func (c *RedisClient) runLockScript(ctx context.Context, op, key string, script *redis.Script, keys []string, args ...interface{}) (int64, error) {
+	start := time.Now()
+	var result int64
+	var err error
+
+	if c.options.EnableCircuitBreaker && c.isCircuitOpen() {
+		err = resilience.ErrCircuitOpen
+	} else {
+		result, err = script.Run(ctx, c.client, keys, args...).Int64()
+	}
+
+	if err != nil {
+		c.recordMetrics(time.Since(start), op+"_Error")
+		c.handleCircuitBreaker(err)
+		logFailure(op, err,
+			logger.String("key", key),
+		)
+		return 0, fmt.Errorf("redis %s operation failed for key %s: %w", strings.ToLower(op), key, err)
+	}
+
+	c.recordMetrics(time.Since(start), op+"_Success")
+	c.resetCircuitBreaker()
+	return result, nil
+}
+
+// Client returns the underlying go-redis client for features the CacheClient interface does
+// not cover, such as streams. Calls made on it bypass the circuit breaker and metrics.
+func (c *RedisClient) Client() *redis.Client {
+	return c.client
+}
+
 // HealthCheck pings the Redis server to check its availability.
 func (c *RedisClient) HealthCheck(ctx context.Context) error {
 	start := time.Now()
@@ -322,54 +682,29 @@ func (c *RedisClient) Close() error {
 
 // isCircuitOpen checks if the circuit breaker is currently open.
 func (c *RedisClient) isCircuitOpen() bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	return c.breaker.Allow() != nil
+}
 
-	if c.circuitState == CircuitOpen {
-		if time.Since(c.lastFailureTime) > c.options.CircuitBreakerTimeout {
-			c.mu.RUnlock()
-			c.mu.Lock()
-			defer c.mu.Unlock()
-			if c.circuitState == CircuitOpen {
-				c.circuitState = CircuitHalfOpen
-				logger.Warn("Circuit breaker moved to Half-Open state", logger.String("last_failure_time", c.lastFailureTime.String()))
-			}
-			return false
+// logFailure logs a failed operation. While the circuit is open every operation fails
+// the same way, so those failures are logged at a limited rate per operation.
+func logFailure(op string, err error, fields ...logger.Field) {
+	if errors.Is(err, resilience.ErrCircuitOpen) {
+		ok, suppressed := logger.Allow("redis:" + op)
+		if !ok {
+			return
 		}
-		return true
+		fields = append(fields, logger.Int("suppressed", suppressed))
 	}
-	return false
+	fields = append(fields, logger.ErrorField(err), logger.String("op", op))
+	logger.Error("Redis "+op+" failed", fields...)
 }
 
-// handleCircuitBreaker increments failure count and opens the circuit if threshold is met.
+// handleCircuitBreaker counts a failure, opening the circuit if the threshold is met.
 func (c *RedisClient) handleCircuitBreaker(err error) {
-	if !c.options.EnableCircuitBreaker {
+	if !c.options.EnableCircuitBreaker || errors.Is(err, resilience.ErrCircuitOpen) {
 		return
 	}
-
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if err != nil && !errors.Is(err, redis.Nil) {
-		c.failureCount++
-		c.lastFailureTime = time.Now()
-		logger.Debug("Circuit breaker: failure counted", logger.Int("failure_count", c.failureCount), logger.ErrorField(err))
-
-		if c.circuitState == CircuitHalfOpen && c.failureCount > 0 {
-			c.circuitState = CircuitOpen
-			c.failureCount = 0
-			logger.Error("Circuit breaker moved from Half-Open back to Open state due to failure")
-			return
-		}
-
-		if c.circuitState == CircuitClosed && c.failureCount >= c.options.CircuitBreakerThreshold {
-			c.circuitState = CircuitOpen
-			logger.Error("Circuit breaker opened due to consecutive failures",
-				logger.Int("threshold", c.options.CircuitBreakerThreshold),
-				logger.Int("failure_count", c.failureCount),
-			)
-		}
-	}
+	c.breaker.Record(err)
 }
 
 // resetCircuitBreaker resets the circuit breaker to closed state on success.
@@ -377,15 +712,7 @@ func (c *RedisClient) resetCircuitBreaker() {
 	if !c.options.EnableCircuitBreaker {
 		return
 	}
-
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.circuitState != CircuitClosed {
-		logger.Info("Circuit breaker reset to Closed state")
-	}
-	c.circuitState = CircuitClosed
-	c.failureCount = 0
+	c.breaker.Record(nil)
 }
 
 // recordMetrics updates operation metrics.
@@ -399,15 +726,19 @@ func (c *RedisClient) recordMetrics(latency time.Duration, op string) {
 
 	c.metrics.requests++
 	c.metrics.latency += latency
+	c.metrics.totals.Requests++
+	c.metrics.totals.Latency += latency
 
-	switch op {
-	case "Get_Hit":
+	switch {
+	case op == "Get_Hit":
 		c.metrics.hits++
-	case "Get_Miss":
+		c.metrics.totals.Hits++
+	case op == "Get_Miss":
 		c.metrics.misses++
-	case "Set_Error", "Get_Error", "Delete_Error", "Update_Error",
-		"HealthCheck_Error":
+		c.metrics.totals.Misses++
+	case strings.HasSuffix(op, "_Error"):
 		c.metrics.errors++
+		c.metrics.totals.Errors++
 	}
 
 	if c.metrics.requests%1000 == 0 || time.Since(c.metrics.lastResetTime) > time.Minute {
@@ -434,6 +765,18 @@ func (c *RedisClient) recordMetrics(latency time.Duration, op string) {
 	}
 }
 
+// Stats returns the operation counters accumulated since the client was created and the
+// state of the circuit breaker.
+func (c *RedisClient) Stats() CacheStats {
+	c.mu.RLock()
+	stats := c.metrics.totals
+	c.mu.RUnlock()
+
+	stats.CircuitState = c.breaker.State()
+	stats.ConsecutiveFailures = c.breaker.Failures()
+	return stats
+}
+
 // GetMetrics returns current Redis operation metrics (for monitoring).
 func (c *RedisClient) GetMetrics() map[string]interface{} {
 	c.mu.RLock()
@@ -450,7 +793,7 @@ func (c *RedisClient) GetMetrics() map[string]interface{} {
 		"hits":          c.metrics.hits,
 		"misses":        c.metrics.misses,
 		"avg_latency":   avgLatency.String(),
-		"circuit_state": c.circuitState,
-		"failure_count": c.failureCount,
+		"circuit_state": c.breaker.State().String(),
+		"failure_count": c.breaker.Failures(),
 	}
 }
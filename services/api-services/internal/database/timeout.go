@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryDeadlineKey is the statement instance key a statement deadline is kept under until
+// the statement completes
+const queryDeadlineKey = "database:query_deadline"
+
+// queryDeadline is the deadline given to a statement and the context it replaced
+type queryDeadline struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// timeoutPlugin bounds every GORM statement run with a context that has no deadline, such
+// as those of background loops or startup work, so that a stuck query cannot hold a
+// connection forever. Statements of requests and jobs already expire with their context.
+// Row and Rows statements are not bounded, since their rows are read after the statement
+// completes.
+type timeoutPlugin struct {
+	timeout time.Duration
+}
+
+// newTimeoutPlugin creates the plugin bounding statements to timeout
+func newTimeoutPlugin(timeout time.Duration) *timeoutPlugin {
+	return &timeoutPlugin{timeout: timeout}
+}
+
+// Name returns the plugin name
+func (p *timeoutPlugin) Name() string {
+	return "database:query_timeout"
+}
+
+// Initialize wraps the statement types whose results are read within the statement
+func (p *timeoutPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	err := errors.Join(
+		cb.Create().Before("*").Register("database:deadline_create", p.begin),
+		cb.Create().After("*").Register("database:release_create", p.end),
+		cb.Query().Before("*").Register("database:deadline_query", p.begin),
+		cb.Query().After("*").Register("database:release_query", p.end),
+		cb.Update().Before("*").Register("database:deadline_update", p.begin),
+		cb.Update().After("*").Register("database:release_update", p.end),
+		cb.Delete().Before("*").Register("database:deadline_delete", p.begin),
+		cb.Delete().After("*").Register("database:release_delete", p.end),
+		cb.Raw().Before("*").Register("database:deadline_raw", p.begin),
+		cb.Raw().After("*").Register("database:release_raw", p.end),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register query timeout callbacks: %w", err)
+	}
+	return nil
+}
+
+// begin gives the statement a deadline when its context has none
+func (p *timeoutPlugin) begin(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || db.Statement.Context == nil {
+		return
+	}
+	if _, ok := db.Statement.Context.Deadline(); ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(db.Statement.Context, p.timeout)
+	db.InstanceSet(queryDeadlineKey, queryDeadline{parent: db.Statement.Context, cancel: cancel})
+	db.Statement.Context = ctx
+}
+
+// end releases the deadline of the statement and restores its context, since a query
+// such as a count may be chained into another statement afterwards
+func (p *timeoutPlugin) end(db *gorm.DB) {
+	value, ok := db.InstanceGet(queryDeadlineKey)
+	if !ok {
+		return
+	}
+	deadline := value.(queryDeadline)
+	if deadline.cancel == nil {
+		return
+	}
+	deadline.cancel()
+	db.Statement.Context = deadline.parent
+	db.InstanceSet(queryDeadlineKey, queryDeadline{})
+}
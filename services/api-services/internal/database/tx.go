@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txContextKey is the context key under which RunInTx stores the open transaction
+type txContextKey struct{}
+
+// WithTx returns a copy of ctx carrying tx, so repositories resolving their
+// connection through Conn join the transaction instead of using their own pool.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// Conn returns the transaction carried by ctx, or db bound to ctx when there is none
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok && tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// RunInTx runs fn inside a transaction on db. The context passed to fn carries the
+// transaction, so every write made through Conn commits or rolls back together.
+// Calls nested inside fn reuse the outer transaction.
+func RunInTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok && tx != nil {
+		return fn(ctx)
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
+
+// Transactor runs service operations atomically without exposing the connection
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// gormTransactor implements Transactor with RunInTx
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor creates a Transactor opening transactions on db
+func NewTransactor(db *gorm.DB) Transactor {
+	return &gormTransactor{db: db}
+}
+
+// RunInTx implements Transactor
+func (t *gormTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, t.db, fn)
+}
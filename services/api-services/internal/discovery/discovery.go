@@ -0,0 +1,224 @@
+// Package discovery proposes monitors for the endpoints a site or API publishes in its
+// sitemap or OpenAPI document, so that teams can monitor them without entering each one.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+)
+
+// Discovery sources
+const (
+	SourceAuto    = "auto"
+	SourceSitemap = "sitemap"
+	SourceOpenAPI = "openapi"
+)
+
+const (
+	// fetchTimeout bounds the fetch of a single document
+	fetchTimeout = 10 * time.Second
+
+	// maxDocumentSize is the largest document read; larger ones are rejected
+	maxDocumentSize = 5 << 20
+
+	// maxNestedSitemaps is how many sitemaps of a sitemap index are followed
+	maxNestedSitemaps = 10
+
+	// maxNameLength is the longest monitor name proposed, the limit of monitor names
+	maxNameLength = 100
+)
+
+// sitemapPaths and openAPIPaths are where documents are looked for when the base URL is
+// not one
+var (
+	sitemapPaths = []string{"/sitemap.xml", "/sitemap_index.xml"}
+	openAPIPaths = []string{"/openapi.json", "/openapi.yaml", "/swagger.json", "/v3/api-docs", "/api-docs", "/swagger/v1/swagger.json"}
+)
+
+var (
+	// ErrInvalidURL is returned for base URLs that are not absolute http or https URLs
+	ErrInvalidURL = errors.New("invalid discovery url")
+	// ErrNoDocument is returned when no sitemap or OpenAPI document was found
+	ErrNoDocument = errors.New("no sitemap or openapi document found")
+	// ErrBlockedAddress is returned when a URL resolves to an address that is not public
+	ErrBlockedAddress = httpclient.ErrBlockedAddress
+)
+
+// Endpoint is an endpoint proposed to be monitored
+type Endpoint struct {
+	Name   string
+	Target string
+}
+
+// Result lists the endpoints found in a document
+type Result struct {
+	Source      string
+	DocumentURL string
+	Endpoints   []Endpoint
+	// Truncated is set when the document had more endpoints than were asked for
+	Truncated bool
+}
+
+// Discoverer fetches and reads sitemaps and OpenAPI documents. It is safe for concurrent use.
+type Discoverer struct {
+	client *http.Client
+}
+
+// New creates a discoverer. Documents are fetched from public addresses only, since the
+// URLs are given by users.
+func New() *Discoverer {
+	return &Discoverer{client: newClient(fetchTimeout)}
+}
+
+// newClient creates the client fetching documents. It does not retry, and it does not break
+// circuits since every call may go to another host.
+func newClient(timeout time.Duration) *http.Client {
+	client := httpclient.New(httpclient.Options{
+		Name:           "discovery",
+		Timeout:        timeout,
+		MaxAttempts:    1,
+		DisableBreaker: true,
+		Transport:      httpclient.NewPublicTransport(),
+	})
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return errors.New("stopped after 5 redirects")
+		}
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+		}
+		return nil
+	}
+	return client
+}
+
+// Discover returns up to limit endpoints of the site or API at baseURL. baseURL may be the
+// URL of a document, or the root of a site where documents are looked for at their usual
+// paths. source restricts the documents to sitemaps or OpenAPI documents; SourceAuto reads
+// the first one found.
+func (d *Discoverer) Discover(ctx context.Context, baseURL, source string, limit int) (*Result, error) {
+	base, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, ErrInvalidURL
+	}
+	base.Fragment = ""
+
+	// The base URL itself is tried first, in case it is a document
+	candidates := []*url.URL{base}
+	if source != SourceOpenAPI {
+		candidates = append(candidates, resolvePaths(base, sitemapPaths)...)
+	}
+	if source != SourceSitemap {
+		candidates = append(candidates, resolvePaths(base, openAPIPaths)...)
+	}
+
+	var fetchErr error
+	for _, candidate := range candidates {
+		body, err := d.fetch(ctx, candidate.String())
+		if err != nil {
+			if errors.Is(err, ErrBlockedAddress) || ctx.Err() != nil {
+				return nil, err
+			}
+			fetchErr = err
+			continue
+		}
+
+		result, err := d.parseDocument(ctx, candidate, body, source, limit)
+		if errors.Is(err, errUnknownDocument) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.DocumentURL = candidate.String()
+		return result, nil
+	}
+
+	if fetchErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoDocument, fetchErr)
+	}
+	return nil, ErrNoDocument
+}
+
+// errUnknownDocument is returned by parseDocument for documents of another kind
+var errUnknownDocument = errors.New("unknown document")
+
+// parseDocument reads a sitemap or OpenAPI document fetched from documentURL, failing with
+// errUnknownDocument for other documents
+func (d *Discoverer) parseDocument(ctx context.Context, documentURL *url.URL, body []byte, source string, limit int) (*Result, error) {
+	if source != SourceOpenAPI {
+		if sitemap, ok := decodeSitemap(body); ok {
+			endpoints, truncated := d.sitemapEndpoints(ctx, documentURL, sitemap, limit)
+			return &Result{Source: SourceSitemap, Endpoints: endpoints, Truncated: truncated}, nil
+		}
+	}
+	if source != SourceSitemap {
+		if document, ok := decodeOpenAPI(body); ok {
+			endpoints, truncated := document.endpoints(documentURL, limit)
+			return &Result{Source: SourceOpenAPI, Endpoints: endpoints, Truncated: truncated}, nil
+		}
+	}
+	return nil, errUnknownDocument
+}
+
+// fetch returns the body of a successful GET of rawURL
+func (d *Discoverer) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml, application/xml, text/xml;q=0.9, */*;q=0.5")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", rawURL, err)
+	}
+	if len(body) > maxDocumentSize {
+		return nil, fmt.Errorf("GET %s: document larger than %d bytes", rawURL, maxDocumentSize)
+	}
+	return body, nil
+}
+
+// resolvePaths returns the URLs of paths at the root of base
+func resolvePaths(base *url.URL, paths []string) []*url.URL {
+	urls := make([]*url.URL, 0, len(paths))
+	for _, path := range paths {
+		u := *base
+		u.Path, u.RawPath, u.RawQuery = path, "", ""
+		if u.String() != base.String() {
+			urls = append(urls, &u)
+		}
+	}
+	return urls
+}
+
+// truncateName shortens a monitor name to maxNameLength characters
+func truncateName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if utf8.RuneCountInString(name) <= maxNameLength {
+		return name
+	}
+	runes := []rune(name)
+	return string(runes[:maxNameLength-1]) + "…"
+}
@@ -0,0 +1,138 @@
+package discovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDocument holds the parts of an OpenAPI 3 or Swagger 2 document that locate its
+// operations
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi" yaml:"openapi"`
+	Swagger string `json:"swagger" yaml:"swagger"`
+	Info    struct {
+		Title string `json:"title" yaml:"title"`
+	} `json:"info" yaml:"info"`
+	Servers []struct {
+		URL string `json:"url" yaml:"url"`
+	} `json:"servers" yaml:"servers"`
+	Host     string                    `json:"host" yaml:"host"`
+	BasePath string                    `json:"basePath" yaml:"basePath"`
+	Schemes  []string                  `json:"schemes" yaml:"schemes"`
+	Security []map[string]any          `json:"security" yaml:"security"`
+	Paths    map[string]map[string]any `json:"paths" yaml:"paths"`
+}
+
+// decodeOpenAPI parses body as an OpenAPI or Swagger document, in JSON or YAML
+func decodeOpenAPI(body []byte) (*openAPIDocument, bool) {
+	var document openAPIDocument
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		err = json.Unmarshal(body, &document)
+	} else {
+		err = yaml.Unmarshal(body, &document)
+	}
+	if err != nil || (document.OpenAPI == "" && document.Swagger == "") || document.Paths == nil {
+		return nil, false
+	}
+	return &document, true
+}
+
+// endpoints returns up to limit endpoints of the document, and whether there were more.
+// Only GET operations without path parameters or required authentication are proposed,
+// since probes cannot fill in the one or pass the other.
+func (d *openAPIDocument) endpoints(documentURL *url.URL, limit int) ([]Endpoint, bool) {
+	server := d.serverURL(documentURL)
+
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var endpoints []Endpoint
+	for _, path := range paths {
+		operation, ok := d.Paths[path]["get"].(map[string]any)
+		if !ok || strings.Contains(path, "{") || d.requiresAuth(operation) {
+			continue
+		}
+		if len(endpoints) == limit {
+			return endpoints, true
+		}
+
+		target := *server
+		target.Path = strings.TrimSuffix(server.Path, "/") + "/" + strings.TrimPrefix(path, "/")
+		target.RawPath = ""
+		name, _ := operation["summary"].(string)
+		if strings.TrimSpace(name) == "" {
+			name = "GET " + path
+		}
+		if d.Info.Title != "" {
+			name = d.Info.Title + " - " + name
+		}
+		endpoints = append(endpoints, Endpoint{Name: truncateName(name), Target: target.String()})
+	}
+	return endpoints, false
+}
+
+// serverURL returns the URL operation paths are relative to: the first server of an OpenAPI
+// document, the host and base path of a Swagger document, resolved against the URL the
+// document was fetched from. Server URLs with variables fall back to the document origin.
+func (d *openAPIDocument) serverURL(documentURL *url.URL) *url.URL {
+	origin := &url.URL{Scheme: documentURL.Scheme, Host: documentURL.Host}
+
+	if d.Swagger != "" {
+		server := *origin
+		if d.Host != "" {
+			server.Host = d.Host
+		}
+		if len(d.Schemes) > 0 && (d.Schemes[0] == "http" || d.Schemes[0] == "https") {
+			server.Scheme = d.Schemes[0]
+		}
+		server.Path = d.BasePath
+		return &server
+	}
+
+	if len(d.Servers) == 0 || strings.Contains(d.Servers[0].URL, "{") {
+		return origin
+	}
+	server, err := documentURL.Parse(d.Servers[0].URL)
+	if err != nil || (server.Scheme != "http" && server.Scheme != "https") {
+		return origin
+	}
+	server.RawQuery, server.Fragment = "", ""
+	return server
+}
+
+// requiresAuth reports whether an operation requires authentication: it, or else the
+// document, lists security requirements and none of them is the empty, anonymous one
+func (d *openAPIDocument) requiresAuth(operation map[string]any) bool {
+	var requirements []any
+	if raw, ok := operation["security"]; ok {
+		requirements, _ = raw.([]any)
+	} else {
+		for _, requirement := range d.Security {
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	if len(requirements) == 0 {
+		return false
+	}
+	for _, requirement := range requirements {
+		switch r := requirement.(type) {
+		case map[string]any:
+			if len(r) == 0 {
+				return false
+			}
+		case nil:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// sitemap is a sitemap or a sitemap index, https://www.sitemaps.org/protocol.html
+type sitemap struct {
+	XMLName  xml.Name
+	URLs     []sitemapLocation `xml:"url"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+// decodeSitemap parses body as a sitemap or a sitemap index
+func decodeSitemap(body []byte) (*sitemap, bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		return nil, false
+	}
+	var s sitemap
+	if err := xml.Unmarshal(body, &s); err != nil {
+		return nil, false
+	}
+	if s.XMLName.Local != "urlset" && s.XMLName.Local != "sitemapindex" {
+		return nil, false
+	}
+	return &s, true
+}
+
+// sitemapEndpoints returns up to limit pages of a sitemap on the host of the sitemap, and
+// whether there were more. The sitemaps of an index are read in order, up to
+// maxNestedSitemaps; the ones that cannot be read are skipped.
+func (d *Discoverer) sitemapEndpoints(ctx context.Context, documentURL *url.URL, root *sitemap, limit int) ([]Endpoint, bool) {
+	var endpoints []Endpoint
+	seen := make(map[string]bool)
+	add := func(s *sitemap) bool {
+		for _, location := range s.URLs {
+			target, ok := sameHostURL(documentURL, location.Loc)
+			if !ok || seen[target.String()] {
+				continue
+			}
+			if len(endpoints) == limit {
+				return true
+			}
+			seen[target.String()] = true
+			endpoints = append(endpoints, Endpoint{Name: pageName(target), Target: target.String()})
+		}
+		return false
+	}
+
+	if add(root) {
+		return endpoints, true
+	}
+	for i, location := range root.Sitemaps {
+		if i == maxNestedSitemaps {
+			return endpoints, true
+		}
+		nestedURL, ok := sameHostURL(documentURL, location.Loc)
+		if !ok {
+			continue
+		}
+		body, err := d.fetch(ctx, nestedURL.String())
+		if err != nil {
+			logger.DebugCtx(ctx, "Skipping unreadable sitemap", logger.String("url", nestedURL.String()), logger.ErrorField(err))
+			continue
+		}
+		nested, ok := decodeSitemap(body)
+		if !ok {
+			continue
+		}
+		if add(nested) {
+			return endpoints, true
+		}
+	}
+	return endpoints, false
+}
+
+// sameHostURL parses a location of a sitemap, which is only kept when it is an http or https
+// URL on the host of the sitemap
+func sameHostURL(documentURL *url.URL, location string) (*url.URL, bool) {
+	target, err := documentURL.Parse(strings.TrimSpace(location))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || !strings.EqualFold(target.Hostname(), documentURL.Hostname()) {
+		return nil, false
+	}
+	target.Fragment = ""
+	return target, true
+}
+
+// pageName names the monitor of a page after its host and path
+func pageName(target *url.URL) string {
+	return truncateName(target.Host + strings.TrimSuffix(target.EscapedPath(), "/"))
+}
@@ -0,0 +1,125 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	uptimev1 "github.com/samaasi/uptime-application/services/api-services/pkg/pb/uptime/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamFlushSize is the number of streamed results buffered before they are ingested.
+const streamFlushSize = 500
+
+// checkResultHandler implements uptimev1.CheckResultServiceServer
+type checkResultHandler struct {
+	uptimev1.UnimplementedCheckResultServiceServer
+	checkResultService *services.CheckResultService
+}
+
+func newCheckResultHandler(checkResultService *services.CheckResultService) *checkResultHandler {
+	return &checkResultHandler{checkResultService: checkResultService}
+}
+
+func (h *checkResultHandler) IngestCheckResults(ctx context.Context, req *uptimev1.IngestCheckResultsRequest) (*uptimev1.IngestCheckResultsResponse, error) {
+	results, rejected := fromProtoCheckResults(req.GetResults())
+
+	accepted, ingestRejected, err := h.checkResultService.Ingest(ctx, results)
+	if err != nil {
+		return nil, ingestError(err)
+	}
+
+	return &uptimev1.IngestCheckResultsResponse{
+		Accepted: int32(accepted),
+		Rejected: int32(rejected + ingestRejected),
+	}, nil
+}
+
+func (h *checkResultHandler) StreamCheckResults(stream uptimev1.CheckResultService_StreamCheckResultsServer) error {
+	ctx := stream.Context()
+	buffer := make([]*uptimev1.CheckResult, 0, streamFlushSize)
+	var accepted, rejected int
+
+	flush := func() error {
+		if len(buffer) == 0 {
+			return nil
+		}
+		results, invalid := fromProtoCheckResults(buffer)
+		buffer = buffer[:0]
+
+		a, r, err := h.checkResultService.Ingest(ctx, results)
+		if err != nil {
+			return ingestError(err)
+		}
+		accepted += a
+		rejected += invalid + r
+		return nil
+	}
+
+	for {
+		result, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if err := flush(); err != nil {
+				return err
+			}
+			return stream.SendAndClose(&uptimev1.IngestCheckResultsResponse{
+				Accepted: int32(accepted),
+				Rejected: int32(rejected),
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		buffer = append(buffer, result)
+		if len(buffer) >= streamFlushSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// fromProtoCheckResults converts wire results, counting those with malformed monitor IDs as rejected.
+func fromProtoCheckResults(in []*uptimev1.CheckResult) ([]models.CheckResult, int) {
+	results := make([]models.CheckResult, 0, len(in))
+	rejected := 0
+	for _, r := range in {
+		monitorID, err := uuid.Parse(r.GetMonitorId())
+		if err != nil {
+			rejected++
+			continue
+		}
+
+		result := models.CheckResult{
+			MonitorID:  monitorID,
+			ProbeID:    r.GetProbeId(),
+			Region:     r.GetRegion(),
+			Status:     r.GetStatus(),
+			LatencyMs:  r.GetLatencyMs(),
+			StatusCode: r.GetStatusCode(),
+			Error:      r.GetError(),
+		}
+		if r.GetCheckedAt() != nil {
+			result.CheckedAt = r.GetCheckedAt().AsTime()
+		}
+		results = append(results, result)
+	}
+	return results, rejected
+}
+
+func ingestError(err error) error {
+	if errors.Is(err, repositories.ErrCheckResultStoreDisabled) {
+		return status.Error(codes.Unavailable, "check result storage is not configured")
+	}
+	logger.Error("Failed to ingest check results over gRPC", logger.ErrorField(err))
+	return status.Error(codes.Internal, "failed to ingest check results")
+}
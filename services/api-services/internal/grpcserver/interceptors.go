@@ -0,0 +1,158 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthServicePrefix is exempt from authentication so orchestrators can probe the server.
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panic",
+					logger.String("method", info.FullMethod),
+					logger.Any("panic", r),
+					logger.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC stream handler panic",
+					logger.String("method", info.FullMethod),
+					logger.Any("panic", r),
+					logger.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func loggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = withTrace(ctx)
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func loggingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		traced := &tracedStream{ServerStream: ss, ctx: withTrace(ss.Context())}
+		err := handler(srv, traced)
+		logRPC(traced.ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// tracedStream is a server stream whose context carries the trace of the call
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream with its trace
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// withTrace stores the trace propagated in the "traceparent" metadata in ctx, so entries
+// logged with it can be matched with the caller's trace
+func withTrace(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get("traceparent")
+	if len(values) == 0 {
+		return ctx
+	}
+	if trace, ok := logger.ParseTraceparent(values[0]); ok {
+		return logger.ContextWithTrace(ctx, trace)
+	}
+	return ctx
+}
+
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	if strings.HasPrefix(method, healthServicePrefix) {
+		return
+	}
+
+	code := status.Code(err)
+	fields := []logger.Field{
+		logger.String("method", method),
+		logger.String("code", code.String()),
+		logger.Duration("latency", time.Since(start)),
+	}
+	if err != nil && code == codes.Internal {
+		logger.ErrorCtx(ctx, "gRPC request failed", append(fields, logger.ErrorField(err))...)
+		return
+	}
+	logger.InfoCtx(ctx, "gRPC request", fields...)
+}
+
+func authUnaryInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, info.FullMethod, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStreamInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), info.FullMethod, token); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize validates the shared bearer token sent in the "authorization" metadata.
+func authorize(ctx context.Context, method, token string) error {
+	if strings.HasPrefix(method, healthServicePrefix) {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+
+	provided := strings.TrimPrefix(values[0], "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid authorization token")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	uptimev1 "github.com/samaasi/uptime-application/services/api-services/pkg/pb/uptime/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// monitorHandler implements uptimev1.MonitorServiceServer
+type monitorHandler struct {
+	uptimev1.UnimplementedMonitorServiceServer
+	monitorService *services.MonitorService
+}
+
+func newMonitorHandler(monitorService *services.MonitorService) *monitorHandler {
+	return &monitorHandler{monitorService: monitorService}
+}
+
+func (h *monitorHandler) ListMonitors(ctx context.Context, req *uptimev1.ListMonitorsRequest) (*uptimev1.ListMonitorsResponse, error) {
+	var organizationID *uuid.UUID
+	if req.GetOrganizationId() != "" {
+		id, err := uuid.Parse(req.GetOrganizationId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid organization_id")
+		}
+		organizationID = &id
+	}
+
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	monitors, hasMore, err := h.monitorService.ListActiveMonitors(ctx, organizationID, int(req.GetPageSize()), offset)
+	if err != nil {
+		logger.Error("Failed to list monitors over gRPC", logger.ErrorField(err))
+		return nil, status.Error(codes.Internal, "failed to list monitors")
+	}
+
+	resp := &uptimev1.ListMonitorsResponse{
+		Monitors: make([]*uptimev1.Monitor, 0, len(monitors)),
+	}
+	for i := range monitors {
+		resp.Monitors = append(resp.Monitors, toProtoMonitor(&monitors[i]))
+	}
+	if hasMore {
+		resp.NextPageToken = encodePageToken(offset + len(monitors))
+	}
+	return resp, nil
+}
+
+func (h *monitorHandler) GetMonitor(ctx context.Context, req *uptimev1.GetMonitorRequest) (*uptimev1.GetMonitorResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	monitor, err := h.monitorService.GetMonitor(ctx, id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "monitor not found")
+		}
+		logger.Error("Failed to get monitor over gRPC", logger.ErrorField(err))
+		return nil, status.Error(codes.Internal, "failed to get monitor")
+	}
+
+	return &uptimev1.GetMonitorResponse{Monitor: toProtoMonitor(monitor)}, nil
+}
+
+func toProtoMonitor(m *models.Monitor) *uptimev1.Monitor {
+	pm := &uptimev1.Monitor{
+		Id:              m.ID.String(),
+		OrganizationId:  m.OrganizationID.String(),
+		Name:            m.Name,
+		Type:            m.Type,
+		Target:          m.Target,
+		IntervalSeconds: int32(m.IntervalSeconds),
+		TimeoutSeconds:  int32(m.TimeoutSeconds),
+		Status:          m.Status,
+	}
+	if m.LastCheckedAt != nil {
+		pm.LastCheckedAt = timestamppb.New(*m.LastCheckedAt)
+	}
+	return pm
+}
+
+// Page tokens are opaque to clients but simply encode the next offset.
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid page token")
+	}
+	return offset, nil
+}
@@ -0,0 +1,174 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/services"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	uptimev1 "github.com/samaasi/uptime-application/services/api-services/pkg/pb/uptime/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthChecker is a dependency whose availability determines the gRPC serving status.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// Server wraps the internal gRPC server used by probes and workers.
+type Server struct {
+	cfg      config.GRPCConfig
+	server   *grpc.Server
+	health   *health.Server
+	checkers map[string]HealthChecker
+	stopCh   chan struct{}
+}
+
+// New builds a gRPC server exposing the monitor, check result and health services.
+// monitorRepository is shared with the HTTP API so both invalidate the same monitor cache.
+// clickhouseClient may be nil when check results go to the Postgres fallback store, and
+// checkResultWriter is nil when no store is configured, in which case ingestion is rejected. publisher receives monitor status changes and incidents for live dashboards and may be nil.
+func New(
+	cfg config.GRPCConfig,
+	postgresClient, clickhouseClient database.Client,
+	monitorRepository repositories.MonitorRepository,
+	checkResultWriter *database.BatchWriter[models.CheckResult],
+	publisher realtime.Publisher,
+) (*Server, error) {
+	if postgresClient == nil {
+		return nil, fmt.Errorf("grpc server requires a PostgreSQL client")
+	}
+
+	// Probes only write check results; they are read through the HTTP API
+	checkResultRepository := repositories.NewCheckResultRepository(checkResultWriter, nil)
+
+	organizationRepository := repositories.NewOrganizationRepository(postgresClient.DB())
+	probeService := services.NewProbeService(repositories.NewProbeRepository(postgresClient.DB()))
+	monitorService := services.NewMonitorService(monitorRepository, organizationRepository)
+	incidentService := services.NewIncidentService(
+		repositories.NewIncidentRepository(postgresClient.DB()),
+		repositories.NewMonitorDependencyRepository(postgresClient.DB()),
+		monitorRepository,
+		organizationRepository,
+		probeService,
+		publisher,
+		outbox.NewPublisher(postgresClient.DB()),
+	)
+	checkResultService := services.NewCheckResultService(monitorRepository, checkResultRepository, incidentService, probeService, publisher)
+
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.ChainUnaryInterceptor(
+			recoveryUnaryInterceptor(),
+			loggingUnaryInterceptor(),
+			authUnaryInterceptor(cfg.AuthToken),
+		),
+		grpc.ChainStreamInterceptor(
+			recoveryStreamInterceptor(),
+			loggingStreamInterceptor(),
+			authStreamInterceptor(cfg.AuthToken),
+		),
+	)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	uptimev1.RegisterMonitorServiceServer(grpcServer, newMonitorHandler(monitorService))
+	uptimev1.RegisterCheckResultServiceServer(grpcServer, newCheckResultHandler(checkResultService))
+
+	checkers := map[string]HealthChecker{"postgres": postgresClient}
+	if clickhouseClient != nil {
+		checkers["clickhouse"] = clickhouseClient
+	}
+
+	return &Server{
+		cfg:      cfg,
+		server:   grpcServer,
+		health:   healthServer,
+		checkers: checkers,
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start listens on the configured port and serves until Stop is called.
+// It blocks, so callers normally run it in a goroutine.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", ":"+s.cfg.Port)
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port %s: %w", s.cfg.Port, err)
+	}
+
+	s.updateHealth()
+	go s.watchHealth()
+
+	logger.Info("gRPC server listening", logger.String("port", s.cfg.Port))
+	if err := s.server.Serve(lis); err != nil {
+		return fmt.Errorf("gRPC server stopped: %w", err)
+	}
+	return nil
+}
+
+// Stop marks the server as not serving and drains in-flight RPCs until ctx expires.
+func (s *Server) Stop(ctx context.Context) {
+	close(s.stopCh)
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Warn("gRPC graceful stop timed out, forcing shutdown")
+		s.server.Stop()
+	}
+}
+
+// watchHealth periodically refreshes the serving status from dependency health checks.
+func (s *Server) watchHealth() {
+	ticker := time.NewTicker(s.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.updateHealth()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// updateHealth reports NOT_SERVING for every service when any dependency is unhealthy.
+func (s *Server) updateHealth() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	for name, checker := range s.checkers {
+		if err := checker.HealthCheck(ctx); err != nil {
+			logger.Warn("gRPC dependency health check failed",
+				logger.String("dependency", name),
+				logger.ErrorField(err),
+			)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(uptimev1.MonitorService_ServiceDesc.ServiceName, status)
+	s.health.SetServingStatus(uptimev1.CheckResultService_ServiceDesc.ServiceName, status)
+}
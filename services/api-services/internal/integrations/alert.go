@@ -0,0 +1,128 @@
+// Package integrations parses the alert webhooks of external monitoring tools, so that
+// teams migrating to the platform can forward their existing alerts as incidents.
+package integrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Supported providers
+const (
+	ProviderAlertmanager = "alertmanager"
+	ProviderGrafana      = "grafana"
+	ProviderUptimeRobot  = "uptimerobot"
+)
+
+// Alert severities
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// maxTitleLength is the longest alert title kept; longer ones are truncated
+const maxTitleLength = 255
+
+var (
+	// ErrUnsupportedProvider is returned for providers Parse does not know
+	ErrUnsupportedProvider = errors.New("unsupported integration provider")
+	// ErrInvalidPayload is returned for webhook payloads that cannot be parsed
+	ErrInvalidPayload = errors.New("invalid integration payload")
+)
+
+// Alert is a firing or resolved alert reported by an external tool. The fingerprint
+// identifies the alert across notifications, so that a resolution matches the alert that
+// fired.
+type Alert struct {
+	Fingerprint string
+	Title       string
+	Description string
+	Severity    string
+	Resolved    bool
+	StartedAt   time.Time
+	EndedAt     *time.Time
+	URL         string
+	Labels      map[string]string
+}
+
+// Parse returns the alerts of a webhook payload sent by provider. Notifications that do
+// not report a firing or resolved alert, such as pending or paused states, yield no alerts.
+func Parse(provider string, payload []byte) ([]Alert, error) {
+	var (
+		alerts []Alert
+		err    error
+	)
+	switch provider {
+	case ProviderAlertmanager:
+		alerts, err = parseAlertmanager(payload)
+	case ProviderGrafana:
+		alerts, err = parseGrafana(payload)
+	case ProviderUptimeRobot:
+		alerts, err = parseUptimeRobot(payload)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedProvider, provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range alerts {
+		alerts[i].Title = truncate(strings.TrimSpace(alerts[i].Title), maxTitleLength)
+		if alerts[i].Title == "" {
+			alerts[i].Title = "Alert from " + provider
+		}
+		if alerts[i].StartedAt.IsZero() {
+			alerts[i].StartedAt = time.Now().UTC()
+		}
+	}
+	return alerts, nil
+}
+
+// normalizeSeverity maps the severity labels used by alerting rules to an alert severity,
+// defaulting to critical
+func normalizeSeverity(severity string) string {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "warning", "warn", "minor", "medium":
+		return SeverityWarning
+	case "info", "informational", "low", "none":
+		return SeverityInfo
+	default:
+		return SeverityCritical
+	}
+}
+
+// labelFingerprint derives a stable fingerprint from labels, for payloads that do not
+// carry one
+func labelFingerprint(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(labels[k]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
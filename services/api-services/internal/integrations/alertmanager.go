@@ -0,0 +1,83 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// alertmanagerPayload is the webhook payload of Prometheus Alertmanager (version 4), which
+// Grafana unified alerting also sends
+type alertmanagerPayload struct {
+	Version string              `json:"version"`
+	Status  string              `json:"status"`
+	Alerts  []alertmanagerAlert `json:"alerts"`
+}
+
+type alertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+
+	// Set by Grafana only
+	DashboardURL string `json:"dashboardURL"`
+	PanelURL     string `json:"panelURL"`
+}
+
+func parseAlertmanager(payload []byte) ([]Alert, error) {
+	var body alertmanagerPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if body.Alerts == nil {
+		return nil, fmt.Errorf("%w: missing alerts", ErrInvalidPayload)
+	}
+	return convertAlertmanagerAlerts(body.Alerts), nil
+}
+
+// convertAlertmanagerAlerts converts the firing and resolved alerts of a payload. The
+// summary annotation, or else the alertname label, is the title of an alert.
+func convertAlertmanagerAlerts(alerts []alertmanagerAlert) []Alert {
+	converted := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if a.Status != "firing" && a.Status != "resolved" {
+			continue
+		}
+
+		alert := Alert{
+			Fingerprint: a.Fingerprint,
+			Title:       a.Annotations["summary"],
+			Description: a.Annotations["description"],
+			Severity:    normalizeSeverity(a.Labels["severity"]),
+			Resolved:    a.Status == "resolved",
+			StartedAt:   a.StartsAt.UTC(),
+			URL:         firstNonEmpty(a.PanelURL, a.DashboardURL, a.GeneratorURL),
+			Labels:      a.Labels,
+		}
+		if alert.Fingerprint == "" {
+			alert.Fingerprint = labelFingerprint(a.Labels)
+		}
+		if alert.Title == "" {
+			alert.Title = a.Labels["alertname"]
+		}
+		if alert.Resolved && !a.EndsAt.IsZero() {
+			ended := a.EndsAt.UTC()
+			alert.EndedAt = &ended
+		}
+		converted = append(converted, alert)
+	}
+	return converted
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
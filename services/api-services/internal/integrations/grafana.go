@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// grafanaPayload holds both Grafana webhook formats: unified alerting sends the
+// Alertmanager payload with its alerts, legacy dashboard alerting a single rule state
+type grafanaPayload struct {
+	Alerts []alertmanagerAlert `json:"alerts"`
+
+	RuleID   int64             `json:"ruleId"`
+	RuleName string            `json:"ruleName"`
+	RuleURL  string            `json:"ruleUrl"`
+	State    string            `json:"state"`
+	Title    string            `json:"title"`
+	Message  string            `json:"message"`
+	Tags     map[string]string `json:"tags"`
+}
+
+func parseGrafana(payload []byte) ([]Alert, error) {
+	var body grafanaPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if body.Alerts != nil {
+		return convertAlertmanagerAlerts(body.Alerts), nil
+	}
+	if body.State == "" {
+		return nil, fmt.Errorf("%w: missing alerts or state", ErrInvalidPayload)
+	}
+
+	// Legacy alerting reports pending and paused rules too, which are not incidents
+	var resolved bool
+	switch body.State {
+	case "alerting", "no_data":
+	case "ok":
+		resolved = true
+	default:
+		return nil, nil
+	}
+
+	fingerprint := body.RuleName
+	if body.RuleID != 0 {
+		fingerprint = "rule:" + strconv.FormatInt(body.RuleID, 10)
+	}
+	if fingerprint == "" {
+		fingerprint = labelFingerprint(body.Tags)
+	}
+	title := body.RuleName
+	if title == "" {
+		title = body.Title
+	}
+	return []Alert{{
+		Fingerprint: fingerprint,
+		Title:       title,
+		Description: body.Message,
+		Severity:    normalizeSeverity(body.Tags["severity"]),
+		Resolved:    resolved,
+		URL:         body.RuleURL,
+		Labels:      body.Tags,
+	}}, nil
+}
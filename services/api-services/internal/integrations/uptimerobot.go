@@ -0,0 +1,99 @@
+package integrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// UptimeRobot alert types
+const (
+	uptimeRobotAlertDown = "1"
+	uptimeRobotAlertUp   = "2"
+)
+
+// parseUptimeRobot parses an UptimeRobot webhook alert contact, sent either as a JSON
+// object or as form values, with the monitorID, monitorURL, monitorFriendlyName,
+// alertType, alertDetails and alertDateTime variables. Alert types other than down and up,
+// such as SSL expiry, are not incidents.
+func parseUptimeRobot(payload []byte) ([]Alert, error) {
+	values, err := uptimeRobotValues(payload)
+	if err != nil {
+		return nil, err
+	}
+	monitorID := values["monitorID"]
+	if monitorID == "" {
+		return nil, fmt.Errorf("%w: missing monitorID", ErrInvalidPayload)
+	}
+
+	var resolved bool
+	switch values["alertType"] {
+	case uptimeRobotAlertDown:
+	case uptimeRobotAlertUp:
+		resolved = true
+	case "":
+		return nil, fmt.Errorf("%w: missing alertType", ErrInvalidPayload)
+	default:
+		return nil, nil
+	}
+
+	name := values["monitorFriendlyName"]
+	if name == "" {
+		name = firstNonEmpty(values["monitorURL"], "Monitor "+monitorID)
+	}
+	alert := Alert{
+		Fingerprint: "monitor:" + monitorID,
+		Title:       name + " is down",
+		Description: values["alertDetails"],
+		Severity:    SeverityCritical,
+		Resolved:    resolved,
+		URL:         values["monitorURL"],
+		Labels: map[string]string{
+			"monitor_id":  monitorID,
+			"monitor_url": values["monitorURL"],
+		},
+	}
+	if seconds, err := strconv.ParseInt(values["alertDateTime"], 10, 64); err == nil && seconds > 0 {
+		at := time.Unix(seconds, 0).UTC()
+		if resolved {
+			alert.EndedAt = &at
+		} else {
+			alert.StartedAt = at
+		}
+	}
+	return []Alert{alert}, nil
+}
+
+// uptimeRobotValues returns the variables of a payload as strings
+func uptimeRobotValues(payload []byte) (map[string]string, error) {
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var raw map[string]any
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		values := make(map[string]string, len(raw))
+		for k, v := range raw {
+			switch v := v.(type) {
+			case string:
+				values[k] = v
+			case float64:
+				values[k] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		return values, nil
+	}
+
+	form, err := url.ParseQuery(string(trimmed))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	values := make(map[string]string, len(form))
+	for k := range form {
+		values[k] = form.Get(k)
+	}
+	return values, nil
+}
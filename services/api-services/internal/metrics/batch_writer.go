@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+)
+
+// batchWriterCollector exports the counters and queue depth of batch writers, read at
+// scrape time
+type batchWriterCollector struct {
+	writers map[string]func() database.BatchWriterStats
+
+	pending *prometheus.Desc
+	written *prometheus.Desc
+	failed  *prometheus.Desc
+}
+
+// NewBatchWriterCollector creates a collector for writers, the Stats methods of batch
+// writers by name. Every metric carries a "writer" label with the name.
+func NewBatchWriterCollector(writers map[string]func() database.BatchWriterStats) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("batch_writer", "", name), help, []string{"writer"}, nil)
+	}
+
+	return &batchWriterCollector{
+		writers: writers,
+		pending: desc("pending_rows", "Number of rows buffered and waiting to be written."),
+		written: desc("written_rows_total", "Total number of rows written."),
+		failed:  desc("failed_rows_total", "Total number of rows dropped after their batch kept failing."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *batchWriterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pending
+	ch <- c.written
+	ch <- c.failed
+}
+
+// Collect implements prometheus.Collector
+func (c *batchWriterCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, stats := range c.writers {
+		s := stats()
+		ch <- prometheus.MustNewConstMetric(c.pending, prometheus.GaugeValue, float64(s.Pending), name)
+		ch <- prometheus.MustNewConstMetric(c.written, prometheus.CounterValue, float64(s.Written), name)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(s.Failed), name)
+	}
+}
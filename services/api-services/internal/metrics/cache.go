@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+)
+
+// cacheCollector exports the counters of the cache service, read at scrape time
+type cacheCollector struct {
+	service *cache.Service
+
+	requests     *prometheus.Desc
+	errors       *prometheus.Desc
+	hits         *prometheus.Desc
+	misses       *prometheus.Desc
+	latency      *prometheus.Desc
+	circuitState *prometheus.Desc
+	failures     *prometheus.Desc
+	localEntries *prometheus.Desc
+}
+
+// NewCacheCollector creates a collector for service. Hits and misses carry a "layer"
+// label, "redis" or "local" for the in-process cache when it is enabled.
+func NewCacheCollector(service *cache.Service) prometheus.Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("cache", "", name), help, labels, nil)
+	}
+
+	return &cacheCollector{
+		service:      service,
+		requests:     desc("requests_total", "Total number of Redis operations."),
+		errors:       desc("errors_total", "Total number of failed Redis operations."),
+		hits:         desc("hits_total", "Total number of cache lookups that found the key.", "layer"),
+		misses:       desc("misses_total", "Total number of cache lookups that did not find the key.", "layer"),
+		latency:      desc("latency_seconds_total", "Total time spent in Redis operations."),
+		circuitState: desc("circuit_breaker_state", "Whether the Redis circuit breaker is in the given state.", "state"),
+		failures:     desc("circuit_breaker_failures", "Number of consecutive Redis failures counted by the circuit breaker."),
+		localEntries: desc("local_entries", "Number of entries held by the in-process cache."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.requests
+	ch <- c.errors
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.latency
+	ch <- c.circuitState
+	ch <- c.failures
+	ch <- c.localEntries
+}
+
+// Collect implements prometheus.Collector
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.service.Stats()
+	redis := stats.Redis
+
+	ch <- prometheus.MustNewConstMetric(c.requests, prometheus.CounterValue, float64(redis.Requests))
+	ch <- prometheus.MustNewConstMetric(c.errors, prometheus.CounterValue, float64(redis.Errors))
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(redis.Hits), "redis")
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(redis.Misses), "redis")
+	ch <- prometheus.MustNewConstMetric(c.latency, prometheus.CounterValue, redis.Latency.Seconds())
+	for _, state := range []resilience.State{resilience.StateClosed, resilience.StateOpen, resilience.StateHalfOpen} {
+		value := 0.0
+		if redis.CircuitState == state {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.circuitState, prometheus.GaugeValue, value, state.String())
+	}
+	ch <- prometheus.MustNewConstMetric(c.failures, prometheus.GaugeValue, float64(redis.ConsecutiveFailures))
+
+	if stats.Local != nil {
+		ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Local.Hits), "local")
+		ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Local.Misses), "local")
+		ch <- prometheus.MustNewConstMetric(c.localEntries, prometheus.GaugeValue, float64(stats.Local.Entries))
+	}
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+)
+
+// dbPoolCollector exports the connection pool statistics of database clients, read at scrape time
+type dbPoolCollector struct {
+	clients map[string]database.Client
+
+	maxOpen           *prometheus.Desc
+	open              *prometheus.Desc
+	inUse             *prometheus.Desc
+	idle              *prometheus.Desc
+	waitCount         *prometheus.Desc
+	waitDuration      *prometheus.Desc
+	maxIdleClosed     *prometheus.Desc
+	maxIdleTimeClosed *prometheus.Desc
+	maxLifetimeClosed *prometheus.Desc
+	replicas          *prometheus.Desc
+	healthyReplicas   *prometheus.Desc
+}
+
+// NewDBPoolCollector creates a collector for clients keyed by the value of the "database"
+// label. Nil clients are skipped, so disabled databases simply export nothing.
+func NewDBPoolCollector(clients map[string]database.Client) prometheus.Collector {
+	labels := []string{"database"}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("db", "pool", name), help, labels, nil)
+	}
+
+	return &dbPoolCollector{
+		clients:           clients,
+		maxOpen:           desc("max_open_connections", "Maximum number of open connections to the database."),
+		open:              desc("open_connections", "Number of established connections, both in use and idle."),
+		inUse:             desc("in_use_connections", "Number of connections currently in use."),
+		idle:              desc("idle_connections", "Number of idle connections."),
+		waitCount:         desc("wait_count_total", "Total number of connections waited for."),
+		waitDuration:      desc("wait_duration_seconds_total", "Total time blocked waiting for a new connection."),
+		maxIdleClosed:     desc("max_idle_closed_total", "Total number of connections closed due to the idle connection limit."),
+		maxIdleTimeClosed: desc("max_idle_time_closed_total", "Total number of connections closed due to the idle time limit."),
+		maxLifetimeClosed: desc("max_lifetime_closed_total", "Total number of connections closed due to the connection lifetime limit."),
+		replicas:          desc("replicas", "Number of configured read replicas."),
+		healthyReplicas:   desc("healthy_replicas", "Number of read replicas currently serving reads."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *dbPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.maxIdleClosed
+	ch <- c.maxIdleTimeClosed
+	ch <- c.maxLifetimeClosed
+	ch <- c.replicas
+	ch <- c.healthyReplicas
+}
+
+// Collect implements prometheus.Collector
+func (c *dbPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, client := range c.clients {
+		if client == nil {
+			continue
+		}
+		stats, err := client.PoolStats()
+		if err != nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections), name)
+		ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections), name)
+		ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse), name)
+		ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle), name)
+		ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount), name)
+		ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, float64(stats.WaitDurationMs)/1000, name)
+		ch <- prometheus.MustNewConstMetric(c.maxIdleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed), name)
+		ch <- prometheus.MustNewConstMetric(c.maxIdleTimeClosed, prometheus.CounterValue, float64(stats.MaxIdleTimeClosed), name)
+		ch <- prometheus.MustNewConstMetric(c.maxLifetimeClosed, prometheus.CounterValue, float64(stats.MaxLifetimeClosed), name)
+		if stats.Replicas != nil {
+			ch <- prometheus.MustNewConstMetric(c.replicas, prometheus.GaugeValue, float64(stats.Replicas.Total), name)
+			ch <- prometheus.MustNewConstMetric(c.healthyReplicas, prometheus.GaugeValue, float64(stats.Replicas.Healthy), name)
+		}
+	}
+}
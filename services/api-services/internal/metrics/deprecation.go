@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/middleware"
+)
+
+// deprecationCollector exports the usage of deprecated API routes, read at scrape time
+type deprecationCollector struct {
+	deprecations *middleware.Deprecations
+
+	requests *prometheus.Desc
+	sunset   *prometheus.Desc
+}
+
+// NewDeprecationCollector creates a collector for deprecations. Every metric carries
+// "method" and "route" labels, the route being the path as registered.
+func NewDeprecationCollector(deprecations *middleware.Deprecations) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("http", "deprecated", name), help, []string{"method", "route"}, nil)
+	}
+
+	return &deprecationCollector{
+		deprecations: deprecations,
+		requests:     desc("requests_total", "Total number of requests to deprecated routes."),
+		sunset:       desc("sunset_timestamp_seconds", "Unix time a deprecated route sunsets, 0 when it is not planned."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *deprecationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.requests
+	ch <- c.sunset
+}
+
+// Collect implements prometheus.Collector
+func (c *deprecationCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, usage := range c.deprecations.Usage() {
+		sunset := 0.0
+		if !usage.Sunset.IsZero() {
+			sunset = float64(usage.Sunset.Unix())
+		}
+		ch <- prometheus.MustNewConstMetric(c.requests, prometheus.CounterValue, float64(usage.Requests), usage.Method, usage.Path)
+		ch <- prometheus.MustNewConstMetric(c.sunset, prometheus.GaugeValue, sunset, usage.Method, usage.Path)
+	}
+}
@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/tenant"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// domainQueryTimeout bounds the queries of a scrape
+const domainQueryTimeout = 5 * time.Second
+
+// monitorStatuses are exported even without monitors, so that alerts on them see a zero
+var monitorStatuses = []string{
+	models.MonitorStatusPending,
+	models.MonitorStatusUp,
+	models.MonitorStatusDown,
+	models.MonitorStatusDegraded,
+	models.MonitorStatusPaused,
+}
+
+// domainCollector exports counts of monitors, open incidents and undelivered outbox
+// messages across every organization, queried at scrape time
+type domainCollector struct {
+	db *gorm.DB
+
+	monitors       *prometheus.Desc
+	openIncidents  *prometheus.Desc
+	outboxMessages *prometheus.Desc
+}
+
+// NewDomainCollector creates a collector counting the rows of db
+func NewDomainCollector(db *gorm.DB) prometheus.Collector {
+	return &domainCollector{
+		db:             db,
+		monitors:       prometheus.NewDesc("monitors", "Number of monitors in the given status.", []string{"status"}, nil),
+		openIncidents:  prometheus.NewDesc("incidents_open", "Number of open incidents, suppressed ones included.", []string{"source", "suppressed"}, nil),
+		outboxMessages: prometheus.NewDesc("outbox_messages", "Number of outbox messages waiting for delivery or given up on.", []string{"topic", "status"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *domainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.monitors
+	ch <- c.openIncidents
+	ch <- c.outboxMessages
+}
+
+// Collect implements prometheus.Collector. A failed query leaves its metric out of the
+// scrape rather than reporting counts that are wrong.
+func (c *domainCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(tenant.WithoutScope(context.Background()), domainQueryTimeout)
+	defer cancel()
+	db := c.db.WithContext(ctx)
+
+	var monitors []struct {
+		Status string
+		Count  int64
+	}
+	if err := db.Model(&models.Monitor{}).Select("status, COUNT(*) AS count").Group("status").Scan(&monitors).Error; err != nil {
+		logger.Warn("Failed to count monitors for metrics", logger.ErrorField(err))
+	} else {
+		counts := make(map[string]int64, len(monitorStatuses))
+		for _, status := range monitorStatuses {
+			counts[status] = 0
+		}
+		for _, row := range monitors {
+			counts[row.Status] = row.Count
+		}
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(c.monitors, prometheus.GaugeValue, float64(count), status)
+		}
+	}
+
+	var incidents []struct {
+		Source     string
+		Suppressed bool
+		Count      int64
+	}
+	if err := db.Model(&models.Incident{}).Select("source, suppressed, COUNT(*) AS count").
+		Where("status = ?", models.IncidentStatusOpen).Group("source, suppressed").Scan(&incidents).Error; err != nil {
+		logger.Warn("Failed to count open incidents for metrics", logger.ErrorField(err))
+	} else {
+		for _, row := range incidents {
+			suppressed := "false"
+			if row.Suppressed {
+				suppressed = "true"
+			}
+			ch <- prometheus.MustNewConstMetric(c.openIncidents, prometheus.GaugeValue, float64(row.Count), row.Source, suppressed)
+		}
+	}
+
+	var messages []struct {
+		Topic  string
+		Status string
+		Count  int64
+	}
+	if err := db.Model(&models.OutboxMessage{}).Select("topic, status, COUNT(*) AS count").
+		Where("status <> ?", models.OutboxStatusSent).Group("topic, status").Scan(&messages).Error; err != nil {
+		logger.Warn("Failed to count outbox messages for metrics", logger.ErrorField(err))
+	} else {
+		for _, row := range messages {
+			ch <- prometheus.MustNewConstMetric(c.outboxMessages, prometheus.GaugeValue, float64(row.Count), row.Topic, row.Status)
+		}
+	}
+}
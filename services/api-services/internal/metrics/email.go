@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+)
+
+// emailCollector exports the per-provider counters of the email service, read at scrape time
+type emailCollector struct {
+	service email.Service
+
+	sent         *prometheus.Desc
+	failed       *prometheus.Desc
+	skipped      *prometheus.Desc
+	latency      *prometheus.Desc
+	circuitState *prometheus.Desc
+	failures     *prometheus.Desc
+}
+
+// NewEmailCollector creates a collector for service. Every metric carries a "provider" label.
+func NewEmailCollector(service email.Service) prometheus.Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("email", "", name), help, append([]string{"provider"}, labels...), nil)
+	}
+
+	return &emailCollector{
+		service:      service,
+		sent:         desc("sent_total", "Total number of emails the provider accepted."),
+		failed:       desc("failures_total", "Total number of send attempts the provider failed."),
+		skipped:      desc("skipped_total", "Total number of sends that skipped the provider while its circuit was open."),
+		latency:      desc("send_latency_seconds_total", "Total time spent in send attempts."),
+		circuitState: desc("circuit_breaker_state", "Whether the provider circuit breaker is in the given state.", "state"),
+		failures:     desc("circuit_breaker_failures", "Number of consecutive provider failures counted by the circuit breaker."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *emailCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sent
+	ch <- c.failed
+	ch <- c.skipped
+	ch <- c.latency
+	ch <- c.circuitState
+	ch <- c.failures
+}
+
+// Collect implements prometheus.Collector
+func (c *emailCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range c.service.Stats() {
+		provider := stats.Provider
+		ch <- prometheus.MustNewConstMetric(c.sent, prometheus.CounterValue, float64(stats.Sent), provider)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(stats.Failed), provider)
+		ch <- prometheus.MustNewConstMetric(c.skipped, prometheus.CounterValue, float64(stats.Skipped), provider)
+		ch <- prometheus.MustNewConstMetric(c.latency, prometheus.CounterValue, stats.Latency.Seconds(), provider)
+		for _, state := range []resilience.State{resilience.StateClosed, resilience.StateOpen, resilience.StateHalfOpen} {
+			value := 0.0
+			if stats.CircuitState == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.circuitState, prometheus.GaugeValue, value, provider, state.String())
+		}
+		ch <- prometheus.MustNewConstMetric(c.failures, prometheus.GaugeValue, float64(stats.ConsecutiveFailures), provider)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+)
+
+// jobsCollector exports the per-job counters of the background job scheduler, read at scrape time
+type jobsCollector struct {
+	scheduler *jobs.Scheduler
+
+	runs         *prometheus.Desc
+	failures     *prometheus.Desc
+	panics       *prometheus.Desc
+	skipped      *prometheus.Desc
+	running      *prometheus.Desc
+	lastDuration *prometheus.Desc
+	lastSuccess  *prometheus.Desc
+	nextRun      *prometheus.Desc
+}
+
+// NewJobsCollector creates a collector for scheduler. Every metric carries a "job" label.
+func NewJobsCollector(scheduler *jobs.Scheduler) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("jobs", "", name), help, []string{"job"}, nil)
+	}
+
+	return &jobsCollector{
+		scheduler:    scheduler,
+		runs:         desc("runs_total", "Total number of job runs."),
+		failures:     desc("failures_total", "Total number of job runs that returned an error or panicked."),
+		panics:       desc("panics_total", "Total number of job runs that panicked."),
+		skipped:      desc("skipped_total", "Total number of job runs skipped because the previous run was still going."),
+		running:      desc("running", "Whether the job is running."),
+		lastDuration: desc("last_duration_seconds", "Duration of the last job run."),
+		lastSuccess:  desc("last_success_timestamp_seconds", "Unix time the last successful job run started, 0 before the first."),
+		nextRun:      desc("next_run_timestamp_seconds", "Unix time of the next scheduled job run."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *jobsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.runs
+	ch <- c.failures
+	ch <- c.panics
+	ch <- c.skipped
+	ch <- c.running
+	ch <- c.lastDuration
+	ch <- c.lastSuccess
+	ch <- c.nextRun
+}
+
+// Collect implements prometheus.Collector
+func (c *jobsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range c.scheduler.Stats() {
+		job := stats.Name
+		running := 0.0
+		if stats.Running {
+			running = 1
+		}
+		lastSuccess := 0.0
+		if !stats.LastSuccess.IsZero() {
+			lastSuccess = float64(stats.LastSuccess.Unix())
+		}
+		ch <- prometheus.MustNewConstMetric(c.runs, prometheus.CounterValue, float64(stats.Runs), job)
+		ch <- prometheus.MustNewConstMetric(c.failures, prometheus.CounterValue, float64(stats.Failures), job)
+		ch <- prometheus.MustNewConstMetric(c.panics, prometheus.CounterValue, float64(stats.Panics), job)
+		ch <- prometheus.MustNewConstMetric(c.skipped, prometheus.CounterValue, float64(stats.Skipped), job)
+		ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, running, job)
+		ch <- prometheus.MustNewConstMetric(c.lastDuration, prometheus.GaugeValue, stats.LastDuration.Seconds(), job)
+		ch <- prometheus.MustNewConstMetric(c.lastSuccess, prometheus.GaugeValue, lastSuccess, job)
+		if !stats.NextRun.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.nextRun, prometheus.GaugeValue, float64(stats.NextRun.Unix()), job)
+		}
+	}
+}
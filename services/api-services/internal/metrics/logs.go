@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// logSinkCollector exports the counters of the log sink, read at scrape time
+type logSinkCollector struct {
+	shipped *prometheus.Desc
+	dropped *prometheus.Desc
+	failed  *prometheus.Desc
+}
+
+// NewLogSinkCollector creates a collector for the log sink. Every metric carries a "sink"
+// label with the sink type.
+func NewLogSinkCollector() prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("log_sink", "", name), help, []string{"sink"}, nil)
+	}
+
+	return &logSinkCollector{
+		shipped: desc("shipped_total", "Total number of log entries the sink accepted."),
+		dropped: desc("dropped_total", "Total number of log entries dropped because the buffer was full."),
+		failed:  desc("failed_total", "Total number of log entries in batches the sink rejected."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *logSinkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.shipped
+	ch <- c.dropped
+	ch <- c.failed
+}
+
+// Collect implements prometheus.Collector
+func (c *logSinkCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, ok := logger.GetSinkStats()
+	if !ok {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.shipped, prometheus.CounterValue, float64(stats.Shipped), stats.Type)
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(stats.Dropped), stats.Type)
+	ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(stats.Failed), stats.Type)
+}
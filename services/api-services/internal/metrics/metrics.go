@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewRegistry creates a registry with the Go runtime and process collectors registered
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// Handler serves the metrics of registry in the Prometheus exposition format.
+// When token is set, scrapers must send it as a bearer token.
+func Handler(registry *prometheus.Registry, token string) gin.HandlerFunc {
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+	return func(c *gin.Context) {
+		if token != "" {
+			provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
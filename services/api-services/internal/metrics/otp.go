@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/otp"
+)
+
+// otpCollector exports the number of OTPs issued, read at scrape time
+type otpCollector struct {
+	service *otp.OTPService
+
+	issued *prometheus.Desc
+}
+
+// NewOTPCollector creates a collector for service. Every metric carries a "type" label.
+func NewOTPCollector(service *otp.OTPService) prometheus.Collector {
+	return &otpCollector{
+		service: service,
+		issued:  prometheus.NewDesc("otp_issued_total", "Total number of one-time passwords issued.", []string{"type"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *otpCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.issued
+}
+
+// Collect implements prometheus.Collector
+func (c *otpCollector) Collect(ch chan<- prometheus.Metric) {
+	for otpType, count := range c.service.Issued() {
+		ch <- prometheus.MustNewConstMetric(c.issued, prometheus.CounterValue, float64(count), otpType)
+	}
+}
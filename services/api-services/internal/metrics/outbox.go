@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+)
+
+// outboxCollector exports the delivery counters of the outbox relay, read at scrape time
+type outboxCollector struct {
+	relay *outbox.Relay
+
+	deliveries *prometheus.Desc
+}
+
+// NewOutboxCollector creates a collector for relay. Deliveries carry a "topic" label, such
+// as email or sms, and an "outcome" one: delivered, retried or failed.
+func NewOutboxCollector(relay *outbox.Relay) prometheus.Collector {
+	return &outboxCollector{
+		relay:      relay,
+		deliveries: prometheus.NewDesc("outbox_deliveries_total", "Total number of outbox delivery attempts by outcome.", []string{"topic", "outcome"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *outboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.deliveries
+}
+
+// Collect implements prometheus.Collector
+func (c *outboxCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range c.relay.Stats() {
+		ch <- prometheus.MustNewConstMetric(c.deliveries, prometheus.CounterValue, float64(stats.Delivered), stats.Topic, "delivered")
+		ch <- prometheus.MustNewConstMetric(c.deliveries, prometheus.CounterValue, float64(stats.Retried), stats.Topic, "retried")
+		ch <- prometheus.MustNewConstMetric(c.deliveries, prometheus.CounterValue, float64(stats.Failed), stats.Topic, "failed")
+	}
+}
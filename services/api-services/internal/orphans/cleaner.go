@@ -0,0 +1,190 @@
+// Package orphans deletes stored files that no database record references anymore, such
+// as replaced profile pictures.
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// reference is a column holding URLs of stored files, or their keys when keys is set.
+// Soft-deleted rows still count, so their files stay until the retention job purges the
+// rows.
+type reference struct {
+	table  string
+	column string
+	keys   bool
+}
+
+// references lists every column pointing to stored files; add new ones here
+var references = []reference{
+	{table: "users", column: "profile_picture_url"},
+	{table: "generated_reports", column: "storage_key", keys: true},
+}
+
+// ignoredPrefixes lists storage directories holding files no record references by
+// design; they are never considered orphans
+var ignoredPrefixes = []string{email.CaptureKeyPrefix}
+
+// cleanupLockKey names the lock that keeps replicas from cleaning up at the same time
+const cleanupLockKey = "orphans:cleanup"
+
+// cleanupLockTTL is how long the cleanup lock lasts without renewal
+const cleanupLockTTL = time.Minute
+
+// Result summarizes a cleanup pass
+type Result struct {
+	Scanned    int
+	Referenced int
+	Orphaned   int
+	Deleted    int
+}
+
+// Cleaner periodically reconciles storage with the database, deleting files older than
+// the grace period that no reference points to.
+type Cleaner struct {
+	db          *gorm.DB
+	driver      storage.Driver
+	locks       *cache.Service
+	interval    time.Duration
+	gracePeriod time.Duration
+	dryRun      bool
+}
+
+// NewCleaner creates a cleaner for the files of driver referenced from db. When locks is
+// not nil, only one replica cleans up at a time.
+func NewCleaner(db *gorm.DB, driver storage.Driver, locks *cache.Service, cfg config.StorageCleanupConfig) *Cleaner {
+	return &Cleaner{
+		db:          db,
+		driver:      driver,
+		locks:       locks,
+		interval:    cfg.Interval,
+		gracePeriod: cfg.GracePeriod,
+		dryRun:      cfg.DryRun,
+	}
+}
+
+// Job returns the job cleaning up when the scheduler starts and then on every interval
+func (c *Cleaner) Job() jobs.Job {
+	return jobs.Job{Name: "orphaned_files_cleanup", Schedule: jobs.Every(c.interval), RunOnStart: true, Run: c.clean}.
+		Exclusive(c.locks, cleanupLockKey, cleanupLockTTL)
+}
+
+// clean runs one pass and logs its result
+func (c *Cleaner) clean(ctx context.Context) error {
+	result, err := c.Clean(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clean up orphaned files: %w", err)
+	}
+	logger.Info("Cleaned up orphaned files",
+		logger.Int("scanned", result.Scanned),
+		logger.Int("referenced", result.Referenced),
+		logger.Int("orphaned", result.Orphaned),
+		logger.Int("deleted", result.Deleted),
+		logger.Bool("dry_run", c.dryRun),
+	)
+	return nil
+}
+
+// Clean runs one pass: it loads every referenced key, then deletes the unreferenced files
+// older than the grace period, or only logs them in dry-run mode. References are loaded
+// first, so files uploaded during the pass are protected by the grace period.
+func (c *Cleaner) Clean(ctx context.Context) (Result, error) {
+	var result Result
+
+	referenced, err := c.referencedKeys(ctx)
+	if err != nil {
+		return result, err
+	}
+	result.Referenced = len(referenced)
+
+	cutoff := time.Now().Add(-c.gracePeriod)
+	var orphans []string
+	err = c.driver.Walk(ctx, func(object storage.Object) error {
+		result.Scanned++
+		if _, ok := referenced[object.Key]; ok || object.ModTime.After(cutoff) || isIgnored(object.Key) {
+			return nil
+		}
+		orphans = append(orphans, object.Key)
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to list stored files: %w", err)
+	}
+	result.Orphaned = len(orphans)
+
+	for _, key := range orphans {
+		if c.dryRun {
+			logger.Info("Would delete orphaned file", logger.String("key", key))
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		// Stop once another replica took the cleanup lock over
+		if err := cache.CheckLease(ctx); err != nil {
+			return result, err
+		}
+		if err := c.driver.Delete(ctx, key); err != nil {
+			logger.Warn("Failed to delete orphaned file", logger.String("key", key), logger.ErrorField(err))
+			continue
+		}
+		result.Deleted++
+	}
+	return result, nil
+}
+
+// referencedKeys returns the storage keys held by every reference column
+func (c *Cleaner) referencedKeys(ctx context.Context) (map[string]struct{}, error) {
+	keys := make(map[string]struct{})
+	for _, ref := range references {
+		rows, err := c.db.WithContext(ctx).
+			Table(ref.table).
+			Select(ref.column).
+			Where(ref.column + " IS NOT NULL AND " + ref.column + " <> ''").
+			Rows()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s.%s: %w", ref.table, ref.column, err)
+		}
+
+		for rows.Next() {
+			var value string
+			if err := rows.Scan(&value); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s.%s: %w", ref.table, ref.column, err)
+			}
+			if ref.keys {
+				keys[value] = struct{}{}
+			} else if key, ok := c.driver.KeyFromURL(value); ok {
+				keys[key] = struct{}{}
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s.%s: %w", ref.table, ref.column, err)
+		}
+	}
+	return keys, nil
+}
+
+// isIgnored reports whether key lies under one of ignoredPrefixes
+func isIgnored(key string) bool {
+	for _, prefix := range ignoredPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+)
+
+// TopicEmail is the topic of emails sent through the email service
+const TopicEmail = "email.send"
+
+// EmailMessage is the payload of TopicEmail messages. Messages naming a Template are
+// rendered from it with Data in the variant closest to Locale; the others are sent as
+// plain text.
+type EmailMessage struct {
+	To       string          `json:"to"`
+	Subject  string          `json:"subject,omitempty"`
+	Body     string          `json:"body,omitempty"`
+	Template string          `json:"template,omitempty"`
+	Locale   string          `json:"locale,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// PublishEmail records a plain text email to be sent once the surrounding transaction commits
+func (p *Publisher) PublishEmail(ctx context.Context, to, subject, body string) error {
+	return p.Publish(ctx, TopicEmail, EmailMessage{To: to, Subject: subject, Body: body})
+}
+
+// PublishTemplatedEmail records an email rendered from the named email template once the
+// surrounding transaction commits, translated to locale when the template has a variant
+// for it. data is stored as JSON, so templates see its fields by their JSON names.
+func (p *Publisher) PublishTemplatedEmail(ctx context.Context, to, template, locale string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s email data: %w", template, err)
+	}
+	return p.Publish(ctx, TopicEmail, EmailMessage{To: to, Template: template, Locale: locale, Data: encoded})
+}
+
+// EmailHandler delivers TopicEmail messages with service. Messages to suppressed
+// addresses are dropped rather than retried.
+func EmailHandler(service email.Service) Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var message EmailMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return fmt.Errorf("invalid email payload: %w", err)
+		}
+
+		err := deliverEmail(ctx, service, message)
+		if errors.Is(err, email.ErrSuppressed) {
+			logger.Info("Dropping email to suppressed address",
+				logger.Email("to", message.To),
+				logger.String("template", message.Template),
+			)
+			return nil
+		}
+		return err
+	}
+}
+
+// deliverEmail sends message, rendering its template when it has one
+func deliverEmail(ctx context.Context, service email.Service, message EmailMessage) error {
+	if message.Template == "" {
+		return service.SendEmail(ctx, message.To, message.Subject, message.Body)
+	}
+
+	var data map[string]any
+	if len(message.Data) > 0 {
+		if err := json.Unmarshal(message.Data, &data); err != nil {
+			return fmt.Errorf("invalid %s email data: %w", message.Template, err)
+		}
+	}
+	return service.SendTemplatedEmail(ctx, message.To, message.Template, message.Locale, data)
+}
@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Topics of the incidents opened and resolved, for the side effects that follow them such
+// as filing tickets in issue trackers
+const (
+	TopicIncidentOpened   = "incident.opened"
+	TopicIncidentResolved = "incident.resolved"
+)
+
+// TopicIncidentRecord is the topic of the resolved incidents to record for analytics, once
+// resolved and again whenever their cause is tagged
+const TopicIncidentRecord = "incident.record"
+
+// IncidentMessage is the payload of TopicIncidentOpened, TopicIncidentResolved and
+// TopicIncidentRecord messages. Handlers load the incident, which may have changed since.
+type IncidentMessage struct {
+	IncidentID     uuid.UUID `json:"incident_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+// PublishIncident records that an incident was opened, resolved or tagged under topic
+func (p *Publisher) PublishIncident(ctx context.Context, topic string, incidentID, organizationID uuid.UUID) error {
+	return p.Publish(ctx, topic, IncidentMessage{IncidentID: incidentID, OrganizationID: organizationID})
+}
@@ -0,0 +1,20 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TopicMonitorCheck is the topic of the checks of monitors requested out of schedule
+const TopicMonitorCheck = "monitor.check"
+
+// MonitorCheckMessage is the payload of TopicMonitorCheck messages
+type MonitorCheckMessage struct {
+	MonitorID uuid.UUID `json:"monitor_id"`
+}
+
+// PublishMonitorCheck records a check of a monitor to run once the surrounding transaction commits
+func (p *Publisher) PublishMonitorCheck(ctx context.Context, monitorID uuid.UUID) error {
+	return p.Publish(ctx, TopicMonitorCheck, MonitorCheckMessage{MonitorID: monitorID})
+}
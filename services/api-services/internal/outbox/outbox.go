@@ -0,0 +1,47 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// Handler delivers one outbox message. Delivery is at-least-once: a handler may see the
+// same payload again if the process dies after it succeeded but before the row was marked sent.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// Publisher records side effects in the outbox table
+type Publisher struct {
+	db *gorm.DB
+}
+
+// NewPublisher creates a publisher writing to db
+func NewPublisher(db *gorm.DB) *Publisher {
+	return &Publisher{db: db}
+}
+
+// Publish records payload under topic. When ctx carries a transaction (see database.RunInTx)
+// the message is written in it, so it is only delivered if the surrounding change commits.
+func (p *Publisher) Publish(ctx context.Context, topic string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s outbox payload: %w", topic, err)
+	}
+
+	message := &models.OutboxMessage{
+		Topic:       topic,
+		Payload:     data,
+		Status:      models.OutboxStatusPending,
+		AvailableAt: time.Now().UTC(),
+	}
+	if err := database.Conn(ctx, p.db).Create(message).Error; err != nil {
+		return fmt.Errorf("failed to write %s outbox message: %w", topic, err)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/queue"
+)
+
+// QueueHandler hands messages of topic over to q as jobs of the same type, to be delivered
+// by whichever replica of its consumer group reads them with the handler registered there.
+// The message counts as sent once the job is queued; failed deliveries are retried and
+// dead-lettered by the queue.
+func QueueHandler(q *queue.Queue, topic string) Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		_, err := q.Enqueue(ctx, topic, payload)
+		return err
+	}
+}
@@ -0,0 +1,231 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// handlerTimeout bounds the delivery of a single message
+	handlerTimeout = 30 * time.Second
+	// cleanupInterval is how often sent messages past the retention window are deleted
+	cleanupInterval = time.Hour
+	// maxErrorLength truncates handler errors stored on the message
+	maxErrorLength = 1000
+	// maxErrorHistory is how many failed attempts are kept on the message
+	maxErrorHistory = 10
+)
+
+// Relay delivers pending outbox messages to the handler registered for their topic.
+// Messages are claimed with FOR UPDATE SKIP LOCKED, so several instances can relay
+// concurrently without delivering the same message twice in the normal case.
+type Relay struct {
+	db       *gorm.DB
+	cfg      config.OutboxConfig
+	handlers map[string]Handler
+
+	mu    sync.Mutex
+	stats map[string]*DeliveryStats
+}
+
+// DeliveryStats counts the delivery attempts of a topic since startup
+type DeliveryStats struct {
+	Topic     string `json:"topic"`
+	Delivered int64  `json:"delivered"`
+	// Retried counts the failed attempts that were scheduled again
+	Retried int64 `json:"retried"`
+	// Failed counts the messages given up on after their last attempt
+	Failed int64 `json:"failed"`
+}
+
+// NewRelay creates a relay reading from db. Register handlers before calling Run.
+func NewRelay(db *gorm.DB, cfg config.OutboxConfig) *Relay {
+	return &Relay{
+		db:       db,
+		cfg:      cfg,
+		handlers: make(map[string]Handler),
+		stats:    make(map[string]*DeliveryStats),
+	}
+}
+
+// Stats returns the delivery counters of the topics messages were relayed for, sorted by topic
+func (r *Relay) Stats() []DeliveryStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make([]DeliveryStats, 0, len(r.stats))
+	for _, topic := range r.stats {
+		stats = append(stats, *topic)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Topic < stats[j].Topic })
+	return stats
+}
+
+// record counts a delivery attempt of topic with the given outcome
+func (r *Relay) record(topic string, count func(*DeliveryStats)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats, ok := r.stats[topic]
+	if !ok {
+		stats = &DeliveryStats{Topic: topic}
+		r.stats[topic] = stats
+	}
+	count(stats)
+}
+
+// Register sets the handler delivering messages of topic
+func (r *Relay) Register(topic string, handler Handler) {
+	r.handlers[topic] = handler
+}
+
+// Run relays messages on every poll interval until ctx is cancelled. A full batch
+// is followed immediately by the next one so a backlog drains without waiting.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		for {
+			n, err := r.RelayBatch(ctx)
+			if err != nil {
+				logger.Error("Failed to relay outbox messages", logger.ErrorField(err))
+				break
+			}
+			if n < r.cfg.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if r.cfg.Retention > 0 && time.Since(lastCleanup) >= cleanupInterval {
+			r.cleanup(ctx)
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch claims up to BatchSize due messages, delivers them and records the outcome
+// in one transaction, returning how many messages were processed.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	var messages []models.OutboxMessage
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("status = ? AND available_at <= ?", models.OutboxStatusPending, time.Now().UTC()).
+			Order("available_at ASC").
+			Limit(r.cfg.BatchSize).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&messages).Error
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox messages: %w", err)
+		}
+
+		for i := range messages {
+			r.deliver(ctx, &messages[i])
+			if err := tx.Save(&messages[i]).Error; err != nil {
+				return fmt.Errorf("failed to update outbox message %s: %w", messages[i].ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(messages), nil
+}
+
+// deliver runs the handler of message and updates its status, attempts and next run time
+func (r *Relay) deliver(ctx context.Context, message *models.OutboxMessage) {
+	message.Attempts++
+
+	err := fmt.Errorf("no handler registered for topic %q", message.Topic)
+	if handler, ok := r.handlers[message.Topic]; ok {
+		handlerCtx, cancel := context.WithTimeout(ctx, handlerTimeout)
+		err = handler(handlerCtx, message.Payload)
+		cancel()
+	}
+
+	now := time.Now().UTC()
+	if err == nil {
+		message.Status = models.OutboxStatusSent
+		message.ProcessedAt = &now
+		message.LastError = nil
+		r.record(message.Topic, func(s *DeliveryStats) { s.Delivered++ })
+		return
+	}
+
+	lastError := err.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+	message.LastError = &lastError
+	message.Errors = append(message.Errors, models.OutboxError{Attempt: message.Attempts, Error: lastError, OccurredAt: now})
+	if len(message.Errors) > maxErrorHistory {
+		message.Errors = message.Errors[len(message.Errors)-maxErrorHistory:]
+	}
+
+	if message.Attempts >= r.cfg.MaxAttempts {
+		message.Status = models.OutboxStatusFailed
+		message.ProcessedAt = &now
+		r.record(message.Topic, func(s *DeliveryStats) { s.Failed++ })
+		logger.Error("Giving up on outbox message",
+			logger.String("id", message.ID.String()),
+			logger.String("topic", message.Topic),
+			logger.Int("attempts", message.Attempts),
+			logger.ErrorField(err),
+		)
+		return
+	}
+
+	message.AvailableAt = now.Add(r.backoff(message.Attempts))
+	r.record(message.Topic, func(s *DeliveryStats) { s.Retried++ })
+	logger.Warn("Outbox message delivery failed, will retry",
+		logger.String("id", message.ID.String()),
+		logger.String("topic", message.Topic),
+		logger.Int("attempts", message.Attempts),
+		logger.ErrorField(err),
+	)
+}
+
+// backoff returns the delay before the next attempt, doubling per attempt up to MaxBackoff
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.RetryBackoff
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxBackoff {
+		delay = r.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// cleanup deletes sent messages older than the retention window. Failed messages are
+// kept until an operator retries or discards them through the admin API.
+func (r *Relay) cleanup(ctx context.Context) {
+	cutoff := time.Now().UTC().Add(-r.cfg.Retention)
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND processed_at < ?", models.OutboxStatusSent, cutoff).
+		Delete(&models.OutboxMessage{})
+	if result.Error != nil {
+		logger.Error("Failed to clean up outbox messages", logger.ErrorField(result.Error))
+		return
+	}
+	if result.RowsAffected > 0 {
+		logger.Info("Cleaned up sent outbox messages", logger.Int("deleted", int(result.RowsAffected)))
+	}
+}
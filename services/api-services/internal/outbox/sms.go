@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/sms"
+)
+
+// TopicSMS is the topic of text messages sent through the SMS service
+const TopicSMS = "sms.send"
+
+// SMSMessage is the payload of TopicSMS messages
+type SMSMessage struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// PublishSMS records a text message to be sent once the surrounding transaction commits
+func (p *Publisher) PublishSMS(ctx context.Context, to, body string) error {
+	return p.Publish(ctx, TopicSMS, SMSMessage{To: to, Body: body})
+}
+
+// SMSHandler delivers TopicSMS messages with service. Messages to malformed numbers are
+// dropped rather than retried.
+func SMSHandler(service sms.Service) Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var message SMSMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return fmt.Errorf("invalid sms payload: %w", err)
+		}
+
+		err := service.SendSMS(ctx, message.To, message.Body)
+		if errors.Is(err, sms.ErrInvalidNumber) {
+			logger.Warn("Dropping SMS to an invalid number", logger.ErrorField(err))
+			return nil
+		}
+		return err
+	}
+}
@@ -0,0 +1,150 @@
+package partitions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// suffixLayout names monthly partitions, e.g. check_results_y2026m01
+const suffixLayout = "_y2006m01"
+
+// Manager maintains the monthly range partitions of a Postgres table partitioned on a
+// timestamp column. It creates partitions ahead of time, since inserts fail for rows
+// no partition accepts, and drops whole partitions once they fall out of the retention
+// window, which is far cheaper than deleting rows.
+type Manager struct {
+	db        *gorm.DB
+	table     string
+	ahead     int
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewManager creates a manager for table. ahead is the number of months after the current
+// one to create partitions for; a zero retention never drops partitions.
+func NewManager(db *gorm.DB, table string, ahead int, retention, interval time.Duration) *Manager {
+	return &Manager{
+		db:        db,
+		table:     table,
+		ahead:     ahead,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Job returns the job maintaining partitions when the scheduler starts and then on every
+// interval
+func (m *Manager) Job() jobs.Job {
+	return jobs.Job{
+		Name:       "partition_maintenance",
+		Schedule:   jobs.Every(m.interval),
+		RunOnStart: true,
+		Run: func(ctx context.Context) error {
+			if err := m.Maintain(ctx); err != nil {
+				return fmt.Errorf("failed to maintain partitions of %s: %w", m.table, err)
+			}
+			return nil
+		},
+	}
+}
+
+// Maintain creates missing partitions and drops expired ones
+func (m *Manager) Maintain(ctx context.Context) error {
+	now := time.Now().UTC()
+	if err := m.EnsurePartitions(ctx, now); err != nil {
+		return err
+	}
+	return m.Prune(ctx, now)
+}
+
+// EnsurePartitions creates the partitions from the month before now up to ahead months
+// after it. The previous month is included so late results near a month boundary land.
+func (m *Manager) EnsurePartitions(ctx context.Context, now time.Time) error {
+	start := monthStart(now).AddDate(0, -1, 0)
+	for i := 0; i <= m.ahead+1; i++ {
+		from := start.AddDate(0, i, 0)
+		if m.expired(from.AddDate(0, 1, 0), now) {
+			continue
+		}
+		if err := m.create(ctx, from); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune drops every partition whose whole range is older than the retention window
+func (m *Manager) Prune(ctx context.Context, now time.Time) error {
+	if m.retention <= 0 {
+		return nil
+	}
+
+	names, err := m.partitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		suffix, ok := strings.CutPrefix(name, m.table)
+		if !ok {
+			continue
+		}
+		from, err := time.Parse(suffixLayout, suffix)
+		if err != nil {
+			// Not created by the manager, e.g. a manually attached partition
+			continue
+		}
+		if !m.expired(from.AddDate(0, 1, 0), now) {
+			continue
+		}
+		if err := m.db.WithContext(ctx).Exec(fmt.Sprintf(`DROP TABLE IF EXISTS "%s"`, name)).Error; err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", name, err)
+		}
+		logger.Info("Dropped expired partition", logger.String("table", m.table), logger.String("partition", name))
+	}
+	return nil
+}
+
+// create creates the partition covering the month starting at from. Postgres does not
+// accept bind parameters in DDL, so the bounds are formatted into the statement.
+func (m *Manager) create(ctx context.Context, from time.Time) error {
+	name := m.table + from.Format(suffixLayout)
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" PARTITION OF "%s" FOR VALUES FROM ('%s') TO ('%s')`,
+		name, m.table, from.Format(time.RFC3339), from.AddDate(0, 1, 0).Format(time.RFC3339))
+	if err := m.db.WithContext(ctx).Exec(query).Error; err != nil {
+		return fmt.Errorf("failed to create partition %s: %w", name, err)
+	}
+	return nil
+}
+
+// partitions lists the partitions currently attached to the table
+func (m *Manager) partitions(ctx context.Context) ([]string, error) {
+	var names []string
+	err := m.db.WithContext(ctx).Raw(`SELECT child.relname
+FROM pg_inherits
+JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+WHERE parent.relname = ?`, m.table).Scan(&names).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", m.table, err)
+	}
+	return names, nil
+}
+
+// expired reports whether a partition ending at end only holds rows past the retention window
+func (m *Manager) expired(end, now time.Time) bool {
+	return m.retention > 0 && !end.After(now.Add(-m.retention))
+}
+
+// monthStart returns the first instant of the UTC month containing t
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,116 @@
+package realtime
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 512
+	sendBufferSize = 64
+)
+
+// Client is a single WebSocket connection subscribed to one organization's room.
+type Client struct {
+	hub            *Hub
+	conn           *websocket.Conn
+	organizationID uuid.UUID
+	userID         uuid.UUID
+	send           chan []byte
+}
+
+// Serve registers the connection with the hub and pumps messages until it closes.
+// It blocks for the lifetime of the connection.
+func (h *Hub) Serve(conn *websocket.Conn, organizationID, userID uuid.UUID) {
+	client := &Client{
+		hub:            h,
+		conn:           conn,
+		organizationID: organizationID,
+		userID:         userID,
+		send:           make(chan []byte, sendBufferSize),
+	}
+
+	h.register(client)
+	logger.Debug("Realtime client connected",
+		logger.String("organization_id", organizationID.String()),
+		logger.String("user_id", userID.String()),
+	)
+
+	go client.writePump()
+	client.readPump()
+}
+
+// enqueue queues a message without blocking; slow clients are disconnected.
+func (c *Client) enqueue(payload []byte) {
+	select {
+	case c.send <- payload:
+	default:
+		logger.Warn("Realtime client too slow, dropping connection",
+			logger.String("organization_id", c.organizationID.String()),
+			logger.String("user_id", c.userID.String()),
+		)
+		go c.close()
+	}
+}
+
+func (c *Client) close() {
+	_ = c.conn.Close()
+}
+
+// readPump discards client messages and keeps the connection alive with pongs.
+// The dashboard only receives events, so inbound frames are ignored.
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		close(c.send)
+		c.close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				logger.Debug("Realtime client read error", logger.ErrorField(err))
+			}
+			return
+		}
+	}
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.close()
+	}()
+
+	for {
+		select {
+		case payload, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
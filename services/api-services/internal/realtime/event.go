@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types pushed to dashboard clients
+const (
+	EventMonitorStatusChanged = "monitor.status_changed"
+	EventIncidentCreated      = "incident.created"
+	EventIncidentResolved     = "incident.resolved"
+	EventIncidentAssigned     = "incident.assigned"
+	EventAlertAcknowledged    = "alert.acknowledged"
+	EventSLAStatusChanged     = "sla.status_changed"
+)
+
+// Event is a message delivered to every client subscribed to an organization's room.
+type Event struct {
+	Type           string      `json:"type"`
+	OrganizationID uuid.UUID   `json:"organization_id"`
+	Data           interface{} `json:"data"`
+	Timestamp      time.Time   `json:"timestamp"`
+}
+
+// NewEvent creates an event stamped with the current time.
+func NewEvent(eventType string, organizationID uuid.UUID, data interface{}) Event {
+	return Event{
+		Type:           eventType,
+		OrganizationID: organizationID,
+		Data:           data,
+		Timestamp:      time.Now().UTC(),
+	}
+}
+
+// Publisher delivers events to connected clients on every replica.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// MonitorStatusChange is the payload of EventMonitorStatusChanged. SuppressedBy is set when
+// a monitor went down while a monitor it depends on was down, to that monitor. OwnerUserID
+// and OwnerTeam are who owns the monitor, when it has an owner.
+type MonitorStatusChange struct {
+	MonitorID      uuid.UUID  `json:"monitor_id"`
+	PreviousStatus string     `json:"previous_status"`
+	Status         string     `json:"status"`
+	CheckedAt      time.Time  `json:"checked_at"`
+	SuppressedBy   *uuid.UUID `json:"suppressed_by,omitempty"`
+	OwnerUserID    *uuid.UUID `json:"owner_user_id,omitempty"`
+	OwnerTeam      string     `json:"owner_team,omitempty"`
+}
+
+// SLAStatusChange is the payload of EventSLAStatusChanged.
+type SLAStatusChange struct {
+	SLATargetID    uuid.UUID `json:"sla_target_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	BudgetConsumed float64   `json:"budget_consumed"`
+	BurnRate       float64   `json:"burn_rate"`
+	EvaluatedAt    time.Time `json:"evaluated_at"`
+}
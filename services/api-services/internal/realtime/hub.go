@@ -0,0 +1,138 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	channelPrefix       = "realtime:org:"
+	resubscribeInterval = 5 * time.Second
+)
+
+// Hub tracks connected clients grouped into per-organization rooms. When a cache service
+// is available, events are fanned out through Redis pub/sub so every replica delivers them;
+// otherwise they are delivered to local clients only.
+type Hub struct {
+	cacheService *cache.Service
+
+	mu    sync.RWMutex
+	rooms map[uuid.UUID]map[*Client]struct{}
+}
+
+// NewHub creates a hub. cacheService may be nil for single-instance deployments.
+func NewHub(cacheService *cache.Service) *Hub {
+	return &Hub{
+		cacheService: cacheService,
+		rooms:        make(map[uuid.UUID]map[*Client]struct{}),
+	}
+}
+
+// Run relays events published by other replicas until ctx is cancelled.
+func (h *Hub) Run(ctx context.Context) {
+	if h.cacheService == nil {
+		return
+	}
+
+	for {
+		err := h.cacheService.Subscribe(ctx, h.handleMessage, channelPrefix+"*")
+		if ctx.Err() != nil {
+			logger.Info("Realtime hub stopped")
+			return
+		}
+
+		logger.Warn("Realtime subscription interrupted, retrying",
+			logger.ErrorField(err),
+			logger.Duration("retry_in", resubscribeInterval),
+		)
+		select {
+		case <-time.After(resubscribeInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Publish sends an event to the organization's room across all replicas.
+func (h *Hub) Publish(ctx context.Context, event Event) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal realtime event: %w", err)
+	}
+
+	if h.cacheService == nil {
+		h.broadcast(event.OrganizationID, payload)
+		return nil
+	}
+
+	return h.cacheService.Publish(ctx, channelPrefix+event.OrganizationID.String(), payload)
+}
+
+// handleMessage delivers a pub/sub message to local clients in the matching room.
+func (h *Hub) handleMessage(channel string, payload []byte) {
+	organizationID, err := uuid.Parse(strings.TrimPrefix(channel, channelPrefix))
+	if err != nil {
+		logger.Warn("Ignoring realtime message on unexpected channel", logger.String("channel", channel))
+		return
+	}
+	h.broadcast(organizationID, payload)
+}
+
+func (h *Hub) broadcast(organizationID uuid.UUID, payload []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.rooms[organizationID] {
+		client.enqueue(payload)
+	}
+}
+
+func (h *Hub) register(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.rooms[client.organizationID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		h.rooms[client.organizationID] = room
+	}
+	room[client] = struct{}{}
+}
+
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.rooms[client.organizationID]
+	if !ok {
+		return
+	}
+	delete(room, client)
+	if len(room) == 0 {
+		delete(h.rooms, client.organizationID)
+	}
+}
+
+// ClientCount returns the number of clients connected to this replica.
+func (h *Hub) ClientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	count := 0
+	for _, room := range h.rooms {
+		count += len(room)
+	}
+	return count
+}
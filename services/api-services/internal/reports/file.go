@@ -0,0 +1,87 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+)
+
+// pdfNameWidth is how many characters of monitor names fit in the PDF table
+const pdfNameWidth = 40
+
+// reportCSVColumns defines the columns of report CSV files, one row per monitor
+var reportCSVColumns = []utils.CSVColumn[monitorUptime]{
+	{Header: "monitor", Value: func(m *monitorUptime) string { return m.Name }},
+	{Header: "availability_percent", Value: func(m *monitorUptime) string { return strconv.FormatFloat(m.Availability, 'f', 4, 64) }},
+	{Header: "total_checks", Value: func(m *monitorUptime) string { return strconv.FormatUint(m.Stats.TotalChecks, 10) }},
+	{Header: "up_checks", Value: func(m *monitorUptime) string { return strconv.FormatUint(m.Stats.UpChecks, 10) }},
+	{Header: "degraded_checks", Value: func(m *monitorUptime) string { return strconv.FormatUint(m.Stats.DegradedChecks, 10) }},
+	{Header: "down_checks", Value: func(m *monitorUptime) string { return strconv.FormatUint(m.Stats.DownChecks, 10) }},
+	{Header: "downtime_minutes", Value: func(m *monitorUptime) string { return strconv.Itoa(int(m.Downtime / time.Minute)) }},
+	{Header: "avg_latency_ms", Value: func(m *monitorUptime) string { return strconv.FormatFloat(m.Stats.AvgLatencyMs, 'f', 1, 64) }},
+	{Header: "p95_latency_ms", Value: func(m *monitorUptime) string { return strconv.FormatFloat(m.P95LatencyMs, 'f', 1, 64) }},
+}
+
+// renderReport renders the uptime of an organization as a file of format and returns it
+// with its content type
+func renderReport(format, organizationName string, u *uptime, slaTarget float64) ([]byte, string, error) {
+	switch format {
+	case models.ReportFormatCSV:
+		var buf bytes.Buffer
+		if err := utils.WriteCSV(&buf, reportCSVColumns, u.Monitors); err != nil {
+			return nil, "", fmt.Errorf("failed to write report CSV: %w", err)
+		}
+		return buf.Bytes(), "text/csv", nil
+	case models.ReportFormatPDF:
+		return renderPDF(organizationName, u, slaTarget), "application/pdf", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// renderPDF lays out the summary of the period and a table of the monitors
+func renderPDF(organizationName string, u *uptime, slaTarget float64) []byte {
+	w := newPDFWriter()
+	w.text(pdfFontBold, 18, "Uptime report: "+organizationName)
+	w.text(pdfFontRegular, 11, fmt.Sprintf("From %s to %s (%s)",
+		u.From.Format(time.DateOnly), u.To.AddDate(0, 0, -1).Format(time.DateOnly), u.From.Location()))
+	w.space(12)
+
+	if len(u.Monitors) == 0 {
+		w.text(pdfFontRegular, 11, "No monitor has check results in this period.")
+		return w.bytes()
+	}
+
+	meetingSLA := 0
+	for _, monitor := range u.Monitors {
+		if monitor.Availability >= slaTarget {
+			meetingSLA++
+		}
+	}
+	w.text(pdfFontBold, 11, fmt.Sprintf("Availability: %.3f%% across %d monitors", u.Availability(), len(u.Monitors)))
+	w.text(pdfFontRegular, 11, fmt.Sprintf("SLA target: %.2f%%, met by %d of %d monitors", slaTarget, meetingSLA, len(u.Monitors)))
+	w.space(12)
+
+	row := "%-*s %12s %12s %12s"
+	w.text(pdfFontMono, 9, fmt.Sprintf(row, pdfNameWidth, "Monitor", "Availability", "Downtime", "P95 (ms)"))
+	for _, monitor := range u.Monitors {
+		w.text(pdfFontMono, 9, fmt.Sprintf(row, pdfNameWidth, truncate(monitor.Name, pdfNameWidth),
+			fmt.Sprintf("%.3f%%", monitor.Availability),
+			monitor.Downtime.String(),
+			strconv.FormatFloat(monitor.P95LatencyMs, 'f', 0, 64)))
+	}
+	return w.bytes()
+}
+
+// truncate shortens s to at most width characters
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "~"
+}
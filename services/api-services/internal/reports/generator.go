@@ -0,0 +1,157 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+
+	"gorm.io/gorm"
+)
+
+// FileKeyPrefix is the storage directory report files are written to
+const FileKeyPrefix = "reports/"
+
+// generateLockKey names the lock that keeps replicas from generating the same reports
+const generateLockKey = "reports:generate"
+
+// generateLockTTL is how long the generation lock lasts without renewal
+const generateLockTTL = time.Minute
+
+// Generator renders the report files members request into the storage driver and deletes
+// them once they expire
+type Generator struct {
+	reports   repositories.GeneratedReportRepository
+	monitors  repositories.MonitorRepository
+	stats     repositories.MonitorStatsRepository
+	driver    storage.Driver
+	locks     *cache.Service
+	cfg       config.ReportFilesConfig
+	slaTarget float64
+}
+
+// NewGenerator creates a generator reading reports and monitors from db and statistics
+// from statsDB. Reports compare monitors to slaTarget. When locks is not nil, only one
+// replica generates at a time.
+func NewGenerator(db, statsDB *gorm.DB, driver storage.Driver, locks *cache.Service, cfg config.ReportFilesConfig, slaTarget float64) *Generator {
+	return &Generator{
+		reports:   repositories.NewGeneratedReportRepository(db),
+		monitors:  repositories.NewMonitorRepository(db),
+		stats:     repositories.NewMonitorStatsRepository(statsDB),
+		driver:    driver,
+		locks:     locks,
+		cfg:       cfg,
+		slaTarget: slaTarget,
+	}
+}
+
+// Job returns the job generating pending reports when the generator starts and then on
+// every interval
+func (g *Generator) Job() jobs.Job {
+	return jobs.Job{Name: "report_generation", Schedule: jobs.Every(g.cfg.Interval), RunOnStart: true, Run: g.GeneratePending}.
+		Exclusive(g.locks, generateLockKey, generateLockTTL)
+}
+
+// GeneratePending deletes the expired reports, then generates up to a batch of pending
+// ones. A report whose generation fails is retried on the next pass until it runs out of
+// attempts.
+func (g *Generator) GeneratePending(ctx context.Context) error {
+	g.deleteExpired(ctx)
+
+	pending, err := g.reports.ListPending(ctx, g.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	generated := 0
+	for _, report := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Stop once another replica took the generation lock over
+		if err := cache.CheckLease(ctx); err != nil {
+			return err
+		}
+
+		if err := g.generate(ctx, report); err != nil {
+			logger.Error("Failed to generate report",
+				logger.String("report_id", report.ID.String()),
+				logger.String("format", report.Format),
+				logger.ErrorField(err),
+			)
+			expiresAt := time.Now().UTC().Add(g.cfg.TTL)
+			if err := g.reports.MarkAttemptFailed(ctx, report.ID, err.Error(), g.cfg.MaxAttempts, expiresAt); err != nil {
+				logger.Error("Failed to record failed report attempt", logger.String("report_id", report.ID.String()), logger.ErrorField(err))
+			}
+			continue
+		}
+		generated++
+	}
+
+	if generated > 0 {
+		logger.Info("Generated reports", logger.Int("generated", generated))
+	}
+	return nil
+}
+
+// generate renders a report and stores its file
+func (g *Generator) generate(ctx context.Context, report models.PendingReport) error {
+	// From and To are days in the time zone of the organization; To is the last day
+	// covered, the rollups are read up to the end of it
+	loc := models.LoadTimezone(report.OrganizationTimezone)
+	from := time.Date(report.From.Year(), report.From.Month(), report.From.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(report.To.Year(), report.To.Month(), report.To.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	u, err := measureUptime(ctx, g.monitors, g.stats, report.OrganizationID, from, to)
+	if err != nil {
+		return err
+	}
+
+	data, contentType, err := renderReport(report.Format, report.OrganizationName, u, g.slaTarget)
+	if err != nil {
+		return err
+	}
+
+	key := path.Join(FileKeyPrefix, report.OrganizationID.String(), report.ID.String(), report.FileName())
+	if _, err := g.driver.Upload(ctx, key, bytes.NewReader(data), contentType); err != nil {
+		return fmt.Errorf("failed to store report: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if err := g.reports.MarkReady(ctx, report.ID, key, int64(len(data)), now, now.Add(g.cfg.TTL)); err != nil {
+		// The file is unreferenced now and removed by the orphaned file cleanup
+		return err
+	}
+	return nil
+}
+
+// deleteExpired deletes the files and records of a batch of expired reports, logging
+// failures so that they are retried on the next pass
+func (g *Generator) deleteExpired(ctx context.Context) {
+	expired, err := g.reports.ListExpired(ctx, time.Now().UTC(), g.cfg.BatchSize)
+	if err != nil {
+		logger.Error("Failed to list expired reports", logger.ErrorField(err))
+		return
+	}
+
+	for _, report := range expired {
+		if report.StorageKey != nil {
+			if err := g.driver.Delete(ctx, *report.StorageKey); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				logger.Warn("Failed to delete expired report file", logger.String("report_id", report.ID.String()), logger.ErrorField(err))
+				continue
+			}
+		}
+		if err := g.reports.SoftDelete(ctx, report.ID); err != nil {
+			logger.Warn("Failed to delete expired report", logger.String("report_id", report.ID.String()), logger.ErrorField(err))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page layout of report PDFs, in points: A4 with a margin on every side
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+)
+
+// Fonts of report PDFs, all standard PDF fonts so nothing needs embedding
+const (
+	pdfFontRegular = "F1"
+	pdfFontBold    = "F2"
+	pdfFontMono    = "F3"
+)
+
+// pdfFonts maps the font resources of every page to their base font
+var pdfFonts = []struct{ name, base string }{
+	{pdfFontRegular, "Helvetica"},
+	{pdfFontBold, "Helvetica-Bold"},
+	{pdfFontMono, "Courier"},
+}
+
+// pdfWriter lays out lines of text top to bottom on as many pages as they need and
+// serializes them as a PDF document. Text is encoded in WinAnsi; characters outside of it
+// are replaced.
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// newPDFWriter creates a writer with an empty first page
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.addPage()
+	return w
+}
+
+// addPage starts a new page and moves to its top
+func (w *pdfWriter) addPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	w.y = pdfPageHeight - pdfMargin
+}
+
+// text writes a line of text in font at size points, starting a new page when the line
+// does not fit on the current one
+func (w *pdfWriter) text(font string, size float64, text string) {
+	lineHeight := size * 1.4
+	if w.y-lineHeight < pdfMargin {
+		w.addPage()
+	}
+	w.y -= lineHeight
+	fmt.Fprintf(w.pages[len(w.pages)-1], "BT /%s %.1f Tf %d %.1f Td (%s) Tj ET\n", font, size, pdfMargin, w.y, pdfEscape(text))
+}
+
+// space leaves a blank gap of height points
+func (w *pdfWriter) space(height float64) {
+	w.y -= height
+}
+
+// bytes serializes the document: the catalog, the page tree, the fonts, then every page
+// and its content stream, followed by the cross-reference table
+func (w *pdfWriter) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Pages start after the catalog, the page tree and the fonts, two objects per page
+	firstPage := 3 + len(pdfFonts)
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	var fonts strings.Builder
+	for i, font := range pdfFonts {
+		fmt.Fprintf(&fonts, "/%s %d 0 R ", font.name, 3+i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	for _, font := range pdfFonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.base))
+	}
+	for i, page := range w.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, fonts.String(), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfEscape encodes text as the content of a PDF string in WinAnsi, which matches Latin-1
+// for the printable characters it shares with it
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,157 @@
+// Package reports emails periodic uptime reports to the organization members who opted in
+// and renders the uptime reports members request into downloadable files.
+package reports
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+)
+
+// topMonitors is how many monitors the downtime and latency rankings list
+const topMonitors = 5
+
+// hourRollupRetention is how long the hour rollups of check results are kept
+const hourRollupRetention = 45 * 24 * time.Hour
+
+// uptime is the uptime of the monitors of an organization over [From, To)
+type uptime struct {
+	From     time.Time
+	To       time.Time
+	Monitors []monitorUptime
+	// TotalChecks and AvailableChecks count the checks of every monitor, the latter those
+	// that were up or degraded
+	TotalChecks     uint64
+	AvailableChecks uint64
+}
+
+// monitorUptime is the uptime of one monitor over the period of a report
+type monitorUptime struct {
+	email.ReportMonitor
+	Stats models.MonitorStatsPoint
+}
+
+// Availability returns the percentage of the checks of every monitor that were available
+func (u *uptime) Availability() float64 {
+	if u.TotalChecks == 0 {
+		return 0
+	}
+	return float64(u.AvailableChecks) / float64(u.TotalChecks) * 100
+}
+
+// rollupResolution returns the rollups a period is read from. Day rollups are bucketed by
+// UTC day, so periods starting at midnight in another time zone are read from the hour
+// rollups while those still cover them, and rounded to UTC days afterwards.
+func rollupResolution(from, to time.Time) string {
+	if isUTCMidnight(from) && isUTCMidnight(to) || time.Since(from) > hourRollupRetention {
+		return models.StatsResolutionDay
+	}
+	return models.StatsResolutionHour
+}
+
+// calendarDay returns the day of t in its own time zone at midnight UTC, the time zone the
+// email templates render days in
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isUTCMidnight(t time.Time) bool {
+	return t.UTC().Truncate(24 * time.Hour).Equal(t)
+}
+
+// measureUptime aggregates the rollups of an organization over [from, to), one entry per
+// monitor with check results in the period, ordered by name. Deleted monitors are left out.
+func measureUptime(ctx context.Context, monitors repositories.MonitorRepository, stats repositories.MonitorStatsRepository, organizationID uuid.UUID, from, to time.Time) (*uptime, error) {
+	summaries, err := stats.OrganizationSummaries(ctx, organizationID, rollupResolution(from, to), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(summaries))
+	for _, summary := range summaries {
+		ids = append(ids, summary.MonitorID)
+	}
+	// Deleted monitors are not returned, so their results are left out of the report
+	found, err := monitors.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(found))
+	for _, monitor := range found {
+		names[monitor.ID] = monitor.Name
+	}
+
+	u := &uptime{From: from, To: to}
+	for _, summary := range summaries {
+		name, ok := names[summary.MonitorID]
+		if !ok || summary.TotalChecks == 0 {
+			continue
+		}
+
+		u.TotalChecks += summary.TotalChecks
+		u.AvailableChecks += summary.UpChecks + summary.DegradedChecks
+
+		// Checks run at a fixed interval, so the share of failed checks estimates the
+		// share of the period the monitor was down
+		downShare := float64(summary.DownChecks) / float64(summary.TotalChecks)
+		u.Monitors = append(u.Monitors, monitorUptime{
+			ReportMonitor: email.ReportMonitor{
+				Name:         name,
+				Availability: summary.Availability,
+				Downtime:     time.Duration(downShare * float64(to.Sub(from))).Round(time.Minute),
+				P95LatencyMs: summary.P95LatencyMs,
+			},
+			Stats: summary.MonitorStatsPoint,
+		})
+	}
+	sort.SliceStable(u.Monitors, func(i, j int) bool { return u.Monitors[i].Name < u.Monitors[j].Name })
+	return u, nil
+}
+
+// build aggregates the rollups of an organization over [from, to) into a report. It
+// returns nil when no monitor of the organization has check results in the period.
+func (s *Scheduler) build(ctx context.Context, organizationID uuid.UUID, organizationName, frequency string, from, to time.Time) (*email.UptimeReportData, error) {
+	u, err := measureUptime(ctx, s.monitors, s.stats, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(u.Monitors) == 0 {
+		return nil, nil
+	}
+
+	report := &email.UptimeReportData{
+		OrganizationName: organizationName,
+		Frequency:        frequency,
+		From:             calendarDay(from),
+		To:               calendarDay(to.AddDate(0, 0, -1)),
+		MonitorCount:     len(u.Monitors),
+		Availability:     u.Availability(),
+		SLATarget:        s.cfg.SLATarget,
+	}
+
+	entries := make([]email.ReportMonitor, 0, len(u.Monitors))
+	for _, monitor := range u.Monitors {
+		if monitor.Availability >= s.cfg.SLATarget {
+			report.MonitorsMeetingSLA++
+		}
+		entries = append(entries, monitor.ReportMonitor)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Downtime > entries[j].Downtime })
+	for _, entry := range entries {
+		if entry.Downtime <= 0 || len(report.TopIncidents) == topMonitors {
+			break
+		}
+		report.TopIncidents = append(report.TopIncidents, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].P95LatencyMs > entries[j].P95LatencyMs })
+	report.SlowestMonitors = entries[:min(len(entries), topMonitors)]
+
+	return report, nil
+}
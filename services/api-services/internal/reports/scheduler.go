@@ -0,0 +1,206 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+
+	"gorm.io/gorm"
+)
+
+// sendLockKey names the lock that keeps replicas from sending the same reports
+const sendLockKey = "reports:send"
+
+// sendLockTTL is how long the send lock lasts without renewal
+const sendLockTTL = time.Minute
+
+// Scheduler queues the uptime report of every period that ended for each subscription
+// that has not received it yet. Reports go through the outbox together with the mark on
+// the subscription, so a report is queued exactly once.
+type Scheduler struct {
+	subscriptions  repositories.ReportSubscriptionRepository
+	monitors       repositories.MonitorRepository
+	stats          repositories.MonitorStatsRepository
+	transactor     database.Transactor
+	outbox         *outbox.Publisher
+	locks          *cache.Service
+	cfg            config.ReportsConfig
+	unsubscribeURL string
+}
+
+// NewScheduler creates a scheduler reading subscriptions and monitors from db and
+// statistics from statsDB. When locks is not nil, only one replica sends at a time.
+func NewScheduler(db, statsDB *gorm.DB, locks *cache.Service, cfg config.ReportsConfig, unsubscribeURL string) *Scheduler {
+	return &Scheduler{
+		subscriptions:  repositories.NewReportSubscriptionRepository(db),
+		monitors:       repositories.NewMonitorRepository(db),
+		stats:          repositories.NewMonitorStatsRepository(statsDB),
+		transactor:     database.NewTransactor(db),
+		outbox:         outbox.NewPublisher(db),
+		locks:          locks,
+		cfg:            cfg,
+		unsubscribeURL: unsubscribeURL,
+	}
+}
+
+// Job returns the job sending due reports when the scheduler starts and then on every
+// interval
+func (s *Scheduler) Job() jobs.Job {
+	run := func(ctx context.Context) error {
+		s.SendDue(ctx)
+		return nil
+	}
+	return jobs.Job{Name: "uptime_reports", Schedule: jobs.Every(s.cfg.Interval), RunOnStart: true, Run: run}.
+		Exclusive(s.locks, sendLockKey, sendLockTTL)
+}
+
+// SendDue queues the reports of the last whole week and month, which end at midnight in
+// the time zone of each organization. Failures are logged per frequency and time zone so
+// one failing period does not block the others.
+func (s *Scheduler) SendDue(ctx context.Context) {
+	now := time.Now()
+	for _, frequency := range []string{models.ReportFrequencyWeekly, models.ReportFrequencyMonthly} {
+		timezones, err := s.subscriptions.ListTimezones(ctx, frequency)
+		if err != nil {
+			logger.Error("Failed to list uptime report time zones", logger.String("frequency", frequency), logger.ErrorField(err))
+			continue
+		}
+
+		for _, timezone := range timezones {
+			from, to := models.LastReportPeriod(frequency, now, models.LoadTimezone(timezone))
+			sent, err := s.sendPeriod(ctx, frequency, timezone, from, to)
+			if err != nil {
+				logger.Error("Failed to send uptime reports",
+					logger.String("frequency", frequency),
+					logger.String("timezone", timezone),
+					logger.Int("sent", sent),
+					logger.ErrorField(err),
+				)
+				continue
+			}
+			if sent > 0 {
+				logger.Info("Queued uptime reports",
+					logger.String("frequency", frequency),
+					logger.String("timezone", timezone),
+					logger.String("period_start", from.Format(time.DateOnly)),
+					logger.Int("sent", sent),
+				)
+			}
+		}
+	}
+}
+
+// sendPeriod queues the report of [from, to) to every due subscription of frequency to an
+// organization in timezone and returns how many were queued. A subscription whose report fails is retried on the next
+// pass; the others are still served.
+func (s *Scheduler) sendPeriod(ctx context.Context, frequency, timezone string, from, to time.Time) (int, error) {
+	// Reports are built once per organization and period
+	built := make(map[uuid.UUID]*email.UptimeReportData)
+	failed := make(map[uuid.UUID]bool)
+	afterID := uuid.Nil
+	sent := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+		// Stop once another replica took the send lock over
+		if err := cache.CheckLease(ctx); err != nil {
+			return sent, err
+		}
+
+		due, err := s.subscriptions.ListDue(ctx, frequency, timezone, to, afterID, s.cfg.BatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, subscription := range due {
+			afterID = subscription.ID
+			organizationID := subscription.OrganizationID
+			if failed[organizationID] {
+				continue
+			}
+
+			report, ok := built[organizationID]
+			if !ok {
+				report, err = s.build(ctx, organizationID, subscription.OrganizationName, frequency, from, to)
+				if err != nil {
+					failed[organizationID] = true
+					logger.Error("Failed to build uptime report",
+						logger.String("organization_id", organizationID.String()),
+						logger.String("frequency", frequency),
+						logger.ErrorField(err),
+					)
+					continue
+				}
+				built[organizationID] = report
+			}
+
+			queued, err := s.send(ctx, subscription, report, to)
+			if err != nil {
+				logger.Error("Failed to queue uptime report",
+					logger.String("subscription_id", subscription.ID.String()),
+					logger.ErrorField(err),
+				)
+				continue
+			}
+			if queued {
+				sent++
+			}
+		}
+
+		if len(due) < s.cfg.BatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// send queues report to the subscriber and marks the period as sent. An organization
+// without check results in the period gets no email, but the period is still marked so
+// it is not looked at again. It reports whether an email was queued.
+func (s *Scheduler) send(ctx context.Context, subscription models.DueReportSubscription, report *email.UptimeReportData, periodEnd time.Time) (bool, error) {
+	err := s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if report != nil {
+			data := *report
+			data.UnsubscribeURL = s.unsubscribeLink(subscription.UnsubscribeToken)
+
+			locale := ""
+			if subscription.Locale != nil {
+				locale = *subscription.Locale
+			}
+			if err := s.outbox.PublishTemplatedEmail(ctx, subscription.Email, email.TemplateUptimeReport, locale, data); err != nil {
+				return err
+			}
+		}
+		return s.subscriptions.MarkSent(ctx, subscription.ID, periodEnd)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to queue report of subscription %s: %w", subscription.ID, err)
+	}
+	return report != nil, nil
+}
+
+// unsubscribeLink returns the unsubscribe page URL carrying token
+func (s *Scheduler) unsubscribeLink(token string) string {
+	link, err := url.Parse(s.unsubscribeURL)
+	if err != nil {
+		// The URL comes from the validated configuration; keep the report usable anyway
+		return s.unsubscribeURL + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
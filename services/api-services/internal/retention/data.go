@@ -0,0 +1,226 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// dataLockKey names the lock that keeps replicas from purging expired data at the same time
+const dataLockKey = "retention:data"
+
+// dataset is a table of organization data whose rows expire by a time column. override
+// returns the number of days an organization policy keeps the rows, nil for the default.
+// The rows of dependents referencing expired rows are deleted with them.
+type dataset struct {
+	db         *gorm.DB
+	table      string
+	column     string
+	window     time.Duration
+	override   func(policy models.RetentionPolicy) *int
+	dependents []dependent
+}
+
+// dependent is a table whose column holds the ID of a dataset row
+type dependent struct {
+	table  string
+	column string
+}
+
+// DataPurger deletes check results, their rollups and resolved incidents once they are
+// older than the retention window of their organization, and purge audit records and
+// activity entries past their window.
+// Check results in Postgres partitions are also bounded by the partition retention, and
+// rollups and incident records by the TTL of their ClickHouse table.
+type DataPurger struct {
+	db              *gorm.DB
+	locks           *cache.Service
+	interval        time.Duration
+	auditLogsWindow time.Duration
+	datasets        []dataset
+}
+
+// NewDataPurger creates a purger reading retention policies from db, which also holds the
+// incidents. checkResults is the store of raw check results and clickHouse the connection
+// holding their rollups and the incident records; either may be nil. When locks is not
+// nil, only one replica purges at a time.
+func NewDataPurger(db, checkResults, clickHouse *gorm.DB, locks *cache.Service, cfg config.RetentionConfig) *DataPurger {
+	var datasets []dataset
+	if checkResults != nil {
+		datasets = append(datasets, dataset{
+			db:       checkResults,
+			table:    repositories.CheckResultsTable,
+			column:   "checked_at",
+			window:   cfg.CheckResultsWindow,
+			override: func(policy models.RetentionPolicy) *int { return policy.CheckResultsDays },
+		})
+	}
+	if clickHouse != nil {
+		for _, table := range repositories.CheckResultRollupTables() {
+			datasets = append(datasets, dataset{
+				db:       clickHouse,
+				table:    table,
+				column:   "bucket",
+				window:   cfg.RollupsWindow,
+				override: func(policy models.RetentionPolicy) *int { return policy.RollupsDays },
+			})
+		}
+	}
+
+	// Open incidents have no resolved_at, so only resolved ones expire
+	datasets = append(datasets, dataset{
+		db:         db,
+		table:      "incidents",
+		column:     "resolved_at",
+		window:     cfg.IncidentsWindow,
+		override:   func(policy models.RetentionPolicy) *int { return policy.IncidentsDays },
+		dependents: []dependent{{table: "incident_tickets", column: "incident_id"}},
+	})
+	if clickHouse != nil {
+		datasets = append(datasets, dataset{
+			db:       clickHouse,
+			table:    repositories.IncidentRecordsTable,
+			column:   "resolved_at",
+			window:   cfg.IncidentsWindow,
+			override: func(policy models.RetentionPolicy) *int { return policy.IncidentsDays },
+		})
+	}
+	datasets = append(datasets, dataset{
+		db:       db,
+		table:    "activity_entries",
+		column:   "occurred_at",
+		window:   cfg.ActivityWindow,
+		override: func(models.RetentionPolicy) *int { return nil },
+	})
+
+	return &DataPurger{
+		db:              db,
+		locks:           locks,
+		interval:        cfg.DataInterval,
+		auditLogsWindow: cfg.AuditLogsWindow,
+		datasets:        datasets,
+	}
+}
+
+// Job returns the job purging expired data when the scheduler starts and then on every
+// interval
+func (p *DataPurger) Job() jobs.Job {
+	return jobs.Job{Name: "data_retention", Schedule: jobs.Every(p.interval), RunOnStart: true, Run: p.PurgeAll}.
+		Exclusive(p.locks, dataLockKey, purgeLockTTL)
+}
+
+// PurgeAll runs one pass over every dataset, logging failures per table so one failing
+// table does not block the others. It fails when the retention policies cannot be read.
+func (p *DataPurger) PurgeAll(ctx context.Context) error {
+	policies, err := repositories.NewRetentionPolicyRepository(p.db).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, d := range p.datasets {
+		if err := p.purgeDataset(ctx, d, policies, now); err != nil {
+			logger.Error("Failed to purge expired data", logger.String("table", d.table), logger.ErrorField(err))
+		}
+	}
+
+	if p.auditLogsWindow > 0 {
+		result := p.db.WithContext(ctx).
+			Where("purged_at < ?", now.Add(-p.auditLogsWindow)).
+			Delete(&models.PurgeAuditLog{})
+		if result.Error != nil {
+			logger.Error("Failed to purge expired purge audit records", logger.ErrorField(result.Error))
+		} else if result.RowsAffected > 0 {
+			logger.Info("Purged expired purge audit records", logger.Int("purged", int(result.RowsAffected)))
+		}
+	}
+	return nil
+}
+
+// purgeDataset deletes the expired rows of d: those of organizations with an override
+// past their own window, then those of every other organization past the default window
+func (p *DataPurger) purgeDataset(ctx context.Context, d dataset, policies []models.RetentionPolicy, now time.Time) error {
+	var overridden []uuid.UUID
+	for _, policy := range policies {
+		days := d.override(policy)
+		if days == nil {
+			continue
+		}
+		overridden = append(overridden, policy.OrganizationID)
+		if *days <= 0 {
+			continue
+		}
+
+		cutoff := now.Add(-time.Duration(*days) * 24 * time.Hour)
+		if err := p.deleteExpired(ctx, d, cutoff, "organization_id = ?", policy.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if d.window <= 0 {
+		return nil
+	}
+	if len(overridden) == 0 {
+		return p.deleteExpired(ctx, d, now.Add(-d.window), "")
+	}
+	return p.deleteExpired(ctx, d, now.Add(-d.window), "organization_id NOT IN ?", overridden)
+}
+
+// deleteExpired deletes the rows of d older than cutoff that match the optional condition.
+// Deletes are costly in ClickHouse even when nothing matches, so they only run when a
+// row has expired.
+func (p *DataPurger) deleteExpired(ctx context.Context, d dataset, cutoff time.Time, condition string, args ...any) error {
+	where := d.column + " < ?"
+	vars := []any{cutoff}
+	if condition != "" {
+		where += " AND " + condition
+		vars = append(vars, args...)
+	}
+
+	var expired []int
+	if err := d.db.WithContext(ctx).Raw("SELECT 1 FROM "+d.table+" WHERE "+where+" LIMIT 1", vars...).Scan(&expired).Error; err != nil {
+		return fmt.Errorf("failed to look for expired %s: %w", d.table, err)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	// Stop once another replica took the data lock over
+	if err := cache.CheckLease(ctx); err != nil {
+		return err
+	}
+
+	if err := d.delete(ctx, where, vars); err != nil {
+		return fmt.Errorf("failed to purge expired %s: %w", d.table, err)
+	}
+	logger.Info("Purged expired data", logger.String("table", d.table), logger.Time("cutoff", cutoff))
+	return nil
+}
+
+// delete deletes the rows of d matching where, and those of its dependents referencing
+// them in the same transaction. Datasets with dependents are Postgres tables; ClickHouse
+// ones are deleted from without a transaction.
+func (d dataset) delete(ctx context.Context, where string, vars []any) error {
+	if len(d.dependents) == 0 {
+		return d.db.WithContext(ctx).Exec("DELETE FROM "+d.table+" WHERE "+where, vars...).Error
+	}
+
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, dep := range d.dependents {
+			err := tx.Exec("DELETE FROM "+dep.table+" WHERE "+dep.column+" IN (SELECT id FROM "+d.table+" WHERE "+where+")", vars...).Error
+			if err != nil {
+				return fmt.Errorf("failed to purge %s: %w", dep.table, err)
+			}
+		}
+		return tx.Exec("DELETE FROM "+d.table+" WHERE "+where, vars...).Error
+	})
+}
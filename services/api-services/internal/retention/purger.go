@@ -0,0 +1,241 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// cascade removes rows that reference a purged parent. Queries take the parent IDs as
+// their only argument; audited queries must return "id" and "parent_id" columns.
+type cascade struct {
+	table       string
+	parentTable string
+	query       string
+	audited     bool
+}
+
+// target is a soft-deleted table purged once rows are older than its retention window
+type target struct {
+	table    string
+	window   time.Duration
+	cascades []cascade
+}
+
+// purgedRow is a soft-deleted row selected for removal
+type purgedRow struct {
+	ID        uuid.UUID
+	DeletedAt time.Time
+}
+
+// cascadedRow is a dependent row removed together with its parent
+type cascadedRow struct {
+	ID       uuid.UUID
+	ParentID uuid.UUID
+}
+
+// purgeLockKey names the lock that keeps replicas from purging at the same time
+const purgeLockKey = "retention:purge"
+
+// purgeLockTTL is how long the purge lock lasts without renewal
+const purgeLockTTL = time.Minute
+
+// Purger permanently removes soft-deleted rows once their retention window has passed,
+// recording every removed row in the purge audit log.
+type Purger struct {
+	db        *gorm.DB
+	locks     *cache.Service
+	interval  time.Duration
+	batchSize int
+	targets   []target
+}
+
+// NewPurger creates a purger for db. Tables with a zero retention window are never purged.
+// When locks is not nil, only one replica purges at a time.
+func NewPurger(db *gorm.DB, locks *cache.Service, cfg config.RetentionConfig) *Purger {
+	// Monitors go first so organizations purged in the same run have fewer dependents left.
+	targets := []target{
+		{table: "monitors", window: cfg.MonitorsWindow},
+		{
+			table:  "organizations",
+			window: cfg.OrganizationsWindow,
+			cascades: []cascade{
+				{table: "environments", parentTable: "applications", audited: true, query: "DELETE FROM environments WHERE application_id IN (SELECT id FROM applications WHERE organization_id IN ?) RETURNING id, application_id AS parent_id"},
+				{table: "applications", parentTable: "organizations", audited: true, query: "DELETE FROM applications WHERE organization_id IN ? RETURNING id, organization_id AS parent_id"},
+				{table: "monitors", parentTable: "organizations", audited: true, query: "DELETE FROM monitors WHERE organization_id IN ? RETURNING id, organization_id AS parent_id"},
+				{table: "role_permissions", query: "DELETE FROM role_permissions WHERE role_id IN (SELECT id FROM roles WHERE organization_id IN ?)"},
+				{table: "user_roles", query: "DELETE FROM user_roles WHERE role_id IN (SELECT id FROM roles WHERE organization_id IN ?)"},
+				{table: "roles", parentTable: "organizations", audited: true, query: "DELETE FROM roles WHERE organization_id IN ? RETURNING id, organization_id AS parent_id"},
+				{table: "policies", parentTable: "organizations", audited: true, query: "DELETE FROM policies WHERE organization_id IN ? RETURNING id, organization_id AS parent_id"},
+				{table: "organization_users", query: "DELETE FROM organization_users WHERE organization_id IN ?"},
+				{table: "report_subscriptions", query: "DELETE FROM report_subscriptions WHERE organization_id IN ?"},
+				{table: "retention_policies", query: "DELETE FROM retention_policies WHERE organization_id IN ?"},
+				{table: "sla_targets", query: "DELETE FROM sla_targets WHERE organization_id IN ?"},
+				{table: "generated_reports", query: "DELETE FROM generated_reports WHERE organization_id IN ?"},
+			},
+		},
+		{
+			table:  "users",
+			window: cfg.UsersWindow,
+			cascades: []cascade{
+				{table: "organization_users", query: "DELETE FROM organization_users WHERE user_id IN ?"},
+				{table: "user_roles", query: "DELETE FROM user_roles WHERE user_id IN ?"},
+				{table: "user_permissions", query: "DELETE FROM user_permissions WHERE user_id IN ?"},
+				{table: "report_subscriptions", query: "DELETE FROM report_subscriptions WHERE user_id IN ?"},
+			},
+		},
+	}
+
+	return &Purger{
+		db:        db,
+		locks:     locks,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+		targets:   targets,
+	}
+}
+
+// Job returns the job purging expired rows when the scheduler starts and then on every
+// interval
+func (p *Purger) Job() jobs.Job {
+	run := func(ctx context.Context) error {
+		p.PurgeAll(ctx)
+		return nil
+	}
+	return jobs.Job{Name: "retention_purge", Schedule: jobs.Every(p.interval), RunOnStart: true, Run: run}.
+		Exclusive(p.locks, purgeLockKey, purgeLockTTL)
+}
+
+// PurgeAll runs one purge pass over every target, logging failures per table
+// so one failing table does not block the others.
+func (p *Purger) PurgeAll(ctx context.Context) {
+	for _, t := range p.targets {
+		if t.window <= 0 {
+			continue
+		}
+
+		purged, err := p.purge(ctx, t)
+		if err != nil {
+			logger.Error("Failed to purge soft-deleted rows",
+				logger.String("table", t.table),
+				logger.Int("purged", purged),
+				logger.ErrorField(err),
+			)
+			continue
+		}
+		if purged > 0 {
+			logger.Info("Purged soft-deleted rows",
+				logger.String("table", t.table),
+				logger.Int("purged", purged),
+			)
+		}
+	}
+}
+
+// purge removes expired rows of t in batches and returns how many were removed
+func (p *Purger) purge(ctx context.Context, t target) (int, error) {
+	cutoff := time.Now().UTC().Add(-t.window)
+	total := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		// Stop once another replica took the purge lock over
+		if err := cache.CheckLease(ctx); err != nil {
+			return total, err
+		}
+
+		n, err := p.purgeBatch(ctx, t, cutoff)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < p.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// purgeBatch removes up to batchSize expired rows, their dependents and writes the audit
+// records in one transaction. SKIP LOCKED lets several instances run the job concurrently.
+func (p *Purger) purgeBatch(ctx context.Context, t target, cutoff time.Time) (int, error) {
+	var rows []purgedRow
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(t.table).
+			Select("id, deleted_at").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Order("deleted_at ASC").
+			Limit(p.batchSize).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Scan(&rows).Error
+		if err != nil {
+			return fmt.Errorf("failed to select expired %s: %w", t.table, err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		purgedAt := time.Now().UTC()
+		ids := make([]uuid.UUID, len(rows))
+		audits := make([]models.PurgeAuditLog, 0, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+			deletedAt := row.DeletedAt
+			audits = append(audits, models.PurgeAuditLog{
+				SourceTable:   t.table,
+				RecordID:      row.ID,
+				SoftDeletedAt: &deletedAt,
+				PurgedAt:      purgedAt,
+			})
+		}
+
+		for _, c := range t.cascades {
+			if !c.audited {
+				if err := tx.Exec(c.query, ids).Error; err != nil {
+					return fmt.Errorf("failed to purge %s of %s: %w", c.table, t.table, err)
+				}
+				continue
+			}
+
+			var removed []cascadedRow
+			if err := tx.Raw(c.query, ids).Scan(&removed).Error; err != nil {
+				return fmt.Errorf("failed to purge %s of %s: %w", c.table, t.table, err)
+			}
+			for _, r := range removed {
+				parentTable, parentID := c.parentTable, r.ParentID
+				audits = append(audits, models.PurgeAuditLog{
+					SourceTable: c.table,
+					RecordID:    r.ID,
+					ParentTable: &parentTable,
+					ParentID:    &parentID,
+					PurgedAt:    purgedAt,
+				})
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM "+t.table+" WHERE id IN ?", ids).Error; err != nil {
+			return fmt.Errorf("failed to purge %s: %w", t.table, err)
+		}
+
+		if err := tx.CreateInBatches(audits, p.batchSize).Error; err != nil {
+			return fmt.Errorf("failed to write purge audit log: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
@@ -19,6 +19,8 @@ type SeedConfig struct {
 	Permissions       []PermissionConfig       `yaml:"permissions"`
 	OrganizationTypes []OrganizationTypeConfig `yaml:"organization_types"`
 	ApplicationTypes  []ApplicationTypeConfig  `yaml:"application_types"`
+	Roles             []RoleConfig             `yaml:"roles"`
+	Demo              DemoConfig               `yaml:"demo"`
 }
 
 // PermissionConfig represents permission configuration
@@ -39,11 +41,49 @@ type ApplicationTypeConfig struct {
 	Description string `yaml:"description"`
 }
 
+// RoleConfig represents a default role created in every organization. Permissions are
+// permission names or patterns such as "monitor:*", matched with path.Match.
+type RoleConfig struct {
+	Name        string   `yaml:"name"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// DemoConfig describes the sample data seeded by the demo profile
+type DemoConfig struct {
+	User          DemoUserConfig           `yaml:"user"`
+	Organizations []DemoOrganizationConfig `yaml:"organizations"`
+}
+
+// DemoUserConfig represents the demo account owning the sample organizations
+type DemoUserConfig struct {
+	FirstName string `yaml:"first_name"`
+	LastName  string `yaml:"last_name"`
+	Email     string `yaml:"email"`
+	Password  string `yaml:"password"`
+}
+
+// DemoOrganizationConfig represents a sample organization and its monitors
+type DemoOrganizationConfig struct {
+	Name     string              `yaml:"name"`
+	Type     string              `yaml:"type"`
+	Monitors []DemoMonitorConfig `yaml:"monitors"`
+}
+
+// DemoMonitorConfig represents a sample monitor
+type DemoMonitorConfig struct {
+	Name            string `yaml:"name"`
+	Type            string `yaml:"type"`
+	Target          string `yaml:"target"`
+	IntervalSeconds int    `yaml:"interval_seconds"`
+	TimeoutSeconds  int    `yaml:"timeout_seconds"`
+}
+
 // ToModel converts PermissionConfig to models.Permission
 func (pc PermissionConfig) ToModel() models.Permission {
 	return models.Permission{
 		Name:        pc.Name,
 		Description: utils.StringPtr(pc.Description),
+		IsSystem:    true,
 	}
 }
 
@@ -52,6 +92,7 @@ func (otc OrganizationTypeConfig) ToModel() models.OrganizationType {
 	return models.OrganizationType{
 		Name:        otc.Name,
 		Description: utils.StringPtr(otc.Description),
+		IsSystem:    true,
 	}
 }
 
@@ -60,6 +101,7 @@ func (atc ApplicationTypeConfig) ToModel() models.ApplicationType {
 	return models.ApplicationType{
 		Name:        atc.Name,
 		Description: utils.StringPtr(atc.Description),
+		IsSystem:    true,
 	}
 }
 
@@ -0,0 +1,202 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// DemoSeeder creates a demo account owning sample organizations and monitors, so a
+// development environment has something to look at. New organizations get the default
+// roles, the account holding the Owner role. Existing entries are matched by email or
+// name and left untouched, so it can run repeatedly.
+type DemoSeeder struct {
+	db     *gorm.DB
+	config *SeedConfig
+}
+
+// NewDemoSeeder creates a new instance of DemoSeeder
+func NewDemoSeeder(db *gorm.DB, config *SeedConfig) Seeder {
+	return &DemoSeeder{db: db, config: config}
+}
+
+// Name returns the name of the seeder.
+func (ds *DemoSeeder) Name() string {
+	return "demo"
+}
+
+// Dependencies returns the dependencies of the seeder.
+func (ds *DemoSeeder) Dependencies() []string {
+	return []string{"organization_types", "roles"}
+}
+
+// Profile returns the profile the seeder belongs to.
+func (ds *DemoSeeder) Profile() string {
+	return ProfileDemo
+}
+
+// Fingerprint returns a checksum of the demo account and of every sample organization.
+func (ds *DemoSeeder) Fingerprint() map[string]string {
+	fingerprint := fingerprintItems(ds.config.Demo.Organizations, func(c DemoOrganizationConfig) string {
+		return "organization:" + c.Name
+	})
+	for key, sum := range fingerprintItems([]DemoUserConfig{ds.config.Demo.User}, func(c DemoUserConfig) string {
+		return "user:" + c.Email
+	}) {
+		fingerprint[key] = sum
+	}
+	return fingerprint
+}
+
+// Seed creates the demo account, organizations and monitors that do not exist yet
+func (ds *DemoSeeder) Seed(ctx context.Context) error {
+	demo := ds.config.Demo
+	if demo.User.Email == "" {
+		logger.Info("No demo data to seed")
+		return nil
+	}
+
+	return database.RunInTx(ctx, ds.db, func(ctx context.Context) error {
+		user, err := ds.seedUser(ctx, demo.User)
+		if err != nil {
+			return err
+		}
+
+		for _, orgConfig := range demo.Organizations {
+			organization, err := ds.seedOrganization(ctx, user, orgConfig)
+			if err != nil {
+				return err
+			}
+			for _, monitorConfig := range orgConfig.Monitors {
+				if err := ds.seedMonitor(ctx, organization, monitorConfig); err != nil {
+					return err
+				}
+			}
+		}
+
+		logger.Info("Demo data seeded",
+			logger.Email("email", demo.User.Email),
+			logger.Int("organizations", len(demo.Organizations)))
+		return nil
+	})
+}
+
+// seedUser returns the demo account, creating it with a verified email if needed
+func (ds *DemoSeeder) seedUser(ctx context.Context, config DemoUserConfig) (*models.User, error) {
+	db := database.Conn(ctx, ds.db)
+
+	var user models.User
+	err := db.Where("email = ?", config.Email).First(&user).Error
+	if err == nil {
+		return &user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &SeedError{EntityType: "demo user", EntityName: config.Email, Operation: "lookup", Err: err}
+	}
+
+	now := time.Now().UTC()
+	user = models.User{
+		FirstName:       config.FirstName,
+		LastName:        config.LastName,
+		Email:           utils.StringPtr(config.Email),
+		HashedPassword:  config.Password, // hashed by the BeforeCreate hook
+		EmailVerifiedAt: &now,
+	}
+	if err := db.Create(&user).Error; err != nil {
+		return nil, &SeedError{EntityType: "demo user", EntityName: config.Email, Operation: "create", Err: err}
+	}
+	return &user, nil
+}
+
+// seedOrganization returns the owner's organization named in config, creating it and
+// the membership if needed
+func (ds *DemoSeeder) seedOrganization(ctx context.Context, owner *models.User, config DemoOrganizationConfig) (*models.Organization, error) {
+	db := database.Conn(ctx, ds.db)
+
+	var organization models.Organization
+	err := db.Where("owner_id = ? AND name = ?", owner.ID, config.Name).First(&organization).Error
+	if err == nil {
+		return &organization, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &SeedError{EntityType: "demo organization", EntityName: config.Name, Operation: "lookup", Err: err}
+	}
+
+	var orgType models.OrganizationType
+	if err := db.Where("name = ?", config.Type).First(&orgType).Error; err != nil {
+		return nil, &SeedError{
+			EntityType: "demo organization",
+			EntityName: config.Name,
+			Operation:  "type lookup",
+			Err:        fmt.Errorf("organization type '%s': %w", config.Type, err),
+		}
+	}
+
+	organization = models.Organization{
+		OwnerID: owner.ID,
+		Name:    config.Name,
+		Icon:    utils.StringPtr(""),
+		TypeID:  orgType.ID,
+	}
+	if err := db.Omit("Type").Create(&organization).Error; err != nil {
+		return nil, &SeedError{EntityType: "demo organization", EntityName: config.Name, Operation: "create", Err: err}
+	}
+
+	membership := models.OrganizationUser{OrganizationID: organization.ID, UserID: owner.ID}
+	if err := db.Create(&membership).Error; err != nil {
+		return nil, &SeedError{EntityType: "demo organization", EntityName: config.Name, Operation: "membership", Err: err}
+	}
+
+	roles := &RoleSeeder{db: ds.db, config: ds.config}
+	roleIDs, err := roles.ProvisionOrganization(ctx, organization.ID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerRoleID, ok := roleIDs[RoleOwner]; ok {
+		if err := db.Create(&models.UserRole{UserID: owner.ID, RoleID: ownerRoleID}).Error; err != nil {
+			return nil, &SeedError{EntityType: "demo organization", EntityName: config.Name, Operation: "owner role", Err: err}
+		}
+	}
+	return &organization, nil
+}
+
+// seedMonitor creates the monitor named in config unless the organization already has it
+func (ds *DemoSeeder) seedMonitor(ctx context.Context, organization *models.Organization, config DemoMonitorConfig) error {
+	db := database.Conn(ctx, ds.db)
+
+	var count int64
+	err := db.Model(&models.Monitor{}).
+		Where("organization_id = ? AND name = ?", organization.ID, config.Name).
+		Count(&count).Error
+	if err != nil {
+		return &SeedError{EntityType: "demo monitor", EntityName: config.Name, Operation: "lookup", Err: err}
+	}
+	if count > 0 {
+		return nil
+	}
+
+	monitor := models.Monitor{
+		OrganizationID:  organization.ID,
+		Name:            config.Name,
+		Type:            config.Type,
+		Target:          config.Target,
+		IntervalSeconds: config.IntervalSeconds,
+		TimeoutSeconds:  config.TimeoutSeconds,
+		Status:          models.MonitorStatusPending,
+	}
+	if monitor.Type == "" {
+		monitor.Type = models.MonitorTypeHTTP
+	}
+	if err := db.Omit("Organization").Create(&monitor).Error; err != nil {
+		return &SeedError{EntityType: "demo monitor", EntityName: config.Name, Operation: "create", Err: err}
+	}
+	return nil
+}
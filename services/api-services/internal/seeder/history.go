@@ -0,0 +1,195 @@
+package seeder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// Fingerprinter is implemented by seeders whose runs are recorded in the seed history.
+// Fingerprint maps every entry the seeder writes to a checksum of its configuration.
+type Fingerprinter interface {
+	Fingerprint() map[string]string
+}
+
+// SeedDrift describes how a seeder's configuration differs from its last recorded run
+type SeedDrift struct {
+	Seeder          string
+	FirstRun        bool
+	PreviousRelease string
+	Added           []string
+	Removed         []string
+	Changed         []string
+}
+
+// HasChanges reports whether the seeder would write a different set than last time
+func (d SeedDrift) HasChanges() bool {
+	return d.FirstRun || len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// String summarizes the drift for logs and command output
+func (d SeedDrift) String() string {
+	if d.FirstRun {
+		return d.Seeder + ": never run"
+	}
+	if !d.HasChanges() {
+		return d.Seeder + ": unchanged"
+	}
+
+	parts := make([]string, 0, 3)
+	if len(d.Added) > 0 {
+		parts = append(parts, "added "+strings.Join(d.Added, ", "))
+	}
+	if len(d.Removed) > 0 {
+		parts = append(parts, "removed "+strings.Join(d.Removed, ", "))
+	}
+	if len(d.Changed) > 0 {
+		parts = append(parts, "changed "+strings.Join(d.Changed, ", "))
+	}
+	summary := fmt.Sprintf("%s: %s", d.Seeder, strings.Join(parts, "; "))
+	if d.PreviousRelease != "" {
+		summary += " (since " + d.PreviousRelease + ")"
+	}
+	return summary
+}
+
+// fingerprintItems checksums the JSON encoding of every item, keyed by key(item)
+func fingerprintItems[T any](items []T, key func(T) string) map[string]string {
+	fingerprint := make(map[string]string, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			// Config entries are plain structs of strings; fall back to the formatted value
+			data = []byte(fmt.Sprintf("%+v", item))
+		}
+		hash := sha256.Sum256(data)
+		fingerprint[key(item)] = hex.EncodeToString(hash[:])
+	}
+	return fingerprint
+}
+
+// checksum combines a fingerprint into a single checksum independent of map ordering
+func checksum(fingerprint map[string]string) string {
+	keys := sortedKeys(fingerprint)
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", key, fingerprint[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// diffFingerprints compares the current fingerprint of a seeder with its last run, if any
+func diffFingerprints(name string, last *models.SeedHistory, current map[string]string) (SeedDrift, error) {
+	drift := SeedDrift{Seeder: name}
+	if last == nil {
+		drift.FirstRun = true
+		return drift, nil
+	}
+	drift.PreviousRelease = last.Release
+
+	var previous map[string]string
+	if err := json.Unmarshal(last.Items, &previous); err != nil {
+		return drift, fmt.Errorf("failed to decode seed history of %s: %w", name, err)
+	}
+
+	for _, key := range sortedKeys(current) {
+		previousSum, existed := previous[key]
+		switch {
+		case !existed:
+			drift.Added = append(drift.Added, key)
+		case previousSum != current[key]:
+			drift.Changed = append(drift.Changed, key)
+		}
+	}
+	for _, key := range sortedKeys(previous) {
+		if _, exists := current[key]; !exists {
+			drift.Removed = append(drift.Removed, key)
+		}
+	}
+	return drift, nil
+}
+
+// lastRun returns the most recent history entry of a seeder, or nil if it never ran
+func (sm *SeedManager) lastRun(ctx context.Context, name string) (*models.SeedHistory, error) {
+	var history models.SeedHistory
+	err := database.Conn(ctx, sm.db).
+		Where("seeder = ?", name).
+		Order("created_at DESC").
+		First(&history).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load seed history of %s: %w", name, err)
+	}
+	return &history, nil
+}
+
+// record stores a run of a seeder in the seed history
+func (sm *SeedManager) record(ctx context.Context, name string, fingerprint map[string]string, release string) error {
+	items, err := json.Marshal(fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to encode seed history of %s: %w", name, err)
+	}
+
+	history := &models.SeedHistory{
+		Seeder:   name,
+		Checksum: checksum(fingerprint),
+		Items:    items,
+		Release:  release,
+	}
+	if err := database.Conn(ctx, sm.db).Create(history).Error; err != nil {
+		return fmt.Errorf("failed to record seed history of %s: %w", name, err)
+	}
+	return nil
+}
+
+// Drift compares the configuration of the seeders selected by opts with their last recorded
+// runs. Seeders that do not implement Fingerprinter are not reported.
+func (sm *SeedManager) Drift(ctx context.Context, opts SeedOptions) ([]SeedDrift, error) {
+	names, err := sm.selection(opts)
+	if err != nil {
+		return nil, err
+	}
+	orderedSeeders, err := sm.resolveDependencies(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	drifts := make([]SeedDrift, 0, len(orderedSeeders))
+	for _, seeder := range orderedSeeders {
+		fingerprinter, ok := seeder.(Fingerprinter)
+		if !ok {
+			continue
+		}
+		last, err := sm.lastRun(ctx, seeder.Name())
+		if err != nil {
+			return nil, err
+		}
+		drift, err := diffFingerprints(seeder.Name(), last, fingerprinter.Fingerprint())
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,63 @@
+package seeder
+
+import (
+	"fmt"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+)
+
+// Seed profiles, from the smallest data set to the largest. Each profile runs the
+// seeders of every profile before it.
+const (
+	// ProfileMinimal seeds only the reference data the application needs to run
+	ProfileMinimal = "minimal"
+	// ProfileFull adds data useful for testing a complete installation
+	ProfileFull = "full"
+	// ProfileDemo adds sample accounts, organizations and monitors
+	ProfileDemo = "demo"
+)
+
+var profileLevels = map[string]int{
+	ProfileMinimal: 0,
+	ProfileFull:    1,
+	ProfileDemo:    2,
+}
+
+// Profiled is implemented by seeders that only run from a given profile upwards.
+// Seeders that do not implement it belong to ProfileMinimal.
+type Profiled interface {
+	Profile() string
+}
+
+// ProfileForMode returns the default seed profile of an APP_ENV mode
+func ProfileForMode(mode string) string {
+	switch mode {
+	case config.AppModeProduction:
+		return ProfileMinimal
+	case config.AppModeStaging:
+		return ProfileFull
+	default:
+		return ProfileDemo
+	}
+}
+
+// validateProfile checks that profile is known; an empty profile means ProfileMinimal
+func validateProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
+	if _, ok := profileLevels[profile]; !ok {
+		return fmt.Errorf("unknown seed profile '%s', must be one of '%s', '%s' or '%s'",
+			profile, ProfileMinimal, ProfileFull, ProfileDemo)
+	}
+	return nil
+}
+
+// inProfile reports whether seeder runs under profile
+func inProfile(seeder Seeder, profile string) bool {
+	profiled, ok := seeder.(Profiled)
+	if !ok {
+		return true
+	}
+	return profileLevels[profiled.Profile()] <= profileLevels[profile]
+}
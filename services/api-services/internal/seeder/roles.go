@@ -0,0 +1,222 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// RoleOwner is the default role given to the owner of an organization
+const RoleOwner = "Owner"
+
+// RoleSeeder creates the default roles in every organization and binds them to the
+// seeded permissions. Roles already flagged is_system follow the configuration, so
+// permissions added to or removed from a default role propagate; roles a user has
+// taken over are left alone.
+type RoleSeeder struct {
+	db     *gorm.DB
+	config *SeedConfig
+}
+
+// NewRoleSeeder creates a new instance of RoleSeeder
+func NewRoleSeeder(db *gorm.DB, config *SeedConfig) Seeder {
+	return &RoleSeeder{db: db, config: config}
+}
+
+// Name returns the name of the seeder.
+func (rs *RoleSeeder) Name() string {
+	return "roles"
+}
+
+// Dependencies returns the dependencies of the seeder.
+func (rs *RoleSeeder) Dependencies() []string {
+	return []string{"permissions"}
+}
+
+// Fingerprint returns a checksum of every configured role, keyed by name. The permission
+// names are included since patterns pick up permissions added later.
+func (rs *RoleSeeder) Fingerprint() map[string]string {
+	fingerprint := fingerprintItems(rs.config.Roles, func(c RoleConfig) string { return c.Name })
+	permissions := fingerprintItems(rs.config.Permissions, func(c PermissionConfig) string { return c.Name })
+	fingerprint["permissions"] = checksum(permissions)
+	return fingerprint
+}
+
+// Seed provisions the default roles in every organization
+func (rs *RoleSeeder) Seed(ctx context.Context) error {
+	if err := validateRoles(rs.config.Roles); err != nil {
+		return &SeedError{EntityType: "roles", EntityName: "config", Operation: "validation", Err: err}
+	}
+
+	var organizationIDs []uuid.UUID
+	if err := database.Conn(ctx, rs.db).Model(&models.Organization{}).Pluck("id", &organizationIDs).Error; err != nil {
+		return &SeedError{EntityType: "roles", EntityName: "organizations", Operation: "lookup", Err: err}
+	}
+
+	logger.Info("Starting roles seeding process",
+		logger.Int("roles", len(rs.config.Roles)),
+		logger.Int("organizations", len(organizationIDs)))
+
+	permissions, err := rs.permissionIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return database.RunInTx(ctx, rs.db, func(ctx context.Context) error {
+		for _, organizationID := range organizationIDs {
+			if _, err := rs.provision(ctx, organizationID, permissions); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ProvisionOrganization creates the default roles of one organization, for example right
+// after it was created, and returns their IDs by name
+func (rs *RoleSeeder) ProvisionOrganization(ctx context.Context, organizationID uuid.UUID) (map[string]uuid.UUID, error) {
+	permissions, err := rs.permissionIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rs.provision(ctx, organizationID, permissions)
+}
+
+// provision creates the default roles of an organization given the permission IDs by name
+func (rs *RoleSeeder) provision(ctx context.Context, organizationID uuid.UUID, permissions map[string]uuid.UUID) (map[string]uuid.UUID, error) {
+	roleIDs := make(map[string]uuid.UUID, len(rs.config.Roles))
+	for _, roleConfig := range rs.config.Roles {
+		role, err := rs.ensureRole(ctx, organizationID, roleConfig.Name)
+		if err != nil {
+			return nil, err
+		}
+		roleIDs[role.Name] = role.ID
+
+		if !role.IsSystem {
+			continue
+		}
+		if err := rs.syncPermissions(ctx, role, matchPermissions(roleConfig.Permissions, permissions)); err != nil {
+			return nil, err
+		}
+	}
+	return roleIDs, nil
+}
+
+// ensureRole returns the organization's role named name, creating it as a system role
+func (rs *RoleSeeder) ensureRole(ctx context.Context, organizationID uuid.UUID, name string) (*models.Role, error) {
+	db := database.Conn(ctx, rs.db)
+
+	var role models.Role
+	err := db.Where("organization_id = ? AND name = ?", organizationID, name).First(&role).Error
+	if err == nil {
+		return &role, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &SeedError{EntityType: "role", EntityName: name, Operation: "lookup", Err: err}
+	}
+
+	role = models.Role{OrganizationID: organizationID, Name: name, IsSystem: true}
+	if err := db.Omit("Permissions").Create(&role).Error; err != nil {
+		return nil, &SeedError{EntityType: "role", EntityName: name, Operation: "create", Err: err}
+	}
+	return &role, nil
+}
+
+// syncPermissions binds role to exactly the permissions in want
+func (rs *RoleSeeder) syncPermissions(ctx context.Context, role *models.Role, want map[uuid.UUID]bool) error {
+	db := database.Conn(ctx, rs.db)
+
+	var current []uuid.UUID
+	if err := db.Model(&models.RolePermission{}).Where("role_id = ?", role.ID).Pluck("permission_id", &current).Error; err != nil {
+		return &SeedError{EntityType: "role", EntityName: role.Name, Operation: "permission lookup", Err: err}
+	}
+
+	have := make(map[uuid.UUID]bool, len(current))
+	var stale []uuid.UUID
+	for _, permissionID := range current {
+		have[permissionID] = true
+		if !want[permissionID] {
+			stale = append(stale, permissionID)
+		}
+	}
+
+	missing := make([]models.RolePermission, 0, len(want))
+	for permissionID := range want {
+		if !have[permissionID] {
+			missing = append(missing, models.RolePermission{RoleID: role.ID, PermissionID: permissionID})
+		}
+	}
+
+	if len(missing) > 0 {
+		if err := db.Create(&missing).Error; err != nil {
+			return &SeedError{EntityType: "role", EntityName: role.Name, Operation: "permission bind", Err: err}
+		}
+	}
+	if len(stale) > 0 {
+		err := db.Where("role_id = ? AND permission_id IN ?", role.ID, stale).Delete(&models.RolePermission{}).Error
+		if err != nil {
+			return &SeedError{EntityType: "role", EntityName: role.Name, Operation: "permission unbind", Err: err}
+		}
+	}
+	return nil
+}
+
+// permissionIDs returns the IDs of every permission by name
+func (rs *RoleSeeder) permissionIDs(ctx context.Context) (map[string]uuid.UUID, error) {
+	var permissions []models.Permission
+	if err := database.Conn(ctx, rs.db).Select("id", "name").Find(&permissions).Error; err != nil {
+		return nil, &SeedError{EntityType: "roles", EntityName: "permissions", Operation: "lookup", Err: err}
+	}
+
+	ids := make(map[string]uuid.UUID, len(permissions))
+	for _, permission := range permissions {
+		ids[permission.Name] = permission.ID
+	}
+	return ids, nil
+}
+
+// matchPermissions returns the IDs of the permissions matching any of patterns
+func matchPermissions(patterns []string, permissions map[string]uuid.UUID) map[uuid.UUID]bool {
+	matched := make(map[uuid.UUID]bool)
+	for name, id := range permissions {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched[id] = true
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// validateRoles checks role names and permission patterns
+func validateRoles(roles []RoleConfig) error {
+	seen := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		if role.Name == "" {
+			return errors.New("role name cannot be empty")
+		}
+		if len(role.Name) > 50 {
+			return errors.New("role name cannot exceed 50 characters")
+		}
+		if seen[role.Name] {
+			return fmt.Errorf("duplicate role '%s'", role.Name)
+		}
+		seen[role.Name] = true
+
+		for _, pattern := range role.Permissions {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid permission pattern '%s' in role '%s': %w", pattern, role.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -3,11 +3,14 @@ package seeder
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"errors"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 
 	"gorm.io/gorm"
@@ -21,6 +24,25 @@ type Seeder interface {
 	Seed(context.Context) error
 }
 
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// SeedOptions controls which seeders run and whether their changes are kept
+type SeedOptions struct {
+	// Only restricts seeding to these seeders and their dependencies, regardless of
+	// Profile; empty runs every seeder of Profile
+	Only []string
+	// Profile selects the data set, see ProfileMinimal; empty means ProfileMinimal
+	Profile string
+	// DryRun runs the seeders in a transaction that is rolled back, so the logs show
+	// what would change without writing anything
+	DryRun bool
+	// Force runs seeders even when their configuration matches the last recorded run
+	Force bool
+	// Release is recorded in the seed history, usually the application version
+	Release string
+}
+
 // SeedError represents a custom error type for seeding operations
 type SeedError struct {
 	EntityType string
@@ -60,6 +82,15 @@ func WithConflictColumns[T any](columns ...string) SeederOption[T] {
 	}
 }
 
+// WithUpdateColumns makes conflicting rows take these columns from the seed data instead
+// of being left alone. Only rows still flagged is_system are updated, so entries a user
+// has taken over keep their changes; the entity must have an is_system column.
+func WithUpdateColumns[T any](columns ...string) SeederOption[T] {
+	return func(gs *GenericSeeder[T]) {
+		gs.updateColumns = columns
+	}
+}
+
 // WithValidator sets the validation function for entities.
 func WithValidator[T any](validator func(T) error) SeederOption[T] {
 	return func(gs *GenericSeeder[T]) {
@@ -74,6 +105,7 @@ type GenericSeeder[T any] struct {
 	batchSize       int
 	validator       func(T) error
 	conflictColumns []string
+	updateColumns   []string
 }
 
 // NewGenericSeeder creates a new generic seeder
@@ -114,16 +146,25 @@ func (gs *GenericSeeder[T]) SeedEntities(ctx context.Context, entities []T) erro
 
 	logger.Info(fmt.Sprintf("Starting %s seeding process", gs.entityName), logger.Int("count", len(entities)))
 
-	return gs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return database.Conn(ctx, gs.db).Transaction(func(tx *gorm.DB) error {
 		conflictColumns := make([]clause.Column, len(gs.conflictColumns))
 		for i, col := range gs.conflictColumns {
 			conflictColumns[i] = clause.Column{Name: col}
 		}
 
-		result := tx.Clauses(clause.OnConflict{
+		onConflict := clause.OnConflict{
 			Columns:   conflictColumns,
 			DoNothing: true,
-		}).CreateInBatches(entities, gs.batchSize)
+		}
+		if len(gs.updateColumns) > 0 {
+			onConflict.DoNothing = false
+			onConflict.DoUpdates = clause.AssignmentColumns(gs.updateColumns)
+			onConflict.Where = clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "is_system"}, Value: true},
+			}}
+		}
+
+		result := tx.Clauses(onConflict).CreateInBatches(entities, gs.batchSize)
 
 		if result.Error != nil {
 			return &SeedError{
@@ -153,6 +194,7 @@ func NewPermissionSeeder(db *gorm.DB, config *SeedConfig) Seeder {
 	return &PermissionSeeder{
 		GenericSeeder: NewGenericSeeder(db, "permissions",
 			WithConflictColumns[models.Permission]("name"),
+			WithUpdateColumns[models.Permission]("description"),
 			WithValidator[models.Permission](validatePermission)),
 		config: config,
 	}
@@ -168,6 +210,11 @@ func (ps *PermissionSeeder) Dependencies() []string {
 	return []string{}
 }
 
+// Fingerprint returns a checksum of every configured entry, keyed by name.
+func (ps *PermissionSeeder) Fingerprint() map[string]string {
+	return fingerprintItems(ps.config.Permissions, func(c PermissionConfig) string { return c.Name })
+}
+
 // getPermissions returns the permissions from configuration
 func (ps *PermissionSeeder) getPermissions() []models.Permission {
 	permissions := make([]models.Permission, len(ps.config.Permissions))
@@ -205,6 +252,7 @@ func NewOrganizationTypeSeeder(db *gorm.DB, config *SeedConfig) Seeder {
 	return &OrganizationTypeSeeder{
 		GenericSeeder: NewGenericSeeder(db, "organization_types",
 			WithConflictColumns[models.OrganizationType]("name"),
+			WithUpdateColumns[models.OrganizationType]("description"),
 			WithValidator[models.OrganizationType](validateOrganizationType)),
 		config: config,
 	}
@@ -220,6 +268,11 @@ func (ots *OrganizationTypeSeeder) Dependencies() []string {
 	return []string{}
 }
 
+// Fingerprint returns a checksum of every configured entry, keyed by name.
+func (ots *OrganizationTypeSeeder) Fingerprint() map[string]string {
+	return fingerprintItems(ots.config.OrganizationTypes, func(c OrganizationTypeConfig) string { return c.Name })
+}
+
 // getOrganizationTypes returns the organization types from configuration
 func (ots *OrganizationTypeSeeder) getOrganizationTypes() []models.OrganizationType {
 	organizationTypes := make([]models.OrganizationType, len(ots.config.OrganizationTypes))
@@ -257,6 +310,7 @@ func NewApplicationTypeSeeder(db *gorm.DB, config *SeedConfig) Seeder {
 	return &ApplicationTypeSeeder{
 		GenericSeeder: NewGenericSeeder(db, "application_types",
 			WithConflictColumns[models.ApplicationType]("name"),
+			WithUpdateColumns[models.ApplicationType]("description"),
 			WithValidator[models.ApplicationType](validateApplicationType)),
 		config: config,
 	}
@@ -272,6 +326,11 @@ func (ats *ApplicationTypeSeeder) Dependencies() []string {
 	return []string{}
 }
 
+// Fingerprint returns a checksum of every configured entry, keyed by name.
+func (ats *ApplicationTypeSeeder) Fingerprint() map[string]string {
+	return fingerprintItems(ats.config.ApplicationTypes, func(c ApplicationTypeConfig) string { return c.Name })
+}
+
 // getApplicationTypes returns the application types from configuration
 func (ats *ApplicationTypeSeeder) getApplicationTypes() []models.ApplicationType {
 	applicationTypes := make([]models.ApplicationType, len(ats.config.ApplicationTypes))
@@ -316,6 +375,8 @@ func NewSeedManager(db *gorm.DB, config *SeedConfig) *SeedManager {
 	sm.Register(NewPermissionSeeder(db, config))
 	sm.Register(NewOrganizationTypeSeeder(db, config))
 	sm.Register(NewApplicationTypeSeeder(db, config))
+	sm.Register(NewRoleSeeder(db, config))
+	sm.Register(NewDemoSeeder(db, config))
 
 	return sm
 }
@@ -325,8 +386,19 @@ func (sm *SeedManager) Register(seeder Seeder) {
 	sm.seeders[seeder.Name()] = seeder
 }
 
-// resolveDependencies performs topological sort to resolve seeding order
-func (sm *SeedManager) resolveDependencies() ([]Seeder, error) {
+// Names returns the names of the registered seeders in alphabetical order
+func (sm *SeedManager) Names() []string {
+	names := make([]string, 0, len(sm.seeders))
+	for name := range sm.seeders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveDependencies performs topological sort to resolve seeding order of the named
+// seeders and everything they depend on
+func (sm *SeedManager) resolveDependencies(names []string) ([]Seeder, error) {
 	visited := make(map[string]bool)
 	visiting := make(map[string]bool)
 	result := make([]Seeder, 0, len(sm.seeders))
@@ -359,7 +431,7 @@ func (sm *SeedManager) resolveDependencies() ([]Seeder, error) {
 		return nil
 	}
 
-	for name := range sm.seeders {
+	for _, name := range names {
 		if err := visit(name); err != nil {
 			return nil, err
 		}
@@ -370,22 +442,103 @@ func (sm *SeedManager) resolveDependencies() ([]Seeder, error) {
 
 // SeedWithDependencies executes all seeders in dependency order
 func (sm *SeedManager) SeedWithDependencies(ctx context.Context) error {
-	orderedSeeders, err := sm.resolveDependencies()
+	return sm.Seed(ctx, SeedOptions{})
+}
+
+// Seed executes the seeders selected by opts in dependency order
+func (sm *SeedManager) Seed(ctx context.Context, opts SeedOptions) error {
+	names, err := sm.selection(opts)
+	if err != nil {
+		return err
+	}
+
+	orderedSeeders, err := sm.resolveDependencies(names)
 	if err != nil {
 		return fmt.Errorf("failed to resolve dependencies: %w", err)
 	}
 
+	if !opts.DryRun {
+		return sm.run(ctx, orderedSeeders, opts)
+	}
+
+	logger.Info("Dry run: changes will be rolled back")
+	err = database.RunInTx(ctx, sm.db, func(ctx context.Context) error {
+		if err := sm.run(ctx, orderedSeeders, opts); err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		logger.Info("Dry run complete, no changes were written")
+		return nil
+	}
+	return err
+}
+
+// selection returns the seeders to start dependency resolution from: opts.Only, once
+// every name is checked to be registered, or all seeders of opts.Profile otherwise
+func (sm *SeedManager) selection(opts SeedOptions) ([]string, error) {
+	if err := validateProfile(opts.Profile); err != nil {
+		return nil, err
+	}
+
+	if len(opts.Only) == 0 {
+		names := make([]string, 0, len(sm.seeders))
+		for _, name := range sm.Names() {
+			if inProfile(sm.seeders[name], opts.Profile) {
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	}
+
+	for _, name := range opts.Only {
+		if _, exists := sm.seeders[name]; !exists {
+			return nil, fmt.Errorf("unknown seeder '%s', available: %s", name, strings.Join(sm.Names(), ", "))
+		}
+	}
+	return opts.Only, nil
+}
+
+// run executes seeders in the given order. Seeders implementing Fingerprinter are
+// skipped when their configuration matches the last recorded run, unless forced, and
+// each run is recorded in the seed history in the same transaction as its writes.
+func (sm *SeedManager) run(ctx context.Context, orderedSeeders []Seeder, opts SeedOptions) error {
 	logger.Info("Starting dependency-ordered seeding", logger.Int("total_seeders", len(orderedSeeders)))
 
 	for _, seeder := range orderedSeeders {
-		logger.Info(fmt.Sprintf("Executing seeder: %s", seeder.Name()))
-		if err := seeder.Seed(ctx); err != nil {
-			return &SeedError{
-				EntityType: seeder.Name(),
-				EntityName: "seeder",
-				Operation:  "execution",
-				Err:        err,
+		fingerprinter, tracked := seeder.(Fingerprinter)
+		if !tracked {
+			logger.Info(fmt.Sprintf("Executing seeder: %s", seeder.Name()))
+			if err := seeder.Seed(ctx); err != nil {
+				return newExecutionError(seeder, err)
 			}
+			continue
+		}
+
+		fingerprint := fingerprinter.Fingerprint()
+		last, err := sm.lastRun(ctx, seeder.Name())
+		if err != nil {
+			return err
+		}
+		drift, err := diffFingerprints(seeder.Name(), last, fingerprint)
+		if err != nil {
+			return err
+		}
+		if !drift.HasChanges() && !opts.Force {
+			logger.Info(fmt.Sprintf("Skipping unchanged seeder: %s", seeder.Name()))
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Executing seeder: %s", seeder.Name()), logger.String("drift", drift.String()))
+		err = database.RunInTx(ctx, sm.db, func(ctx context.Context) error {
+			if err := seeder.Seed(ctx); err != nil {
+				return newExecutionError(seeder, err)
+			}
+			return sm.record(ctx, seeder.Name(), fingerprint, opts.Release)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -393,6 +546,16 @@ func (sm *SeedManager) SeedWithDependencies(ctx context.Context) error {
 	return nil
 }
 
+// newExecutionError wraps an error returned by a seeder
+func newExecutionError(seeder Seeder, err error) error {
+	return &SeedError{
+		EntityType: seeder.Name(),
+		EntityName: "seeder",
+		Operation:  "execution",
+		Err:        err,
+	}
+}
+
 // withRetry executes a function with exponential backoff retry
 func withRetry(ctx context.Context, maxRetries int, fn func() error) error {
 	var lastErr error
@@ -429,8 +592,31 @@ func SeedDefaultData(ctx context.Context, db *gorm.DB) error {
 
 // SeedDefaultDataWithConfig seeds all default data with custom configuration path
 func SeedDefaultDataWithConfig(ctx context.Context, db *gorm.DB, configPath string) error {
+	return SeedDefaultDataWithOptions(ctx, db, configPath, SeedOptions{})
+}
+
+// SeedDefaultDataWithOptions seeds the default data selected by opts with custom configuration path
+func SeedDefaultDataWithOptions(ctx context.Context, db *gorm.DB, configPath string, opts SeedOptions) error {
 	logger.Info("Starting default data seeding with enhanced error handling")
 
+	seedManager, err := NewDefaultSeedManager(db, configPath)
+	if err != nil {
+		return err
+	}
+
+	// An invalid selection would fail every attempt, so report it without retrying
+	if _, err := seedManager.selection(opts); err != nil {
+		return err
+	}
+
+	return withRetry(ctx, 3, func() error {
+		return seedManager.Seed(ctx, opts)
+	})
+}
+
+// NewDefaultSeedManager creates a seed manager for the default data with custom configuration
+// path, falling back to the embedded configuration when it cannot be loaded
+func NewDefaultSeedManager(db *gorm.DB, configPath string) (*SeedManager, error) {
 	// Use default config path if not provided
 	if configPath == "" {
 		configPath = "configs/seed_config.yaml"
@@ -441,16 +627,9 @@ func SeedDefaultDataWithConfig(ctx context.Context, db *gorm.DB, configPath stri
 		logger.Warn("Failed to load seed config, using defaults", logger.ErrorField(err))
 		config, err = getDefaultSeedConfig()
 		if err != nil {
-			return fmt.Errorf("failed to load default seed config: %w", err)
+			return nil, fmt.Errorf("failed to load default seed config: %w", err)
 		}
 	}
 
-	seedManager := NewSeedManager(db, config)
-	seedManager.Register(NewPermissionSeeder(db, config))
-	seedManager.Register(NewOrganizationTypeSeeder(db, config))
-	seedManager.Register(NewApplicationTypeSeeder(db, config))
-
-	return withRetry(ctx, 3, func() error {
-		return seedManager.SeedWithDependencies(ctx)
-	})
+	return NewSeedManager(db, config), nil
 }
@@ -0,0 +1,160 @@
+// Package selfmonitor watches the health checks of the dependencies of the service, such
+// as Postgres, Redis and ClickHouse. A dependency failing several checks in a row raises an
+// internal incident, which platform admins are notified of by email along with its recovery.
+//
+// Incidents live in memory and are raised by every replica on its own: the dependencies
+// they are about may well be the ones state would be shared through. Notifications are
+// sent with the email service directly rather than through the outbox for the same reason.
+package selfmonitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+)
+
+// maxResolved is how many resolved incidents are kept for the admin endpoint
+const maxResolved = 50
+
+// Incident is a dependency that kept failing its health checks
+type Incident struct {
+	ID         uuid.UUID `json:"id"`
+	Dependency string    `json:"dependency"`
+	// Failures counts the failed checks in a row, including the ones before the incident
+	Failures      int        `json:"failures"`
+	LastError     string     `json:"last_error"`
+	StartedAt     time.Time  `json:"started_at"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+// Monitor tracks the consecutive failures of each dependency and raises an incident once
+// they reach the threshold
+type Monitor struct {
+	threshold  int
+	recipients []string
+	email      email.Service
+	instance   string
+
+	mu       sync.Mutex
+	failures map[string]int
+	open     map[string]*Incident
+	resolved []Incident
+}
+
+// New creates a monitor raising incidents after cfg.AlertThreshold failures in a row and
+// notifying cfg.AlertEmails. emailService may be nil, in which case incidents are only
+// logged and listed.
+func New(cfg config.AdminConfig, emailService email.Service) *Monitor {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+	return &Monitor{
+		threshold:  cfg.AlertThreshold,
+		recipients: cfg.AlertEmails,
+		email:      emailService,
+		instance:   instance,
+		failures:   make(map[string]int),
+		open:       make(map[string]*Incident),
+	}
+}
+
+// Observe records the result of a health check of dependency, err being nil when it
+// passed. It opens an incident when the failures in a row reach the threshold and
+// resolves the open one when the dependency passes again.
+func (m *Monitor) Observe(ctx context.Context, dependency string, err error) {
+	now := time.Now().UTC()
+
+	m.mu.Lock()
+	var opened, resolved *Incident
+	if err != nil {
+		m.failures[dependency]++
+		if incident, ok := m.open[dependency]; ok {
+			incident.Failures = m.failures[dependency]
+			incident.LastError = err.Error()
+			incident.LastFailureAt = now
+		} else if m.failures[dependency] >= m.threshold {
+			incident = &Incident{
+				ID:            uuid.New(),
+				Dependency:    dependency,
+				Failures:      m.failures[dependency],
+				LastError:     err.Error(),
+				StartedAt:     now,
+				LastFailureAt: now,
+			}
+			m.open[dependency] = incident
+			snapshot := *incident
+			opened = &snapshot
+		}
+	} else {
+		delete(m.failures, dependency)
+		if incident, ok := m.open[dependency]; ok {
+			delete(m.open, dependency)
+			incident.ResolvedAt = &now
+			m.resolved = append([]Incident{*incident}, m.resolved...)
+			if len(m.resolved) > maxResolved {
+				m.resolved = m.resolved[:maxResolved]
+			}
+			resolved = incident
+		}
+	}
+	m.mu.Unlock()
+
+	switch {
+	case opened != nil:
+		logger.Error("Dependency keeps failing its health checks, incident raised",
+			logger.String("incident_id", opened.ID.String()),
+			logger.String("dependency", dependency),
+			logger.Int("failures", opened.Failures),
+			logger.ErrorField(err))
+		m.notify(ctx,
+			fmt.Sprintf("[Incident] %s is failing its health checks", dependency),
+			fmt.Sprintf("%s failed %d health checks in a row on %s.\n\nLast error: %s\nIncident: %s\nStarted at: %s\n",
+				dependency, opened.Failures, m.instance, opened.LastError, opened.ID, opened.StartedAt.Format(time.RFC3339)))
+	case resolved != nil:
+		downtime := resolved.ResolvedAt.Sub(resolved.StartedAt).Round(time.Second)
+		logger.Info("Dependency passes its health checks again, incident resolved",
+			logger.String("incident_id", resolved.ID.String()),
+			logger.String("dependency", dependency),
+			logger.Duration("duration", downtime))
+		m.notify(ctx,
+			fmt.Sprintf("[Resolved] %s is healthy again", dependency),
+			fmt.Sprintf("%s passes its health checks again on %s after %s.\n\nLast error: %s\nIncident: %s\n",
+				dependency, m.instance, downtime, resolved.LastError, resolved.ID))
+	}
+}
+
+// Incidents returns the open incidents by dependency, then the most recently resolved ones
+func (m *Monitor) Incidents() []Incident {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	incidents := make([]Incident, 0, len(m.open)+len(m.resolved))
+	for _, incident := range m.open {
+		incidents = append(incidents, *incident)
+	}
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].Dependency < incidents[j].Dependency })
+	return append(incidents, m.resolved...)
+}
+
+// notify emails every recipient, logging the failures since the incident itself is
+// already recorded
+func (m *Monitor) notify(ctx context.Context, subject, body string) {
+	if m.email == nil || len(m.recipients) == 0 {
+		return
+	}
+	for _, recipient := range m.recipients {
+		if err := m.email.SendEmail(ctx, recipient, subject, body); err != nil {
+			logger.Warn("Failed to notify an admin of an internal incident", logger.Email("recipient", recipient), logger.String("subject", subject), logger.ErrorField(err))
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/integrations"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// burnAlertShortWindow divides the window of a burn alert into the short window that has to
+// burn as fast for the alert to fire, so that the alert stops soon after the burn does
+const burnAlertShortWindow = 12
+
+// firedBurnAlert is a burn alert fired by a monitor and the burn rate over its window
+type firedBurnAlert struct {
+	config.BurnAlert
+	BurnRate float64
+}
+
+// alertBurn opens a warning incident for every monitor target covers that fires a burn
+// alert, unless one is open, and resolves the incidents of the monitors that no longer fire
+// any. A burn alert fires when both its window and the last twelfth of it spend the error
+// budget fast enough to spend the share of the alert within the window. Failures are only
+// logged; the incidents are brought up to date on the next pass.
+func (e *Evaluator) alertBurn(ctx context.Context, target models.EvaluatedSLATarget, u *usage, now time.Time) {
+	from, to := models.CurrentSLAPeriod(target.Period, now)
+	prefix := target.ID.String() + "/"
+
+	evaluated := make(map[string]bool)
+	for id, monitor := range u.monitors {
+		if !covers(target.SLATarget, monitor) {
+			continue
+		}
+		fingerprint := prefix + id.String()
+		evaluated[fingerprint] = true
+
+		fired := e.firedBurnAlert(target.Target, u, id, to.Sub(from))
+		open := u.burning[fingerprint]
+		switch {
+		case fired != nil && open == nil:
+			incident, err := e.openBurnIncident(ctx, target, &monitor, fingerprint, fired, now)
+			if err != nil {
+				logger.Error("Failed to open SLA burn incident",
+					logger.String("sla_target_id", target.ID.String()),
+					logger.String("monitor_id", id.String()),
+					logger.ErrorField(err),
+				)
+				continue
+			}
+			u.burning[fingerprint] = incident
+		case fired == nil && open != nil:
+			e.resolveBurnIncident(ctx, u, open, now)
+		}
+	}
+
+	// Monitors without recent checks or no longer covered have stopped burning the budget
+	for fingerprint, incident := range u.burning {
+		if strings.HasPrefix(fingerprint, prefix) && !evaluated[fingerprint] {
+			e.resolveBurnIncident(ctx, u, incident, now)
+		}
+	}
+}
+
+// firedBurnAlert returns the first burn alert the checks of a monitor fire against a target
+// of availability target over a period of periodLength, nil when none fires
+func (e *Evaluator) firedBurnAlert(target float64, u *usage, monitorID uuid.UUID, periodLength time.Duration) *firedBurnAlert {
+	for _, alert := range e.alerts {
+		threshold := alert.Budget * float64(periodLength) / float64(alert.Window)
+		long := models.BudgetBurnRate(target, u.windows[alert.Window][monitorID])
+		short := models.BudgetBurnRate(target, u.windows[alert.Window/burnAlertShortWindow][monitorID])
+		if long >= threshold && short >= threshold {
+			return &firedBurnAlert{BurnAlert: alert, BurnRate: long}
+		}
+	}
+	return nil
+}
+
+// openBurnIncident opens the warning incident of a monitor burning the error budget of a
+// target, queueing it in the outbox for its side effects
+func (e *Evaluator) openBurnIncident(ctx context.Context, target models.EvaluatedSLATarget, monitor *models.Monitor, fingerprint string, fired *firedBurnAlert, now time.Time) (*models.Incident, error) {
+	incident := &models.Incident{
+		OrganizationID: target.OrganizationID,
+		MonitorID:      &monitor.ID,
+		Source:         models.IncidentSourceSLA,
+		Fingerprint:    fingerprint,
+		Title:          fmt.Sprintf("%s is burning the error budget of %s", monitor.Name, target.Name),
+		Description: fmt.Sprintf("%s spent %g%% of the %s error budget of %s within %s, %.1f times faster than the %g%% target allows.",
+			monitor.Name, fired.Budget*100, target.Period, target.Name, formatWindow(fired.Window), fired.BurnRate, target.Target),
+		Severity:   integrations.SeverityWarning,
+		Status:     models.IncidentStatusOpen,
+		AssigneeID: monitor.OwnerUserID,
+		Team:       monitor.OwnerTeam,
+		Labels: map[string]string{
+			"sla_target_id": target.ID.String(),
+			"burn_window":   formatWindow(fired.Window),
+		},
+		StartedAt: now,
+	}
+
+	err := e.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := e.incidents.Create(ctx, incident); err != nil {
+			return err
+		}
+		return e.outbox.PublishIncident(ctx, outbox.TopicIncidentOpened, incident.ID, incident.OrganizationID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Opened SLA burn incident",
+		logger.String("sla_target_id", target.ID.String()),
+		logger.String("monitor_id", monitor.ID.String()),
+		logger.String("window", formatWindow(fired.Window)),
+	)
+	auditIncident(logger.AuditIncidentOpened, incident)
+	e.publishIncident(ctx, realtime.EventIncidentCreated, incident)
+	return incident, nil
+}
+
+// resolveBurnIncident resolves the warning incident of a monitor that stopped burning the
+// error budget of a target. Failures are only logged.
+func (e *Evaluator) resolveBurnIncident(ctx context.Context, u *usage, incident *models.Incident, now time.Time) {
+	var resolved bool
+	err := e.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if resolved, err = e.incidents.Resolve(ctx, incident.ID, now); err != nil || !resolved {
+			return err
+		}
+		if err := e.outbox.PublishIncident(ctx, outbox.TopicIncidentResolved, incident.ID, incident.OrganizationID); err != nil {
+			return err
+		}
+		return e.outbox.PublishIncident(ctx, outbox.TopicIncidentRecord, incident.ID, incident.OrganizationID)
+	})
+	if err != nil {
+		logger.Error("Failed to resolve SLA burn incident", logger.String("incident_id", incident.ID.String()), logger.ErrorField(err))
+		return
+	}
+
+	delete(u.burning, incident.Fingerprint)
+	if !resolved {
+		return
+	}
+	incident.Status = models.IncidentStatusResolved
+	incident.ResolvedAt = &now
+	auditIncident(logger.AuditIncidentResolved, incident)
+	e.publishIncident(ctx, realtime.EventIncidentResolved, incident)
+}
+
+// auditIncident records a change of a burn incident in the audit log, which the activity
+// feed of its organization is read from
+func auditIncident(event logger.AuditEvent, incident *models.Incident) {
+	logger.Audit(event,
+		logger.String("organization_id", incident.OrganizationID.String()),
+		logger.String("incident_id", incident.ID.String()),
+		logger.String("source", incident.Source),
+		logger.String("title", incident.Title),
+	)
+}
+
+// publishIncident notifies dashboards of an incident change; failures are only logged.
+func (e *Evaluator) publishIncident(ctx context.Context, eventType string, incident *models.Incident) {
+	if e.publisher == nil {
+		return
+	}
+	if err := e.publisher.Publish(ctx, realtime.NewEvent(eventType, incident.OrganizationID, incident)); err != nil {
+		logger.Warn("Failed to publish incident event",
+			logger.String("incident_id", incident.ID.String()),
+			logger.String("event", eventType),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// formatWindow returns a window as the hours or minutes it spans, such as 6h or 90m
+func formatWindow(window time.Duration) string {
+	if window%time.Hour == 0 {
+		return fmt.Sprintf("%dh", window/time.Hour)
+	}
+	return fmt.Sprintf("%dm", window/time.Minute)
+}
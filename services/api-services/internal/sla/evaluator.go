@@ -0,0 +1,214 @@
+// Package sla evaluates the SLA targets of organizations against their check results and
+// warns the owners of organizations whose targets are at risk or breached.
+package sla
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/internal/database"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/internal/realtime"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/email"
+
+	"gorm.io/gorm"
+)
+
+// evaluateLockKey names the lock that keeps replicas from evaluating the same targets
+const evaluateLockKey = "sla:evaluate"
+
+// evaluateLockTTL is how long the evaluation lock lasts without renewal
+const evaluateLockTTL = time.Minute
+
+// Evaluator refreshes the status, error budget and burn rate of every SLA target for its
+// current period. The owner of the organization is emailed the first time in a period a
+// target becomes at risk and again when it is breached; the email goes through the outbox
+// together with the evaluation, so it is queued exactly once. Monitors spending the budget
+// of a target too fast get a warning incident while they do, see alertBurn.
+type Evaluator struct {
+	targets    repositories.SLATargetRepository
+	monitors   repositories.MonitorRepository
+	incidents  repositories.IncidentRepository
+	stats      repositories.MonitorStatsRepository
+	transactor database.Transactor
+	outbox     *outbox.Publisher
+	publisher  realtime.Publisher
+	locks      *cache.Service
+	cfg        config.SLAConfig
+	alerts     []config.BurnAlert
+}
+
+// NewEvaluator creates an evaluator reading targets, monitors and incidents from db and
+// statistics from statsDB. Status changes and incidents are pushed to dashboards through
+// publisher, which may be nil. When locks is not nil, only one replica evaluates at a time.
+// The burn alerts of cfg are expected to be valid; malformed ones disable them.
+func NewEvaluator(db, statsDB *gorm.DB, publisher realtime.Publisher, locks *cache.Service, cfg config.SLAConfig) *Evaluator {
+	alerts, err := cfg.ParseBurnAlerts()
+	if err != nil {
+		logger.Warn("Invalid SLA burn alerts, disabling them", logger.ErrorField(err))
+	}
+	return &Evaluator{
+		targets:    repositories.NewSLATargetRepository(db),
+		monitors:   repositories.NewMonitorRepository(db),
+		incidents:  repositories.NewIncidentRepository(db),
+		stats:      repositories.NewMonitorStatsRepository(statsDB),
+		transactor: database.NewTransactor(db),
+		outbox:     outbox.NewPublisher(db),
+		publisher:  publisher,
+		locks:      locks,
+		cfg:        cfg,
+		alerts:     alerts,
+	}
+}
+
+// Job returns the job evaluating the targets when the evaluator starts and then on every
+// interval
+func (e *Evaluator) Job() jobs.Job {
+	return jobs.Job{Name: "sla_evaluation", Schedule: jobs.Every(e.cfg.Interval), RunOnStart: true, Run: e.EvaluateAll}.
+		Exclusive(e.locks, evaluateLockKey, evaluateLockTTL)
+}
+
+// EvaluateAll evaluates every SLA target. A target whose evaluation fails is logged and
+// retried on the next pass; the others are still evaluated.
+func (e *Evaluator) EvaluateAll(ctx context.Context) error {
+	now := time.Now().UTC()
+	loaded := make(map[usageKey]*usage)
+	failed := make(map[usageKey]bool)
+	afterID := uuid.Nil
+	evaluated, alerted := 0, 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Stop once another replica took the evaluation lock over
+		if err := cache.CheckLease(ctx); err != nil {
+			return err
+		}
+
+		targets, err := e.targets.ListForEvaluation(ctx, afterID, e.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			afterID = target.ID
+			key := usageKey{organizationID: target.OrganizationID, period: target.Period}
+			if failed[key] {
+				continue
+			}
+
+			u, ok := loaded[key]
+			if !ok {
+				u, err = e.load(ctx, key, now)
+				if err != nil {
+					failed[key] = true
+					logger.Error("Failed to load SLA usage",
+						logger.String("organization_id", key.organizationID.String()),
+						logger.String("period", key.period),
+						logger.ErrorField(err),
+					)
+					continue
+				}
+				loaded[key] = u
+			}
+
+			queued, err := e.evaluate(ctx, target, u, now)
+			if err != nil {
+				logger.Error("Failed to evaluate SLA target",
+					logger.String("sla_target_id", target.ID.String()),
+					logger.ErrorField(err),
+				)
+				continue
+			}
+			evaluated++
+			if queued {
+				alerted++
+			}
+		}
+
+		if len(targets) < e.cfg.BatchSize {
+			break
+		}
+	}
+
+	if alerted > 0 {
+		logger.Info("Queued SLA alerts", logger.Int("evaluated", evaluated), logger.Int("alerted", alerted))
+	}
+	return nil
+}
+
+// evaluate refreshes the evaluation of target and alerts the owner when the target got
+// worse than already notified in the period, then opens and resolves the burn incidents of
+// the monitors it covers. It reports whether an email was queued.
+func (e *Evaluator) evaluate(ctx context.Context, target models.EvaluatedSLATarget, u *usage, now time.Time) (bool, error) {
+	period, recent := u.aggregate(target.SLATarget)
+	evaluation := models.EvaluateSLA(target.Target, target.Period, now, period, recent, e.cfg.AtRiskBudget, e.cfg.FastBurnRate)
+
+	alertedStatus := target.AlertedStatus
+	if target.PeriodStart == nil || !target.PeriodStart.Equal(evaluation.PeriodStart) {
+		alertedStatus = ""
+	}
+	notify := evaluation.Availability != nil &&
+		models.SLAStatusSeverity(evaluation.Status) >= models.SLAStatusSeverity(models.SLAStatusAtRisk) &&
+		models.SLAStatusSeverity(evaluation.Status) > models.SLAStatusSeverity(alertedStatus)
+	if notify {
+		alertedStatus = evaluation.Status
+	}
+	queued := notify && target.OwnerEmail != nil
+
+	err := e.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if queued {
+			locale := ""
+			if target.OwnerLocale != nil {
+				locale = *target.OwnerLocale
+			}
+			data := email.SLAAlertData{
+				OrganizationName: target.OrganizationName,
+				TargetName:       target.Name,
+				Status:           evaluation.Status,
+				Target:           target.Target,
+				Period:           target.Period,
+				PeriodStart:      evaluation.PeriodStart,
+				Availability:     *evaluation.Availability,
+				BudgetConsumed:   min(evaluation.BudgetConsumed, 1) * 100,
+				BurnRate:         evaluation.BurnRate,
+			}
+			if err := e.outbox.PublishTemplatedEmail(ctx, *target.OwnerEmail, email.TemplateSLAAlert, locale, data); err != nil {
+				return err
+			}
+		}
+		return e.targets.SaveEvaluation(ctx, target.ID, evaluation, now, alertedStatus)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to save evaluation of SLA target %s: %w", target.ID, err)
+	}
+
+	e.alertBurn(ctx, target, u, now)
+
+	if evaluation.Status != target.Status && e.publisher != nil {
+		event := realtime.NewEvent(realtime.EventSLAStatusChanged, target.OrganizationID, realtime.SLAStatusChange{
+			SLATargetID:    target.ID,
+			PreviousStatus: target.Status,
+			Status:         evaluation.Status,
+			BudgetConsumed: evaluation.BudgetConsumed,
+			BurnRate:       evaluation.BurnRate,
+			EvaluatedAt:    now,
+		})
+		if err := e.publisher.Publish(ctx, event); err != nil {
+			logger.Warn("Failed to publish SLA status change",
+				logger.String("sla_target_id", target.ID.String()),
+				logger.ErrorField(err),
+			)
+		}
+	}
+	return queued, nil
+}
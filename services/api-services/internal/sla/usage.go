@@ -0,0 +1,127 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+)
+
+// usageKey identifies the check results loaded for the targets of an organization that
+// share a period
+type usageKey struct {
+	organizationID uuid.UUID
+	period         string
+}
+
+// usage holds the per-monitor check results of an organization over the current period,
+// over the recent burn rate window and over the windows of the burn alerts, and the open
+// incidents of the burn alerts by fingerprint
+type usage struct {
+	monitors map[uuid.UUID]models.Monitor
+	period   []models.MonitorStatsSummary
+	recent   []models.MonitorStatsSummary
+	windows  map[time.Duration]map[uuid.UUID]models.MonitorStatsPoint
+	burning  map[string]*models.Incident
+}
+
+// load reads the check results of the organization of key, by hour over the period so far
+// and by minute over the burn rate window and the windows of the burn alerts, and the open
+// incidents of the burn alerts
+func (e *Evaluator) load(ctx context.Context, key usageKey, now time.Time) (*usage, error) {
+	from, _ := models.CurrentSLAPeriod(key.period, now)
+	period, err := e.stats.OrganizationSummaries(ctx, key.organizationID, models.StatsResolutionHour, from, now)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := e.stats.OrganizationSummaries(ctx, key.organizationID, models.StatsResolutionMinute, now.Add(-e.cfg.FastBurnWindow), now)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(period)+len(recent))
+	for _, summary := range period {
+		ids = append(ids, summary.MonitorID)
+	}
+	for _, summary := range recent {
+		ids = append(ids, summary.MonitorID)
+	}
+
+	windows := make(map[time.Duration]map[uuid.UUID]models.MonitorStatsPoint, len(e.alerts)*2)
+	for _, alert := range e.alerts {
+		for _, window := range []time.Duration{alert.Window, alert.Window / burnAlertShortWindow} {
+			if _, ok := windows[window]; ok {
+				continue
+			}
+			summaries, err := e.stats.OrganizationSummaries(ctx, key.organizationID, models.StatsResolutionMinute, now.Add(-window), now)
+			if err != nil {
+				return nil, err
+			}
+			windows[window] = make(map[uuid.UUID]models.MonitorStatsPoint, len(summaries))
+			for _, summary := range summaries {
+				windows[window][summary.MonitorID] = summary.MonitorStatsPoint
+				ids = append(ids, summary.MonitorID)
+			}
+		}
+	}
+
+	// Deleted monitors are not returned, so their results no longer count
+	monitors, err := e.monitors.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := e.incidents.ListOpenBySource(ctx, key.organizationID, models.IncidentSourceSLA)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &usage{
+		monitors: make(map[uuid.UUID]models.Monitor, len(monitors)),
+		period:   period,
+		recent:   recent,
+		windows:  windows,
+		burning:  make(map[string]*models.Incident, len(incidents)),
+	}
+	for _, monitor := range monitors {
+		u.monitors[monitor.ID] = monitor
+	}
+	for i := range incidents {
+		u.burning[incidents[i].Fingerprint] = &incidents[i]
+	}
+	return u, nil
+}
+
+// aggregate sums the check results of the monitors target covers over the period and
+// over the recent window
+func (u *usage) aggregate(target models.SLATarget) (period, recent models.MonitorStatsPoint) {
+	return u.sum(target, u.period), u.sum(target, u.recent)
+}
+
+// sum adds up the summaries of the monitors target covers
+func (u *usage) sum(target models.SLATarget, summaries []models.MonitorStatsSummary) models.MonitorStatsPoint {
+	var total models.MonitorStatsPoint
+	for _, summary := range summaries {
+		monitor, ok := u.monitors[summary.MonitorID]
+		if !ok || !covers(target, monitor) {
+			continue
+		}
+		total.TotalChecks += summary.TotalChecks
+		total.UpChecks += summary.UpChecks
+		total.DegradedChecks += summary.DegradedChecks
+		total.DownChecks += summary.DownChecks
+	}
+	return total
+}
+
+// covers reports whether monitor counts towards target
+func covers(target models.SLATarget, monitor models.Monitor) bool {
+	switch {
+	case target.MonitorID != nil:
+		return monitor.ID == *target.MonitorID
+	case target.EnvironmentID != nil:
+		return monitor.EnvironmentID != nil && *monitor.EnvironmentID == *target.EnvironmentID
+	default:
+		return true
+	}
+}
@@ -0,0 +1,43 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey int
+
+const (
+	organizationKey contextKey = iota
+	unscopedKey
+)
+
+// WithOrganization returns a context whose database queries are scoped to organizationID
+func WithOrganization(ctx context.Context, organizationID uuid.UUID) context.Context {
+	return context.WithValue(ctx, organizationKey, organizationID)
+}
+
+// OrganizationFromContext returns the organization the context is scoped to, if any
+func OrganizationFromContext(ctx context.Context) (uuid.UUID, bool) {
+	if ctx == nil || IsUnscoped(ctx) {
+		return uuid.Nil, false
+	}
+	organizationID, ok := ctx.Value(organizationKey).(uuid.UUID)
+	if !ok || organizationID == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return organizationID, true
+}
+
+// WithoutScope returns a context that bypasses tenant scoping. It is meant for
+// system work such as schedulers and migrations that span every organization.
+func WithoutScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unscopedKey, true)
+}
+
+// IsUnscoped reports whether tenant scoping was explicitly disabled on ctx
+func IsUnscoped(ctx context.Context) bool {
+	unscoped, _ := ctx.Value(unscopedKey).(bool)
+	return unscoped
+}
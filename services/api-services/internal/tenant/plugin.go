@@ -0,0 +1,141 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Column is the column tenant-owned tables use to reference their organization
+const Column = "organization_id"
+
+// ErrCrossTenantWrite is returned when a record is written for an organization
+// other than the one the request is scoped to.
+var ErrCrossTenantWrite = errors.New("record belongs to a different organization")
+
+// Owned marks models whose rows belong to a single organization. Queries run
+// with a context from WithOrganization are automatically filtered on Column.
+type Owned interface {
+	OrganizationOwned()
+}
+
+// Plugin is a GORM plugin that enforces tenant scoping on Owned models.
+//
+// Queries, row scans, updates and deletes get an "organization_id = ?" condition
+// and creates have their organization filled in, or are rejected when it points
+// at another organization. Raw and Exec statements are not rewritten.
+type Plugin struct {
+	owned sync.Map // reflect.Type -> bool
+}
+
+// NewPlugin creates a tenant scoping plugin
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+// Name implements gorm.Plugin
+func (p *Plugin) Name() string {
+	return "tenant:scope"
+}
+
+// Initialize implements gorm.Plugin by registering the scoping callbacks
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", p.scope); err != nil {
+		return fmt.Errorf("failed to register tenant query callback: %w", err)
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:row", p.scope); err != nil {
+		return fmt.Errorf("failed to register tenant row callback: %w", err)
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", p.scope); err != nil {
+		return fmt.Errorf("failed to register tenant update callback: %w", err)
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", p.scope); err != nil {
+		return fmt.Errorf("failed to register tenant delete callback: %w", err)
+	}
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", p.assign); err != nil {
+		return fmt.Errorf("failed to register tenant create callback: %w", err)
+	}
+	return nil
+}
+
+// scope adds the organization condition to statements on Owned models
+func (p *Plugin) scope(db *gorm.DB) {
+	organizationID, field, ok := p.resolve(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: field.DBName}, Value: organizationID},
+	}})
+}
+
+// assign stamps new Owned records with the scoped organization
+func (p *Plugin) assign(db *gorm.DB) {
+	organizationID, field, ok := p.resolve(db)
+	if !ok {
+		return
+	}
+
+	ctx := db.Statement.Context
+	stamp := func(rv reflect.Value) {
+		value, isZero := field.ValueOf(ctx, rv)
+		if isZero {
+			if err := field.Set(ctx, rv, organizationID); err != nil {
+				_ = db.AddError(fmt.Errorf("failed to set organization: %w", err))
+			}
+			return
+		}
+		if id, ok := value.(uuid.UUID); ok && id != organizationID {
+			_ = db.AddError(ErrCrossTenantWrite)
+		}
+	}
+
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			stamp(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		stamp(rv)
+	}
+}
+
+// resolve returns the scoped organization and its column when the statement
+// targets an Owned model and runs with a tenant context
+func (p *Plugin) resolve(db *gorm.DB) (uuid.UUID, *schema.Field, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return uuid.Nil, nil, false
+	}
+
+	organizationID, ok := OrganizationFromContext(db.Statement.Context)
+	if !ok || !p.isOwned(db.Statement.Schema.ModelType) {
+		return uuid.Nil, nil, false
+	}
+
+	field := db.Statement.Schema.LookUpField(Column)
+	if field == nil {
+		_ = db.AddError(fmt.Errorf("tenant-owned model %s has no %s column", db.Statement.Schema.Name, Column))
+		return uuid.Nil, nil, false
+	}
+	return organizationID, field, true
+}
+
+// isOwned reports whether the model type implements Owned, caching the result
+func (p *Plugin) isOwned(modelType reflect.Type) bool {
+	if cached, ok := p.owned.Load(modelType); ok {
+		return cached.(bool)
+	}
+
+	_, owned := reflect.New(modelType).Interface().(Owned)
+	p.owned.Store(modelType, owned)
+	return owned
+}
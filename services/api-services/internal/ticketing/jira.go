@@ -0,0 +1,142 @@
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultJiraIssueType is the type of the Jira issues filed when the account sets none
+const defaultJiraIssueType = "Bug"
+
+// jiraDoneCategory is the status category of the statuses that close Jira issues
+const jiraDoneCategory = "done"
+
+// jiraClient files issues with the REST API v3 of Jira Cloud
+type jiraClient struct {
+	client  *http.Client
+	baseURL string
+	account Account
+}
+
+func newJiraClient(client *http.Client, account Account) (*jiraClient, error) {
+	base, err := url.Parse(strings.TrimSpace(account.BaseURL))
+	if err != nil || base.Scheme != "https" || base.Host == "" {
+		return nil, errors.New("Jira site must be an https URL")
+	}
+	if account.Email == "" || account.Project == "" {
+		return nil, errors.New("Jira accounts need an email and a project key")
+	}
+	if account.IssueType == "" {
+		account.IssueType = defaultJiraIssueType
+	}
+	return &jiraClient{client: client, baseURL: strings.TrimRight(base.String(), "/"), account: account}, nil
+}
+
+// CreateIssue implements Client
+func (c *jiraClient) CreateIssue(ctx context.Context, issue Issue) (*Ticket, error) {
+	body := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": c.account.Project},
+			"issuetype":   map[string]string{"name": c.account.IssueType},
+			"summary":     issue.Title,
+			"description": jiraDocument(issue.Description),
+		},
+	}
+	var created struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := c.call(ctx, http.MethodPost, "/rest/api/3/issue", body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create Jira issue: %w", err)
+	}
+	return &Ticket{ExternalID: created.ID, Key: created.Key, URL: c.baseURL + "/browse/" + created.Key}, nil
+}
+
+// ResolveIssue implements Client with the first transition of the issue to a done status
+func (c *jiraClient) ResolveIssue(ctx context.Context, externalID string) error {
+	path := "/rest/api/3/issue/" + url.PathEscape(externalID) + "/transitions"
+	var available struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.call(ctx, http.MethodGet, path, nil, &available); err != nil {
+		return fmt.Errorf("failed to list Jira issue transitions: %w", err)
+	}
+
+	for _, transition := range available.Transitions {
+		if transition.To.StatusCategory.Key != jiraDoneCategory {
+			continue
+		}
+		body := map[string]any{"transition": map[string]string{"id": transition.ID}}
+		if err := c.call(ctx, http.MethodPost, path, body, nil); err != nil {
+			return fmt.Errorf("failed to transition Jira issue: %w", err)
+		}
+		return nil
+	}
+	return ErrNoDoneState
+}
+
+// call sends a request to the Jira API and decodes its response into out when not nil
+func (c *jiraClient) call(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.account.Email, c.account.APIToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(method+" "+path, resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// jiraDocument wraps text in the Atlassian document format Jira descriptions are written in,
+// one paragraph per line
+func jiraDocument(text string) map[string]any {
+	paragraphs := []map[string]any{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		paragraphs = append(paragraphs, map[string]any{
+			"type":    "paragraph",
+			"content": []map[string]any{{"type": "text", "text": line}},
+		})
+	}
+	return map[string]any{"type": "doc", "version": 1, "content": paragraphs}
+}
@@ -0,0 +1,133 @@
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// linearURL is the GraphQL endpoint of the Linear API
+const linearURL = "https://api.linear.app/graphql"
+
+// linearClient files issues with the GraphQL API of Linear
+type linearClient struct {
+	client   *http.Client
+	endpoint string
+	account  Account
+}
+
+func newLinearClient(client *http.Client, account Account) (*linearClient, error) {
+	if account.Project == "" {
+		return nil, errors.New("Linear accounts need a team ID")
+	}
+	return &linearClient{client: client, endpoint: linearURL, account: account}, nil
+}
+
+// CreateIssue implements Client
+func (c *linearClient) CreateIssue(ctx context.Context, issue Issue) (*Ticket, error) {
+	const mutation = `mutation($input: IssueCreateInput!) {
+  issueCreate(input: $input) { success issue { id identifier url } }
+}`
+	variables := map[string]any{
+		"input": map[string]string{"teamId": c.account.Project, "title": issue.Title, "description": issue.Description},
+	}
+	var data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+				URL        string `json:"url"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	if err := c.query(ctx, mutation, variables, &data); err != nil {
+		return nil, fmt.Errorf("failed to create Linear issue: %w", err)
+	}
+	if !data.IssueCreate.Success {
+		return nil, errors.New("failed to create Linear issue: not created")
+	}
+	created := data.IssueCreate.Issue
+	return &Ticket{ExternalID: created.ID, Key: created.Identifier, URL: created.URL}, nil
+}
+
+// ResolveIssue implements Client with the first completed state of the team of the issue
+func (c *linearClient) ResolveIssue(ctx context.Context, externalID string) error {
+	const statesQuery = `query($id: String!) {
+  issue(id: $id) { team { states(filter: { type: { eq: "completed" } }) { nodes { id } } } }
+}`
+	var states struct {
+		Issue struct {
+			Team struct {
+				States struct {
+					Nodes []struct {
+						ID string `json:"id"`
+					} `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	}
+	if err := c.query(ctx, statesQuery, map[string]any{"id": externalID}, &states); err != nil {
+		return fmt.Errorf("failed to list Linear workflow states: %w", err)
+	}
+	nodes := states.Issue.Team.States.Nodes
+	if len(nodes) == 0 {
+		return ErrNoDoneState
+	}
+
+	const mutation = `mutation($id: String!, $stateId: String!) {
+  issueUpdate(id: $id, input: { stateId: $stateId }) { success }
+}`
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+	if err := c.query(ctx, mutation, map[string]any{"id": externalID, "stateId": nodes[0].ID}, &data); err != nil {
+		return fmt.Errorf("failed to complete Linear issue: %w", err)
+	}
+	if !data.IssueUpdate.Success {
+		return errors.New("failed to complete Linear issue: not updated")
+	}
+	return nil
+}
+
+// query runs a GraphQL query and decodes its data into out
+func (c *linearClient) query(ctx context.Context, query string, variables map[string]any, out any) error {
+	encoded, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", c.account.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse("Linear API", resp); err != nil {
+		return err
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+	}
+	return json.Unmarshal(result.Data, out)
+}
@@ -0,0 +1,239 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/models"
+	"github.com/samaasi/uptime-application/services/api-services/internal/api/repositories"
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/internal/outbox"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// maxTitleLength is the longest issue title filed, the limit of Jira summaries
+const maxTitleLength = 255
+
+var (
+	// ErrAlreadyFiled is returned when filing an incident through an integration it was
+	// already filed through
+	ErrAlreadyFiled = errors.New("incident is already filed through this integration")
+	// ErrTrackerFailed wraps the failures of the issue tracker of an integration
+	ErrTrackerFailed = errors.New("issue tracker request failed")
+)
+
+// Syncer files incidents through ticket integrations and moves their issues to a done state
+// once the incidents are resolved. Its outbox handlers file the incidents opened through the
+// integrations that file every incident, and resolve the tickets of the incidents resolved.
+// Delivery being at-least-once, an issue may be filed twice when the process dies between
+// filing it and recording its ticket.
+type Syncer struct {
+	integrations repositories.TicketIntegrationRepository
+	tickets      repositories.IncidentTicketRepository
+	incidents    repositories.IncidentRepository
+	newClient    func(provider string, account Account) (Client, error)
+}
+
+// NewSyncer creates a syncer reading integrations, tickets and incidents from db
+func NewSyncer(db *gorm.DB) *Syncer {
+	return &Syncer{
+		integrations: repositories.NewTicketIntegrationRepository(db),
+		tickets:      repositories.NewIncidentTicketRepository(db),
+		incidents:    repositories.NewIncidentRepository(db),
+		newClient:    NewClient,
+	}
+}
+
+// AccountOf returns the account of the tracker of an integration
+func AccountOf(integration *models.TicketIntegration) Account {
+	return Account{
+		BaseURL:   integration.BaseURL,
+		Email:     integration.Email,
+		APIToken:  integration.APIToken,
+		Project:   integration.Project,
+		IssueType: integration.IssueType,
+	}
+}
+
+// File files an incident as an issue through an integration. It fails with ErrAlreadyFiled
+// when the incident already has a ticket for the integration, and wraps ErrTrackerFailed
+// when the tracker does, which is also recorded on the integration.
+func (s *Syncer) File(ctx context.Context, integration *models.TicketIntegration, incident *models.Incident) (*models.IncidentTicket, error) {
+	if _, err := s.tickets.GetByIncidentAndIntegration(ctx, incident.ID, integration.ID); !errors.Is(err, common.ErrNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrAlreadyFiled
+	}
+
+	client, err := s.newClient(integration.Provider, AccountOf(integration))
+	if err != nil {
+		return nil, s.trackerFailed(ctx, integration, err)
+	}
+	issue, err := client.CreateIssue(ctx, issueOf(incident))
+	if err != nil {
+		return nil, s.trackerFailed(ctx, integration, err)
+	}
+
+	ticket := &models.IncidentTicket{
+		OrganizationID: incident.OrganizationID,
+		IncidentID:     incident.ID,
+		IntegrationID:  integration.ID,
+		Provider:       integration.Provider,
+		ExternalID:     issue.ExternalID,
+		Key:            issue.Key,
+		URL:            issue.URL,
+		Status:         models.IncidentTicketStatusOpen,
+	}
+	created, err := s.tickets.CreateIfAbsent(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		logger.WarnCtx(ctx, "Incident was filed twice concurrently",
+			logger.String("incident_id", incident.ID.String()),
+			logger.String("integration_id", integration.ID.String()),
+			logger.String("key", issue.Key),
+		)
+		return nil, ErrAlreadyFiled
+	}
+
+	logger.InfoCtx(ctx, "Incident filed as an issue",
+		logger.String("incident_id", incident.ID.String()),
+		logger.String("integration_id", integration.ID.String()),
+		logger.String("key", issue.Key),
+	)
+	return ticket, nil
+}
+
+// HandleIncidentOpened returns the handler of outbox.TopicIncidentOpened messages, filing the
+// incident through every integration of its organization that files every incident. Incidents
+// suppressed or resolved by then are not filed.
+func (s *Syncer) HandleIncidentOpened() outbox.Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		incident, err := s.incidentOf(ctx, payload)
+		if err != nil || incident == nil {
+			return err
+		}
+		if incident.Suppressed || incident.Status != models.IncidentStatusOpen {
+			return nil
+		}
+
+		integrations, err := s.integrations.ListAutoCreate(ctx, incident.OrganizationID)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for i := range integrations {
+			if _, err := s.File(ctx, &integrations[i], incident); err != nil && !errors.Is(err, ErrAlreadyFiled) {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// HandleIncidentResolved returns the handler of outbox.TopicIncidentResolved messages, moving
+// the open tickets of the incident to a done state. Tickets whose integration was deleted or
+// whose issue has no done state to move to are left open.
+func (s *Syncer) HandleIncidentResolved() outbox.Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		incident, err := s.incidentOf(ctx, payload)
+		if err != nil || incident == nil {
+			return err
+		}
+
+		tickets, err := s.tickets.ListOpenByIncident(ctx, incident.ID)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for i := range tickets {
+			if err := s.resolve(ctx, &tickets[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// resolve moves the issue of a ticket to a done state and marks the ticket resolved
+func (s *Syncer) resolve(ctx context.Context, ticket *models.IncidentTicket) error {
+	integration, err := s.integrations.GetByID(ctx, ticket.IntegrationID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	client, err := s.newClient(integration.Provider, AccountOf(integration))
+	if err == nil {
+		err = client.ResolveIssue(ctx, ticket.ExternalID)
+	}
+	if errors.Is(err, ErrNoDoneState) {
+		logger.WarnCtx(ctx, "Leaving incident ticket open, its issue has no done state",
+			logger.String("ticket_id", ticket.ID.String()),
+			logger.String("key", ticket.Key),
+		)
+		return nil
+	}
+	if err != nil {
+		return s.trackerFailed(ctx, integration, err)
+	}
+	return s.tickets.MarkResolved(ctx, ticket.ID, time.Now().UTC())
+}
+
+// incidentOf loads the incident of an outbox message, nil when it no longer exists
+func (s *Syncer) incidentOf(ctx context.Context, payload json.RawMessage) (*models.Incident, error) {
+	var message outbox.IncidentMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, fmt.Errorf("invalid incident payload: %w", err)
+	}
+	incident, err := s.incidents.GetByID(ctx, message.IncidentID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return incident, nil
+}
+
+// trackerFailed records the failure of the tracker of an integration and wraps it in
+// ErrTrackerFailed
+func (s *Syncer) trackerFailed(ctx context.Context, integration *models.TicketIntegration, err error) error {
+	if recordErr := s.integrations.RecordError(ctx, integration.ID, err.Error(), time.Now().UTC()); recordErr != nil {
+		logger.WarnCtx(ctx, "Failed to record ticket integration error", logger.ErrorField(recordErr))
+	}
+	return fmt.Errorf("%w: %v", ErrTrackerFailed, err)
+}
+
+// issueOf returns the issue an incident is filed as
+func issueOf(incident *models.Incident) Issue {
+	title := "[" + incident.Ref() + "] " + incident.Title
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = string(runes[:maxTitleLength])
+	}
+
+	lines := []string{
+		"Severity: " + incident.Severity,
+		"Started at: " + incident.StartedAt.UTC().Format(time.RFC3339),
+	}
+	if incident.Team != "" {
+		lines = append(lines, "Team: "+incident.Team)
+	}
+	if incident.URL != "" {
+		lines = append(lines, "Source: "+incident.URL)
+	}
+	if incident.Description != "" {
+		lines = append(lines, "", incident.Description)
+	}
+	return Issue{Title: title, Description: strings.Join(lines, "\n")}
+}
@@ -0,0 +1,100 @@
+// Package ticketing files the incidents of organizations as issues in their issue tracker,
+// Jira or Linear, and closes those issues when the incidents are resolved.
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+)
+
+// Supported providers
+const (
+	ProviderJira   = "jira"
+	ProviderLinear = "linear"
+)
+
+// callTimeout bounds a call to an issue tracker, retries included
+const callTimeout = 15 * time.Second
+
+// maxErrorBodyLength is how much of an error response is kept in the error
+const maxErrorBodyLength = 512
+
+var (
+	// ErrUnsupportedProvider is returned for providers this package does not know
+	ErrUnsupportedProvider = errors.New("unsupported ticketing provider")
+	// ErrNoDoneState is returned when an issue cannot be moved to a done state, such as a
+	// Jira workflow without a transition to one
+	ErrNoDoneState = errors.New("issue has no done state to move to")
+)
+
+// Account is how to reach the issue tracker of an organization
+type Account struct {
+	// BaseURL is the site of a Jira account, such as https://example.atlassian.net
+	BaseURL string
+	// Email is the Jira user the API token belongs to; Linear API keys need none
+	Email    string
+	APIToken string
+	// Project is the key of the Jira project or the ID of the Linear team issues are filed in
+	Project string
+	// IssueType is the type of the Jira issues filed, such as Bug
+	IssueType string
+}
+
+// Issue is what an incident is filed as
+type Issue struct {
+	Title       string
+	Description string
+}
+
+// Ticket is an issue filed in a tracker
+type Ticket struct {
+	// ExternalID is the ID the tracker identifies the issue by in its API
+	ExternalID string
+	// Key is the reference people know the issue by, such as OPS-42
+	Key string
+	URL string
+}
+
+// Client files and closes the issues of one account
+type Client interface {
+	CreateIssue(ctx context.Context, issue Issue) (*Ticket, error)
+	// ResolveIssue moves an issue to a done state. It fails with ErrNoDoneState when the
+	// issue cannot be moved to one.
+	ResolveIssue(ctx context.Context, externalID string) error
+}
+
+// NewClient returns the client of an account of provider. Calls only go to public
+// addresses, since the Jira site is given by users.
+func NewClient(provider string, account Account) (Client, error) {
+	client := httpclient.New(httpclient.Options{
+		Name:           "ticketing",
+		Timeout:        callTimeout,
+		DisableBreaker: true,
+		Transport:      httpclient.NewPublicTransport(),
+	})
+
+	switch provider {
+	case ProviderJira:
+		return newJiraClient(client, account)
+	case ProviderLinear:
+		return newLinearClient(client, account)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedProvider, provider)
+	}
+}
+
+// checkResponse turns a response that is not a success into an error naming the call
+func checkResponse(call string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	return fmt.Errorf("%s failed with status %d: %s", call, resp.StatusCode, strings.TrimSpace(string(body)))
+}
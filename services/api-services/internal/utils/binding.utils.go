@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateStruct checks the `validate` tags of a struct or pointer to struct, returning
+// validator.ValidationErrors when a rule fails. Other values have nothing to check.
+func ValidateStruct(obj any) error {
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	return structValidator.Struct(obj)
+}
+
+// BindJSON binds the JSON body of the request into req, a pointer to a DTO, and checks both
+// its `binding` and `validate` tags. When the body is too large, malformed or invalid it
+// sends the error response, with the field errors for invalid bodies, and returns false.
+func BindJSON(c *gin.Context, req any) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		err = ValidateStruct(req)
+	}
+	if err == nil {
+		return true
+	}
+
+	var validationErrors validator.ValidationErrors
+	switch {
+	case IsRequestTooLarge(err):
+		SendPayloadTooLarge(c, "Request body too large")
+	case errors.As(err, &validationErrors):
+		SendValidationError(c, err, req)
+	default:
+		SendBadRequest(c, "Invalid request body")
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ?format= values that switch a list endpoint to a streamed download.
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// exportFlushEvery controls how many rows are written before flushing to the client.
+const exportFlushEvery = 500
+
+// CSVColumn describes one exported column: its header and how to render a row's value.
+type CSVColumn[T any] struct {
+	Header string
+	Value  func(item *T) string
+}
+
+// WantsCSV reports whether the client requested a CSV export via ?format=csv.
+func WantsCSV(c *gin.Context) bool {
+	return strings.EqualFold(c.Query("format"), ExportFormatCSV)
+}
+
+// WantsNDJSON reports whether the client requested a newline-delimited JSON export via
+// ?format=ndjson.
+func WantsNDJSON(c *gin.Context) bool {
+	return strings.EqualFold(c.Query("format"), ExportFormatNDJSON)
+}
+
+// WantsExport reports whether the client requested a streamed download via ?format=csv or
+// ?format=ndjson.
+func WantsExport(c *gin.Context) bool {
+	return WantsCSV(c) || WantsNDJSON(c)
+}
+
+// clearWriteDeadline lifts the write timeout of the server for a streamed download, which
+// lasts as long as there are rows to send
+func clearWriteDeadline(c *gin.Context) {
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("Failed to clear write deadline of export", logger.ErrorField(err), logger.String("request_id", GetRequestID(c)))
+	}
+}
+
+// StreamCSV writes a CSV attachment row by row as produce yields items, flushing periodically
+// so large exports are never buffered in memory. Once the first byte is written the status
+// can no longer change, so errors from produce are logged and end the stream early.
+func StreamCSV[T any](c *gin.Context, filename string, columns []CSVColumn[T], produce func(yield func(item *T) error) error) {
+	clearWriteDeadline(c)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", ContentDisposition(DispositionAttachment, filename))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Header
+	}
+	if err := w.Write(header); err != nil {
+		logger.Error("Failed to write CSV header", logger.ErrorField(err), logger.String("request_id", GetRequestID(c)))
+		return
+	}
+
+	rows := 0
+	record := make([]string, len(columns))
+	err := produce(func(item *T) error {
+		for i, col := range columns {
+			record[i] = sanitizeCSVCell(col.Value(item))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushEvery == 0 {
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	w.Flush()
+	if err == nil {
+		err = w.Error()
+	}
+	if err != nil {
+		logger.Error("CSV export aborted",
+			logger.ErrorField(err),
+			logger.Int("rows_written", rows),
+			logger.String("request_id", GetRequestID(c)),
+		)
+		return
+	}
+	c.Writer.Flush()
+}
+
+// StreamNDJSON writes an NDJSON attachment, one JSON document per line, as produce yields
+// items. It flushes like StreamCSV, and like it logs errors from produce and ends the
+// stream early, since the status is sent with the first line.
+func StreamNDJSON[T any](c *gin.Context, filename string, produce func(yield func(item *T) error) error) {
+	clearWriteDeadline(c)
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", ContentDisposition(DispositionAttachment, filename))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	// Encode writes a newline after every document
+	enc := json.NewEncoder(c.Writer)
+	rows := 0
+	err := produce(func(item *T) error {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("NDJSON export aborted",
+			logger.ErrorField(err),
+			logger.Int("rows_written", rows),
+			logger.String("request_id", GetRequestID(c)),
+		)
+		return
+	}
+	c.Writer.Flush()
+}
+
+// WriteCSV writes items as a CSV document with a header row to w, for exports that are
+// stored rather than streamed to a client.
+func WriteCSV[T any](w io.Writer, columns []CSVColumn[T], items []T) error {
+	cw := csv.NewWriter(w)
+
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		record[i] = col.Header
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for i := range items {
+		for j, col := range columns {
+			record[j] = sanitizeCSVCell(col.Value(&items[i]))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// sanitizeCSVCell neutralizes values that spreadsheet applications would evaluate as formulas.
+func sanitizeCSVCell(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldsQueryParam is the query parameter used to request a sparse fieldset.
+const FieldsQueryParam = "fields"
+
+// GetFieldsParam parses ?fields=id,name,status into a set of top-level JSON field names.
+// It returns nil when the parameter is absent or empty.
+func GetFieldsParam(c *gin.Context) map[string]struct{} {
+	raw := c.Query(FieldsQueryParam)
+	if raw == "" {
+		return nil
+	}
+
+	fields := make(map[string]struct{})
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields[f] = struct{}{}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// shouldShapeFields reports whether the response data should be reduced to a sparse fieldset.
+// Shaping only applies to successful GET responses so writes always echo the full resource.
+func shouldShapeFields(c *gin.Context, statusCode int) bool {
+	return c.Request.Method == http.MethodGet &&
+		statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+}
+
+// ShapeFields reduces data to the requested top-level fields. Objects keep only matching keys,
+// arrays are shaped element by element, and scalar values are returned unchanged.
+// Unknown field names are ignored.
+func ShapeFields(data any, fields map[string]struct{}) (any, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data for field selection: %w", err)
+	}
+
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode data for field selection: %w", err)
+	}
+
+	return selectFields(decoded, fields), nil
+}
+
+func selectFields(value any, fields map[string]struct{}) any {
+	switch v := value.(type) {
+	case map[string]any:
+		shaped := make(map[string]any, len(fields))
+		for key, val := range v {
+			if _, ok := fields[key]; ok {
+				shaped[key] = val
+			}
+		}
+		return shaped
+	case []any:
+		for i, item := range v {
+			v[i] = selectFields(item, fields)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// Content-Disposition types
+const (
+	DispositionAttachment = "attachment"
+	DispositionInline     = "inline"
+)
+
+// ContentDisposition returns a Content-Disposition header value for filename. Names outside
+// of ASCII are encoded as RFC 2231 extended values, with an ASCII fallback for clients that
+// do not read them, and quotes and path separators are replaced so that the name cannot
+// break out of the header or point to a directory.
+func ContentDisposition(disposition, filename string) string {
+	filename = strings.Map(func(r rune) rune {
+		switch r {
+		case '"', '\\', '/', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, filename)
+
+	value := mime.FormatMediaType(disposition, map[string]string{"filename": filename})
+	if value == "" {
+		return disposition
+	}
+	if extended, ok := strings.CutPrefix(value, disposition+"; filename*="); ok {
+		fallback := strings.Map(func(r rune) rune {
+			if r < 0x20 || r > 0x7e {
+				return '_'
+			}
+			return r
+		}, filename)
+		return disposition + `; filename="` + fallback + `"; filename*=` + extended
+	}
+	return value
+}
+
+// ContentTypeByName returns the media type of a file from its extension, falling back to
+// application/octet-stream
+func ContentTypeByName(name string) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// SendFile serves content as the file name, answering range requests with 206 Partial
+// Content and conditional requests with 304 Not Modified against modTime. disposition is
+// DispositionAttachment for downloads and DispositionInline for files shown in the browser.
+func SendFile(c *gin.Context, name, disposition string, modTime time.Time, content io.ReadSeeker) {
+	setFileHeaders(c, name, disposition)
+	http.ServeContent(c.Writer, c.Request, name, modTime, content)
+}
+
+// SendReader serves reader as the file name. Readers that can seek, such as files, are
+// served with SendFile; other ones are streamed whole, so neither ranges nor conditional
+// requests are supported. Once streaming has started the status can no longer change, so
+// read errors are logged and end the response early.
+func SendReader(c *gin.Context, name, disposition string, reader io.Reader) {
+	if file, ok := reader.(*os.File); ok {
+		modTime := time.Time{}
+		if info, err := file.Stat(); err == nil {
+			modTime = info.ModTime()
+		}
+		SendFile(c, name, disposition, modTime, file)
+		return
+	}
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		SendFile(c, name, disposition, time.Time{}, seeker)
+		return
+	}
+
+	setFileHeaders(c, name, disposition)
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		logger.WarnCtx(c.Request.Context(), "Failed to stream file",
+			logger.String("name", name),
+			logger.String("request_id", GetRequestID(c)),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// setFileHeaders sets the content type, disposition and sniffing headers of a file response
+func setFileHeaders(c *gin.Context, name, disposition string) {
+	c.Header("Content-Type", ContentTypeByName(name))
+	c.Header("Content-Disposition", ContentDisposition(disposition, name))
+	c.Header("X-Content-Type-Options", "nosniff")
+}
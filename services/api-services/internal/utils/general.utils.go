@@ -14,6 +14,7 @@ import (
 	"unicode"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/i18n"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/security"
 
@@ -62,6 +63,27 @@ func GetAuthUser(c *gin.Context) (userID uuid.UUID, err error) {
 	return authPayload.UserID, nil
 }
 
+// GetOrganizationID retrieves the organization resolved by the membership middleware.
+func GetOrganizationID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get(string(common.OrganizationIDContextKey))
+	if !exists {
+		return uuid.Nil, false
+	}
+	organizationID, ok := value.(uuid.UUID)
+	return organizationID, ok
+}
+
+// GetLocale returns the response locale resolved by LocaleMiddleware, falling back to
+// matching the Accept-Language header directly.
+func GetLocale(c *gin.Context) string {
+	if locale, ok := c.Get(string(common.LocaleContextKey)); ok {
+		if s, ok := locale.(string); ok {
+			return s
+		}
+	}
+	return i18n.Match(c.GetHeader("Accept-Language"))
+}
+
 // GetClientIP extracts the client's IP address from the Gin context.
 func GetClientIP(c *gin.Context) string {
 	return c.ClientIP()
@@ -88,6 +110,12 @@ func IsDuplicateEntryError(err error) bool {
 	return false
 }
 
+// IsRequestTooLarge reports whether err was caused by a request body exceeding http.MaxBytesReader's limit.
+func IsRequestTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
 // StringPtr returns a pointer to the given string.
 // Returns nil if the string is empty.
 func StringPtr(s string) *string {
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
+)
+
+// SignedLink is a time-limited URL returned in responses for unauthenticated downloads.
+type SignedLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewSignedLink signs path (relative, e.g. "/downloads/...") with the given query parameters.
+// The signature covers every parameter, so none can be altered by the holder of the link.
+func NewSignedLink(signer *urlsigner.Signer, path string, query url.Values, ttl time.Duration) (*SignedLink, error) {
+	target := path
+	if encoded := query.Encode(); encoded != "" {
+		target += "?" + encoded
+	}
+
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	signed, err := signer.Generate(target, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedLink{URL: signed, ExpiresAt: expiresAt}, nil
+}
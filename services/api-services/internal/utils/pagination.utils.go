@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"math"
 	"strconv"
 
@@ -13,6 +16,15 @@ const DefaultPerPage = 10
 // MaxPerPage is the maximum number of items that can be requested per page.
 const MaxPerPage = 100
 
+// DefaultCursorLimit is the default number of items to return per cursor page.
+const DefaultCursorLimit = 100
+
+// MaxCursorLimit is the maximum number of items that can be requested per cursor page.
+const MaxCursorLimit = 1000
+
+// ErrInvalidCursor is returned for cursors that were not issued by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 // Pagination contains the metadata for a paginated API response.
 type Pagination struct {
 	CurrentPage int   `json:"current_page"`
@@ -21,6 +33,14 @@ type Pagination struct {
 	TotalItems  int64 `json:"total_items"`
 }
 
+// CursorPagination contains the metadata for a cursor-paginated API response. NextCursor is
+// sent back as ?cursor= to get the following page and is empty on the last one.
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
 // Params holds the validated pagination parameters extracted from a request.
 type Params struct {
 	Page    int
@@ -74,3 +94,48 @@ func NewPaginationMeta(p Params, totalItems int64) *Pagination {
 		TotalItems:  totalItems,
 	}
 }
+
+// CursorParams holds the validated cursor pagination parameters extracted from a request.
+type CursorParams struct {
+	Cursor string
+	Limit  int
+}
+
+// GetCursorParams extracts and validates cursor pagination parameters from the Gin context.
+func GetCursorParams(c *gin.Context, defaultLimit, maxLimit int) CursorParams {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit < 1 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return CursorParams{
+		Cursor: c.Query("cursor"),
+		Limit:  limit,
+	}
+}
+
+// EncodeCursor encodes the position of the last item of a page as an opaque cursor.
+// Cursors are not signed: a decoded position must only ever narrow a query.
+func EncodeCursor(position any) (string, error) {
+	raw, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor decodes a cursor issued by EncodeCursor into position, returning
+// ErrInvalidCursor when it is malformed.
+func DecodeCursor(cursor string, position any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, position); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
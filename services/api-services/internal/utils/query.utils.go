@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MaxSearchLength is the maximum accepted length of the ?q= search term.
+const MaxSearchLength = 100
+
+// maxFilterValues caps the number of comma-separated values in a single filter.
+const maxFilterValues = 20
+
+// QueryOptions whitelists what a list endpoint allows clients to filter, sort and search on.
+// Keys are the public names used in the query string, values are the database columns.
+// Only whitelisted columns ever reach SQL, so parsed params are safe to turn into scopes.
+type QueryOptions struct {
+	Filters      map[string]string
+	Sorts        map[string]string
+	SearchFields []string
+	// DefaultSort is applied when ?sort= is absent, e.g. "-created_at".
+	DefaultSort string
+}
+
+// SortField is a single validated ORDER BY term.
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// QueryParams holds the validated filtering, sorting and search parameters of a request.
+type QueryParams struct {
+	Filters      map[string][]string
+	Sorts        []SortField
+	Search       string
+	searchFields []string
+}
+
+// QueryParamError is returned when a request references a field that is not allowed.
+type QueryParamError struct {
+	Param   string
+	Message string
+}
+
+func (e *QueryParamError) Error() string {
+	return fmt.Sprintf("invalid query parameter %q: %s", e.Param, e.Message)
+}
+
+// GetQueryParams parses ?filter[field]=a,b&sort=-field,other&q=term from the Gin context.
+// Filter values are comma-separated and matched with IN; sort fields prefixed with "-" are descending.
+func GetQueryParams(c *gin.Context, opts QueryOptions) (QueryParams, error) {
+	params := QueryParams{
+		Filters:      make(map[string][]string),
+		searchFields: opts.SearchFields,
+	}
+
+	for key, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]")
+		column, ok := opts.Filters[name]
+		if !ok {
+			return QueryParams{}, &QueryParamError{Param: key, Message: "filtering on this field is not supported"}
+		}
+
+		var parsed []string
+		for _, value := range values {
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					parsed = append(parsed, v)
+				}
+			}
+		}
+		if len(parsed) == 0 {
+			continue
+		}
+		if len(parsed) > maxFilterValues {
+			return QueryParams{}, &QueryParamError{Param: key, Message: fmt.Sprintf("at most %d values are allowed", maxFilterValues)}
+		}
+		params.Filters[column] = parsed
+	}
+
+	sort := c.Query("sort")
+	if sort == "" {
+		sort = opts.DefaultSort
+	}
+	for _, term := range strings.Split(sort, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(term, "-")
+		column, ok := opts.Sorts[strings.TrimPrefix(term, "-")]
+		if !ok {
+			return QueryParams{}, &QueryParamError{Param: "sort", Message: fmt.Sprintf("sorting on %q is not supported", strings.TrimPrefix(term, "-"))}
+		}
+		params.Sorts = append(params.Sorts, SortField{Column: column, Desc: desc})
+	}
+
+	if q := strings.TrimSpace(c.Query("q")); q != "" && len(opts.SearchFields) > 0 {
+		if len(q) > MaxSearchLength {
+			return QueryParams{}, &QueryParamError{Param: "q", Message: fmt.Sprintf("search term cannot exceed %d characters", MaxSearchLength)}
+		}
+		params.Search = q
+	}
+
+	return params, nil
+}
+
+// FilterScope converts the filters and search term into a GORM scope of WHERE clauses.
+func (p QueryParams) FilterScope() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for column, values := range p.Filters {
+			if len(values) == 1 {
+				db = db.Where(fmt.Sprintf("%s = ?", column), values[0])
+			} else {
+				db = db.Where(fmt.Sprintf("%s IN ?", column), values)
+			}
+		}
+
+		if p.Search != "" {
+			pattern := "%" + EscapeLike(p.Search) + "%"
+			conditions := make([]string, 0, len(p.searchFields))
+			args := make([]interface{}, 0, len(p.searchFields))
+			for _, column := range p.searchFields {
+				conditions = append(conditions, fmt.Sprintf("%s ILIKE ?", column))
+				args = append(args, pattern)
+			}
+			db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+		}
+
+		return db
+	}
+}
+
+// OrderScope converts the sort fields into a GORM scope of ORDER BY clauses.
+// It is kept separate from FilterScope so totals can be counted without ordering.
+func (p QueryParams) OrderScope() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, s := range p.Sorts {
+			direction := "ASC"
+			if s.Desc {
+				direction = "DESC"
+			}
+			db = db.Order(fmt.Sprintf("%s %s", s.Column, direction))
+		}
+		return db
+	}
+}
+
+// EscapeLike escapes LIKE wildcards so user input is matched literally.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/samaasi/uptime-application/services/api-services/internal/common"
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/i18n"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 )
 
@@ -32,6 +33,8 @@ const (
 	ErrCodeBadRequest               = "BAD_REQUEST"
 	ErrCodeInternalError            = "INTERNAL_SERVER_ERROR"
 	ErrCodeConflict                 = "CONFLICT_ERROR"
+	ErrCodeRequestTimeout           = "REQUEST_TIMEOUT"
+	ErrCodePayloadTooLarge          = "PAYLOAD_TOO_LARGE"
 	DefaultSuccessMessage           = "Request processed successfully"
 	DefaultValidationErrMsg         = "Validation failed: Please check the provided data."
 	DefaultTopLevelValidationErrMsg = "Request failed due to validation errors."
@@ -47,12 +50,13 @@ type ErrorDetails struct {
 
 // Meta contains metadata about the API response.
 type Meta struct {
-	RequestID  string      `json:"request_id"`
-	Timestamp  string      `json:"timestamp"`
-	Version    string      `json:"version"`
-	DurationMs int64       `json:"duration_ms,omitempty"`
-	Pagination *Pagination `json:"pagination,omitempty"`
-	UserID     string      `json:"user_id,omitempty"`
+	RequestID  string            `json:"request_id"`
+	Timestamp  string            `json:"timestamp"`
+	Version    string            `json:"version"`
+	DurationMs int64             `json:"duration_ms,omitempty"`
+	Pagination *Pagination       `json:"pagination,omitempty"`
+	Cursor     *CursorPagination `json:"cursor,omitempty"`
+	UserID     string            `json:"user_id,omitempty"`
 }
 
 // GenericResponse is a standardized API response format with typed data.
@@ -74,6 +78,7 @@ type ResponseBuilder[T any] struct {
 	message    string
 	errDetails *ErrorDetails
 	pagination *Pagination
+	cursor     *CursorPagination
 	startTime  time.Time
 }
 
@@ -86,6 +91,7 @@ func InitResponseUtil(cfg *config.Config, devMode bool) error {
 		}
 		appConfig = cfg
 		isDevMode = devMode
+		configInitErr = i18n.Init(cfg.App.DefaultLocale)
 	})
 	return configInitErr
 }
@@ -159,6 +165,12 @@ func (r *ResponseBuilder[T]) WithPagination(p *Pagination) *ResponseBuilder[T] {
 	return r
 }
 
+// WithCursor adds cursor pagination metadata to the response.
+func (r *ResponseBuilder[T]) WithCursor(p *CursorPagination) *ResponseBuilder[T] {
+	r.cursor = p
+	return r
+}
+
 // WithHeader adds a custom header to the response.
 func (r *ResponseBuilder[T]) WithHeader(key, value string) *ResponseBuilder[T] {
 	if r.headers == nil {
@@ -198,6 +210,13 @@ func (r *ResponseBuilder[T]) Send() {
 		r.message = DefaultSuccessMessage
 	}
 
+	locale := GetLocale(r.c)
+	r.message = i18n.T(locale, r.message, nil)
+	if r.errDetails != nil {
+		r.errDetails.Message = i18n.T(locale, r.errDetails.Message, nil)
+	}
+	r.c.Writer.Header().Set("Content-Language", locale)
+
 	resp := GenericResponse[T]{
 		Success: r.errDetails == nil,
 		Message: r.message,
@@ -210,9 +229,27 @@ func (r *ResponseBuilder[T]) Send() {
 			Version:    r.appVersion,
 			DurationMs: totalDuration.Milliseconds(),
 			Pagination: r.pagination,
+			Cursor:     r.cursor,
 		},
 	}
 
+	var body any = resp
+	if r.errDetails == nil && shouldShapeFields(r.c, r.statusCode) {
+		if selected := GetFieldsParam(r.c); selected != nil {
+			shaped, err := ShapeFields(r.data, selected)
+			if err != nil {
+				logger.Warn("Failed to apply sparse fieldset, sending full response", logger.ErrorField(err))
+			} else {
+				body = GenericResponse[any]{
+					Success: resp.Success,
+					Message: resp.Message,
+					Data:    shaped,
+					Meta:    resp.Meta,
+				}
+			}
+		}
+	}
+
 	fields := []logger.Field{
 		logger.Int("status", r.statusCode),
 		logger.String("path", r.c.Request.URL.Path),
@@ -223,7 +260,7 @@ func (r *ResponseBuilder[T]) Send() {
 	}
 
 	if r.errDetails != nil {
-		logger.Error("Request failed",
+		logger.ErrorCtx(r.c.Request.Context(), "Request failed",
 			append(fields,
 				logger.String("error_code", r.errDetails.Code),
 				logger.String("error_message", r.errDetails.Message),
@@ -231,10 +268,10 @@ func (r *ResponseBuilder[T]) Send() {
 			)...,
 		)
 	} else {
-		logger.Info("Request completed", fields...)
+		logger.InfoCtx(r.c.Request.Context(), "Request completed", fields...)
 	}
 
-	r.c.JSON(r.statusCode, resp)
+	r.c.JSON(r.statusCode, body)
 }
 
 // --- Common Response Helpers ---
@@ -309,7 +346,7 @@ func SendBadRequest(c *gin.Context, message string, details ...any) {
 // SendValidationError sends a 400 Bad Request error with validation details.
 // It now expects a `validator.ValidationErrors` type for `err` and the `targetStruct` for JSON tag extraction.
 func SendValidationError(c *gin.Context, err error, targetStruct any) {
-	validationErrors := FormatValidationErrors(err, targetStruct)
+	validationErrors := FormatLocalizedValidationErrors(err, targetStruct, GetLocale(c))
 
 	builder, buildErr := NewResponse[any](c)
 	if buildErr != nil {
@@ -387,6 +424,32 @@ func SendConflict(c *gin.Context, message string, details ...any) {
 		Send()
 }
 
+// SendRequestTimeout sends a 408 Request Timeout error response.
+func SendRequestTimeout(c *gin.Context, message string, details ...any) {
+	builder, err := NewResponse[any](c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create response builder"})
+		return
+	}
+	builder.
+		Status(http.StatusRequestTimeout).
+		WithError(ErrCodeRequestTimeout, message, details...).
+		Send()
+}
+
+// SendPayloadTooLarge sends a 413 Request Entity Too Large error response.
+func SendPayloadTooLarge(c *gin.Context, message string, details ...any) {
+	builder, err := NewResponse[any](c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create response builder"})
+		return
+	}
+	builder.
+		Status(http.StatusRequestEntityTooLarge).
+		WithError(ErrCodePayloadTooLarge, message, details...).
+		Send()
+}
+
 // SendError sends a structured error JSON response with the specified HTTP status, code, message, and details.
 func SendError(c *gin.Context, httpStatus int, code string, message string, details ...any) {
 	builder, err := NewResponse[any](c)
@@ -423,6 +486,11 @@ func SendInternalServerError(c *gin.Context, details ...any) {
 // FormatValidationErrors processes a validator.ValidationErrors into a map for API response.
 // It requires the targetStruct to correctly extract JSON tags.
 func FormatValidationErrors(err error, targetStruct any) map[string]string {
+	return FormatLocalizedValidationErrors(err, targetStruct, i18n.Default().Fallback())
+}
+
+// FormatLocalizedValidationErrors is FormatValidationErrors with messages translated into locale.
+func FormatLocalizedValidationErrors(err error, targetStruct any, locale string) map[string]string {
 	formattedErrors := make(map[string]string)
 
 	var validationErrors validator.ValidationErrors
@@ -434,7 +502,7 @@ func FormatValidationErrors(err error, targetStruct any) map[string]string {
 
 		for _, e := range validationErrors {
 			jsonTag := extractJSONTag(e, structType)
-			formattedErrors[jsonTag] = formatErrorMessage(e)
+			formattedErrors[jsonTag] = formatErrorMessage(e, locale)
 		}
 	} else {
 		formattedErrors["general"] = err.Error()
@@ -443,61 +511,28 @@ func FormatValidationErrors(err error, targetStruct any) map[string]string {
 	return formattedErrors
 }
 
-// formatErrorMessage generates a user-friendly error message for a validation field error.
-func formatErrorMessage(e validator.FieldError) string {
-	field := e.Field()
-	param := e.Param()
-
-	switch e.Tag() {
-	case "required":
-		return fmt.Sprintf("The %s field is required.", field)
-	case "email":
-		return fmt.Sprintf("The %s field must be a valid email address.", field)
-	case "url":
-		return fmt.Sprintf("The %s field must be a valid URL.", field)
-	case "uuid":
-		return fmt.Sprintf("The %s field must be a valid UUID.", field)
-	case "ip":
-		return fmt.Sprintf("The %s field must be a valid IP address.", field)
-	case "ipv4":
-		return fmt.Sprintf("The %s field must be a valid IPv4 address.", field)
-	case "ipv6":
-		return fmt.Sprintf("The %s field must be a valid IPv6 address.", field)
-	case "len":
-		return fmt.Sprintf("The %s field must be exactly %s characters long.", field, param)
-	case "min":
-		return fmt.Sprintf("The %s field must be at least %s characters long.", field, param)
-	case "max":
-		return fmt.Sprintf("The %s field must not exceed %s characters.", field, param)
-	case "eq":
-		return fmt.Sprintf("The %s field must be equal to %s.", field, param)
-	case "ne":
-		return fmt.Sprintf("The %s field must not be equal to %s.", field, param)
-	case "lt":
-		return fmt.Sprintf("The %s field must be less than %s.", field, param)
-	case "lte":
-		return fmt.Sprintf("The %s field must be less than or equal to %s.", field, param)
-	case "gt":
-		return fmt.Sprintf("The %s field must be greater than %s.", field, param)
-	case "gte":
-		return fmt.Sprintf("The %s field must be greater than or equal to %s.", field, param)
-	case "alphanum":
-		return fmt.Sprintf("The %s field must be alphanumeric.", field)
-	case "contains":
-		return fmt.Sprintf("The %s field must contain '%s'.", field, param)
-	case "startswith":
-		return fmt.Sprintf("The %s field must start with '%s'.", field, param)
-	case "endswith":
-		return fmt.Sprintf("The %s field must end with '%s'.", field, param)
-	case "oneof":
-		return fmt.Sprintf("The %s field must be one of [%s].", field, param)
-	case "datetime":
-		return fmt.Sprintf("The %s field must be a valid datetime in format %s.", field, param)
-	case "phone_number":
-		return fmt.Sprintf("The %s field must be a valid phone number.", field)
-	default:
-		return fmt.Sprintf("The %s field is invalid.", field)
+// validationMessageTags are the validator tags with a dedicated translated message.
+var validationMessageTags = map[string]bool{
+	"required": true, "email": true, "url": true, "uuid": true,
+	"ip": true, "ipv4": true, "ipv6": true,
+	"len": true, "min": true, "max": true,
+	"eq": true, "ne": true, "lt": true, "lte": true, "gt": true, "gte": true,
+	"alphanum": true, "contains": true, "startswith": true, "endswith": true,
+	"oneof": true, "datetime": true, "phone_number": true,
+	"cron": true, "url_or_ip": true, "duration": true, "timezone": true,
+}
+
+// formatErrorMessage generates a user-friendly, localized error message for a validation field error.
+func formatErrorMessage(e validator.FieldError, locale string) string {
+	key := "validation.invalid"
+	if validationMessageTags[e.Tag()] {
+		key = "validation." + e.Tag()
 	}
+
+	return i18n.T(locale, key, map[string]string{
+		"field": e.Field(),
+		"param": e.Param(),
+	})
 }
 
 // extractJSONTag extracts the JSON tag name for a given field error.
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/jobs"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/notifier/sms"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// customValidations are the validator tags this application defines, on top of the ones
+// go-playground/validator ships with. Each has a message in the validation.<tag> locale key.
+var customValidations = map[string]validator.Func{
+	// phone_number is an E.164 number, e.g. +14155550100
+	"phone_number": func(fl validator.FieldLevel) bool {
+		return sms.IsE164(fl.Field().String())
+	},
+	// cron is a job schedule: a five field cron expression, a descriptor such as @daily, or
+	// "@every <duration>"
+	"cron": func(fl validator.FieldLevel) bool {
+		_, err := jobs.ParseSchedule(fl.Field().String())
+		return err == nil
+	},
+	// url_or_ip is an http or https URL with a host, or a bare IP address
+	"url_or_ip": func(fl validator.FieldLevel) bool {
+		value := strings.TrimSpace(fl.Field().String())
+		if net.ParseIP(value) != nil {
+			return true
+		}
+		u, err := url.Parse(value)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != ""
+	},
+	// duration is a positive Go duration, e.g. 90s or 1h30m
+	"duration": func(fl validator.FieldLevel) bool {
+		d, err := time.ParseDuration(fl.Field().String())
+		return err == nil && d > 0
+	},
+	// timezone is an IANA time zone name, e.g. Europe/Paris; "Local" is refused because it
+	// depends on the server
+	"timezone": func(fl validator.FieldLevel) bool {
+		name := fl.Field().String()
+		if name == "" || strings.EqualFold(name, "local") {
+			return false
+		}
+		_, err := time.LoadLocation(name)
+		return err == nil
+	},
+}
+
+// structValidator checks the `validate` tags of DTOs. Gin's binding only reads `binding`
+// tags, so without it those rules would never run.
+var structValidator = newStructValidator()
+
+func newStructValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.SetTagName("validate")
+	RegisterValidations(v)
+	return v
+}
+
+func init() {
+	// Gin validates `binding` tags with its own instance, which needs the same rules
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		RegisterValidations(v)
+	}
+}
+
+// Validator returns the validator checking `validate` tags, with the custom validations
+// registered, for code validating values outside of request binding
+func Validator() *validator.Validate {
+	return structValidator
+}
+
+// RegisterValidations registers the custom validations of the application on v. It panics
+// on an invalid registration, which is a programming error.
+func RegisterValidations(v *validator.Validate) {
+	for tag, fn := range customValidations {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(fmt.Sprintf("failed to register validation %q: %v", tag, err))
+		}
+	}
+}
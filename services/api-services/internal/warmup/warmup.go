@@ -0,0 +1,107 @@
+// Package warmup preloads hot data into the cache when the application starts, so the
+// first requests after a deploy are not all cache misses hitting the database at once.
+package warmup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	// lockKey names the lock that lets a single replica warm the cache
+	lockKey = "warmup"
+	// lockTTL is how long the warm-up lock lasts without renewal
+	lockTTL = time.Minute
+	// completedKey marks a finished warm-up; replicas starting while it exists skip theirs
+	completedKey = "warmup:completed"
+)
+
+// Loader loads one kind of hot data into the cache and returns how many entries it stored
+type Loader func(ctx context.Context) (int, error)
+
+// Warmer runs the registered loaders once per deploy. Replicas starting together race for
+// a distributed lock; the winner runs the loaders and the others skip, as do replicas
+// starting while the data it loaded is still fresh.
+type Warmer struct {
+	cache   *cache.Service
+	fresh   time.Duration
+	names   []string
+	loaders map[string]Loader
+}
+
+// NewWarmer creates a warmer writing to cacheService. fresh is how long warmed data stays
+// useful, normally the TTL the loaders cache entries with.
+func NewWarmer(cacheService *cache.Service, fresh time.Duration) *Warmer {
+	return &Warmer{
+		cache:   cacheService,
+		fresh:   fresh,
+		loaders: make(map[string]Loader),
+	}
+}
+
+// Register adds a loader; loaders run in registration order
+func (w *Warmer) Register(name string, loader Loader) {
+	if _, ok := w.loaders[name]; !ok {
+		w.names = append(w.names, name)
+	}
+	w.loaders[name] = loader
+}
+
+// Run warms the cache unless another replica is doing so or already did. A failing loader
+// is logged and does not stop the others, since the cache fills on demand anyway.
+func (w *Warmer) Run(ctx context.Context) {
+	err := w.cache.WithLock(ctx, lockKey, lockTTL, func(ctx context.Context, _ *cache.Lease) error {
+		var completedAt time.Time
+		if err := w.cache.Get(ctx, completedKey, &completedAt); err == nil {
+			logger.Info("Skipping cache warm-up, cache already warm", logger.Time("completed_at", completedAt))
+			return nil
+		} else if !errors.Is(err, cache.ErrCacheMiss) {
+			return fmt.Errorf("failed to read cache warm-up marker: %w", err)
+		}
+
+		w.load(ctx)
+		// A replica that took the lock over after this lease expired records the warm-up itself
+		if err := cache.CheckLease(ctx); err != nil {
+			return err
+		}
+		if err := w.cache.Set(ctx, completedKey, time.Now().UTC(), w.fresh); err != nil {
+			return fmt.Errorf("failed to record cache warm-up: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, cache.ErrLockHeld) {
+		logger.Info("Skipping cache warm-up, another instance is warming the cache")
+	} else if err != nil && ctx.Err() == nil {
+		logger.Warn("Cache warm-up failed", logger.ErrorField(err))
+	}
+}
+
+// load runs every loader, logging what each stored
+func (w *Warmer) load(ctx context.Context) {
+	for _, name := range w.names {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		count, err := w.loaders[name](ctx)
+		if err != nil {
+			logger.Warn("Failed to warm cache",
+				logger.String("loader", name),
+				logger.Int("entries", count),
+				logger.ErrorField(err),
+			)
+			continue
+		}
+		logger.Info("Cache warmed",
+			logger.String("loader", name),
+			logger.Int("entries", count),
+			logger.Duration("duration", time.Since(start)),
+		)
+	}
+}
@@ -7,11 +7,15 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/samaasi/uptime-application/services/api-services/internal/database"
 	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
 	"golang.org/x/sync/singleflight"
 )
 
+// ErrCacheMiss is returned by Get when the key does not exist
+var ErrCacheMiss = database.ErrCacheMiss
+
 const (
 	cachedErrorPrefix = "ERR:"
 	cachedErrorTTL    = 1 * time.Minute
@@ -22,15 +26,25 @@ type Service struct {
 	cacheClient database.CacheClient
 	sfGroup     *singleflight.Group
 	randSource  rand.Source
+
+	// local is the optional in-process layer in front of cacheClient; instanceID tells
+	// this replica's invalidations apart from the others'
+	local      *localCache
+	instanceID string
 }
 
 // NewCacheService creates a new instance of Service.
-func NewCacheService(client database.CacheClient) *Service {
-	return &Service{
+func NewCacheService(client database.CacheClient, opts ...Option) *Service {
+	s := &Service{
 		cacheClient: client,
 		sfGroup:     &singleflight.Group{},
 		randSource:  rand.NewSource(time.Now().UnixNano()),
+		instanceID:  uuid.NewString(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Set stores a value in the cache with a specified key and expiration duration.
@@ -44,12 +58,16 @@ func (s *Service) Set(ctx context.Context, key string, value interface{}, durati
 	jitterDuration := s.addJitter(duration, 0.1)
 	logger.Debug("setting cache value with jitter", logger.String("key", key), logger.Duration("duration", duration), logger.Duration("jittered_duration", jitterDuration))
 
-	return s.cacheClient.Set(ctx, key, data, jitterDuration)
+	if err := s.cacheClient.Set(ctx, key, data, jitterDuration); err != nil {
+		return err
+	}
+	s.invalidate(ctx, key)
+	return nil
 }
 
 // Get retrieves a value from the cache and unmarshal it into the provided destination.
 func (s *Service) Get(ctx context.Context, key string, dest interface{}) error {
-	data, err := s.cacheClient.Get(ctx, key)
+	data, err := s.getRaw(ctx, key)
 	if err != nil {
 		logger.Debug("cache get miss or error", logger.String("key", key), logger.ErrorField(err))
 		return err
@@ -69,10 +87,114 @@ func (s *Service) Get(ctx context.Context, key string, dest interface{}) error {
 	return nil
 }
 
+// getRaw reads key from the local cache when enabled, falling back to the cache client
+func (s *Service) getRaw(ctx context.Context, key string) ([]byte, error) {
+	if s.local == nil {
+		return s.cacheClient.Get(ctx, key)
+	}
+	if data, ok := s.local.get(key); ok {
+		return data, nil
+	}
+
+	data, err := s.cacheClient.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	s.local.set(key, data)
+	return data, nil
+}
+
+// SetMany stores every value with the same expiration in a single round trip.
+func (s *Service) SetMany(ctx context.Context, values map[string]interface{}, duration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	encoded := make(map[string][]byte, len(values))
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			logger.Error("failed to marshal cache value", logger.String("key", key), logger.ErrorField(err))
+			return fmt.Errorf("failed to marshal cache value for key %s: %w", key, err)
+		}
+		encoded[key] = data
+		keys = append(keys, key)
+	}
+
+	if err := s.cacheClient.MSet(ctx, encoded, s.addJitter(duration, 0.1)); err != nil {
+		return err
+	}
+	s.invalidate(ctx, keys...)
+	return nil
+}
+
+// GetMany retrieves the values of keys in a single round trip, decoding each into a T.
+// Keys that are missing, hold a cached error or fail to decode are left out of the result.
+func GetMany[T any](ctx context.Context, s *Service, keys []string) (map[string]T, error) {
+	raw, err := s.getManyRaw(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]T, len(raw))
+	for key, data := range raw {
+		if len(data) >= len(cachedErrorPrefix) && string(data[:len(cachedErrorPrefix)]) == cachedErrorPrefix {
+			continue
+		}
+		var value T
+		if err := json.Unmarshal(data, &value); err != nil {
+			logger.Warn("failed to unmarshal cache value", logger.String("key", key), logger.ErrorField(err))
+			continue
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// getManyRaw reads keys from the local cache when enabled and the rest from the cache
+// client, returning only the keys that exist
+func (s *Service) getManyRaw(ctx context.Context, keys []string) (map[string][]byte, error) {
+	found := make(map[string][]byte, len(keys))
+	missing := keys
+	if s.local != nil {
+		missing = make([]string, 0, len(keys))
+		for _, key := range keys {
+			if data, ok := s.local.get(key); ok {
+				found[key] = data
+			} else {
+				missing = append(missing, key)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	values, err := s.cacheClient.MGet(ctx, missing...)
+	if err != nil {
+		return nil, err
+	}
+	for i, data := range values {
+		if data == nil {
+			continue
+		}
+		found[missing[i]] = data
+		if s.local != nil {
+			s.local.set(missing[i], data)
+		}
+	}
+	return found, nil
+}
+
 // Delete removes a value from the cache by its key.
 func (s *Service) Delete(ctx context.Context, key string) error {
 	logger.Info("deleting cache key", logger.String("key", key))
-	return s.cacheClient.Delete(ctx, key)
+	if err := s.cacheClient.Delete(ctx, key); err != nil {
+		return err
+	}
+	s.invalidate(ctx, key)
+	return nil
 }
 
 // Update updates the value of an existing key in the cache without altering its TTL.
@@ -84,7 +206,38 @@ func (s *Service) Update(ctx context.Context, key string, value interface{}) err
 	}
 
 	logger.Debug("updating cache key with new value", logger.String("key", key))
-	return s.cacheClient.Update(ctx, key, data)
+	if err := s.cacheClient.Update(ctx, key, data); err != nil {
+		return err
+	}
+	s.invalidate(ctx, key)
+	return nil
+}
+
+// Publish sends a raw message to a pub/sub channel.
+func (s *Service) Publish(ctx context.Context, channel string, payload []byte) error {
+	return s.cacheClient.Publish(ctx, channel, payload)
+}
+
+// Subscribe blocks while delivering messages from channels matching patterns to handler.
+func (s *Service) Subscribe(ctx context.Context, handler func(channel string, payload []byte), patterns ...string) error {
+	return s.cacheClient.Subscribe(ctx, handler, patterns...)
+}
+
+// Stats are the counters of the cache layers
+type Stats struct {
+	Redis database.CacheStats `json:"redis"`
+	// Local is nil when the in-process layer is disabled
+	Local *LocalStats `json:"local,omitempty"`
+}
+
+// Stats returns the counters of the cache client and of the local cache when enabled.
+func (s *Service) Stats() Stats {
+	stats := Stats{Redis: s.cacheClient.Stats()}
+	if s.local != nil {
+		local := s.local.stats()
+		stats.Local = &local
+	}
+	return stats
 }
 
 // HealthCheck performs a health check on the underlying cache client.
@@ -101,12 +254,69 @@ func (s *Service) Close() error {
 // If the key does not exist, it is set to 1.
 func (s *Service) Increment(ctx context.Context, key string) (int64, error) {
 	logger.Debug("incrementing cache key", logger.String("key", key))
-	return s.cacheClient.Increment(ctx, key)
+	value, err := s.cacheClient.Increment(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(ctx, key)
+	return value, nil
 }
 
 // Decrement atomically decrements the value of a key by 1.
 func (s *Service) Decrement(ctx context.Context, key string) (int64, error) {
-	return s.cacheClient.Decrement(ctx, key)
+	value, err := s.cacheClient.Decrement(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(ctx, key)
+	return value, nil
+}
+
+// IncrementWithTTL atomically increments the value of a key by 1 and returns the new value.
+// The key expires ttl after the increment that created it, so counting requests per key
+// gives a fixed-window rate limit.
+func (s *Service) IncrementWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	value, err := s.cacheClient.IncrementWithTTL(ctx, key, ttl)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(ctx, key)
+	return value, nil
+}
+
+// TTL returns the remaining time to live of a key, zero if it never expires, or
+// ErrCacheMiss if it does not exist.
+func (s *Service) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return s.cacheClient.GetTTL(ctx, key)
+}
+
+// Expire sets the time to live of an existing key, reporting false if the key does not exist.
+func (s *Service) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := s.cacheClient.Expire(ctx, key, ttl)
+	if err != nil {
+		return false, err
+	}
+	s.invalidate(ctx, key)
+	return ok, nil
+}
+
+// SetNX stores a value under a key unless it exists, reporting whether it was stored. Unlike
+// Set the duration is exact, so it can back cooldowns.
+func (s *Service) SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.Error("failed to marshal cache value", logger.String("key", key), logger.ErrorField(err))
+		return false, fmt.Errorf("failed to marshal cache value for key %s: %w", key, err)
+	}
+
+	ok, err := s.cacheClient.SetNX(ctx, key, data, duration)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		s.invalidate(ctx, key)
+	}
+	return ok, nil
 }
 
 // GetOrSet retrieves a value from the cache by key. If not found or expired,
@@ -130,6 +340,8 @@ func (s *Service) GetOrSet(ctx context.Context, key string, dest interface{}, du
 			logger.Error("fetch function failed for cache key", logger.String("key", key), logger.ErrorField(innerFetchErr))
 			if setErr := s.cacheClient.Set(ctx, key, []byte(cachedErrorPrefix+innerFetchErr.Error()), cachedErrorTTL); setErr != nil {
 				logger.Error("failed to cache error result", logger.String("key", key), logger.ErrorField(setErr))
+			} else {
+				s.invalidate(ctx, key)
 			}
 			return nil, innerFetchErr
 		}
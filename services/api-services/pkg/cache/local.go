@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	// invalidationChannel carries the keys written by one replica so the others drop
+	// their local copies
+	invalidationChannel = "cache:invalidate"
+	resubscribeInterval = 5 * time.Second
+)
+
+// Option configures a Service
+type Option func(*Service)
+
+// WithLocalCache keeps up to size recently read values in process memory for at most
+// ttl, in front of the cache client. Writes through the Service invalidate the local
+// copies of every replica over pub/sub; ttl bounds how stale a value can get when an
+// invalidation is missed, so keep it short.
+func WithLocalCache(size int, ttl time.Duration) Option {
+	return func(s *Service) {
+		if size > 0 && ttl > 0 {
+			s.local = newLocalCache(size, ttl)
+		}
+	}
+}
+
+// invalidation is the message published when a replica writes keys
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// localEntry is a raw cached value held by the local cache
+type localEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// localCache is a size-bounded LRU of raw cached values with a fixed TTL
+type localCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[string]*list.Element
+	order    *list.List
+	hits     int64
+	misses   int64
+}
+
+// LocalStats are the counters of the in-process cache layer
+type LocalStats struct {
+	Entries  int   `json:"entries"`
+	Capacity int   `json:"capacity"`
+	Hits     int64 `json:"hits"`
+	Misses   int64 `json:"misses"`
+}
+
+func newLocalCache(capacity int, ttl time.Duration) *localCache {
+	return &localCache{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// get returns the value of key unless it is missing or expired
+func (c *localCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	entry := element.Value.(*localEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		c.misses++
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	c.hits++
+	return entry.data, true
+}
+
+// set stores the value of key, evicting the least recently used entry when full
+func (c *localCache) set(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*localEntry)
+		entry.data = data
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&localEntry{key: key, data: data, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// delete drops the value of key
+func (c *localCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// purge drops every value
+func (c *localCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element, c.capacity)
+	c.order.Init()
+}
+
+// stats returns the current size and the hit and miss counters
+func (c *localCache) stats() LocalStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return LocalStats{Entries: c.order.Len(), Capacity: c.capacity, Hits: c.hits, Misses: c.misses}
+}
+
+func (c *localCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*localEntry).key)
+}
+
+// invalidate drops the local copies of keys and tells the other replicas to drop theirs
+func (s *Service) invalidate(ctx context.Context, keys ...string) {
+	if s.local == nil || len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		s.local.delete(key)
+	}
+
+	payload, err := json.Marshal(invalidation{Origin: s.instanceID, Keys: keys})
+	if err != nil {
+		logger.Error("failed to marshal cache invalidation", logger.Int("keys", len(keys)), logger.ErrorField(err))
+		return
+	}
+	if err := s.cacheClient.Publish(ctx, invalidationChannel, payload); err != nil {
+		logger.Warn("failed to publish cache invalidation", logger.Int("keys", len(keys)), logger.ErrorField(err))
+	}
+}
+
+// Run applies the invalidations published by other replicas to the local cache until ctx
+// is cancelled. It returns immediately when the local cache is disabled.
+func (s *Service) Run(ctx context.Context) {
+	if s.local == nil {
+		return
+	}
+
+	for {
+		err := s.cacheClient.Subscribe(ctx, s.handleInvalidation, invalidationChannel)
+		if ctx.Err() != nil {
+			logger.Info("Local cache invalidation stopped")
+			return
+		}
+
+		// Invalidations published while disconnected are lost; start over from Redis
+		s.local.purge()
+		logger.Warn("Local cache invalidation interrupted, retrying",
+			logger.ErrorField(err),
+			logger.Duration("retry_in", resubscribeInterval),
+		)
+		select {
+		case <-time.After(resubscribeInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleInvalidation drops the local copies of keys written by another replica
+func (s *Service) handleInvalidation(_ string, payload []byte) {
+	var message invalidation
+	if err := json.Unmarshal(payload, &message); err != nil {
+		logger.Warn("Dropping malformed cache invalidation", logger.ErrorField(err))
+		return
+	}
+	if message.Origin == s.instanceID {
+		return
+	}
+	for _, key := range message.Keys {
+		s.local.delete(key)
+	}
+}
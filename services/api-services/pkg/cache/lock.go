@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+var (
+	// ErrLockHeld is returned by Lock when another holder has the lock
+	ErrLockHeld = errors.New("lock is held by another holder")
+	// ErrLockLost is returned when a lease expired and the lock may have been taken over
+	ErrLockLost = errors.New("lock lease was lost")
+)
+
+// lockKeyPrefix namespaces lock keys so they never collide with cached values
+const lockKeyPrefix = "lock:"
+
+// leaseContextKey is the context key of the lease held by WithLock
+type leaseContextKey struct{}
+
+// Lease is a held distributed lock. It is valid until its TTL runs out unless renewed.
+//
+// Fence is a fencing token that increases every time the lock changes hands. A lease
+// can expire while its holder is paused, so holders call Check before each write guarded
+// by the lock, which fails once a newer holder took it.
+type Lease struct {
+	service *Service
+	key     string
+	token   string
+	ttl     time.Duration
+	fence   int64
+
+	mu       sync.Mutex
+	released bool
+}
+
+// Lock acquires the lock named key for ttl without waiting, returning ErrLockHeld when
+// another holder has it. Release the lease when done; it expires by itself otherwise.
+func (s *Service) Lock(ctx context.Context, key string, ttl time.Duration) (*Lease, error) {
+	if ttl < time.Millisecond {
+		return nil, fmt.Errorf("lock ttl must be at least 1ms")
+	}
+
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	fullKey := lockKeyPrefix + key
+	fence, acquired, err := s.cacheClient.AcquireLock(ctx, fullKey, token, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		return nil, ErrLockHeld
+	}
+
+	logger.Debug("lock acquired", logger.String("key", key), logger.Int64("fence", fence))
+	return &Lease{service: s, key: fullKey, token: token, ttl: ttl, fence: fence}, nil
+}
+
+// WithLock runs fn while holding the lock named key, renewing the lease in the background
+// every third of ttl. The context passed to fn carries the lease for CheckLease and is
+// cancelled if the lease is lost. It returns ErrLockHeld without running fn when another
+// holder has the lock.
+func (s *Service) WithLock(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context, lease *Lease) error) error {
+	lease, err := s.Lock(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+
+	fnCtx, cancel := context.WithCancel(context.WithValue(ctx, leaseContextKey{}, lease))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lease.keepAlive(fnCtx, cancel)
+	}()
+
+	fnErr := fn(fnCtx, lease)
+	cancel()
+	<-done
+
+	// Release with a fresh context so cancellation of ctx does not leave the lock held
+	releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer releaseCancel()
+	if err := lease.Release(releaseCtx); err != nil && !errors.Is(err, ErrLockLost) {
+		logger.Warn("Failed to release lock", logger.String("key", key), logger.ErrorField(err))
+	}
+	return fnErr
+}
+
+// CheckLease checks the lease carried by ctx, see Lease.Check. It returns nil when ctx
+// carries no lease, so work that also runs without the lock can call it unconditionally.
+func CheckLease(ctx context.Context) error {
+	lease, ok := ctx.Value(leaseContextKey{}).(*Lease)
+	if !ok {
+		return nil
+	}
+	return lease.Check(ctx)
+}
+
+// Fence returns the fencing token of the lease
+func (l *Lease) Fence() int64 {
+	return l.fence
+}
+
+// Check returns ErrLockLost unless the lease still holds the lock and its fence is the
+// latest one issued, so a holder resuming after its lease expired stops before writing
+// over the work of the holder that took the lock since
+func (l *Lease) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ErrLockLost
+	}
+
+	held, err := l.service.cacheClient.CheckLock(ctx, l.key, l.token, l.fence)
+	if err != nil {
+		return fmt.Errorf("failed to check lock %s: %w", l.key, err)
+	}
+	if !held {
+		l.released = true
+		return ErrLockLost
+	}
+	return nil
+}
+
+// Renew extends the lease to its full TTL, returning ErrLockLost if it already expired
+func (l *Lease) Renew(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ErrLockLost
+	}
+
+	renewed, err := l.service.cacheClient.RenewLock(ctx, l.key, l.token, l.ttl)
+	if err != nil {
+		return fmt.Errorf("failed to renew lock %s: %w", l.key, err)
+	}
+	if !renewed {
+		l.released = true
+		return ErrLockLost
+	}
+	return nil
+}
+
+// Release gives the lock up. Releasing twice is a no-op; ErrLockLost reports that the
+// lease had already expired.
+func (l *Lease) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return nil
+	}
+	l.released = true
+
+	released, err := l.service.cacheClient.ReleaseLock(ctx, l.key, l.token)
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", l.key, err)
+	}
+	if !released {
+		return ErrLockLost
+	}
+	return nil
+}
+
+// keepAlive renews the lease until ctx is done, calling lost when renewal fails
+func (l *Lease) keepAlive(ctx context.Context, lost context.CancelFunc) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Renew(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Warn("Lost lock lease", logger.String("key", l.key), logger.ErrorField(err))
+				lost()
+				return
+			}
+		}
+	}
+}
+
+// newLockToken returns a random token identifying one lock holder
+func newLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,141 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	ProviderTurnstile = "turnstile"
+	ProviderReCaptcha = "recaptcha"
+
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+)
+
+var (
+	ErrMissingToken  = errors.New("captcha token missing")
+	ErrInvalidToken  = errors.New("captcha verification failed")
+	ErrScoreTooLow   = errors.New("captcha score below threshold")
+	ErrVerifyRequest = errors.New("captcha verification request failed")
+)
+
+// siteVerifyResponse covers the fields shared by the Turnstile and reCAPTCHA siteverify APIs.
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score,omitempty"`
+	Action     string   `json:"action,omitempty"`
+	Hostname   string   `json:"hostname,omitempty"`
+	ErrorCodes []string `json:"error-codes,omitempty"`
+}
+
+// SiteVerifier implements Verifier against a siteverify-compatible endpoint.
+// Both Cloudflare Turnstile and Google reCAPTCHA expose the same form-encoded API.
+type SiteVerifier struct {
+	provider   string
+	secretKey  string
+	verifyURL  string
+	minScore   float64
+	httpClient *http.Client
+}
+
+// NewVerifier creates a Verifier for the provider configured in cfg.
+func NewVerifier(cfg config.CaptchaConfig) (Verifier, error) {
+	verifyURL := cfg.VerifyURL
+	switch cfg.Provider {
+	case ProviderTurnstile:
+		if verifyURL == "" {
+			verifyURL = turnstileVerifyURL
+		}
+	case ProviderReCaptcha:
+		if verifyURL == "" {
+			verifyURL = recaptchaVerifyURL
+		}
+	default:
+		return nil, fmt.Errorf("unsupported captcha provider: %s", cfg.Provider)
+	}
+
+	if cfg.SecretKey == "" {
+		return nil, fmt.Errorf("captcha secret key cannot be empty")
+	}
+
+	return &SiteVerifier{
+		provider:   cfg.Provider,
+		secretKey:  cfg.SecretKey,
+		verifyURL:  verifyURL,
+		minScore:   cfg.MinScore,
+		httpClient: httpclient.New(httpclient.Options{Name: "captcha_" + cfg.Provider, Timeout: cfg.Timeout}),
+	}, nil
+}
+
+// Verify checks the token against the provider. The remoteIP is optional and forwarded as a hint.
+func (v *SiteVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if strings.TrimSpace(token) == "" {
+		return ErrMissingToken
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secretKey)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVerifyRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	start := time.Now()
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVerifyRequest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: unexpected status %d", ErrVerifyRequest, resp.StatusCode)
+	}
+
+	var result siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("%w: failed to decode response: %v", ErrVerifyRequest, err)
+	}
+
+	if !result.Success {
+		logger.Warn("captcha verification rejected",
+			logger.String("provider", v.provider),
+			logger.String("error_codes", strings.Join(result.ErrorCodes, ",")),
+			logger.Duration("duration", time.Since(start)),
+		)
+		return ErrInvalidToken
+	}
+
+	// Score is only reported by reCAPTCHA v3; Turnstile and reCAPTCHA v2 are pass/fail.
+	if result.Score != nil && *result.Score < v.minScore {
+		logger.Warn("captcha score below threshold",
+			logger.String("provider", v.provider),
+			logger.Float64("score", *result.Score),
+			logger.Float64("min_score", v.minScore),
+		)
+		return ErrScoreTooLow
+	}
+
+	return nil
+}
+
+// Name returns the provider name.
+func (v *SiteVerifier) Name() string {
+	return v.provider
+}
@@ -0,0 +1,9 @@
+package captcha
+
+import "context"
+
+// Verifier defines the interface for validating a CAPTCHA challenge response token.
+type Verifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+	Name() string
+}
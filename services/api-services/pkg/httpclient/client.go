@@ -0,0 +1,310 @@
+// Package httpclient builds the HTTP clients used to call external services. Clients pool
+// connections, bound every call with timeouts, retry transient failures with jittered
+// backoff, stop calling a host whose circuit is open, and forward the request ID and trace
+// of the context to the called service.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/common"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+)
+
+// Defaults of the options left unset
+const (
+	DefaultTimeout          = 15 * time.Second
+	DefaultMaxAttempts      = 3
+	DefaultRetryBaseDelay   = 200 * time.Millisecond
+	DefaultRetryMaxDelay    = 5 * time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerTimeout   = 30 * time.Second
+	DefaultMaxIdleConns     = 10
+)
+
+// Headers forwarded to the called service
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceparentHeader = "traceparent"
+	// IdempotencyKeyHeader marks a POST or PATCH request as safe to retry
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// Options configures a client. Zero values use the defaults.
+type Options struct {
+	// Name identifies the client in logs and circuit breaker names
+	Name string
+	// Timeout bounds a whole call, retries included
+	Timeout time.Duration
+	// MaxAttempts is how many times a call is attempted; 1 disables retries
+	MaxAttempts int
+	// RetryBaseDelay and RetryMaxDelay bound the backoff between attempts, which doubles
+	// with every attempt and is fully jittered
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// BreakerThreshold is how many consecutive failures of a host open its circuit for
+	// BreakerTimeout
+	BreakerThreshold int
+	BreakerTimeout   time.Duration
+	// DisableBreaker turns circuit breaking off, e.g. when the caller already breaks the
+	// circuit of the service
+	DisableBreaker bool
+	// MaxIdleConnsPerHost is how many idle connections are kept per host
+	MaxIdleConnsPerHost int
+	// Transport sends the requests; defaults to a pooled transport with dial, TLS and
+	// response header timeouts
+	Transport http.RoundTripper
+}
+
+// New creates an HTTP client with opts
+func New(opts Options) *http.Client {
+	opts = opts.withDefaults()
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: NewTransport(opts),
+	}
+}
+
+// withDefaults returns the options with the unset ones defaulted
+func (o Options) withDefaults() Options {
+	if o.Name == "" {
+		o.Name = "http"
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.RetryBaseDelay <= 0 {
+		o.RetryBaseDelay = DefaultRetryBaseDelay
+	}
+	if o.RetryMaxDelay <= 0 {
+		o.RetryMaxDelay = DefaultRetryMaxDelay
+	}
+	if o.BreakerThreshold <= 0 {
+		o.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if o.BreakerTimeout <= 0 {
+		o.BreakerTimeout = DefaultBreakerTimeout
+	}
+	if o.MaxIdleConnsPerHost <= 0 {
+		o.MaxIdleConnsPerHost = DefaultMaxIdleConns
+	}
+	if o.Transport == nil {
+		o.Transport = newPooledTransport(o.MaxIdleConnsPerHost)
+	}
+	return o
+}
+
+// newPooledTransport creates a transport keeping up to maxIdlePerHost connections per host
+func newPooledTransport(maxIdlePerHost int) *http.Transport {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdlePerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// Transport is a round tripper adding retries, circuit breaking and context propagation
+// to another one. It is safe for concurrent use.
+type Transport struct {
+	opts Options
+
+	mu       sync.Mutex
+	breakers map[string]*resilience.Breaker
+}
+
+// NewTransport creates a transport with opts
+func NewTransport(opts Options) *Transport {
+	return &Transport{opts: opts.withDefaults(), breakers: make(map[string]*resilience.Breaker)}
+}
+
+// RoundTrip sends req, retrying it while it is safe to repeat and its attempts failed
+// transiently. The response of the last attempt is returned, whatever its status.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	breaker := t.breaker(req.URL.Host)
+	attempts := 1
+	if retryable(req) {
+		attempts = t.opts.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if breaker != nil {
+			if err := breaker.Allow(); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", t.opts.Name, req.URL.Host, err)
+			}
+		}
+
+		attemptReq, err := prepare(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.opts.Transport.RoundTrip(attemptReq)
+		if breaker != nil {
+			breaker.Record(failure(resp, err))
+		}
+
+		if attempt >= attempts || !transient(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		logger.DebugCtx(ctx, "Retrying HTTP request",
+			logger.String("client", t.opts.Name),
+			logger.String("method", req.Method),
+			logger.String("host", req.URL.Host),
+			logger.Int("attempt", attempt),
+			logger.Duration("delay", delay),
+			logger.String("reason", reason(resp, err)),
+		)
+		if resp != nil {
+			// Drain the body so that the connection goes back to the pool
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// breaker returns the circuit breaker of host, nil when circuit breaking is disabled
+func (t *Transport) breaker(host string) *resilience.Breaker {
+	if t.opts.DisableBreaker {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.breakers[host]
+	if !ok {
+		b = resilience.NewBreaker(resilience.BreakerOptions{
+			Name:      t.opts.Name + ":" + host,
+			Threshold: t.opts.BreakerThreshold,
+			Timeout:   t.opts.BreakerTimeout,
+		})
+		t.breakers[host] = b
+	}
+	return b
+}
+
+// backoff returns how long to wait after a failed attempt: the delay the server asked for
+// in Retry-After when it fits under the maximum, a jittered exponential delay otherwise
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if delay := time.Duration(seconds) * time.Second; delay <= t.opts.RetryMaxDelay {
+				return delay
+			}
+		}
+	}
+
+	ceiling := t.opts.RetryBaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > t.opts.RetryMaxDelay {
+		ceiling = t.opts.RetryMaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(ceiling))) + 1
+}
+
+// prepare returns the request of an attempt: a copy of req carrying the request ID and
+// trace of its context, with a fresh body for every attempt after the first
+func prepare(req *http.Request, attempt int) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		out.Body = body
+	}
+	propagate(req.Context(), out.Header)
+	return out, nil
+}
+
+// propagate sets the request ID and traceparent headers from ctx unless the caller set them
+func propagate(ctx context.Context, header http.Header) {
+	if header.Get(RequestIDHeader) == "" {
+		if requestID, ok := ctx.Value(common.RequestIDContextKey).(string); ok && requestID != "" {
+			header.Set(RequestIDHeader, requestID)
+		}
+	}
+	if header.Get(TraceparentHeader) == "" {
+		if trace, ok := logger.TraceFromContext(ctx); ok {
+			header.Set(TraceparentHeader, "00-"+trace.TraceID+"-"+trace.SpanID+"-01")
+		}
+	}
+}
+
+// retryable reports whether req may be sent more than once: idempotent methods, and
+// requests carrying an idempotency key, as long as their body can be rewound
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return req.Header.Get(IdempotencyKeyHeader) != ""
+	}
+}
+
+// transient reports whether an attempt failed in a way another attempt may not
+func transient(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// failure returns the error an attempt counts as against the circuit of its host: transport
+// errors and server errors, not the errors of the caller
+func failure(resp *http.Response, err error) error {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("server error: %s", resp.Status)
+	}
+	return nil
+}
+
+// reason describes why an attempt failed
+func reason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress is returned when a URL resolves to an address that is not public, such
+// as a loopback, private or link-local one, which user-given URLs must not reach
+var ErrBlockedAddress = errors.New("url resolves to a non-public address")
+
+// NewPublicTransport creates a transport that only connects to public addresses, for calls
+// to user-given URLs. The check runs on the resolved address of every connection, redirects
+// included, so that names resolving to internal addresses are caught too. Proxies are not
+// used, since they would connect on our behalf.
+func NewPublicTransport() *http.Transport {
+	return &http.Transport{
+		DialContext:           NewPublicDialer(),
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// NewPublicDialer returns a dial function that only connects to public addresses, for
+// connections to user-given hosts. Connections to other addresses fail with
+// ErrBlockedAddress.
+func NewPublicDialer() func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isPublicAddress(addrPort.Addr()) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, address)
+		if err != nil && errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return conn, err
+	}
+}
+
+// isPublicAddress reports whether addr may be reached from user-given URLs
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which IsPrivate does not cover
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
@@ -0,0 +1,175 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is used when no configured or requested locale is available.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// Bundle holds the translation catalogs keyed by locale.
+// Message keys are either the English source message or a dotted template key
+// such as "validation.required"; unknown keys are returned unchanged.
+type Bundle struct {
+	fallback string
+	catalogs map[string]map[string]string
+	tags     []language.Tag
+	locales  []string
+	matcher  language.Matcher
+}
+
+var (
+	defaultBundle *Bundle
+	bundleMu      sync.RWMutex
+)
+
+// NewBundle loads the embedded catalogs. fallback must be one of the embedded locales.
+func NewBundle(fallback string) (*Bundle, error) {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded locales: %w", err)
+	}
+
+	catalogs := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		locale := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		data, err := localeFS.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read locale %s: %w", locale, err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse locale %s: %w", locale, err)
+		}
+		catalogs[locale] = messages
+	}
+
+	if _, ok := catalogs[fallback]; !ok {
+		return nil, fmt.Errorf("fallback locale %q is not available", fallback)
+	}
+
+	// The fallback locale goes first so the matcher prefers it when nothing matches.
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		if locale != fallback {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	locales = append([]string{fallback}, locales...)
+
+	tags := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		tags[i] = language.Make(locale)
+	}
+
+	return &Bundle{
+		fallback: fallback,
+		catalogs: catalogs,
+		tags:     tags,
+		locales:  locales,
+		matcher:  language.NewMatcher(tags),
+	}, nil
+}
+
+// Fallback returns the locale used when a request does not match any catalog.
+func (b *Bundle) Fallback() string {
+	return b.fallback
+}
+
+// Locales returns the available locales, fallback first.
+func (b *Bundle) Locales() []string {
+	return b.locales
+}
+
+// Match picks the best available locale for an Accept-Language header value.
+func (b *Bundle) Match(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return b.fallback
+	}
+
+	requested, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(requested) == 0 {
+		return b.fallback
+	}
+
+	_, index, confidence := b.matcher.Match(requested...)
+	if confidence == language.No {
+		return b.fallback
+	}
+	return b.locales[index]
+}
+
+// Translate returns the message for key in locale, falling back to the fallback locale
+// and then to the key itself. Placeholders such as {field} are replaced from args.
+func (b *Bundle) Translate(locale, key string, args map[string]string) string {
+	message, ok := b.catalogs[locale][key]
+	if !ok {
+		if message, ok = b.catalogs[b.fallback][key]; !ok {
+			message = key
+		}
+	}
+	return interpolate(message, args)
+}
+
+func interpolate(message string, args map[string]string) string {
+	if len(args) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, len(args)*2)
+	for name, value := range args {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// Init configures the package-level bundle with the given fallback locale.
+func Init(fallback string) error {
+	bundle, err := NewBundle(fallback)
+	if err != nil {
+		return err
+	}
+
+	bundleMu.Lock()
+	defer bundleMu.Unlock()
+	defaultBundle = bundle
+	return nil
+}
+
+// Default returns the package-level bundle, initializing it with DefaultLocale if needed.
+func Default() *Bundle {
+	bundleMu.RLock()
+	bundle := defaultBundle
+	bundleMu.RUnlock()
+	if bundle != nil {
+		return bundle
+	}
+
+	if err := Init(DefaultLocale); err != nil {
+		// The embedded catalogs are part of the binary, so this only fails on a broken build.
+		panic(fmt.Sprintf("i18n: failed to load embedded catalogs: %v", err))
+	}
+	return Default()
+}
+
+// Match picks the best locale for an Accept-Language header using the default bundle.
+func Match(acceptLanguage string) string {
+	return Default().Match(acceptLanguage)
+}
+
+// T translates key into locale using the default bundle.
+func T(locale, key string, args map[string]string) string {
+	return Default().Translate(locale, key, args)
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/cache"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// Exclusive returns the job running only while it holds the lock named key, so that one
+// replica at a time runs it. A run finding the lock held by another replica is skipped and
+// succeeds. The lease lasts ttl and is renewed while the job runs; its context carries the
+// lease for cache.CheckLease. With nil locks, as when Redis is disabled, the job is
+// returned as it is.
+func (j Job) Exclusive(locks *cache.Service, key string, ttl time.Duration) Job {
+	if locks == nil {
+		return j
+	}
+
+	run := j.Run
+	j.Run = func(ctx context.Context) error {
+		err := locks.WithLock(ctx, key, ttl, func(ctx context.Context, _ *cache.Lease) error {
+			return run(ctx)
+		})
+		if errors.Is(err, cache.ErrLockHeld) {
+			logger.Debug("Skipping job run, another replica holds the lock", logger.String("job", j.Name), logger.String("lock", key))
+			return nil
+		}
+		return err
+	}
+	return j
+}
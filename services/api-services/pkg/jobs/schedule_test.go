@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+// at parses a UTC time written as 2006-01-02 15:04
+func at(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestParseScheduleNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		after string
+		want  string
+	}{
+		{"every minute", "* * * * *", "2026-10-16 10:07", "2026-10-16 10:08"},
+		{"strictly after", "5,35 * * * *", "2026-10-16 10:05", "2026-10-16 10:35"},
+		{"list wraps to the next hour", "5,35 * * * *", "2026-10-16 10:35", "2026-10-16 11:05"},
+		{"minute step", "*/15 * * * *", "2026-10-16 10:07", "2026-10-16 10:15"},
+		{"minute step wraps to the next hour", "*/15 * * * *", "2026-10-16 10:45", "2026-10-16 11:00"},
+		{"value with step runs to the end", "10/20 * * * *", "2026-10-16 10:31", "2026-10-16 10:50"},
+		{"hour range", "0 9-17 * * *", "2026-10-16 12:30", "2026-10-16 13:00"},
+		{"hour range wraps to the next day", "0 9-17 * * *", "2026-10-16 17:00", "2026-10-17 09:00"},
+		{"range with step", "0 8-18/4 * * *", "2026-10-16 12:01", "2026-10-16 16:00"},
+		{"range with step ends before its bound", "0 8-18/4 * * *", "2026-10-16 16:00", "2026-10-17 08:00"},
+		{"list of ranges", "0 1-2,22-23 * * *", "2026-10-16 03:00", "2026-10-16 22:00"},
+		{"weekday range", "0 3 * * mon-fri", "2026-10-16 04:00", "2026-10-19 03:00"},
+		{"sunday as 7", "0 0 * * 7", "2026-10-16 00:00", "2026-10-18 00:00"},
+		{"sunday as 0", "0 0 * * sun", "2026-10-16 00:00", "2026-10-18 00:00"},
+		{"day of month step", "0 0 */10 * *", "2026-10-21 00:00", "2026-10-31 00:00"},
+		{"month names", "0 0 1 jan,jul *", "2026-02-01 00:00", "2026-07-01 00:00"},
+		{"descriptor", "@daily", "2026-10-16 10:00", "2026-10-17 00:00"},
+
+		// When both day fields are restricted, a day matching either one matches
+		{"day of week before day of month", "0 0 13 * mon", "2026-10-16 00:00", "2026-10-19 00:00"},
+		{"day of month before day of week", "0 0 13 * mon", "2026-11-10 00:00", "2026-11-13 00:00"},
+		// With one of them unrestricted, only the other one decides
+		{"day of month only", "0 0 13 * *", "2026-10-16 00:00", "2026-11-13 00:00"},
+		{"day of week only", "0 0 * * mon", "2026-11-10 00:00", "2026-11-16 00:00"},
+		{"both restricted by steps", "0 0 */15 * */7", "2026-10-16 00:00", "2026-10-18 00:00"},
+
+		{"next month", "0 0 1 * *", "2026-01-31 23:59", "2026-02-01 00:00"},
+		{"skips months without the day", "0 0 31 * *", "2026-09-15 00:00", "2026-10-31 00:00"},
+		{"skips february", "30 12 30 * *", "2026-01-30 12:30", "2026-03-30 12:30"},
+		{"next year", "@yearly", "2026-12-31 23:59", "2027-01-01 00:00"},
+		{"leap day", "0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+			}
+			if got, want := schedule.Next(at(t, tt.after)), at(t, tt.want); !got.Equal(want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.after, got.Format(time.RFC3339), want.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestParseScheduleEvery(t *testing.T) {
+	schedule, err := ParseSchedule("@every 90s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := at(t, "2026-10-16 10:00")
+	if got, want := schedule.Next(after), after.Add(90*time.Second); !got.Equal(want) {
+		t.Errorf("Next() = %s, want %s", got, want)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"1-x * * * *",
+		"* * * foo *",
+		"0 0 30 feb *",
+		"@every -1s",
+		"@every soon",
+		"@sometimes",
+	} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) succeeded, want an error", spec)
+		}
+	}
+}
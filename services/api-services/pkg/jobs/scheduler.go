@@ -0,0 +1,291 @@
+// Package jobs runs background work on schedules with a bounded worker pool. Each run gets
+// a timeout, panics are recovered and reported as failures, and a job is never run twice
+// at the same time: a run due while the previous one is still going is skipped.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// Job is background work run on a schedule
+type Job struct {
+	// Name identifies the job in logs and metrics
+	Name     string
+	Schedule Schedule
+	// Timeout bounds each run; 0 uses the timeout of the scheduler
+	Timeout time.Duration
+	// RunOnStart runs the job as soon as the scheduler starts, then on its schedule
+	RunOnStart bool
+	Run        func(ctx context.Context) error
+}
+
+// PanicError is the failure of a run that panicked
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// Stats are the counters of a job. Times the job has not reached yet are zero.
+type Stats struct {
+	Name     string `json:"name"`
+	Runs     int64  `json:"runs"`
+	Failures int64  `json:"failures"`
+	Panics   int64  `json:"panics"`
+	// Skipped counts the runs that were due while the previous one was still running
+	Skipped      int64         `json:"skipped"`
+	Running      bool          `json:"running"`
+	LastDuration time.Duration `json:"last_duration_ns"`
+	LastRun      time.Time     `json:"last_run,omitzero"`
+	LastSuccess  time.Time     `json:"last_success,omitzero"`
+	NextRun      time.Time     `json:"next_run,omitzero"`
+}
+
+// Scheduler runs jobs on their schedules with a fixed number of workers
+type Scheduler struct {
+	workers int
+	timeout time.Duration
+
+	mu      sync.Mutex
+	entries []*entry
+	started bool
+}
+
+// entry is a job with its state
+type entry struct {
+	job     Job
+	running atomic.Bool
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewScheduler creates a scheduler running at most workers jobs at a time. timeout
+// bounds the runs of jobs without their own; 0 leaves them unbounded.
+func NewScheduler(workers int, timeout time.Duration) *Scheduler {
+	if workers <= 0 {
+		workers = 1
+	}
+	return &Scheduler{workers: workers, timeout: timeout}
+}
+
+// Add registers job. Jobs must be added before Run and have unique names.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return errors.New("job needs a name, a schedule and a run function")
+	}
+	if job.Schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("schedule of job %s never fires", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("cannot add job %s, the scheduler is running", job.Name)
+	}
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("job %s is already registered", job.Name)
+		}
+	}
+	s.entries = append(s.entries, &entry{job: job, stats: Stats{Name: job.Name}})
+	return nil
+}
+
+// Run runs the jobs until ctx is cancelled, then waits for the running ones to return.
+// Running jobs see ctx cancelled too.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.started = true
+	entries := s.entries
+	s.mu.Unlock()
+
+	if len(entries) == 0 {
+		<-ctx.Done()
+		return
+	}
+
+	// Each job is queued or running at most once, so the queue never blocks
+	queue := make(chan *entry, len(entries))
+	var wg sync.WaitGroup
+	for range min(s.workers, len(entries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range queue {
+				s.execute(ctx, e)
+			}
+		}()
+	}
+	defer func() {
+		close(queue)
+		wg.Wait()
+	}()
+
+	now := time.Now()
+	for _, e := range entries {
+		e.setNext(e.job.Schedule.Next(now))
+		if e.job.RunOnStart {
+			e.dispatch(queue)
+		}
+	}
+
+	timer := time.NewTimer(time.Until(earliest(entries)))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			now := time.Now()
+			for _, e := range entries {
+				next := e.next()
+				if next.IsZero() || next.After(now) {
+					continue
+				}
+				e.dispatch(queue)
+				e.setNext(e.job.Schedule.Next(now))
+			}
+			timer.Reset(time.Until(earliest(entries)))
+		}
+	}
+}
+
+// Stats returns the counters of every job, sorted by name
+func (s *Scheduler) Stats() []Stats {
+	s.mu.Lock()
+	entries := s.entries
+	s.mu.Unlock()
+
+	stats := make([]Stats, 0, len(entries))
+	for _, e := range entries {
+		e.mu.Lock()
+		current := e.stats
+		e.mu.Unlock()
+		current.Running = e.running.Load()
+		stats = append(stats, current)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// execute runs e once with its timeout, recovering a panic
+func (s *Scheduler) execute(ctx context.Context, e *entry) {
+	defer e.running.Store(false)
+	if ctx.Err() != nil {
+		return
+	}
+
+	timeout := e.job.Timeout
+	if timeout == 0 {
+		timeout = s.timeout
+	}
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := runSafely(runCtx, e.job.Run)
+	duration := time.Since(start)
+	e.record(start, duration, err)
+
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		logger.Error("Job panicked",
+			logger.String("job", e.job.Name),
+			logger.Any("panic", panicErr.Value),
+			logger.String("stack", string(panicErr.Stack)),
+		)
+	case err != nil:
+		logger.Error("Job failed", logger.String("job", e.job.Name), logger.Duration("duration", duration), logger.ErrorField(err))
+	default:
+		logger.Debug("Job finished", logger.String("job", e.job.Name), logger.Duration("duration", duration))
+	}
+}
+
+// runSafely calls run, turning a panic into a PanicError
+func runSafely(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &PanicError{Value: recovered, Stack: debug.Stack()}
+		}
+	}()
+	return run(ctx)
+}
+
+// dispatch queues e unless its previous run is still going
+func (e *entry) dispatch(queue chan<- *entry) {
+	if !e.running.CompareAndSwap(false, true) {
+		e.mu.Lock()
+		e.stats.Skipped++
+		e.mu.Unlock()
+		logger.Warn("Skipping job run, the previous one is still running", logger.String("job", e.job.Name))
+		return
+	}
+	queue <- e
+}
+
+func (e *entry) record(start time.Time, duration time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stats.Runs++
+	e.stats.LastRun = start
+	e.stats.LastDuration = duration
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		e.stats.Failures++
+		e.stats.Panics++
+	case err != nil:
+		e.stats.Failures++
+	default:
+		e.stats.LastSuccess = start
+	}
+}
+
+func (e *entry) next() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.stats.NextRun
+}
+
+func (e *entry) setNext(next time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stats.NextRun = next
+}
+
+// earliest returns the next time one of entries is due, far in the future when none is
+func earliest(entries []*entry) time.Time {
+	var first time.Time
+	for _, e := range entries {
+		if next := e.next(); !next.IsZero() && (first.IsZero() || next.Before(first)) {
+			first = next
+		}
+	}
+	if first.IsZero() {
+		return time.Now().Add(maxSearch)
+	}
+	return first
+}
@@ -0,0 +1,173 @@
+// Package lifecycle coordinates the startup and the graceful shutdown of the application.
+// Startup tracks the steps to go through before traffic is served. On shutdown, components
+// register hooks in a phase; the phases run in order and the hooks of a phase run one after
+// the other in the order they were registered, each bounded by its own timeout. A hook
+// failing or timing out is logged and does not stop the ones after it.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// Phase orders shutdown hooks: lower phases run first
+type Phase int
+
+// Shutdown phases, in the order they run
+const (
+	// PhaseServers stops accepting requests and finishes the ones in flight
+	PhaseServers Phase = iota
+	// PhaseWorkers stops the job scheduler and the background loops
+	PhaseWorkers
+	// PhaseDrain flushes buffered writes and queued work
+	PhaseDrain
+	// PhaseClients closes database, cache and storage clients
+	PhaseClients
+)
+
+// String returns the name of the phase used in logs
+func (p Phase) String() string {
+	switch p {
+	case PhaseServers:
+		return "servers"
+	case PhaseWorkers:
+		return "workers"
+	case PhaseDrain:
+		return "drain"
+	case PhaseClients:
+		return "clients"
+	default:
+		return fmt.Sprintf("phase_%d", int(p))
+	}
+}
+
+// Hook stops one component during shutdown
+type Hook struct {
+	// Name identifies the component in logs
+	Name  string
+	Phase Phase
+	// Timeout bounds the hook; 0 uses the hook timeout of the manager
+	Timeout time.Duration
+	// Stop must return once ctx is done, even when the component has not stopped cleanly
+	Stop func(ctx context.Context) error
+}
+
+// Manager holds the shutdown hooks of the application and runs them in order
+type Manager struct {
+	mu          sync.Mutex
+	hooks       []Hook
+	hookTimeout time.Duration
+	stopped     bool
+}
+
+// NewManager creates a manager giving hooks without a timeout of their own hookTimeout
+func NewManager(hookTimeout time.Duration) *Manager {
+	return &Manager{hookTimeout: hookTimeout}
+}
+
+// Register adds a shutdown hook. Hooks registered once Shutdown has started are ignored.
+func (m *Manager) Register(hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped {
+		logger.Warn("Ignoring shutdown hook registered during shutdown", logger.String("hook", hook.Name))
+		return
+	}
+	m.hooks = append(m.hooks, hook)
+}
+
+// Go runs a background loop until shutdown. The loop gets a context derived from ctx that
+// is cancelled in phase, and its hook waits for the loop to return.
+func (m *Manager) Go(ctx context.Context, name string, phase Phase, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+
+	m.Register(Hook{
+		Name:  name,
+		Phase: phase,
+		Stop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return fmt.Errorf("%s did not stop: %w", name, stopCtx.Err())
+			}
+		},
+	})
+}
+
+// Shutdown runs every hook, phase by phase, and returns their errors joined. Each hook gets
+// a context bounded by its timeout and by ctx, so a hook that overruns it is abandoned and
+// the next one still gets its turn while ctx lasts.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.stopped = true
+	hooks := make([]Hook, len(m.hooks))
+	copy(hooks, m.hooks)
+	m.mu.Unlock()
+
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].Phase < hooks[j].Phase })
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := m.run(ctx, hook); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run runs a hook under its timeout, logging how long it took and how it ended
+func (m *Manager) run(ctx context.Context, hook Hook) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = m.hookTimeout
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fields := []logger.Field{logger.String("hook", hook.Name), logger.String("phase", hook.Phase.String())}
+	start := time.Now()
+
+	err := stop(hookCtx, hook)
+	fields = append(fields, logger.Duration("duration", time.Since(start)))
+	if err != nil {
+		logger.Error("Shutdown hook failed", append(fields, logger.ErrorField(err))...)
+		return err
+	}
+	logger.Info("Shutdown hook completed", fields...)
+	return nil
+}
+
+// stop calls the hook, recovering a panic so that the hooks after it still run, and gives
+// up on it once ctx is done
+func stop(ctx context.Context, hook Hook) error {
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				result <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		result <- hook.Stop(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,168 @@
+package lifecycle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// Startup step statuses
+const (
+	StepPending = "pending"
+	StepRunning = "running"
+	StepDone    = "done"
+	StepFailed  = "failed"
+	StepSkipped = "skipped"
+)
+
+// StepProgress is the progress of a startup step
+type StepProgress struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// StartupProgress is the progress of the whole startup
+type StartupProgress struct {
+	Ready     bool           `json:"ready"`
+	StartedAt time.Time      `json:"started_at"`
+	ReadyAt   *time.Time     `json:"ready_at,omitempty"`
+	Steps     []StepProgress `json:"steps"`
+}
+
+// Startup tracks the steps the application goes through before it can serve traffic, such
+// as migrations and cache warm-up. The application is ready once every step finished: done,
+// skipped, or failed, since the steps that cannot fail stop the process instead. It is safe
+// for concurrent use.
+type Startup struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	readyAt   *time.Time
+	steps     []*StepProgress
+}
+
+// NewStartup creates a startup made of the named steps, all pending
+func NewStartup(names ...string) *Startup {
+	s := &Startup{startedAt: time.Now()}
+	for _, name := range names {
+		s.steps = append(s.steps, &StepProgress{Name: name, Status: StepPending})
+	}
+	if len(s.steps) == 0 {
+		s.readyAt = &s.startedAt
+	}
+	return s
+}
+
+// Begin marks a step as running
+func (s *Startup) Begin(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if step := s.step(name); step != nil {
+		now := time.Now()
+		step.Status = StepRunning
+		step.StartedAt = &now
+	}
+}
+
+// Complete marks a step as done, or as failed when err is not nil
+func (s *Startup) Complete(name string, err error) {
+	s.finish(name, err, StepDone)
+}
+
+// Skip marks a step that does not apply to this instance, such as the cache warm-up without
+// a cache, as skipped
+func (s *Startup) Skip(name string) {
+	s.finish(name, nil, StepSkipped)
+}
+
+func (s *Startup) finish(name string, err error, status string) {
+	s.mu.Lock()
+	step := s.step(name)
+	if step == nil {
+		s.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	step.Status = status
+	step.FinishedAt = &now
+	if step.StartedAt != nil {
+		step.DurationMs = now.Sub(*step.StartedAt).Milliseconds()
+	}
+	if err != nil {
+		step.Status = StepFailed
+		step.Error = err.Error()
+	}
+	finished := *step
+	ready := s.readyAt == nil && s.finished()
+	if ready {
+		s.readyAt = &now
+	}
+	s.mu.Unlock()
+
+	logger.Info("Startup step finished", logger.String("step", name), logger.String("status", finished.Status), logger.Int64("duration_ms", finished.DurationMs))
+	if ready {
+		logger.Info("Startup completed, ready to serve traffic", logger.Duration("duration", now.Sub(s.startedAt)))
+	}
+}
+
+// Ready reports whether every step finished
+func (s *Startup) Ready() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readyAt != nil
+}
+
+// Pending returns the names of the steps that are pending or still running
+func (s *Startup) Pending() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	for _, step := range s.steps {
+		if step.Status == StepPending || step.Status == StepRunning {
+			names = append(names, step.Name)
+		}
+	}
+	return names
+}
+
+// Progress returns a copy of the progress of every step
+func (s *Startup) Progress() StartupProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	progress := StartupProgress{Ready: s.readyAt != nil, StartedAt: s.startedAt, ReadyAt: s.readyAt, Steps: make([]StepProgress, 0, len(s.steps))}
+	for _, step := range s.steps {
+		p := *step
+		if p.Status == StepRunning && p.StartedAt != nil {
+			p.DurationMs = time.Since(*p.StartedAt).Milliseconds()
+		}
+		progress.Steps = append(progress.Steps, p)
+	}
+	return progress
+}
+
+// step returns the named step, nil for unknown names
+func (s *Startup) step(name string) *StepProgress {
+	for _, step := range s.steps {
+		if step.Name == name {
+			return step
+		}
+	}
+	return nil
+}
+
+// finished reports whether no step is pending or running
+func (s *Startup) finished() bool {
+	for _, step := range s.steps {
+		if step.Status == StepPending || step.Status == StepRunning {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,378 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AuditEvent names an action recorded in the audit log
+type AuditEvent string
+
+// Audit events
+const (
+	AuditSignUp                      AuditEvent = "auth.sign_up"
+	AuditSignIn                      AuditEvent = "auth.sign_in"
+	AuditSignInFailed                AuditEvent = "auth.sign_in_failed"
+	AuditPasswordResetRequested      AuditEvent = "auth.password_reset_requested"
+	AuditPasswordReset               AuditEvent = "auth.password_reset"
+	AuditEmailVerified               AuditEvent = "auth.email_verified"
+	AuditPhoneVerified               AuditEvent = "auth.phone_verified"
+	AuditLogLevelChanged             AuditEvent = "config.log_level_changed"
+	AuditEmailSuppressionRemoved     AuditEvent = "config.email_suppression_removed"
+	AuditConfigReloaded              AuditEvent = "config.reloaded"
+	AuditOutboxMessagesRetried       AuditEvent = "outbox.messages_retried"
+	AuditOutboxMessageDiscarded      AuditEvent = "outbox.message_discarded"
+	AuditRetentionPolicyChanged      AuditEvent = "config.retention_policy_changed"
+	AuditLogExported                 AuditEvent = "audit.exported"
+	AuditIncidentOpened              AuditEvent = "incident.opened"
+	AuditIncidentResolved            AuditEvent = "incident.resolved"
+	AuditIncidentAssigned            AuditEvent = "incident.assigned"
+	AuditMonitorUpdated              AuditEvent = "monitor.updated"
+	AuditMonitorOwnerChanged         AuditEvent = "monitor.owner_changed"
+	AuditMonitorsImported            AuditEvent = "monitor.imported"
+	AuditConfigSynced                AuditEvent = "config.synced"
+	AuditOrganizationSettingsChanged AuditEvent = "config.organization_settings_changed"
+	AuditOrganizationTimezoneChanged AuditEvent = "config.organization_timezone_changed"
+)
+
+// auditTailBytes is how much of an existing audit file is read at a time, from its end,
+// to resume its hash chain
+const auditTailBytes = 64 * 1024
+
+// auditHashSuffix matches the hash that ends every audit line
+var auditHashSuffix = regexp.MustCompile(`,"hash":"([0-9a-f]{64})"}$`)
+
+// auditLogger writes the audit channel, nil when it is disabled
+var auditLogger *zap.Logger
+
+// auditFile is the audit file the chain of auditLogger is resumed from and exported from,
+// nil when the audit log is disabled or only written to the console
+var auditFile *auditFileState
+
+// auditFileState is the audit file of the audit channel and its chain
+type auditFileState struct {
+	path  string
+	chain *auditChain
+}
+
+// AuditEntry is an audit event passed to the observer registered with SetAuditObserver:
+// when it was recorded, the event and the fields it was recorded with, redacted
+type AuditEntry struct {
+	Time   time.Time
+	Event  AuditEvent
+	Fields map[string]any
+}
+
+// AuditObserver receives the audit events recorded by Audit
+type AuditObserver func(entry AuditEntry)
+
+// auditObserver is the observer registered with SetAuditObserver
+var auditObserver atomic.Pointer[AuditObserver]
+
+// auditRedactor redacts the fields of the entries passed to auditObserver the way the
+// logs are redacted
+var auditRedactor = newRedactor(nil)
+
+// SetAuditObserver makes Audit pass every event to observe as well, whether the audit
+// log is enabled or not, so they can be stored where every replica reads them. observe
+// runs on the goroutine recording the event and must not block. A nil observe removes
+// the observer.
+func SetAuditObserver(observe AuditObserver) {
+	if observe == nil {
+		auditObserver.Store(nil)
+		return
+	}
+	auditObserver.Store(&observe)
+}
+
+// Audit records event in the audit log. Audit entries are never sampled and each carries
+// a hash chaining it to the previous one, so removed or edited entries can be detected
+// with VerifyAuditLog. When the audit log is disabled, the event goes to the main log.
+func Audit(event AuditEvent, fields ...Field) {
+	if observe := auditObserver.Load(); observe != nil {
+		(*observe)(newAuditEntry(event, fields))
+	}
+	if auditLogger == nil {
+		Get().WithOptions(zap.AddCallerSkip(1)).Info(string(event), append([]Field{Bool("audit", true)}, fields...)...)
+		return
+	}
+	auditLogger.Info(string(event), fields...)
+}
+
+// newAuditEntry returns the entry of event passed to the audit observer
+func newAuditEntry(event AuditEvent, fields []Field) AuditEntry {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range auditRedactor.redact(fields) {
+		field.AddTo(encoder)
+	}
+	return AuditEntry{Time: time.Now().UTC(), Event: event, Fields: encoder.Fields}
+}
+
+// initAuditLogger creates the audit channel configured by cfg
+func initAuditLogger(cfg config.LoggingConfig, encoderConfig zapcore.EncoderConfig) error {
+	chain := &auditChain{key: []byte(cfg.AuditSigningKey)}
+	var file *auditFileState
+	for _, path := range cfg.AuditOutputPaths {
+		if strings.EqualFold(path, "stdout") || strings.EqualFold(path, "stderr") {
+			continue
+		}
+		// Continue the chain of the file instead of starting a new one on every restart
+		if err := chain.resume(path); err != nil {
+			return fmt.Errorf("failed to resume audit log %s: %w", path, err)
+		}
+		file = &auditFileState{path: path, chain: chain}
+		break
+	}
+
+	encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+	encoderConfig.LineEnding = "\n"
+	core := &auditCore{
+		encoder: zapcore.NewJSONEncoder(encoderConfig),
+		out:     newWriteSyncer(cfg.AuditOutputPaths, cfg),
+		chain:   chain,
+	}
+
+	auditLogger = zap.New(newRedactingCore(core, cfg.RedactKeys), zap.AddCaller(), zap.AddCallerSkip(1))
+	auditFile = file
+	return nil
+}
+
+// auditChain links audit entries by hash. With a key, hashes are HMACs so they cannot be
+// recomputed by someone editing the file without it.
+type auditChain struct {
+	mu   sync.Mutex
+	key  []byte
+	seq  int64
+	prev string
+}
+
+// sum returns the hex hash of body
+func (c *auditChain) sum(body []byte) string {
+	var h hash.Hash
+	if len(c.key) > 0 {
+		h = hmac.New(sha256.New, c.key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// resume continues from the last entry of the audit file at path, if any. A missing or
+// empty file was just rotated, so the chain continues from the newest rotated file.
+func (c *auditChain) resume(path string) error {
+	last, err := lastAuditLine(path)
+	if err != nil {
+		return err
+	}
+	if len(last) == 0 {
+		backups, err := auditBackups(path)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			return nil
+		}
+		err = readAuditBackup(backups[len(backups)-1], func(line []byte) error {
+			if len(line) > 0 {
+				last = append(last[:0], line...)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(last) == 0 {
+			return nil
+		}
+	}
+
+	var entry struct {
+		Seq  int64  `json:"seq"`
+		Hash string `json:"hash"`
+	}
+	if err := json.Unmarshal(last, &entry); err != nil || entry.Hash == "" {
+		return fmt.Errorf("last line is not an audit entry")
+	}
+	c.seq = entry.Seq
+	c.prev = entry.Hash
+	return nil
+}
+
+// lastAuditLine returns the last line of the audit file at path, or nil when the file is
+// missing or empty. The file is read backwards auditTailBytes at a time until the line
+// preceding the last one ends, so entries longer than that are read whole.
+func lastAuditLine(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var tail []byte
+	for offset := info.Size(); offset > 0; {
+		n := min(offset, auditTailBytes)
+		offset -= n
+		chunk := make([]byte, n, n+int64(len(tail)))
+		if _, err := file.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+		if bytes.IndexByte(bytes.TrimRight(tail, "\n"), '\n') >= 0 {
+			break
+		}
+	}
+
+	tail = bytes.TrimRight(tail, "\n")
+	return tail[bytes.LastIndexByte(tail, '\n')+1:], nil
+}
+
+// auditCore writes every entry, whatever its level, with its sequence number, the hash of
+// the previous entry and its own hash as the last field
+type auditCore struct {
+	encoder zapcore.Encoder
+	out     zapcore.WriteSyncer
+	chain   *auditChain
+}
+
+// Enabled implements zapcore.Core
+func (c *auditCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+// With implements zapcore.Core
+func (c *auditCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &auditCore{encoder: c.encoder.Clone(), out: c.out, chain: c.chain}
+	for _, field := range fields {
+		field.AddTo(clone.encoder)
+	}
+	return clone
+}
+
+// Check implements zapcore.Core
+func (c *auditCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(entry, c)
+}
+
+// Write implements zapcore.Core
+func (c *auditCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+
+	seq := c.chain.seq + 1
+	chained := append(fields[:len(fields):len(fields)], zap.Int64("seq", seq), zap.String("prev_hash", c.chain.prev))
+	buf, err := c.encoder.EncodeEntry(entry, chained)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	// The hash covers the line up to the closing brace, then closes it
+	body := bytes.TrimSuffix(buf.Bytes(), []byte("}\n"))
+	sum := c.chain.sum(body)
+	line := make([]byte, 0, len(body)+len(sum)+12)
+	line = append(line, body...)
+	line = append(line, `,"hash":"`...)
+	line = append(line, sum...)
+	line = append(line, "\"}\n"...)
+
+	if _, err := c.out.Write(line); err != nil {
+		return err
+	}
+	c.chain.seq = seq
+	c.chain.prev = sum
+	return nil
+}
+
+// Sync implements zapcore.Core
+func (c *auditCore) Sync() error {
+	return c.out.Sync()
+}
+
+// VerifyAuditLog checks the hash chain of an audit log read from r, signed with key when
+// one was configured, and returns how many entries it verified. The first entry is
+// trusted to follow a valid predecessor, since rotated files start mid-chain.
+func VerifyAuditLog(r io.Reader, key string) (int, error) {
+	verifier := newAuditVerifier(key)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if err := verifier.add(scanner.Bytes()); err != nil {
+			return verifier.count - 1, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return verifier.count, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return verifier.count, nil
+}
+
+// auditVerifier checks the hash chain of audit entries added one line at a time
+type auditVerifier struct {
+	chain *auditChain
+	count int
+}
+
+// newAuditVerifier creates a verifier of the entries signed with key, if any
+func newAuditVerifier(key string) *auditVerifier {
+	return &auditVerifier{chain: &auditChain{key: []byte(key)}}
+}
+
+// add verifies the entry of line, skipping empty lines
+func (v *auditVerifier) add(line []byte) error {
+	if len(line) == 0 {
+		return nil
+	}
+	v.count++
+
+	match := auditHashSuffix.FindSubmatchIndex(line)
+	if match == nil {
+		return fmt.Errorf("entry %d has no hash", v.count)
+	}
+	body := line[:match[0]]
+	sum := string(line[match[2]:match[3]])
+	if !hmac.Equal([]byte(v.chain.sum(body)), []byte(sum)) {
+		return fmt.Errorf("entry %d was modified", v.count)
+	}
+
+	var entry struct {
+		Seq      int64  `json:"seq"`
+		PrevHash string `json:"prev_hash"`
+	}
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return fmt.Errorf("entry %d is not valid JSON: %w", v.count, err)
+	}
+	if v.count > 1 && (entry.PrevHash != v.chain.prev || entry.Seq != v.chain.seq+1) {
+		return fmt.Errorf("entry %d does not follow entry %d", v.count, v.count-1)
+	}
+	v.chain.seq = entry.Seq
+	v.chain.prev = sum
+	return nil
+}
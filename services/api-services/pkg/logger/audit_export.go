@@ -0,0 +1,306 @@
+package logger
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Names of the files of an audit log archive
+const (
+	AuditArchiveLogName      = "audit.log"
+	AuditArchiveManifestName = "manifest.json"
+)
+
+var (
+	// ErrAuditLogUnavailable is returned when exporting an audit log that is disabled or
+	// only written to the console
+	ErrAuditLogUnavailable = errors.New("audit log is not written to a file")
+
+	// ErrInvalidAuditArchive is returned when an audit log archive is incomplete, or does
+	// not match its manifest
+	ErrInvalidAuditArchive = errors.New("invalid audit log archive")
+)
+
+// AuditManifest describes an audit log archive: the entries of its audit.log, the hashes
+// anchoring them and whether their chain verified when they were exported
+type AuditManifest struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Entries     int       `json:"entries"`
+	FirstSeq    int64     `json:"first_seq"`
+	LastSeq     int64     `json:"last_seq"`
+	// FirstPrevHash is the hash of the entry before the first one, empty when the archive
+	// starts the chain; older entries were rotated away
+	FirstPrevHash string `json:"first_prev_hash"`
+	LastHash      string `json:"last_hash"`
+	// Keyed reports whether the hashes are HMACs of the audit signing key, which are needed
+	// to verify them
+	Keyed bool `json:"keyed"`
+	// SHA256 is the hex digest of audit.log
+	SHA256      string `json:"sha256"`
+	Verified    bool   `json:"verified"`
+	VerifyError string `json:"verify_error,omitempty"`
+}
+
+// ExportAuditLog writes a zip archive of the audit log to w: every retained entry, from the
+// oldest rotated file to the current one, in audit.log and a manifest.json describing them.
+// The archive is produced even when the hash chain does not verify, which the manifest
+// records. Entries written while exporting may be left out. It fails with
+// ErrAuditLogUnavailable when the audit log is not written to a file.
+func ExportAuditLog(w io.Writer) (*AuditManifest, error) {
+	file := auditFile
+	if file == nil {
+		return nil, ErrAuditLogUnavailable
+	}
+
+	backups, size, err := file.files()
+	if err != nil {
+		return nil, err
+	}
+
+	archive := zip.NewWriter(w)
+	out, err := archive.Create(AuditArchiveLogName)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &AuditManifest{Keyed: len(file.chain.key) > 0}
+	digest := sha256.New()
+	verifier := newAuditVerifier(string(file.chain.key))
+	var verifyErr error
+	add := func(line []byte) error {
+		if len(line) == 0 {
+			return nil
+		}
+		if verifyErr == nil {
+			verifyErr = verifier.add(line)
+		}
+		// Entries that do not verify are exported as they are, for what they are worth
+		var entry struct {
+			Seq      int64  `json:"seq"`
+			PrevHash string `json:"prev_hash"`
+			Hash     string `json:"hash"`
+		}
+		_ = json.Unmarshal(line, &entry)
+		manifest.Entries++
+		if manifest.Entries == 1 {
+			manifest.FirstSeq = entry.Seq
+			manifest.FirstPrevHash = entry.PrevHash
+		}
+		manifest.LastSeq = entry.Seq
+		manifest.LastHash = entry.Hash
+
+		_, err := io.MultiWriter(out, digest).Write(append(line, '\n'))
+		return err
+	}
+
+	for _, backup := range backups {
+		if err := readAuditBackup(backup, add); err != nil {
+			return nil, fmt.Errorf("failed to export audit log %s: %w", backup, err)
+		}
+	}
+	if size > 0 {
+		if err := readAuditFile(file.path, size, add); err != nil {
+			return nil, fmt.Errorf("failed to export audit log %s: %w", file.path, err)
+		}
+	}
+
+	manifest.GeneratedAt = time.Now().UTC()
+	manifest.SHA256 = hex.EncodeToString(digest.Sum(nil))
+	manifest.Verified = verifyErr == nil
+	if verifyErr != nil {
+		manifest.VerifyError = verifyErr.Error()
+	}
+	if err := writeAuditManifest(archive, manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// VerifyAuditArchive checks an audit log archive read from r: that audit.log matches the
+// digest, entry count and last hash of the manifest, and that its hash chain verifies with
+// key, the audit signing key when the manifest is keyed. The first entry is trusted to
+// follow a valid predecessor, as with VerifyAuditLog. It returns the manifest, and an
+// error wrapping ErrInvalidAuditArchive when the archive does not verify.
+func VerifyAuditArchive(r io.ReaderAt, size int64, key string) (*AuditManifest, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuditArchive, err)
+	}
+
+	var manifest AuditManifest
+	if err := readArchiveFile(archive, AuditArchiveManifestName, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&manifest)
+	}); err != nil {
+		return nil, err
+	}
+
+	verifier := newAuditVerifier(key)
+	digest := sha256.New()
+	if err := readArchiveFile(archive, AuditArchiveLogName, func(r io.Reader) error {
+		return readAuditLines(io.TeeReader(r, digest), verifier.add)
+	}); err != nil {
+		return &manifest, err
+	}
+
+	switch {
+	case hex.EncodeToString(digest.Sum(nil)) != manifest.SHA256:
+		return &manifest, fmt.Errorf("%w: %s does not match the digest of the manifest", ErrInvalidAuditArchive, AuditArchiveLogName)
+	case verifier.count != manifest.Entries:
+		return &manifest, fmt.Errorf("%w: %s has %d entries, the manifest %d", ErrInvalidAuditArchive, AuditArchiveLogName, verifier.count, manifest.Entries)
+	case verifier.chain.prev != manifest.LastHash:
+		return &manifest, fmt.Errorf("%w: %s does not end with the last hash of the manifest", ErrInvalidAuditArchive, AuditArchiveLogName)
+	}
+	return &manifest, nil
+}
+
+// files returns the rotated files of the audit file, oldest first, and the size of the
+// current one. Rotation happens while writing an entry, so holding the chain keeps the file
+// list and the size of the current file consistent.
+func (f *auditFileState) files() ([]string, int64, error) {
+	f.chain.mu.Lock()
+	defer f.chain.mu.Unlock()
+
+	backups, err := auditBackups(f.path)
+	var size int64
+	if info, statErr := os.Stat(f.path); statErr == nil {
+		size = info.Size()
+	} else if !os.IsNotExist(statErr) {
+		err = errors.Join(err, statErr)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit log files: %w", err)
+	}
+	return backups, size, nil
+}
+
+// auditBackups returns the rotated files of the audit file at path, oldest first. Rotated
+// files are named after the file and the time they were rotated, and may be compressed.
+func auditBackups(path string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+	// A file being compressed is listed once, uncompressed, which readAuditBackup falls back from
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if name = strings.TrimSuffix(name, ".gz"); strings.HasSuffix(name, ext) {
+			seen[filepath.Join(filepath.Dir(path), name)] = true
+		}
+	}
+
+	backups := make([]string, 0, len(seen))
+	for backup := range seen {
+		backups = append(backups, backup)
+	}
+	// The rotation times sort chronologically
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// readAuditBackup passes every line of a rotated audit file to add. Files are compressed
+// in the background after rotation, so one that is not found uncompressed is read
+// compressed, and one found neither way was removed as too old.
+func readAuditBackup(path string, add func([]byte) error) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) && !strings.HasSuffix(path, ".gz") {
+		path += ".gz"
+		file, err = os.Open(path)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return readAuditLines(r, add)
+}
+
+// readAuditFile passes the complete lines of the first size bytes of the current audit
+// file to add
+func readAuditFile(path string, size int64, add func([]byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return readAuditLines(io.LimitReader(file, size), add)
+}
+
+// readAuditLines passes every complete line of r to add, leaving out a last line still
+// being written
+func readAuditLines(r io.Reader, add func([]byte) error) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := add(bytes.TrimSuffix(line, []byte("\n"))); err != nil {
+			return err
+		}
+	}
+}
+
+// writeAuditManifest adds the manifest to archive
+func writeAuditManifest(archive *zip.Writer, manifest *AuditManifest) error {
+	out, err := archive.Create(AuditArchiveManifestName)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+// readArchiveFile passes the file of archive named name to read
+func readArchiveFile(archive *zip.Reader, name string, read func(io.Reader) error) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAuditArchive, err)
+	}
+	defer file.Close()
+	if err := read(file); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAuditArchive, err)
+	}
+	return nil
+}
@@ -13,10 +13,18 @@ func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
 	return context.WithValue(ctx, contextKey{}, logger)
 }
 
-// FromContext returns the logger from the context or the global logger if none is found
+// FromContext returns the logger from the context or the global logger if none is found,
+// carrying the trace_id and span_id of the context when it is traced
 func FromContext(ctx context.Context) *zap.Logger {
-	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
-		return logger
+	if ctx == nil {
+		return Get()
 	}
-	return Get()
+	logger, ok := ctx.Value(contextKey{}).(*zap.Logger)
+	if !ok {
+		logger = Get()
+	}
+	if fields := traceFields(ctx); fields != nil {
+		return logger.With(fields...)
+	}
+	return logger
 }
@@ -36,21 +36,21 @@ func (l *GormZapLogger) Info(ctx context.Context, msg string, data ...interface{
 	if l.logLevel < gormLogger.Info {
 		return
 	}
-	Get().WithOptions(zap.AddCallerSkip(1)).Info(fmt.Sprintf(msg, data...))
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Info(fmt.Sprintf(msg, data...))
 }
 
 func (l *GormZapLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
 	if l.logLevel < gormLogger.Warn {
 		return
 	}
-	Get().WithOptions(zap.AddCallerSkip(1)).Warn(fmt.Sprintf(msg, data...))
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Warn(fmt.Sprintf(msg, data...))
 }
 
 func (l *GormZapLogger) Error(ctx context.Context, msg string, data ...interface{}) {
 	if l.logLevel < gormLogger.Error {
 		return
 	}
-	Get().WithOptions(zap.AddCallerSkip(1)).Error(fmt.Sprintf(msg, data...))
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Error(fmt.Sprintf(msg, data...))
 }
 
 // Trace logs SQL execution details with elapsed time, rows affected, and error
@@ -64,7 +64,7 @@ func (l *GormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 
 	if err != nil {
 		if l.logLevel >= gormLogger.Error {
-			Get().WithOptions(zap.AddCallerSkip(1)).Error("gorm query error",
+			FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Error("gorm query error",
 				zap.Error(err),
 				zap.String("sql", sql),
 				zap.Int64("rows", rows),
@@ -76,7 +76,7 @@ func (l *GormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 
 	if l.cfg.SlowThreshold > 0 && elapsed > l.cfg.SlowThreshold {
 		if l.logLevel >= gormLogger.Warn {
-			Get().WithOptions(zap.AddCallerSkip(1)).Warn("gorm slow query",
+			FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Warn("gorm slow query",
 				zap.String("sql", sql),
 				zap.Int64("rows", rows),
 				zap.Duration("elapsed", elapsed),
@@ -86,7 +86,7 @@ func (l *GormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 	}
 
 	if l.logLevel >= gormLogger.Info {
-		Get().WithOptions(zap.AddCallerSkip(1)).Info("gorm query",
+		FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Info("gorm query",
 			zap.String("sql", sql),
 			zap.Int64("rows", rows),
 			zap.Duration("elapsed", elapsed),
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelStatus describes the current log level
+type LevelStatus struct {
+	Level string `json:"level"`
+	// BaseLevel is the level restored when a temporary level expires
+	BaseLevel string     `json:"base_level"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+var (
+	// atomicLevel is the level of the global logger, changed at runtime by SetLevel
+	atomicLevel = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+	levelMu     sync.Mutex
+	baseLevel   = zap.InfoLevel
+	revertTimer *time.Timer
+	expiresAt   *time.Time
+	// levelChanges tells a pending revert whether the level changed again since
+	levelChanges uint64
+)
+
+// GetLevel returns the current log level
+func GetLevel() LevelStatus {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	return levelStatus()
+}
+
+// SetLevel changes the level of the global logger without a restart. A positive ttl makes
+// the change temporary: the previous base level is restored once it passes. Without one,
+// level becomes the new base level.
+func SetLevel(level string, ttl time.Duration) (LevelStatus, error) {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return LevelStatus{}, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if revertTimer != nil {
+		revertTimer.Stop()
+		revertTimer = nil
+		expiresAt = nil
+	}
+	levelChanges++
+
+	if ttl > 0 {
+		expiry := time.Now().Add(ttl)
+		expiresAt = &expiry
+		change := levelChanges
+		revertTimer = time.AfterFunc(ttl, func() { revertLevel(change) })
+	} else {
+		baseLevel = parsed
+	}
+
+	changeLevel(parsed, Duration("ttl", ttl))
+	return levelStatus(), nil
+}
+
+// changeLevel sets the level and logs the change at whichever of the two levels is more
+// verbose, so it is not hidden by the level it moves from or to
+func changeLevel(level zapcore.Level, fields ...Field) {
+	previous := atomicLevel.Level()
+	if previous == level {
+		return
+	}
+	fields = append([]Field{String("from", previous.String()), String("to", level.String())}, fields...)
+	if level > previous {
+		Get().Warn("Log level changed", fields...)
+	}
+	atomicLevel.SetLevel(level)
+	if level < previous {
+		Get().Warn("Log level changed", fields...)
+	}
+}
+
+// revertLevel restores the base level unless the level changed again after change
+func revertLevel(change uint64) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	if levelChanges != change {
+		return
+	}
+	revertTimer = nil
+	expiresAt = nil
+
+	changeLevel(baseLevel, String("reason", "temporary level expired"))
+}
+
+func levelStatus() LevelStatus {
+	status := LevelStatus{Level: atomicLevel.Level().String(), BaseLevel: baseLevel.String()}
+	if expiresAt != nil {
+		expiry := *expiresAt
+		status.ExpiresAt = &expiry
+	}
+	return status
+}
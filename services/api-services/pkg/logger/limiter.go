@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxLimiterKeys is how many keys a Limiter tracks before dropping the expired ones
+const maxLimiterKeys = 1024
+
+// Limiter caps how often a recurring event is logged. Each key gets up to burst
+// occurrences per interval; the rest are counted so the next logged occurrence can
+// report how many were suppressed. It is safe for concurrent use.
+type Limiter struct {
+	interval time.Duration
+	burst    int
+
+	mu      sync.Mutex
+	windows map[string]*limitWindow
+}
+
+// limitWindow counts the occurrences of a key in the current interval
+type limitWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// NewLimiter creates a limiter allowing burst occurrences of a key per interval. An
+// interval of 0 allows every occurrence.
+func NewLimiter(interval time.Duration, burst int) *Limiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &Limiter{
+		interval: interval,
+		burst:    burst,
+		windows:  make(map[string]*limitWindow),
+	}
+}
+
+// Allow reports whether an occurrence of key should be logged and, when it should, how
+// many occurrences were suppressed since the last one logged.
+func (l *Limiter) Allow(key string) (bool, int) {
+	if l.interval <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	window, ok := l.windows[key]
+	if !ok {
+		if len(l.windows) >= maxLimiterKeys {
+			l.prune(now)
+		}
+		window = &limitWindow{start: now}
+		l.windows[key] = window
+	} else if now.Sub(window.start) >= l.interval {
+		window.start = now
+		window.count = 0
+	}
+
+	if window.count >= l.burst {
+		window.suppressed++
+		return false, 0
+	}
+	window.count++
+	suppressed := window.suppressed
+	window.suppressed = 0
+	return true, suppressed
+}
+
+// prune drops the keys whose interval ended without suppressed occurrences to report
+func (l *Limiter) prune(now time.Time) {
+	for key, window := range l.windows {
+		if now.Sub(window.start) >= l.interval && window.suppressed == 0 {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// globalLimiter is the limiter used by Allow, configured by InitFromConfig
+var globalLimiter atomic.Pointer[Limiter]
+
+func init() {
+	globalLimiter.Store(NewLimiter(10*time.Second, 5))
+}
+
+// Allow reports whether an occurrence of the high-frequency event key should be logged,
+// using the global limiter. Callers add the suppressed count to the entry they log:
+//
+//	if ok, suppressed := logger.Allow("redis:" + op); ok {
+//		logger.Error("Redis failed", logger.Int("suppressed", suppressed))
+//	}
+func Allow(key string) (bool, int) {
+	return globalLimiter.Load().Allow(key)
+}
+
+// SetRateLimit replaces the global limiter with one allowing burst occurrences of a key
+// per interval. Suppressed counts of the previous limiter are discarded.
+func SetRateLimit(interval time.Duration, burst int) {
+	globalLimiter.Store(NewLimiter(interval, burst))
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -17,6 +18,8 @@ import (
 var (
 	globalLogger *zap.Logger
 	once         sync.Once
+	// sink ships logs to a remote backend when one is configured
+	sink *shipper
 )
 
 // Field is a type alias for zap.Field, allowing external packages to use logger.Field
@@ -68,11 +71,15 @@ func NewLogger(cfg *LoggerConfig) (*zap.Logger, error) {
 func InitFromConfig(cfg config.LoggingConfig) error {
 	var initErr error
 	once.Do(func() {
-		logLevel, err := zap.ParseAtomicLevel(cfg.Level)
+		level, err := zapcore.ParseLevel(cfg.Level)
 		if err != nil {
 			initErr = fmt.Errorf("failed to parse log level: %w", err)
 			return
 		}
+		// The level stays adjustable at runtime through SetLevel
+		atomicLevel.SetLevel(level)
+		baseLevel = level
+		logLevel := atomicLevel
 
 		encoderConfig := zap.NewProductionEncoderConfig()
 		if cfg.Development {
@@ -97,25 +104,43 @@ func InitFromConfig(cfg config.LoggingConfig) error {
 			encoder = zapcore.NewConsoleEncoder(encoderConfig)
 		}
 
-		var writers []zapcore.WriteSyncer
-		for _, path := range cfg.OutputPaths {
-			if strings.EqualFold(path, "stdout") || strings.EqualFold(path, "stderr") {
-				writers = append(writers, zapcore.Lock(os.Stdout))
-			} else {
-				// Implement log rotation with Lumberjack for file outputs
-				lj := &lumberjack.Logger{
-					Filename:   path,
-					MaxSize:    cfg.MaxSize,
-					MaxBackups: cfg.MaxBackups,
-					MaxAge:     cfg.MaxAge,
-					Compress:   cfg.Compress,
-				}
-				writers = append(writers, zapcore.AddSync(lj))
+		syncer := newWriteSyncer(cfg.OutputPaths, cfg)
+
+		// Loki receives JSON lines whatever the local encoding is
+		sinkEncoderConfig := encoderConfig
+		sinkEncoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+		sinkEncoder := zapcore.NewJSONEncoder(sinkEncoderConfig)
+
+		if cfg.SinkType != "" {
+			sink, err = newShipper(cfg)
+			if err != nil {
+				initErr = fmt.Errorf("failed to create log sink: %w", err)
+				return
+			}
+		}
+
+		// Redaction wraps the writing cores rather than the sampler, which samples in Check
+		newCore := func(enabler zapcore.LevelEnabler) zapcore.Core {
+			core := zapcore.NewCore(encoder.Clone(), syncer, enabler)
+			if sink != nil {
+				core = zapcore.NewTee(core, newSinkCore(sink, sinkEncoder.Clone(), enabler))
 			}
+			return newRedactingCore(core, cfg.RedactKeys)
 		}
-		syncer := zapcore.NewMultiWriteSyncer(writers...)
 
-		core := zapcore.NewCore(encoder, syncer, logLevel)
+		core := newCore(logLevel)
+		if cfg.SamplingEnable {
+			core = sampledCore(newCore, logLevel, cfg)
+		}
+		SetRateLimit(cfg.RateLimitInterval, cfg.RateLimitBurst)
+
+		auditRedactor = newRedactor(cfg.RedactKeys)
+		if cfg.AuditEnable {
+			if err := initAuditLogger(cfg, encoderConfig); err != nil {
+				initErr = fmt.Errorf("failed to initialize audit log: %w", err)
+				return
+			}
+		}
 
 		options := []zap.Option{zap.ErrorOutput(zapcore.AddSync(os.Stderr))}
 		if cfg.Caller {
@@ -133,6 +158,60 @@ func InitFromConfig(cfg config.LoggingConfig) error {
 	return initErr
 }
 
+// newWriteSyncer writes to every path, rotating files with the limits of cfg
+func newWriteSyncer(paths []string, cfg config.LoggingConfig) zapcore.WriteSyncer {
+	var writers []zapcore.WriteSyncer
+	for _, path := range paths {
+		if strings.EqualFold(path, "stdout") || strings.EqualFold(path, "stderr") {
+			writers = append(writers, zapcore.Lock(os.Stdout))
+		} else {
+			// Implement log rotation with Lumberjack for file outputs
+			lj := &lumberjack.Logger{
+				Filename:   path,
+				MaxSize:    cfg.MaxSize,
+				MaxBackups: cfg.MaxBackups,
+				MaxAge:     cfg.MaxAge,
+				Compress:   cfg.Compress,
+			}
+			writers = append(writers, zapcore.AddSync(lj))
+		}
+	}
+	return zapcore.NewMultiWriteSyncer(writers...)
+}
+
+// samplingCaps are the sampler settings of one level
+type samplingCaps struct {
+	level      zapcore.Level
+	initial    int
+	thereafter int
+}
+
+// sampledCore builds a core sampling debug, info and warn entries with the caps of their
+// level. Error and above always pass so failures are never hidden by a noisy neighbour.
+func sampledCore(newCore func(zapcore.LevelEnabler) zapcore.Core, enabled zapcore.LevelEnabler, cfg config.LoggingConfig) zapcore.Core {
+	levels := []samplingCaps{
+		{zapcore.DebugLevel, cfg.SamplingDebugInitial, cfg.SamplingDebugThereafter},
+		{zapcore.InfoLevel, cfg.SamplingInfoInitial, cfg.SamplingInfoThereafter},
+		{zapcore.WarnLevel, cfg.SamplingWarnInitial, cfg.SamplingWarnThereafter},
+	}
+
+	cores := make([]zapcore.Core, 0, len(levels)+1)
+	for _, caps := range levels {
+		level := caps.level
+		only := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l == level && enabled.Enabled(l)
+		})
+		cores = append(cores, zapcore.NewSamplerWithOptions(newCore(only), cfg.SamplingTick, caps.initial, caps.thereafter))
+	}
+
+	unsampled := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= zapcore.ErrorLevel && enabled.Enabled(l)
+	})
+	cores = append(cores, newCore(unsampled))
+
+	return zapcore.NewTee(cores...)
+}
+
 func parseZapLevel(levelStr string) zapcore.Level {
 	switch strings.ToLower(levelStr) {
 	case "debug":
@@ -170,12 +249,35 @@ func InitGlobalLogger(cfg *LoggerConfig) (*zap.Logger, error) {
 
 // Sync flushes any buffered log entries
 func Sync() error {
+	if auditLogger != nil {
+		if err := auditLogger.Sync(); err != nil && !IsBrokenPipeError(err) {
+			return err
+		}
+	}
 	if globalLogger == nil {
 		return nil
 	}
 	return globalLogger.Sync()
 }
 
+// GetSinkStats returns the counters of the log sink, or false when no sink is configured
+func GetSinkStats() (SinkStats, bool) {
+	if sink == nil {
+		return SinkStats{}, false
+	}
+	return sink.stats(), true
+}
+
+// Shutdown flushes the log sink and stops it, waiting until the buffered entries are
+// shipped or ctx ends. Entries logged afterwards only reach the local outputs.
+func Shutdown(ctx context.Context) {
+	if sink == nil {
+		return
+	}
+	_ = Sync()
+	sink.close(ctx)
+}
+
 // Get returns the global logger instance
 func Get() *zap.Logger {
 	if globalLogger == nil {
@@ -214,6 +316,10 @@ func String(key, val string) zap.Field {
 	return zap.String(key, val)
 }
 
+func Strings(key string, val []string) zap.Field {
+	return zap.Strings(key, val)
+}
+
 func Int(key string, val int) zap.Field {
 	return zap.Int(key, val)
 }
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redacted replaces the value of a secret field
+const redacted = "[REDACTED]"
+
+// secretKeys are the field keys whose values are always redacted, compared case-insensitively
+var secretKeys = []string{
+	"password", "new_password", "old_password", "passwd",
+	"secret", "client_secret", "private_key",
+	"token", "access_token", "refresh_token", "id_token", "jwt",
+	"authorization", "cookie", "set_cookie",
+	"api_key", "apikey", "api_token", "otp",
+	"verification_key",
+}
+
+// emailKeys are the field keys whose values are masked as email addresses
+var emailKeys = []string{"email", "email_address", "recipient"}
+
+// Secret creates a field whose value is never written to the logs. Use it for credentials
+// and tokens whose presence, but not content, matters.
+func Secret(key, _ string) Field {
+	return zap.String(key, redacted)
+}
+
+// Email creates a field holding address with the local part masked, keeping the domain
+// and first character so entries can still be told apart.
+func Email(key, address string) Field {
+	return zap.String(key, MaskEmail(address))
+}
+
+// MaskEmail masks the local part of address except its first character, e.g.
+// "jane@example.com" becomes "j***@example.com". A value that is not an address is
+// redacted entirely.
+func MaskEmail(address string) string {
+	if address == "" {
+		return ""
+	}
+	at := strings.LastIndex(address, "@")
+	if at <= 0 || at == len(address)-1 {
+		return redacted
+	}
+	return address[:1] + "***" + address[at:]
+}
+
+// redactingCore masks the fields with known sensitive keys before they reach the wrapped
+// core, catching values logged with plain String or Any
+type redactingCore struct {
+	zapcore.Core
+	secrets map[string]bool
+	emails  map[string]bool
+}
+
+// newRedactingCore wraps core, redacting the built-in secret keys and extraKeys
+func newRedactingCore(core zapcore.Core, extraKeys []string) zapcore.Core {
+	c := newRedactor(extraKeys)
+	c.Core = core
+	return c
+}
+
+// newRedactor returns a redactingCore wrapping no core, used to redact fields that are
+// not written through one
+func newRedactor(extraKeys []string) *redactingCore {
+	c := &redactingCore{
+		secrets: make(map[string]bool, len(secretKeys)+len(extraKeys)),
+		emails:  make(map[string]bool, len(emailKeys)),
+	}
+	for _, key := range append(append([]string(nil), secretKeys...), extraKeys...) {
+		c.secrets[strings.ToLower(strings.TrimSpace(key))] = true
+	}
+	for _, key := range emailKeys {
+		c.emails[key] = true
+	}
+	return c
+}
+
+// With implements zapcore.Core
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redact(fields)), secrets: c.secrets, emails: c.emails}
+}
+
+// Check implements zapcore.Core, so the wrapped core writes through this one
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redact(fields))
+}
+
+// redact returns fields with the sensitive values masked, copying the slice only when a
+// field changes
+func (c *redactingCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, field := range fields {
+		masked, ok := c.mask(field)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = append(make([]zapcore.Field, 0, len(fields)), fields...)
+		}
+		out[i] = masked
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// mask returns the masked version of field and whether it needed one. Fields already
+// masked by Secret or Email are left alone.
+func (c *redactingCore) mask(field zapcore.Field) (zapcore.Field, bool) {
+	key := strings.ToLower(field.Key)
+	switch {
+	case c.secrets[key]:
+		if field.Type == zapcore.StringType && field.String == redacted {
+			return field, false
+		}
+		return zap.String(field.Key, redacted), true
+	case c.emails[key]:
+		if field.Type != zapcore.StringType {
+			return zap.String(field.Key, redacted), true
+		}
+		if field.String == "" || strings.Contains(field.String, "***@") || field.String == redacted {
+			return field, false
+		}
+		return zap.String(field.Key, MaskEmail(field.String)), true
+	}
+	return field, false
+}
@@ -0,0 +1,281 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// sinkRecord is a log entry waiting to be shipped
+type sinkRecord struct {
+	time    time.Time
+	level   zapcore.Level
+	message string
+	// line is the entry encoded like the local output, shipped by Loki
+	line []byte
+	// attributes are the entry fields, shipped by OTLP
+	attributes map[string]any
+}
+
+// pusher sends a batch of records to a log backend
+type pusher interface {
+	push(ctx context.Context, records []sinkRecord) error
+}
+
+// SinkStats are the counters of the log sink
+type SinkStats struct {
+	Type    string `json:"type"`
+	Shipped uint64 `json:"shipped"`
+	Dropped uint64 `json:"dropped"`
+	Failed  uint64 `json:"failed"`
+}
+
+// shipper buffers log records and pushes them in batches from a single goroutine. When the
+// buffer is full, new records are dropped instead of blocking the caller.
+type shipper struct {
+	kind      string
+	pusher    pusher
+	batchSize int
+	interval  time.Duration
+	timeout   time.Duration
+
+	mu      sync.RWMutex
+	closed  bool
+	queue   chan sinkRecord
+	flushes chan chan struct{}
+	done    chan struct{}
+
+	shipped atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+
+	// errors limits how often push failures are reported on stderr
+	errors *Limiter
+}
+
+// newShipper creates the shipper configured by cfg and starts its goroutine
+func newShipper(cfg config.LoggingConfig) (*shipper, error) {
+	client := &http.Client{Timeout: cfg.SinkTimeout}
+
+	var p pusher
+	switch cfg.SinkType {
+	case config.LogSinkLoki:
+		p = newLokiPusher(client, cfg)
+	case config.LogSinkOTLP:
+		p = newOTLPPusher(client, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported log sink type: %q", cfg.SinkType)
+	}
+
+	s := &shipper{
+		kind:      cfg.SinkType,
+		pusher:    p,
+		batchSize: cfg.SinkBatchSize,
+		interval:  cfg.SinkFlushInterval,
+		timeout:   cfg.SinkTimeout,
+		queue:     make(chan sinkRecord, cfg.SinkBufferSize),
+		flushes:   make(chan chan struct{}),
+		done:      make(chan struct{}),
+		errors:    NewLimiter(time.Minute, 1),
+	}
+	go s.run()
+	return s, nil
+}
+
+// enqueue buffers record, dropping it when the buffer is full or the shipper is closed
+func (s *shipper) enqueue(record sinkRecord) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		s.dropped.Add(1)
+		return
+	}
+	select {
+	case s.queue <- record:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// run pushes batches when they are full, on every flush interval and on request
+func (s *shipper) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	batch := make([]sinkRecord, 0, s.batchSize)
+	send := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.send(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case record, ok := <-s.queue:
+			if !ok {
+				send()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= s.batchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case ack := <-s.flushes:
+			for pending := len(s.queue); pending > 0; pending-- {
+				batch = append(batch, <-s.queue)
+				if len(batch) >= s.batchSize {
+					send()
+				}
+			}
+			send()
+			close(ack)
+		}
+	}
+}
+
+// send pushes batch, counting its records as failed when the backend rejects it. Failures
+// are reported on stderr since logging them would feed them back into the sink.
+func (s *shipper) send(batch []sinkRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	if err := s.pusher.push(ctx, batch); err != nil {
+		s.failed.Add(uint64(len(batch)))
+		if ok, suppressed := s.errors.Allow("push"); ok {
+			fmt.Fprintf(os.Stderr, "%s ERROR: failed to ship %d log entries to %s (%d failures suppressed): %v\n",
+				time.Now().Format(time.RFC3339), len(batch), s.kind, suppressed, err)
+		}
+		return
+	}
+	s.shipped.Add(uint64(len(batch)))
+}
+
+// flush pushes the buffered records, waiting at most for the sink timeout
+func (s *shipper) flush() {
+	ack := make(chan struct{})
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case s.flushes <- ack:
+	case <-s.done:
+		return
+	case <-timer.C:
+		return
+	}
+	select {
+	case <-ack:
+	case <-timer.C:
+	}
+}
+
+// close stops accepting records and waits until the buffered ones are pushed or ctx ends
+func (s *shipper) close(ctx context.Context) {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+	}
+}
+
+// stats returns the current counters
+func (s *shipper) stats() SinkStats {
+	return SinkStats{
+		Type:    s.kind,
+		Shipped: s.shipped.Load(),
+		Dropped: s.dropped.Load(),
+		Failed:  s.failed.Load(),
+	}
+}
+
+// sinkCore is the zap core feeding a shipper
+type sinkCore struct {
+	zapcore.LevelEnabler
+	shipper *shipper
+	encoder zapcore.Encoder
+	fields  []zapcore.Field
+}
+
+// newSinkCore creates a core shipping the entries enabled by enabler
+func newSinkCore(s *shipper, encoder zapcore.Encoder, enabler zapcore.LevelEnabler) zapcore.Core {
+	return &sinkCore{LevelEnabler: enabler, shipper: s, encoder: encoder}
+}
+
+// With implements zapcore.Core
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &sinkCore{
+		LevelEnabler: c.LevelEnabler,
+		shipper:      c.shipper,
+		encoder:      c.encoder.Clone(),
+		fields:       make([]zapcore.Field, 0, len(c.fields)+len(fields)),
+	}
+	clone.fields = append(append(clone.fields, c.fields...), fields...)
+	for _, field := range fields {
+		field.AddTo(clone.encoder)
+	}
+	return clone
+}
+
+// Check implements zapcore.Core
+func (c *sinkCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core
+func (c *sinkCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	record := sinkRecord{time: entry.Time, level: entry.Level, message: entry.Message}
+
+	if c.shipper.kind == config.LogSinkOTLP {
+		encoder := zapcore.NewMapObjectEncoder()
+		for _, field := range c.fields {
+			field.AddTo(encoder)
+		}
+		for _, field := range fields {
+			field.AddTo(encoder)
+		}
+		if entry.Caller.Defined {
+			encoder.AddString("caller", entry.Caller.TrimmedPath())
+		}
+		record.attributes = encoder.Fields
+	} else {
+		buf, err := c.encoder.EncodeEntry(entry, fields)
+		if err != nil {
+			return err
+		}
+		record.line = append([]byte(nil), buf.Bytes()...)
+		buf.Free()
+	}
+
+	c.shipper.enqueue(record)
+	return nil
+}
+
+// Sync implements zapcore.Core by pushing the buffered entries
+func (c *sinkCore) Sync() error {
+	c.shipper.flush()
+	return nil
+}
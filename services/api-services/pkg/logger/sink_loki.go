@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// lokiPusher ships records to the Loki push API, one stream per level
+type lokiPusher struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+	labels  map[string]string
+}
+
+// lokiStream is a stream of the Loki push API
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func newLokiPusher(client *http.Client, cfg config.LoggingConfig) *lokiPusher {
+	labels := make(map[string]string, len(cfg.SinkLabels)+1)
+	for key, value := range cfg.SinkLabels {
+		labels[key] = value
+	}
+	if cfg.SinkServiceName != "" {
+		labels["service_name"] = cfg.SinkServiceName
+	}
+	return &lokiPusher{client: client, url: cfg.SinkURL, headers: cfg.SinkHeaders, labels: labels}
+}
+
+func (p *lokiPusher) push(ctx context.Context, records []sinkRecord) error {
+	streams := make(map[zapcore.Level]*lokiStream)
+	order := make([]zapcore.Level, 0, 4)
+	for _, record := range records {
+		stream, ok := streams[record.level]
+		if !ok {
+			labels := make(map[string]string, len(p.labels)+1)
+			for key, value := range p.labels {
+				labels[key] = value
+			}
+			labels["level"] = record.level.String()
+			stream = &lokiStream{Stream: labels}
+			streams[record.level] = stream
+			order = append(order, record.level)
+		}
+		line := string(bytes.TrimRight(record.line, "\n"))
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(record.time.UnixNano(), 10), line})
+	}
+
+	body := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{Streams: make([]*lokiStream, 0, len(order))}
+	for _, level := range order {
+		body.Streams = append(body.Streams, streams[level])
+	}
+
+	return postJSON(ctx, p.client, p.url, p.headers, body)
+}
+
+// postJSON posts body as JSON to url, failing on any status but 2xx
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode log batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create log push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("log push rejected with status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// otlpScopeName identifies the records of this logger in OTLP
+const otlpScopeName = "github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+
+// otlpPusher ships records to an OTLP/HTTP collector using the JSON encoding
+type otlpPusher struct {
+	client   *http.Client
+	url      string
+	headers  map[string]string
+	resource []otlpAttribute
+}
+
+// otlpAttribute is a key-value pair of the OTLP JSON encoding
+type otlpAttribute struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+// otlpLogRecord is a log record of the OTLP JSON encoding
+type otlpLogRecord struct {
+	TimeUnixNano   string          `json:"timeUnixNano"`
+	SeverityNumber int             `json:"severityNumber"`
+	SeverityText   string          `json:"severityText"`
+	Body           map[string]any  `json:"body"`
+	Attributes     []otlpAttribute `json:"attributes,omitempty"`
+}
+
+func newOTLPPusher(client *http.Client, cfg config.LoggingConfig) *otlpPusher {
+	resource := make(map[string]any, len(cfg.SinkLabels)+1)
+	for key, value := range cfg.SinkLabels {
+		resource[key] = value
+	}
+	if cfg.SinkServiceName != "" {
+		resource["service.name"] = cfg.SinkServiceName
+	}
+	return &otlpPusher{client: client, url: cfg.SinkURL, headers: cfg.SinkHeaders, resource: otlpAttributes(resource)}
+}
+
+func (p *otlpPusher) push(ctx context.Context, records []sinkRecord) error {
+	logRecords := make([]otlpLogRecord, 0, len(records))
+	for _, record := range records {
+		number, text := otlpSeverity(record.level)
+		logRecords = append(logRecords, otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(record.time.UnixNano(), 10),
+			SeverityNumber: number,
+			SeverityText:   text,
+			Body:           map[string]any{"stringValue": record.message},
+			Attributes:     otlpAttributes(record.attributes),
+		})
+	}
+
+	body := map[string]any{
+		"resourceLogs": []map[string]any{{
+			"resource": map[string]any{"attributes": p.resource},
+			"scopeLogs": []map[string]any{{
+				"scope":      map[string]any{"name": otlpScopeName},
+				"logRecords": logRecords,
+			}},
+		}},
+	}
+
+	return postJSON(ctx, p.client, p.url, p.headers, body)
+}
+
+// otlpSeverity maps a zap level to the OTLP severity number and text
+func otlpSeverity(level zapcore.Level) (int, string) {
+	switch level {
+	case zapcore.DebugLevel:
+		return 5, "DEBUG"
+	case zapcore.InfoLevel:
+		return 9, "INFO"
+	case zapcore.WarnLevel:
+		return 13, "WARN"
+	case zapcore.ErrorLevel, zapcore.DPanicLevel:
+		return 17, "ERROR"
+	default:
+		return 21, "FATAL"
+	}
+}
+
+// otlpAttributes converts fields to OTLP attributes sorted by key
+func otlpAttributes(fields map[string]any) []otlpAttribute {
+	attributes := make([]otlpAttribute, 0, len(fields))
+	for key, value := range fields {
+		attributes = append(attributes, otlpAttribute{Key: key, Value: otlpValue(value)})
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Key < attributes[j].Key })
+	return attributes
+}
+
+// otlpValue wraps value in the OTLP any-value type closest to its Go type. Nested objects
+// and arrays are sent as JSON strings.
+func otlpValue(value any) map[string]any {
+	switch v := value.(type) {
+	case string:
+		return map[string]any{"stringValue": v}
+	case bool:
+		return map[string]any{"boolValue": v}
+	case int:
+		return map[string]any{"intValue": strconv.FormatInt(int64(v), 10)}
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return map[string]any{"intValue": fmt.Sprint(v)}
+	case float32:
+		return map[string]any{"doubleValue": float64(v)}
+	case float64:
+		return map[string]any{"doubleValue": v}
+	case time.Duration:
+		return map[string]any{"stringValue": v.String()}
+	case time.Time:
+		return map[string]any{"stringValue": v.Format(time.RFC3339Nano)}
+	case fmt.Stringer:
+		return map[string]any{"stringValue": v.String()}
+	case error:
+		return map[string]any{"stringValue": v.Error()}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return map[string]any{"stringValue": fmt.Sprint(value)}
+	}
+	return map[string]any{"stringValue": strings.TrimSpace(string(encoded))}
+}
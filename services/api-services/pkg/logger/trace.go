@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// TraceContext identifies the trace and span a log entry was emitted in
+type TraceContext struct {
+	TraceID string
+	SpanID  string
+}
+
+// TraceExtractor returns the trace of ctx, reporting false when ctx is not traced
+type TraceExtractor func(ctx context.Context) (TraceContext, bool)
+
+type traceKey struct{}
+
+// traceExtractor is the extractor registered with SetTraceExtractor
+var traceExtractor atomic.Pointer[TraceExtractor]
+
+// SetTraceExtractor makes the logger read the trace of a context with extract before
+// falling back to the trace stored by ContextWithTrace. Register one when an OpenTelemetry
+// tracer is active so entries carry the IDs of its current span, e.g. with
+// trace.SpanContextFromContext.
+func SetTraceExtractor(extract TraceExtractor) {
+	traceExtractor.Store(&extract)
+}
+
+// ContextWithTrace returns a copy of ctx carrying trace
+func ContextWithTrace(ctx context.Context, trace TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// TraceFromContext returns the trace of ctx, if any
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	if extract := traceExtractor.Load(); extract != nil {
+		if trace, ok := (*extract)(ctx); ok {
+			return trace, true
+		}
+	}
+	trace, ok := ctx.Value(traceKey{}).(TraceContext)
+	return trace, ok
+}
+
+// ParseTraceparent reads a W3C traceparent header, "00-<trace-id>-<parent-id>-<flags>".
+// It reports false when the header is malformed or carries the all-zero IDs the
+// specification declares invalid.
+func ParseTraceparent(header string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return TraceContext{}, false
+	}
+	// Later versions may append fields, version 00 has exactly four
+	if parts[0] == "00" && len(parts) != 4 {
+		return TraceContext{}, false
+	}
+	traceID, spanID := strings.ToLower(parts[1]), strings.ToLower(parts[2])
+	if len(traceID) != 32 || len(spanID) != 16 || !isHex(traceID) || !isHex(spanID) {
+		return TraceContext{}, false
+	}
+	if strings.Trim(traceID, "0") == "" || strings.Trim(spanID, "0") == "" {
+		return TraceContext{}, false
+	}
+	return TraceContext{TraceID: traceID, SpanID: spanID}, true
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// traceFields returns the trace_id and span_id fields of ctx, none when it is not traced
+func traceFields(ctx context.Context) []Field {
+	trace, ok := TraceFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return []Field{zap.String("trace_id", trace.TraceID), zap.String("span_id", trace.SpanID)}
+}
+
+// withTrace appends the trace fields of ctx to fields
+func withTrace(ctx context.Context, fields []Field) []Field {
+	trace := traceFields(ctx)
+	if trace == nil {
+		return fields
+	}
+	return append(fields[:len(fields):len(fields)], trace...)
+}
+
+// DebugCtx logs at debug level with the trace of ctx
+func DebugCtx(ctx context.Context, msg string, fields ...Field) {
+	Get().WithOptions(zap.AddCallerSkip(1)).Debug(msg, withTrace(ctx, fields)...)
+}
+
+// InfoCtx logs at info level with the trace of ctx
+func InfoCtx(ctx context.Context, msg string, fields ...Field) {
+	Get().WithOptions(zap.AddCallerSkip(1)).Info(msg, withTrace(ctx, fields)...)
+}
+
+// WarnCtx logs at warn level with the trace of ctx
+func WarnCtx(ctx context.Context, msg string, fields ...Field) {
+	Get().WithOptions(zap.AddCallerSkip(1)).Warn(msg, withTrace(ctx, fields)...)
+}
+
+// ErrorCtx logs at error level with the trace of ctx
+func ErrorCtx(ctx context.Context, msg string, fields ...Field) {
+	Get().WithOptions(zap.AddCallerSkip(1)).Error(msg, withTrace(ctx, fields)...)
+}
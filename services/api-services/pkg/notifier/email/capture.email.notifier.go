@@ -0,0 +1,176 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+)
+
+const (
+	// CaptureProviderName names the capture provider in EMAIL_DEFAULT_PROVIDER and EMAIL_PROVIDER_ORDER
+	CaptureProviderName = "capture"
+	// CaptureKeyPrefix is the storage directory captured emails are written to
+	CaptureKeyPrefix = "captured-emails/"
+)
+
+// captureIDPattern matches the IDs issued by the capture provider, keeping lookups inside
+// CaptureKeyPrefix
+var captureIDPattern = regexp.MustCompile(`^[0-9]{19}-[0-9a-f]{8}$`)
+
+// ErrCapturedEmailNotFound is returned for IDs that name no captured email
+var ErrCapturedEmailNotFound = errors.New("captured email not found")
+
+// CapturedEmail is an email stored by the capture provider instead of being sent
+type CapturedEmail struct {
+	ID         string    `json:"id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Subject    string    `json:"subject"`
+	Text       string    `json:"text"`
+	HTML       string    `json:"html,omitempty"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// CapturedEmailSummary describes a captured email without its bodies
+type CapturedEmailSummary struct {
+	ID         string    `json:"id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Subject    string    `json:"subject"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// CaptureEmailProvider implements Provider by writing rendered emails to a storage driver,
+// letting developers inspect them without a mail server. It also reads them back.
+type CaptureEmailProvider struct {
+	driver storage.Driver
+	from   string
+}
+
+// WithCaptureStorage sets the storage the capture provider writes emails to. It is
+// required when EMAIL_CAPTURE_ENABLE is set.
+func WithCaptureStorage(driver storage.Driver) ServiceOption {
+	return func(s *ServiceImpl) { s.captureStorage = driver }
+}
+
+// NewCaptureEmailProvider creates a capture provider storing emails in driver
+func NewCaptureEmailProvider(driver storage.Driver, from string) *CaptureEmailProvider {
+	return &CaptureEmailProvider{driver: driver, from: from}
+}
+
+// SendEmail stores the email under a new ID. IDs start with the capture time in
+// nanoseconds, so they sort chronologically.
+func (p *CaptureEmailProvider) SendEmail(ctx context.Context, from, to string, message *Message) error {
+	now := time.Now().UTC()
+	captured := CapturedEmail{
+		ID:         fmt.Sprintf("%019d-%s", now.UnixNano(), uuid.NewString()[:8]),
+		From:       from,
+		To:         to,
+		Subject:    message.Subject,
+		Text:       message.Text,
+		HTML:       message.HTML,
+		CapturedAt: now,
+	}
+
+	data, err := json.Marshal(captured)
+	if err != nil {
+		return fmt.Errorf("capture provider: failed to encode email: %w", err)
+	}
+	if _, err := p.driver.Upload(ctx, captureKey(captured.ID), bytes.NewReader(data), "application/json"); err != nil {
+		return fmt.Errorf("capture provider: failed to store email: %w", err)
+	}
+	return nil
+}
+
+// List returns up to limit captured emails, newest first
+func (p *CaptureEmailProvider) List(ctx context.Context, limit int) ([]CapturedEmailSummary, error) {
+	var ids []string
+	err := p.driver.Walk(ctx, func(object storage.Object) error {
+		id, ok := strings.CutPrefix(object.Key, CaptureKeyPrefix)
+		if !ok {
+			return nil
+		}
+		if id, ok = strings.CutSuffix(id, ".json"); ok && captureIDPattern.MatchString(id) {
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list captured emails: %w", err)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	summaries := make([]CapturedEmailSummary, 0, len(ids))
+	for _, id := range ids {
+		captured, err := p.Get(ctx, id)
+		if errors.Is(err, ErrCapturedEmailNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, CapturedEmailSummary{
+			ID:         captured.ID,
+			From:       captured.From,
+			To:         captured.To,
+			Subject:    captured.Subject,
+			CapturedAt: captured.CapturedAt,
+		})
+	}
+	return summaries, nil
+}
+
+// Get returns the captured email with id
+func (p *CaptureEmailProvider) Get(ctx context.Context, id string) (*CapturedEmail, error) {
+	if !captureIDPattern.MatchString(id) {
+		return nil, ErrCapturedEmailNotFound
+	}
+
+	reader, err := p.driver.Download(ctx, captureKey(id))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrCapturedEmailNotFound
+		}
+		return nil, fmt.Errorf("failed to read captured email: %w", err)
+	}
+	defer reader.Close()
+
+	var captured CapturedEmail
+	if err := json.NewDecoder(reader).Decode(&captured); err != nil {
+		return nil, fmt.Errorf("failed to decode captured email %q: %w", id, err)
+	}
+	return &captured, nil
+}
+
+// Name returns the provider name
+func (p *CaptureEmailProvider) Name() string {
+	return CaptureProviderName
+}
+
+// GetFromAddress returns the default sender address
+func (p *CaptureEmailProvider) GetFromAddress() string {
+	return p.from
+}
+
+// HealthCheck reports whether the storage driver accepts writes
+func (p *CaptureEmailProvider) HealthCheck(ctx context.Context) error {
+	return p.driver.HealthCheck(ctx)
+}
+
+// captureKey returns the storage key of the captured email with id
+func captureKey(id string) string {
+	return CaptureKeyPrefix + id + ".json"
+}
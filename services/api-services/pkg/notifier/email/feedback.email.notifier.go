@@ -0,0 +1,185 @@
+package email
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/webhookverify"
+)
+
+var (
+	// ErrSuppressed is returned instead of sending to an address that bounced or complained
+	ErrSuppressed = errors.New("recipient address is suppressed")
+	// ErrInvalidSignature is returned for webhook payloads that fail verification
+	ErrInvalidSignature = webhookverify.ErrInvalidSignature
+)
+
+// FeedbackKind is why a provider reports an address as undeliverable
+type FeedbackKind string
+
+const (
+	// FeedbackBounce is a permanent delivery failure, such as a nonexistent mailbox
+	FeedbackBounce FeedbackKind = "bounce"
+	// FeedbackComplaint is a recipient marking an email as spam
+	FeedbackComplaint FeedbackKind = "complaint"
+	// FeedbackUnsubscribe is a recipient opting out of all email
+	FeedbackUnsubscribe FeedbackKind = "unsubscribe"
+)
+
+// Feedback is a bounce, complaint or unsubscribe reported by a provider's webhook
+type Feedback struct {
+	Address    string
+	Kind       FeedbackKind
+	Provider   string
+	Detail     string
+	OccurredAt time.Time
+}
+
+// SuppressionList tells whether an address must no longer receive email
+type SuppressionList interface {
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+}
+
+// ServiceOption configures the service created by NewEmailService
+type ServiceOption func(*ServiceImpl)
+
+// WithSuppressionList makes the service refuse to send to suppressed addresses with
+// ErrSuppressed. Lookups that fail let the email through.
+func WithSuppressionList(list SuppressionList) ServiceOption {
+	return func(s *ServiceImpl) { s.suppressions = list }
+}
+
+// ParseSendGridPublicKey parses the base64 encoded verification key of SendGrid's signed
+// event webhook
+func ParseSendGridPublicKey(encoded string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode SendGrid public key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SendGrid public key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("SendGrid public key is not an ECDSA key")
+	}
+	return ecdsaKey, nil
+}
+
+// VerifySendGridSignature checks the X-Twilio-Email-Event-Webhook-Signature and -Timestamp
+// headers of a SendGrid event webhook against its raw payload
+func VerifySendGridSignature(key *ecdsa.PublicKey, payload []byte, signature, timestamp string) error {
+	return webhookverify.VerifySendGrid(key, timestamp, signature, payload, time.Now())
+}
+
+// sendGridEvent is the part of a SendGrid event webhook entry used here
+type sendGridEvent struct {
+	Email     string `json:"email"`
+	Event     string `json:"event"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ParseSendGridEvents extracts the bounces, spam reports and global unsubscribes from a
+// SendGrid event webhook payload. Blocked messages are temporary failures and are ignored.
+func ParseSendGridEvents(payload []byte) ([]Feedback, error) {
+	var events []sendGridEvent
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return nil, fmt.Errorf("invalid SendGrid payload: %w", err)
+	}
+
+	var feedback []Feedback
+	for _, event := range events {
+		var kind FeedbackKind
+		switch {
+		case event.Event == "bounce" && event.Type != "blocked":
+			kind = FeedbackBounce
+		case event.Event == "spamreport":
+			kind = FeedbackComplaint
+		case event.Event == "unsubscribe":
+			kind = FeedbackUnsubscribe
+		default:
+			continue
+		}
+		feedback = append(feedback, Feedback{
+			Address:    event.Email,
+			Kind:       kind,
+			Provider:   "sendgrid",
+			Detail:     event.Reason,
+			OccurredAt: time.Unix(event.Timestamp, 0).UTC(),
+		})
+	}
+	return feedback, nil
+}
+
+// mailgunWebhook is the part of a Mailgun webhook payload used here
+type mailgunWebhook struct {
+	Signature struct {
+		Timestamp string `json:"timestamp"`
+		Token     string `json:"token"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+	EventData struct {
+		Event          string  `json:"event"`
+		Severity       string  `json:"severity"`
+		Recipient      string  `json:"recipient"`
+		Reason         string  `json:"reason"`
+		Timestamp      float64 `json:"timestamp"`
+		DeliveryStatus struct {
+			Description string `json:"description"`
+			Message     string `json:"message"`
+		} `json:"delivery-status"`
+	} `json:"event-data"`
+}
+
+// ParseMailgunWebhook verifies a Mailgun webhook payload with the webhook signing key and
+// extracts a permanent failure, complaint or unsubscribe. Other events yield no feedback.
+func ParseMailgunWebhook(signingKey string, payload []byte) ([]Feedback, error) {
+	var webhook mailgunWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, fmt.Errorf("invalid Mailgun payload: %w", err)
+	}
+
+	signature := webhook.Signature
+	if err := webhookverify.VerifyMailgun(signingKey, signature.Timestamp, signature.Token, signature.Signature, time.Now()); err != nil {
+		return nil, err
+	}
+
+	event := webhook.EventData
+	var kind FeedbackKind
+	switch {
+	case event.Event == "failed" && event.Severity == "permanent":
+		kind = FeedbackBounce
+	case event.Event == "complained":
+		kind = FeedbackComplaint
+	case event.Event == "unsubscribed":
+		kind = FeedbackUnsubscribe
+	default:
+		return nil, nil
+	}
+
+	detail := event.DeliveryStatus.Description
+	if detail == "" {
+		detail = event.DeliveryStatus.Message
+	}
+	if detail == "" {
+		detail = event.Reason
+	}
+	seconds := int64(event.Timestamp)
+	return []Feedback{{
+		Address:    event.Recipient,
+		Kind:       kind,
+		Provider:   "mailgun",
+		Detail:     detail,
+		OccurredAt: time.Unix(seconds, 0).UTC(),
+	}}, nil
+}
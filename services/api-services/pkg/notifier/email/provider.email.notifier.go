@@ -8,17 +8,24 @@ import (
 	"time"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/storage"
+)
+
+const (
+	// providerFailureThreshold consecutive failures take a provider out of the failover order
+	providerFailureThreshold = 3
+	// providerCooldown is how long a failing provider is skipped before it is tried again
+	providerCooldown = time.Minute
 )
 
 // NoopService implements EmailService but does nothing. (non-nil EmailService interface)
 type NoopService struct{}
 
-// BasicTemplateRenderer is a simple implementation for demonstration.
-type BasicTemplateRenderer struct{}
-
 // Provider defines the interface for email sending providers
 type Provider interface {
-	SendEmail(ctx context.Context, from, to, subject, body string) error
+	SendEmail(ctx context.Context, from, to string, message *Message) error
 	Name() string
 	GetFromAddress() string
 	HealthCheck(ctx context.Context) error
@@ -27,8 +34,12 @@ type Provider interface {
 // Service manages multiple email providers with failover
 type Service interface {
 	SendEmail(ctx context.Context, to, subject, body string) error
-	SendTemplatedEmail(ctx context.Context, to, templateContent, templateSubject string, templateData map[string]string) error
+	// SendTemplatedEmail renders the named template in the variant closest to locale,
+	// the default one when locale is empty, and sends it
+	SendTemplatedEmail(ctx context.Context, to, templateName, locale string, data any) error
 	HealthCheck(ctx context.Context) error
+	// Stats returns the send counters and circuit state of every provider
+	Stats() []ProviderStats
 }
 
 type ServiceImpl struct {
@@ -37,20 +48,24 @@ type ServiceImpl struct {
 	failoverOrder       []string
 	cfg                 *config.EmailConfig
 	templateRenderer    TemplateRenderer
-}
-
-// TemplateRenderer defines an interface for rendering email templates.
-type TemplateRenderer interface {
-	Render(templateContent string, data map[string]string) (string, error)
+	breakers            map[string]*resilience.Breaker
+	counters            map[string]*providerCounters
+	suppressions        SuppressionList
+	captureStorage      storage.Driver
 }
 
 // NewEmailService creates a new EmailService with multiple providers based on the application configuration.
-func NewEmailService(cfg *config.EmailConfig) (Service, error) {
+func NewEmailService(cfg *config.EmailConfig, opts ...ServiceOption) (Service, error) {
 	if !cfg.Enable {
 		log.Printf("INFO: Email service is globally disabled by configuration (EMAIL_ENABLE=false).")
 		return nil, nil
 	}
 
+	service := &ServiceImpl{cfg: cfg}
+	for _, opt := range opts {
+		opt(service)
+	}
+
 	providersMap := make(map[string]Provider)
 
 	if cfg.SMTP.Enable {
@@ -65,6 +80,15 @@ func NewEmailService(cfg *config.EmailConfig) (Service, error) {
 		log.Printf("INFO: SMTP Email Provider enabled and initialized.")
 	}
 
+	if cfg.Capture.Enable {
+		if service.captureStorage == nil {
+			return nil, fmt.Errorf("email capture is enabled but no storage driver was provided")
+		}
+		captureProvider := NewCaptureEmailProvider(service.captureStorage, cfg.DefaultFromAddress)
+		providersMap[captureProvider.Name()] = captureProvider
+		log.Printf("INFO: Capture Email Provider enabled; emails are stored instead of sent.")
+	}
+
 	if len(providersMap) == 0 {
 		return nil, fmt.Errorf("no email providers enabled in configuration")
 	}
@@ -117,32 +141,55 @@ func NewEmailService(cfg *config.EmailConfig) (Service, error) {
 		return nil, fmt.Errorf("no active email providers available after processing configuration")
 	}
 
-	return &ServiceImpl{
-		defaultProviderName: cfg.DefaultProvider,
-		providersMap:        providersMap,
-		failoverOrder:       failoverOrder,
-		cfg:                 cfg,
-		templateRenderer:    &BasicTemplateRenderer{},
-	}, nil
-}
+	templateRenderer, err := NewHTMLTemplateRenderer(cfg.ProductName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email templates: %w", err)
+	}
 
-func (etr *BasicTemplateRenderer) Render(templateContent string, data map[string]string) (string, error) {
-	renderedContent := templateContent
-	for key, value := range data {
-		renderedContent = strings.ReplaceAll(renderedContent, fmt.Sprintf("{{.%s}}", key), value)
+	breakers := make(map[string]*resilience.Breaker, len(providersMap))
+	counters := make(map[string]*providerCounters, len(providersMap))
+	for name := range providersMap {
+		breakers[name] = resilience.NewBreaker(resilience.BreakerOptions{
+			Name:      "email:" + name,
+			Threshold: providerFailureThreshold,
+			Timeout:   providerCooldown,
+		})
+		counters[name] = &providerCounters{}
 	}
-	return renderedContent, nil
+
+	service.defaultProviderName = cfg.DefaultProvider
+	service.providersMap = providersMap
+	service.failoverOrder = failoverOrder
+	service.templateRenderer = templateRenderer
+	service.breakers = breakers
+	service.counters = counters
+	return service, nil
 }
 
-// SendEmail attempts to send an email using the configured providers with a failover mechanism.
+// SendEmail attempts to send a plaintext email using the configured providers with a failover mechanism.
 func (s *ServiceImpl) SendEmail(ctx context.Context, to, subject, body string) error {
-	fromAddress := s.cfg.DefaultFromAddress
-
 	if s == nil || s.cfg == nil || !s.cfg.Enable {
 		log.Printf("WARN: Attempted to send email but email service is globally disabled or not initialized.")
 		return fmt.Errorf("email service is disabled")
 	}
 
+	return s.send(ctx, to, &Message{Subject: subject, Text: body})
+}
+
+// send delivers message through the first provider that accepts it
+func (s *ServiceImpl) send(ctx context.Context, to string, message *Message) error {
+	fromAddress := s.cfg.DefaultFromAddress
+
+	if s.suppressions != nil {
+		suppressed, err := s.suppressions.IsSuppressed(ctx, to)
+		if err != nil {
+			log.Printf("WARN: Failed to check the suppression list for %s, sending anyway: %v", logger.MaskEmail(to), err)
+		} else if suppressed {
+			log.Printf("INFO: Not sending email to suppressed address %s.", logger.MaskEmail(to))
+			return ErrSuppressed
+		}
+	}
+
 	for _, providerName := range s.failoverOrder {
 		provider, ok := s.providersMap[providerName]
 		if !ok {
@@ -155,37 +202,48 @@ func (s *ServiceImpl) SendEmail(ctx context.Context, to, subject, body string) e
 			fromAddress = providerFrom
 		}
 
-		log.Printf("INFO: Attempting to send email to %s using %s provider (From: %s).", to, provider.Name(), fromAddress)
+		breaker := s.breakers[providerName]
+		counters := s.counters[providerName]
+		if err := breaker.Allow(); err != nil {
+			log.Printf("WARN: Skipping %s provider, its circuit breaker is open.", provider.Name())
+			counters.skipped.Add(1)
+			continue
+		}
+
+		log.Printf("INFO: Attempting to send email to %s using %s provider (From: %s).", logger.MaskEmail(to), provider.Name(), fromAddress)
 
 		sendCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		err := provider.SendEmail(sendCtx, fromAddress, to, subject, body)
+		start := time.Now()
+		err := provider.SendEmail(sendCtx, fromAddress, to, message)
 		cancel()
+		counters.record(err, time.Since(start))
+		breaker.Record(err)
 
 		if err == nil {
-			log.Printf("INFO: Email successfully sent to %s using %s provider.", to, provider.Name())
+			log.Printf("INFO: Email successfully sent to %s using %s provider.", logger.MaskEmail(to), provider.Name())
 			return nil
 		}
 		log.Printf("ERROR: Failed to send email via %s: %v", provider.Name(), err)
 	}
 
-	return fmt.Errorf("all configured email providers failed to send email to %s", to)
+	return fmt.Errorf("all configured email providers failed to send email to %s", logger.MaskEmail(to))
 }
 
-// SendTemplatedEmail renders the template and then sends the email.
-func (s *ServiceImpl) SendTemplatedEmail(ctx context.Context, to, templateContent, templateSubject string, templateData map[string]string) error {
+// SendTemplatedEmail renders the named template in locale with data and then sends the
+// email with its HTML body and plaintext alternative.
+func (s *ServiceImpl) SendTemplatedEmail(ctx context.Context, to, templateName, locale string, data any) error {
 	if s == nil || s.cfg == nil || !s.cfg.Enable {
 		log.Printf("WARN: Attempted to send templated email but email service is globally disabled or not initialized.")
 		return fmt.Errorf("email service is disabled")
 	}
 
-	log.Printf("INFO: Sending templated email to %s with subject: %s", to, templateSubject)
-
-	renderedBody, err := s.templateRenderer.Render(templateContent, templateData)
+	message, err := s.templateRenderer.Render(templateName, locale, data)
 	if err != nil {
 		return fmt.Errorf("failed to render email template: %w", err)
 	}
 
-	return s.SendEmail(ctx, to, templateSubject, renderedBody)
+	log.Printf("INFO: Sending %s email to %s with subject: %s", templateName, logger.MaskEmail(to), message.Subject)
+	return s.send(ctx, to, message)
 }
 
 func (s *ServiceImpl) HealthCheck(ctx context.Context) error {
@@ -221,11 +279,11 @@ func (n *NoopService) HealthCheck(ctx context.Context) error {
 }
 
 func (n *NoopService) SendEmail(ctx context.Context, to, subject, body string) error {
-	log.Printf("DEBUG: SendEmail (no-op) called for %s", to)
+	log.Printf("DEBUG: SendEmail (no-op) called for %s", logger.MaskEmail(to))
 	return nil
 }
 
-func (n *NoopService) SendTemplatedEmail(ctx context.Context, to, templateContent, templateSubject string, templateData map[string]string) error {
-	log.Printf("DEBUG: SendTemplatedEmail (no-op) called for %s", to)
+func (n *NoopService) SendTemplatedEmail(ctx context.Context, to, templateName, locale string, data any) error {
+	log.Printf("DEBUG: SendTemplatedEmail (no-op) called for %s", logger.MaskEmail(to))
 	return nil
 }
@@ -0,0 +1,324 @@
+package email
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/webhookverify"
+)
+
+const (
+	// snsTypeNotification carries an SES notification
+	snsTypeNotification = "Notification"
+	// snsTypeSubscriptionConfirmation asks the endpoint to confirm a new subscription
+	snsTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	// snsTypeUnsubscribeConfirmation reports that the subscription was removed
+	snsTypeUnsubscribeConfirmation = "UnsubscribeConfirmation"
+
+	// snsMaxCertificateSize bounds the signing certificate download
+	snsMaxCertificateSize = 64 << 10
+)
+
+// snsHostPattern matches the hosts SNS signing certificates and subscription URLs live on
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// SNSMessage is an Amazon SNS HTTP delivery, through which SES publishes bounces and complaints
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicARN         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// SNSVerifier verifies SNS deliveries against their AWS signing certificates, which it
+// downloads once per URL, and confirms subscriptions.
+type SNSVerifier struct {
+	client    *http.Client
+	topicARNs map[string]struct{}
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewSNSVerifier creates a verifier accepting deliveries from topicARNs only; with none
+// given every delivery is rejected
+func NewSNSVerifier(topicARNs []string) *SNSVerifier {
+	allowed := make(map[string]struct{}, len(topicARNs))
+	for _, arn := range topicARNs {
+		if arn = strings.TrimSpace(arn); arn != "" {
+			allowed[arn] = struct{}{}
+		}
+	}
+	return &SNSVerifier{
+		client:    httpclient.New(httpclient.Options{Name: "sns", Timeout: 10 * time.Second}),
+		topicARNs: allowed,
+		certs:     make(map[string]*x509.Certificate),
+	}
+}
+
+// ParseSNSMessage decodes and verifies an SNS delivery
+func (v *SNSVerifier) ParseSNSMessage(ctx context.Context, payload []byte) (*SNSMessage, error) {
+	var message SNSMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, fmt.Errorf("invalid SNS payload: %w", err)
+	}
+	if err := v.checkTopic(&message); err != nil {
+		return nil, err
+	}
+	if err := v.verify(ctx, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// ConfirmSubscription visits the subscribe URL of a verified subscription confirmation.
+// Other message types need no confirmation.
+func (v *SNSVerifier) ConfirmSubscription(ctx context.Context, message *SNSMessage) error {
+	if message.Type != snsTypeSubscriptionConfirmation {
+		return nil
+	}
+	if err := v.checkTopic(message); err != nil {
+		return err
+	}
+	if err := checkSNSURL(message.SubscribeURL); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, message.SubscribeURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build SNS subscription confirmation: %w", err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to confirm SNS subscription: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm SNS subscription: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// checkTopic rejects messages published to a topic outside the allowlist
+func (v *SNSVerifier) checkTopic(message *SNSMessage) error {
+	if _, ok := v.topicARNs[message.TopicARN]; !ok {
+		return fmt.Errorf("%w: unexpected topic %s", ErrInvalidSignature, message.TopicARN)
+	}
+	return nil
+}
+
+// verify checks the signature of message with the certificate it points to
+func (v *SNSVerifier) verify(ctx context.Context, message *SNSMessage) error {
+	canonical, err := snsStringToSign(message)
+	if err != nil {
+		return err
+	}
+	cert, err := v.certificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: SNS certificate has no RSA key", ErrInvalidSignature)
+	}
+	return webhookverify.VerifySNS(key, message.SignatureVersion, message.Signature, canonical)
+}
+
+// certificate returns the signing certificate at rawURL, downloading it on first use
+func (v *SNSVerifier) certificate(ctx context.Context, rawURL string) (*x509.Certificate, error) {
+	if err := checkSNSURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	cert, ok := v.certs[rawURL]
+	v.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SNS certificate request: %w", err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download SNS certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download SNS certificate: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, snsMaxCertificateSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SNS certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("SNS certificate is not PEM encoded")
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SNS certificate: %w", err)
+	}
+	if time.Now().After(cert.NotAfter) {
+		return nil, fmt.Errorf("%w: SNS certificate expired", ErrInvalidSignature)
+	}
+
+	v.mu.Lock()
+	v.certs[rawURL] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+// checkSNSURL only lets through HTTPS URLs on SNS hosts, so a forged message cannot make
+// the verifier fetch an attacker's certificate or call arbitrary URLs
+func checkSNSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || !snsHostPattern.MatchString(u.Hostname()) {
+		return fmt.Errorf("%w: untrusted SNS URL %q", ErrInvalidSignature, rawURL)
+	}
+	return nil
+}
+
+// snsStringToSign builds the canonical string SNS signs for the message type
+func snsStringToSign(message *SNSMessage) (string, error) {
+	var fields [][2]string
+	switch message.Type {
+	case snsTypeNotification:
+		fields = [][2]string{{"Message", message.Message}, {"MessageId", message.MessageID}}
+		if message.Subject != "" {
+			fields = append(fields, [2]string{"Subject", message.Subject})
+		}
+		fields = append(fields,
+			[2]string{"Timestamp", message.Timestamp},
+			[2]string{"TopicArn", message.TopicARN},
+			[2]string{"Type", message.Type},
+		)
+	case snsTypeSubscriptionConfirmation, snsTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", message.Message},
+			{"MessageId", message.MessageID},
+			{"SubscribeURL", message.SubscribeURL},
+			{"Timestamp", message.Timestamp},
+			{"Token", message.Token},
+			{"TopicArn", message.TopicARN},
+			{"Type", message.Type},
+		}
+	default:
+		return "", fmt.Errorf("%w: unsupported SNS message type %q", ErrInvalidSignature, message.Type)
+	}
+
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(field[0])
+		builder.WriteByte('\n')
+		builder.WriteString(field[1])
+		builder.WriteByte('\n')
+	}
+	return builder.String(), nil
+}
+
+// sesNotification is the part of an SES bounce or complaint notification used here. SES
+// names the type notificationType for notifications and eventType for event publishing.
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		Timestamp         string `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		Timestamp             string `json:"timestamp"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+}
+
+// ParseSESNotification extracts permanent bounces and complaints from the message of an
+// SNS notification published by SES. Transient bounces and other events yield no feedback.
+func ParseSESNotification(message *SNSMessage) ([]Feedback, error) {
+	if message.Type != snsTypeNotification {
+		return nil, nil
+	}
+
+	var notification sesNotification
+	if err := json.Unmarshal([]byte(message.Message), &notification); err != nil {
+		return nil, fmt.Errorf("invalid SES notification: %w", err)
+	}
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+
+	var feedback []Feedback
+	switch notificationType {
+	case "Bounce":
+		bounce := notification.Bounce
+		if bounce.BounceType != "Permanent" {
+			return nil, nil
+		}
+		occurredAt := parseSESTime(bounce.Timestamp)
+		for _, recipient := range bounce.BouncedRecipients {
+			detail := recipient.DiagnosticCode
+			if detail == "" {
+				detail = bounce.BounceSubType
+			}
+			feedback = append(feedback, Feedback{
+				Address:    recipient.EmailAddress,
+				Kind:       FeedbackBounce,
+				Provider:   "ses",
+				Detail:     detail,
+				OccurredAt: occurredAt,
+			})
+		}
+	case "Complaint":
+		complaint := notification.Complaint
+		occurredAt := parseSESTime(complaint.Timestamp)
+		for _, recipient := range complaint.ComplainedRecipients {
+			feedback = append(feedback, Feedback{
+				Address:    recipient.EmailAddress,
+				Kind:       FeedbackComplaint,
+				Provider:   "ses",
+				Detail:     complaint.ComplaintFeedbackType,
+				OccurredAt: occurredAt,
+			})
+		}
+	}
+	return feedback, nil
+}
+
+// parseSESTime parses an SES timestamp, falling back to now
+func parseSESTime(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC()
+	}
+	return time.Now().UTC()
+}
@@ -31,7 +31,7 @@ func NewSMTPEmailProvider(host string, port int, username, password, from string
 }
 
 // SendEmail builds and dispatches an email using go-mail.
-func (p *SMTPEmailProvider) SendEmail(ctx context.Context, from, to, subject, body string) error {
+func (p *SMTPEmailProvider) SendEmail(ctx context.Context, from, to string, message *Message) error {
 	msg := mail.NewMsg()
 	if err := msg.From(from); err != nil {
 		return fmt.Errorf("smtp provider: invalid From address: %w", err)
@@ -39,8 +39,11 @@ func (p *SMTPEmailProvider) SendEmail(ctx context.Context, from, to, subject, bo
 	if err := msg.To(to); err != nil {
 		return fmt.Errorf("smtp provider: invalid To address: %w", err)
 	}
-	msg.Subject(subject)
-	msg.SetBodyString(mail.TypeTextPlain, body)
+	msg.Subject(message.Subject)
+	msg.SetBodyString(mail.TypeTextPlain, message.Text)
+	if message.HTML != "" {
+		msg.AddAlternativeString(mail.TypeTextHTML, message.HTML)
+	}
 
 	opts := []mail.Option{
 		mail.WithPort(p.port),
@@ -68,8 +71,8 @@ func (p *SMTPEmailProvider) SendEmail(ctx context.Context, from, to, subject, bo
 		return fmt.Errorf("smtp provider: failed to send: %w", err)
 	}
 
-	// log.Printf("INFO: [SMTP] Sent email from %s to %s with subject \"%s\"", from, to, subject)
-	// log.Printf("DEBUG: [SMTP] Body: %s", body)
+	// log.Printf("INFO: [SMTP] Sent email from %s to %s with subject \"%s\"", from, to, message.Subject)
+	// log.Printf("DEBUG: [SMTP] Body: %s", message.Text)
 	return nil
 }
 
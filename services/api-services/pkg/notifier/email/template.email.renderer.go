@@ -0,0 +1,396 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Names of the embedded email templates
+const (
+	TemplateOTP           = "otp"
+	TemplatePasswordReset = "password_reset"
+	TemplateIncidentAlert = "incident_alert"
+	TemplateInvitation    = "invitation"
+	TemplateUptimeReport  = "uptime_report"
+	TemplateSLAAlert      = "sla_alert"
+)
+
+// OTPData is the data of the otp and password_reset templates
+type OTPData struct {
+	Code      string
+	ExpiresIn time.Duration
+}
+
+// IncidentAlertData is the data of the incident_alert template
+type IncidentAlertData struct {
+	MonitorName string
+	Status      string
+	Reason      string
+	URL         string
+	StartedAt   time.Time
+}
+
+// InvitationData is the data of the invitation template
+type InvitationData struct {
+	InviterName      string
+	OrganizationName string
+	URL              string
+	ExpiresIn        time.Duration
+}
+
+// UptimeReportData is the data of the uptime_report template. From and To are the first
+// and last days covered; Availability and SLATarget are percentages.
+type UptimeReportData struct {
+	OrganizationName   string
+	Frequency          string
+	From               time.Time
+	To                 time.Time
+	MonitorCount       int
+	Availability       float64
+	SLATarget          float64
+	MonitorsMeetingSLA int
+	TopIncidents       []ReportMonitor
+	SlowestMonitors    []ReportMonitor
+	UnsubscribeURL     string
+}
+
+// ReportMonitor is a monitor listed in an uptime report
+type ReportMonitor struct {
+	Name         string
+	Availability float64
+	Downtime     time.Duration
+	P95LatencyMs float64
+}
+
+// SLAAlertData is the data of the sla_alert template. Status is at_risk or breached;
+// Target, Availability and BudgetConsumed are percentages, BudgetConsumed of the error
+// budget of the period that starts at PeriodStart.
+type SLAAlertData struct {
+	OrganizationName string
+	TargetName       string
+	Status           string
+	Target           float64
+	Period           string
+	PeriodStart      time.Time
+	Availability     float64
+	BudgetConsumed   float64
+	BurnRate         float64
+}
+
+// Message is a rendered email with an HTML body and its plaintext alternative
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// TemplateRenderer defines an interface for rendering email templates.
+type TemplateRenderer interface {
+	// Render renders the template called name in the variant closest to locale, a BCP 47
+	// tag such as "fr" or "pt-BR". An empty or unknown locale selects the default variant.
+	Render(name, locale string, data any) (*Message, error)
+}
+
+// defaultTemplateLocale is the language of the templates at the root of templates/
+const defaultTemplateLocale = "en"
+
+// HTMLTemplateRenderer renders the embedded templates. Every email is a pair of files in
+// templates/: name.txt defines the "subject" and the plaintext "content", name.html the
+// HTML "content". Contents are wrapped in the layout of their kind from templates/layouts
+// and may use the partials from templates/partials. HTML is escaped by html/template.
+//
+// Translations live in templates/locales/<locale>/, which holds name.txt and name.html
+// pairs and may override partials in its own partials/ directory. Templates without a
+// translation fall back to the default variant.
+type HTMLTemplateRenderer struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// NewHTMLTemplateRenderer parses every embedded template and its translations.
+// productName is shown in the layouts and available to templates as {{appName}}.
+func NewHTMLTemplateRenderer(productName string) (*HTMLTemplateRenderer, error) {
+	r := &HTMLTemplateRenderer{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+
+	names, err := fs.Glob(templateFS, "templates/*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	for _, file := range names {
+		name := strings.TrimSuffix(path.Base(file), ".txt")
+		if err := r.parse(productName, "", name, "templates", nil); err != nil {
+			return nil, err
+		}
+	}
+
+	dirs, err := fs.Glob(templateFS, "templates/locales/*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email template locales: %w", err)
+	}
+	for _, dir := range dirs {
+		locale := strings.ToLower(path.Base(dir))
+		overrides, err := fs.Glob(templateFS, dir+"/partials/*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list email partials of %s: %w", locale, err)
+		}
+		for _, file := range names {
+			name := strings.TrimSuffix(path.Base(file), ".txt")
+			if _, err := fs.Stat(templateFS, dir+"/"+name+".txt"); err != nil {
+				continue
+			}
+			if err := r.parse(productName, locale, name, dir, overrides); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+// parse parses the variant of the template called name found in dir. overrides are the
+// partials of the locale, parsed after the default partials so they replace them.
+func (r *HTMLTemplateRenderer) parse(productName, locale, name, dir string, overrides []string) error {
+	funcs := templateFuncs(productName, locale)
+	withOverrides := func(ext string, files ...string) []string {
+		for _, override := range overrides {
+			if path.Ext(override) == ext {
+				files = append(files, override)
+			}
+		}
+		return files
+	}
+
+	textFiles := withOverrides(".txt", "templates/layouts/base.txt", "templates/partials/*.txt")
+	textFile := dir + "/" + name + ".txt"
+	text, err := texttemplate.New(name).Funcs(funcs).Option("missingkey=error").
+		ParseFS(templateFS, append(textFiles, textFile)...)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template %s: %w", textFile, err)
+	}
+	if text.Lookup("subject") == nil || text.Lookup("content") == nil {
+		return fmt.Errorf("email template %s must define subject and content", textFile)
+	}
+
+	htmlFiles := withOverrides(".html", "templates/layouts/base.html", "templates/partials/*.html")
+	htmlFile := dir + "/" + name + ".html"
+	html, err := htmltemplate.New(name).Funcs(funcs).Option("missingkey=error").
+		ParseFS(templateFS, append(htmlFiles, htmlFile)...)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template %s: %w", htmlFile, err)
+	}
+	if html.Lookup("content") == nil {
+		return fmt.Errorf("email template %s must define content", htmlFile)
+	}
+
+	r.text[variantKey(locale, name)] = text
+	r.html[variantKey(locale, name)] = html
+	return nil
+}
+
+// Render renders the subject, plaintext and HTML bodies of the template called name,
+// trying locale, then its base language, then the default variant
+func (r *HTMLTemplateRenderer) Render(name, locale string, data any) (*Message, error) {
+	var key string
+	for _, candidate := range localeCandidates(locale) {
+		if _, ok := r.text[variantKey(candidate, name)]; ok {
+			key = variantKey(candidate, name)
+			break
+		}
+	}
+	text, ok := r.text[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	if err := text.ExecuteTemplate(&body, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render plaintext body of %s: %w", name, err)
+	}
+	if err := r.html[key].ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render HTML body of %s: %w", name, err)
+	}
+
+	return &Message{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+// variantKey identifies the variant of the template called name in locale, the default
+// variant having an empty locale
+func variantKey(locale, name string) string {
+	if locale == "" {
+		return name
+	}
+	return locale + "/" + name
+}
+
+// localeCandidates lists the variants to try for locale, most specific first: "pt-BR"
+// yields "pt-br", "pt" and the default variant
+func localeCandidates(locale string) []string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if locale == "" {
+		return []string{""}
+	}
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+	return append(candidates, "")
+}
+
+// localeFormat holds the unit names, singular and plural, and the layouts used by the
+// duration, datetime and date functions of one language
+type localeFormat struct {
+	minute, hour, day [2]string
+	dateLayout        string
+	dayLayout         string
+}
+
+// localeFormats are keyed by base language; other languages use the English format
+var localeFormats = map[string]localeFormat{
+	"en": {
+		minute:     [2]string{"minute", "minutes"},
+		hour:       [2]string{"hour", "hours"},
+		day:        [2]string{"day", "days"},
+		dateLayout: "Jan 2, 2006 15:04 UTC",
+		dayLayout:  "Jan 2, 2006",
+	},
+	"es": {
+		minute:     [2]string{"minuto", "minutos"},
+		hour:       [2]string{"hora", "horas"},
+		day:        [2]string{"día", "días"},
+		dateLayout: "02/01/2006 15:04 UTC",
+		dayLayout:  "02/01/2006",
+	},
+	"fr": {
+		minute:     [2]string{"minute", "minutes"},
+		hour:       [2]string{"heure", "heures"},
+		day:        [2]string{"jour", "jours"},
+		dateLayout: "02/01/2006 15:04 UTC",
+		dayLayout:  "02/01/2006",
+	},
+}
+
+// formatFor returns the format of the language of locale
+func formatFor(locale string) localeFormat {
+	for _, candidate := range localeCandidates(locale) {
+		if format, ok := localeFormats[candidate]; ok {
+			return format
+		}
+	}
+	return localeFormats[defaultTemplateLocale]
+}
+
+// templateFuncs are the functions available to every template of locale. Data may come
+// decoded from JSON, so durations and times also accept numbers and strings.
+func templateFuncs(productName, locale string) map[string]any {
+	if locale == "" {
+		locale = defaultTemplateLocale
+	}
+	format := formatFor(locale)
+	return map[string]any{
+		"appName": func() string { return productName },
+		"locale":  func() string { return locale },
+		"year":    func() int { return time.Now().Year() },
+		"dict": func(pairs ...any) (map[string]any, error) {
+			if len(pairs)%2 != 0 {
+				return nil, fmt.Errorf("dict requires key and value pairs")
+			}
+			values := make(map[string]any, len(pairs)/2)
+			for i := 0; i < len(pairs); i += 2 {
+				key, ok := pairs[i].(string)
+				if !ok {
+					return nil, fmt.Errorf("dict keys must be strings")
+				}
+				values[key] = pairs[i+1]
+			}
+			return values, nil
+		},
+		"duration": format.duration,
+		"datetime": format.datetime,
+		"date":     format.date,
+	}
+}
+
+// duration renders a duration in whole minutes, hours or days, e.g. "15 minutes"
+func (f localeFormat) duration(value any) (string, error) {
+	var d time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		d = v
+	case int64:
+		d = time.Duration(v)
+	case int:
+		d = time.Duration(v)
+	case float64:
+		d = time.Duration(v)
+	default:
+		return "", fmt.Errorf("cannot format %T as a duration", value)
+	}
+
+	plural := func(n int64, unit [2]string) string {
+		if n == 1 {
+			return "1 " + unit[0]
+		}
+		return fmt.Sprintf("%d %s", n, unit[1])
+	}
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return plural(int64(d/(24*time.Hour)), f.day), nil
+	case d >= time.Hour && d%time.Hour == 0:
+		return plural(int64(d/time.Hour), f.hour), nil
+	default:
+		return plural(int64(max(d.Round(time.Minute), time.Minute)/time.Minute), f.minute), nil
+	}
+}
+
+// datetime renders a time in UTC, e.g. "Jan 2, 2006 15:04 UTC" in English
+func (f localeFormat) datetime(value any) (string, error) {
+	t, err := parseTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(f.dateLayout), nil
+}
+
+// date renders the day of a time in UTC, e.g. "Jan 2, 2006" in English
+func (f localeFormat) date(value any) (string, error) {
+	t, err := parseTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(f.dayLayout), nil
+}
+
+// parseTime accepts a time or its RFC 3339 encoding
+func parseTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot format %q as a time: %w", v, err)
+		}
+		return parsed, nil
+	default:
+		return time.Time{}, fmt.Errorf("cannot format %T as a time", value)
+	}
+}
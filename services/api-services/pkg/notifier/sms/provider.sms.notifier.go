@@ -0,0 +1,200 @@
+// Package sms sends text messages through one or more SMS providers with failover,
+// mirroring the email notifier.
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+)
+
+const (
+	// providerFailureThreshold consecutive failures take a provider out of the failover order
+	providerFailureThreshold = 3
+	// providerCooldown is how long a failing provider is skipped before it is tried again
+	providerCooldown = time.Minute
+	// sendTimeout bounds a single provider call
+	sendTimeout = 10 * time.Second
+)
+
+// ErrInvalidNumber is returned for recipient numbers that are not in E.164 format
+var ErrInvalidNumber = errors.New("phone number must be in E.164 format")
+
+// Provider defines the interface for SMS sending providers
+type Provider interface {
+	SendSMS(ctx context.Context, from, to, body string) error
+	Name() string
+	GetFromNumber() string
+	HealthCheck(ctx context.Context) error
+}
+
+// Service manages multiple SMS providers with failover
+type Service interface {
+	SendSMS(ctx context.Context, to, body string) error
+	HealthCheck(ctx context.Context) error
+	// Stats returns the send counters and circuit state of every provider
+	Stats() []ProviderStats
+}
+
+type ServiceImpl struct {
+	providersMap  map[string]Provider
+	failoverOrder []string
+	breakers      map[string]*resilience.Breaker
+	counters      map[string]*providerCounters
+}
+
+// NewSMSService creates a new SMS Service with the providers enabled in the configuration.
+// It returns nil when SMS is disabled.
+func NewSMSService(cfg *config.SMSConfig) (Service, error) {
+	if !cfg.Enable {
+		log.Printf("INFO: SMS service is globally disabled by configuration (SMS_ENABLE=false).")
+		return nil, nil
+	}
+
+	providersMap := make(map[string]Provider)
+	if cfg.Twilio.Enable {
+		twilioProvider := NewTwilioSMSProvider(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken, cfg.Twilio.FromNumber)
+		providersMap[twilioProvider.Name()] = twilioProvider
+		log.Printf("INFO: Twilio SMS Provider enabled and initialized.")
+	}
+	if cfg.Vonage.Enable {
+		vonageProvider := NewVonageSMSProvider(cfg.Vonage.APIKey, cfg.Vonage.APISecret, cfg.Vonage.FromNumber)
+		providersMap[vonageProvider.Name()] = vonageProvider
+		log.Printf("INFO: Vonage SMS Provider enabled and initialized.")
+	}
+	if len(providersMap) == 0 {
+		return nil, fmt.Errorf("no SMS providers enabled in configuration")
+	}
+
+	failoverOrder := resolveFailoverOrder(providersMap, cfg.DefaultProvider, cfg.ProviderOrder)
+
+	breakers := make(map[string]*resilience.Breaker, len(providersMap))
+	counters := make(map[string]*providerCounters, len(providersMap))
+	for name := range providersMap {
+		breakers[name] = resilience.NewBreaker(resilience.BreakerOptions{
+			Name:      "sms:" + name,
+			Threshold: providerFailureThreshold,
+			Timeout:   providerCooldown,
+		})
+		counters[name] = &providerCounters{}
+	}
+
+	return &ServiceImpl{
+		providersMap:  providersMap,
+		failoverOrder: failoverOrder,
+		breakers:      breakers,
+		counters:      counters,
+	}, nil
+}
+
+// resolveFailoverOrder puts the default provider first, then the providers named in
+// order, then every remaining provider in name order
+func resolveFailoverOrder(providersMap map[string]Provider, defaultProvider, order string) []string {
+	var failoverOrder []string
+	seen := make(map[string]bool, len(providersMap))
+	add := func(name, setting string) {
+		if name == "" || seen[name] {
+			return
+		}
+		if _, ok := providersMap[name]; !ok {
+			log.Printf("WARN: Configured SMS provider '%s' in %s is not enabled or does not exist. Skipping.", name, setting)
+			return
+		}
+		seen[name] = true
+		failoverOrder = append(failoverOrder, name)
+	}
+
+	add(strings.TrimSpace(defaultProvider), "SMS_DEFAULT_PROVIDER")
+	for _, name := range strings.Split(order, ",") {
+		add(strings.TrimSpace(name), "SMS_PROVIDER_ORDER")
+	}
+	for _, name := range []string{twilioProviderName, vonageProviderName} {
+		if _, ok := providersMap[name]; ok {
+			add(name, "")
+		}
+	}
+	return failoverOrder
+}
+
+// SendSMS sends body to the E.164 number to through the first provider that accepts it
+func (s *ServiceImpl) SendSMS(ctx context.Context, to, body string) error {
+	if s == nil {
+		log.Printf("WARN: Attempted to send SMS but SMS service is not initialized.")
+		return fmt.Errorf("sms service is disabled")
+	}
+	if !IsE164(to) {
+		return ErrInvalidNumber
+	}
+
+	for _, providerName := range s.failoverOrder {
+		provider := s.providersMap[providerName]
+		breaker := s.breakers[providerName]
+		counters := s.counters[providerName]
+		if err := breaker.Allow(); err != nil {
+			log.Printf("WARN: Skipping %s SMS provider, its circuit breaker is open.", provider.Name())
+			counters.skipped.Add(1)
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		start := time.Now()
+		err := provider.SendSMS(sendCtx, provider.GetFromNumber(), to, body)
+		cancel()
+		counters.record(err, time.Since(start))
+		breaker.Record(err)
+
+		if err == nil {
+			log.Printf("INFO: SMS successfully sent to %s using %s provider.", maskNumber(to), provider.Name())
+			return nil
+		}
+		log.Printf("ERROR: Failed to send SMS via %s: %v", provider.Name(), err)
+	}
+
+	return fmt.Errorf("all configured SMS providers failed to send SMS to %s", maskNumber(to))
+}
+
+// HealthCheck reports healthy while at least one provider is reachable
+func (s *ServiceImpl) HealthCheck(ctx context.Context) error {
+	if s == nil {
+		return fmt.Errorf("sms service is disabled")
+	}
+
+	var lastErr error
+	for _, providerName := range s.failoverOrder {
+		provider := s.providersMap[providerName]
+		if err := provider.HealthCheck(ctx); err != nil {
+			log.Printf("WARN: SMS provider %s health check failed: %v", provider.Name(), err)
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("all SMS providers failed health check: %w", lastErr)
+}
+
+// IsE164 reports whether number is a + followed by 8 to 15 digits
+func IsE164(number string) bool {
+	if len(number) < 9 || len(number) > 16 || number[0] != '+' || number[1] == '0' {
+		return false
+	}
+	for _, r := range number[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// maskNumber hides all but the last four digits of a phone number in logs
+func maskNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
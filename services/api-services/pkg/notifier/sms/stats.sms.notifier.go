@@ -0,0 +1,63 @@
+package sms
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/resilience"
+)
+
+// ProviderStats are the send counters of one provider and the state of its circuit breaker
+type ProviderStats struct {
+	Provider string `json:"provider"`
+	// Sent and Failed count send attempts by outcome
+	Sent   int64 `json:"sent"`
+	Failed int64 `json:"failed"`
+	// Skipped counts the sends that passed over the provider while its circuit was open
+	Skipped int64 `json:"skipped"`
+	// Latency is the total time spent in send attempts
+	Latency             time.Duration    `json:"latency_ns"`
+	CircuitState        resilience.State `json:"circuit_state"`
+	ConsecutiveFailures int              `json:"consecutive_failures"`
+}
+
+// providerCounters accumulates the send outcomes of one provider
+type providerCounters struct {
+	sent    atomic.Int64
+	failed  atomic.Int64
+	skipped atomic.Int64
+	latency atomic.Int64
+}
+
+// record counts one send attempt that took elapsed
+func (c *providerCounters) record(err error, elapsed time.Duration) {
+	c.latency.Add(int64(elapsed))
+	if err != nil {
+		c.failed.Add(1)
+		return
+	}
+	c.sent.Add(1)
+}
+
+// Stats returns the counters of every provider in failover order
+func (s *ServiceImpl) Stats() []ProviderStats {
+	if s == nil {
+		return nil
+	}
+
+	stats := make([]ProviderStats, 0, len(s.failoverOrder))
+	for _, name := range s.failoverOrder {
+		counters := s.counters[name]
+		breaker := s.breakers[name]
+		stats = append(stats, ProviderStats{
+			Provider:            name,
+			Sent:                counters.sent.Load(),
+			Failed:              counters.failed.Load(),
+			Skipped:             counters.skipped.Load(),
+			Latency:             time.Duration(counters.latency.Load()),
+			CircuitState:        breaker.State(),
+			ConsecutiveFailures: breaker.Failures(),
+		})
+	}
+	return stats
+}
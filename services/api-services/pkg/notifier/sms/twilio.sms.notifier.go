@@ -0,0 +1,105 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+)
+
+const (
+	twilioProviderName = "twilio"
+	twilioBaseURL      = "https://api.twilio.com/2010-04-01"
+)
+
+// TwilioSMSProvider implements Provider with the Twilio Messages API
+type TwilioSMSProvider struct {
+	accountSID string
+	authToken  string
+	from       string
+	baseURL    string
+	client     *http.Client
+}
+
+// NewTwilioSMSProvider creates a new TwilioSMSProvider.
+func NewTwilioSMSProvider(accountSID, authToken, from string) *TwilioSMSProvider {
+	return &TwilioSMSProvider{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		baseURL:    twilioBaseURL,
+		// The SMS service breaks the circuit of each provider
+		client: httpclient.New(httpclient.Options{Name: twilioProviderName, DisableBreaker: true}),
+	}
+}
+
+// twilioError is the error body returned by the Twilio API
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SendSMS creates a Twilio message
+func (p *TwilioSMSProvider) SendSMS(ctx context.Context, from, to, body string) error {
+	form := url.Values{}
+	form.Set("From", from)
+	form.Set("To", to)
+	form.Set("Body", body)
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", p.baseURL, url.PathEscape(p.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("twilio provider: could not build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(p.accountSID, p.authToken)
+
+	return p.do(req)
+}
+
+// Name returns the provider name
+func (p *TwilioSMSProvider) Name() string {
+	return twilioProviderName
+}
+
+// GetFromNumber returns the configured sender number
+func (p *TwilioSMSProvider) GetFromNumber() string {
+	return p.from
+}
+
+// HealthCheck verifies the credentials by fetching the account
+func (p *TwilioSMSProvider) HealthCheck(ctx context.Context) error {
+	endpoint := fmt.Sprintf("%s/Accounts/%s.json", p.baseURL, url.PathEscape(p.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("twilio provider: could not build request: %w", err)
+	}
+	req.SetBasicAuth(p.accountSID, p.authToken)
+
+	return p.do(req)
+}
+
+// do sends req and turns non-2xx responses into errors carrying the Twilio message
+func (p *TwilioSMSProvider) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio provider: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	var apiErr twilioError
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&apiErr); err == nil && apiErr.Message != "" {
+		return fmt.Errorf("twilio provider: status %d: %s (code %d)", resp.StatusCode, apiErr.Message, apiErr.Code)
+	}
+	return fmt.Errorf("twilio provider: status %d", resp.StatusCode)
+}
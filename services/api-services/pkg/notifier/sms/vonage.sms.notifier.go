@@ -0,0 +1,120 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+)
+
+const (
+	vonageProviderName = "vonage"
+	vonageBaseURL      = "https://rest.nexmo.com"
+)
+
+// VonageSMSProvider implements Provider with the Vonage SMS API
+type VonageSMSProvider struct {
+	apiKey    string
+	apiSecret string
+	from      string
+	baseURL   string
+	client    *http.Client
+}
+
+// NewVonageSMSProvider creates a new VonageSMSProvider.
+func NewVonageSMSProvider(apiKey, apiSecret, from string) *VonageSMSProvider {
+	return &VonageSMSProvider{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		from:      from,
+		baseURL:   vonageBaseURL,
+		// The SMS service breaks the circuit of each provider
+		client: httpclient.New(httpclient.Options{Name: vonageProviderName, DisableBreaker: true}),
+	}
+}
+
+// vonageResponse is the body returned by the Vonage SMS API. Each part of a long message
+// has its own status; "0" means accepted.
+type vonageResponse struct {
+	Messages []struct {
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+// SendSMS submits a message to Vonage. Vonage expects numbers without the leading +.
+func (p *VonageSMSProvider) SendSMS(ctx context.Context, from, to, body string) error {
+	form := url.Values{}
+	form.Set("api_key", p.apiKey)
+	form.Set("api_secret", p.apiSecret)
+	form.Set("from", strings.TrimPrefix(from, "+"))
+	form.Set("to", strings.TrimPrefix(to, "+"))
+	form.Set("text", body)
+	form.Set("type", "unicode")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/sms/json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("vonage provider: could not build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vonage provider: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vonage provider: status %d", resp.StatusCode)
+	}
+
+	var result vonageResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&result); err != nil {
+		return fmt.Errorf("vonage provider: invalid response: %w", err)
+	}
+	if len(result.Messages) == 0 {
+		return fmt.Errorf("vonage provider: empty response")
+	}
+	for _, message := range result.Messages {
+		if message.Status != "0" {
+			return fmt.Errorf("vonage provider: status %s: %s", message.Status, message.ErrorText)
+		}
+	}
+	return nil
+}
+
+// Name returns the provider name
+func (p *VonageSMSProvider) Name() string {
+	return vonageProviderName
+}
+
+// GetFromNumber returns the configured sender number or ID
+func (p *VonageSMSProvider) GetFromNumber() string {
+	return p.from
+}
+
+// HealthCheck verifies the credentials by fetching the account balance
+func (p *VonageSMSProvider) HealthCheck(ctx context.Context) error {
+	query := url.Values{}
+	query.Set("api_key", p.apiKey)
+	query.Set("api_secret", p.apiSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/account/get-balance?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("vonage provider: could not build request: %w", err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vonage provider: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vonage provider: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/common"
@@ -33,10 +34,24 @@ func DefaultOTPConfig() OTPConfig {
 // OTPService contains only domain logic: create OTP model and validate rules.
 type OTPService struct {
 	config OTPConfig
+
+	mu     sync.Mutex
+	issued map[string]int64
 }
 
 func NewOTPService(cfg OTPConfig) *OTPService {
-	return &OTPService{config: cfg}
+	return &OTPService{config: cfg, issued: make(map[string]int64)}
+}
+
+// Issued returns the number of OTPs generated since startup, by type
+func (s *OTPService) Issued() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issued := make(map[string]int64, len(s.issued))
+	for otpType, count := range s.issued {
+		issued[otpType] = count
+	}
+	return issued
 }
 
 // Generate creates a new models.OTP and returns it along with the ttl to be used by the repo.
@@ -70,6 +85,10 @@ func (s *OTPService) Generate(identifier string, otpType common.OTPType) (*OTP,
 		Attempts:   0,
 	}
 
+	s.mu.Lock()
+	s.issued[otp.Type]++
+	s.mu.Unlock()
+
 	return otp, ttl, nil
 }
 
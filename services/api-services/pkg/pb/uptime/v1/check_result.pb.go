@@ -0,0 +1,306 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: uptime/v1/check_result.proto
+
+package uptimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CheckResult is the outcome of a single check executed by a probe.
+type CheckResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MonitorId string                 `protobuf:"bytes,1,opt,name=monitor_id,json=monitorId,proto3" json:"monitor_id,omitempty"`
+	ProbeId   string                 `protobuf:"bytes,2,opt,name=probe_id,json=probeId,proto3" json:"probe_id,omitempty"`
+	Region    string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	// Outcome: "up", "down" or "degraded".
+	Status    string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	LatencyMs int64  `protobuf:"varint,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// Protocol status code, e.g. the HTTP status; 0 when not applicable.
+	StatusCode    int32                  `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_uptime_v1_check_result_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_check_result_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_check_result_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckResult) GetMonitorId() string {
+	if x != nil {
+		return x.MonitorId
+	}
+	return ""
+}
+
+func (x *CheckResult) GetProbeId() string {
+	if x != nil {
+		return x.ProbeId
+	}
+	return ""
+}
+
+func (x *CheckResult) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CheckResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckResult) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *CheckResult) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CheckResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CheckResult) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+type IngestCheckResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CheckResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestCheckResultsRequest) Reset() {
+	*x = IngestCheckResultsRequest{}
+	mi := &file_uptime_v1_check_result_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestCheckResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestCheckResultsRequest) ProtoMessage() {}
+
+func (x *IngestCheckResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_check_result_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestCheckResultsRequest.ProtoReflect.Descriptor instead.
+func (*IngestCheckResultsRequest) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_check_result_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IngestCheckResultsRequest) GetResults() []*CheckResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type IngestCheckResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestCheckResultsResponse) Reset() {
+	*x = IngestCheckResultsResponse{}
+	mi := &file_uptime_v1_check_result_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestCheckResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestCheckResultsResponse) ProtoMessage() {}
+
+func (x *IngestCheckResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_check_result_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestCheckResultsResponse.ProtoReflect.Descriptor instead.
+func (*IngestCheckResultsResponse) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_check_result_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IngestCheckResultsResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestCheckResultsResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+var File_uptime_v1_check_result_proto protoreflect.FileDescriptor
+
+const file_uptime_v1_check_result_proto_rawDesc = "" +
+	"\n" +
+	"\x1cuptime/v1/check_result.proto\x12\tuptime.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x02\n" +
+	"\vCheckResult\x12\x1d\n" +
+	"\n" +
+	"monitor_id\x18\x01 \x01(\tR\tmonitorId\x12\x19\n" +
+	"\bprobe_id\x18\x02 \x01(\tR\aprobeId\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x03R\tlatencyMs\x12\x1f\n" +
+	"\vstatus_code\x18\x06 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"checked_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"M\n" +
+	"\x19IngestCheckResultsRequest\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.uptime.v1.CheckResultR\aresults\"T\n" +
+	"\x1aIngestCheckResultsResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x05R\brejected2\xce\x01\n" +
+	"\x12CheckResultService\x12a\n" +
+	"\x12IngestCheckResults\x12$.uptime.v1.IngestCheckResultsRequest\x1a%.uptime.v1.IngestCheckResultsResponse\x12U\n" +
+	"\x12StreamCheckResults\x12\x16.uptime.v1.CheckResult\x1a%.uptime.v1.IngestCheckResultsResponse(\x01BWZUgithub.com/samaasi/uptime-application/services/api-services/pkg/pb/uptime/v1;uptimev1b\x06proto3"
+
+var (
+	file_uptime_v1_check_result_proto_rawDescOnce sync.Once
+	file_uptime_v1_check_result_proto_rawDescData []byte
+)
+
+func file_uptime_v1_check_result_proto_rawDescGZIP() []byte {
+	file_uptime_v1_check_result_proto_rawDescOnce.Do(func() {
+		file_uptime_v1_check_result_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_uptime_v1_check_result_proto_rawDesc), len(file_uptime_v1_check_result_proto_rawDesc)))
+	})
+	return file_uptime_v1_check_result_proto_rawDescData
+}
+
+var file_uptime_v1_check_result_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_uptime_v1_check_result_proto_goTypes = []any{
+	(*CheckResult)(nil),                // 0: uptime.v1.CheckResult
+	(*IngestCheckResultsRequest)(nil),  // 1: uptime.v1.IngestCheckResultsRequest
+	(*IngestCheckResultsResponse)(nil), // 2: uptime.v1.IngestCheckResultsResponse
+	(*timestamppb.Timestamp)(nil),      // 3: google.protobuf.Timestamp
+}
+var file_uptime_v1_check_result_proto_depIdxs = []int32{
+	3, // 0: uptime.v1.CheckResult.checked_at:type_name -> google.protobuf.Timestamp
+	0, // 1: uptime.v1.IngestCheckResultsRequest.results:type_name -> uptime.v1.CheckResult
+	1, // 2: uptime.v1.CheckResultService.IngestCheckResults:input_type -> uptime.v1.IngestCheckResultsRequest
+	0, // 3: uptime.v1.CheckResultService.StreamCheckResults:input_type -> uptime.v1.CheckResult
+	2, // 4: uptime.v1.CheckResultService.IngestCheckResults:output_type -> uptime.v1.IngestCheckResultsResponse
+	2, // 5: uptime.v1.CheckResultService.StreamCheckResults:output_type -> uptime.v1.IngestCheckResultsResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_uptime_v1_check_result_proto_init() }
+func file_uptime_v1_check_result_proto_init() {
+	if File_uptime_v1_check_result_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_uptime_v1_check_result_proto_rawDesc), len(file_uptime_v1_check_result_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_uptime_v1_check_result_proto_goTypes,
+		DependencyIndexes: file_uptime_v1_check_result_proto_depIdxs,
+		MessageInfos:      file_uptime_v1_check_result_proto_msgTypes,
+	}.Build()
+	File_uptime_v1_check_result_proto = out.File
+	file_uptime_v1_check_result_proto_goTypes = nil
+	file_uptime_v1_check_result_proto_depIdxs = nil
+}
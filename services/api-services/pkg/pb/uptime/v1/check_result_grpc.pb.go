@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: uptime/v1/check_result.proto
+
+package uptimev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CheckResultService_IngestCheckResults_FullMethodName = "/uptime.v1.CheckResultService/IngestCheckResults"
+	CheckResultService_StreamCheckResults_FullMethodName = "/uptime.v1.CheckResultService/StreamCheckResults"
+)
+
+// CheckResultServiceClient is the client API for CheckResultService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CheckResultService ingests check results reported by probe workers.
+type CheckResultServiceClient interface {
+	// IngestCheckResults stores a batch of results.
+	IngestCheckResults(ctx context.Context, in *IngestCheckResultsRequest, opts ...grpc.CallOption) (*IngestCheckResultsResponse, error)
+	// StreamCheckResults stores results sent over a client stream, acknowledging once the stream closes.
+	StreamCheckResults(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CheckResult, IngestCheckResultsResponse], error)
+}
+
+type checkResultServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCheckResultServiceClient(cc grpc.ClientConnInterface) CheckResultServiceClient {
+	return &checkResultServiceClient{cc}
+}
+
+func (c *checkResultServiceClient) IngestCheckResults(ctx context.Context, in *IngestCheckResultsRequest, opts ...grpc.CallOption) (*IngestCheckResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestCheckResultsResponse)
+	err := c.cc.Invoke(ctx, CheckResultService_IngestCheckResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkResultServiceClient) StreamCheckResults(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CheckResult, IngestCheckResultsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CheckResultService_ServiceDesc.Streams[0], CheckResultService_StreamCheckResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CheckResult, IngestCheckResultsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckResultService_StreamCheckResultsClient = grpc.ClientStreamingClient[CheckResult, IngestCheckResultsResponse]
+
+// CheckResultServiceServer is the server API for CheckResultService service.
+// All implementations must embed UnimplementedCheckResultServiceServer
+// for forward compatibility.
+//
+// CheckResultService ingests check results reported by probe workers.
+type CheckResultServiceServer interface {
+	// IngestCheckResults stores a batch of results.
+	IngestCheckResults(context.Context, *IngestCheckResultsRequest) (*IngestCheckResultsResponse, error)
+	// StreamCheckResults stores results sent over a client stream, acknowledging once the stream closes.
+	StreamCheckResults(grpc.ClientStreamingServer[CheckResult, IngestCheckResultsResponse]) error
+	mustEmbedUnimplementedCheckResultServiceServer()
+}
+
+// UnimplementedCheckResultServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCheckResultServiceServer struct{}
+
+func (UnimplementedCheckResultServiceServer) IngestCheckResults(context.Context, *IngestCheckResultsRequest) (*IngestCheckResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestCheckResults not implemented")
+}
+func (UnimplementedCheckResultServiceServer) StreamCheckResults(grpc.ClientStreamingServer[CheckResult, IngestCheckResultsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCheckResults not implemented")
+}
+func (UnimplementedCheckResultServiceServer) mustEmbedUnimplementedCheckResultServiceServer() {}
+func (UnimplementedCheckResultServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCheckResultServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CheckResultServiceServer will
+// result in compilation errors.
+type UnsafeCheckResultServiceServer interface {
+	mustEmbedUnimplementedCheckResultServiceServer()
+}
+
+func RegisterCheckResultServiceServer(s grpc.ServiceRegistrar, srv CheckResultServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCheckResultServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CheckResultService_ServiceDesc, srv)
+}
+
+func _CheckResultService_IngestCheckResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestCheckResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckResultServiceServer).IngestCheckResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckResultService_IngestCheckResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckResultServiceServer).IngestCheckResults(ctx, req.(*IngestCheckResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckResultService_StreamCheckResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CheckResultServiceServer).StreamCheckResults(&grpc.GenericServerStream[CheckResult, IngestCheckResultsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckResultService_StreamCheckResultsServer = grpc.ClientStreamingServer[CheckResult, IngestCheckResultsResponse]
+
+// CheckResultService_ServiceDesc is the grpc.ServiceDesc for CheckResultService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CheckResultService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "uptime.v1.CheckResultService",
+	HandlerType: (*CheckResultServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IngestCheckResults",
+			Handler:    _CheckResultService_IngestCheckResults_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCheckResults",
+			Handler:       _CheckResultService_StreamCheckResults_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "uptime/v1/check_result.proto",
+}
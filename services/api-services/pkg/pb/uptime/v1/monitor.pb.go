@@ -0,0 +1,431 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: uptime/v1/monitor.proto
+
+package uptimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Monitor describes a single check target.
+type Monitor struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Check type, e.g. "http", "tcp" or "ping".
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// URL or host:port to check.
+	Target          string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	IntervalSeconds int32  `protobuf:"varint,6,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	TimeoutSeconds  int32  `protobuf:"varint,7,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Last known state: "pending", "up", "down" or "paused".
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	LastCheckedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_checked_at,json=lastCheckedAt,proto3" json:"last_checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Monitor) Reset() {
+	*x = Monitor{}
+	mi := &file_uptime_v1_monitor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Monitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Monitor) ProtoMessage() {}
+
+func (x *Monitor) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_monitor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Monitor.ProtoReflect.Descriptor instead.
+func (*Monitor) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_monitor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Monitor) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Monitor) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Monitor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Monitor) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Monitor) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Monitor) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *Monitor) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *Monitor) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Monitor) GetLastCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheckedAt
+	}
+	return nil
+}
+
+type ListMonitorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional organization filter; empty returns monitors for all organizations.
+	OrganizationId string `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Maximum number of monitors to return; defaults to 100, capped at 1000.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token from a previous ListMonitorsResponse.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMonitorsRequest) Reset() {
+	*x = ListMonitorsRequest{}
+	mi := &file_uptime_v1_monitor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMonitorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMonitorsRequest) ProtoMessage() {}
+
+func (x *ListMonitorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_monitor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMonitorsRequest.ProtoReflect.Descriptor instead.
+func (*ListMonitorsRequest) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_monitor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMonitorsRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ListMonitorsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMonitorsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMonitorsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Monitors []*Monitor             `protobuf:"bytes,1,rep,name=monitors,proto3" json:"monitors,omitempty"`
+	// Empty when there are no more results.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMonitorsResponse) Reset() {
+	*x = ListMonitorsResponse{}
+	mi := &file_uptime_v1_monitor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMonitorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMonitorsResponse) ProtoMessage() {}
+
+func (x *ListMonitorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_monitor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMonitorsResponse.ProtoReflect.Descriptor instead.
+func (*ListMonitorsResponse) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_monitor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMonitorsResponse) GetMonitors() []*Monitor {
+	if x != nil {
+		return x.Monitors
+	}
+	return nil
+}
+
+func (x *ListMonitorsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetMonitorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMonitorRequest) Reset() {
+	*x = GetMonitorRequest{}
+	mi := &file_uptime_v1_monitor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMonitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonitorRequest) ProtoMessage() {}
+
+func (x *GetMonitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_monitor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonitorRequest.ProtoReflect.Descriptor instead.
+func (*GetMonitorRequest) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_monitor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetMonitorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetMonitorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Monitor       *Monitor               `protobuf:"bytes,1,opt,name=monitor,proto3" json:"monitor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMonitorResponse) Reset() {
+	*x = GetMonitorResponse{}
+	mi := &file_uptime_v1_monitor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMonitorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonitorResponse) ProtoMessage() {}
+
+func (x *GetMonitorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uptime_v1_monitor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonitorResponse.ProtoReflect.Descriptor instead.
+func (*GetMonitorResponse) Descriptor() ([]byte, []int) {
+	return file_uptime_v1_monitor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMonitorResponse) GetMonitor() *Monitor {
+	if x != nil {
+		return x.Monitor
+	}
+	return nil
+}
+
+var File_uptime_v1_monitor_proto protoreflect.FileDescriptor
+
+const file_uptime_v1_monitor_proto_rawDesc = "" +
+	"\n" +
+	"\x17uptime/v1/monitor.proto\x12\tuptime.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb2\x02\n" +
+	"\aMonitor\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x12)\n" +
+	"\x10interval_seconds\x18\x06 \x01(\x05R\x0fintervalSeconds\x12'\n" +
+	"\x0ftimeout_seconds\x18\a \x01(\x05R\x0etimeoutSeconds\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12B\n" +
+	"\x0flast_checked_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rlastCheckedAt\"z\n" +
+	"\x13ListMonitorsRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"n\n" +
+	"\x14ListMonitorsResponse\x12.\n" +
+	"\bmonitors\x18\x01 \x03(\v2\x12.uptime.v1.MonitorR\bmonitors\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"#\n" +
+	"\x11GetMonitorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"B\n" +
+	"\x12GetMonitorResponse\x12,\n" +
+	"\amonitor\x18\x01 \x01(\v2\x12.uptime.v1.MonitorR\amonitor2\xac\x01\n" +
+	"\x0eMonitorService\x12O\n" +
+	"\fListMonitors\x12\x1e.uptime.v1.ListMonitorsRequest\x1a\x1f.uptime.v1.ListMonitorsResponse\x12I\n" +
+	"\n" +
+	"GetMonitor\x12\x1c.uptime.v1.GetMonitorRequest\x1a\x1d.uptime.v1.GetMonitorResponseBWZUgithub.com/samaasi/uptime-application/services/api-services/pkg/pb/uptime/v1;uptimev1b\x06proto3"
+
+var (
+	file_uptime_v1_monitor_proto_rawDescOnce sync.Once
+	file_uptime_v1_monitor_proto_rawDescData []byte
+)
+
+func file_uptime_v1_monitor_proto_rawDescGZIP() []byte {
+	file_uptime_v1_monitor_proto_rawDescOnce.Do(func() {
+		file_uptime_v1_monitor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_uptime_v1_monitor_proto_rawDesc), len(file_uptime_v1_monitor_proto_rawDesc)))
+	})
+	return file_uptime_v1_monitor_proto_rawDescData
+}
+
+var file_uptime_v1_monitor_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_uptime_v1_monitor_proto_goTypes = []any{
+	(*Monitor)(nil),               // 0: uptime.v1.Monitor
+	(*ListMonitorsRequest)(nil),   // 1: uptime.v1.ListMonitorsRequest
+	(*ListMonitorsResponse)(nil),  // 2: uptime.v1.ListMonitorsResponse
+	(*GetMonitorRequest)(nil),     // 3: uptime.v1.GetMonitorRequest
+	(*GetMonitorResponse)(nil),    // 4: uptime.v1.GetMonitorResponse
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_uptime_v1_monitor_proto_depIdxs = []int32{
+	5, // 0: uptime.v1.Monitor.last_checked_at:type_name -> google.protobuf.Timestamp
+	0, // 1: uptime.v1.ListMonitorsResponse.monitors:type_name -> uptime.v1.Monitor
+	0, // 2: uptime.v1.GetMonitorResponse.monitor:type_name -> uptime.v1.Monitor
+	1, // 3: uptime.v1.MonitorService.ListMonitors:input_type -> uptime.v1.ListMonitorsRequest
+	3, // 4: uptime.v1.MonitorService.GetMonitor:input_type -> uptime.v1.GetMonitorRequest
+	2, // 5: uptime.v1.MonitorService.ListMonitors:output_type -> uptime.v1.ListMonitorsResponse
+	4, // 6: uptime.v1.MonitorService.GetMonitor:output_type -> uptime.v1.GetMonitorResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_uptime_v1_monitor_proto_init() }
+func file_uptime_v1_monitor_proto_init() {
+	if File_uptime_v1_monitor_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_uptime_v1_monitor_proto_rawDesc), len(file_uptime_v1_monitor_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_uptime_v1_monitor_proto_goTypes,
+		DependencyIndexes: file_uptime_v1_monitor_proto_depIdxs,
+		MessageInfos:      file_uptime_v1_monitor_proto_msgTypes,
+	}.Build()
+	File_uptime_v1_monitor_proto = out.File
+	file_uptime_v1_monitor_proto_goTypes = nil
+	file_uptime_v1_monitor_proto_depIdxs = nil
+}
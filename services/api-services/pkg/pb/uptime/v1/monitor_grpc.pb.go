@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: uptime/v1/monitor.proto
+
+package uptimev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MonitorService_ListMonitors_FullMethodName = "/uptime.v1.MonitorService/ListMonitors"
+	MonitorService_GetMonitor_FullMethodName   = "/uptime.v1.MonitorService/GetMonitor"
+)
+
+// MonitorServiceClient is the client API for MonitorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MonitorService exposes monitor definitions to probe workers and internal services.
+type MonitorServiceClient interface {
+	// ListMonitors returns active monitors, optionally scoped to one organization.
+	ListMonitors(ctx context.Context, in *ListMonitorsRequest, opts ...grpc.CallOption) (*ListMonitorsResponse, error)
+	// GetMonitor returns a single monitor by ID.
+	GetMonitor(ctx context.Context, in *GetMonitorRequest, opts ...grpc.CallOption) (*GetMonitorResponse, error)
+}
+
+type monitorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMonitorServiceClient(cc grpc.ClientConnInterface) MonitorServiceClient {
+	return &monitorServiceClient{cc}
+}
+
+func (c *monitorServiceClient) ListMonitors(ctx context.Context, in *ListMonitorsRequest, opts ...grpc.CallOption) (*ListMonitorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMonitorsResponse)
+	err := c.cc.Invoke(ctx, MonitorService_ListMonitors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitorServiceClient) GetMonitor(ctx context.Context, in *GetMonitorRequest, opts ...grpc.CallOption) (*GetMonitorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMonitorResponse)
+	err := c.cc.Invoke(ctx, MonitorService_GetMonitor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MonitorServiceServer is the server API for MonitorService service.
+// All implementations must embed UnimplementedMonitorServiceServer
+// for forward compatibility.
+//
+// MonitorService exposes monitor definitions to probe workers and internal services.
+type MonitorServiceServer interface {
+	// ListMonitors returns active monitors, optionally scoped to one organization.
+	ListMonitors(context.Context, *ListMonitorsRequest) (*ListMonitorsResponse, error)
+	// GetMonitor returns a single monitor by ID.
+	GetMonitor(context.Context, *GetMonitorRequest) (*GetMonitorResponse, error)
+	mustEmbedUnimplementedMonitorServiceServer()
+}
+
+// UnimplementedMonitorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMonitorServiceServer struct{}
+
+func (UnimplementedMonitorServiceServer) ListMonitors(context.Context, *ListMonitorsRequest) (*ListMonitorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMonitors not implemented")
+}
+func (UnimplementedMonitorServiceServer) GetMonitor(context.Context, *GetMonitorRequest) (*GetMonitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMonitor not implemented")
+}
+func (UnimplementedMonitorServiceServer) mustEmbedUnimplementedMonitorServiceServer() {}
+func (UnimplementedMonitorServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeMonitorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MonitorServiceServer will
+// result in compilation errors.
+type UnsafeMonitorServiceServer interface {
+	mustEmbedUnimplementedMonitorServiceServer()
+}
+
+func RegisterMonitorServiceServer(s grpc.ServiceRegistrar, srv MonitorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMonitorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MonitorService_ServiceDesc, srv)
+}
+
+func _MonitorService_ListMonitors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMonitorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitorServiceServer).ListMonitors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MonitorService_ListMonitors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitorServiceServer).ListMonitors(ctx, req.(*ListMonitorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MonitorService_GetMonitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitorServiceServer).GetMonitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MonitorService_GetMonitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitorServiceServer).GetMonitor(ctx, req.(*GetMonitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MonitorService_ServiceDesc is the grpc.ServiceDesc for MonitorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MonitorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "uptime.v1.MonitorService",
+	HandlerType: (*MonitorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMonitors",
+			Handler:    _MonitorService_ListMonitors_Handler,
+		},
+		{
+			MethodName: "GetMonitor",
+			Handler:    _MonitorService_GetMonitor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "uptime/v1/monitor.proto",
+}
@@ -0,0 +1,368 @@
+// Package queue is a job queue on Redis Streams. Producers append jobs to a stream and
+// consumers in a consumer group share them, each job going to one consumer. A job stays
+// pending until its handler succeeds; jobs left pending longer than ClaimIdle, because
+// their handler failed or their consumer died, are claimed again by a live consumer, and
+// jobs that keep failing are moved to a dead-letter stream after MaxAttempts deliveries.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+const (
+	fieldType       = "type"
+	fieldPayload    = "payload"
+	fieldEnqueuedAt = "enqueued_at"
+	fieldError      = "error"
+
+	// deadLetterSuffix names the stream receiving jobs that exhausted their attempts
+	deadLetterSuffix = ":dead"
+	// retryInterval is how long Run waits after Redis fails before reading again
+	retryInterval = 5 * time.Second
+)
+
+// ErrUnknownJobType is returned for jobs without a registered handler. Such jobs are
+// dead-lettered right away since retrying cannot help.
+var ErrUnknownJobType = errors.New("no handler registered for job type")
+
+// Handler processes the payload of one job. Delivery is at-least-once: a job may be
+// handled again if its consumer dies after the handler succeeded but before the ack.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// Typed adapts fn to a Handler decoding the payload into a T. Payloads that do not decode
+// fail permanently and are dead-lettered.
+func Typed[T any](fn func(ctx context.Context, payload T) error) Handler {
+	return func(ctx context.Context, raw json.RawMessage) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode job payload: %w", err))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the job is dead-lettered instead of retried
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// job is a job read from the stream
+type job struct {
+	id      string
+	jobType string
+	payload json.RawMessage
+}
+
+// Options configures a Queue
+type Options struct {
+	// Consumer names this process in the consumer group; it must be unique per replica
+	Consumer string
+	// BatchSize is how many jobs are read or claimed at a time
+	BatchSize int64
+	// Block is how long a read waits for new jobs
+	Block time.Duration
+	// ClaimIdle is how long a job stays pending before another consumer retries it
+	ClaimIdle time.Duration
+	// MaxAttempts is how many deliveries a job gets before it is dead-lettered
+	MaxAttempts int64
+	// HandlerTimeout bounds a single handler call
+	HandlerTimeout time.Duration
+	// MaxLen caps the stream length approximately; zero keeps every entry
+	MaxLen int64
+}
+
+// DefaultOptions provides default options with a consumer name unique to this process.
+func DefaultOptions() Options {
+	hostname, _ := os.Hostname()
+	return Options{
+		Consumer:       fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8]),
+		BatchSize:      10,
+		Block:          5 * time.Second,
+		ClaimIdle:      time.Minute,
+		MaxAttempts:    5,
+		HandlerTimeout: 30 * time.Second,
+		MaxLen:         100000,
+	}
+}
+
+// Queue produces and consumes the jobs of one stream within one consumer group
+type Queue struct {
+	client   redis.UniversalClient
+	stream   string
+	group    string
+	opts     Options
+	handlers map[string]Handler
+}
+
+// New creates a queue on stream for the consumer group group. Register handlers before
+// calling Run; producers only need Enqueue.
+func New(client redis.UniversalClient, stream, group string, opts Options) *Queue {
+	return &Queue{
+		client:   client,
+		stream:   stream,
+		group:    group,
+		opts:     opts,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register sets the handler processing jobs of jobType
+func (q *Queue) Register(jobType string, handler Handler) {
+	q.handlers[jobType] = handler
+}
+
+// Enqueue appends a job of jobType carrying payload and returns its ID
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s job payload: %w", jobType, err)
+	}
+
+	args := &redis.XAddArgs{
+		Stream: q.stream,
+		Values: map[string]interface{}{
+			fieldType:       jobType,
+			fieldPayload:    string(data),
+			fieldEnqueuedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		},
+	}
+	if q.opts.MaxLen > 0 {
+		args.MaxLen = q.opts.MaxLen
+		args.Approx = true
+	}
+
+	id, err := q.client.XAdd(ctx, args).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to enqueue %s job: %w", jobType, err)
+	}
+	return id, nil
+}
+
+// EnsureGroup creates the stream and the consumer group if they do not exist. New groups
+// start at the end of the stream.
+func (q *Queue) EnsureGroup(ctx context.Context) error {
+	err := q.client.XGroupCreateMkStream(ctx, q.stream, q.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s on %s: %w", q.group, q.stream, err)
+	}
+	return nil
+}
+
+// Run processes jobs until ctx is cancelled, first claiming jobs stuck with other
+// consumers and then reading new ones.
+func (q *Queue) Run(ctx context.Context) {
+	for {
+		if err := q.EnsureGroup(ctx); err == nil {
+			break
+		} else if ctx.Err() == nil {
+			logger.Error("Failed to prepare job queue", logger.String("stream", q.stream), logger.ErrorField(err))
+		}
+		if !q.wait(ctx) {
+			return
+		}
+	}
+
+	for ctx.Err() == nil {
+		claimed, err := q.ClaimStuck(ctx)
+		if err == nil && claimed < int(q.opts.BatchSize) {
+			_, err = q.ReadNew(ctx)
+		}
+		if err != nil && ctx.Err() == nil {
+			logger.Error("Failed to consume jobs", logger.String("stream", q.stream), logger.ErrorField(err))
+			if !q.wait(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// ReadNew waits up to Block for jobs never delivered to the group, processes them and
+// returns how many were processed
+func (q *Queue) ReadNew(ctx context.Context) (int, error) {
+	streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    q.group,
+		Consumer: q.opts.Consumer,
+		Streams:  []string{q.stream, ">"},
+		Count:    q.opts.BatchSize,
+		Block:    q.opts.Block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read jobs from %s: %w", q.stream, err)
+	}
+
+	processed := 0
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			q.process(ctx, message, 1)
+			processed++
+		}
+	}
+	return processed, nil
+}
+
+// ClaimStuck takes over jobs pending for longer than ClaimIdle, processes them and
+// returns how many were processed. A job failing on its last attempt is dead-lettered.
+func (q *Queue) ClaimStuck(ctx context.Context) (int, error) {
+	pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: q.stream,
+		Group:  q.group,
+		Idle:   q.opts.ClaimIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  q.opts.BatchSize,
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending jobs of %s: %w", q.stream, err)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(pending))
+	attempts := make(map[string]int64, len(pending))
+	for _, entry := range pending {
+		ids = append(ids, entry.ID)
+		// The claim below counts as one more delivery
+		attempts[entry.ID] = entry.RetryCount + 1
+	}
+
+	messages, err := q.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   q.stream,
+		Group:    q.group,
+		Consumer: q.opts.Consumer,
+		MinIdle:  q.opts.ClaimIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim pending jobs of %s: %w", q.stream, err)
+	}
+
+	for _, message := range messages {
+		q.process(ctx, message, attempts[message.ID])
+	}
+	return len(messages), nil
+}
+
+// process runs the handler of message, acknowledging it on success and dead-lettering it
+// when the failure is permanent or the attempts are exhausted. Other failures leave the
+// job pending for ClaimStuck.
+func (q *Queue) process(ctx context.Context, message redis.XMessage, attempts int64) {
+	job := decodeJob(message)
+
+	err := q.handle(ctx, job)
+	if err == nil {
+		q.ack(ctx, job.id)
+		return
+	}
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) || errors.Is(err, ErrUnknownJobType) || attempts >= q.opts.MaxAttempts {
+		logger.Error("Dead-lettering job",
+			logger.String("stream", q.stream),
+			logger.String("job_id", job.id),
+			logger.String("type", job.jobType),
+			logger.Int64("attempts", attempts),
+			logger.ErrorField(err),
+		)
+		q.deadLetter(ctx, message, err)
+		return
+	}
+
+	logger.Warn("Job failed, will retry",
+		logger.String("stream", q.stream),
+		logger.String("job_id", job.id),
+		logger.String("type", job.jobType),
+		logger.Int64("attempts", attempts),
+		logger.ErrorField(err),
+	)
+}
+
+// handle runs the handler registered for the job type with the handler timeout
+func (q *Queue) handle(ctx context.Context, job job) error {
+	handler, ok := q.handlers[job.jobType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownJobType, job.jobType)
+	}
+
+	handlerCtx, cancel := context.WithTimeout(ctx, q.opts.HandlerTimeout)
+	defer cancel()
+	return handler(handlerCtx, job.payload)
+}
+
+// deadLetter copies message to the dead-letter stream with the last error and acknowledges it
+func (q *Queue) deadLetter(ctx context.Context, message redis.XMessage, cause error) {
+	values := make(map[string]interface{}, len(message.Values)+1)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+	values[fieldError] = cause.Error()
+
+	err := q.client.XAdd(ctx, &redis.XAddArgs{Stream: q.stream + deadLetterSuffix, Values: values}).Err()
+	if err != nil {
+		// Leave the job pending so it is dead-lettered on a later claim
+		logger.Error("Failed to dead-letter job",
+			logger.String("stream", q.stream),
+			logger.String("job_id", message.ID),
+			logger.ErrorField(err),
+		)
+		return
+	}
+	q.ack(ctx, message.ID)
+}
+
+// ack acknowledges and removes a finished job
+func (q *Queue) ack(ctx context.Context, id string) {
+	pipe := q.client.TxPipeline()
+	pipe.XAck(ctx, q.stream, q.group, id)
+	pipe.XDel(ctx, q.stream, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error("Failed to acknowledge job",
+			logger.String("stream", q.stream),
+			logger.String("job_id", id),
+			logger.ErrorField(err),
+		)
+	}
+}
+
+// wait sleeps for retryInterval, reporting false if ctx was cancelled meanwhile
+func (q *Queue) wait(ctx context.Context) bool {
+	select {
+	case <-time.After(retryInterval):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// decodeJob reads the fields written by Enqueue
+func decodeJob(message redis.XMessage) job {
+	decoded := job{id: message.ID}
+	if value, ok := message.Values[fieldType].(string); ok {
+		decoded.jobType = value
+	}
+	if value, ok := message.Values[fieldPayload].(string); ok {
+		decoded.payload = json.RawMessage(value)
+	}
+	return decoded
+}
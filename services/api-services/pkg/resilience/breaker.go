@@ -0,0 +1,170 @@
+// Package resilience protects calls to external dependencies from cascading failures.
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+)
+
+// ErrCircuitOpen is returned instead of calling a dependency whose circuit is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// State is the state of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = iota
+	// StateOpen rejects every call until the open timeout has passed
+	StateOpen
+	// StateHalfOpen lets calls through to probe the dependency; the first failure opens
+	// the circuit again and the first success closes it
+	StateHalfOpen
+)
+
+// String returns the name of the state
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the state by name, e.g. in JSON
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// BreakerOptions configures a Breaker
+type BreakerOptions struct {
+	// Name identifies the protected dependency in logs
+	Name string
+	// Threshold is how many consecutive failures open the circuit
+	Threshold int
+	// Timeout is how long the circuit stays open before calls may probe the dependency
+	Timeout time.Duration
+	// IsFailure reports whether an error counts against the dependency. Errors caused by
+	// the caller, such as a missing row, should not. Defaults to every non-nil error.
+	IsFailure func(err error) bool
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	opts BreakerOptions
+
+	mu              sync.Mutex
+	state           State
+	failureCount    int
+	lastFailureTime time.Time
+}
+
+// NewBreaker creates a closed circuit breaker
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 1
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(err error) bool { return err != nil }
+	}
+	return &Breaker{opts: opts}
+}
+
+// Allow returns ErrCircuitOpen while calls must not reach the dependency. Once the open
+// timeout has passed it moves the circuit to half-open and lets the call through.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateOpen {
+		return nil
+	}
+	if time.Since(b.lastFailureTime) <= b.opts.Timeout {
+		return ErrCircuitOpen
+	}
+
+	b.state = StateHalfOpen
+	logger.Warn("Circuit breaker moved to Half-Open state",
+		logger.String("name", b.opts.Name),
+		logger.String("last_failure_time", b.lastFailureTime.String()),
+	)
+	return nil
+}
+
+// Record reports the outcome of a call that Allow let through
+func (b *Breaker) Record(err error) {
+	if err != nil && !b.opts.IsFailure(err) {
+		err = nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		if b.state != StateClosed {
+			logger.Info("Circuit breaker reset to Closed state", logger.String("name", b.opts.Name))
+		}
+		b.state = StateClosed
+		b.failureCount = 0
+		return
+	}
+
+	b.failureCount++
+	b.lastFailureTime = time.Now()
+	// A failing dependency fails every call, so the count is logged at a limited rate
+	if ok, suppressed := logger.Allow("breaker:" + b.opts.Name); ok {
+		logger.Debug("Circuit breaker: failure counted",
+			logger.String("name", b.opts.Name),
+			logger.Int("failure_count", b.failureCount),
+			logger.Int("suppressed", suppressed),
+			logger.ErrorField(err),
+		)
+	}
+
+	switch {
+	case b.state == StateHalfOpen:
+		b.state = StateOpen
+		b.failureCount = 0
+		logger.Error("Circuit breaker moved from Half-Open back to Open state due to failure",
+			logger.String("name", b.opts.Name))
+	case b.state == StateClosed && b.failureCount >= b.opts.Threshold:
+		b.state = StateOpen
+		logger.Error("Circuit breaker opened due to consecutive failures",
+			logger.String("name", b.opts.Name),
+			logger.Int("threshold", b.opts.Threshold),
+			logger.Int("failure_count", b.failureCount),
+		)
+	}
+}
+
+// Execute calls fn unless the circuit is open and records its outcome
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// State returns the current state of the circuit
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Failures returns the number of consecutive failures counted so far
+func (b *Breaker) Failures() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failureCount
+}
@@ -0,0 +1,161 @@
+package security
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+)
+
+// FieldKeySize is the size of the AES-256 keys encrypting fields
+const FieldKeySize = 32
+
+// encryptedFieldPrefix starts every encrypted value, followed by the ID of its key
+const encryptedFieldPrefix = "enc:v1:"
+
+// fieldKeyInfo binds the keys derived from the application key to field encryption
+const fieldKeyInfo = "uptime-application field encryption"
+
+// ErrFieldDecryption is returned for encrypted values that none of the keys decrypt
+var ErrFieldDecryption = errors.New("failed to decrypt field")
+
+// FieldCipher encrypts the secrets stored in the database with AES-GCM. Values are
+// "enc:v1:<key ID>:<base64 nonce and ciphertext>", so that values encrypted with a previous
+// key still decrypt after a rotation. Each value is sealed with additional data naming
+// where it is stored, so a value copied to another row or column does not decrypt.
+type FieldCipher struct {
+	// keys holds the key encrypting first, then the ones that only decrypt
+	keys []fieldKey
+}
+
+// fieldKey is an AES-GCM key and the ID values name it by
+type fieldKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewFieldCipher creates a cipher encrypting with the first key; the others only decrypt.
+// Keys must be FieldKeySize bytes.
+func NewFieldCipher(keys ...[]byte) (*FieldCipher, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("field encryption needs a key")
+	}
+	c := &FieldCipher{keys: make([]fieldKey, 0, len(keys))}
+	for _, key := range keys {
+		if len(key) != FieldKeySize {
+			return nil, fmt.Errorf("field encryption keys must be %d bytes", FieldKeySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(key)
+		c.keys = append(c.keys, fieldKey{id: hex.EncodeToString(sum[:4]), aead: aead})
+	}
+	return c, nil
+}
+
+// NewFieldCipherFromConfig creates a cipher from configuration. Without a configured key,
+// keys are derived from appKeys, the current application key first. With a KMS, the
+// configured keys are unwrapped by it.
+func NewFieldCipherFromConfig(ctx context.Context, cfg config.EncryptionConfig, appKeys []string) (*FieldCipher, error) {
+	if cfg.Key == "" {
+		keys := make([][]byte, 0, len(appKeys))
+		for _, secret := range appKeys {
+			key, err := DeriveFieldKey(secret)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return NewFieldCipher(keys...)
+	}
+
+	encoded := append([]string{cfg.Key}, cfg.PreviousKeys...)
+	keys := make([][]byte, 0, len(encoded))
+	for _, value := range encoded {
+		var key []byte
+		var err error
+		if cfg.KMSURL != "" {
+			key, err = UnwrapDataKey(ctx, cfg, value)
+		} else {
+			key, err = base64.StdEncoding.DecodeString(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return NewFieldCipher(keys...)
+}
+
+// DeriveFieldKey derives a field encryption key from an application secret with HKDF
+func DeriveFieldKey(secret string) ([]byte, error) {
+	return hkdf.Key(sha256.New, []byte(secret), nil, fieldKeyInfo, FieldKeySize)
+}
+
+// Encrypt encrypts plaintext with the current key, bound to additionalData. Empty values
+// are kept empty, since they hold no secret.
+func (c *FieldCipher) Encrypt(plaintext string, additionalData []byte) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	key := c.keys[0]
+	nonce := make([]byte, key.aead.NonceSize(), key.aead.NonceSize()+len(plaintext)+key.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := key.aead.Seal(nonce, nonce, []byte(plaintext), additionalData)
+	return c.Prefix() + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value encrypted with any of the keys and the same additionalData.
+// Values that are not encrypted, stored before their field was, are returned as they are.
+func (c *FieldCipher) Decrypt(value string, additionalData []byte) (string, error) {
+	rest, ok := strings.CutPrefix(value, encryptedFieldPrefix)
+	if !ok {
+		return value, nil
+	}
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", ErrFieldDecryption
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrFieldDecryption
+	}
+
+	for _, key := range c.keys {
+		if key.id != id {
+			continue
+		}
+		if len(sealed) < key.aead.NonceSize() {
+			return "", ErrFieldDecryption
+		}
+		nonce, ciphertext := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+		plaintext, err := key.aead.Open(nil, nonce, ciphertext, additionalData)
+		if err != nil {
+			return "", ErrFieldDecryption
+		}
+		return string(plaintext), nil
+	}
+	return "", fmt.Errorf("%w: unknown key %s", ErrFieldDecryption, id)
+}
+
+// Prefix returns the prefix of the values encrypted with the current key
+func (c *FieldCipher) Prefix() string {
+	return encryptedFieldPrefix + c.keys[0].id + ":"
+}
@@ -0,0 +1,119 @@
+package security
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestFieldKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, FieldKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newTestFieldCipher(t *testing.T, keys ...[]byte) *FieldCipher {
+	t.Helper()
+	c, err := NewFieldCipher(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestFieldCipherRoundTrip(t *testing.T) {
+	c := newTestFieldCipher(t, newTestFieldKey(t))
+	data := []byte("ticket_integrations\x00api_token\x001")
+
+	for _, plaintext := range []string{"", "secret", strings.Repeat("long secret ", 100)} {
+		value, err := c.Encrypt(plaintext, data)
+		if err != nil {
+			t.Fatalf("Encrypt(%q) error = %v", plaintext, err)
+		}
+		if plaintext != "" && !strings.HasPrefix(value, c.Prefix()) {
+			t.Errorf("Encrypt(%q) = %q, want the prefix %q", plaintext, value, c.Prefix())
+		}
+		got, err := c.Decrypt(value, data)
+		if err != nil || got != plaintext {
+			t.Errorf("Decrypt(Encrypt(%q)) = %q, %v", plaintext, got, err)
+		}
+	}
+
+	// Values stored before their field was encrypted are read as they are
+	if got, err := c.Decrypt("plain", data); err != nil || got != "plain" {
+		t.Errorf("Decrypt(plain) = %q, %v", got, err)
+	}
+}
+
+func TestFieldCipherRejectsTampering(t *testing.T) {
+	c := newTestFieldCipher(t, newTestFieldKey(t))
+	data := []byte("ticket_integrations\x00api_token\x001")
+	value, err := c.Encrypt("secret", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, c.Prefix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flipped := bytes.Clone(sealed)
+	flipped[len(flipped)-1] ^= 1
+	// A value sealed without the binding to its row must not open either
+	unbound, err := c.Encrypt("secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		data  []byte
+	}{
+		{"flipped ciphertext bit", c.Prefix() + base64.RawStdEncoding.EncodeToString(flipped), data},
+		{"truncated", c.Prefix() + base64.RawStdEncoding.EncodeToString(sealed[:4]), data},
+		{"invalid base64", c.Prefix() + "!!!", data},
+		{"missing key ID", encryptedFieldPrefix + "nokey", data},
+		{"other row", value, []byte("ticket_integrations\x00api_token\x002")},
+		{"other column", value, []byte("ticket_integrations\x00email\x001")},
+		{"no additional data", value, nil},
+		{"sealed without additional data", unbound, data},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := c.Decrypt(tt.value, tt.data); !errors.Is(err, ErrFieldDecryption) {
+				t.Errorf("Decrypt() = %q, %v, want %v", got, err, ErrFieldDecryption)
+			}
+		})
+	}
+}
+
+func TestFieldCipherKeys(t *testing.T) {
+	oldKey, newKey := newTestFieldKey(t), newTestFieldKey(t)
+	data := []byte("chat_integrations\x00verification_key\x001")
+	value, err := newTestFieldCipher(t, oldKey).Encrypt("secret", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A rotated cipher still decrypts with the previous key
+	if got, err := newTestFieldCipher(t, newKey, oldKey).Decrypt(value, data); err != nil || got != "secret" {
+		t.Errorf("Decrypt() with the previous key = %q, %v", got, err)
+	}
+	if got, err := newTestFieldCipher(t, newKey).Decrypt(value, data); !errors.Is(err, ErrFieldDecryption) {
+		t.Errorf("Decrypt() with the wrong key = %q, %v, want %v", got, err, ErrFieldDecryption)
+	}
+
+	// A different key under the same ID, as after a key was replaced in place, fails to open
+	forged := newTestFieldCipher(t, newKey)
+	forged.keys[0].id = newTestFieldCipher(t, oldKey).keys[0].id
+	if got, err := forged.Decrypt(value, data); !errors.Is(err, ErrFieldDecryption) {
+		t.Errorf("Decrypt() with the wrong key under the same ID = %q, %v, want %v", got, err, ErrFieldDecryption)
+	}
+}
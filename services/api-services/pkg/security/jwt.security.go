@@ -1,6 +1,8 @@
 package security
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"strings"
 	"time"
@@ -30,8 +32,10 @@ func NewPayload(userID uuid.UUID, duration time.Duration) *Payload {
 }
 
 // CreateToken generates a signed JWT token from the payload using the provided secret.
+// The token names the secret in its kid header so verification can pick it among several.
 func CreateToken(payload *Payload, secret string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	token.Header["kid"] = keyID(secret)
 	signedToken, err := token.SignedString([]byte(secret))
 	if err != nil {
 		logger.Error("failed to sign JWT token", logger.ErrorField(err))
@@ -40,27 +44,40 @@ func CreateToken(payload *Payload, secret string) (string, error) {
 	return signedToken, nil
 }
 
-// VerifyToken parses and validates the JWT token using the provided secret, returning the payload if valid.
-func VerifyToken(tokenStr string, secret string) (*Payload, error) {
+// VerifyToken parses and validates the JWT token using the provided secrets, returning the payload if valid.
+// A token signed with any of the secrets is accepted, so earlier secrets keep working after a rotation.
+func VerifyToken(tokenStr string, secrets ...string) (*Payload, error) {
 	keyFunc := func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return []byte(secret), nil
+		if kid, ok := token.Header["kid"].(string); ok {
+			for _, secret := range secrets {
+				if keyID(secret) == kid {
+					return []byte(secret), nil
+				}
+			}
+		}
+		// Tokens issued before key IDs were added are tried against every secret
+		keys := make([]jwt.VerificationKey, 0, len(secrets))
+		for _, secret := range secrets {
+			keys = append(keys, []byte(secret))
+		}
+		return jwt.VerificationKeySet{Keys: keys}, nil
 	}
 
 	token, err := jwt.ParseWithClaims(tokenStr, &Payload{}, keyFunc)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			logger.Warn("JWT token expired", logger.String("token", tokenStr))
+			logger.Warn("JWT token expired", logger.Secret("token", tokenStr))
 		} else {
-			logger.Error("failed to parse JWT token", logger.ErrorField(err), logger.String("token", tokenStr))
+			logger.Error("failed to parse JWT token", logger.ErrorField(err), logger.Secret("token", tokenStr))
 		}
 		return nil, err
 	}
 
 	if !token.Valid {
-		logger.Warn("invalid JWT token", logger.String("token", tokenStr))
+		logger.Warn("invalid JWT token", logger.Secret("token", tokenStr))
 		return nil, jwt.ErrSignatureInvalid
 	}
 
@@ -73,6 +90,12 @@ func VerifyToken(tokenStr string, secret string) (*Payload, error) {
 	return payload, nil
 }
 
+// keyID identifies secret without revealing it
+func keyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:4])
+}
+
 // ExtractTokenFromHeader extracts the JWT token from the Authorization header.
 func ExtractTokenFromHeader(c *gin.Context) string {
 	authHeader := c.GetHeader("Authorization")
@@ -87,3 +110,9 @@ func ExtractTokenFromHeader(c *gin.Context) string {
 
 	return parts[1]
 }
+
+// ExtractTokenFromQuery extracts the JWT token from the access_token query parameter.
+// It exists for clients such as browser WebSockets that cannot set request headers.
+func ExtractTokenFromQuery(c *gin.Context) string {
+	return c.Query("access_token")
+}
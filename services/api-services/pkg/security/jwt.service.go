@@ -3,32 +3,34 @@ package security
 import (
     "errors"
     "github.com/samaasi/uptime-application/services/api-services/pkg/logger"
+    "slices"
     "time"
 )
 
-// JWTService provides methods to create and verify JWT tokens using a configured secret.
+// JWTService provides methods to create and verify JWT tokens using configured secrets.
 type JWTService struct {
-    secret     string
+    secrets    []string
     expiration time.Duration
 }
 
-// NewJWTService constructs a JWTService with the provided secret and default expiration.
-func NewJWTService(secret string, expiration time.Duration) (*JWTService, error) {
-    if secret == "" {
-        logger.Error("jwt service requires non-empty secret")
+// NewJWTService constructs a JWTService with the provided secrets and default expiration.
+// The first secret signs new tokens; all of them verify tokens.
+func NewJWTService(secrets []string, expiration time.Duration) (*JWTService, error) {
+    if len(secrets) == 0 || slices.Contains(secrets, "") {
+        logger.Error("jwt service requires non-empty secrets")
         return nil, errors.New("invalid jwt secret: empty")
     }
-    return &JWTService{secret: secret, expiration: expiration}, nil
+    return &JWTService{secrets: secrets, expiration: expiration}, nil
 }
 
-// CreateToken signs the provided payload using the service secret.
+// CreateToken signs the provided payload using the current service secret.
 func (s *JWTService) CreateToken(payload *Payload) (string, error) {
-    return CreateToken(payload, s.secret)
+    return CreateToken(payload, s.secrets[0])
 }
 
-// VerifyToken validates a token string using the service secret.
+// VerifyToken validates a token string using any of the service secrets.
 func (s *JWTService) VerifyToken(tokenStr string) (*Payload, error) {
-    return VerifyToken(tokenStr, s.secret)
+    return VerifyToken(tokenStr, s.secrets...)
 }
 
 // Expiration returns the configured default expiration.
@@ -0,0 +1,61 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/httpclient"
+)
+
+// maxKMSResponseBytes bounds the responses of the KMS read
+const maxKMSResponseBytes = 64 * 1024
+
+// UnwrapDataKey decrypts a data key wrapped by the transit key cfg.KMSKeyName of a Vault or
+// OpenBao server, such as "vault:v1:...", as created with its datakey endpoint
+func UnwrapDataKey(ctx context.Context, cfg config.EncryptionConfig, wrapped string) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{"ciphertext": wrapped})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := strings.TrimRight(cfg.KMSURL, "/") + "/v1/transit/decrypt/" + url.PathEscape(cfg.KMSKeyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", cfg.KMSToken)
+
+	client := httpclient.New(httpclient.Options{Name: "kms", Timeout: cfg.KMSTimeout})
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+		Errors []string `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxKMSResponseBytes)).Decode(&result); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("failed to unwrap data key: invalid KMS response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to unwrap data key: KMS responded %d %s", resp.StatusCode, strings.Join(result.Errors, "; "))
+	}
+
+	key, err := base64.StdEncoding.DecodeString(result.Data.Plaintext)
+	if err != nil || len(key) != FieldKeySize {
+		return nil, fmt.Errorf("failed to unwrap data key: KMS returned no %d byte key", FieldKeySize)
+	}
+	return key, nil
+}
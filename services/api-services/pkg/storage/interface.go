@@ -6,6 +6,13 @@ import (
 	"time"
 )
 
+// Object describes a stored asset
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
 // Driver defines the interface for interacting with any storage backend.
 type Driver interface {
 	Upload(ctx context.Context, key string, data io.Reader, mimeType string) (string, error)
@@ -14,4 +21,13 @@ type Driver interface {
 	Exists(ctx context.Context, key string) (bool, error)
 	GetName() string
 	GenerateSignedURL(ctx context.Context, key string, operation string, expires time.Duration) (string, error)
+	// Walk calls fn for every stored asset, stopping at the first error fn returns
+	Walk(ctx context.Context, fn func(Object) error) error
+	// KeyFromURL returns the key of the asset a URL issued by the driver points to, and
+	// false for URLs the driver did not issue
+	KeyFromURL(rawURL string) (string, bool)
+	// HealthCheck reports whether assets can currently be stored and read
+	HealthCheck(ctx context.Context) error
+	// Close releases the driver; later calls fail with ErrClosed
+	Close() error
 }
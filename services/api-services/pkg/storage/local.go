@@ -2,30 +2,51 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode"
 
 	"github.com/samaasi/uptime-application/services/api-services/internal/utils"
+	"github.com/samaasi/uptime-application/services/api-services/pkg/urlsigner"
 )
 
 const LocalStorageName = "local"
 
+// ErrNotFound is returned when no asset exists at a key
+var ErrNotFound = errors.New("asset not found")
+
+// ErrClosed is returned by drivers used after Close
+var ErrClosed = errors.New("storage driver closed")
+
 // LocalStorageDriver implements StorageDriver for local disk storage.
 type LocalStorageDriver struct {
-	basePath string // Base path for storing files (e.g., "/var/www/assets")
-	baseURL  string // Base URL for accessing files (e.g., "http://localhost:5005/assets")
+	basePath string            // Base path for storing files (e.g., "/var/www/assets")
+	baseURL  string            // Base URL for accessing files (e.g., "http://localhost:5005/assets")
+	signer   *urlsigner.Signer // Signs download URLs; nil issues unsigned URLs
+	closed   atomic.Bool
+}
+
+// LocalOption configures a LocalStorageDriver
+type LocalOption func(*LocalStorageDriver)
+
+// WithSigner makes GenerateSignedURL issue expiring URLs signed by signer. The routes
+// serving the files must validate them, e.g. with the URL signature middleware.
+func WithSigner(signer *urlsigner.Signer) LocalOption {
+	return func(l *LocalStorageDriver) { l.signer = signer }
 }
 
 // NewLocalStorageDriver creates a new LocalStorageDriver instance.
-func NewLocalStorageDriver(basePath, baseURL string) (*LocalStorageDriver, error) {
+func NewLocalStorageDriver(basePath, baseURL string, opts ...LocalOption) (*LocalStorageDriver, error) {
 	if basePath == "" {
 		return nil, fmt.Errorf("local storage base path cannot be empty")
 	}
@@ -48,14 +69,21 @@ func NewLocalStorageDriver(basePath, baseURL string) (*LocalStorageDriver, error
 		return nil, fmt.Errorf("failed to create local storage base path '%s': %w", basePath, err)
 	}
 
-	return &LocalStorageDriver{
+	driver := &LocalStorageDriver{
 		basePath: basePath,
 		baseURL:  baseURL,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(driver)
+	}
+	return driver, nil
 }
 
 // Upload saves the given data to the specified key on the local disk.
 func (l *LocalStorageDriver) Upload(ctx context.Context, key string, data io.Reader, mimeType string) (string, error) {
+	if l.closed.Load() {
+		return "", ErrClosed
+	}
 	if key == "" {
 		return "", fmt.Errorf("key cannot be empty")
 	}
@@ -94,6 +122,9 @@ func (l *LocalStorageDriver) Upload(ctx context.Context, key string, data io.Rea
 
 // Download retrieves the data for the given key from the local disk.
 func (l *LocalStorageDriver) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	if l.closed.Load() {
+		return nil, ErrClosed
+	}
 	if key == "" {
 		return nil, fmt.Errorf("key cannot be empty")
 	}
@@ -109,10 +140,10 @@ func (l *LocalStorageDriver) Download(ctx context.Context, key string) (io.ReadC
 		return nil, fmt.Errorf("path verification failed: %w", err)
 	}
 
-	file, err := openFileSecurely(fullPath)
+	file, err := openFileForReading(fullPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("asset not found")
+			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -121,6 +152,9 @@ func (l *LocalStorageDriver) Download(ctx context.Context, key string) (io.ReadC
 
 // Delete removes the asset at the specified key from the local disk.
 func (l *LocalStorageDriver) Delete(ctx context.Context, key string) error {
+	if l.closed.Load() {
+		return ErrClosed
+	}
 	if key == "" {
 		return fmt.Errorf("key cannot be empty")
 	}
@@ -170,12 +204,112 @@ func (l *LocalStorageDriver) Exists(ctx context.Context, key string) (bool, erro
 	return false, fmt.Errorf("failed to check file existence: %w", err)
 }
 
+// HealthCheck verifies that the base path is a directory and writable by creating and
+// removing a probe file.
+func (l *LocalStorageDriver) HealthCheck(ctx context.Context) error {
+	if l.closed.Load() {
+		return ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(l.basePath)
+	if err != nil {
+		return fmt.Errorf("local storage base path unavailable: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("local storage base path '%s' is not a directory", l.basePath)
+	}
+
+	probe, err := os.CreateTemp(l.basePath, ".health-*")
+	if err != nil {
+		return fmt.Errorf("local storage base path not writable: %w", err)
+	}
+	_, writeErr := probe.Write([]byte("ok"))
+	closeErr := probe.Close()
+	removeErr := os.Remove(probe.Name())
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		return fmt.Errorf("failed to write local storage probe: %w", err)
+	}
+	if removeErr != nil {
+		return fmt.Errorf("failed to remove local storage probe: %w", removeErr)
+	}
+	return nil
+}
+
+// Close marks the driver closed. Local storage holds no open resources between calls,
+// so this only makes later calls fail instead of writing during shutdown.
+func (l *LocalStorageDriver) Close() error {
+	l.closed.Store(true)
+	return nil
+}
+
 // GetName returns the name of the local storage driver.
 func (l *LocalStorageDriver) GetName() string {
 	return LocalStorageName
 }
 
-// GenerateSignedURL returns a direct URL since local storage doesn't support signatures.
+// Walk calls fn for every file under the base path. Keys use forward slashes.
+func (l *LocalStorageDriver) Walk(ctx context.Context, fn func(Object) error) error {
+	return filepath.WalkDir(l.basePath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(l.basePath, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve key of %s: %w", path, err)
+		}
+		return fn(Object{Key: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime()})
+	})
+}
+
+// KeyFromURL returns the key of the asset a URL returned by Upload or GenerateSignedURL
+// points to. Only the path is compared, so URLs stored before the base URL's host changed
+// still resolve.
+func (l *LocalStorageDriver) KeyFromURL(rawURL string) (string, bool) {
+	_, path := splitOrigin(rawURL)
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	escaped, ok := strings.CutPrefix(path, l.URLPath())
+	if !ok || escaped == "" {
+		return "", false
+	}
+	key, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", false
+	}
+	key, err = sanitizeKey(key)
+	if err != nil {
+		return "", false
+	}
+	return filepath.ToSlash(key), true
+}
+
+// URLPath returns the path of the base URL, under which the files must be served
+func (l *LocalStorageDriver) URLPath() string {
+	_, path := splitOrigin(l.baseURL)
+	return path
+}
+
+// GenerateSignedURL returns a URL to download the asset at key that expires after expires.
+// Without a signer the URL is a direct, unsigned one.
 func (l *LocalStorageDriver) GenerateSignedURL(ctx context.Context, key string, operation string, expires time.Duration) (string, error) {
 	if key == "" {
 		return "", fmt.Errorf("key cannot be empty")
@@ -188,12 +322,37 @@ func (l *LocalStorageDriver) GenerateSignedURL(ctx context.Context, key string,
 
 	switch operation {
 	case "GET":
-		return l.baseURL + url.PathEscape(key), nil
+		target := l.baseURL + url.PathEscape(key)
+		if l.signer == nil {
+			return target, nil
+		}
+		if expires <= 0 {
+			return "", fmt.Errorf("signed URL lifetime must be positive")
+		}
+
+		// The signer only accepts relative URLs; sign the path and keep the origin as is
+		origin, path := splitOrigin(target)
+		signed, err := l.signer.Generate(path, expires)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign URL: %w", err)
+		}
+		return origin + signed, nil
 	default:
 		return "", fmt.Errorf("operation '%s' not supported for local storage", operation)
 	}
 }
 
+// splitOrigin splits an absolute URL into its scheme and host and the rest; relative
+// URLs have no origin
+func splitOrigin(rawURL string) (string, string) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", rawURL
+	}
+	origin := u.Scheme + "://" + u.Host
+	return origin, strings.TrimPrefix(rawURL, origin)
+}
+
 // sanitizeKey prevents directory traversal attacks and cleans paths
 func sanitizeKey(key string) (string, error) {
 	for _, r := range key {
@@ -265,6 +424,21 @@ func verifyPathWithinBase(base, target string) error {
 	return nil
 }
 
+// openFileForReading opens an existing regular file read-only, refusing symlinks
+func openFileForReading(path string) (*os.File, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("symlinks not allowed")
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("not a regular file")
+	}
+	return os.Open(path)
+}
+
 // openFileSecurely opens a file with secure flags and permissions
 func openFileSecurely(path string) (*os.File, error) {
 	flags := os.O_RDWR | os.O_CREATE
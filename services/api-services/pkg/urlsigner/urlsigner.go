@@ -10,9 +10,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
-)
 
-var urlSigner *Signer
+	"github.com/samaasi/uptime-application/services/api-services/internal/config"
+)
 
 // Signer is used to sign and validate URLs.
 type Signer struct {
@@ -20,6 +20,10 @@ type Signer struct {
 	ExpiresParam   string
 	SignatureParam string
 	ClockSkewGrace time.Duration
+	// MaxTTL is the longest lifetime Generate signs a URL for; zero means no limit
+	MaxTTL time.Duration
+	// PreviousSecrets still validate URLs signed before a rotation
+	PreviousSecrets [][]byte
 }
 
 // New creates a new Signer with a secret key and optional configurations.
@@ -36,9 +40,34 @@ func New(secret string, options ...Option) *Signer {
 	return s
 }
 
+// NewFromConfig creates a Signer from configuration, using fallbackSecrets when no
+// dedicated secret is configured. The first secret signs, the others only validate.
+func NewFromConfig(cfg config.URLSignerConfig, fallbackSecrets []string) *Signer {
+	secrets := append([]string{cfg.Secret}, cfg.PreviousSecrets...)
+	if cfg.Secret == "" {
+		secrets = fallbackSecrets
+	}
+	return New(secrets[0],
+		WithPreviousSecrets(secrets[1:]...),
+		WithExpiresParam(cfg.ExpiresParam),
+		WithSignatureParam(cfg.SignatureParam),
+		WithClockSkewGrace(cfg.ClockSkewGrace),
+		WithMaxTTL(cfg.MaxTTL),
+	)
+}
+
 // Option is a functional option for configuring Signer.
 type Option func(*Signer)
 
+// WithPreviousSecrets sets secrets that still validate URLs but no longer sign them.
+func WithPreviousSecrets(secrets ...string) Option {
+	return func(s *Signer) {
+		for _, secret := range secrets {
+			s.PreviousSecrets = append(s.PreviousSecrets, []byte(secret))
+		}
+	}
+}
+
 // WithExpiresParam sets a custom name for the expiration parameter.
 func WithExpiresParam(name string) Option {
 	return func(s *Signer) { s.ExpiresParam = name }
@@ -54,9 +83,19 @@ func WithClockSkewGrace(d time.Duration) Option {
 	return func(s *Signer) { s.ClockSkewGrace = d }
 }
 
+// WithMaxTTL sets the longest lifetime a URL may be signed for.
+func WithMaxTTL(d time.Duration) Option {
+	return func(s *Signer) { s.MaxTTL = d }
+}
+
 // Generate creates a signed URL with a given lifetime.
-// The originalURL should be a relative path (e.g., "/path?param=val"); absolute URLs are rejected.
+// The originalURL should be a relative path (e.g., "/path?param=val"); absolute URLs are rejected,
+// and so are lifetimes longer than MaxTTL.
 func (s *Signer) Generate(originalURL string, lifetime time.Duration) (string, error) {
+	if s.MaxTTL > 0 && lifetime > s.MaxTTL {
+		return "", fmt.Errorf("lifetime %s exceeds the maximum of %s", lifetime, s.MaxTTL)
+	}
+
 	u, err := url.Parse(originalURL)
 	if err != nil {
 		return "", err
@@ -71,10 +110,7 @@ func (s *Signer) Generate(originalURL string, lifetime time.Duration) (string, e
 	query.Set(s.ExpiresParam, strconv.FormatInt(expires, 10))
 
 	u.RawQuery = sortQuery(query)
-
-	mac := hmac.New(sha256.New, s.Secret)
-	mac.Write([]byte(u.String()))
-	signature := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	signature := sign(s.Secret, u.String())
 
 	query.Set(s.SignatureParam, signature)
 	u.RawQuery = query.Encode()
@@ -109,12 +145,21 @@ func (s *Signer) Validate(signedURL string) (bool, error) {
 
 	query.Del(s.SignatureParam)
 	u.RawQuery = sortQuery(query)
+	base := u.String()
 
-	mac := hmac.New(sha256.New, s.Secret)
-	mac.Write([]byte(u.String()))
-	expectedSignature := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	for _, secret := range append([][]byte{s.Secret}, s.PreviousSecrets...) {
+		if hmac.Equal([]byte(signature), []byte(sign(secret, base))) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
-	return hmac.Equal([]byte(signature), []byte(expectedSignature)), nil
+// sign returns the signature of base with secret
+func sign(secret []byte, base string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(base))
+	return base64.URLEncoding.EncodeToString(mac.Sum(nil))
 }
 
 // sortQuery sorts query parameters alphabetically by key, and values per key.
@@ -0,0 +1,136 @@
+package webhookverify
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// slackSignatureVersion prefixes the signed content and the signature of Slack requests
+const slackSignatureVersion = "v0"
+
+// stripeSignatureScheme names the signatures of the Stripe-Signature header checked here
+const stripeSignatureScheme = "v1"
+
+// VerifySlack checks the X-Slack-Signature of a Slack request sent at the
+// X-Slack-Request-Timestamp: the hex HMAC-SHA256, keyed with the signing secret of the app,
+// of the version, timestamp and body
+func VerifySlack(signingSecret, timestamp, signature string, body []byte, now time.Time) error {
+	if err := CheckTimestamp(timestamp, now, DefaultTolerance); err != nil {
+		return err
+	}
+	sum, ok := strings.CutPrefix(signature, slackSignatureVersion+"=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return VerifyHMACSHA256Hex([]byte(signingSecret), sum, []byte(slackSignatureVersion+":"+timestamp+":"), body)
+}
+
+// VerifyStripe checks the Stripe-Signature header of a Stripe event, "t=<timestamp>,v1=<sig>"
+// with one v1 signature per active endpoint secret: the hex HMAC-SHA256, keyed with the
+// endpoint secret, of the timestamp and body. tolerance bounds the age of the timestamp.
+func VerifyStripe(endpointSecret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp string
+	var signatures []string
+	for _, item := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case stripeSignatureScheme:
+			signatures = append(signatures, value)
+		}
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w: no %s signature", ErrInvalidSignature, stripeSignatureScheme)
+	}
+	if err := CheckTimestamp(timestamp, now, tolerance); err != nil {
+		return err
+	}
+
+	for _, signature := range signatures {
+		if VerifyHMACSHA256Hex([]byte(endpointSecret), signature, []byte(timestamp+"."), body) == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// VerifyMailgun checks the signature of a Mailgun webhook: the hex HMAC-SHA256, keyed with
+// the webhook signing key, of its timestamp and token
+func VerifyMailgun(signingKey, timestamp, token, signature string, now time.Time) error {
+	if err := CheckTimestamp(timestamp, now, DefaultTolerance); err != nil {
+		return err
+	}
+	return VerifyHMACSHA256Hex([]byte(signingKey), signature, []byte(timestamp+token))
+}
+
+// VerifySendGrid checks the X-Twilio-Email-Event-Webhook-Signature of a SendGrid event
+// webhook sent at the -Timestamp header: the base64 ECDSA signature of the timestamp and body
+func VerifySendGrid(key *ecdsa.PublicKey, timestamp, signature string, body []byte, now time.Time) error {
+	if err := CheckTimestamp(timestamp, now, DefaultTolerance); err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	digest := sha256.New()
+	digest.Write([]byte(timestamp))
+	digest.Write(body)
+	if !ecdsa.VerifyASN1(key, digest.Sum(nil), sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyDiscord checks the X-Signature-Ed25519 of a Discord interaction sent at the
+// X-Signature-Timestamp: the hex Ed25519 signature, by the application, of the timestamp
+// and body. Discord does not bound the age of interactions, so the timestamp is not checked.
+func VerifyDiscord(key ed25519.PublicKey, timestamp, signature string, body []byte) error {
+	raw, err := hex.DecodeString(signature)
+	if err != nil || timestamp == "" {
+		return ErrInvalidSignature
+	}
+	if !ed25519.Verify(key, append([]byte(timestamp), body...), raw) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifySNS checks the base64 signature of an Amazon SNS delivery, through which SES
+// publishes its notifications, against the canonical string SNS signs for it. key is that
+// of the signing certificate the delivery points to, which the caller must have fetched
+// from an AWS host. Version 1 signatures use SHA1, version 2 SHA256.
+func VerifySNS(key *rsa.PublicKey, signatureVersion, signature, stringToSign string) error {
+	var hash crypto.Hash
+	var digest []byte
+	switch signatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(stringToSign))
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256([]byte(stringToSign))
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return fmt.Errorf("%w: unsupported signature version %q", ErrInvalidSignature, signatureVersion)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if err := rsa.VerifyPKCS1v15(key, hash, digest, sig); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
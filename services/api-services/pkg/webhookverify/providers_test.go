@@ -0,0 +1,233 @@
+package webhookverify
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testNow is the time webhooks are verified at
+var testNow = time.Unix(1_700_000_000, 0)
+
+// fresh and stale are a current and an expired signed timestamp
+var (
+	fresh = strconv.FormatInt(testNow.Unix(), 10)
+	stale = strconv.FormatInt(testNow.Add(-DefaultTolerance-time.Minute).Unix(), 10)
+)
+
+// checkVerified fails t unless err matches whether the webhook should verify
+func checkVerified(t *testing.T, err error, wantErr bool) {
+	t.Helper()
+	if (err != nil) != wantErr {
+		t.Fatalf("error = %v, wantErr %v", err, wantErr)
+	}
+	if err != nil && !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestVerifySlack(t *testing.T) {
+	secret, body := "signing-secret", []byte("command=/status&text=api")
+	sign := func(timestamp string) string {
+		return "v0=" + hex.EncodeToString(HMACSHA256([]byte(secret), []byte("v0:"+timestamp+":"), body))
+	}
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+	}{
+		{"valid", fresh, sign(fresh), false},
+		{"bad signature", fresh, "v0=" + hex.EncodeToString(HMACSHA256([]byte("other"), []byte("v0:"+fresh+":"), body)), true},
+		{"signature of another timestamp", fresh, sign(stale), true},
+		{"stale timestamp", stale, sign(stale), true},
+		{"missing version", fresh, sign(fresh)[len("v0="):], true},
+		{"malformed signature", fresh, "v0=zz", true},
+		{"malformed timestamp", "now", sign("now"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkVerified(t, VerifySlack(secret, tt.timestamp, tt.signature, body, testNow), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyStripe(t *testing.T) {
+	secret, body := "whsec_test", []byte(`{"type":"invoice.paid"}`)
+	sign := func(secret, timestamp string) string {
+		return hex.EncodeToString(HMACSHA256([]byte(secret), []byte(timestamp+"."), body))
+	}
+
+	tests := []struct {
+		name    string
+		header  string
+		wantErr bool
+	}{
+		{"valid", "t=" + fresh + ",v1=" + sign(secret, fresh), false},
+		{"valid among rolled secrets", "t=" + fresh + ",v1=" + sign("whsec_old", fresh) + ",v1=" + sign(secret, fresh), false},
+		{"ignores other schemes", "t=" + fresh + ",v0=" + sign("x", fresh) + ",v1=" + sign(secret, fresh), false},
+		{"bad signature", "t=" + fresh + ",v1=" + sign("whsec_other", fresh), true},
+		{"stale timestamp", "t=" + stale + ",v1=" + sign(secret, stale), true},
+		{"no v1 signature", "t=" + fresh + ",v0=" + sign(secret, fresh), true},
+		{"no timestamp", "v1=" + sign(secret, fresh), true},
+		{"malformed header", "garbage", true},
+		{"empty header", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkVerified(t, VerifyStripe(secret, tt.header, body, testNow, DefaultTolerance), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyMailgun(t *testing.T) {
+	key, token := "mailgun-key", "a8ce0edb2dd8301dee6c2405235584e45aa91d1e9f979f3de0"
+	sign := func(key, timestamp string) string {
+		return hex.EncodeToString(HMACSHA256([]byte(key), []byte(timestamp+token)))
+	}
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+	}{
+		{"valid", fresh, sign(key, fresh), false},
+		{"bad signature", fresh, sign("other", fresh), true},
+		{"stale timestamp", stale, sign(key, stale), true},
+		{"malformed signature", fresh, "not-hex", true},
+		{"malformed timestamp", "", sign(key, ""), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkVerified(t, VerifyMailgun(key, tt.timestamp, token, tt.signature, testNow), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifySendGrid(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`[{"event":"bounce","email":"a@example.com"}]`)
+	sign := func(key *ecdsa.PrivateKey, timestamp string) string {
+		digest := sha256.Sum256(append([]byte(timestamp), body...))
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.StdEncoding.EncodeToString(sig)
+	}
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+	}{
+		{"valid", fresh, sign(key, fresh), false},
+		{"bad signature", fresh, sign(other, fresh), true},
+		{"stale timestamp", stale, sign(key, stale), true},
+		{"malformed signature", fresh, "%%%", true},
+		{"malformed timestamp", "soon", sign(key, "soon"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkVerified(t, VerifySendGrid(&key.PublicKey, tt.timestamp, tt.signature, body, testNow), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyDiscord(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"type":1}`)
+	sign := func(key ed25519.PrivateKey, timestamp string) string {
+		return hex.EncodeToString(ed25519.Sign(key, append([]byte(timestamp), body...)))
+	}
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+	}{
+		{"valid", fresh, sign(private, fresh), false},
+		// Discord does not bound the age of interactions
+		{"old timestamp", stale, sign(private, stale), false},
+		{"bad signature", fresh, sign(other, fresh), true},
+		{"signature of another timestamp", fresh, sign(private, stale), true},
+		{"malformed signature", fresh, "zz", true},
+		{"missing timestamp", "", sign(private, ""), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkVerified(t, VerifyDiscord(public, tt.timestamp, tt.signature, body), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifySNS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical := "Message\nhello\nMessageId\n1\nTimestamp\n2026-10-16T00:00:00Z\nTopicArn\narn:aws:sns:us-east-1:1:ses\nType\nNotification\n"
+	sign := func(hash crypto.Hash, stringToSign string) string {
+		var digest []byte
+		if hash == crypto.SHA1 {
+			sum := sha1.Sum([]byte(stringToSign))
+			digest = sum[:]
+		} else {
+			sum := sha256.Sum256([]byte(stringToSign))
+			digest = sum[:]
+		}
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, hash, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.StdEncoding.EncodeToString(sig)
+	}
+
+	tests := []struct {
+		name      string
+		version   string
+		signature string
+		wantErr   bool
+	}{
+		{"valid version 1", "1", sign(crypto.SHA1, canonical), false},
+		{"valid version 2", "2", sign(crypto.SHA256, canonical), false},
+		{"version mismatch", "2", sign(crypto.SHA1, canonical), true},
+		{"bad signature", "2", sign(crypto.SHA256, canonical+"tampered"), true},
+		{"unsupported version", "3", sign(crypto.SHA256, canonical), true},
+		{"malformed signature", "2", "%%%", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkVerified(t, VerifySNS(&key.PublicKey, tt.version, tt.signature, canonical), tt.wantErr)
+		})
+	}
+}
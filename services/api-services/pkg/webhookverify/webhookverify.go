@@ -0,0 +1,60 @@
+// Package webhookverify checks the signatures providers put on the webhooks they deliver,
+// such as Slack slash commands, Stripe events or SES notifications relayed by SNS, so that
+// every inbound webhook is verified the same way: against the raw body, with constant-time
+// comparisons and a bounded timestamp against replays.
+package webhookverify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultTolerance bounds how far a signed timestamp may be from now, limiting replays
+const DefaultTolerance = 5 * time.Minute
+
+// ErrInvalidSignature is returned for webhooks that fail verification
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Equal reports whether a and b are equal in time independent of their content, for
+// comparing secrets and signatures
+func Equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// HMACSHA256 returns the HMAC-SHA256 of the concatenated parts keyed with secret
+func HMACSHA256(secret []byte, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	for _, part := range parts {
+		mac.Write(part)
+	}
+	return mac.Sum(nil)
+}
+
+// VerifyHMACSHA256Hex checks that signature is the hex HMAC-SHA256 of the concatenated parts
+// keyed with secret
+func VerifyHMACSHA256Hex(secret []byte, signature string, parts ...[]byte) error {
+	raw, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(raw, HMACSHA256(secret, parts...)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// CheckTimestamp rejects signed Unix timestamps, in seconds, older or further in the future
+// than tolerance from now
+func CheckTimestamp(timestamp string, now time.Time, tolerance time.Duration) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside the accepted window", ErrInvalidSignature)
+	}
+	return nil
+}
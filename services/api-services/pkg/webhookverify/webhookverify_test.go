@@ -0,0 +1,67 @@
+package webhookverify
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyHMACSHA256Hex(t *testing.T) {
+	secret := []byte("secret")
+	valid := hex.EncodeToString(HMACSHA256(secret, []byte("a"), []byte("b")))
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{"valid", valid, false},
+		{"same concatenation", hex.EncodeToString(HMACSHA256(secret, []byte("ab"))), false},
+		{"bad signature", hex.EncodeToString(HMACSHA256([]byte("other"), []byte("ab"))), true},
+		{"malformed", "not hex", true},
+		{"empty", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyHMACSHA256Hex(secret, tt.signature, []byte("a"), []byte("b"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyHMACSHA256Hex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifyHMACSHA256Hex() error = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	unix := func(d time.Duration) string { return strconv.FormatInt(now.Add(d).Unix(), 10) }
+
+	tests := []struct {
+		name      string
+		timestamp string
+		wantErr   bool
+	}{
+		{"now", unix(0), false},
+		{"within tolerance", unix(-DefaultTolerance + time.Second), false},
+		{"slightly ahead", unix(DefaultTolerance - time.Second), false},
+		{"stale", unix(-DefaultTolerance - time.Second), true},
+		{"too far ahead", unix(DefaultTolerance + time.Second), true},
+		{"malformed", "yesterday", true},
+		{"empty", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTimestamp(tt.timestamp, now, DefaultTolerance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckTimestamp(%q) error = %v, wantErr %v", tt.timestamp, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("CheckTimestamp(%q) error = %v, want %v", tt.timestamp, err, ErrInvalidSignature)
+			}
+		})
+	}
+}